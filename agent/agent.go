@@ -76,6 +76,26 @@ func (s APIHostPortsSetter) SetAPIHostPorts(servers [][]network.HostPort) error
 	})
 }
 
+// SetCACert is the APIAddressSetter interface.
+func (s APIHostPortsSetter) SetCACert(caCert string) error {
+	return s.ChangeConfig(func(c ConfigSetter) error {
+		c.SetCACert(caCert)
+		return nil
+	})
+}
+
+// StateServingInfoGetter trivially wraps an Agent to implement
+// worker/certupdater/StateServingInfoGetter, always reporting the
+// agent's current state serving info.
+type StateServingInfoGetter struct {
+	Agent
+}
+
+// StateServingInfo is the StateServingInfoGetter interface.
+func (g StateServingInfoGetter) StateServingInfo() (params.StateServingInfo, bool) {
+	return g.CurrentConfig().StateServingInfo()
+}
+
 // StateServingInfoSetter trivially wraps an Agent to implement
 // worker/certupdater/SetStateServingInfo.
 type StateServingInfoSetter struct {
@@ -163,6 +183,9 @@ const (
 	MongoOplogSize         = "MONGO_OPLOG_SIZE"
 	NumaCtlPreference      = "NUMA_CTL_PREFERENCE"
 	AllowsSecureConnection = "SECURE_CONTROLLER_CONNECTION"
+	APIMaxConnections      = "API_MAX_CONNECTIONS"
+	APIRequestRate         = "API_REQUEST_RATE"
+	APIRequestBurst        = "API_REQUEST_BURST"
 )
 
 // The Config interface is the sole way that the agent gets access to the
@@ -4,7 +4,9 @@
 package action_test
 
 import (
+	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/juju/names"
 	jc "github.com/juju/testing/checkers"
@@ -13,6 +15,7 @@ import (
 
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type actionSuite struct {
@@ -132,3 +135,53 @@ func patchServiceCharmActions(c *gc.C, apiCli *action.Client, patchResults []par
 		},
 	)
 }
+
+func (s *actionSuite) TestWatchActionProgress(c *gc.C) {
+	svc := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	unit, err := svc.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("first")
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := s.client.WatchActionProgress(a.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		w.Kill()
+		c.Check(w.Wait(), jc.ErrorIsNil)
+	}()
+
+	nextMessages := func() []string {
+		s.BackingState.StartSync()
+		select {
+		case changes, ok := <-w.Changes():
+			c.Assert(ok, jc.IsTrue)
+			var messages []string
+			for _, change := range changes {
+				var message params.ActionMessage
+				err := json.Unmarshal([]byte(change), &message)
+				c.Assert(err, jc.ErrorIsNil)
+				messages = append(messages, message.Message)
+			}
+			return messages
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for action progress")
+		}
+		return nil
+	}
+
+	// The initial event holds the messages logged so far.
+	c.Assert(nextMessages(), jc.DeepEquals, []string{"first"})
+
+	err = a.Log("second")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(nextMessages(), jc.DeepEquals, []string{"second"})
+}
+
+func (s *actionSuite) TestWatchActionProgressNotFound(c *gc.C) {
+	_, err := s.client.WatchActionProgress(names.NewActionTag("00000000-0000-0000-0000-000000000000"))
+	c.Assert(err, gc.ErrorMatches, `action "00000000-0000-0000-0000-000000000000" not found`)
+}
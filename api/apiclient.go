@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
 	"net/url"
 	"strings"
@@ -30,7 +31,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/rpc"
-	"github.com/juju/juju/rpc/jsoncodec"
+	"github.com/juju/juju/rpc/wscodec"
 )
 
 var logger = loggo.GetLogger("juju.api")
@@ -45,6 +46,21 @@ var (
 	// PingTimeout defines how long a health check can take before we
 	// consider it to have failed.
 	PingTimeout = 30 * time.Second
+
+	// RetryAfterDelay defines how long to wait before first retrying
+	// a request that the API server asked us to retry later. The
+	// delay doubles, up to RetryAfterMaxDelay, for each further
+	// attempt, and is jittered so that many clients rejected at the
+	// same time do not all retry at the same time.
+	RetryAfterDelay = 250 * time.Millisecond
+
+	// RetryAfterMaxDelay defines the longest delay between attempts
+	// to make a request that the API server asked us to retry later.
+	RetryAfterMaxDelay = 30 * time.Second
+
+	// RetryAfterAttempts defines how many times a request will be made
+	// before giving up if the API server keeps asking us to retry later.
+	RetryAfterAttempts = 10
 )
 
 // state is the internal implementation of the Connection interface.
@@ -52,6 +68,12 @@ type state struct {
 	client *rpc.Conn
 	conn   *websocket.Conn
 
+	// codec holds the codec used by client. Its format is set
+	// at login to use the message features in messageFeatures
+	// that the server supports.
+	codec           *wscodec.Codec
+	messageFeatures []string
+
 	// addr is the address used to connect to the API server.
 	addr string
 
@@ -148,7 +170,8 @@ func open(
 		return nil, errors.Trace(err)
 	}
 
-	client := rpc.NewConn(jsoncodec.NewWebsocket(conn), nil)
+	codec := wscodec.New(conn)
+	client := rpc.NewConn(codec, nil)
 	client.Start()
 
 	bakeryClient := opts.BakeryClient
@@ -170,9 +193,11 @@ func open(
 	}
 
 	st := &state{
-		client: client,
-		conn:   conn,
-		addr:   apiHost,
+		client:          client,
+		conn:            conn,
+		codec:           codec,
+		messageFeatures: opts.messageFeatures(),
+		addr:            apiHost,
 		cookieURL: &url.URL{
 			Scheme: "https",
 			Host:   conn.Config().Location.Host,
@@ -549,13 +574,41 @@ func (s *state) Ping() error {
 // object id, and the specific RPC method. It marshalls the Arguments, and will
 // unmarshall the result into the response object that is supplied.
 func (s *state) APICall(facade string, version int, id, method string, args, response interface{}) error {
-	err := s.client.Call(rpc.Request{
+	req := rpc.Request{
 		Type:    facade,
 		Version: version,
 		Id:      id,
 		Action:  method,
-	}, args, response)
-	return errors.Trace(err)
+	}
+	delay := RetryAfterDelay
+	for attempt := 1; ; attempt++ {
+		err := s.client.Call(req, args, response)
+		if !params.IsCodeRetryAfter(err) || attempt >= RetryAfterAttempts {
+			return errors.Trace(err)
+		}
+		wait := jitter(delay)
+		logger.Debugf("API server busy, retrying %s.%s in %v", facade, method, wait)
+		select {
+		case <-time.After(wait):
+		case <-s.closed:
+			return errors.Trace(err)
+		case <-s.client.Dead():
+			// The server refused the connection, and closed it.
+			return errors.Trace(err)
+		}
+		if delay *= 2; delay > RetryAfterMaxDelay {
+			delay = RetryAfterMaxDelay
+		}
+	}
+}
+
+// jitter returns a random duration between half and one and a half
+// times the given duration.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return d
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d)))
 }
 
 func (s *state) Close() error {
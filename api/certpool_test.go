@@ -45,6 +45,12 @@ func (*certPoolSuite) TestCreateCertPoolTestCert(c *gc.C) {
 	c.Assert(pool.Subjects(), gc.HasLen, 1)
 }
 
+func (*certPoolSuite) TestCreateCertPoolCertBundle(c *gc.C) {
+	pool, err := api.CreateCertPool(testing.CACert + testing.OtherCACert)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pool.Subjects(), gc.HasLen, 2)
+}
+
 func (s *certPoolSuite) TestCreateCertPoolNoDir(c *gc.C) {
 	certDir := filepath.Join(c.MkDir(), "missing")
 	s.PatchValue(api.CertDir, certDir)
@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
+	"github.com/juju/httprequest"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
 	"github.com/juju/version"
@@ -33,7 +34,29 @@ import (
 type Client struct {
 	base.ClientFacade
 	facade base.FacadeCaller
-	st     *state
+
+	// conn is the connection used for watchers, HTTP requests and
+	// streams, and closed by Close. It is usually the underlying API
+	// state, but differs when the Client was obtained from a
+	// connection that wraps it, so that those requests follow the
+	// wrapping connection when it fails over.
+	conn Connection
+}
+
+// rootHTTPClienter is implemented by connections that can make HTTP
+// requests to the API server root path rather than to the model.
+type rootHTTPClienter interface {
+	RootHTTPClient() (*httprequest.Client, error)
+}
+
+// rootHTTPClient returns an HTTP client pointing to the API server root
+// path of the controller the Client's connection currently uses.
+func (c *Client) rootHTTPClient() (*httprequest.Client, error) {
+	conn, ok := c.conn.(rootHTTPClienter)
+	if !ok {
+		return nil, errors.NotSupportedf("HTTP requests to the API server root")
+	}
+	return conn.RootHTTPClient()
 }
 
 // Status returns the status of the juju model.
@@ -178,7 +201,7 @@ func (c *Client) ModelInfo() (params.ModelInfo, error) {
 
 // ModelUUID returns the model UUID from the client connection.
 func (c *Client) ModelUUID() string {
-	tag, err := c.st.ModelTag()
+	tag, err := c.conn.ModelTag()
 	if err != nil {
 		logger.Warningf("model tag not an model: %v", err)
 		return ""
@@ -212,11 +235,18 @@ type WatchAll struct {
 // WatchAll returns an AllWatcher, from which you can request the Next
 // collection of Deltas.
 func (c *Client) WatchAll() (*AllWatcher, error) {
+	return c.WatchAllFiltered(params.AllWatcherFilter{})
+}
+
+// WatchAllFiltered is like WatchAll, but the returned AllWatcher only
+// returns the deltas that match the given filter. API servers that
+// cannot filter deltas ignore the filter.
+func (c *Client) WatchAllFiltered(filter params.AllWatcherFilter) (*AllWatcher, error) {
 	info := new(WatchAll)
-	if err := c.facade.FacadeCall("WatchAll", nil, info); err != nil {
+	if err := c.facade.FacadeCall("WatchAll", filter, info); err != nil {
 		return nil, err
 	}
-	return NewAllWatcher(c.st, &info.AllWatcherId), nil
+	return NewAllWatcher(c.conn, &info.AllWatcherId), nil
 }
 
 // Close closes the Client's underlying State connection
@@ -224,7 +254,7 @@ func (c *Client) WatchAll() (*AllWatcher, error) {
 // connection, but it is conventional to use a Client object without any access
 // to its underlying state connection.
 func (c *Client) Close() error {
-	return c.st.Close()
+	return c.conn.Close()
 }
 
 // ModelGet returns all model settings.
@@ -253,12 +283,41 @@ func (c *Client) SetModelAgentVersion(version version.Number) error {
 	return c.facade.FacadeCall("SetModelAgentVersion", args, nil)
 }
 
+// SetMachineAgentVersions sets the target agent version of each of
+// the given machines, so that their agents are upgraded to it ahead
+// of the rest of the model.
+func (c *Client) SetMachineAgentVersions(version version.Number, machines ...string) error {
+	args := params.SetMachineAgentVersions{
+		Version:  version,
+		Machines: make([]params.Entity, len(machines)),
+	}
+	for i, id := range machines {
+		if !names.IsValidMachine(id) {
+			return errors.NotValidf("machine ID %q", id)
+		}
+		args.Machines[i].Tag = names.NewMachineTag(id).String()
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetMachineAgentVersions", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
+
 // AbortCurrentUpgrade aborts and archives the current upgrade
 // synchronisation record, if any.
 func (c *Client) AbortCurrentUpgrade() error {
 	return c.facade.FacadeCall("AbortCurrentUpgrade", nil, nil)
 }
 
+// RollbackUpgrade abandons an upgrade that has not completed, setting
+// the model agent version back to the version being upgraded from.
+func (c *Client) RollbackUpgrade() (params.RollbackUpgradeResult, error) {
+	var result params.RollbackUpgradeResult
+	err := c.facade.FacadeCall("RollbackUpgrade", nil, &result)
+	return result, errors.Trace(err)
+}
+
 // FindTools returns a List containing all tools matching the specified parameters.
 func (c *Client) FindTools(majorVersion, minorVersion int, series, arch string) (result params.FindToolsResult, err error) {
 	args := params.FindToolsParams{
@@ -430,7 +489,7 @@ func (c *Client) ResolveCharm(ref *charm.URL) (*charm.URL, error) {
 // the API.
 func (c *Client) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
 	// The returned httpClient sets the base url to /model/<uuid> if it can.
-	httpClient, err := c.st.HTTPClient()
+	httpClient, err := c.conn.HTTPClient()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -492,7 +551,7 @@ func (c *Client) httpPost(content io.ReadSeeker, endpoint, contentType string, r
 	req.Header.Set("Content-Type", contentType)
 
 	// The returned httpClient sets the base url to /model/<uuid> if it can.
-	httpClient, err := c.st.HTTPClient()
+	httpClient, err := c.conn.HTTPClient()
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -616,7 +675,22 @@ func (c *Client) WatchDebugLog(args DebugLogParams) (io.ReadCloser, error) {
 		attrs.Set("level", fmt.Sprint(args.Level))
 	}
 
-	connection, err := c.st.ConnectStream("/log", attrs)
+	connection, err := c.conn.ConnectStream("/log", attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return connection, nil
+}
+
+// OpenSSHTunnel returns a connection to the SSH server of the machine
+// or unit with the given tag, relayed by the API server so that no
+// network route to the machine is needed. The user must have write
+// access to the model, and the session is recorded in the audit log.
+func (c *Client) OpenSSHTunnel(target names.Tag) (io.ReadWriteCloser, error) {
+	attrs := url.Values{
+		"target": {target.String()},
+	}
+	connection, err := c.conn.ConnectStream("/ssh", attrs)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -512,6 +512,28 @@ func (s *clientSuite) TestAbortCurrentUpgrade(c *gc.C) {
 	c.Assert(err, gc.Equals, someErr) // Confirms that the correct facade was called
 }
 
+func (s *clientSuite) TestRollbackUpgrade(c *gc.C) {
+	client := s.APIState.Client()
+	cleanup := api.PatchClientFacadeCall(client,
+		func(request string, args interface{}, response interface{}) error {
+			c.Assert(request, gc.Equals, "RollbackUpgrade")
+			c.Assert(args, gc.IsNil)
+			result := response.(*params.RollbackUpgradeResult)
+			result.PreviousVersion = version.MustParse("1.2.3")
+			result.SnapshotID = "backup-id"
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := client.RollbackUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.RollbackUpgradeResult{
+		PreviousVersion: version.MustParse("1.2.3"),
+		SnapshotID:      "backup-id",
+	})
+}
+
 func (s *clientSuite) TestEnvironmentGet(c *gc.C) {
 	client := s.APIState.Client()
 	env, err := client.ModelGet()
@@ -4,6 +4,8 @@
 package controller
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names"
@@ -86,11 +88,44 @@ func (c *Client) RemoveBlocks() error {
 	return c.facade.FacadeCall("RemoveBlocks", args, nil)
 }
 
+// RotateControllerCertificates causes every controller to regenerate
+// its server certificate. If newCA is true, the controller's CA is
+// replaced first, and the previous CA remains trusted for the given
+// transition period; a zero period selects the controller's default.
+func (c *Client) RotateControllerCertificates(newCA bool, transitionPeriod time.Duration) (params.RotateControllerCertificatesResult, error) {
+	args := params.RotateControllerCertificatesArgs{
+		NewCA:            newCA,
+		TransitionPeriod: transitionPeriod,
+	}
+	var result params.RotateControllerCertificatesResult
+	err := c.facade.FacadeCall("RotateControllerCertificates", args, &result)
+	return result, errors.Trace(err)
+}
+
+// AuditLog returns the API calls recorded in the controller's audit
+// log that match the filter, oldest first.
+func (c *Client) AuditLog(filter params.AuditLogFilter) ([]params.AuditLogEntry, error) {
+	var result params.AuditLogResults
+	if err := c.facade.FacadeCall("AuditLog", filter, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Entries, nil
+}
+
 // WatchAllModels returns an AllWatcher, from which you can request
 // the Next collection of Deltas (for all models).
 func (c *Client) WatchAllModels() (*api.AllWatcher, error) {
+	return c.WatchAllModelsFiltered(params.AllWatcherFilter{})
+}
+
+// WatchAllModelsFiltered is like WatchAllModels, but the returned
+// AllWatcher only returns the deltas that match the given filter, which
+// may select the models of interest so that they can all be watched
+// over one connection. API servers that cannot filter deltas ignore
+// the filter.
+func (c *Client) WatchAllModelsFiltered(filter params.AllWatcherFilter) (*api.AllWatcher, error) {
 	info := new(api.WatchAll)
-	if err := c.facade.FacadeCall("WatchAllModels", nil, info); err != nil {
+	if err := c.facade.FacadeCall("WatchAllModels", filter, info); err != nil {
 		return nil, err
 	}
 	return api.NewAllModelWatcher(c.facade.RawAPICaller(), &info.AllWatcherId), nil
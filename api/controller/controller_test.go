@@ -114,6 +114,36 @@ func (s *controllerSuite) TestRemoveBlocks(c *gc.C) {
 	c.Assert(blocks, gc.HasLen, 0)
 }
 
+func (s *controllerSuite) TestRotateControllerCertificates(c *gc.C) {
+	sysManager := s.OpenAPI(c)
+	result, err := sysManager.RotateControllerCertificates(true, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+
+	certs, err := s.State.ControllerCertificates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(certs.PreviousCACert, gc.Equals, testing.CACert)
+	c.Assert(result.CACert, gc.Equals, certs.CACert+testing.CACert)
+	c.Assert(result.TransitionEnd, gc.NotNil)
+}
+
+func (s *controllerSuite) TestAuditLog(c *gc.C) {
+	err := s.State.AddAuditEntry(state.AuditEntry{
+		Time:    time.Date(2016, 5, 1, 12, 0, 0, 0, time.UTC),
+		User:    "bob@local",
+		Facade:  "Service",
+		Version: 1,
+		Method:  "Deploy",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	sysManager := s.OpenAPI(c)
+	entries, err := sysManager.AuditLog(params.AuditLogFilter{UserTag: "user-bob"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Assert(entries[0].Method, gc.Equals, "Deploy")
+	c.Assert(entries[0].UserTag, gc.Equals, "user-bob@local")
+}
+
 func (s *controllerSuite) TestWatchAllModels(c *gc.C) {
 	// The WatchAllModels infrastructure is comprehensively tested
 	// else. This test just ensure that the API calls work end-to-end.
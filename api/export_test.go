@@ -18,18 +18,20 @@ var (
 	BestVersion           = bestVersion
 	FacadeVersions        = &facadeVersions
 	ConnectWebsocket      = connectWebsocket
+	OpenWithFailoverFunc  = openWithFailover
 )
 
 // SetServerAddress allows changing the URL to the internal API server
 // that AddLocalCharm uses in order to test NotImplementedError.
 func SetServerAddress(c *Client, scheme, addr string) {
-	c.st.serverScheme = scheme
-	c.st.addr = addr
+	st := c.conn.(*state)
+	st.serverScheme = scheme
+	st.addr = addr
 }
 
 // ServerRoot is exported so that we can test the built URL.
 func ServerRoot(c *Client) string {
-	return c.st.serverRoot()
+	return c.conn.(*state).serverRoot()
 }
 
 // TestingStateParams is the parameters for NewTestingState, so that you can
@@ -50,7 +50,7 @@ var facadeVersions = map[string]int{
 	"Machiner":                     1,
 	"MeterStatus":                  1,
 	"MetricsAdder":                 2,
-	"MetricsDebug":                 1,
+	"MetricsDebug":                 2,
 	"MetricsManager":               1,
 	"MigrationFlag":                1,
 	"MigrationMaster":              1,
@@ -78,7 +78,8 @@ var facadeVersions = map[string]int{
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       3,
+	"Uniter":                       4,
+	"UpgradeSeries":                1,
 	"Upgrader":                     1,
 	"UserManager":                  1,
 	"VolumeAttachmentsWatcher":     2,
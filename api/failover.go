@@ -0,0 +1,415 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api
+
+import (
+	"io"
+	"net/url"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/httprequest"
+	"github.com/juju/names"
+	"github.com/juju/utils/set"
+	"github.com/juju/version"
+	"gopkg.in/macaroon.v1"
+
+	"github.com/juju/juju/api/addresser"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/charmrevisionupdater"
+	"github.com/juju/juju/api/cleaner"
+	"github.com/juju/juju/api/discoverspaces"
+	"github.com/juju/juju/api/firewaller"
+	"github.com/juju/juju/api/imagemetadata"
+	"github.com/juju/juju/api/instancepoller"
+	"github.com/juju/juju/api/provisioner"
+	"github.com/juju/juju/api/reboot"
+	"github.com/juju/juju/api/unitassigner"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/api/upgrader"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/rpc"
+)
+
+// OpenWithFailover is like Open, but the returned Connection
+// transparently fails over to another controller when its connection
+// to the API server breaks. It reconnects using the API addresses
+// most recently reported by the server, logs in again with the same
+// credentials, and retries the failed call if the call is known not
+// to alter the database; other calls fail with the original error,
+// because they may or may not have been made.
+//
+// The Connection is only reported as broken if it cannot reconnect.
+func OpenWithFailover(info *Info, opts DialOpts) (Connection, error) {
+	return openWithFailover(info, opts, Open)
+}
+
+func openWithFailover(info *Info, opts DialOpts, open OpenFunc) (Connection, error) {
+	conn, err := open(info, opts)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	infoCopy := *info
+	fc := &failoverConnection{
+		info:   &infoCopy,
+		opts:   opts,
+		open:   open,
+		conn:   conn,
+		broken: make(chan struct{}),
+		closed: make(chan struct{}),
+	}
+	go fc.monitor(conn)
+	return fc, nil
+}
+
+// failoverConnection implements Connection by delegating to an
+// underlying connection that is replaced when it breaks.
+type failoverConnection struct {
+	info *Info
+	opts DialOpts
+	open OpenFunc
+
+	// mu guards the following fields. It is not held while
+	// dialing, so that calls on the current connection are not
+	// held up by a slow reconnection.
+	mu       sync.Mutex
+	conn     Connection
+	isBroken bool
+	isClosed bool
+
+	// reconnecting is non-nil while a reconnection is in progress,
+	// and is closed when it completes.
+	reconnecting chan struct{}
+
+	// broken is closed when the connection breaks and
+	// cannot be reestablished.
+	broken chan struct{}
+
+	// closed is closed when Close is called.
+	closed chan struct{}
+}
+
+// current returns the underlying connection in use.
+func (fc *failoverConnection) current() Connection {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.conn
+}
+
+// monitor reconnects as soon as the given connection breaks, so that
+// a working connection is usually ready by the time it's next used.
+func (fc *failoverConnection) monitor(conn Connection) {
+	for {
+		select {
+		case <-conn.Broken():
+		case <-fc.closed:
+			return
+		}
+		var err error
+		conn, err = fc.reconnect(conn)
+		if err != nil {
+			return
+		}
+	}
+}
+
+// reconnect replaces the failed connection with a new one, unless that
+// has already been done, and returns the connection now in use. If it
+// cannot reconnect, the failoverConnection is marked as broken.
+func (fc *failoverConnection) reconnect(failed Connection) (Connection, error) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	for fc.reconnecting != nil {
+		// Wait for the reconnection in progress to finish.
+		reconnecting := fc.reconnecting
+		fc.mu.Unlock()
+		<-reconnecting
+		fc.mu.Lock()
+	}
+	if fc.isClosed || fc.isBroken {
+		return nil, rpc.ErrShutdown
+	}
+	if fc.conn != failed {
+		// Someone else got here first.
+		return fc.conn, nil
+	}
+	info := *fc.info
+	info.Addrs = failoverAddrs(failed.Addr(), failed.APIHostPorts(), fc.info.Addrs)
+	logger.Infof("API connection to %q failed; reconnecting to one of %v", failed.Addr(), info.Addrs)
+
+	reconnecting := make(chan struct{})
+	fc.reconnecting = reconnecting
+	fc.mu.Unlock()
+	conn, err := fc.open(&info, fc.opts)
+	fc.mu.Lock()
+	fc.reconnecting = nil
+	close(reconnecting)
+
+	if err != nil {
+		logger.Errorf("cannot reconnect to API: %v", err)
+		if !fc.isBroken {
+			fc.isBroken = true
+			close(fc.broken)
+		}
+		return nil, errors.Annotate(err, "cannot reconnect to API")
+	}
+	if fc.isClosed {
+		// The failoverConnection was closed while dialing.
+		if err := conn.Close(); err != nil {
+			logger.Debugf("error closing new API connection: %v", err)
+		}
+		return nil, rpc.ErrShutdown
+	}
+	if err := failed.Close(); err != nil {
+		logger.Debugf("error closing failed API connection: %v", err)
+	}
+	fc.conn = conn
+	return conn, nil
+}
+
+// failoverAddrs returns the addresses to try when reconnecting after
+// the connection to the failed address broke. The controllers last
+// reported by the server are preferred over those originally
+// supplied, and the failed address is tried last in case it was only
+// briefly unavailable.
+func failoverAddrs(failed string, hostPorts [][]network.HostPort, original []string) []string {
+	var addrs []string
+	seen := set.NewStrings(failed)
+	add := func(addr string) {
+		if !seen.Contains(addr) {
+			seen.Add(addr)
+			addrs = append(addrs, addr)
+		}
+	}
+	for _, addr := range network.HostPortsToStrings(network.CollapseHostPorts(hostPorts)) {
+		add(addr)
+	}
+	for _, addr := range original {
+		add(addr)
+	}
+	return append(addrs, failed)
+}
+
+// isConnectionError reports whether the error from an API call was
+// caused by the failure of the connection rather than of the call.
+func isConnectionError(err error) bool {
+	switch errors.Cause(err) {
+	case rpc.ErrShutdown, io.EOF, io.ErrUnexpectedEOF:
+		return true
+	}
+	return false
+}
+
+// APICall is part of the base.APICaller interface.
+func (fc *failoverConnection) APICall(facade string, version int, id, method string, args, response interface{}) error {
+	conn := fc.current()
+	err := conn.APICall(facade, version, id, method, args, response)
+	if !isConnectionError(err) {
+		return errors.Trace(err)
+	}
+	conn, rerr := fc.reconnect(conn)
+	if rerr != nil || !params.IsReadOnlyCall(facade, method) {
+		return errors.Trace(err)
+	}
+	logger.Debugf("retrying %s.%s after reconnecting", facade, method)
+	return errors.Trace(conn.APICall(facade, version, id, method, args, response))
+}
+
+// Ping is part of the Connection interface. Pinging is always safe to
+// retry, so a ping only fails if the connection cannot be reestablished.
+func (fc *failoverConnection) Ping() error {
+	conn := fc.current()
+	err := conn.Ping()
+	if !isConnectionError(err) {
+		return errors.Trace(err)
+	}
+	conn, rerr := fc.reconnect(conn)
+	if rerr != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(conn.Ping())
+}
+
+// Close is part of the Connection interface.
+func (fc *failoverConnection) Close() error {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	if fc.isClosed {
+		return nil
+	}
+	fc.isClosed = true
+	close(fc.closed)
+	if !fc.isBroken {
+		fc.isBroken = true
+		close(fc.broken)
+	}
+	return fc.conn.Close()
+}
+
+// Broken is part of the Connection interface. The returned channel is
+// closed when the connection is closed, or when it breaks and cannot be
+// reestablished.
+func (fc *failoverConnection) Broken() <-chan struct{} {
+	return fc.broken
+}
+
+// Login is part of the Connection interface. The credentials are
+// remembered so that they are used again when reconnecting.
+func (fc *failoverConnection) Login(tag names.Tag, password, nonce string, ms []macaroon.Slice) error {
+	if err := fc.current().Login(tag, password, nonce, ms); err != nil {
+		return errors.Trace(err)
+	}
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.info.Tag = tag
+	fc.info.Password = password
+	fc.info.Nonce = nonce
+	fc.info.Macaroons = ms
+	fc.info.SkipLogin = false
+	return nil
+}
+
+// Addr is part of the Connection interface.
+func (fc *failoverConnection) Addr() string {
+	return fc.current().Addr()
+}
+
+// APIHostPorts is part of the Connection interface.
+func (fc *failoverConnection) APIHostPorts() [][]network.HostPort {
+	return fc.current().APIHostPorts()
+}
+
+// ServerVersion is part of the Connection interface.
+func (fc *failoverConnection) ServerVersion() (version.Number, bool) {
+	return fc.current().ServerVersion()
+}
+
+// BestFacadeVersion is part of the base.APICaller interface.
+func (fc *failoverConnection) BestFacadeVersion(facade string) int {
+	return fc.current().BestFacadeVersion(facade)
+}
+
+// ModelTag is part of the base.APICaller interface.
+func (fc *failoverConnection) ModelTag() (names.ModelTag, error) {
+	return fc.current().ModelTag()
+}
+
+// HTTPClient is part of the base.APICaller interface.
+func (fc *failoverConnection) HTTPClient() (*httprequest.Client, error) {
+	return fc.current().HTTPClient()
+}
+
+// ConnectStream is part of the base.APICaller interface.
+func (fc *failoverConnection) ConnectStream(path string, attrs url.Values) (base.Stream, error) {
+	return fc.current().ConnectStream(path, attrs)
+}
+
+// ControllerTag is part of the Connection interface.
+func (fc *failoverConnection) ControllerTag() (names.ModelTag, error) {
+	return fc.current().ControllerTag()
+}
+
+// RPCClient is part of the Connection interface.
+func (fc *failoverConnection) RPCClient() *rpc.Conn {
+	return fc.current().RPCClient()
+}
+
+// AllFacadeVersions is part of the Connection interface.
+func (fc *failoverConnection) AllFacadeVersions() map[string][]int {
+	return fc.current().AllFacadeVersions()
+}
+
+// AuthTag is part of the Connection interface.
+func (fc *failoverConnection) AuthTag() names.Tag {
+	return fc.current().AuthTag()
+}
+
+// Client is part of the Connection interface. Calls, watchers, HTTP
+// requests and streams made through the returned Client all use the
+// connection in use at the time, so they fail over too.
+func (fc *failoverConnection) Client() *Client {
+	frontend, backend := base.NewClientFacade(fc, "Client")
+	return &Client{ClientFacade: frontend, facade: backend, conn: fc}
+}
+
+// RootHTTPClient returns an HTTP client pointing to the API server root
+// path of the controller currently in use.
+func (fc *failoverConnection) RootHTTPClient() (*httprequest.Client, error) {
+	conn, ok := fc.current().(rootHTTPClienter)
+	if !ok {
+		return nil, errors.NotSupportedf("HTTP requests to the API server root")
+	}
+	return conn.RootHTTPClient()
+}
+
+// UnitAssigner is part of the Connection interface.
+func (fc *failoverConnection) UnitAssigner() unitassigner.API {
+	return unitassigner.New(fc)
+}
+
+// Provisioner is part of the Connection interface.
+func (fc *failoverConnection) Provisioner() *provisioner.State {
+	return provisioner.NewState(fc)
+}
+
+// Uniter is part of the Connection interface.
+func (fc *failoverConnection) Uniter() (*uniter.State, error) {
+	authTag := fc.AuthTag()
+	unitTag, ok := authTag.(names.UnitTag)
+	if !ok {
+		return nil, errors.Errorf("expected UnitTag, got %T %v", authTag, authTag)
+	}
+	return uniter.NewState(fc, unitTag), nil
+}
+
+// Firewaller is part of the Connection interface.
+func (fc *failoverConnection) Firewaller() *firewaller.State {
+	return firewaller.NewState(fc)
+}
+
+// Upgrader is part of the Connection interface.
+func (fc *failoverConnection) Upgrader() *upgrader.State {
+	return upgrader.NewState(fc)
+}
+
+// Reboot is part of the Connection interface.
+func (fc *failoverConnection) Reboot() (reboot.State, error) {
+	switch tag := fc.AuthTag().(type) {
+	case names.MachineTag:
+		return reboot.NewState(fc, tag), nil
+	default:
+		return nil, errors.Errorf("expected names.MachineTag, got %T", tag)
+	}
+}
+
+// Addresser is part of the Connection interface.
+func (fc *failoverConnection) Addresser() *addresser.API {
+	return addresser.NewAPI(fc)
+}
+
+// DiscoverSpaces is part of the Connection interface.
+func (fc *failoverConnection) DiscoverSpaces() *discoverspaces.API {
+	return discoverspaces.NewAPI(fc)
+}
+
+// InstancePoller is part of the Connection interface.
+func (fc *failoverConnection) InstancePoller() *instancepoller.API {
+	return instancepoller.NewAPI(fc)
+}
+
+// CharmRevisionUpdater is part of the Connection interface.
+func (fc *failoverConnection) CharmRevisionUpdater() *charmrevisionupdater.State {
+	return charmrevisionupdater.NewState(fc)
+}
+
+// Cleaner is part of the Connection interface.
+func (fc *failoverConnection) Cleaner() *cleaner.API {
+	return cleaner.NewAPI(fc)
+}
+
+// MetadataUpdater is part of the Connection interface.
+func (fc *failoverConnection) MetadataUpdater() *imagemetadata.Client {
+	return imagemetadata.NewClient(fc)
+}
@@ -0,0 +1,286 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api_test
+
+import (
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/rpc"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type failoverSuite struct {
+	testing.IsolationSuite
+
+	mu     sync.Mutex
+	conns  []*fakeConnection
+	opened []api.Info
+	// openErr, if set, is returned from subsequent opens.
+	openErr error
+	// openBlock, if set, blocks subsequent opens until it is closed.
+	openBlock chan struct{}
+	// dials counts the opens started, including blocked ones.
+	dials int
+}
+
+var _ = gc.Suite(&failoverSuite{})
+
+func (s *failoverSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.conns = nil
+	s.opened = nil
+	s.openErr = nil
+	s.openBlock = nil
+	s.dials = 0
+}
+
+func (s *failoverSuite) open(info *api.Info, opts api.DialOpts) (api.Connection, error) {
+	s.mu.Lock()
+	s.dials++
+	block := s.openBlock
+	s.mu.Unlock()
+	if block != nil {
+		<-block
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.opened = append(s.opened, *info)
+	if s.openErr != nil {
+		return nil, s.openErr
+	}
+	conn := newFakeConnection(info.Addrs[0])
+	s.conns = append(s.conns, conn)
+	return conn, nil
+}
+
+func (s *failoverSuite) openWithFailover(c *gc.C) api.Connection {
+	info := &api.Info{
+		Addrs:    []string{"10.0.0.1:17070", "10.0.0.9:17070"},
+		Tag:      names.NewUserTag("bob"),
+		Password: "secret",
+	}
+	conn, err := api.OpenWithFailoverFunc(info, api.DialOpts{}, s.open)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.conns, gc.HasLen, 1)
+	s.conns[0].hostPorts = [][]network.HostPort{
+		network.NewHostPorts(17070, "10.0.0.1"),
+		network.NewHostPorts(17070, "10.0.0.2"),
+	}
+	return conn
+}
+
+func (s *failoverSuite) TestReadOnlyCallRetried(c *gc.C) {
+	conn := s.openWithFailover(c)
+	defer conn.Close()
+	s.conns[0].callErr = rpc.ErrShutdown
+
+	err := conn.APICall("Client", 1, "", "FullStatus", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.opened, gc.HasLen, 2)
+	c.Check(s.opened[1].Addrs, jc.DeepEquals, []string{
+		"10.0.0.2:17070", "10.0.0.9:17070", "10.0.0.1:17070",
+	})
+	c.Check(s.opened[1].Tag, gc.Equals, names.NewUserTag("bob"))
+	c.Check(s.opened[1].Password, gc.Equals, "secret")
+	c.Check(s.conns[0].closed, jc.IsTrue)
+	c.Check(s.conns[1].calls, jc.DeepEquals, []string{"Client.FullStatus"})
+	c.Check(conn.Addr(), gc.Equals, "10.0.0.2:17070")
+}
+
+func (s *failoverSuite) TestWritingCallNotRetried(c *gc.C) {
+	conn := s.openWithFailover(c)
+	defer conn.Close()
+	s.conns[0].callErr = rpc.ErrShutdown
+
+	err := conn.APICall("Service", 1, "", "Deploy", nil, nil)
+	c.Assert(errors.Cause(err), gc.Equals, rpc.ErrShutdown)
+
+	// The connection has still failed over, ready for the next call.
+	c.Assert(s.conns, gc.HasLen, 2)
+	c.Check(s.conns[1].calls, gc.HasLen, 0)
+	err = conn.APICall("Service", 1, "", "Deploy", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.conns[1].calls, jc.DeepEquals, []string{"Service.Deploy"})
+}
+
+func (s *failoverSuite) TestOtherErrorsNotRetried(c *gc.C) {
+	conn := s.openWithFailover(c)
+	defer conn.Close()
+	s.conns[0].callErr = errors.New("boom")
+
+	err := conn.APICall("Client", 1, "", "FullStatus", nil, nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(s.opened, gc.HasLen, 1)
+}
+
+func (s *failoverSuite) TestReconnectFailureBreaks(c *gc.C) {
+	conn := s.openWithFailover(c)
+	defer conn.Close()
+	s.conns[0].callErr = rpc.ErrShutdown
+	s.openErr = errors.New("no controllers")
+
+	err := conn.APICall("Client", 1, "", "FullStatus", nil, nil)
+	c.Assert(errors.Cause(err), gc.Equals, rpc.ErrShutdown)
+	select {
+	case <-conn.Broken():
+	default:
+		c.Fatalf("connection not broken")
+	}
+}
+
+func (s *failoverSuite) TestReconnectsWhenBroken(c *gc.C) {
+	conn := s.openWithFailover(c)
+	defer conn.Close()
+	close(s.conns[0].broken)
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if conn.Addr() == "10.0.0.2:17070" {
+			break
+		}
+	}
+	c.Assert(conn.Addr(), gc.Equals, "10.0.0.2:17070")
+	select {
+	case <-conn.Broken():
+		c.Fatalf("connection unexpectedly broken")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *failoverSuite) TestReconnectDoesNotBlockCalls(c *gc.C) {
+	conn := s.openWithFailover(c)
+	defer conn.Close()
+	s.mu.Lock()
+	s.openBlock = make(chan struct{})
+	s.mu.Unlock()
+	close(s.conns[0].broken)
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		s.mu.Lock()
+		dialing := s.dials == 2
+		s.mu.Unlock()
+		if dialing {
+			break
+		}
+	}
+	// The dial is blocked, but the connection can still be used.
+	done := make(chan string)
+	go func() {
+		done <- conn.Addr()
+	}()
+	select {
+	case addr := <-done:
+		c.Check(addr, gc.Equals, "10.0.0.1:17070")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("call blocked by reconnection")
+	}
+
+	close(s.openBlock)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if conn.Addr() == "10.0.0.2:17070" {
+			break
+		}
+	}
+	c.Assert(conn.Addr(), gc.Equals, "10.0.0.2:17070")
+}
+
+func (s *failoverSuite) TestClientStreamsFailOver(c *gc.C) {
+	conn := s.openWithFailover(c)
+	defer conn.Close()
+	client := conn.Client()
+	close(s.conns[0].broken)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if conn.Addr() == "10.0.0.2:17070" {
+			break
+		}
+	}
+	c.Assert(conn.Addr(), gc.Equals, "10.0.0.2:17070")
+
+	_, err := client.OpenSSHTunnel(names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.conns[0].streams, gc.HasLen, 0)
+	c.Check(s.conns[1].streams, jc.DeepEquals, []string{"/ssh"})
+}
+
+func (s *failoverSuite) TestClose(c *gc.C) {
+	conn := s.openWithFailover(c)
+	err := conn.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.conns[0].closed, jc.IsTrue)
+	select {
+	case <-conn.Broken():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("connection not broken after close")
+	}
+	c.Assert(s.opened, gc.HasLen, 1)
+}
+
+// fakeConnection is an api.Connection whose calls are recorded, and
+// which fails them all with callErr if it is set.
+type fakeConnection struct {
+	api.Connection
+
+	mu        sync.Mutex
+	addr      string
+	hostPorts [][]network.HostPort
+	calls     []string
+	streams   []string
+	callErr   error
+	closed    bool
+	broken    chan struct{}
+}
+
+func newFakeConnection(addr string) *fakeConnection {
+	return &fakeConnection{
+		addr:   addr,
+		broken: make(chan struct{}),
+	}
+}
+
+func (f *fakeConnection) APICall(facade string, version int, id, method string, args, response interface{}) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.callErr != nil {
+		return f.callErr
+	}
+	f.calls = append(f.calls, facade+"."+method)
+	return nil
+}
+
+func (f *fakeConnection) ConnectStream(path string, attrs url.Values) (base.Stream, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.streams = append(f.streams, path)
+	return nil, nil
+}
+
+func (f *fakeConnection) Addr() string {
+	return f.addr
+}
+
+func (f *fakeConnection) APIHostPorts() [][]network.HostPort {
+	return f.hostPorts
+}
+
+func (f *fakeConnection) Broken() <-chan struct{} {
+	return f.broken
+}
+
+func (f *fakeConnection) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	return nil
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/api/upgrader"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/wscodec"
 )
 
 // Info encapsulates information about a server holding juju state and
@@ -120,6 +121,21 @@ type DialOpts struct {
 	// be used in tests, or when verification cannot be
 	// performed and the communication need not be secure.
 	InsecureSkipVerify bool
+
+	// DisableCompression prevents large API messages from being
+	// compressed, which is otherwise done when the API server
+	// supports it.
+	DisableCompression bool
+}
+
+// messageFeatures returns the message format features that the
+// client asks the API server to use.
+func (opts DialOpts) messageFeatures() []string {
+	var features []string
+	if !opts.DisableCompression {
+		features = append(features, wscodec.FeatureDeflate)
+	}
+	return features
 }
 
 // DefaultDialOpts returns a DialOpts representing the default
@@ -5,6 +5,7 @@ package machinemanager
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/names"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -36,3 +37,35 @@ func (client *Client) AddMachines(machineParams []params.AddMachineParams) ([]pa
 	}
 	return results.Machines, err
 }
+
+// UpgradeSeriesPrepare locks the given machine, and the units it hosts,
+// for an in-place upgrade to the given series. The machine's units run
+// their pre-series-upgrade hooks, and its agents are reinstalled for
+// the new series; no other hooks run until the upgrade completes.
+func (client *Client) UpgradeSeriesPrepare(machineName, series string) error {
+	return client.upgradeSeries("UpgradeSeriesPrepare", machineName, series)
+}
+
+// UpgradeSeriesComplete records that the operating system of the given
+// machine has been upgraded, and lets its units run their
+// post-series-upgrade hooks.
+func (client *Client) UpgradeSeriesComplete(machineName string) error {
+	return client.upgradeSeries("UpgradeSeriesComplete", machineName, "")
+}
+
+func (client *Client) upgradeSeries(method, machineName, series string) error {
+	if !names.IsValidMachine(machineName) {
+		return errors.NotValidf("machine name %q", machineName)
+	}
+	args := params.UpgradeSeriesArgs{
+		Args: []params.UpgradeSeriesArg{{
+			Entity: params.Entity{Tag: names.NewMachineTag(machineName).String()},
+			Series: series,
+		}},
+	}
+	var results params.ErrorResults
+	if err := client.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
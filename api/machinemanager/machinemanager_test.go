@@ -116,3 +116,52 @@ func (s *MachinemanagerSuite) TestAddMachinesResultCountInvalid(c *gc.C) {
 		c.Check(err, gc.ErrorMatches, fmt.Sprintf("expected 1 result, got %d", n))
 	}
 }
+
+func (s *MachinemanagerSuite) TestUpgradeSeriesPrepare(c *gc.C) {
+	var called bool
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "MachineManager")
+		c.Check(request, gc.Equals, "UpgradeSeriesPrepare")
+		c.Check(arg, jc.DeepEquals, params.UpgradeSeriesArgs{
+			Args: []params.UpgradeSeriesArg{{
+				Entity: params.Entity{Tag: "machine-1"},
+				Series: "xenial",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		called = true
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	err := st.UpgradeSeriesPrepare("1", "xenial")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *MachinemanagerSuite) TestUpgradeSeriesCompleteServerError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "UpgradeSeriesComplete")
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "not ready"},
+			}},
+		}
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	err := st.UpgradeSeriesComplete("1")
+	c.Check(err, gc.ErrorMatches, "not ready")
+}
+
+func (s *MachinemanagerSuite) TestUpgradeSeriesInvalidMachine(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	st := machinemanager.NewClient(apiCaller)
+	err := st.UpgradeSeriesComplete("mysql/0")
+	c.Check(err, gc.ErrorMatches, `machine name "mysql/0" not valid`)
+}
@@ -24,6 +24,10 @@ type Client struct {
 type MetricsDebugClient interface {
 	// GetMetrics will receive metrics collected by the given entity tag
 	GetMetrics(tag string) ([]params.MetricResult, error)
+
+	// AggregateMetrics will receive the aggregated metrics
+	// collected by the entity with the given tag.
+	AggregateMetrics(arg params.AggregateMetricsArg) ([]params.AggregatedMetric, error)
 }
 
 // MeterStatusClient defines methods on the metricsdebug API end point.
@@ -60,6 +64,26 @@ func (c *Client) GetMetrics(tag string) ([]params.MetricResult, error) {
 	return metrics, nil
 }
 
+// AggregateMetrics will receive the aggregated metrics collected
+// by the entity with the tag specified in the given argument.
+func (c *Client) AggregateMetrics(arg params.AggregateMetricsArg) ([]params.AggregatedMetric, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotImplementedf("AggregateMetrics")
+	}
+	args := params.AggregateMetricsArgs{Args: []params.AggregateMetricsArg{arg}}
+	var results params.AggregateMetricsResults
+	if err := c.facade.FacadeCall("AggregateMetrics", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].Metrics, nil
+}
+
 // SetMeterStatus will set the meter status on the given entity tag.
 func (c *Client) SetMeterStatus(tag, code, info string) error {
 	args := params.MeterStatusParams{
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"time"
 
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -100,6 +101,83 @@ func (s *metricsdebugSuiteMock) TestGetMetricsFacadeCallError(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *metricsdebugSuiteMock) TestAggregateMetrics(c *gc.C) {
+	var called bool
+	now := time.Now()
+	arg := params.AggregateMetricsArg{
+		Tag:       "service-wordpress",
+		Key:       "pings",
+		Aggregate: params.AggregateSum,
+		Interval:  time.Minute,
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			c.Assert(request, gc.Equals, "AggregateMetrics")
+			c.Assert(a, jc.DeepEquals, params.AggregateMetricsArgs{
+				Args: []params.AggregateMetricsArg{arg},
+			})
+			result := response.(*params.AggregateMetricsResults)
+			result.Results = []params.AggregateMetricsResult{{
+				Metrics: []params.AggregatedMetric{{
+					Time:  now,
+					Key:   "pings",
+					Value: 12,
+					Count: 2,
+				}},
+			}}
+			called = true
+			return nil
+		})
+	client := metricsdebug.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 2})
+	metrics, err := client.AggregateMetrics(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(metrics, jc.DeepEquals, []params.AggregatedMetric{{
+		Time:  now,
+		Key:   "pings",
+		Value: 12,
+		Count: 2,
+	}})
+}
+
+func (s *metricsdebugSuiteMock) TestAggregateMetricsFails(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			result := response.(*params.AggregateMetricsResults)
+			result.Results = []params.AggregateMetricsResult{{
+				Error: common.ServerError(errors.New("an error")),
+			}}
+			return nil
+		})
+	client := metricsdebug.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 2})
+	metrics, err := client.AggregateMetrics(params.AggregateMetricsArg{Tag: "unit-wordpress/0"})
+	c.Assert(err, gc.ErrorMatches, "an error")
+	c.Assert(metrics, gc.IsNil)
+}
+
+func (s *metricsdebugSuiteMock) TestAggregateMetricsNotImplemented(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		})
+	client := metricsdebug.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 1})
+	_, err := client.AggregateMetrics(params.AggregateMetricsArg{Tag: "unit-wordpress/0"})
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotImplemented)
+}
+
 func (s *metricsdebugSuiteMock) TestSetMeterStatus(c *gc.C) {
 	var called bool
 	apiCaller := basetesting.APICallerFunc(
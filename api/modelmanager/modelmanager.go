@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/juju/permission"
 )
 
@@ -70,6 +71,92 @@ func (c *Client) CreateModel(owner string, account, config map[string]interface{
 	return result, nil
 }
 
+// CreateModelWithCredential creates a new model using the model config
+// specified, and the named cloud credential previously stored in the
+// controller by the owner with AddCloudCredential or
+// UpdateCloudCredential. The model is
+// updated whenever the credential is.
+func (c *Client) CreateModelWithCredential(owner, cloudName, credential string, config map[string]interface{}) (params.Model, error) {
+	var result params.Model
+	if !names.IsValidUser(owner) {
+		return result, errors.Errorf("invalid owner name %q", owner)
+	}
+	createArgs := params.ModelCreateArgs{
+		OwnerTag:        names.NewUserTag(owner).String(),
+		Config:          config,
+		Cloud:           cloudName,
+		CloudCredential: credential,
+	}
+	err := c.facade.FacadeCall("CreateModel", createArgs, &result)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// AddCloudCredential stores the given credential in the controller,
+// unless the owner already has a credential with the same cloud and
+// name, in which case an error satisfying params.IsCodeAlreadyExists
+// is returned and the stored credential is left untouched.
+func (c *Client) AddCloudCredential(owner, cloudName, name string, credential cloud.Credential) error {
+	if !names.IsValidUser(owner) {
+		return errors.Errorf("invalid owner name %q", owner)
+	}
+	args := params.UpdateCloudCredentials{
+		Credentials: []params.UpdateCloudCredential{{
+			OwnerTag:   names.NewUserTag(owner).String(),
+			Cloud:      cloudName,
+			Name:       name,
+			AuthType:   string(credential.AuthType()),
+			Attributes: credential.Attributes(),
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("AddCloudCredentials", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// UpdateCloudCredential stores the given credential in the controller,
+// replacing any credential the owner has with the same cloud and name.
+// The names of the models updated to use the new credential are
+// returned.
+func (c *Client) UpdateCloudCredential(owner, cloudName, name string, credential cloud.Credential) ([]names.ModelTag, error) {
+	if !names.IsValidUser(owner) {
+		return nil, errors.Errorf("invalid owner name %q", owner)
+	}
+	args := params.UpdateCloudCredentials{
+		Credentials: []params.UpdateCloudCredential{{
+			OwnerTag:   names.NewUserTag(owner).String(),
+			Cloud:      cloudName,
+			Name:       name,
+			AuthType:   string(credential.AuthType()),
+			Attributes: credential.Attributes(),
+		}},
+	}
+	var results params.UpdateCloudCredentialResults
+	if err := c.facade.FacadeCall("UpdateCloudCredentials", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	models := make([]names.ModelTag, len(result.Models))
+	for i, model := range result.Models {
+		tag, err := names.ParseModelTag(model)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		models[i] = tag
+	}
+	return models, nil
+}
+
 // ListModels returns the models that the specified user
 // has access to in the current server.  Only that controller owner
 // can list models for any user (at this stage).  Other users
@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/api/modelmanager"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
 	jujutesting "github.com/juju/juju/juju/testing"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
@@ -78,6 +79,45 @@ func (s *modelmanagerSuite) TestCreateModel(c *gc.C) {
 	c.Assert(utils.IsValidUUIDString(newEnv.UUID), jc.IsTrue)
 }
 
+func (s *modelmanagerSuite) TestCreateModelWithCredential(c *gc.C) {
+	modelManager := s.OpenAPI(c)
+	owner := s.AdminUserTag(c).Canonical()
+	models, err := modelManager.UpdateCloudCredential(owner, "dummy", "default", cloud.NewCredential(
+		cloud.UserPassAuthType, map[string]string{"secret": "sesame"},
+	))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, gc.HasLen, 0)
+
+	newEnv, err := modelManager.CreateModelWithCredential(owner, "dummy", "default", map[string]interface{}{
+		"name":            "new-model",
+		"authorized-keys": "ssh-key",
+		// dummy needs controller
+		"controller": false,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newEnv.Name, gc.Equals, "new-model")
+
+	models, err = modelManager.UpdateCloudCredential(owner, "dummy", "default", cloud.NewCredential(
+		cloud.UserPassAuthType, map[string]string{"secret": "open"},
+	))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, jc.DeepEquals, []names.ModelTag{names.NewModelTag(newEnv.UUID)})
+}
+
+func (s *modelmanagerSuite) TestAddCloudCredential(c *gc.C) {
+	modelManager := s.OpenAPI(c)
+	owner := s.AdminUserTag(c).Canonical()
+	err := modelManager.AddCloudCredential(owner, "dummy", "default", cloud.NewCredential(
+		cloud.UserPassAuthType, map[string]string{"secret": "sesame"},
+	))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = modelManager.AddCloudCredential(owner, "dummy", "default", cloud.NewCredential(
+		cloud.UserPassAuthType, map[string]string{"secret": "open"},
+	))
+	c.Assert(err, jc.Satisfies, params.IsCodeAlreadyExists)
+}
+
 func (s *modelmanagerSuite) TestListModelsBadUser(c *gc.C) {
 	modelManager := s.OpenAPI(c)
 	_, err := modelManager.ListModels("not a user")
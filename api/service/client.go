@@ -210,6 +210,23 @@ func (c *Client) SetConstraints(service string, constraints constraints.Value) e
 	return c.facade.FacadeCall("SetConstraints", params, nil)
 }
 
+// GetContainerProfile returns the container profile for the given service.
+func (c *Client) GetContainerProfile(service string) (instance.ContainerProfile, error) {
+	result := new(params.ContainerProfileResult)
+	err := c.facade.FacadeCall("GetContainerProfile", params.GetServiceContainerProfile{service}, result)
+	return result.Profile, err
+}
+
+// SetContainerProfile replaces the container profile for the given
+// service. An empty profile removes any customisations.
+func (c *Client) SetContainerProfile(service string, profile instance.ContainerProfile) error {
+	args := params.SetContainerProfile{
+		ServiceName: service,
+		Profile:     profile,
+	}
+	return c.facade.FacadeCall("SetContainerProfile", args, nil)
+}
+
 // Expose changes the juju-managed firewall to expose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Expose(service string) error {
@@ -266,6 +283,64 @@ func (c *Client) AddRelation(endpoints ...string) (*params.AddRelationResults, e
 	return &addRelRes, err
 }
 
+// SetRelationSuspended suspends or resumes the relation between the
+// specified endpoints.
+func (c *Client) SetRelationSuspended(suspended bool, endpoints ...string) error {
+	args := params.SetRelationSuspended{
+		Endpoints: endpoints,
+		Suspended: suspended,
+	}
+	return c.facade.FacadeCall("SetRelationSuspended", args, nil)
+}
+
+// TransferLeadership arranges for leadership of the unit's service to be
+// handed to the unit once the current leader's lease expires.
+func (c *Client) TransferLeadership(unitName string) error {
+	args := params.TransferLeadership{UnitName: unitName}
+	return c.facade.FacadeCall("TransferLeadership", args, nil)
+}
+
+// SetLeadershipPinned pins or unpins the leadership of the service.
+func (c *Client) SetLeadershipPinned(serviceName string, pinned bool) error {
+	args := params.SetLeadershipPinned{
+		ServiceName: serviceName,
+		Pinned:      pinned,
+	}
+	return c.facade.FacadeCall("SetLeadershipPinned", args, nil)
+}
+
+// Offer makes the given endpoints of a service available for relations
+// with services in other models. If endpoints is empty, all of the
+// service's non-peer endpoints are offered.
+func (c *Client) Offer(service string, endpoints []string, offerName, description string) (params.ServiceOfferDetails, error) {
+	args := params.ServiceOffers{
+		Offers: []params.ServiceOffer{{
+			ServiceName: service,
+			Endpoints:   endpoints,
+			OfferName:   offerName,
+			Description: description,
+		}},
+	}
+	var results params.ServiceOfferResults
+	if err := c.facade.FacadeCall("Offer", args, &results); err != nil {
+		return params.ServiceOfferDetails{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ServiceOfferDetails{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.ServiceOfferDetails{}, err
+	}
+	return *results.Results[0].Result, nil
+}
+
+// ListOffers returns the offers made by models hosted by the controller.
+func (c *Client) ListOffers() ([]params.ServiceOfferDetails, error) {
+	var results params.ListServiceOffersResults
+	err := c.facade.FacadeCall("ListOffers", nil, &results)
+	return results.Offers, err
+}
+
 // DestroyRelation removes the relation between the specified endpoints.
 func (c *Client) DestroyRelation(endpoints ...string) error {
 	params := params.DestroyRelation{Endpoints: endpoints}
@@ -52,6 +52,106 @@ func (s *serviceSuite) TestSetServiceMetricCredentials(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *serviceSuite) TestSetContainerProfile(c *gc.C) {
+	var called bool
+	profile := instance.ContainerProfile{
+		LXDConfig: map[string]string{"security.nesting": "true"},
+	}
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetContainerProfile")
+		c.Assert(a, jc.DeepEquals, params.SetContainerProfile{
+			ServiceName: "mysql",
+			Profile:     profile,
+		})
+		return nil
+	})
+	err := s.client.SetContainerProfile("mysql", profile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestSetRelationSuspended(c *gc.C) {
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetRelationSuspended")
+		c.Assert(a, jc.DeepEquals, params.SetRelationSuspended{
+			Endpoints: []string{"wordpress", "mysql"},
+			Suspended: true,
+		})
+		return nil
+	})
+	err := s.client.SetRelationSuspended(true, "wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestTransferLeadership(c *gc.C) {
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "TransferLeadership")
+		c.Assert(a, jc.DeepEquals, params.TransferLeadership{UnitName: "mysql/1"})
+		return nil
+	})
+	err := s.client.TransferLeadership("mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestSetLeadershipPinned(c *gc.C) {
+	var called bool
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetLeadershipPinned")
+		c.Assert(a, jc.DeepEquals, params.SetLeadershipPinned{
+			ServiceName: "mysql",
+			Pinned:      true,
+		})
+		return nil
+	})
+	err := s.client.SetLeadershipPinned("mysql", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestOffer(c *gc.C) {
+	var called bool
+	details := params.ServiceOfferDetails{URL: "local:/u/admin@local/model/db"}
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Offer")
+		c.Assert(a, jc.DeepEquals, params.ServiceOffers{
+			Offers: []params.ServiceOffer{{
+				ServiceName: "mysql",
+				Endpoints:   []string{"server"},
+				OfferName:   "db",
+				Description: "a database",
+			}},
+		})
+		result := response.(*params.ServiceOfferResults)
+		result.Results = []params.ServiceOfferResult{{Result: &details}}
+		return nil
+	})
+	offer, err := s.client.Offer("mysql", []string{"server"}, "db", "a database")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer, jc.DeepEquals, details)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestOfferError(c *gc.C) {
+	service.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		result := response.(*params.ServiceOfferResults)
+		result.Results = []params.ServiceOfferResult{{
+			Error: &params.Error{Message: "boom"},
+		}}
+		return nil
+	})
+	_, err := s.client.Offer("mysql", nil, "", "")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
 func (s *serviceSuite) TestSetServiceMetricCredentialsFails(c *gc.C) {
 	var called bool
 	service.PatchFacadeCall(s, s.client, func(request string, args, response interface{}) error {
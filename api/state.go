@@ -29,6 +29,7 @@ import (
 	"github.com/juju/juju/api/upgrader"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/rpc/wscodec"
 )
 
 // Login authenticates as the entity with the given name and password
@@ -56,6 +57,8 @@ func (st *state) loginForVersion(tag names.Tag, password, nonce string, macaroon
 		Credentials: password,
 		Nonce:       nonce,
 		Macaroons:   macaroons,
+
+		MessageFeatures: st.messageFeatures,
 	}
 	if tag == nil {
 		// Add any macaroons from the cookie jar that might work for
@@ -64,6 +67,11 @@ func (st *state) loginForVersion(tag names.Tag, password, nonce string, macaroon
 			httpbakery.MacaroonsForURL(st.bakeryClient.Client.Jar, st.cookieURL)...,
 		)
 	}
+	if st.codec != nil {
+		// The server may reply using any of the
+		// message features that we ask for.
+		st.codec.Accept(wscodec.NewFormat(st.messageFeatures))
+	}
 	err := st.APICall("Admin", vers, "", "Login", request, &result)
 	if err != nil {
 		return errors.Trace(err)
@@ -105,6 +113,11 @@ func (st *state) loginForVersion(tag names.Tag, password, nonce string, macaroon
 			return errors.Trace(err)
 		}
 	}
+	if st.codec != nil {
+		// Servers that don't support any of the requested
+		// message features leave us sending plain JSON.
+		st.codec.SetFormat(wscodec.NewFormat(result.MessageFeatures))
+	}
 	servers := params.NetworkHostsPorts(result.Servers)
 	err = st.setLoginResult(tag, result.ModelTag, result.ControllerTag, servers, result.Facades)
 	if err != nil {
@@ -191,7 +204,7 @@ func addAddress(servers [][]network.HostPort, addr string) ([][]network.HostPort
 // to access client-specific functionality.
 func (st *state) Client() *Client {
 	frontend, backend := base.NewClientFacade(st, "Client")
-	return &Client{ClientFacade: frontend, facade: backend, st: st}
+	return &Client{ClientFacade: frontend, facade: backend, conn: st}
 }
 
 // UnitAssigner returns a version of the state that provides functionality
@@ -738,3 +738,111 @@ func (u *Unit) NetworkConfig(bindingName string) ([]params.NetworkConfig, error)
 
 	return result.Config, nil
 }
+
+// WatchUpgradeSeriesNotifications returns a NotifyWatcher for observing
+// changes to the series upgrade lock of the unit's machine.
+func (u *Unit) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error) {
+	if u.st.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotImplementedf("WatchUpgradeSeriesNotifications")
+	}
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("WatchUpgradeSeriesNotifications", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(u.st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// UpgradeSeriesStatus returns the progress of the unit through the
+// series upgrade of its machine. An empty status is returned if no
+// series upgrade is in progress.
+func (u *Unit) UpgradeSeriesStatus() (params.UpgradeSeriesStatus, error) {
+	if u.st.facade.BestAPIVersion() < 4 {
+		return "", errors.NotImplementedf("UpgradeSeriesStatus")
+	}
+	var results params.UpgradeSeriesStatusResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("UpgradeSeriesStatus", args, &results)
+	if err != nil {
+		return "", err
+	}
+	if len(results.Results) != 1 {
+		return "", fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Status, nil
+}
+
+// SetUpgradeSeriesStatus records the progress of the unit through the
+// series upgrade of its machine.
+func (u *Unit) SetUpgradeSeriesStatus(status params.UpgradeSeriesStatus) error {
+	if u.st.facade.BestAPIVersion() < 4 {
+		return errors.NotImplementedf("SetUpgradeSeriesStatus")
+	}
+	var result params.ErrorResults
+	args := params.SetUpgradeSeriesStatusArgs{
+		Args: []params.SetUpgradeSeriesStatusArg{{
+			Entity: params.Entity{Tag: u.tag.String()},
+			Status: status,
+		}},
+	}
+	err := u.st.facade.FacadeCall("SetUpgradeSeriesStatus", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// CharmState returns the key-value pairs that the unit's charm has
+// persisted between hooks.
+func (u *Unit) CharmState() (map[string]string, error) {
+	var results params.SettingsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("CharmState", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return map[string]string(result.Settings), nil
+}
+
+// SetCharmState replaces the key-value pairs persisted by the unit's
+// charm.
+func (u *Unit) SetCharmState(state map[string]string) error {
+	var result params.ErrorResults
+	args := params.SetCharmStateArgs{
+		Args: []params.SetCharmStateArg{{
+			Tag:   u.tag.String(),
+			State: params.Settings(state),
+		}},
+	}
+	err := u.st.facade.FacadeCall("SetCharmState", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
@@ -627,6 +627,63 @@ func (s *unitSuite) TestWatchAddressesErrors(c *gc.C) {
 	c.Assert(err, jc.Satisfies, params.IsCodeNotAssigned)
 }
 
+func (s *unitSuite) TestUpgradeSeries(c *gc.C) {
+	w, err := s.apiUnit.WatchUpgradeSeriesNotifications()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	status, err := s.apiUnit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, params.UpgradeSeriesNotStarted)
+
+	err = s.wordpressMachine.PrepareUpgradeSeries("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	status, err = s.apiUnit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, params.UpgradeSeriesPrepareStarted)
+
+	err = s.apiUnit.SetUpgradeSeriesStatus(params.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	status, err = s.apiUnit.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, params.UpgradeSeriesPrepareCompleted)
+}
+
+func (s *unitSuite) TestUpgradeSeriesNotImplemented(c *gc.C) {
+	s.patchNewState(c, uniter.NewStateV3)
+
+	_, err := s.apiUnit.WatchUpgradeSeriesNotifications()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	_, err = s.apiUnit.UpgradeSeriesStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	err = s.apiUnit.SetUpgradeSeriesStatus(params.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *unitSuite) TestCharmState(c *gc.C) {
+	charmState, err := s.apiUnit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+
+	err = s.apiUnit.SetCharmState(map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	charmState, err = s.apiUnit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "bar"})
+	charmState, err = s.wordpressUnit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
 func (s *unitSuite) TestAddMetrics(c *gc.C) {
 	uniter.PatchUnitResponse(s, s.apiUnit, "AddMetrics",
 		func(results interface{}) error {
@@ -45,7 +45,17 @@ func (st *State) SetVersion(tag string, v version.Binary) error {
 	return results.OneError()
 }
 
+// DesiredVersion returns the version of agent tools that should run on
+// the given entity.
 func (st *State) DesiredVersion(tag string) (version.Number, error) {
+	vers, _, err := st.DesiredVersionRollback(tag)
+	return vers, err
+}
+
+// DesiredVersionRollback returns the version of agent tools that
+// should run on the given entity, and whether it is the version an
+// upgrade was rolled back to, which the entity may downgrade to.
+func (st *State) DesiredVersionRollback(tag string) (version.Number, bool, error) {
 	var results params.VersionResults
 	args := params.Entities{
 		Entities: []params.Entity{{Tag: tag}},
@@ -53,21 +63,21 @@ func (st *State) DesiredVersion(tag string) (version.Number, error) {
 	err := st.facade.FacadeCall("DesiredVersion", args, &results)
 	if err != nil {
 		// TODO: Not directly tested
-		return version.Number{}, err
+		return version.Number{}, false, err
 	}
 	if len(results.Results) != 1 {
 		// TODO: Not directly tested
-		return version.Number{}, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+		return version.Number{}, false, fmt.Errorf("expected 1 result, got %d", len(results.Results))
 	}
 	result := results.Results[0]
 	if err := result.Error; err != nil {
-		return version.Number{}, err
+		return version.Number{}, false, err
 	}
 	if result.Version == nil {
 		// TODO: Not directly tested
-		return version.Number{}, fmt.Errorf("received no error, but got a nil Version")
+		return version.Number{}, false, fmt.Errorf("received no error, but got a nil Version")
 	}
-	return *result.Version, nil
+	return *result.Version, result.Rollback, nil
 }
 
 // Tools returns the agent tools that should run on the given entity,
@@ -0,0 +1,91 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package upgradeseries implements the client-side API facade used
+// by the upgradeseries worker.
+package upgradeseries
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+// Facade provides access to the UpgradeSeries API facade.
+type Facade struct {
+	caller base.FacadeCaller
+	tag    names.MachineTag
+}
+
+// NewFacade creates a new client-side UpgradeSeries facade for the
+// machine with the given tag.
+func NewFacade(caller base.APICaller, tag names.MachineTag) *Facade {
+	return &Facade{
+		caller: base.NewFacadeCaller(caller, "UpgradeSeries"),
+		tag:    tag,
+	}
+}
+
+// WatchUpgradeSeriesNotifications returns a NotifyWatcher for observing
+// changes to the series upgrade lock of the machine.
+func (f *Facade) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error) {
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: f.tag.String()}},
+	}
+	err := f.caller.FacadeCall("WatchUpgradeSeriesNotifications", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(f.caller.RawAPICaller(), result), nil
+}
+
+// UpgradeSeriesStatus returns the progress of the machine agent through
+// the series upgrade of its machine, and the series being upgraded to.
+// An empty status is returned if no series upgrade is in progress.
+func (f *Facade) UpgradeSeriesStatus() (params.UpgradeSeriesStatus, string, error) {
+	var results params.UpgradeSeriesStatusResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: f.tag.String()}},
+	}
+	err := f.caller.FacadeCall("UpgradeSeriesStatus", args, &results)
+	if err != nil {
+		return "", "", err
+	}
+	if len(results.Results) != 1 {
+		return "", "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", "", result.Error
+	}
+	return result.Status, result.ToSeries, nil
+}
+
+// SetUpgradeSeriesStatus records the progress of the machine agent
+// through the series upgrade of its machine.
+func (f *Facade) SetUpgradeSeriesStatus(status params.UpgradeSeriesStatus) error {
+	var result params.ErrorResults
+	args := params.SetUpgradeSeriesStatusArgs{
+		Args: []params.SetUpgradeSeriesStatusArg{{
+			Entity: params.Entity{Tag: f.tag.String()},
+			Status: status,
+		}},
+	}
+	err := f.caller.FacadeCall("SetUpgradeSeriesStatus", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
@@ -0,0 +1,99 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries_test
+
+import (
+	"errors"
+
+	"github.com/juju/names"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/upgradeseries"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) TestUpgradeSeriesStatus(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "UpgradeSeries")
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		*response.(*params.UpgradeSeriesStatusResults) = params.UpgradeSeriesStatusResults{
+			Results: []params.UpgradeSeriesStatusResult{{
+				Status:     params.UpgradeSeriesPrepareStarted,
+				FromSeries: "trusty",
+				ToSeries:   "xenial",
+			}},
+		}
+		return nil
+	})
+	facade := upgradeseries.NewFacade(apiCaller, names.NewMachineTag("42"))
+
+	status, series, err := facade.UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, gc.Equals, params.UpgradeSeriesPrepareStarted)
+	c.Assert(series, gc.Equals, "xenial")
+
+	stub.CheckCalls(c, []testing.StubCall{{
+		"UpgradeSeriesStatus", []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: "machine-42"}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestSetUpgradeSeriesStatus(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "UpgradeSeries")
+		stub.AddCall(request, args)
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	facade := upgradeseries.NewFacade(apiCaller, names.NewMachineTag("42"))
+
+	err := facade.SetUpgradeSeriesStatus(params.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stub.CheckCalls(c, []testing.StubCall{{
+		"SetUpgradeSeriesStatus", []interface{}{params.SetUpgradeSeriesStatusArgs{
+			Args: []params.SetUpgradeSeriesStatusArg{{
+				Entity: params.Entity{Tag: "machine-42"},
+				Status: params.UpgradeSeriesPrepareCompleted,
+			}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		return errors.New("blam")
+	})
+	facade := upgradeseries.NewFacade(apiCaller, names.NewMachineTag("42"))
+
+	_, _, err := facade.UpgradeSeriesStatus()
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
 )
 
 func init() {
@@ -69,6 +70,34 @@ func (a *ActionAPI) Actions(arg params.Entities) (params.ActionResults, error) {
 	return response, nil
 }
 
+// WatchActionsProgress creates a watcher that reports the progress
+// messages logged by each given action.
+func (a *ActionAPI) WatchActionsProgress(arg params.Entities) (params.StringsWatchResults, error) {
+	results := params.StringsWatchResults{Results: make([]params.StringsWatchResult, len(arg.Entities))}
+	for i, entity := range arg.Entities {
+		result := &results.Results[i]
+		actionTag, err := names.ParseActionTag(entity.Tag)
+		if err != nil {
+			result.Error = common.ServerError(common.ErrBadId)
+			continue
+		}
+		if _, err := a.state.ActionByTag(actionTag); err != nil {
+			result.Error = common.ServerError(err)
+			continue
+		}
+		w := a.state.WatchActionLogs(actionTag.Id())
+		// Consume the initial event.
+		changes, ok := <-w.Changes()
+		if !ok {
+			result.Error = common.ServerError(watcher.EnsureErr(w))
+			continue
+		}
+		result.StringsWatcherId = a.resources.Register(w)
+		result.Changes = changes
+	}
+	return results, nil
+}
+
 // FindActionTagsByPrefix takes a list of string prefixes and finds
 // corresponding ActionTags that match that prefix.
 func (a *ActionAPI) FindActionTagsByPrefix(arg params.FindTags) (params.FindTagsResults, error) {
@@ -165,6 +165,33 @@ func (s *actionSuite) TestActions(c *gc.C) {
 	}
 }
 
+func (s *actionSuite) TestWatchActionsProgress(c *gc.C) {
+	a, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("halfway there")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.action.WatchActionsProgress(params.Entities{Entities: []params.Entity{
+		{Tag: a.ActionTag().String()},
+		{Tag: names.NewActionTag("00000000-0000-0000-0000-000000000000").String()},
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.StringsWatcherId, gc.Equals, "1")
+	c.Assert(result.Changes, gc.HasLen, 1)
+	c.Assert(result.Changes[0], jc.Contains, `"message":"halfway there"`)
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `action "00000000-0000-0000-0000-000000000000" not found`)
+	c.Assert(results.Results[2].Error, gc.DeepEquals, common.ServerError(common.ErrBadId))
+}
+
 func (s *actionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	// NOTE: full testing with multiple matches has been moved to state package.
 	arg := params.Actions{Actions: []params.Action{{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction", Parameters: map[string]interface{}{}}}}
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/presence"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/rpc/wscodec"
 	"github.com/juju/juju/state"
 	statepresence "github.com/juju/juju/state/presence"
 	jujuversion "github.com/juju/juju/version"
@@ -203,9 +204,18 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 	if envUser != nil {
 		authedApi = newClientAuthRoot(authedApi, envUser)
 	}
+	authedApi = newAuditRoot(authedApi, a.root.state, a.srv.clock, entity.Tag())
 
+	a.root.setRequestLimiter(a.srv.requestLimiter(entity.Tag()))
 	a.root.rpcConn.ServeFinder(authedApi, serverError)
 
+	if a.root.codec != nil && len(req.MessageFeatures) > 0 {
+		// The client reads messages in any format it asked for, so
+		// the format can be switched before the result is sent.
+		loginResult.MessageFeatures = wscodec.SupportedFeatures(req.MessageFeatures)
+		a.root.codec.SetFormat(wscodec.NewFormat(loginResult.MessageFeatures))
+	}
+
 	return loginResult, nil
 }
 
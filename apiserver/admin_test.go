@@ -623,6 +623,41 @@ func (s *loginSuite) TestControllerModel(c *gc.C) {
 	s.assertRemoteEnvironment(c, st, s.State.ModelTag())
 }
 
+func (s *loginSuite) TestLoginNegotiatesMessageFeatures(c *gc.C) {
+	info, cleanup := s.setupServerWithValidator(c, nil)
+	defer cleanup()
+	st := s.openAPIWithoutLogin(c, info)
+	defer st.Close()
+
+	var result params.LoginResultV1
+	err := st.APICall("Admin", 3, "", "Login", &params.LoginRequest{
+		AuthTag:         s.AdminUserTag(c).String(),
+		Credentials:     "dummy-secret",
+		MessageFeatures: []string{"cbor", "brotli", "deflate"},
+	}, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.MessageFeatures, jc.DeepEquals, []string{"deflate"})
+
+	// The server now compresses large messages, which are still
+	// understood by the client even though it sends plain JSON.
+	_, err = st.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *loginSuite) TestLoginWithoutCompression(c *gc.C) {
+	info, cleanup := s.setupServerWithValidator(c, nil)
+	defer cleanup()
+	opts := fastDialOpts
+	opts.DisableCompression = true
+	st, err := api.Open(info, opts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	status, err := st.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.ModelName, gc.Not(gc.Equals), "")
+}
+
 func (s *loginSuite) TestControllerModelBadCreds(c *gc.C) {
 	info, cleanup := s.setupServerWithValidator(c, nil)
 	defer cleanup()
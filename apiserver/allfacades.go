@@ -65,5 +65,6 @@ import (
 	_ "github.com/juju/juju/apiserver/unitassigner"
 	_ "github.com/juju/juju/apiserver/uniter"
 	_ "github.com/juju/juju/apiserver/upgrader"
+	_ "github.com/juju/juju/apiserver/upgradeseries"
 	_ "github.com/juju/juju/apiserver/usermanager"
 )
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/names"
 	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	"golang.org/x/net/websocket"
 	"launchpad.net/tomb"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/jsoncodec"
+	"github.com/juju/juju/rpc/wscodec"
 	"github.com/juju/juju/state"
 )
 
@@ -51,6 +53,15 @@ type Server struct {
 	modelUUID         string
 	authCtxt          *authContext
 	connections       int32 // count of active websocket connections
+	maxConnections    int32
+	requestRate       float64
+	requestBurst      int64
+	clock             clock.Clock
+
+	// bucketsMutex guards buckets, which holds the token bucket
+	// limiting the RPC requests of each authenticated entity.
+	bucketsMutex sync.Mutex
+	buckets      map[string]*entityBucket
 }
 
 // LoginValidator functions are used to decide whether login requests
@@ -68,6 +79,27 @@ type ServerConfig struct {
 	Validator   LoginValidator
 	CertChanged chan params.StateServingInfo
 
+	// MaxConnections holds the maximum number of concurrent API
+	// connections. Connections beyond the limit are closed after
+	// their first request, which fails with a "retry after" error.
+	// If it is zero, connections are not limited.
+	MaxConnections int
+
+	// RequestRate holds the number of RPC requests per second that
+	// each authenticated agent or user may make, shared across all of
+	// its connections. Requests beyond the limit fail with a "retry
+	// after" error. If it is zero, requests are not limited.
+	RequestRate float64
+
+	// RequestBurst holds the number of requests that each entity may
+	// make in a burst before RequestRate applies. If it is zero, the
+	// burst allowed is one second's worth of requests.
+	RequestBurst int
+
+	// Clock is used to timestamp the entries recorded in the audit
+	// log. If it is nil, the wall clock is used.
+	Clock clock.Clock
+
 	// This field only exists to support testing.
 	StatePool *state.StatePool
 }
@@ -195,15 +227,35 @@ func newServer(s *state.State, lis *net.TCPListener, cfg ServerConfig) (_ *Serve
 		stPool = state.NewStatePool(s)
 	}
 
+	if cfg.MaxConnections < 0 {
+		return nil, errors.NotValidf("max connections %d", cfg.MaxConnections)
+	}
+	if cfg.RequestRate < 0 {
+		return nil, errors.NotValidf("request rate %v", cfg.RequestRate)
+	}
+	if cfg.RequestBurst < 0 {
+		return nil, errors.NotValidf("request burst %d", cfg.RequestBurst)
+	}
+
+	serverClock := cfg.Clock
+	if serverClock == nil {
+		serverClock = clock.WallClock
+	}
+
 	srv := &Server{
-		state:     s,
-		statePool: stPool,
-		lis:       newChangeCertListener(lis, cfg.CertChanged, tlsConfig),
-		tag:       cfg.Tag,
-		dataDir:   cfg.DataDir,
-		logDir:    cfg.LogDir,
-		limiter:   utils.NewLimiter(loginRateLimit),
-		validator: cfg.Validator,
+		state:          s,
+		statePool:      stPool,
+		lis:            newChangeCertListener(lis, cfg.CertChanged, tlsConfig),
+		tag:            cfg.Tag,
+		dataDir:        cfg.DataDir,
+		logDir:         cfg.LogDir,
+		limiter:        utils.NewLimiter(loginRateLimit),
+		validator:      cfg.Validator,
+		maxConnections: int32(cfg.MaxConnections),
+		requestRate:    cfg.RequestRate,
+		requestBurst:   int64(cfg.RequestBurst),
+		buckets:        make(map[string]*entityBucket),
+		clock:          serverClock,
 		adminApiFactories: map[int]adminApiFactory{
 			3: newAdminApiV3,
 		},
@@ -387,9 +439,11 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	mainAPIHandler := srv.trackRequests(http.HandlerFunc(srv.apiHandler))
 	logSinkHandler := srv.trackRequests(newLogSinkHandler(httpCtxt, srv.logDir))
 	debugLogHandler := srv.trackRequests(newDebugLogDBHandler(httpCtxt))
+	sshHandler := srv.trackRequests(&sshTunnelHandler{ctxt: httpCtxt})
 
 	add("/model/:modeluuid/logsink", logSinkHandler)
 	add("/model/:modeluuid/log", debugLogHandler)
+	add("/model/:modeluuid/ssh", sshHandler)
 	add("/model/:modeluuid/charms",
 		&charmsHandler{
 			ctxt:    httpCtxt,
@@ -533,6 +587,12 @@ func registerEndpoint(ep apihttp.Endpoint, mux *pat.PatternServeMux) {
 }
 
 func (srv *Server) apiHandler(w http.ResponseWriter, req *http.Request) {
+	if srv.tooManyConnections() {
+		logger.Debugf("too many connections, refusing API connection from %s", req.RemoteAddr)
+		wsServer := websocket.Server{Handler: srv.refuseConn}
+		wsServer.ServeHTTP(w, req)
+		return
+	}
 	reqNotifier := newRequestNotifier(&srv.connections)
 	reqNotifier.join(req)
 	defer reqNotifier.leave()
@@ -549,7 +609,7 @@ func (srv *Server) apiHandler(w http.ResponseWriter, req *http.Request) {
 }
 
 func (srv *Server) serveConn(wsConn *websocket.Conn, reqNotifier *requestNotifier, modelUUID string) error {
-	codec := jsoncodec.NewWebsocket(wsConn)
+	codec := wscodec.New(wsConn)
 	if loggo.GetLogger("juju.rpc.jsoncodec").EffectiveLogLevel() <= loggo.TRACE {
 		codec.SetLogging(true)
 	}
@@ -565,6 +625,8 @@ func (srv *Server) serveConn(wsConn *websocket.Conn, reqNotifier *requestNotifie
 	if err != nil {
 		conn.ServeFinder(&errRoot{err}, serverError)
 	} else {
+		h.codec = codec
+		defer h.releaseLimiter()
 		adminApis := make(map[int]interface{})
 		for apiVersion, factory := range srv.adminApiFactories {
 			adminApis[apiVersion] = factory(srv, h, reqNotifier)
@@ -0,0 +1,297 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/juju/names"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/state"
+)
+
+// redactedValue is recorded in place of the values of argument fields
+// that may hold secrets.
+const redactedValue = "(redacted)"
+
+// auditTag is the struct tag marking fields of API call arguments whose
+// values are not recorded in the audit log, as in:
+//
+//	Password string `json:"password" audit:"redact"`
+const auditTag = "audit"
+
+// isCallAudited returns whether a call of the method on the facade is
+// recorded in the audit log. Calls that don't alter the
+// database, and calls that only maintain the connection, are not.
+func isCallAudited(facade, method string) bool {
+	if isCallReadOnly(facade, method) {
+		return false
+	}
+	switch {
+	case facade == "Admin", facade == "Pinger":
+		return false
+	case strings.HasSuffix(facade, "Watcher"):
+		return false
+	}
+	return true
+}
+
+// auditRecorder is the part of state used to record audit log entries.
+type auditRecorder interface {
+	AddAuditEntry(entry state.AuditEntry) error
+}
+
+// auditRoot records the API calls of a user or agent in the audit log.
+type auditRoot struct {
+	finder   rpc.MethodFinder
+	recorder auditRecorder
+	clock    clock.Clock
+	entity   names.Tag
+}
+
+// newAuditRoot returns a new auditRoot, which records calls made by
+// the entity with the given tag, timestamped with the given clock.
+func newAuditRoot(finder rpc.MethodFinder, recorder auditRecorder, clock clock.Clock, entity names.Tag) *auditRoot {
+	return &auditRoot{finder, recorder, clock, entity}
+}
+
+// auditUser returns the name recorded as the user that made a call:
+// the canonical name of a user, or the tag of an agent.
+func auditUser(entity names.Tag) string {
+	if user, ok := entity.(names.UserTag); ok {
+		return user.Canonical()
+	}
+	return entity.String()
+}
+
+// FindMethod is part of the rpc.MethodFinder interface. The returned
+// caller records calls in the audit log when they complete.
+func (r *auditRoot) FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	caller, err := r.finder.FindMethod(rootName, version, methodName)
+	if err != nil || !isCallAudited(rootName, methodName) {
+		return caller, err
+	}
+	return &auditingCaller{
+		MethodCaller: caller,
+		root:         r,
+		facade:       rootName,
+		version:      version,
+		method:       methodName,
+	}, nil
+}
+
+// record adds an entry for the completed call to the audit log. Failure
+// to record the entry is logged, but does not fail the call.
+func (r *auditRoot) record(facade string, version int, method string, arg, result reflect.Value, callErr error) {
+	entry := state.AuditEntry{
+		Time:    r.clock.Now(),
+		User:    auditUser(r.entity),
+		Facade:  facade,
+		Version: version,
+		Method:  method,
+		Args:    auditArgs(facade, method, arg),
+	}
+	if callErr == nil && result.IsValid() && result.CanInterface() {
+		// Many bulk calls report failures in their results.
+		if results, ok := result.Interface().(params.ErrorResults); ok {
+			callErr = results.Combine()
+		}
+	}
+	if callErr != nil {
+		entry.Error = callErr.Error()
+	}
+	if err := r.recorder.AddAuditEntry(entry); err != nil {
+		logger.Errorf("cannot record %s.%s call by %s in audit log: %v", facade, method, entry.User, err)
+	}
+}
+
+// auditArgs returns the call's arguments serialised as JSON, with the
+// values of any fields that may hold secrets redacted.
+func auditArgs(facade, method string, arg reflect.Value) string {
+	if !arg.IsValid() || !arg.CanInterface() {
+		return ""
+	}
+	data, err := json.Marshal(redactSecrets(arg))
+	if err != nil {
+		logger.Warningf("cannot serialise %s.%s arguments for audit log: %v", facade, method, err)
+		return ""
+	}
+	return string(data)
+}
+
+// redactSecrets returns a value that serialises to JSON as v does,
+// except that the values of fields tagged audit:"redact" are replaced.
+func redactSecrets(v reflect.Value) interface{} {
+	if !hasSecrets(v.Type()) {
+		return v.Interface()
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return redactSecrets(v.Elem())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		out := make([]interface{}, v.Len())
+		for i := range out {
+			out[i] = redactSecrets(v.Index(i))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		out := make(map[string]interface{}, v.Len())
+		for _, key := range v.MapKeys() {
+			out[fmt.Sprint(key.Interface())] = redactSecrets(v.MapIndex(key))
+		}
+		return out
+	}
+	out := make(map[string]interface{})
+	redactStructFields(v, out)
+	return out
+}
+
+// redactStructFields adds the fields of the struct v to out, keyed as
+// they are when serialised to JSON, redacting those that may hold
+// secrets.
+func redactStructFields(v reflect.Value, out map[string]interface{}) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name, omitEmpty := jsonFieldName(field)
+		if name == "-" {
+			continue
+		}
+		value := v.Field(i)
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			redactStructFields(value, out)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if omitEmpty && isEmptyValue(value) {
+			continue
+		}
+		if field.Tag.Get(auditTag) == "redact" {
+			out[name] = redactedValue
+		} else {
+			out[name] = redactSecrets(value)
+		}
+	}
+}
+
+// jsonFieldName returns the name given to the field by its json tag,
+// if any, and whether it is omitted when empty.
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	parts := strings.Split(field.Tag.Get("json"), ",")
+	omitEmpty := false
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return parts[0], omitEmpty
+}
+
+// isEmptyValue reports whether v is omitted from JSON by omitempty.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+var (
+	secretTypesMu sync.Mutex
+	secretTypes   = make(map[reflect.Type]bool)
+)
+
+// hasSecrets returns whether values of type t may contain fields
+// tagged audit:"redact".
+func hasSecrets(t reflect.Type) bool {
+	secretTypesMu.Lock()
+	defer secretTypesMu.Unlock()
+	if result, ok := secretTypes[t]; ok {
+		return result
+	}
+	result := hasSecretsLocked(t, make(map[reflect.Type]bool))
+	secretTypes[t] = result
+	return result
+}
+
+// hasSecretsLocked returns whether values of type t may contain
+// fields tagged audit:"redact". Types in inProgress are being
+// inspected further up the stack, and are treated as having no
+// secrets of their own to guard against recursive types. Results
+// that depend on such a type are incomplete, so only the outermost
+// call's result is cached, by hasSecrets.
+func hasSecretsLocked(t reflect.Type, inProgress map[reflect.Type]bool) bool {
+	if result, ok := secretTypes[t]; ok {
+		return result
+	}
+	if inProgress[t] {
+		return false
+	}
+	inProgress[t] = true
+	defer delete(inProgress, t)
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return hasSecretsLocked(t.Elem(), inProgress)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			if field.Tag.Get(auditTag) == "redact" || hasSecretsLocked(field.Type, inProgress) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// auditingCaller wraps a MethodCaller so that its calls are recorded in
+// the audit log.
+type auditingCaller struct {
+	rpcreflect.MethodCaller
+	root    *auditRoot
+	facade  string
+	version int
+	method  string
+}
+
+// Call is part of the rpcreflect.MethodCaller interface.
+func (c *auditingCaller) Call(objId string, arg reflect.Value) (reflect.Value, error) {
+	result, err := c.MethodCaller.Call(objId, arg)
+	c.root.record(c.facade, c.version, c.method, arg, result, err)
+	return result, err
+}
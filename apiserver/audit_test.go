@@ -0,0 +1,210 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditRootSuite struct {
+	clock    *coretesting.Clock
+	recorder *fakeAuditRecorder
+	root     *auditRoot
+}
+
+var _ = gc.Suite(&auditRootSuite{})
+
+func (s *auditRootSuite) SetUpTest(c *gc.C) {
+	s.clock = coretesting.NewClock(time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.recorder = &fakeAuditRecorder{}
+	s.root = newAuditRoot(&fakeFinder{}, s.recorder, s.clock, names.NewUserTag("bob"))
+}
+
+func (s *auditRootSuite) call(c *gc.C, facade, method string, arg, result interface{}, err error) {
+	caller, findErr := s.root.FindMethod(facade, 1, method)
+	c.Assert(findErr, jc.ErrorIsNil)
+	if auditing, ok := caller.(*auditingCaller); ok {
+		auditing.MethodCaller = &resultCaller{result: result, err: err}
+	}
+	caller.Call("", reflect.ValueOf(arg))
+}
+
+func (s *auditRootSuite) TestRecordsCall(c *gc.C) {
+	s.call(c, "Service", "Expose", params.ServiceExpose{ServiceName: "mysql"}, nil, nil)
+	c.Assert(s.recorder.entries, gc.HasLen, 1)
+	entry := s.recorder.entries[0]
+	c.Check(entry.Time, gc.Equals, s.clock.Now())
+	c.Check(entry.User, gc.Equals, "bob@local")
+	c.Check(entry.Facade, gc.Equals, "Service")
+	c.Check(entry.Version, gc.Equals, 1)
+	c.Check(entry.Method, gc.Equals, "Expose")
+	c.Check(entry.Args, gc.Equals, `{"ServiceName":"mysql"}`)
+	c.Check(entry.Error, gc.Equals, "")
+}
+
+func (s *auditRootSuite) TestRecordsError(c *gc.C) {
+	s.call(c, "Service", "Expose", params.ServiceExpose{ServiceName: "mysql"}, nil, errors.New("boom"))
+	c.Assert(s.recorder.entries, gc.HasLen, 1)
+	c.Check(s.recorder.entries[0].Error, gc.Equals, "boom")
+}
+
+func (s *auditRootSuite) TestRecordsErrorResults(c *gc.C) {
+	results := params.ErrorResults{Results: []params.ErrorResult{{
+		Error: &params.Error{Message: "boom"},
+	}}}
+	s.call(c, "Service", "Expose", params.ServiceExpose{}, results, nil)
+	c.Assert(s.recorder.entries, gc.HasLen, 1)
+	c.Check(s.recorder.entries[0].Error, gc.Equals, "boom")
+}
+
+func (s *auditRootSuite) TestRedactsSecrets(c *gc.C) {
+	args := params.ServiceMetricCredentials{Creds: []params.ServiceMetricCredential{{
+		ServiceName:       "mysql",
+		MetricCredentials: []byte("sekrit"),
+	}}}
+	s.call(c, "Service", "SetMetricCredentials", args, nil, nil)
+	c.Assert(s.recorder.entries, gc.HasLen, 1)
+	c.Check(s.recorder.entries[0].Args, gc.Equals,
+		`{"Creds":[{"MetricCredentials":"(redacted)","ServiceName":"mysql"}]}`)
+}
+
+func (s *auditRootSuite) TestRedactsSecretsByJSONName(c *gc.C) {
+	args := params.AddUsers{Users: []params.AddUser{{
+		Username: "fred",
+		Password: "sekrit",
+	}, {
+		Username: "mary",
+	}}}
+	s.call(c, "UserManager", "AddUser", args, nil, nil)
+	c.Assert(s.recorder.entries, gc.HasLen, 1)
+	recorded := s.recorder.entries[0].Args
+	c.Check(recorded, jc.Contains, `"username":"fred"`)
+	c.Check(recorded, jc.Contains, `"password":"(redacted)"`)
+	c.Check(recorded, gc.Not(jc.Contains), "sekrit")
+	// Empty passwords are omitted, as they are when serialised.
+	c.Check(strings.Count(recorded, `"password"`), gc.Equals, 1)
+}
+
+func (s *auditRootSuite) TestRedactsUpdatedCredentials(c *gc.C) {
+	args := params.UpdateCloudCredentials{Credentials: []params.UpdateCloudCredential{{
+		OwnerTag:   "user-bob",
+		Cloud:      "aws",
+		Name:       "default",
+		AuthType:   "access-key",
+		Attributes: map[string]string{"secret-key": "sekrit"},
+	}}}
+	s.call(c, "ModelManager", "UpdateCloudCredentials", args, nil, nil)
+	c.Assert(s.recorder.entries, gc.HasLen, 1)
+	c.Check(s.recorder.entries[0].Args, jc.Contains, `"Attributes":"(redacted)"`)
+	c.Check(s.recorder.entries[0].Args, gc.Not(jc.Contains), "sekrit")
+}
+
+func (s *auditRootSuite) TestRedactsServiceSettings(c *gc.C) {
+	args := params.ServiceUpdate{
+		ServiceName:     "mysql",
+		SettingsStrings: map[string]string{"password": "sekrit"},
+		SettingsYAML:    "mysql:\n  password: sekrit\n",
+	}
+	s.call(c, "Service", "Update", args, nil, nil)
+	c.Assert(s.recorder.entries, gc.HasLen, 1)
+	c.Check(s.recorder.entries[0].Args, jc.Contains, `"ServiceName":"mysql"`)
+	c.Check(s.recorder.entries[0].Args, gc.Not(jc.Contains), "sekrit")
+}
+
+func (s *auditRootSuite) TestRecordsAgentCall(c *gc.C) {
+	s.root = newAuditRoot(&fakeFinder{}, s.recorder, s.clock, names.NewMachineTag("0"))
+	s.call(c, "Machiner", "SetStatus", params.SetStatus{}, nil, nil)
+	c.Assert(s.recorder.entries, gc.HasLen, 1)
+	c.Check(s.recorder.entries[0].User, gc.Equals, "machine-0")
+}
+
+// secretsCycle refers to secretsHolder, which holds a secret, only
+// through a type that refers back to it.
+type secretsCycle struct {
+	Next   *secretsCycle
+	Holder *secretsHolder
+}
+
+type secretsHolder struct {
+	Cycle  *secretsCycle
+	Secret string `audit:"redact"`
+}
+
+func (s *auditRootSuite) TestHasSecretsRecursive(c *gc.C) {
+	// secretsCycle is inspected while it's still in progress as
+	// part of secretsHolder; the incomplete result must not be
+	// cached.
+	c.Check(hasSecrets(reflect.TypeOf(secretsHolder{})), jc.IsTrue)
+	c.Check(hasSecrets(reflect.TypeOf(secretsCycle{})), jc.IsTrue)
+}
+
+func (s *auditRootSuite) TestIgnoresReadOnlyCalls(c *gc.C) {
+	s.call(c, "Client", "FullStatus", params.StatusParams{}, nil, nil)
+	c.Assert(s.recorder.entries, gc.HasLen, 0)
+}
+
+func (s *auditRootSuite) TestIsCallAudited(c *gc.C) {
+	for _, test := range []struct {
+		facade  string
+		method  string
+		audited bool
+	}{
+		{"Service", "Deploy", true},
+		{"UserManager", "AddUser", true},
+		{"Client", "FullStatus", false},
+		{"Admin", "Login", false},
+		{"Pinger", "Ping", false},
+		{"AllWatcher", "Next", false},
+		{"NotifyWatcher", "Stop", false},
+	} {
+		c.Logf("check %s.%s", test.facade, test.method)
+		c.Check(isCallAudited(test.facade, test.method), gc.Equals, test.audited)
+	}
+}
+
+func (s *auditRootSuite) TestRecorderFailureDoesNotFailCall(c *gc.C) {
+	s.recorder.err = errors.New("no audit for you")
+	caller, err := s.root.FindMethod("Service", 1, "Expose")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = caller.Call("", reflect.ValueOf(params.ServiceExpose{}))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+type fakeAuditRecorder struct {
+	entries []state.AuditEntry
+	err     error
+}
+
+func (r *fakeAuditRecorder) AddAuditEntry(entry state.AuditEntry) error {
+	r.entries = append(r.entries, entry)
+	return r.err
+}
+
+// resultCaller is a rpcreflect.MethodCaller returning a fixed result.
+type resultCaller struct {
+	fakeCaller
+	result interface{}
+	err    error
+}
+
+var _ rpcreflect.MethodCaller = (*resultCaller)(nil)
+
+func (r *resultCaller) Call(_ string, _ reflect.Value) (reflect.Value, error) {
+	if r.result == nil {
+		return reflect.Value{}, r.err
+	}
+	return reflect.ValueOf(r.result), r.err
+}
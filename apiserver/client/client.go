@@ -77,8 +77,15 @@ func NewClient(st *state.State, resources *common.Resources, authorizer common.A
 	return client, nil
 }
 
-func (c *Client) WatchAll() (params.AllWatcherId, error) {
-	w := c.api.stateAccessor.Watch()
+// WatchAll starts watching changes to the model that match the given
+// filter. The returned AllWatcherId should be used with Next on the
+// AllWatcher endpoint to receive deltas.
+func (c *Client) WatchAll(args params.AllWatcherFilter) (params.AllWatcherId, error) {
+	filter, err := common.MultiwatcherFilter(args)
+	if err != nil {
+		return params.AllWatcherId{}, errors.Trace(err)
+	}
+	w := c.api.stateAccessor.WatchFiltered(filter)
 	return params.AllWatcherId{
 		AllWatcherId: c.api.resources.Register(w),
 	}, nil
@@ -460,6 +467,32 @@ func (c *Client) SetModelAgentVersion(args params.SetModelAgentVersion) error {
 	return c.api.stateAccessor.SetModelAgentVersion(args.Version)
 }
 
+// SetMachineAgentVersions sets the target agent version of each of
+// the given machines, so that their agents are upgraded ahead of the
+// rest of the model. This allows an upgrade to be staged, machine by
+// machine, before the model agent version is finally set.
+func (c *Client) SetMachineAgentVersions(args params.SetMachineAgentVersions) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Machines)),
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Machines {
+		tag, err := names.ParseMachineTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		machine, err := c.api.stateAccessor.Machine(tag.Id())
+		if err == nil {
+			err = machine.SetTargetAgentVersion(args.Version)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
 var getEnvironment = func(cfg *config.Config) (environs.Environ, error) {
 	env, err := environs.New(cfg)
 	if err != nil {
@@ -477,6 +510,24 @@ func (c *Client) AbortCurrentUpgrade() error {
 	return c.api.stateAccessor.AbortCurrentUpgrade()
 }
 
+// RollbackUpgrade abandons an upgrade that has not completed and sets
+// the model agent version back to the version being upgraded from.
+// Agents that have already upgraded will then revert to their
+// previous tools.
+func (c *Client) RollbackUpgrade() (params.RollbackUpgradeResult, error) {
+	if err := c.check.ChangeAllowed(); err != nil {
+		return params.RollbackUpgradeResult{}, errors.Trace(err)
+	}
+	info, err := c.api.stateAccessor.RollbackUpgrade()
+	if err != nil {
+		return params.RollbackUpgradeResult{}, errors.Trace(err)
+	}
+	return params.RollbackUpgradeResult{
+		PreviousVersion: info.PreviousVersion(),
+		SnapshotID:      info.SnapshotID(),
+	}, nil
+}
+
 // FindTools returns a List containing all tools matching the given parameters.
 func (c *Client) FindTools(args params.FindToolsParams) (params.FindToolsResult, error) {
 	return c.api.toolsFinder.FindTools(args)
@@ -178,6 +178,44 @@ func (s *serverSuite) TestSetEnvironAgentVersion(c *gc.C) {
 	c.Assert(agentVersion, gc.Equals, "9.8.7")
 }
 
+func (s *serverSuite) TestSetMachineAgentVersions(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	newer := jujuversion.Current
+	newer.Patch++
+	results, err := s.client.SetMachineAgentVersions(params.SetMachineAgentVersions{
+		Version: newer,
+		Machines: []params.Entity{
+			{Tag: machine.Tag().String()},
+			{Tag: "machine-42"},
+			{Tag: "unit-foo-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "machine 42 not found", Code: params.CodeNotFound}},
+			{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+		},
+	})
+
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	vers, ok := machine.TargetAgentVersion()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(vers, gc.Equals, newer)
+}
+
+func (s *serverSuite) TestBlockChangesSetMachineAgentVersions(c *gc.C) {
+	s.BlockAllChanges(c, "TestBlockChangesSetMachineAgentVersions")
+	_, err := s.client.SetMachineAgentVersions(params.SetMachineAgentVersions{
+		Version:  version.MustParse("9.8.7"),
+		Machines: []params.Entity{{Tag: "machine-0"}},
+	})
+	s.AssertBlocked(c, err, "TestBlockChangesSetMachineAgentVersions")
+}
+
 type mockEnviron struct {
 	environs.Environ
 	allInstancesCalled bool
@@ -329,6 +367,50 @@ func (s *serverSuite) TestBlockChangesAbortCurrentUpgrade(c *gc.C) {
 	s.assertAbortCurrentUpgradeBlocked(c, "TestBlockChangesAbortCurrentUpgrade")
 }
 
+func (s *serverSuite) TestRollbackUpgrade(c *gc.C) {
+	// Create a provisioned controller.
+	machine, err := s.State.AddMachine("series", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProvisioned(instance.Id("i-blah"), "fake-nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Start an upgrade that does not complete.
+	err = s.State.UpdateModelConfig(map[string]interface{}{"agent-version": "9.8.7"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := s.State.EnsureUpgradeInfo(machine.Id(), version.MustParse("1.2.3"), version.MustParse("9.8.7"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = info.SetSnapshotID("backup-id")
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.client.RollbackUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.RollbackUpgradeResult{
+		PreviousVersion: version.MustParse("1.2.3"),
+		SnapshotID:      "backup-id",
+	})
+
+	isUpgrading, err := s.State.IsUpgrading()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(isUpgrading, jc.IsFalse)
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	agentVersion, ok := cfg.AgentVersion()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(agentVersion, gc.Equals, version.MustParse("1.2.3"))
+}
+
+func (s *serverSuite) TestRollbackUpgradeNotUpgrading(c *gc.C) {
+	_, err := s.client.RollbackUpgrade()
+	c.Assert(err, gc.ErrorMatches, "cannot roll back upgrade: no incomplete upgrade to roll back")
+}
+
+func (s *serverSuite) TestBlockChangesRollbackUpgrade(c *gc.C) {
+	s.setupAbortCurrentUpgradeBlocked(c)
+	s.BlockAllChanges(c, "TestBlockChangesRollbackUpgrade")
+	_, err := s.client.RollbackUpgrade()
+	s.AssertBlocked(c, err, "TestBlockChangesRollbackUpgrade")
+}
+
 type clientSuite struct {
 	baseSuite
 }
@@ -663,6 +745,37 @@ func (s *clientSuite) TestClientWatchAll(c *gc.C) {
 	}
 }
 
+func (s *clientSuite) TestClientWatchAllFiltered(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	watcher, err := s.APIState.Client().WatchAllFiltered(params.AllWatcherFilter{
+		Kinds: []string{"service"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		err := watcher.Stop()
+		c.Assert(err, jc.ErrorIsNil)
+	}()
+	deltas, err := watcher.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deltas, gc.HasLen, 0)
+
+	service := s.Factory.MakeService(c, nil)
+	deltas, err = watcher.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deltas, gc.HasLen, 1)
+	info, ok := deltas[0].Entity.(*multiwatcher.ServiceInfo)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(info.Name, gc.Equals, service.Name())
+}
+
+func (s *clientSuite) TestClientWatchAllInvalidFilter(c *gc.C) {
+	_, err := s.APIState.Client().WatchAllFiltered(params.AllWatcherFilter{
+		Kinds: []string{"gadget"},
+	})
+	c.Assert(err, gc.ErrorMatches, `entity kind "gadget" not valid`)
+}
+
 func (s *clientSuite) TestClientSetModelConstraints(c *gc.C) {
 	// Set constraints for the model.
 	cons, err := constraints.Parse("mem=4096", "cpu-cores=2")
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/status"
 )
 
@@ -38,6 +39,7 @@ type stateInterface interface {
 	AllMachines() ([]*state.Machine, error)
 	AllServices() ([]*state.Service, error)
 	AllRelations() ([]*state.Relation, error)
+	AllRemoteServices() ([]*state.RemoteService, error)
 	AddOneMachine(state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideMachine(state.MachineTemplate, string, instance.ContainerType) (*state.Machine, error)
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
@@ -60,8 +62,11 @@ type stateInterface interface {
 	AddModelUser(state.ModelUserSpec) (*state.ModelUser, error)
 	RemoveModelUser(names.UserTag) error
 	Watch() *state.Multiwatcher
+	WatchFiltered(multiwatcher.Filter) *state.Multiwatcher
 	AbortCurrentUpgrade() error
+	RollbackUpgrade() (*state.UpgradeInfo, error)
 	APIHostPorts() ([][]network.HostPort, error)
+	ControllerCertificates() (state.ControllerCertificates, error)
 }
 
 type stateShim struct {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/set"
@@ -14,6 +15,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cert"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -166,6 +168,8 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		return noStatus, errors.Annotate(err, "could not fetch machines")
 	} else if context.relations, err = fetchRelations(c.api.stateAccessor); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch relations")
+	} else if context.remoteServices, err = fetchRemoteServices(c.api.stateAccessor); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch remote services")
 	}
 
 	logger.Debugf("Services: %v", context.services)
@@ -234,6 +238,26 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 			}
 		}
 
+		// Filter remote services, keeping those related to the
+		// remaining services.
+		for name := range context.remoteServices {
+			related := false
+			for _, relation := range context.relations[name] {
+				eps, err := relation.RelatedEndpoints(name)
+				if err != nil {
+					return noStatus, errors.Annotate(err, "could not filter remote services")
+				}
+				for _, ep := range eps {
+					if _, ok := context.services[ep.ServiceName]; ok {
+						related = true
+					}
+				}
+			}
+			if !related {
+				delete(context.remoteServices, name)
+			}
+		}
+
 		// Filter machines
 		for status, machineList := range context.machines {
 			matched := make([]*state.Machine, 0, len(machineList))
@@ -263,15 +287,59 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	if err != nil {
 		return noStatus, errors.Annotate(err, "cannot determine mongo information")
 	}
+	certWarnings, err := c.certificateWarnings()
+	if err != nil {
+		return noStatus, errors.Annotate(err, "cannot check controller certificates")
+	}
 	return params.FullStatus{
-		ModelName:        cfg.Name(),
-		AvailableVersion: newToolsVersion,
-		Machines:         processMachines(context.machines),
-		Services:         context.processServices(),
-		Relations:        context.processRelations(),
+		ModelName:           cfg.Name(),
+		AvailableVersion:    newToolsVersion,
+		Machines:            processMachines(context.machines),
+		Services:            context.processServices(),
+		Relations:           context.processRelations(),
+		RemoteServices:      context.processRemoteServices(),
+		CertificateWarnings: certWarnings,
 	}, nil
 }
 
+// certificateExpiryWarningPeriod is how long before a controller
+// certificate expires that status starts warning about it.
+const certificateExpiryWarningPeriod = 30 * 24 * time.Hour
+
+// certificateWarnings returns warnings for each of the controller's
+// trusted CA certificates that has expired or will expire soon.
+func (c *Client) certificateWarnings() ([]string, error) {
+	certs, err := c.api.stateAccessor.ControllerCertificates()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	now := time.Now()
+	var warnings []string
+	check := func(what, certPEM string) error {
+		caCert, err := cert.ParseCert(certPEM)
+		if err != nil {
+			return errors.Annotatef(err, "cannot parse %s", what)
+		}
+		expiry := caCert.NotAfter.UTC().Format(time.RFC3339)
+		switch {
+		case !now.Before(caCert.NotAfter):
+			warnings = append(warnings, fmt.Sprintf("%s expired at %s", what, expiry))
+		case caCert.NotAfter.Sub(now) < certificateExpiryWarningPeriod:
+			warnings = append(warnings, fmt.Sprintf("%s expires at %s", what, expiry))
+		}
+		return nil
+	}
+	if err := check("controller CA certificate", certs.CACert); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if certs.InTransition(now) {
+		if err := check("previous controller CA certificate", certs.PreviousCACert); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return warnings, nil
+}
+
 // newToolsVersionAvailable will return a string representing a tools
 // version only if the latest check is newer than current tools.
 func (c *Client) newToolsVersionAvailable() (string, error) {
@@ -305,6 +373,8 @@ type statusContext struct {
 	relations    map[string][]*state.Relation
 	units        map[string]map[string]*state.Unit
 	latestCharms map[charm.URL]*state.Charm
+	// remoteServices: remote service name -> remote service
+	remoteServices map[string]*state.RemoteService
 }
 
 // fetchMachines returns a map from top level machine id to machines, where machines[0] is the host
@@ -407,6 +477,19 @@ func fetchRelations(st stateInterface) (map[string][]*state.Relation, error) {
 	return out, nil
 }
 
+// fetchRemoteServices returns a map of all remote services keyed by name.
+func fetchRemoteServices(st stateInterface) (map[string]*state.RemoteService, error) {
+	remoteServices, err := st.AllRemoteServices()
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]*state.RemoteService)
+	for _, remote := range remoteServices {
+		out[remote.Name()] = remote
+	}
+	return out, nil
+}
+
 type machineAndContainers map[string][]*state.Machine
 
 func (m machineAndContainers) HostForMachineId(id string) *state.Machine {
@@ -513,6 +596,7 @@ func (context *statusContext) processRelations() []params.RelationStatus {
 			Interface: relationInterface,
 			Scope:     scope,
 			Endpoints: eps,
+			Suspended: relation.Suspended(),
 		}
 		out = append(out, relStatus)
 	}
@@ -601,6 +685,48 @@ func (context *statusContext) processService(service *state.Service) params.Serv
 	return processedStatus
 }
 
+func (context *statusContext) processRemoteServices() map[string]params.RemoteServiceStatus {
+	remoteServicesMap := make(map[string]params.RemoteServiceStatus)
+	for _, remote := range context.remoteServices {
+		remoteServicesMap[remote.Name()] = context.processRemoteService(remote)
+	}
+	return remoteServicesMap
+}
+
+func (context *statusContext) processRemoteService(remote *state.RemoteService) (out params.RemoteServiceStatus) {
+	out.URL = remote.URL()
+	out.SourceModelTag = remote.SourceModel().String()
+	out.SourceServiceName = remote.SourceServiceName()
+	for _, ep := range remote.Endpoints() {
+		out.Endpoints = append(out.Endpoints, params.RemoteEndpoint{
+			Name:      ep.Name,
+			Role:      ep.Role,
+			Interface: ep.Interface,
+		})
+	}
+	out.Life = processLife(remote)
+	out.Relations = make(map[string][]string)
+	for _, relation := range context.relations[remote.Name()] {
+		ep, err := relation.Endpoint(remote.Name())
+		if err != nil {
+			out.Err = err
+			return
+		}
+		eps, err := relation.RelatedEndpoints(remote.Name())
+		if err != nil {
+			out.Err = err
+			return
+		}
+		for _, related := range eps {
+			out.Relations[ep.Name] = append(out.Relations[ep.Name], related.ServiceName)
+		}
+	}
+	for name, serviceNames := range out.Relations {
+		out.Relations[name] = set.NewStrings(serviceNames...).SortedValues()
+	}
+	return out
+}
+
 func isColorStatus(code state.MeterStatusCode) bool {
 	return code == state.MeterGreen || code == state.MeterAmber || code == state.MeterRed
 }
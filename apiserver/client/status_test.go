@@ -4,6 +4,8 @@
 package client_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/cert"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
@@ -48,6 +51,21 @@ func (s *statusSuite) TestFullStatus(c *gc.C) {
 	}
 	c.Check(resultMachine.Id, gc.Equals, machine.Id())
 	c.Check(resultMachine.Series, gc.Equals, machine.Series())
+	c.Check(status.CertificateWarnings, gc.HasLen, 0)
+}
+
+func (s *statusSuite) TestFullStatusCertificateWarnings(c *gc.C) {
+	expiry := time.Now().AddDate(0, 0, 10)
+	caCert, caKey, err := cert.NewCA("juju testing", "1234", expiry)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ReplaceControllerCA(caCert, caKey, "", time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.APIState.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.CertificateWarnings, jc.DeepEquals, []string{
+		"controller CA certificate expires at " + expiry.UTC().Format(time.RFC3339),
+	})
 }
 
 var _ = gc.Suite(&statusUnitTestSuite{})
@@ -184,6 +184,31 @@ func (s *actionsSuite) TestFinishActions(c *gc.C) {
 	})
 }
 
+func (s *actionsSuite) TestLogActionsMessages(c *gc.C) {
+	args := params.ActionMessageParams{
+		[]params.ActionMessageParam{
+			{Tag: "success", Message: "hello"},
+			{Tag: "notfound", Message: "hello"},
+			{Tag: "logFail", Message: "hello"},
+		},
+	}
+	expectErr := errors.New("explosivo")
+	logged := []string{}
+	actionFn := makeGetActionByTagString(map[string]state.Action{
+		"success": fakeAction{logged: &logged},
+		"logFail": fakeAction{logErr: expectErr},
+	})
+	results := common.LogActionsMessages(args, actionFn)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		[]params.ErrorResult{
+			{},
+			{common.ServerError(actionNotFoundErr)},
+			{common.ServerError(expectErr)},
+		},
+	})
+	c.Assert(logged, jc.DeepEquals, []string{"hello"})
+}
+
 func (s *actionsSuite) TestWatchActionNotifications(c *gc.C) {
 	args := entities("invalid-actionreceiver", "machine-1", "machine-2", "machine-3")
 	canAccess := makeCanAccess(map[names.Tag]bool{
@@ -333,6 +358,8 @@ type fakeAction struct {
 	name      string
 	beginErr  error
 	finishErr error
+	logErr    error
+	logged    *[]string
 	status    state.ActionStatus
 }
 
@@ -356,6 +383,14 @@ func (mock fakeAction) Parameters() map[string]interface{} {
 	return nil
 }
 
+func (mock fakeAction) Log(message string) error {
+	if mock.logErr != nil {
+		return mock.logErr
+	}
+	*mock.logged = append(*mock.logged, message)
+	return nil
+}
+
 func (mock fakeAction) Finish(state.ActionResults) (state.Action, error) {
 	return nil, mock.finishErr
 }
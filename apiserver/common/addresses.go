@@ -19,6 +19,7 @@ type AddressAndCertGetter interface {
 	ModelUUID() string
 	APIHostPorts() ([][]network.HostPort, error)
 	WatchAPIHostPorts() state.NotifyWatcher
+	WatchControllerCertificates() state.NotifyWatcher
 }
 
 // APIAddresser implements the APIAddresses method
@@ -47,9 +48,13 @@ func (api *APIAddresser) APIHostPorts() (params.APIHostPortsResult, error) {
 	}, nil
 }
 
-// WatchAPIHostPorts watches the API server addresses.
+// WatchAPIHostPorts watches the API server addresses, and the CA
+// certificates used to validate connections to them.
 func (api *APIAddresser) WatchAPIHostPorts() (params.NotifyWatchResult, error) {
-	watch := api.getter.WatchAPIHostPorts()
+	watch := NewMultiNotifyWatcher(
+		api.getter.WatchAPIHostPorts(),
+		api.getter.WatchControllerCertificates(),
+	)
 	if _, ok := <-watch.Changes(); ok {
 		return params.NotifyWatchResult{
 			NotifyWatcherId: api.resources.Register(watch),
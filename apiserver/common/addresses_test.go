@@ -127,3 +127,7 @@ func (f fakeAddresses) APIHostPorts() ([][]network.HostPort, error) {
 func (fakeAddresses) WatchAPIHostPorts() state.NotifyWatcher {
 	panic("should never be called")
 }
+
+func (fakeAddresses) WatchControllerCertificates() state.NotifyWatcher {
+	panic("should never be called")
+}
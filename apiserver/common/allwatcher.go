@@ -0,0 +1,26 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// MultiwatcherFilter returns the multiwatcher filter described by the
+// given parameters, or an error if they are not valid.
+func MultiwatcherFilter(args params.AllWatcherFilter) (multiwatcher.Filter, error) {
+	filter := multiwatcher.Filter{
+		Kinds:      args.Kinds,
+		ModelUUIDs: args.ModelUUIDs,
+		Services:   args.Services,
+		Fields:     args.Fields,
+	}
+	if err := filter.Validate(); err != nil {
+		return multiwatcher.Filter{}, errors.Trace(err)
+	}
+	return filter, nil
+}
@@ -96,6 +96,7 @@ var (
 	ErrStoppedWatcher     = errors.New("watcher has been stopped")
 	ErrBadRequest         = errors.New("invalid request")
 	ErrTryAgain           = errors.New("try again")
+	ErrRetryAfter         = errors.New("server busy, retry after a delay")
 	ErrActionNotAvailable = errors.New("action no longer available")
 )
 
@@ -127,6 +128,7 @@ var singletonErrorCodes = map[error]string{
 	ErrUnknownWatcher:            params.CodeNotFound,
 	ErrStoppedWatcher:            params.CodeStopped,
 	ErrTryAgain:                  params.CodeTryAgain,
+	ErrRetryAfter:                params.CodeRetryAfter,
 	ErrActionNotAvailable:        params.CodeActionNotAvailable,
 }
 
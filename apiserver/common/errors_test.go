@@ -128,6 +128,11 @@ var errorTransformTests = []struct {
 	code:       params.CodeTryAgain,
 	status:     http.StatusInternalServerError,
 	helperFunc: params.IsCodeTryAgain,
+}, {
+	err:        common.ErrRetryAfter,
+	code:       params.CodeRetryAfter,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodeRetryAfter,
 }, {
 	err:        leadership.ErrClaimDenied,
 	code:       params.CodeLeadershipClaimDenied,
@@ -113,6 +113,23 @@ func (t *ToolsGetter) getGlobalAgentVersion() (version.Number, error) {
 	return agentVersion, nil
 }
 
+type hasTargetAgentVersion interface {
+	TargetAgentVersion() (version.Number, bool)
+}
+
+// TargetAgentVersion returns the agent version that the entity should
+// run, given the model's agent version. An entity with a newer target
+// agent version, such as a machine that is part of a staged upgrade,
+// should run its target version instead.
+func TargetAgentVersion(entity state.Entity, agentVersion version.Number) version.Number {
+	if e, ok := entity.(hasTargetAgentVersion); ok {
+		if target, ok := e.TargetAgentVersion(); ok && target.Compare(agentVersion) > 0 {
+			return target
+		}
+	}
+	return agentVersion
+}
+
 func (t *ToolsGetter) oneAgentTools(canRead AuthFunc, tag names.Tag, agentVersion version.Number, storage binarystorage.Storage) (coretools.List, error) {
 	if !canRead(tag) {
 		return nil, ErrPerm
@@ -125,6 +142,7 @@ func (t *ToolsGetter) oneAgentTools(canRead AuthFunc, tag names.Tag, agentVersio
 	if !ok {
 		return nil, NotSupportedError(tag, "agent tools")
 	}
+	agentVersion = TargetAgentVersion(entity, agentVersion)
 	existingTools, err := tooler.AgentTools()
 	if err != nil {
 		return nil, err
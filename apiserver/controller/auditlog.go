@@ -0,0 +1,72 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AuditLog returns the API calls, made against any model in the
+// controller, recorded in the audit log and matching the filter.
+func (s *ControllerAPI) AuditLog(args params.AuditLogFilter) (params.AuditLogResults, error) {
+	var result params.AuditLogResults
+	filter := state.AuditLogFilter{
+		Facade: args.Facade,
+		Method: args.Method,
+		Limit:  args.Limit,
+	}
+	if args.ModelTag != "" {
+		modelTag, err := names.ParseModelTag(args.ModelTag)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		filter.ModelUUID = modelTag.Id()
+	}
+	if args.UserTag != "" {
+		userTag, err := names.ParseUserTag(args.UserTag)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		filter.User = userTag.Canonical()
+	}
+	if args.From != nil {
+		filter.From = *args.From
+	}
+	if args.To != nil {
+		filter.To = *args.To
+	}
+
+	entries, err := s.state.AuditLog(filter)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Entries = make([]params.AuditLogEntry, len(entries))
+	for i, entry := range entries {
+		result.Entries[i] = params.AuditLogEntry{
+			ModelTag: names.NewModelTag(entry.ModelUUID).String(),
+			Time:     entry.Time,
+			UserTag:  auditUserTag(entry.User).String(),
+			Facade:   entry.Facade,
+			Version:  entry.Version,
+			Method:   entry.Method,
+			Args:     entry.Args,
+			Error:    entry.Error,
+		}
+	}
+	return result, nil
+}
+
+// auditUserTag returns the tag of the user or agent recorded as having
+// made a call. Agents are recorded by tag, and users by canonical
+// name, which always includes a domain and so never parses as a tag.
+func auditUserTag(user string) names.Tag {
+	if tag, err := names.ParseTag(user); err == nil {
+		return tag
+	}
+	return names.NewUserTag(user)
+}
@@ -0,0 +1,99 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+func (s *controllerSuite) addAuditEntries(c *gc.C, t0 time.Time) {
+	for i, entry := range []state.AuditEntry{{
+		Time:   t0,
+		User:   "bob@local",
+		Facade: "Service",
+		Method: "Deploy",
+		Args:   `{"Services":[]}`,
+	}, {
+		Time:   t0.Add(time.Minute),
+		User:   "mary@local",
+		Facade: "Service",
+		Method: "Destroy",
+		Error:  "boom",
+	}, {
+		Time:   t0.Add(2 * time.Minute),
+		User:   "bob@local",
+		Facade: "Client",
+		Method: "AddMachines",
+	}} {
+		entry.Version = 1
+		err := s.State.AddAuditEntry(entry)
+		c.Assert(err, jc.ErrorIsNil, gc.Commentf("entry %d", i))
+	}
+}
+
+func (s *controllerSuite) TestAuditLog(c *gc.C) {
+	t0 := time.Date(2016, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.addAuditEntries(c, t0)
+
+	result, err := s.controller.AuditLog(params.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entries, gc.HasLen, 3)
+	c.Assert(result.Entries[1], jc.DeepEquals, params.AuditLogEntry{
+		ModelTag: s.State.ModelTag().String(),
+		Time:     t0.Add(time.Minute),
+		UserTag:  "user-mary@local",
+		Facade:   "Service",
+		Version:  1,
+		Method:   "Destroy",
+		Error:    "boom",
+	})
+}
+
+func (s *controllerSuite) TestAuditLogAgent(c *gc.C) {
+	err := s.State.AddAuditEntry(state.AuditEntry{
+		Time:    time.Date(2016, 5, 1, 12, 0, 0, 0, time.UTC),
+		User:    "machine-0",
+		Facade:  "Machiner",
+		Version: 1,
+		Method:  "SetStatus",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.controller.AuditLog(params.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entries, gc.HasLen, 1)
+	c.Assert(result.Entries[0].UserTag, gc.Equals, "machine-0")
+}
+
+func (s *controllerSuite) TestAuditLogFiltered(c *gc.C) {
+	t0 := time.Date(2016, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.addAuditEntries(c, t0)
+
+	from := t0.Add(time.Second)
+	result, err := s.controller.AuditLog(params.AuditLogFilter{
+		ModelTag: s.State.ModelTag().String(),
+		UserTag:  "user-bob",
+		From:     &from,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entries, gc.HasLen, 1)
+	c.Assert(result.Entries[0].Method, gc.Equals, "AddMachines")
+
+	result, err = s.controller.AuditLog(params.AuditLogFilter{Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entries, gc.HasLen, 2)
+	c.Assert(result.Entries[0].Method, gc.Equals, "Destroy")
+	c.Assert(result.Entries[1].Method, gc.Equals, "AddMachines")
+}
+
+func (s *controllerSuite) TestAuditLogInvalidUser(c *gc.C) {
+	_, err := s.controller.AuditLog(params.AuditLogFilter{UserTag: "machine-0"})
+	c.Assert(err, gc.ErrorMatches, `"machine-0" is not a valid user tag`)
+}
@@ -0,0 +1,165 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cert"
+	"github.com/juju/juju/environs/config"
+)
+
+// defaultCATransitionPeriod is how long a replaced CA remains trusted
+// when no transition period is specified.
+const defaultCATransitionPeriod = 7 * 24 * time.Hour
+
+// RotateControllerCertificates causes every controller to regenerate
+// its server certificate. If the args specify a new CA, the
+// controller's CA is replaced first; the previous CA remains trusted
+// for the transition period, during which the controllers present the
+// new CA cross-signed by the previous one.
+func (s *ControllerAPI) RotateControllerCertificates(
+	args params.RotateControllerCertificatesArgs,
+) (params.RotateControllerCertificatesResult, error) {
+	var result params.RotateControllerCertificatesResult
+	if args.NewCA {
+		if err := s.replaceControllerCA(args.TransitionPeriod); err != nil {
+			return result, errors.Trace(err)
+		}
+	} else if err := s.state.RotateControllerCertificates(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	certs, err := s.state.ControllerCertificates()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	now := time.Now()
+	result.CACert = certs.TrustedCACerts(now)
+	if certs.InTransition(now) {
+		transitionEnd := certs.TransitionEnd
+		result.TransitionEnd = &transitionEnd
+	}
+	return result, nil
+}
+
+// replaceControllerCA replaces the controller's CA, and updates every
+// model's config to record the new CA certificate. If a previous
+// replacement is still in transition, no new CA is generated; instead,
+// any models whose config does not yet record the current CA are
+// updated, so that a call which failed part way through can be
+// retried.
+func (s *ControllerAPI) replaceControllerCA(transitionPeriod time.Duration) error {
+	if transitionPeriod < 0 {
+		return errors.NotValidf("negative transition period")
+	}
+	if transitionPeriod == 0 {
+		transitionPeriod = defaultCATransitionPeriod
+	}
+	certs, err := s.state.ControllerCertificates()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := time.Now()
+	if certs.InTransition(now) {
+		updated, err := s.updateModelCACerts(certs.CACert)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if updated == 0 {
+			return errors.Errorf(
+				"cannot replace controller CA: previous CA still trusted until %s",
+				certs.TransitionEnd.Format(time.RFC3339),
+			)
+		}
+		logger.Infof("completed replacement of controller CA, updated %d models", updated)
+		return nil
+	}
+
+	info, err := s.state.StateServingInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if info.CAPrivateKey == "" {
+		return errors.New("controller has no CA private key, cannot replace CA")
+	}
+	oldCACert, err := cert.ParseCert(certs.CACert)
+	if err != nil {
+		return errors.Annotate(err, "cannot parse CA certificate")
+	}
+
+	controllerModel, err := s.state.ControllerModel()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The new CA is valid for as long as the previous one was.
+	expiry := now.Add(oldCACert.NotAfter.Sub(oldCACert.NotBefore))
+	newCACert, newCAKey, err := cert.NewCA(controllerModel.Name(), controllerModel.UUID(), expiry)
+	if err != nil {
+		return errors.Annotate(err, "cannot generate CA certificate")
+	}
+	crossSigned, err := cert.CrossSign(newCACert, certs.CACert, info.CAPrivateKey)
+	if err != nil {
+		return errors.Annotate(err, "cannot cross-sign CA certificate")
+	}
+	err = s.state.ReplaceControllerCA(newCACert, newCAKey, crossSigned, now.Add(transitionPeriod))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = s.updateModelCACerts(newCACert)
+	return errors.Trace(err)
+}
+
+// updateModelCACerts records the given CA certificate in the config
+// of every model that does not already record it, and returns the
+// number of models updated. Every model's config records the
+// controller's CA certificate, and new models are created with the
+// controller model's, so they must all be kept up to date.
+func (s *ControllerAPI) updateModelCACerts(caCert string) (int, error) {
+	models, err := s.state.AllModels()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	updated := 0
+	for _, model := range models {
+		ok, err := s.updateModelCACert(model.ModelTag(), caCert)
+		if err != nil {
+			return updated, errors.Annotatef(err, "cannot update model %q config", model.Name())
+		}
+		if ok {
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+// updateModelCACert records the given CA certificate in the config of
+// the model with the given tag, and reports whether it needed to.
+func (s *ControllerAPI) updateModelCACert(tag names.ModelTag, caCert string) (bool, error) {
+	st := s.state
+	if tag != s.state.ModelTag() {
+		var err error
+		st, err = s.state.ForModel(tag)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		defer st.Close()
+	}
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if current, _ := cfg.CACert(); current == caCert {
+		return false, nil
+	}
+	attrs := map[string]interface{}{config.CACertKey: caCert}
+	if err := st.UpdateModelConfig(attrs, nil, nil); err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
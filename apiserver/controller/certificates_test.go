@@ -0,0 +1,128 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cert"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *controllerSuite) TestRotateControllerCertificates(c *gc.C) {
+	result, err := s.controller.RotateControllerCertificates(params.RotateControllerCertificatesArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.RotateControllerCertificatesResult{
+		CACert: testing.CACert,
+	})
+
+	certs, err := s.State.ControllerCertificates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(certs.CACert, gc.Equals, testing.CACert)
+	c.Assert(certs.Serial, gc.Equals, 1)
+}
+
+func (s *controllerSuite) TestRotateControllerCertificatesNewCA(c *gc.C) {
+	hostedSt := s.Factory.MakeModel(c, &factory.ModelParams{Name: "hosted"})
+	defer hostedSt.Close()
+
+	before := time.Now()
+	result, err := s.controller.RotateControllerCertificates(params.RotateControllerCertificatesArgs{
+		NewCA:            true,
+		TransitionPeriod: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	certs, err := s.State.ControllerCertificates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(certs.CACert, gc.Not(gc.Equals), testing.CACert)
+	c.Assert(certs.PreviousCACert, gc.Equals, testing.CACert)
+	c.Assert(result.CACert, gc.Equals, certs.CACert+testing.CACert)
+	c.Assert(result.TransitionEnd, gc.NotNil)
+	c.Assert(result.TransitionEnd.After(before.Add(time.Hour-time.Second)), jc.IsTrue)
+
+	// The new CA is signed by the old one in the cross-signed certificate.
+	crossSigned, err := cert.ParseCert(certs.CrossSignedCACert)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(crossSigned.CheckSignatureFrom(testing.CACertX509), jc.ErrorIsNil)
+
+	// The new CA's key is made available to the controllers.
+	info, err := s.State.StateServingInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	_, _, err = cert.ParseCertAndKey(certs.CACert, info.CAPrivateKey)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The controller model's config is updated...
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	caCert, _ := cfg.CACert()
+	c.Assert(caCert, gc.Equals, certs.CACert)
+
+	// And so is every hosted model's.
+	cfg, err = hostedSt.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	caCert, _ = cfg.CACert()
+	c.Assert(caCert, gc.Equals, certs.CACert)
+}
+
+func (s *controllerSuite) TestRotateControllerCertificatesNegativeTransition(c *gc.C) {
+	_, err := s.controller.RotateControllerCertificates(params.RotateControllerCertificatesArgs{
+		NewCA:            true,
+		TransitionPeriod: -time.Hour,
+	})
+	c.Assert(err, gc.ErrorMatches, "negative transition period not valid")
+}
+
+func (s *controllerSuite) TestRotateControllerCertificatesNewCAInTransition(c *gc.C) {
+	args := params.RotateControllerCertificatesArgs{
+		NewCA:            true,
+		TransitionPeriod: time.Hour,
+	}
+	_, err := s.controller.RotateControllerCertificates(args)
+	c.Assert(err, jc.ErrorIsNil)
+	certs, err := s.State.ControllerCertificates()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.controller.RotateControllerCertificates(args)
+	c.Assert(err, gc.ErrorMatches, "cannot replace controller CA: previous CA still trusted until .*")
+	after, err := s.State.ControllerCertificates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(after, jc.DeepEquals, certs)
+}
+
+func (s *controllerSuite) TestRotateControllerCertificatesNewCAResumed(c *gc.C) {
+	hostedSt := s.Factory.MakeModel(c, &factory.ModelParams{Name: "hosted"})
+	defer hostedSt.Close()
+	args := params.RotateControllerCertificatesArgs{
+		NewCA:            true,
+		TransitionPeriod: time.Hour,
+	}
+	_, err := s.controller.RotateControllerCertificates(args)
+	c.Assert(err, jc.ErrorIsNil)
+	certs, err := s.State.ControllerCertificates()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Simulate a call that failed before updating the hosted model.
+	err = hostedSt.UpdateModelConfig(map[string]interface{}{config.CACertKey: testing.CACert}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Retrying updates the hosted model without replacing the CA again.
+	result, err := s.controller.RotateControllerCertificates(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.CACert, gc.Equals, certs.CACert+testing.CACert)
+	after, err := s.State.ControllerCertificates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(after, jc.DeepEquals, certs)
+
+	cfg, err := hostedSt.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	caCert, _ := cfg.CACert()
+	c.Assert(caCert, gc.Equals, certs.CACert)
+}
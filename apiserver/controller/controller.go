@@ -32,9 +32,11 @@ type Controller interface {
 	ModelConfig() (params.ModelConfigResults, error)
 	ListBlockedModels() (params.ModelBlockInfoList, error)
 	RemoveBlocks(args params.RemoveBlocksArgs) error
-	WatchAllModels() (params.AllWatcherId, error)
+	WatchAllModels(params.AllWatcherFilter) (params.AllWatcherId, error)
 	ModelStatus(req params.Entities) (params.ModelStatusResults, error)
 	InitiateModelMigration(params.InitiateModelMigrationArgs) (params.InitiateModelMigrationResults, error)
+	RotateControllerCertificates(params.RotateControllerCertificatesArgs) (params.RotateControllerCertificatesResult, error)
+	AuditLog(params.AuditLogFilter) (params.AuditLogResults, error)
 }
 
 // ControllerAPI implements the environment manager interface and is
@@ -207,10 +209,16 @@ func (s *ControllerAPI) RemoveBlocks(args params.RemoveBlocksArgs) error {
 }
 
 // WatchAllModels starts watching events for all models in the
-// controller. The returned AllWatcherId should be used with Next on the
-// AllModelWatcher endpoint to receive deltas.
-func (c *ControllerAPI) WatchAllModels() (params.AllWatcherId, error) {
-	w := c.state.WatchAllModels()
+// controller, or for those selected by the filter, so that several
+// models can be watched over a single connection. The returned
+// AllWatcherId should be used with Next on the AllModelWatcher
+// endpoint to receive deltas.
+func (c *ControllerAPI) WatchAllModels(args params.AllWatcherFilter) (params.AllWatcherId, error) {
+	filter, err := common.MultiwatcherFilter(args)
+	if err != nil {
+		return params.AllWatcherId{}, errors.Trace(err)
+	}
+	w := c.state.WatchAllModelsFiltered(filter)
 	return params.AllWatcherId{
 		AllWatcherId: c.resources.Register(w),
 	}, nil
@@ -191,7 +191,7 @@ func (s *controllerSuite) TestRemoveBlocksNotAll(c *gc.C) {
 }
 
 func (s *controllerSuite) TestWatchAllModels(c *gc.C) {
-	watcherId, err := s.controller.WatchAllModels()
+	watcherId, err := s.controller.WatchAllModels(params.AllWatcherFilter{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	watcherAPI_, err := apiserver.NewAllWatcher(s.State, s.resources, s.authorizer, watcherId.AllWatcherId)
@@ -221,6 +221,50 @@ func (s *controllerSuite) TestWatchAllModels(c *gc.C) {
 	}
 }
 
+func (s *controllerSuite) TestWatchAllModelsFiltered(c *gc.C) {
+	otherSt := s.Factory.MakeModel(c, nil)
+	defer otherSt.Close()
+
+	watcherId, err := s.controller.WatchAllModels(params.AllWatcherFilter{
+		Kinds:      []string{"model"},
+		ModelUUIDs: []string{otherSt.ModelUUID()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	watcherAPI_, err := apiserver.NewAllWatcher(s.State, s.resources, s.authorizer, watcherId.AllWatcherId)
+	c.Assert(err, jc.ErrorIsNil)
+	watcherAPI := watcherAPI_.(*apiserver.SrvAllWatcher)
+	defer func() {
+		err := watcherAPI.Stop()
+		c.Assert(err, jc.ErrorIsNil)
+	}()
+
+	resultC := make(chan params.AllWatcherNextResults)
+	go func() {
+		result, err := watcherAPI.Next()
+		c.Assert(err, jc.ErrorIsNil)
+		resultC <- result
+	}()
+
+	select {
+	case result := <-resultC:
+		// Only the selected model is reported.
+		deltas := result.Deltas
+		c.Assert(deltas, gc.HasLen, 1)
+		envInfo := deltas[0].Entity.(*multiwatcher.ModelInfo)
+		c.Assert(envInfo.ModelUUID, gc.Equals, otherSt.ModelUUID())
+	case <-time.After(testing.LongWait):
+		c.Fatal("timed out")
+	}
+}
+
+func (s *controllerSuite) TestWatchAllModelsInvalidFilter(c *gc.C) {
+	_, err := s.controller.WatchAllModels(params.AllWatcherFilter{
+		Kinds: []string{"gadget"},
+	})
+	c.Assert(err, gc.ErrorMatches, `entity kind "gadget" not valid`)
+}
+
 func (s *controllerSuite) TestModelStatus(c *gc.C) {
 	otherEnvOwner := s.Factory.MakeModelUser(c, nil)
 	otherSt := s.Factory.MakeModel(c, &factory.ModelParams{
@@ -29,8 +29,17 @@ var (
 	BZMimeType                   = bzMimeType
 	JSMimeType                   = jsMimeType
 	SpritePath                   = spritePath
+	SSHTunnelPort                = &sshTunnelPort
 )
 
+// RequestBucketCount returns the number of entities whose requests
+// the server is currently rate limiting.
+func RequestBucketCount(srv *Server) int {
+	srv.bucketsMutex.Lock()
+	defer srv.bucketsMutex.Unlock()
+	return len(srv.buckets)
+}
+
 func ServerMacaroon(srv *Server) (*macaroon.Macaroon, error) {
 	auth, err := srv.authCtxt.macaroonAuth()
 	if err != nil {
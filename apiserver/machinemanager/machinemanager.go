@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/juju/errors"
+	"github.com/juju/names"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
@@ -157,3 +158,48 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 	}
 	return mm.st.AddMachineInsideNewMachine(template, template, p.ContainerType)
 }
+
+// UpgradeSeriesPrepare locks each of the given machines, and the units
+// they host, for an in-place upgrade to the requested series.
+func (mm *MachineManagerAPI) UpgradeSeriesPrepare(args params.UpgradeSeriesArgs) (params.ErrorResults, error) {
+	return mm.upgradeSeries(args, func(m Machine, arg params.UpgradeSeriesArg) error {
+		if arg.Series == "" {
+			return errors.NotValidf("empty series")
+		}
+		return m.PrepareUpgradeSeries(arg.Series)
+	})
+}
+
+// UpgradeSeriesComplete records that the operating systems of the given
+// machines have been upgraded, and asks their agents and units to
+// finish the series upgrade.
+func (mm *MachineManagerAPI) UpgradeSeriesComplete(args params.UpgradeSeriesArgs) (params.ErrorResults, error) {
+	return mm.upgradeSeries(args, func(m Machine, _ params.UpgradeSeriesArg) error {
+		return m.CompleteUpgradeSeries()
+	})
+}
+
+func (mm *MachineManagerAPI) upgradeSeries(
+	args params.UpgradeSeriesArgs,
+	upgrade func(Machine, params.UpgradeSeriesArg) error,
+) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseMachineTag(arg.Entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		m, err := mm.st.Machine(tag.Id())
+		if err == nil {
+			err = upgrade(m, arg)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
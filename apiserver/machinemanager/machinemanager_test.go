@@ -118,10 +118,74 @@ func (s *MachineManagerSuite) TestAddMachinesStateError(c *gc.C) {
 	c.Assert(s.st.calls, gc.Equals, 1)
 }
 
+func (s *MachineManagerSuite) TestUpgradeSeriesPrepare(c *gc.C) {
+	s.st.machine = &mockMachine{}
+	results, err := s.api.UpgradeSeriesPrepare(params.UpgradeSeriesArgs{
+		Args: []params.UpgradeSeriesArg{{
+			Entity: params.Entity{Tag: "machine-1"},
+			Series: "xenial",
+		}, {
+			Entity: params.Entity{Tag: "machine-1"},
+		}, {
+			Entity: params.Entity{Tag: "unit-mysql-0"},
+			Series: "xenial",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "empty series not valid"}},
+			{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+		},
+	})
+	c.Assert(s.st.machineIds, jc.DeepEquals, []string{"1", "1"})
+	c.Assert(s.st.machine.prepared, gc.Equals, "xenial")
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesComplete(c *gc.C) {
+	s.st.machine = &mockMachine{err: errors.New("not ready")}
+	results, err := s.api.UpgradeSeriesComplete(params.UpgradeSeriesArgs{
+		Args: []params.UpgradeSeriesArg{{
+			Entity: params.Entity{Tag: "machine-1"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: &params.Error{Message: "not ready"}},
+		},
+	})
+	c.Assert(s.st.machine.completed, jc.IsTrue)
+}
+
 type mockState struct {
-	calls    int
-	machines []state.MachineTemplate
-	err      error
+	calls      int
+	machines   []state.MachineTemplate
+	machine    *mockMachine
+	machineIds []string
+	err        error
+}
+
+func (st *mockState) Machine(id string) (machinemanager.Machine, error) {
+	st.machineIds = append(st.machineIds, id)
+	return st.machine, st.err
+}
+
+type mockMachine struct {
+	prepared  string
+	completed bool
+	err       error
+}
+
+func (m *mockMachine) PrepareUpgradeSeries(toSeries string) error {
+	m.prepared = toSeries
+	return m.err
+}
+
+func (m *mockMachine) CompleteUpgradeSeries() error {
+	m.completed = true
+	return m.err
 }
 
 func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machine, error) {
@@ -6,6 +6,10 @@
 package metricsdebug
 
 import (
+	"sort"
+	"strconv"
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names"
 
@@ -16,6 +20,7 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("MetricsDebug", 1, NewMetricsDebugAPI)
+	common.RegisterStandardFacade("MetricsDebug", 2, NewMetricsDebugAPIV2)
 }
 
 type metricsDebug interface {
@@ -41,6 +46,16 @@ type MetricsDebug interface {
 	SetMeterStatus(params.MeterStatusParams) (params.ErrorResults, error)
 }
 
+// MetricsDebugV2 defines the methods on version 2 of the metricsdebug
+// API end point.
+type MetricsDebugV2 interface {
+	MetricsDebug
+
+	// AggregateMetrics returns the aggregated metrics stored for
+	// the given units and services.
+	AggregateMetrics(args params.AggregateMetricsArgs) (params.AggregateMetricsResults, error)
+}
+
 // MetricsDebugAPI implements the metricsdebug interface and is the concrete
 // implementation of the api end point.
 type MetricsDebugAPI struct {
@@ -49,6 +64,14 @@ type MetricsDebugAPI struct {
 
 var _ MetricsDebug = (*MetricsDebugAPI)(nil)
 
+// MetricsDebugAPIV2 implements version 2 of the metricsdebug api end
+// point, which adds metric aggregation.
+type MetricsDebugAPIV2 struct {
+	MetricsDebugAPI
+}
+
+var _ MetricsDebugV2 = (*MetricsDebugAPIV2)(nil)
+
 // NewMetricsDebugAPI creates a new API endpoint for calling metrics debug functions.
 func NewMetricsDebugAPI(
 	st *state.State,
@@ -64,6 +87,20 @@ func NewMetricsDebugAPI(
 	}, nil
 }
 
+// NewMetricsDebugAPIV2 creates a new API endpoint for calling version
+// 2 of the metrics debug functions.
+func NewMetricsDebugAPIV2(
+	st *state.State,
+	resources *common.Resources,
+	authorizer common.Authorizer,
+) (*MetricsDebugAPIV2, error) {
+	api, err := NewMetricsDebugAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricsDebugAPIV2{*api}, nil
+}
+
 // GetMetrics returns all metrics stored by the state server.
 func (api *MetricsDebugAPI) GetMetrics(args params.Entities) (params.MetricResults, error) {
 	results := params.MetricResults{
@@ -119,6 +156,145 @@ func (api *MetricsDebugAPI) GetMetrics(args params.Entities) (params.MetricResul
 	return results, nil
 }
 
+// aggregateFuncs holds the supported metric aggregation functions.
+var aggregateFuncs = map[string]func(values []float64) float64{
+	params.AggregateSum: func(values []float64) float64 {
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		return sum
+	},
+	params.AggregateAvg: func(values []float64) float64 {
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		return sum / float64(len(values))
+	},
+	params.AggregateMax: func(values []float64) float64 {
+		max := values[0]
+		for _, v := range values[1:] {
+			if v > max {
+				max = v
+			}
+		}
+		return max
+	},
+}
+
+// AggregateMetrics returns the aggregated metrics stored for the
+// given units and services. The metrics of all of a service's units
+// are aggregated together.
+func (api *MetricsDebugAPIV2) AggregateMetrics(args params.AggregateMetricsArgs) (params.AggregateMetricsResults, error) {
+	results := params.AggregateMetricsResults{
+		Results: make([]params.AggregateMetricsResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		metrics, err := api.aggregateMetrics(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Metrics = metrics
+	}
+	return results, nil
+}
+
+// metricBucket holds the values of a metric within an interval.
+type metricBucket struct {
+	start  time.Time
+	key    string
+	values []float64
+}
+
+func (api *MetricsDebugAPI) aggregateMetrics(arg params.AggregateMetricsArg) ([]params.AggregatedMetric, error) {
+	aggregate, ok := aggregateFuncs[arg.Aggregate]
+	if !ok {
+		return nil, errors.NotValidf("aggregate %q", arg.Aggregate)
+	}
+	if arg.Interval < 0 {
+		return nil, errors.NotValidf("negative interval")
+	}
+	tag, err := names.ParseTag(arg.Tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var batches []state.MetricBatch
+	switch tag.Kind() {
+	case names.UnitTagKind:
+		batches, err = api.state.MetricBatchesForUnit(tag.Id())
+	case names.ServiceTagKind:
+		batches, err = api.state.MetricBatchesForService(tag.Id())
+	default:
+		return nil, errors.Errorf("invalid tag %v", arg.Tag)
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to get metrics")
+	}
+
+	type bucketKey struct {
+		start time.Time
+		key   string
+	}
+	buckets := make(map[bucketKey]*metricBucket)
+	for _, batch := range batches {
+		for _, m := range batch.Metrics() {
+			if arg.Key != "" && m.Key != arg.Key {
+				continue
+			}
+			if !arg.Since.IsZero() && m.Time.Before(arg.Since) {
+				continue
+			}
+			value, err := strconv.ParseFloat(m.Value, 64)
+			if err != nil {
+				return nil, errors.Errorf("invalid value %q for metric %q", m.Value, m.Key)
+			}
+			t := m.Time.UTC()
+			var start time.Time
+			if arg.Interval > 0 {
+				start = t.Truncate(arg.Interval)
+			}
+			k := bucketKey{start, m.Key}
+			bucket, ok := buckets[k]
+			if !ok {
+				bucket = &metricBucket{start: start, key: m.Key}
+				buckets[k] = bucket
+			}
+			if arg.Interval == 0 && (bucket.start.IsZero() || t.Before(bucket.start)) {
+				// With no interval, the metrics are aggregated
+				// from the time of the earliest.
+				bucket.start = t
+			}
+			bucket.values = append(bucket.values, value)
+		}
+	}
+
+	metrics := make([]params.AggregatedMetric, 0, len(buckets))
+	for _, bucket := range buckets {
+		metrics = append(metrics, params.AggregatedMetric{
+			Time:  bucket.start,
+			Key:   bucket.key,
+			Value: aggregate(bucket.values),
+			Count: len(bucket.values),
+		})
+	}
+	sort.Sort(aggregatedMetrics(metrics))
+	return metrics, nil
+}
+
+// aggregatedMetrics sorts aggregated metrics by time and then key.
+type aggregatedMetrics []params.AggregatedMetric
+
+func (m aggregatedMetrics) Len() int      { return len(m) }
+func (m aggregatedMetrics) Swap(i, j int) { m[i], m[j] = m[j], m[i] }
+func (m aggregatedMetrics) Less(i, j int) bool {
+	if !m[i].Time.Equal(m[j].Time) {
+		return m[i].Time.Before(m[j].Time)
+	}
+	return m[i].Key < m[j].Key
+}
+
 // SetMeterStatus sets meter statuses for entities.
 func (api *MetricsDebugAPI) SetMeterStatus(args params.MeterStatusParams) (params.ErrorResults, error) {
 	results := params.ErrorResults{
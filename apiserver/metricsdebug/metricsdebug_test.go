@@ -20,7 +20,7 @@ import (
 type metricsDebugSuite struct {
 	jujutesting.JujuConnSuite
 
-	metricsdebug *metricsdebug.MetricsDebugAPI
+	metricsdebug *metricsdebug.MetricsDebugAPIV2
 	authorizer   apiservertesting.FakeAuthorizer
 	unit         *state.Unit
 }
@@ -32,7 +32,7 @@ func (s *metricsDebugSuite) SetUpTest(c *gc.C) {
 	s.authorizer = apiservertesting.FakeAuthorizer{
 		Tag: s.AdminUserTag(c),
 	}
-	debug, err := metricsdebug.NewMetricsDebugAPI(s.State, nil, s.authorizer)
+	debug, err := metricsdebug.NewMetricsDebugAPIV2(s.State, nil, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 	s.metricsdebug = debug
 }
@@ -275,3 +275,64 @@ func (s *metricsDebugSuite) TestGetMultipleMetricsNoMocksWithService(c *gc.C) {
 	c.Assert(metrics.Results[0].Metrics[1].Value, gc.Equals, metricUnit1.Metrics()[0].Value)
 	c.Assert(metrics.Results[0].Metrics[1].Time, jc.TimeBetween(metricUnit1.Metrics()[0].Time, metricUnit1.Metrics()[0].Time))
 }
+
+func (s *metricsDebugSuite) TestAggregateMetrics(c *gc.C) {
+	meteredCharm := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "metered", URL: "local:quantal/metered"})
+	meteredService := s.Factory.MakeService(c, &factory.ServiceParams{Charm: meteredCharm})
+	unit0 := s.Factory.MakeUnit(c, &factory.UnitParams{Service: meteredService, SetCharmURL: true})
+	unit1 := s.Factory.MakeUnit(c, &factory.UnitParams{Service: meteredService, SetCharmURL: true})
+	base := time.Now().UTC().Truncate(time.Hour).Add(-time.Hour)
+	s.Factory.MakeMetric(c, &factory.MetricParams{Unit: unit0, Metrics: []state.Metric{
+		{"pings", "5", base},
+		{"juju-units", "1", base},
+	}})
+	s.Factory.MakeMetric(c, &factory.MetricParams{Unit: unit0, Metrics: []state.Metric{
+		{"pings", "7", base.Add(time.Minute)},
+	}})
+	s.Factory.MakeMetric(c, &factory.MetricParams{Unit: unit1, Metrics: []state.Metric{
+		{"pings", "10", base.Add(6 * time.Minute)},
+	}})
+
+	args := params.AggregateMetricsArgs{Args: []params.AggregateMetricsArg{{
+		Tag:       "service-metered",
+		Key:       "pings",
+		Aggregate: params.AggregateSum,
+		Interval:  5 * time.Minute,
+	}, {
+		Tag:       "service-metered",
+		Aggregate: params.AggregateMax,
+	}, {
+		Tag:       "unit-metered/0",
+		Key:       "pings",
+		Since:     base.Add(30 * time.Second),
+		Aggregate: params.AggregateAvg,
+	}, {
+		Tag:       "service-metered",
+		Aggregate: "median",
+	}, {
+		Tag:       "machine-0",
+		Aggregate: params.AggregateSum,
+	}}}
+	results, err := s.metricsdebug.AggregateMetrics(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 5)
+	c.Assert(results.Results[0], jc.DeepEquals, params.AggregateMetricsResult{
+		Metrics: []params.AggregatedMetric{
+			{Time: base, Key: "pings", Value: 12, Count: 2},
+			{Time: base.Add(5 * time.Minute), Key: "pings", Value: 10, Count: 1},
+		},
+	})
+	c.Assert(results.Results[1], jc.DeepEquals, params.AggregateMetricsResult{
+		Metrics: []params.AggregatedMetric{
+			{Time: base, Key: "juju-units", Value: 1, Count: 1},
+			{Time: base, Key: "pings", Value: 10, Count: 3},
+		},
+	})
+	c.Assert(results.Results[2], jc.DeepEquals, params.AggregateMetricsResult{
+		Metrics: []params.AggregatedMetric{
+			{Time: base.Add(time.Minute), Key: "pings", Value: 7, Count: 1},
+		},
+	})
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `aggregate "median" not valid`)
+	c.Assert(results.Results[4].Error, gc.ErrorMatches, `invalid tag machine-0`)
+}
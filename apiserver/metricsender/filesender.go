@@ -0,0 +1,56 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsender
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/juju/errors"
+	wireformat "github.com/juju/romulus/wireformat/metrics"
+)
+
+// fileSenderMutex serialises writes by all FileSenders, since the
+// metrics of several models may be sent to the same file at once.
+var fileSenderMutex sync.Mutex
+
+// FileSender is a sender that appends metrics to a local file,
+// one JSON-encoded batch per line.
+type FileSender struct {
+	// Path holds the path of the file to append metrics to. Its
+	// directory is created if necessary.
+	Path string
+}
+
+// Send implements the MetricSender interface. All batches written
+// to the file are acknowledged.
+func (s *FileSender) Send(batches []*wireformat.MetricBatch) (*wireformat.Response, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, batch := range batches {
+		if err := enc.Encode(batch); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	fileSenderMutex.Lock()
+	defer fileSenderMutex.Unlock()
+	// Metric batches include charm credentials, so the file
+	// and its directory are accessible only by their owner.
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return nil, errors.Annotate(err, "cannot create metrics directory")
+	}
+	f, err := os.OpenFile(s.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot open metrics file")
+	}
+	defer f.Close()
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		return nil, errors.Annotate(err, "cannot write metrics file")
+	}
+	return ackResponse(batches)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/loggo"
 	wireformat "github.com/juju/romulus/wireformat/metrics"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
@@ -126,6 +127,37 @@ func DefaultMetricSender() MetricSender {
 	return defaultSender
 }
 
+// NewMetricSender returns the metric sender specified by the
+// given controller model configuration.
+func NewMetricSender(cfg *config.Config) (MetricSender, error) {
+	sender, target := cfg.MetricsSender()
+	switch sender {
+	case config.MetricsSenderCollector:
+		return defaultSender, nil
+	case config.MetricsSenderHTTP:
+		return &HttpSender{URL: target}, nil
+	case config.MetricsSenderFile:
+		return &FileSender{Path: target}, nil
+	case config.MetricsSenderPushgateway:
+		return &PushgatewaySender{URL: target}, nil
+	}
+	return nil, errors.NotValidf("metrics sender %q", sender)
+}
+
+// ControllerMetricSender returns the metric sender configured for
+// the controller hosting the given state's model.
+func ControllerMetricSender(st *state.State) (MetricSender, error) {
+	controllerModel, err := st.ControllerModel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := controllerModel.Config()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get controller model config")
+	}
+	return NewMetricSender(cfg)
+}
+
 // ToWire converts the state.MetricBatch into a type
 // that can be sent over the wire to the collector.
 func ToWire(mb *state.MetricBatch) *wireformat.MetricBatch {
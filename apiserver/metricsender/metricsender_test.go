@@ -144,3 +144,57 @@ func (s *MetricSenderSuite) TestFailuresResetOnSuccessfulSend(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(mm.ConsecutiveErrors(), gc.Equals, 0)
 }
+
+func (s *MetricSenderSuite) TestControllerMetricSenderDefault(c *gc.C) {
+	sender, err := metricsender.ControllerMetricSender(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sender, gc.Equals, metricsender.DefaultMetricSender())
+}
+
+func (s *MetricSenderSuite) TestControllerMetricSender(c *gc.C) {
+	tests := []struct {
+		attrs  map[string]interface{}
+		sender metricsender.MetricSender
+	}{{
+		attrs: map[string]interface{}{
+			"metrics-sender":        "http",
+			"metrics-sender-target": "https://metrics.example.com/v1",
+		},
+		sender: &metricsender.HttpSender{URL: "https://metrics.example.com/v1"},
+	}, {
+		attrs: map[string]interface{}{
+			"metrics-sender":        "file",
+			"metrics-sender-target": "/var/log/juju/metrics/metrics.log",
+		},
+		sender: &metricsender.FileSender{Path: "/var/log/juju/metrics/metrics.log"},
+	}, {
+		attrs: map[string]interface{}{
+			"metrics-sender":        "pushgateway",
+			"metrics-sender-target": "http://10.0.0.1:9091",
+		},
+		sender: &metricsender.PushgatewaySender{URL: "http://10.0.0.1:9091"},
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %v", i, test.attrs)
+		err := s.State.UpdateModelConfig(test.attrs, nil, nil)
+		c.Assert(err, jc.ErrorIsNil)
+		sender, err := metricsender.ControllerMetricSender(s.State)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(sender, jc.DeepEquals, test.sender)
+	}
+}
+
+func (s *MetricSenderSuite) TestControllerMetricSenderHostedModel(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"metrics-sender":        "file",
+		"metrics-sender-target": "/var/log/juju/metrics/metrics.log",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	// The controller model's configuration is used.
+	sender, err := metricsender.ControllerMetricSender(st)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sender, jc.DeepEquals, &metricsender.FileSender{Path: "/var/log/juju/metrics/metrics.log"})
+}
@@ -15,6 +15,12 @@ type NopSender struct {
 
 // Implement the send interface, act like everything is fine.
 func (n NopSender) Send(batches []*wireformat.MetricBatch) (*wireformat.Response, error) {
+	return ackResponse(batches)
+}
+
+// ackResponse returns a response acknowledging all the given batches,
+// for senders whose destinations do not respond themselves.
+func ackResponse(batches []*wireformat.MetricBatch) (*wireformat.Response, error) {
 	var resp = make(wireformat.EnvironmentResponses)
 	for _, batch := range batches {
 		resp.Ack(batch.ModelUUID, batch.UUID)
@@ -0,0 +1,136 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsender
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	wireformat "github.com/juju/romulus/wireformat/metrics"
+)
+
+// pushgatewayMetricName is the name of the Prometheus metric
+// under which all charm metrics are pushed.
+const pushgatewayMetricName = "juju_charm_metric"
+
+// PushgatewaySender is a sender that pushes metrics to a Prometheus
+// pushgateway. The metrics of each unit are pushed as a separate
+// group, replacing the values previously pushed for that unit; each
+// metric is labelled with its key and the unit's charm URL.
+type PushgatewaySender struct {
+	// URL holds the base URL of the pushgateway.
+	URL string
+}
+
+// pushgatewayGroup holds the latest value of each metric
+// of a single unit.
+type pushgatewayGroup struct {
+	modelUUID string
+	unitName  string
+	charmURL  string
+	metrics   map[string]wireformat.Metric
+}
+
+// Send implements the MetricSender interface. All batches
+// pushed to the gateway are acknowledged.
+func (s *PushgatewaySender) Send(batches []*wireformat.MetricBatch) (*wireformat.Response, error) {
+	groups := make(map[string]*pushgatewayGroup)
+	var groupKeys []string
+	for _, batch := range batches {
+		key := batch.ModelUUID + " " + batch.UnitName
+		group, ok := groups[key]
+		if !ok {
+			group = &pushgatewayGroup{
+				modelUUID: batch.ModelUUID,
+				unitName:  batch.UnitName,
+				metrics:   make(map[string]wireformat.Metric),
+			}
+			groups[key] = group
+			groupKeys = append(groupKeys, key)
+		}
+		group.charmURL = batch.CharmUrl
+		for _, m := range batch.Metrics {
+			// The pushgateway only holds one value per
+			// metric, so keep the most recent.
+			if existing, ok := group.metrics[m.Key]; ok && existing.Time.After(m.Time) {
+				continue
+			}
+			group.metrics[m.Key] = m
+		}
+	}
+	for _, key := range groupKeys {
+		if err := s.push(groups[key]); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return ackResponse(batches)
+}
+
+func (s *PushgatewaySender) push(group *pushgatewayGroup) error {
+	body, err := group.exposition()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Unit names contain a slash, so the grouping label
+	// value must be base64 encoded.
+	pushURL := fmt.Sprintf("%s/metrics/job/juju/model_uuid/%s/unit@base64/%s",
+		strings.TrimRight(s.URL, "/"),
+		url.QueryEscape(group.modelUUID),
+		base64.URLEncoding.EncodeToString([]byte(group.unitName)),
+	)
+	req, err := http.NewRequest("PUT", pushURL, body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return errors.Errorf("failed to push metrics for unit %q: http %v", group.unitName, resp.StatusCode)
+	}
+	return nil
+}
+
+// exposition returns the group's metrics in the Prometheus
+// text exposition format.
+func (group *pushgatewayGroup) exposition() (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# TYPE %s untyped\n", pushgatewayMetricName)
+	keys := make([]string, 0, len(group.metrics))
+	for key := range group.metrics {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value, err := strconv.ParseFloat(group.metrics[key].Value, 64)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid value for metric %q of unit %q", key, group.unitName)
+		}
+		fmt.Fprintf(&buf, "%s{key=%s,charm_url=%s} %s\n",
+			pushgatewayMetricName,
+			quoteLabelValue(key),
+			quoteLabelValue(group.charmURL),
+			strconv.FormatFloat(value, 'g', -1, 64),
+		)
+	}
+	return &buf, nil
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quoteLabelValue returns the given Prometheus label value,
+// escaped and quoted.
+func quoteLabelValue(value string) string {
+	return `"` + labelValueReplacer.Replace(value) + `"`
+}
@@ -19,6 +19,9 @@ var (
 // HttpSender is the default used for sending
 // metrics to the collector service.
 type HttpSender struct {
+	// URL holds the URL of the endpoint to send metrics to. If
+	// it is empty, the charm store's collector service is used.
+	URL string
 }
 
 // Send sends the given metrics to the collector service.
@@ -27,9 +30,13 @@ func (s *HttpSender) Send(metrics []*wireformat.MetricBatch) (*wireformat.Respon
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	url := s.URL
+	if url == "" {
+		url = metricsHost
+	}
 	r := bytes.NewBuffer(b)
 	client := &http.Client{}
-	resp, err := client.Post(metricsHost, "application/json", r)
+	resp, err := client.Post(url, "application/json", r)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
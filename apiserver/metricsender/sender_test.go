@@ -6,9 +6,15 @@ package metricsender_test
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	wireformat "github.com/juju/romulus/wireformat/metrics"
@@ -289,3 +295,122 @@ func (s *SenderSuite) TestZeroGracePeriodResponse(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(mm.GracePeriod(), gc.Equals, 24*time.Hour*7) //Default (unchanged)
 }
+
+// TestHttpSenderURL checks that metrics are sent to the
+// sender's URL rather than the collector when it is set.
+func (s *SenderSuite) TestHttpSenderURL(c *gc.C) {
+	receiverChan := make(chan wireformat.MetricBatch, 1)
+	ts := httptest.NewServer(testHandler(c, receiverChan, nil, 0))
+	defer ts.Close()
+
+	metric := s.Factory.MakeMetric(c, &factory.MetricParams{Unit: s.unit, Sent: false})
+	sender := metricsender.HttpSender{URL: ts.URL}
+	err := metricsender.SendMetrics(s.State, &sender, 10)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(receiverChan, gc.HasLen, 1)
+	batch := <-receiverChan
+	c.Assert(batch.UUID, gc.Equals, metric.UUID())
+	m, err := s.State.MetricBatch(metric.UUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Sent(), jc.IsTrue)
+}
+
+var _ metricsender.MetricSender = (*metricsender.FileSender)(nil)
+
+// TestFileSender checks that metrics are appended to the file,
+// one batch per line, and marked as sent.
+func (s *SenderSuite) TestFileSender(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "metrics", "metrics.log")
+	metrics := make([]*state.MetricBatch, 3)
+	for i := range metrics {
+		metrics[i] = s.Factory.MakeMetric(c, &factory.MetricParams{Unit: s.unit, Sent: false})
+	}
+	sender := metricsender.FileSender{Path: path}
+	err := metricsender.SendMetrics(s.State, &sender, 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	c.Assert(lines, gc.HasLen, 3)
+	var uuids []string
+	for _, line := range lines {
+		var batch wireformat.MetricBatch
+		err := json.Unmarshal([]byte(line), &batch)
+		c.Assert(err, jc.ErrorIsNil)
+		uuids = append(uuids, batch.UUID)
+	}
+	for _, metric := range metrics {
+		c.Assert(uuids, jc.Contains, metric.UUID())
+		m, err := s.State.MetricBatch(metric.UUID())
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(m.Sent(), jc.IsTrue)
+	}
+
+	info, err := os.Stat(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+}
+
+var _ metricsender.MetricSender = (*metricsender.PushgatewaySender)(nil)
+
+// TestPushgatewaySender checks that the latest value of each of a
+// unit's metrics is pushed to the unit's group on the pushgateway.
+func (s *SenderSuite) TestPushgatewaySender(c *gc.C) {
+	type push struct {
+		method string
+		path   string
+		body   string
+	}
+	pushes := make(chan push, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		c.Assert(err, jc.ErrorIsNil)
+		pushes <- push{r.Method, r.URL.Path, string(body)}
+	}))
+	defer ts.Close()
+
+	earlier := time.Now().Add(-time.Minute)
+	later := time.Now()
+	s.Factory.MakeMetric(c, &factory.MetricParams{
+		Unit: s.unit, Time: &earlier,
+		Metrics: []state.Metric{{"pings", "5", earlier}},
+	})
+	metric := s.Factory.MakeMetric(c, &factory.MetricParams{
+		Unit: s.unit, Time: &later,
+		Metrics: []state.Metric{{"pings", "7", later}},
+	})
+	sender := metricsender.PushgatewaySender{URL: ts.URL + "/"}
+	err := metricsender.SendMetrics(s.State, &sender, 10)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(pushes, gc.HasLen, 1)
+	p := <-pushes
+	c.Assert(p.method, gc.Equals, "PUT")
+	c.Assert(p.path, gc.Equals, fmt.Sprintf(
+		"/metrics/job/juju/model_uuid/%s/unit@base64/%s",
+		s.State.ModelUUID(), base64.URLEncoding.EncodeToString([]byte(s.unit.Name())),
+	))
+	c.Assert(p.body, gc.Equals, fmt.Sprintf(`
+# TYPE juju_charm_metric untyped
+juju_charm_metric{key="pings",charm_url=%q} 7
+`[1:], metric.CharmURL()))
+
+	m, err := s.State.MetricBatch(metric.UUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Sent(), jc.IsTrue)
+}
+
+func (s *SenderSuite) TestPushgatewaySenderError(c *gc.C) {
+	ts := httptest.NewServer(errorHandler(c, http.StatusBadRequest))
+	defer ts.Close()
+
+	metric := s.Factory.MakeMetric(c, &factory.MetricParams{Unit: s.unit, Sent: false})
+	sender := metricsender.PushgatewaySender{URL: ts.URL}
+	err := metricsender.SendMetrics(s.State, &sender, 10)
+	c.Assert(err, gc.ErrorMatches, `failed to push metrics for unit "metered/0": http 400`)
+	m, err := s.State.MetricBatch(metric.UUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Sent(), jc.IsFalse)
+}
@@ -5,8 +5,11 @@ package metricsmanager
 
 import (
 	"github.com/juju/juju/apiserver/metricsender"
+	"github.com/juju/juju/state"
 )
 
 func PatchSender(s metricsender.MetricSender) {
-	sender = s
+	newSender = func(*state.State) (metricsender.MetricSender, error) {
+		return s, nil
+	}
 }
@@ -20,7 +20,7 @@ var (
 	logger            = loggo.GetLogger("juju.apiserver.metricsmanager")
 	maxBatchesPerSend = metricsender.DefaultMaxBatchesPerSend()
 
-	sender = metricsender.DefaultMetricSender()
+	newSender = metricsender.ControllerMetricSender
 )
 
 func init() {
@@ -125,6 +125,13 @@ func (api *MetricsManagerAPI) SendMetrics(args params.Entities) (params.ErrorRes
 			result.Results[i].Error = common.ServerError(common.ErrPerm)
 			continue
 		}
+		sender, err := newSender(api.state)
+		if err != nil {
+			err = errors.Annotate(err, "cannot get metrics sender")
+			logger.Warningf("%v", err)
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
 		err = metricsender.SendMetrics(api.state, sender, maxBatchesPerSend)
 		if err != nil {
 			err = errors.Annotate(err, "failed to send metrics")
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/modelmanager"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -219,6 +220,21 @@ func (st *mockState) NewModel(args state.ModelArgs) (*state.Model, *state.State,
 	return nil, nil, st.NextErr()
 }
 
+func (st *mockState) CloudCredential(ref state.CloudCredentialRef) (cloud.Credential, error) {
+	st.MethodCall(st, "CloudCredential", ref)
+	return cloud.Credential{}, st.NextErr()
+}
+
+func (st *mockState) AddCloudCredential(ref state.CloudCredentialRef, cred cloud.Credential) error {
+	st.MethodCall(st, "AddCloudCredential", ref, cred)
+	return st.NextErr()
+}
+
+func (st *mockState) UpdateCloudCredential(ref state.CloudCredentialRef, cred cloud.Credential) ([]names.ModelTag, error) {
+	st.MethodCall(st, "UpdateCloudCredential", ref, cred)
+	return nil, st.NextErr()
+}
+
 func (st *mockState) ControllerModel() (*state.Model, error) {
 	st.MethodCall(st, "ControllerModel")
 	return nil, st.NextErr()
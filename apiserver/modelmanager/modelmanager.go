@@ -18,6 +18,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller/modelmanager"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/permission"
@@ -36,6 +37,8 @@ type ModelManager interface {
 	ConfigSkeleton(args params.ModelSkeletonConfigArgs) (params.ModelConfigResult, error)
 	CreateModel(args params.ModelCreateArgs) (params.Model, error)
 	ListModels(user params.Entity) (params.UserModelList, error)
+	AddCloudCredentials(args params.UpdateCloudCredentials) (params.ErrorResults, error)
+	UpdateCloudCredentials(args params.UpdateCloudCredentials) (params.UpdateCloudCredentialResults, error)
 }
 
 // ModelManagerAPI implements the model manager interface and is
@@ -208,6 +211,27 @@ func (mm *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Mode
 		return result, errors.Trace(err)
 	}
 
+	var credential state.CloudCredentialRef
+	if args.Cloud != "" || args.CloudCredential != "" {
+		credential = state.CloudCredentialRef{
+			Owner: ownerTag,
+			Cloud: args.Cloud,
+			Name:  args.CloudCredential,
+		}
+		cred, err := mm.state.CloudCredential(credential)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		account := make(map[string]interface{})
+		for key, value := range args.Account {
+			account[key] = value
+		}
+		for key, value := range cred.Attributes() {
+			account[key] = value
+		}
+		args.Account = account
+	}
+
 	newConfig, err := mm.newModelConfig(args, controllerModel)
 	if err != nil {
 		return result, errors.Annotate(err, "failed to create config")
@@ -215,7 +239,11 @@ func (mm *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Mode
 	// NOTE: check the agent-version of the config, and if it is > the current
 	// version, it is not supported, also check existing tools, and if we don't
 	// have tools for that version, also die.
-	model, st, err := mm.state.NewModel(state.ModelArgs{Config: newConfig, Owner: ownerTag})
+	model, st, err := mm.state.NewModel(state.ModelArgs{
+		Config:          newConfig,
+		Owner:           ownerTag,
+		CloudCredential: credential,
+	})
 	if err != nil {
 		return result, errors.Annotate(err, "failed to create new model")
 	}
@@ -228,6 +256,76 @@ func (mm *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Mode
 	return result, nil
 }
 
+// AddCloudCredentials stores the given cloud credentials in the
+// controller. Existing credentials are never replaced: an error
+// satisfying params.IsCodeAlreadyExists is returned for each credential
+// with the same owner, cloud and name as one already stored. Users may
+// only add their own credentials, unless they are controller
+// administrators.
+func (mm *ModelManagerAPI) AddCloudCredentials(args params.UpdateCloudCredentials) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Credentials)),
+	}
+	for i, arg := range args.Credentials {
+		ref, credential, err := mm.cloudCredential(arg)
+		if err == nil {
+			err = mm.state.AddCloudCredential(ref, credential)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// UpdateCloudCredentials stores the given cloud credentials in the
+// controller, replacing any existing credentials with the same owner,
+// cloud and name. Every model using an updated credential has its
+// config updated with the new credential attributes. Users may only
+// update their own credentials, unless they are controller
+// administrators.
+func (mm *ModelManagerAPI) UpdateCloudCredentials(args params.UpdateCloudCredentials) (params.UpdateCloudCredentialResults, error) {
+	results := params.UpdateCloudCredentialResults{
+		Results: make([]params.UpdateCloudCredentialResult, len(args.Credentials)),
+	}
+	for i, arg := range args.Credentials {
+		models, err := mm.updateCloudCredential(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		for _, model := range models {
+			results.Results[i].Models = append(results.Results[i].Models, model.String())
+		}
+	}
+	return results, nil
+}
+
+func (mm *ModelManagerAPI) updateCloudCredential(arg params.UpdateCloudCredential) ([]names.ModelTag, error) {
+	ref, credential, err := mm.cloudCredential(arg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return mm.state.UpdateCloudCredential(ref, credential)
+}
+
+// cloudCredential returns the reference to, and contents of, the given
+// cloud credential, checking that the user may store it.
+func (mm *ModelManagerAPI) cloudCredential(arg params.UpdateCloudCredential) (state.CloudCredentialRef, cloud.Credential, error) {
+	ownerTag, err := names.ParseUserTag(arg.OwnerTag)
+	if err != nil {
+		return state.CloudCredentialRef{}, cloud.Credential{}, errors.Trace(err)
+	}
+	if err := mm.authCheck(ownerTag); err != nil {
+		return state.CloudCredentialRef{}, cloud.Credential{}, errors.Trace(err)
+	}
+	ref := state.CloudCredentialRef{
+		Owner: ownerTag,
+		Cloud: arg.Cloud,
+		Name:  arg.Name,
+	}
+	credential := cloud.NewCredential(cloud.AuthType(arg.AuthType), arg.Attributes)
+	return ref, credential, nil
+}
+
 // ListModels returns the models that the specified user
 // has access to in the current server.  Only that controller owner
 // can list models for any user (at this stage).  Other users
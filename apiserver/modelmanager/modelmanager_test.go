@@ -135,6 +135,112 @@ func (s *modelManagerSuite) TestNonAdminCannotCreateModelForSomeoneElse(c *gc.C)
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
+func (s *modelManagerSuite) updateCloudCredential(c *gc.C, owner names.UserTag, secret string) params.UpdateCloudCredentialResult {
+	results, err := s.modelmanager.UpdateCloudCredentials(params.UpdateCloudCredentials{
+		Credentials: []params.UpdateCloudCredential{{
+			OwnerTag:   owner.String(),
+			Cloud:      "dummy",
+			Name:       "default",
+			AuthType:   "userpass",
+			Attributes: map[string]string{"secret": secret},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	return results.Results[0]
+}
+
+func (s *modelManagerSuite) TestCreateModelWithCloudCredential(c *gc.C) {
+	owner := s.AdminUserTag(c)
+	s.setAPIUser(c, owner)
+	result := s.updateCloudCredential(c, owner, "sesame")
+	c.Assert(result.Error, gc.IsNil)
+
+	args := s.createArgs(c, owner)
+	args.Cloud = "dummy"
+	args.CloudCredential = "default"
+	model, err := s.modelmanager.CreateModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	st, err := s.State.ForModel(names.NewModelTag(model.UUID))
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	cfg, err := st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllAttrs()["secret"], gc.Equals, "sesame")
+
+	// Rotating the credential updates the model.
+	result = s.updateCloudCredential(c, owner, "open")
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Models, jc.DeepEquals, []string{names.NewModelTag(model.UUID).String()})
+	cfg, err = st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllAttrs()["secret"], gc.Equals, "open")
+}
+
+func (s *modelManagerSuite) TestCreateModelMissingCloudCredential(c *gc.C) {
+	owner := s.AdminUserTag(c)
+	s.setAPIUser(c, owner)
+	args := s.createArgs(c, owner)
+	args.Cloud = "dummy"
+	args.CloudCredential = "missing"
+	_, err := s.modelmanager.CreateModel(args)
+	c.Assert(err, gc.ErrorMatches, `cloud credential "dummy:.*/missing" not found`)
+}
+
+func (s *modelManagerSuite) TestUpdateCloudCredentialForSomeoneElseDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("non-admin@remote"))
+	result := s.updateCloudCredential(c, names.NewUserTag("external@remote"), "sesame")
+	c.Assert(result.Error, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelManagerSuite) TestAddCloudCredentials(c *gc.C) {
+	owner := s.AdminUserTag(c)
+	s.setAPIUser(c, owner)
+	args := params.UpdateCloudCredentials{
+		Credentials: []params.UpdateCloudCredential{{
+			OwnerTag:   owner.String(),
+			Cloud:      "dummy",
+			Name:       "default",
+			AuthType:   "userpass",
+			Attributes: map[string]string{"secret": "sesame"},
+		}},
+	}
+	results, err := s.modelmanager.AddCloudCredentials(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+
+	// Adding the credential again does not replace it.
+	args.Credentials[0].Attributes = map[string]string{"secret": "open"}
+	results, err = s.modelmanager.AddCloudCredentials(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeAlreadyExists)
+	cred, err := s.State.CloudCredential(state.CloudCredentialRef{
+		Owner: owner,
+		Cloud: "dummy",
+		Name:  "default",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cred.Attributes(), jc.DeepEquals, map[string]string{"secret": "sesame"})
+}
+
+func (s *modelManagerSuite) TestAddCloudCredentialForSomeoneElseDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("non-admin@remote"))
+	results, err := s.modelmanager.AddCloudCredentials(params.UpdateCloudCredentials{
+		Credentials: []params.UpdateCloudCredential{{
+			OwnerTag: names.NewUserTag("external@remote").String(),
+			Cloud:    "dummy",
+			Name:     "default",
+			AuthType: "userpass",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+}
+
 func (s *modelManagerSuite) TestConfigSkeleton(c *gc.C) {
 	s.setAPIUser(c, names.NewUserTag("non-admin@remote"))
 
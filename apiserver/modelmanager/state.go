@@ -7,6 +7,7 @@ import (
 	"github.com/juju/names"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -21,6 +22,9 @@ type Backend interface {
 	ModelsForUser(names.UserTag) ([]*state.UserModel, error)
 	IsControllerAdministrator(user names.UserTag) (bool, error)
 	NewModel(state.ModelArgs) (*state.Model, *state.State, error)
+	CloudCredential(state.CloudCredentialRef) (cloud.Credential, error)
+	AddCloudCredential(state.CloudCredentialRef, cloud.Credential) error
+	UpdateCloudCredential(state.CloudCredentialRef, cloud.Credential) ([]names.ModelTag, error)
 	ControllerModel() (*state.Model, error)
 	ForModel(tag names.ModelTag) (Backend, error)
 	Model() (Model, error)
@@ -59,9 +59,16 @@ type ActionResult struct {
 	Status    string                 `json:"status,omitempty"`
 	Message   string                 `json:"message,omitempty"`
 	Output    map[string]interface{} `json:"output,omitempty"`
+	Log       []ActionMessage        `json:"log,omitempty"`
 	Error     *Error                 `json:"error,omitempty"`
 }
 
+// ActionMessage is a timestamped progress message logged by an Action.
+type ActionMessage struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
 // ActionsByReceivers wrap a slice of Actions for API calls.
 type ActionsByReceivers struct {
 	Actions []ActionsByReceiver `json:"actions,omitempty"`
@@ -121,6 +128,19 @@ type ActionExecutionResult struct {
 	Message   string                 `json:"message,omitempty"`
 }
 
+// ActionMessageParams holds the progress messages to log for a bulk
+// action API call.
+type ActionMessageParams struct {
+	Messages []ActionMessageParam `json:"messages"`
+}
+
+// ActionMessageParam holds a progress message to log for the Action
+// with the given tag.
+type ActionMessageParam struct {
+	Tag     string `json:"tag"`
+	Message string `json:"message"`
+}
+
 // ServicesCharmActionsResults holds a slice of ServiceCharmActionsResult for
 // a bulk result of charm Actions for Services.
 type ServicesCharmActionsResults struct {
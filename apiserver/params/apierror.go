@@ -72,6 +72,7 @@ const (
 	CodeNotProvisioned            = "not provisioned"
 	CodeNoAddressSet              = "no address set"
 	CodeTryAgain                  = "try again"
+	CodeRetryAfter                = "retry after"     // asserted to match rpc.codeRetryAfter in rpc/rpc_test.go
 	CodeNotImplemented            = "not implemented" // asserted to match rpc.codeNotImplemented in rpc/rpc_test.go
 	CodeAlreadyExists             = "already exists"
 	CodeUpgradeInProgress         = "upgrade in progress"
@@ -174,6 +175,13 @@ func IsCodeTryAgain(err error) bool {
 	return ErrCode(err) == CodeTryAgain
 }
 
+// IsCodeRetryAfter returns whether the error indicates that the API
+// server is too busy to handle the request, and that the client
+// should make it again after backing off.
+func IsCodeRetryAfter(err error) bool {
+	return ErrCode(err) == CodeRetryAfter
+}
+
 func IsCodeNotImplemented(err error) bool {
 	return ErrCode(err) == CodeNotImplemented
 }
@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
 	// DestroyModels specifies whether or not the hosted models
@@ -46,3 +48,69 @@ type ModelStatus struct {
 type ModelStatusResults struct {
 	Results []ModelStatus `json:"models"`
 }
+
+// RotateControllerCertificatesArgs holds the arguments for rotating a
+// controller's certificates.
+type RotateControllerCertificatesArgs struct {
+	// NewCA specifies whether the controller's CA should be
+	// replaced, rather than just its server certificates.
+	NewCA bool `json:"new-ca"`
+
+	// TransitionPeriod specifies how long the previous CA remains
+	// trusted once it has been replaced. If it is zero, a default
+	// period is used.
+	TransitionPeriod time.Duration `json:"transition-period"`
+}
+
+// RotateControllerCertificatesResult holds the result of rotating a
+// controller's certificates.
+type RotateControllerCertificatesResult struct {
+	// CACert holds the CA certificates that clients should now trust.
+	CACert string `json:"ca-cert"`
+
+	// TransitionEnd holds the time at which the previous CA stops
+	// being trusted, if it has been replaced.
+	TransitionEnd *time.Time `json:"transition-end,omitempty"`
+}
+
+// AuditLogFilter holds the arguments for querying a controller's
+// audit log. Empty fields do not restrict the entries returned.
+type AuditLogFilter struct {
+	// ModelTag restricts entries to calls made against the model.
+	ModelTag string `json:"model-tag,omitempty"`
+
+	// UserTag restricts entries to calls made by the user.
+	UserTag string `json:"user-tag,omitempty"`
+
+	// Facade and Method restrict entries to calls of the facade and
+	// method.
+	Facade string `json:"facade,omitempty"`
+	Method string `json:"method,omitempty"`
+
+	// From and To restrict entries to calls made no earlier than
+	// From, and earlier than To.
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+
+	// Limit restricts the entries returned to the most recent Limit
+	// entries.
+	Limit int `json:"limit,omitempty"`
+}
+
+// AuditLogEntry holds a single API call recorded in the audit log.
+type AuditLogEntry struct {
+	ModelTag string    `json:"model-tag"`
+	Time     time.Time `json:"time"`
+	UserTag  string    `json:"user-tag"` // the user's or agent's tag
+	Facade   string    `json:"facade"`
+	Version  int       `json:"version"`
+	Method   string    `json:"method"`
+	Args     string    `json:"args,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// AuditLogResults holds the entries of the audit log matching a
+// filter, oldest first.
+type AuditLogResults struct {
+	Entries []AuditLogEntry `json:"entries"`
+}
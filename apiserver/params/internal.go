@@ -100,12 +100,48 @@ type ModelCreateArgs struct {
 
 	// Account holds the provider specific account details necessary to
 	// interact with the provider to create, list and destroy machines.
-	Account map[string]interface{}
+	Account map[string]interface{} `audit:"redact"`
 
 	// Config defines the model config, which includes the name of the
 	// model.  An model UUID is allocated by the API server during
 	// the creation of the model.
-	Config map[string]interface{}
+	Config map[string]interface{} `audit:"redact"`
+
+	// Cloud and CloudCredential, if set, name a cloud credential
+	// owned by the model owner and stored in the controller. The
+	// credential's attributes override any Account values, and the
+	// model is updated whenever the credential is.
+	Cloud           string `json:",omitempty"`
+	CloudCredential string `json:",omitempty"`
+}
+
+// UpdateCloudCredential holds a cloud credential to be stored in the
+// controller.
+type UpdateCloudCredential struct {
+	OwnerTag   string
+	Cloud      string
+	Name       string
+	AuthType   string
+	Attributes map[string]string `audit:"redact"`
+}
+
+// UpdateCloudCredentials holds the arguments for
+// ModelManager.UpdateCloudCredentials.
+type UpdateCloudCredentials struct {
+	Credentials []UpdateCloudCredential
+}
+
+// UpdateCloudCredentialResult holds the tags of the models updated
+// with a new cloud credential, or an error.
+type UpdateCloudCredentialResult struct {
+	Models []string
+	Error  *Error
+}
+
+// UpdateCloudCredentialResults holds the results of
+// ModelManager.UpdateCloudCredentials.
+type UpdateCloudCredentialResults struct {
+	Results []UpdateCloudCredentialResult
 }
 
 // Model holds the result of an API call returning a name and UUID
@@ -197,6 +233,19 @@ type SettingsResults struct {
 	Results []SettingsResult
 }
 
+// SetCharmStateArg holds the key-value pairs to persist as the charm
+// state of a unit.
+type SetCharmStateArg struct {
+	Tag   string
+	State Settings
+}
+
+// SetCharmStateArgs holds the charm state to persist for one or more
+// units.
+type SetCharmStateArgs struct {
+	Args []SetCharmStateArg
+}
+
 // ConfigSettings holds unit, service or cham configuration settings
 // with string keys and arbitrary values.
 type ConfigSettings map[string]interface{}
@@ -267,11 +316,12 @@ type RelationUnitsSettings struct {
 // RelationResult returns information about a single relation,
 // or an error.
 type RelationResult struct {
-	Error    *Error
-	Life     Life
-	Id       int
-	Key      string
-	Endpoint multiwatcher.Endpoint
+	Error     *Error
+	Life      Life
+	Suspended bool
+	Id        int
+	Key       string
+	Endpoint  multiwatcher.Endpoint
 }
 
 // RelationResults holds the result of an API call that returns
@@ -382,7 +432,13 @@ type AgentGetEntitiesResult struct {
 // DesiredVersion() API call.
 type VersionResult struct {
 	Version *version.Number
-	Error   *Error
+
+	// Rollback reports whether Version is the version an upgrade
+	// was rolled back to, so that agents which have already
+	// upgraded may downgrade to it.
+	Rollback bool
+
+	Error *Error
 }
 
 // VersionResults is a list of versions for the requested entities.
@@ -570,6 +626,7 @@ type ProvisioningInfo struct {
 	SubnetsToZones   map[string][]string
 	ImageMetadata    []CloudImageMetadata
 	EndpointBindings map[string]string
+	ContainerProfile *instance.ContainerProfile
 }
 
 // ProvisioningInfoResult holds machine provisioning info or an error.
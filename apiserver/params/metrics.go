@@ -36,3 +36,64 @@ type MetricResult struct {
 	Key   string    `json:"key"`
 	Value string    `json:"value"`
 }
+
+// Metric aggregation functions supported by AggregateMetrics.
+const (
+	AggregateSum = "sum"
+	AggregateAvg = "avg"
+	AggregateMax = "max"
+)
+
+// AggregateMetricsArgs holds the arguments for aggregating
+// the metrics of a number of units or services.
+type AggregateMetricsArgs struct {
+	Args []AggregateMetricsArg `json:"args"`
+}
+
+// AggregateMetricsArg holds the arguments for aggregating the
+// metrics of a unit or service.
+type AggregateMetricsArg struct {
+	// Tag holds the tag of the unit or service.
+	Tag string `json:"tag"`
+
+	// Key, if not empty, restricts aggregation to
+	// metrics with the given key.
+	Key string `json:"key,omitempty"`
+
+	// Since, if not zero, restricts aggregation to
+	// metrics recorded at or after the given time.
+	Since time.Time `json:"since"`
+
+	// Aggregate holds the aggregation function to apply:
+	// AggregateSum, AggregateAvg or AggregateMax.
+	Aggregate string `json:"aggregate"`
+
+	// Interval, if not zero, holds the length of the intervals
+	// over which metrics are aggregated. If it is zero, all
+	// matching metrics with the same key are aggregated together.
+	Interval time.Duration `json:"interval,omitempty"`
+}
+
+// AggregateMetricsResults holds the results of aggregating metrics.
+type AggregateMetricsResults struct {
+	Results []AggregateMetricsResult `json:"results"`
+}
+
+// AggregateMetricsResult holds the aggregated metrics of
+// a unit or service, ordered by time and then key.
+type AggregateMetricsResult struct {
+	Metrics []AggregatedMetric `json:"metrics,omitempty"`
+	Error   *Error             `json:"error,omitempty"`
+}
+
+// AggregatedMetric holds the aggregated values of a metric
+// over an interval.
+type AggregatedMetric struct {
+	// Time holds the start of the interval.
+	Time  time.Time `json:"time"`
+	Key   string    `json:"key"`
+	Value float64   `json:"value"`
+
+	// Count holds the number of values that were aggregated.
+	Count int `json:"count"`
+}
@@ -23,7 +23,7 @@ type ModelMigrationTargetInfo struct {
 	Addrs         []string `json:"addrs"`
 	CACert        string   `json:"ca-cert"`
 	AuthTag       string   `json:"auth-tag"`
-	Password      string   `json:"password"`
+	Password      string   `json:"password" audit:"redact"`
 }
 
 // InitiateModelMigrationResults is used to return the result of one
@@ -18,7 +18,7 @@ type ModelConfigResults struct {
 // ModelSet contains the arguments for ModelSet client API
 // call.
 type ModelSet struct {
-	Config map[string]interface{}
+	Config map[string]interface{} `audit:"redact"`
 }
 
 // ModelUnset contains the arguments for ModelUnset client API
@@ -33,6 +33,23 @@ type SetModelAgentVersion struct {
 	Version version.Number
 }
 
+// SetMachineAgentVersions contains the arguments for the
+// SetMachineAgentVersions client API call: the machines whose agents
+// should be upgraded ahead of the rest of the model, and the version
+// they should be upgraded to.
+type SetMachineAgentVersions struct {
+	Version  version.Number
+	Machines []Entity
+}
+
+// RollbackUpgradeResult holds the result of the RollbackUpgrade client
+// API call: the agent version the model was rolled back to, and the id
+// of any backup taken before the abandoned upgrade's steps were run.
+type RollbackUpgradeResult struct {
+	PreviousVersion version.Number
+	SnapshotID      string
+}
+
 // ModelInfo holds information about the Juju model.
 type ModelInfo struct {
 	// The json names for the fields below are as per the older
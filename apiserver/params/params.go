@@ -57,7 +57,7 @@ type EntityPasswords struct {
 // with the given tag.
 type EntityPassword struct {
 	Tag      string
-	Password string
+	Password string `audit:"redact"`
 }
 
 // ErrorResults holds the results of calling a bulk operation which
@@ -119,6 +119,26 @@ type DestroyRelation struct {
 	Endpoints []string
 }
 
+// SetRelationSuspended holds the parameters for making the
+// SetRelationSuspended call. The endpoints specified are unordered.
+type SetRelationSuspended struct {
+	Endpoints []string `json:"endpoints"`
+	Suspended bool     `json:"suspended"`
+}
+
+// TransferLeadership holds the parameters for making the
+// TransferLeadership call.
+type TransferLeadership struct {
+	UnitName string `json:"unit-name"`
+}
+
+// SetLeadershipPinned holds the parameters for making the
+// SetLeadershipPinned call.
+type SetLeadershipPinned struct {
+	ServiceName string `json:"service-name"`
+	Pinned      bool   `json:"pinned"`
+}
+
 // AddCharm holds the arguments for making an AddCharm API call.
 type AddCharm struct {
 	URL     string
@@ -129,7 +149,7 @@ type AddCharm struct {
 type AddCharmWithAuthorization struct {
 	URL                string
 	Channel            string
-	CharmStoreMacaroon *macaroon.Macaroon
+	CharmStoreMacaroon *macaroon.Macaroon `audit:"redact"`
 }
 
 // AddMachineParams encapsulates the parameters used to create a new machine.
@@ -208,8 +228,8 @@ type ServiceDeploy struct {
 	CharmUrl         string
 	Channel          string
 	NumUnits         int
-	Config           map[string]string
-	ConfigYAML       string // Takes precedence over config if both are present.
+	Config           map[string]string `audit:"redact"`
+	ConfigYAML       string            `audit:"redact"` // Takes precedence over config if both are present.
 	Constraints      constraints.Value
 	Placement        []*instance.Placement
 	Storage          map[string]storage.Constraints
@@ -224,8 +244,8 @@ type ServiceUpdate struct {
 	ForceCharmUrl   bool
 	ForceSeries     bool
 	MinUnits        *int
-	SettingsStrings map[string]string
-	SettingsYAML    string // Takes precedence over SettingsStrings if both are present.
+	SettingsStrings map[string]string `audit:"redact"`
+	SettingsYAML    string            `audit:"redact"` // Takes precedence over SettingsStrings if both are present.
 	Constraints     *constraints.Value
 }
 
@@ -256,7 +276,7 @@ type ServiceExpose struct {
 // command. Options contains the configuration data.
 type ServiceSet struct {
 	ServiceName string
-	Options     map[string]string
+	Options     map[string]string `audit:"redact"`
 }
 
 // ServiceUnset holds the parameters for a service Unset
@@ -299,7 +319,7 @@ type ServiceUnexpose struct {
 // ServiceMetricCredential holds parameters for the SetServiceCredentials call.
 type ServiceMetricCredential struct {
 	ServiceName       string
-	MetricCredentials []byte
+	MetricCredentials []byte `audit:"redact"`
 }
 
 // ServiceMetricCredentials holds multiple ServiceMetricCredential parameters.
@@ -382,6 +402,11 @@ type LoginRequest struct {
 	Credentials string           `json:"credentials"`
 	Nonce       string           `json:"nonce"`
 	Macaroons   []macaroon.Slice `json:"macaroons"`
+
+	// MessageFeatures holds the message format features, such as
+	// "deflate", that the client can read and would like the server
+	// to use.
+	MessageFeatures []string `json:"message-features,omitempty"`
 }
 
 // LoginRequestCompat holds credentials for identifying an entity to the Login v1
@@ -423,6 +448,66 @@ type SetConstraints struct {
 	Constraints constraints.Value
 }
 
+// GetServiceContainerProfile stores parameters for making the
+// GetContainerProfile call.
+type GetServiceContainerProfile struct {
+	ServiceName string `json:"service-name"`
+}
+
+// ContainerProfileResult holds the result of the GetContainerProfile call.
+type ContainerProfileResult struct {
+	Profile instance.ContainerProfile `json:"profile"`
+}
+
+// SetContainerProfile stores parameters for making the
+// SetContainerProfile call.
+type SetContainerProfile struct {
+	ServiceName string                    `json:"service-name"`
+	Profile     instance.ContainerProfile `json:"profile"`
+}
+
+// ServiceOffer holds the parameters for offering a service's endpoints
+// to other models.
+type ServiceOffer struct {
+	ServiceName string   `json:"service-name"`
+	Endpoints   []string `json:"endpoints,omitempty"`
+	OfferName   string   `json:"offer-name,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// ServiceOffers holds the parameters for making the Offer call.
+type ServiceOffers struct {
+	Offers []ServiceOffer `json:"offers"`
+}
+
+// ServiceOfferDetails describes an offer made by a model hosted by the
+// controller.
+type ServiceOfferDetails struct {
+	URL            string           `json:"url"`
+	OfferName      string           `json:"offer-name"`
+	SourceModelTag string           `json:"source-model-tag"`
+	ServiceName    string           `json:"service-name"`
+	Endpoints      []RemoteEndpoint `json:"endpoints"`
+	Description    string           `json:"description,omitempty"`
+	OwnerTag       string           `json:"owner-tag"`
+}
+
+// ServiceOfferResult holds the result of offering a service.
+type ServiceOfferResult struct {
+	Result *ServiceOfferDetails `json:"result,omitempty"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// ServiceOfferResults holds the results of the Offer call.
+type ServiceOfferResults struct {
+	Results []ServiceOfferResult `json:"results"`
+}
+
+// ListServiceOffersResults holds the result of the ListOffers call.
+type ListServiceOffersResults struct {
+	Offers []ServiceOfferDetails `json:"offers"`
+}
+
 // ResolveCharms stores charm references for a ResolveCharms call.
 type ResolveCharms struct {
 	References []charm.URL
@@ -444,6 +529,29 @@ type AllWatcherId struct {
 	AllWatcherId string
 }
 
+// AllWatcherFilter restricts the deltas returned by an AllWatcher.
+// Empty fields place no restriction on the deltas.
+type AllWatcherFilter struct {
+	// Kinds holds the kinds of the entities of interest, such
+	// as "unit" or "service".
+	Kinds []string `json:"kinds,omitempty"`
+
+	// ModelUUIDs holds the UUIDs of the models of interest. It
+	// is only useful when watching all models in the controller.
+	ModelUUIDs []string `json:"model-uuids,omitempty"`
+
+	// Services holds the names of the services whose entities
+	// are of interest. Entities that do not belong to a service,
+	// such as machines, are not affected.
+	Services []string `json:"services,omitempty"`
+
+	// Fields holds the names of the entity fields of interest,
+	// such as "WorkloadStatus". Changes to entities that have
+	// already been reported are only reported if they affect
+	// one of these fields.
+	Fields []string `json:"fields,omitempty"`
+}
+
 // AllWatcherNextResults holds deltas returned from calling AllWatcher.Next().
 type AllWatcherNextResults struct {
 	Deltas []multiwatcher.Delta
@@ -640,6 +748,11 @@ type LoginResultV1 struct {
 	// ServerVersion is the string representation of the server version
 	// if the server supports it.
 	ServerVersion string `json:"server-version,omitempty"`
+
+	// MessageFeatures holds the message format features requested
+	// by the client that the server will use from now on. Messages
+	// sent by the client may use any of them.
+	MessageFeatures []string `json:"message-features,omitempty"`
 }
 
 // ControllersServersSpec contains arguments for
@@ -29,6 +29,11 @@ type FullStatus struct {
 	Machines         map[string]MachineStatus
 	Services         map[string]ServiceStatus
 	Relations        []RelationStatus
+	RemoteServices   map[string]RemoteServiceStatus
+
+	// CertificateWarnings holds warnings about controller
+	// certificates which have expired or will expire soon.
+	CertificateWarnings []string
 }
 
 // MachineStatus holds status info about a machine.
@@ -61,6 +66,26 @@ type ServiceStatus struct {
 	Status        DetailedStatus
 }
 
+// RemoteServiceStatus holds status info about a remote service: a
+// service hosted by another model, with which services in this model
+// may be related.
+type RemoteServiceStatus struct {
+	Err               error
+	URL               string
+	SourceModelTag    string
+	SourceServiceName string
+	Endpoints         []RemoteEndpoint
+	Life              string
+	Relations         map[string][]string
+}
+
+// RemoteEndpoint describes an endpoint of a remote service.
+type RemoteEndpoint struct {
+	Name      string
+	Role      charm.RelationRole
+	Interface string
+}
+
 // MeterStatus represents the meter status of a unit.
 type MeterStatus struct {
 	Color   string
@@ -89,6 +114,7 @@ type RelationStatus struct {
 	Interface string
 	Scope     charm.RelationScope
 	Endpoints []EndpointStatus
+	Suspended bool
 }
 
 // EndpointStatus holds status info about a single endpoint
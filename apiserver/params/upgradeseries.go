@@ -0,0 +1,58 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// UpgradeSeriesStatus describes how far a machine or unit agent has
+// progressed through an in-place series upgrade.
+type UpgradeSeriesStatus string
+
+const (
+	UpgradeSeriesNotStarted       UpgradeSeriesStatus = ""
+	UpgradeSeriesPrepareStarted   UpgradeSeriesStatus = "prepare started"
+	UpgradeSeriesPrepareCompleted UpgradeSeriesStatus = "prepare completed"
+	UpgradeSeriesCompleteStarted  UpgradeSeriesStatus = "complete started"
+	UpgradeSeriesCompleted        UpgradeSeriesStatus = "completed"
+)
+
+// UpgradeSeriesArgs holds the arguments for preparing or completing
+// in-place series upgrades of one or more machines.
+type UpgradeSeriesArgs struct {
+	Args []UpgradeSeriesArg `json:"args"`
+}
+
+// UpgradeSeriesArg identifies a machine to be upgraded and, when
+// preparing the upgrade, the series it is to be upgraded to.
+type UpgradeSeriesArg struct {
+	Entity Entity `json:"entity"`
+	Series string `json:"series,omitempty"`
+}
+
+// SetUpgradeSeriesStatusArgs holds the progress of one or more agents
+// through an in-place series upgrade.
+type SetUpgradeSeriesStatusArgs struct {
+	Args []SetUpgradeSeriesStatusArg `json:"args"`
+}
+
+// SetUpgradeSeriesStatusArg holds the progress of a single machine or
+// unit agent through an in-place series upgrade.
+type SetUpgradeSeriesStatusArg struct {
+	Entity Entity              `json:"entity"`
+	Status UpgradeSeriesStatus `json:"status"`
+}
+
+// UpgradeSeriesStatusResults holds the series upgrade status of one
+// or more agents.
+type UpgradeSeriesStatusResults struct {
+	Results []UpgradeSeriesStatusResult `json:"results"`
+}
+
+// UpgradeSeriesStatusResult holds the series upgrade status of a
+// single machine or unit agent. An empty status indicates that no
+// series upgrade is in progress.
+type UpgradeSeriesStatusResult struct {
+	Status     UpgradeSeriesStatus `json:"status,omitempty"`
+	FromSeries string              `json:"from-series,omitempty"`
+	ToSeries   string              `json:"to-series,omitempty"`
+	Error      *Error              `json:"error,omitempty"`
+}
@@ -51,7 +51,7 @@ type AddUser struct {
 	// and returned in AddUserResult. It will not
 	// be possible to login with a password until
 	// registration with the secret key is completed.
-	Password string `json:"password,omitempty"`
+	Password string `json:"password,omitempty" audit:"redact"`
 
 	// ModelAccess is the permission that the user will have to access the models.
 	ModelAccess ModelAccessPermission `json:"model-access-permission,omitempty"`
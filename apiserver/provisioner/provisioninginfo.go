@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/cloudimagemetadata"
 	"github.com/juju/juju/state/multiwatcher"
@@ -88,6 +89,10 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine) (*params.Provisio
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot get available image metadata")
 	}
+	containerProfile, err := p.machineContainerProfile(m)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot determine machine container profile")
+	}
 
 	return &params.ProvisioningInfo{
 		Constraints:      cons,
@@ -99,6 +104,7 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine) (*params.Provisio
 		SubnetsToZones:   subnetsToZones,
 		EndpointBindings: endpointBindings,
 		ImageMetadata:    imageMetadata,
+		ContainerProfile: containerProfile,
 	}, nil
 }
 
@@ -317,6 +323,47 @@ func (p *ProvisionerAPI) machineEndpointBindings(m *state.Machine) (map[string]s
 	return combinedBindings, nil
 }
 
+// machineContainerProfile returns the combined container profiles of the
+// services with principal units on the machine, or nil if the machine is
+// not a container or no profile applies.
+func (p *ProvisionerAPI) machineContainerProfile(m *state.Machine) (*instance.ContainerProfile, error) {
+	if m.ContainerType() == "" {
+		return nil, nil
+	}
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var combined instance.ContainerProfile
+	processedServicesSet := set.NewStrings()
+	for _, unit := range units {
+		if !unit.IsPrincipal() {
+			continue
+		}
+		service, err := unit.Service()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if processedServicesSet.Contains(service.Name()) {
+			continue
+		}
+		processedServicesSet.Add(service.Name())
+		profile, err := service.ContainerProfile()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		combined, err = combined.Merge(profile)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot apply container profile of service %q", service.Name())
+		}
+	}
+	if combined.IsEmpty() {
+		return nil, nil
+	}
+	return &combined, nil
+}
+
 func (p *ProvisionerAPI) allSpaceNamesToProviderIds() (map[string]string, error) {
 	allSpaces, err := p.st.AllSpaces()
 	if err != nil {
@@ -0,0 +1,133 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/juju/names"
+	"github.com/juju/ratelimit"
+	"golang.org/x/net/websocket"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/wscodec"
+)
+
+// refuseTimeout holds how long a refused connection is kept open
+// waiting for its first request before it is closed anyway.
+const refuseTimeout = 30 * time.Second
+
+// tooManyConnections reports whether the server already has as many
+// active connections as it has been configured to admit, so that
+// another connection must be refused. Concurrently accepted
+// connections may briefly take the server over the limit.
+func (srv *Server) tooManyConnections() bool {
+	if srv.maxConnections == 0 {
+		return false
+	}
+	return atomic.LoadInt32(&srv.connections) >= srv.maxConnections
+}
+
+// refuseConn serves a connection that was accepted while the server
+// had too many connections. Its first request, usually a login, fails
+// with a "retry after" error so that the client backs off, and the
+// connection is then closed. Refused connections are not counted as
+// active, and never acquire a State.
+func (srv *Server) refuseConn(wsConn *websocket.Conn) {
+	codec := &closeAfterReplyCodec{Codec: wscodec.New(wsConn)}
+	conn := rpc.NewConn(codec, nil)
+	conn.ServeFinder(&errRoot{common.ErrRetryAfter}, serverError)
+	conn.Start()
+	select {
+	case <-conn.Dead():
+	case <-time.After(refuseTimeout):
+	case <-srv.tomb.Dying():
+	}
+	if err := conn.Close(); err != nil {
+		logger.Debugf("error closing refused API connection: %v", err)
+	}
+}
+
+// closeAfterReplyCodec is an rpc.Codec that closes itself once it has
+// written a message.
+type closeAfterReplyCodec struct {
+	rpc.Codec
+}
+
+// WriteMessage is part of the rpc.Codec interface.
+func (c *closeAfterReplyCodec) WriteMessage(hdr *rpc.Header, body interface{}) error {
+	err := c.Codec.WriteMessage(hdr, body)
+	c.Codec.Close()
+	return err
+}
+
+// entityBucket holds the token bucket limiting the RPC requests of
+// an authenticated entity, and the number of the entity's connections
+// that share it.
+type entityBucket struct {
+	bucket      *ratelimit.Bucket
+	connections int
+}
+
+// requestLimiter returns the rate limiter to apply to the RPC
+// requests of an API connection logged in as the entity with the
+// given tag, or nil if requests are not limited. All connections
+// logged in as the same entity share the same limit.
+//
+// The returned function must be called once the connection is
+// closed. The entity's bucket is discarded when its last connection
+// is closed.
+func (srv *Server) requestLimiter(tag names.Tag) (rpc.RateLimiter, func()) {
+	if srv.requestRate == 0 {
+		return nil, func() {}
+	}
+	key := tag.String()
+	srv.bucketsMutex.Lock()
+	defer srv.bucketsMutex.Unlock()
+	eb, ok := srv.buckets[key]
+	if !ok {
+		burst := srv.requestBurst
+		if burst == 0 {
+			burst = int64(math.Ceil(srv.requestRate))
+		}
+		eb = &entityBucket{
+			bucket: ratelimit.NewBucketWithRate(srv.requestRate, burst),
+		}
+		srv.buckets[key] = eb
+	}
+	eb.connections++
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			srv.bucketsMutex.Lock()
+			defer srv.bucketsMutex.Unlock()
+			eb.connections--
+			if eb.connections == 0 {
+				delete(srv.buckets, key)
+			}
+		})
+	}
+	return &requestLimiter{eb.bucket}, release
+}
+
+// requestLimiter implements rpc.RateLimiter by taking a token from
+// a bucket for each request.
+type requestLimiter struct {
+	bucket *ratelimit.Bucket
+}
+
+// Allow is part of the rpc.RateLimiter interface.
+func (l *requestLimiter) Allow(req rpc.Request) bool {
+	// Pings are never limited, so that a busy agent's
+	// connection is not mistaken for a dead one.
+	if req.Type == "Pinger" && req.Action == "Ping" {
+		return true
+	}
+	return l.bucket.TakeAvailable(1) == 1
+}
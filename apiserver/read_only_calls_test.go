@@ -10,6 +10,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 )
 
 type readOnlyCallsSuite struct {
@@ -33,7 +34,7 @@ func (*readOnlyCallsSuite) TestReadOnlyCallsExist(c *gc.C) {
 		maxVersion[facade.Name] = version
 	}
 
-	for _, name := range readOnlyCalls.Values() {
+	for _, name := range params.ReadOnlyCalls() {
 		parts := strings.Split(name, ".")
 		facade, method := parts[0], parts[1]
 		version := maxVersion[facade]
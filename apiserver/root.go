@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/rpcreflect"
+	"github.com/juju/juju/rpc/wscodec"
 	"github.com/juju/juju/state"
 )
 
@@ -42,8 +43,11 @@ type objectKey struct {
 // after it has logged in. It contains an rpc.MethodFinder which it
 // uses to dispatch Api calls appropriately.
 type apiHandler struct {
-	state     *state.State
-	rpcConn   *rpc.Conn
+	state   *state.State
+	rpcConn *rpc.Conn
+	// codec holds the codec used by rpcConn, if its message
+	// format can be negotiated at login.
+	codec     *wscodec.Codec
 	resources *common.Resources
 	entity    state.Entity
 	// An empty modelUUID means that the user has logged in through the
@@ -51,6 +55,9 @@ type apiHandler struct {
 	// path, logins processed with v2 or later will only offer the
 	// user manager and model manager api endpoints from here.
 	modelUUID string
+	// releaseRequestLimiter releases the rate limiter applied to
+	// rpcConn's requests, if any.
+	releaseRequestLimiter func()
 }
 
 var _ = (*apiHandler)(nil)
@@ -94,6 +101,24 @@ func (r *apiHandler) Kill() {
 	r.resources.StopAll()
 }
 
+// setRequestLimiter applies the given rate limiter to the requests
+// of the connection. The release function is called when the limiter
+// is replaced or the connection is closed.
+func (r *apiHandler) setRequestLimiter(limiter rpc.RateLimiter, release func()) {
+	r.releaseLimiter()
+	r.rpcConn.SetRateLimiter(limiter)
+	r.releaseRequestLimiter = release
+}
+
+// releaseLimiter releases the rate limiter applied to the requests of
+// the connection, if any.
+func (r *apiHandler) releaseLimiter() {
+	if r.releaseRequestLimiter != nil {
+		r.releaseRequestLimiter()
+		r.releaseRequestLimiter = nil
+	}
+}
+
 // srvCaller is our implementation of the rpcreflect.MethodCaller interface.
 // It lives just long enough to encapsulate the methods that should be
 // available for an RPC call and allow the RPC code to instantiate an object
@@ -10,6 +10,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -448,16 +449,147 @@ func (s *serverSuite) checkApiHandlerTeardown(c *gc.C, srvSt, st *state.State) {
 	c.Assert(resource.stopped, jc.IsTrue)
 }
 
+func (s *serverSuite) machineAPIInfo(c *gc.C, srv *apiserver.Server) *api.Info {
+	machine, password := s.Factory.MakeMachineReturningPassword(
+		c, &factory.MachineParams{Nonce: "fake_nonce"})
+	return &api.Info{
+		Tag:      machine.Tag(),
+		Password: password,
+		Nonce:    "fake_nonce",
+		Addrs:    []string{fmt.Sprintf("localhost:%d", srv.Addr().Port)},
+		CACert:   coretesting.CACert,
+		ModelTag: s.State.ModelTag(),
+	}
+}
+
+func (s *serverSuite) TestMaxConnections(c *gc.C) {
+	srv := newServerWithConfig(c, s.State, apiserver.ServerConfig{
+		MaxConnections: 1,
+	})
+	defer srv.Stop()
+	info := s.machineAPIInfo(c, srv)
+
+	st, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The refused connection is closed after the login fails, so
+	// the client gives up rather than retrying on it.
+	_, err = api.Open(info, fastDialOpts)
+	c.Assert(err, jc.Satisfies, params.IsCodeRetryAfter)
+
+	// Once the first connection has gone, logins are admitted again.
+	err = st.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		st, err = api.Open(info, fastDialOpts)
+		if err == nil {
+			st.Close()
+			return
+		}
+		c.Assert(err, jc.Satisfies, params.IsCodeRetryAfter)
+	}
+	c.Fatalf("timed out waiting for login to be admitted")
+}
+
+func (s *serverSuite) TestRequestRateLimited(c *gc.C) {
+	s.PatchValue(&api.RetryAfterAttempts, 1)
+	srv := newServerWithConfig(c, s.State, apiserver.ServerConfig{
+		RequestRate:  0.001,
+		RequestBurst: 1,
+	})
+	defer srv.Stop()
+	info := s.machineAPIInfo(c, srv)
+
+	st, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	machiner := apimachiner.NewState(st)
+	_, err = machiner.Machine(info.Tag.(names.MachineTag))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = machiner.Machine(info.Tag.(names.MachineTag))
+	c.Assert(err, jc.Satisfies, params.IsCodeRetryAfter)
+
+	// Pings are never limited.
+	err = st.Ping()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The limit is shared by all of the machine's connections.
+	st2, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st2.Close()
+	_, err = apimachiner.NewState(st2).Machine(info.Tag.(names.MachineTag))
+	c.Assert(err, jc.Satisfies, params.IsCodeRetryAfter)
+}
+
+func (s *serverSuite) TestRequestRateLimitReleased(c *gc.C) {
+	srv := newServerWithConfig(c, s.State, apiserver.ServerConfig{
+		RequestRate:  0.001,
+		RequestBurst: 1,
+	})
+	defer srv.Stop()
+	info := s.machineAPIInfo(c, srv)
+
+	st, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	st2, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apiserver.RequestBucketCount(srv), gc.Equals, 1)
+
+	// The bucket is kept while any of the machine's connections
+	// remain, and discarded once the last one is closed.
+	err = st.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	err = st2.Ping()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apiserver.RequestBucketCount(srv), gc.Equals, 1)
+
+	err = st2.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if apiserver.RequestBucketCount(srv) == 0 {
+			return
+		}
+	}
+	c.Fatalf("timed out waiting for the bucket to be discarded")
+}
+
+func (s *serverSuite) TestRequestRateLimitRetried(c *gc.C) {
+	s.PatchValue(&api.RetryAfterDelay, time.Millisecond)
+	srv := newServerWithConfig(c, s.State, apiserver.ServerConfig{
+		RequestRate:  20,
+		RequestBurst: 1,
+	})
+	defer srv.Stop()
+	info := s.machineAPIInfo(c, srv)
+
+	st, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+	machiner := apimachiner.NewState(st)
+	// The client backs off and retries until the server
+	// has capacity for each request.
+	for i := 0; i < 3; i++ {
+		_, err = machiner.Machine(info.Tag.(names.MachineTag))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
 // newServer returns a new running API server.
 func newServer(c *gc.C, st *state.State) *apiserver.Server {
+	return newServerWithConfig(c, st, apiserver.ServerConfig{})
+}
+
+// newServerWithConfig returns a new running API server with the
+// given configuration, to which the test certificate, key, tag and
+// log directory are added.
+func newServerWithConfig(c *gc.C, st *state.State, cfg apiserver.ServerConfig) *apiserver.Server {
 	listener, err := net.Listen("tcp", ":0")
 	c.Assert(err, jc.ErrorIsNil)
-	srv, err := apiserver.NewServer(st, listener, apiserver.ServerConfig{
-		Cert:   []byte(coretesting.ServerCert),
-		Key:    []byte(coretesting.ServerKey),
-		Tag:    names.NewMachineTag("0"),
-		LogDir: c.MkDir(),
-	})
+	cfg.Cert = []byte(coretesting.ServerCert)
+	cfg.Key = []byte(coretesting.ServerKey)
+	cfg.Tag = names.NewMachineTag("0")
+	cfg.LogDir = c.MkDir()
+	srv, err := apiserver.NewServer(st, listener, cfg)
 	c.Assert(err, jc.ErrorIsNil)
 	return srv
 }
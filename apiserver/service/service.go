@@ -7,8 +7,11 @@
 package service
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/names"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	goyaml "gopkg.in/yaml.v2"
@@ -547,12 +550,46 @@ func (api *API) SetConstraints(args params.SetConstraints) error {
 	return svc.SetConstraints(args.Constraints)
 }
 
+// GetContainerProfile returns the container profile for a given service.
+func (api *API) GetContainerProfile(args params.GetServiceContainerProfile) (params.ContainerProfileResult, error) {
+	svc, err := api.state.Service(args.ServiceName)
+	if err != nil {
+		return params.ContainerProfileResult{}, err
+	}
+	profile, err := svc.ContainerProfile()
+	return params.ContainerProfileResult{profile}, err
+}
+
+// SetContainerProfile sets the container profile for a given service.
+func (api *API) SetContainerProfile(args params.SetContainerProfile) error {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	svc, err := api.state.Service(args.ServiceName)
+	if err != nil {
+		return err
+	}
+	return svc.SetContainerProfile(args.Profile)
+}
+
 // AddRelation adds a relation between the specified endpoints and returns the relation info.
 func (api *API) AddRelation(args params.AddRelation) (params.AddRelationResults, error) {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return params.AddRelationResults{}, errors.Trace(err)
 	}
-	inEps, err := api.state.InferEndpoints(args.Endpoints...)
+	endpoints := make([]string, len(args.Endpoints))
+	for i, ep := range args.Endpoints {
+		if !strings.HasPrefix(ep, offerURLPrefix) {
+			endpoints[i] = ep
+			continue
+		}
+		name, err := api.consumeOffer(ep)
+		if err != nil {
+			return params.AddRelationResults{}, errors.Trace(err)
+		}
+		endpoints[i] = name
+	}
+	inEps, err := api.state.InferEndpoints(endpoints...)
 	if err != nil {
 		return params.AddRelationResults{}, err
 	}
@@ -571,6 +608,189 @@ func (api *API) AddRelation(args params.AddRelation) (params.AddRelationResults,
 	return params.AddRelationResults{Endpoints: outEps}, nil
 }
 
+// SetRelationSuspended suspends or resumes the relation between the
+// specified endpoints.
+func (api *API) SetRelationSuspended(args params.SetRelationSuspended) error {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	eps, err := api.state.InferEndpoints(args.Endpoints...)
+	if err != nil {
+		return err
+	}
+	rel, err := api.state.EndpointsRelation(eps...)
+	if err != nil {
+		return err
+	}
+	return rel.SetSuspended(args.Suspended)
+}
+
+// TransferLeadership arranges for leadership of the specified unit's
+// service to be handed to that unit once the current leader's lease
+// expires.
+func (api *API) TransferLeadership(args params.TransferLeadership) error {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	return api.state.TransferLeadership(args.UnitName)
+}
+
+// SetLeadershipPinned pins or unpins the leadership of the specified
+// service. While pinned, the service's leader keeps leadership even if
+// it stops renewing its claim.
+func (api *API) SetLeadershipPinned(args params.SetLeadershipPinned) error {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	if args.Pinned {
+		return api.state.PinLeadership(args.ServiceName)
+	}
+	return api.state.UnpinLeadership(args.ServiceName)
+}
+
+// offerURLPrefix prefixes the URLs of offers made by models hosted by
+// the controller.
+const offerURLPrefix = "local:"
+
+// consumeOffer ensures that the model has a remote service for the offer
+// with the given URL, and returns the remote service's name.
+func (api *API) consumeOffer(url string) (string, error) {
+	offer, err := api.state.ServiceOffer(url)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if canAccess, err := api.canAccessModel(offer.SourceModelUUID()); err != nil {
+		return "", errors.Trace(err)
+	} else if !canAccess {
+		return "", common.ErrPerm
+	}
+	remote, err := api.state.RemoteService(offer.OfferName())
+	if err == nil {
+		if remote.URL() != url {
+			return "", errors.Errorf(
+				"remote service %q already exists for offer %q",
+				remote.Name(), remote.URL(),
+			)
+		}
+		return remote.Name(), nil
+	} else if !errors.IsNotFound(err) {
+		return "", errors.Trace(err)
+	}
+	remote, err = api.state.AddRemoteService(state.AddRemoteServiceArgs{
+		Name:              offer.OfferName(),
+		URL:               url,
+		SourceModel:       names.NewModelTag(offer.SourceModelUUID()),
+		SourceServiceName: offer.ServiceName(),
+		Endpoints:         offer.Endpoints(),
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return remote.Name(), nil
+}
+
+// Offer makes the specified service endpoints available for relations
+// with services in other models hosted by the controller.
+func (api *API) Offer(args params.ServiceOffers) (params.ServiceOfferResults, error) {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ServiceOfferResults{}, errors.Trace(err)
+	}
+	owner, ok := api.authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return params.ServiceOfferResults{}, common.ErrPerm
+	}
+	results := make([]params.ServiceOfferResult, len(args.Offers))
+	for i, arg := range args.Offers {
+		offer, err := api.state.AddServiceOffer(state.ServiceOfferArgs{
+			OfferName:   arg.OfferName,
+			ServiceName: arg.ServiceName,
+			Endpoints:   arg.Endpoints,
+			Description: arg.Description,
+			Owner:       owner,
+		})
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		details := serviceOfferDetails(offer)
+		results[i].Result = &details
+	}
+	return params.ServiceOfferResults{Results: results}, nil
+}
+
+// ListOffers returns the offers made by the models hosted by the
+// controller that the authenticated user can access.
+func (api *API) ListOffers() (params.ListServiceOffersResults, error) {
+	offers, err := api.state.ServiceOffers()
+	if err != nil {
+		return params.ListServiceOffersResults{}, errors.Trace(err)
+	}
+	result := params.ListServiceOffersResults{
+		Offers: []params.ServiceOfferDetails{},
+	}
+	access := make(map[string]bool)
+	for _, offer := range offers {
+		modelUUID := offer.SourceModelUUID()
+		canAccess, ok := access[modelUUID]
+		if !ok {
+			canAccess, err = api.canAccessModel(modelUUID)
+			if err != nil {
+				return params.ListServiceOffersResults{}, errors.Trace(err)
+			}
+			access[modelUUID] = canAccess
+		}
+		if canAccess {
+			result.Offers = append(result.Offers, serviceOfferDetails(offer))
+		}
+	}
+	return result, nil
+}
+
+// canAccessModel returns whether the authenticated user can access the
+// model with the given UUID, either as a user of the model or as a
+// controller administrator.
+func (api *API) canAccessModel(modelUUID string) (bool, error) {
+	user, ok := api.authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return false, nil
+	}
+	st := api.state
+	if modelUUID != st.ModelUUID() {
+		modelSt, err := st.ForModel(names.NewModelTag(modelUUID))
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		defer modelSt.Close()
+		st = modelSt
+	}
+	if _, err := st.ModelUser(user); err == nil {
+		return true, nil
+	} else if !errors.IsNotFound(err) {
+		return false, errors.Trace(err)
+	}
+	isAdmin, err := api.state.IsControllerAdministrator(user)
+	return isAdmin, errors.Trace(err)
+}
+
+func serviceOfferDetails(offer *state.ServiceOffer) params.ServiceOfferDetails {
+	details := params.ServiceOfferDetails{
+		URL:            offer.URL(),
+		OfferName:      offer.OfferName(),
+		SourceModelTag: names.NewModelTag(offer.SourceModelUUID()).String(),
+		ServiceName:    offer.ServiceName(),
+		Description:    offer.Description(),
+		OwnerTag:       offer.Owner().String(),
+	}
+	for _, ep := range offer.Endpoints() {
+		details.Endpoints = append(details.Endpoints, params.RemoteEndpoint{
+			Name:      ep.Name,
+			Role:      ep.Role,
+			Interface: ep.Interface,
+		})
+	}
+	return details
+}
+
 // DestroyRelation removes the relation between the specified endpoints.
 func (api *API) DestroyRelation(args params.DestroyRelation) error {
 	if err := api.check.RemoveAllowed(); err != nil {
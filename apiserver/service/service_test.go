@@ -8,6 +8,7 @@ import (
 	"io"
 	"regexp"
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names"
@@ -21,11 +22,13 @@ import (
 	"gopkg.in/macaroon.v1"
 	"gopkg.in/mgo.v2"
 
+	"github.com/juju/juju/apiserver/common"
 	commontesting "github.com/juju/juju/apiserver/common/testing"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/apiserver/service"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
@@ -2448,6 +2451,168 @@ func (s *serviceSuite) TestClientGetServiceConstraints(c *gc.C) {
 	c.Assert(result.Constraints, gc.DeepEquals, cons)
 }
 
+func (s *serviceSuite) TestContainerProfile(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	profile := instance.ContainerProfile{
+		LXDConfig: map[string]string{"security.nesting": "true"},
+	}
+	err := s.serviceApi.SetContainerProfile(params.SetContainerProfile{
+		ServiceName: "dummy",
+		Profile:     profile,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.serviceApi.GetContainerProfile(params.GetServiceContainerProfile{"dummy"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Profile, jc.DeepEquals, profile)
+}
+
+func (s *serviceSuite) TestSetContainerProfileRejectsUnsafeKeys(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	err := s.serviceApi.SetContainerProfile(params.SetContainerProfile{
+		ServiceName: "dummy",
+		Profile: instance.ContainerProfile{
+			LXDConfig: map[string]string{"security.privileged": "true"},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `.*LXD config key "security.privileged" not valid`)
+}
+
+func (s *serviceSuite) TestBlockChangesSetContainerProfile(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.BlockAllChanges(c, "TestBlockChangesSetContainerProfile")
+	err := s.serviceApi.SetContainerProfile(params.SetContainerProfile{
+		ServiceName: "dummy",
+		Profile: instance.ContainerProfile{
+			LXDConfig: map[string]string{"security.nesting": "true"},
+		},
+	})
+	s.AssertBlocked(c, err, "TestBlockChangesSetContainerProfile")
+}
+
+func (s *serviceSuite) TestOfferAndListOffers(c *gc.C) {
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	results, err := s.serviceApi.Offer(params.ServiceOffers{
+		Offers: []params.ServiceOffer{{
+			ServiceName: "mysql",
+			OfferName:   "db",
+			Description: "a database",
+		}, {
+			ServiceName: "mysql",
+			Endpoints:   []string{"admin"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `cannot offer service "mysql": endpoint "admin" not found`)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	expected := params.ServiceOfferDetails{
+		URL:            "local:/u/admin@local/" + model.Name() + "/db",
+		OfferName:      "db",
+		SourceModelTag: s.State.ModelTag().String(),
+		ServiceName:    "mysql",
+		Endpoints: []params.RemoteEndpoint{{
+			Name:      "server",
+			Role:      charm.RoleProvider,
+			Interface: "mysql",
+		}},
+		Description: "a database",
+		OwnerTag:    s.AdminUserTag(c).String(),
+	}
+	c.Assert(*results.Results[0].Result, jc.DeepEquals, expected)
+
+	list, err := s.serviceApi.ListOffers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Offers, jc.DeepEquals, []params.ServiceOfferDetails{expected})
+}
+
+func (s *serviceSuite) TestBlockChangesOffer(c *gc.C) {
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.BlockAllChanges(c, "TestBlockChangesOffer")
+	_, err := s.serviceApi.Offer(params.ServiceOffers{
+		Offers: []params.ServiceOffer{{ServiceName: "mysql"}},
+	})
+	s.AssertBlocked(c, err, "TestBlockChangesOffer")
+}
+
+func (s *serviceSuite) TestAddRelationToOffer(c *gc.C) {
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	offers, err := s.serviceApi.Offer(params.ServiceOffers{
+		Offers: []params.ServiceOffer{{ServiceName: "mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	url := offers.Results[0].Result.URL
+
+	consumer := s.Factory.MakeModel(c, nil)
+	defer consumer.Close()
+	state.AddTestingService(c, consumer, "wordpress", state.AddTestingCharm(c, consumer, "wordpress"), s.AdminUserTag(c))
+	consumerApi, err := service.NewAPI(consumer, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.AddRelation{Endpoints: []string{"wordpress", url}}
+	result, err := consumerApi.AddRelation(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Endpoints["mysql"].Name, gc.Equals, "server")
+	c.Assert(result.Endpoints["wordpress"].Name, gc.Equals, "db")
+
+	// Relating to the offer again reuses the remote service.
+	_, err = consumerApi.AddRelation(args)
+	c.Assert(err, gc.ErrorMatches, `.*relation already exists`)
+
+	remote, err := consumer.RemoteService("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(remote.URL(), gc.Equals, url)
+	c.Assert(remote.SourceModel(), gc.Equals, s.State.ModelTag())
+}
+
+func (s *serviceSuite) TestAddRelationToOfferPermissionDenied(c *gc.C) {
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	offers, err := s.serviceApi.Offer(params.ServiceOffers{
+		Offers: []params.ServiceOffer{{ServiceName: "mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	url := offers.Results[0].Result.URL
+
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	consumer := s.Factory.MakeModel(c, &factory.ModelParams{Owner: user.Tag()})
+	defer consumer.Close()
+	state.AddTestingService(c, consumer, "wordpress", state.AddTestingCharm(c, consumer, "wordpress"), user.UserTag())
+	consumerApi, err := service.NewAPI(consumer, nil, apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = consumerApi.AddRelation(params.AddRelation{Endpoints: []string{"wordpress", url}})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+	_, err = consumer.RemoteService("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *serviceSuite) TestListOffersFiltersByModelAccess(c *gc.C) {
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	_, err := s.serviceApi.Offer(params.ServiceOffers{
+		Offers: []params.ServiceOffer{{ServiceName: "mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	userApi, err := service.NewAPI(s.State, nil, apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	list, err := userApi.ListOffers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Offers, gc.HasLen, 0)
+
+	s.Factory.MakeModelUser(c, &factory.ModelUserParams{User: user.UserTag().Canonical()})
+	list, err = userApi.ListOffers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Offers, gc.HasLen, 1)
+}
+
 func (s *serviceSuite) checkEndpoints(c *gc.C, endpoints map[string]charm.Relation) {
 	c.Assert(endpoints["wordpress"], gc.DeepEquals, charm.Relation{
 		Name:      "db",
@@ -2589,6 +2754,97 @@ func (s *serviceSuite) TestSuccessfullyDestroyRelationSwapped(c *gc.C) {
 	s.assertDestroyRelation(c, endpoints)
 }
 
+func (s *serviceSuite) TestSetRelationSuspended(c *gc.C) {
+	endpoints := []string{"wordpress", "mysql"}
+	relation := s.setupDestroyRelationScenario(c, endpoints)
+	err := s.serviceApi.SetRelationSuspended(params.SetRelationSuspended{
+		Endpoints: endpoints,
+		Suspended: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relation.Refresh(), jc.ErrorIsNil)
+	c.Assert(relation.Suspended(), jc.IsTrue)
+
+	err = s.serviceApi.SetRelationSuspended(params.SetRelationSuspended{
+		Endpoints: endpoints,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relation.Refresh(), jc.ErrorIsNil)
+	c.Assert(relation.Suspended(), jc.IsFalse)
+}
+
+func (s *serviceSuite) TestBlockChangesSetRelationSuspended(c *gc.C) {
+	endpoints := []string{"wordpress", "mysql"}
+	s.setupDestroyRelationScenario(c, endpoints)
+	s.BlockAllChanges(c, "TestBlockChangesSetRelationSuspended")
+	err := s.serviceApi.SetRelationSuspended(params.SetRelationSuspended{
+		Endpoints: endpoints,
+		Suspended: true,
+	})
+	s.AssertBlocked(c, err, "TestBlockChangesSetRelationSuspended")
+}
+
+func (s *serviceSuite) TestTransferLeadership(c *gc.C) {
+	service := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit0, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	unit1, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	claimer := s.State.LeadershipClaimer()
+	err = claimer.ClaimLeadership("wordpress", unit0.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.serviceApi.TransferLeadership(params.TransferLeadership{UnitName: unit1.Name()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The current leader can no longer extend its lease.
+	err = claimer.ClaimLeadership("wordpress", unit0.Name(), time.Minute)
+	c.Assert(err, gc.Equals, leadership.ErrClaimDenied)
+}
+
+func (s *serviceSuite) TestTransferLeadershipNoLeader(c *gc.C) {
+	service := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.serviceApi.TransferLeadership(params.TransferLeadership{UnitName: unit.Name()})
+	c.Assert(err, gc.ErrorMatches, `cannot transfer leadership to "wordpress/0": service "wordpress" has no leader`)
+}
+
+func (s *serviceSuite) TestSetLeadershipPinned(c *gc.C) {
+	service := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit0, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	unit1, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.LeadershipClaimer().ClaimLeadership("wordpress", unit0.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.serviceApi.SetLeadershipPinned(params.SetLeadershipPinned{
+		ServiceName: "wordpress",
+		Pinned:      true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.serviceApi.TransferLeadership(params.TransferLeadership{UnitName: unit1.Name()})
+	c.Assert(err, gc.ErrorMatches, `.*leadership of service "wordpress" is pinned`)
+
+	err = s.serviceApi.SetLeadershipPinned(params.SetLeadershipPinned{
+		ServiceName: "wordpress",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.serviceApi.TransferLeadership(params.TransferLeadership{UnitName: unit1.Name()})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *serviceSuite) TestBlockChangesSetLeadershipPinned(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.BlockAllChanges(c, "TestBlockChangesSetLeadershipPinned")
+	err := s.serviceApi.SetLeadershipPinned(params.SetLeadershipPinned{
+		ServiceName: "wordpress",
+		Pinned:      true,
+	})
+	s.AssertBlocked(c, err, "TestBlockChangesSetLeadershipPinned")
+}
+
 func (s *serviceSuite) TestNoRelation(c *gc.C) {
 	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
 	endpoints := []string{"wordpress", "mysql"}
@@ -0,0 +1,197 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"golang.org/x/net/websocket"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// sshTunnelPort is the port of the SSH servers that tunnels are
+// connected to.
+var sshTunnelPort = 22
+
+// sshTunnelDialTimeout is how long to wait for the connection to a
+// target's SSH server to be established.
+const sshTunnelDialTimeout = 30 * time.Second
+
+// sshTunnelHandler relays SSH sessions between users and the machines
+// in a model over a websocket, so that users need no network route to
+// the machines themselves. Every session is recorded in the audit log.
+type sshTunnelHandler struct {
+	ctxt httpContext
+}
+
+// sshSession describes an SSH session. It is recorded, serialised as
+// JSON, as the arguments of the session's audit log entry.
+type sshSession struct {
+	Target   string `json:"target"`
+	Address  string `json:"address,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// ServeHTTP will serve up connections as a websocket relaying an SSH
+// session.
+//
+// The target of the session is given by the "target" argument of the
+// HTTP request, holding the tag of a machine or unit. Users need write
+// access to the model. The first line sent on the websocket is a JSON
+// error result; when it holds no error, everything after it is relayed
+// to and from the SSH server of the target's machine.
+func (h *sshTunnelHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	server := websocket.Server{
+		Handler: func(socket *websocket.Conn) {
+			defer socket.Close()
+
+			st, entity, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+			if err != nil {
+				h.sendError(socket, req, err)
+				return
+			}
+			user := entity.Tag().(names.UserTag)
+			session := sshSession{
+				Target: req.URL.Query().Get("target"),
+			}
+			clock := h.ctxt.srv.clock
+			started := clock.Now()
+			conn, err := h.connect(st, user, session.Target)
+			if err != nil {
+				h.sendError(socket, req, err)
+				session.Duration = clock.Now().Sub(started).String()
+				h.record(st, user, session, err)
+				return
+			}
+			session.Address = conn.RemoteAddr().String()
+			logger.Infof("%s started SSH session with %s (%s)", user.Canonical(), session.Target, session.Address)
+
+			h.sendError(socket, req, nil)
+			socket.PayloadType = websocket.BinaryFrame
+			if err := relaySSH(socket, conn, h.ctxt.stop()); err != nil && !isBrokenPipe(err) {
+				logger.Debugf("SSH session with %s ended: %v", session.Target, err)
+			}
+			session.Duration = clock.Now().Sub(started).String()
+			logger.Infof("%s ended SSH session with %s after %s", user.Canonical(), session.Target, session.Duration)
+			h.record(st, user, session, nil)
+		},
+	}
+	server.ServeHTTP(w, req)
+}
+
+// connect checks that the user may access the target and connects to
+// the SSH server of its machine.
+func (h *sshTunnelHandler) connect(st *state.State, user names.UserTag, target string) (net.Conn, error) {
+	modelUser, err := st.ModelUser(user)
+	if errors.IsNotFound(err) {
+		return nil, common.ErrPerm
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if modelUser.ReadOnly() {
+		return nil, common.ErrPerm
+	}
+	machine, err := sshTunnelMachine(st, target)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	addr, err := machine.PrivateAddress()
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get address of machine %s", machine.Id())
+	}
+	hostPort := net.JoinHostPort(addr.Value, strconv.Itoa(sshTunnelPort))
+	conn, err := net.DialTimeout("tcp", hostPort, sshTunnelDialTimeout)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot connect to machine %s", machine.Id())
+	}
+	return conn, nil
+}
+
+// sshTunnelMachine returns the machine whose SSH server serves the
+// target with the given tag.
+func sshTunnelMachine(st *state.State, target string) (*state.Machine, error) {
+	tag, err := names.ParseTag(target)
+	if err != nil {
+		return nil, errors.NotValidf("SSH target %q", target)
+	}
+	switch tag := tag.(type) {
+	case names.MachineTag:
+		return st.Machine(tag.Id())
+	case names.UnitTag:
+		unit, err := st.Unit(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machineId, err := unit.AssignedMachineId()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return st.Machine(machineId)
+	}
+	return nil, errors.NotValidf("SSH target %q", target)
+}
+
+// relaySSH copies data between the websocket and the connection to the
+// SSH server until either of them is closed or the handler is stopped.
+func relaySSH(socket *websocket.Conn, conn net.Conn, stop <-chan struct{}) error {
+	defer conn.Close()
+	done := make(chan error, 2)
+	go func() {
+		_, err := io.Copy(conn, socket)
+		done <- err
+	}()
+	go func() {
+		_, err := io.Copy(socket, conn)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-stop:
+		return nil
+	}
+}
+
+// record adds an entry for the session to the audit log. Failure to
+// record the entry is logged, but does not fail the session.
+func (h *sshTunnelHandler) record(st *state.State, user names.UserTag, session sshSession, sessionErr error) {
+	args, err := json.Marshal(session)
+	if err != nil {
+		logger.Warningf("cannot serialise SSH session for audit log: %v", err)
+	}
+	entry := state.AuditEntry{
+		Time:   h.ctxt.srv.clock.Now(),
+		User:   user.Canonical(),
+		Facade: "SSH",
+		Method: "Session",
+		Args:   string(args),
+	}
+	if sessionErr != nil {
+		entry.Error = sessionErr.Error()
+	}
+	if err := st.AddAuditEntry(entry); err != nil {
+		logger.Errorf("cannot record SSH session by %s in audit log: %v", entry.User, err)
+	}
+}
+
+// sendError sends a JSON-encoded error result, which is nil when the
+// session has been established.
+func (h *sshTunnelHandler) sendError(w io.Writer, req *http.Request, err error) {
+	if err != nil {
+		logger.Errorf("returning error from %s %s: %s", req.Method, req.URL.Path, errors.Details(err))
+	}
+	sendJSON(w, &params.ErrorResult{
+		Error: common.ServerError(err),
+	})
+}
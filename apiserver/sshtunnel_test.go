@@ -0,0 +1,159 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	"golang.org/x/net/websocket"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type sshTunnelSuite struct {
+	authHttpSuite
+}
+
+var _ = gc.Suite(&sshTunnelSuite{})
+
+func (s *sshTunnelSuite) TestNoAuth(c *gc.C) {
+	conn := s.dialSSH(c, "machine-0", nil)
+	reader := bufio.NewReader(conn)
+	assertJSONError(c, reader, "no credentials provided")
+	s.assertWebsocketClosed(c, reader)
+}
+
+func (s *sshTunnelSuite) TestAgentLoginsRejected(c *gc.C) {
+	m, password := s.Factory.MakeMachineReturningPassword(c, &factory.MachineParams{
+		Nonce: "foo-nonce",
+	})
+	header := utils.BasicAuthHeader(m.Tag().String(), password)
+	conn := s.dialSSH(c, m.Tag().String(), header)
+	reader := bufio.NewReader(conn)
+	assertJSONError(c, reader, "invalid entity name or password")
+	s.assertWebsocketClosed(c, reader)
+}
+
+func (s *sshTunnelSuite) TestReadOnlyUserRejected(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Password: "sekrit",
+		Access:   state.ModelReadAccess,
+	})
+	header := utils.BasicAuthHeader(user.Tag().String(), "sekrit")
+	conn := s.dialSSH(c, machine.Tag().String(), header)
+	reader := bufio.NewReader(conn)
+	assertJSONError(c, reader, "permission denied")
+	s.assertWebsocketClosed(c, reader)
+
+	entry := s.waitForSession(c)
+	c.Check(entry.User, gc.Equals, user.UserTag().Canonical())
+	c.Check(entry.Error, gc.Equals, "permission denied")
+}
+
+func (s *sshTunnelSuite) TestInvalidTarget(c *gc.C) {
+	conn := s.dialSSH(c, "service-mysql", s.authHeader())
+	reader := bufio.NewReader(conn)
+	assertJSONError(c, reader, `SSH target "service-mysql" not valid`)
+	s.assertWebsocketClosed(c, reader)
+}
+
+func (s *sshTunnelSuite) TestUnknownTarget(c *gc.C) {
+	conn := s.dialSSH(c, "unit-mysql-0", s.authHeader())
+	reader := bufio.NewReader(conn)
+	assertJSONError(c, reader, `unit "mysql/0" not found`)
+	s.assertWebsocketClosed(c, reader)
+}
+
+func (s *sshTunnelSuite) TestRelaysToUnitMachine(c *gc.C) {
+	// Stand in for the machine's SSH server with an echo server.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		io.Copy(conn, conn)
+	}()
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	c.Assert(err, jc.ErrorIsNil)
+	portNum, err := strconv.Atoi(port)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(apiserver.SSHTunnelPort, portNum)
+
+	unit := s.Factory.MakeUnit(c, nil)
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProviderAddresses(network.NewScopedAddress("127.0.0.1", network.ScopeCloudLocal))
+	c.Assert(err, jc.ErrorIsNil)
+
+	conn := s.dialSSH(c, unit.Tag().String(), s.authHeader())
+	reader := bufio.NewReader(conn)
+	errResult := readJSONErrorLine(c, reader)
+	c.Assert(errResult.Error, gc.IsNil)
+
+	_, err = conn.Write([]byte("SSH-2.0-test\r\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	line, err := reader.ReadString('\n')
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(line, gc.Equals, "SSH-2.0-test\r\n")
+	conn.Close()
+
+	entry := s.waitForSession(c)
+	c.Check(entry.User, gc.Equals, s.userTag.Canonical())
+	c.Check(entry.Error, gc.Equals, "")
+	var session map[string]string
+	err = json.Unmarshal([]byte(entry.Args), &session)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(session["target"], gc.Equals, unit.Tag().String())
+	c.Check(session["address"], gc.Equals, listener.Addr().String())
+	c.Check(session["duration"], gc.Not(gc.Equals), "")
+}
+
+func (s *sshTunnelSuite) authHeader() http.Header {
+	return utils.BasicAuthHeader(s.userTag.String(), s.password)
+}
+
+func (s *sshTunnelSuite) dialSSH(c *gc.C, target string, header http.Header) *websocket.Conn {
+	server := s.makeURL(c, "wss", "/model/"+s.modelUUID+"/ssh", url.Values{"target": {target}})
+	conn := s.dialWebsocketFromURL(c, server.String(), header)
+	s.AddCleanup(func(_ *gc.C) { conn.Close() })
+	return conn
+}
+
+// waitForSession waits for an SSH session to be recorded in the audit
+// log and returns its entry.
+func (s *sshTunnelSuite) waitForSession(c *gc.C) state.AuditEntry {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		entries, err := s.State.AuditLog(state.AuditLogFilter{
+			Facade: "SSH",
+			Method: "Session",
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		if len(entries) > 0 {
+			c.Assert(entries, gc.HasLen, 1)
+			return entries[0]
+		}
+	}
+	c.Fatalf("SSH session not recorded in audit log")
+	panic("unreachable")
+}
@@ -0,0 +1,66 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// CharmState returns the key-value pairs that each unit's charm has
+// persisted between hooks.
+func (u *UniterAPIV3) CharmState(args params.Entities) (params.SettingsResults, error) {
+	result := params.SettingsResults{
+		Results: make([]params.SettingsResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.SettingsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		unit, err := u.accessibleUnit(canAccess, entity.Tag)
+		if err == nil {
+			var charmState map[string]string
+			charmState, err = unit.CharmState()
+			if err == nil {
+				result.Results[i].Settings = params.Settings(charmState)
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// SetCharmState replaces the key-value pairs persisted by each unit's
+// charm.
+func (u *UniterAPIV3) SetCharmState(args params.SetCharmStateArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		unit, err := u.accessibleUnit(canAccess, arg.Tag)
+		if err == nil {
+			err = unit.SetCharmState(arg.State)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// accessibleUnit returns the unit with the given tag, if the caller
+// may access it.
+func (u *UniterAPIV3) accessibleUnit(canAccess common.AuthFunc, tagString string) (*state.Unit, error) {
+	tag, err := names.ParseUnitTag(tagString)
+	if err != nil || !canAccess(tag) {
+		return nil, common.ErrPerm
+	}
+	return u.getUnit(tag)
+}
@@ -28,6 +28,22 @@ var logger = loggo.GetLogger("juju.apiserver.uniter")
 
 func init() {
 	common.RegisterStandardFacade("Uniter", 3, NewUniterAPIV3)
+	common.RegisterStandardFacade("Uniter", 4, NewUniterAPIV4)
+}
+
+// UniterAPIV4 implements the API version 4, used by the uniter worker.
+// It adds the methods used to take part in series upgrades.
+type UniterAPIV4 struct {
+	UniterAPIV3
+}
+
+// NewUniterAPIV4 creates a new instance of the Uniter API, version 4.
+func NewUniterAPIV4(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*UniterAPIV4, error) {
+	baseAPI, err := NewUniterAPIV3(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV4{*baseAPI}, nil
 }
 
 // UniterAPIV3 implements the API version 3, used by the uniter worker.
@@ -911,6 +927,18 @@ func (u *UniterAPIV3) FinishActions(args params.ActionExecutionResults) (params.
 	return common.FinishActions(args, actionFn), nil
 }
 
+// LogActionsMessages records the given progress messages against
+// their running actions.
+func (u *UniterAPIV3) LogActionsMessages(args params.ActionMessageParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, u.st.ActionByTag)
+	return common.LogActionsMessages(args, actionFn), nil
+}
+
 // RelationById returns information about all given relations,
 // specified by their ids, including their key and the local
 // endpoint.
@@ -1272,9 +1300,10 @@ func (u *UniterAPIV3) prepareRelationResult(rel *state.Relation, unit *state.Uni
 		return nothing, err
 	}
 	return params.RelationResult{
-		Id:   rel.Id(),
-		Key:  rel.String(),
-		Life: params.Life(rel.Life().String()),
+		Id:        rel.Id(),
+		Key:       rel.String(),
+		Life:      params.Life(rel.Life().String()),
+		Suspended: rel.Suspended(),
 		Endpoint: multiwatcher.Endpoint{
 			ServiceName: ep.ServiceName,
 			Relation:    ep.Relation,
@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// WatchUpgradeSeriesNotifications returns a NotifyWatcher for observing
+// changes to the series upgrade lock of the machine hosting each unit.
+func (u *UniterAPIV4) WatchUpgradeSeriesNotifications(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+	for i, entity := range args.Entities {
+		machine, err := u.unitMachine(canAccess, entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		watch := machine.WatchUpgradeSeriesNotifications()
+		// Consume the initial event. Technically, API
+		// calls to Watch 'transmit' the initial event
+		// in the Watch response. But NotifyWatchers
+		// have no state to transmit.
+		if _, ok := <-watch.Changes(); ok {
+			result.Results[i].NotifyWatcherId = u.resources.Register(watch)
+		} else {
+			result.Results[i].Error = common.ServerError(watcher.EnsureErr(watch))
+		}
+	}
+	return result, nil
+}
+
+// UpgradeSeriesStatus returns the progress of each unit through the
+// series upgrade of the machine hosting it.
+func (u *UniterAPIV4) UpgradeSeriesStatus(args params.Entities) (params.UpgradeSeriesStatusResults, error) {
+	result := params.UpgradeSeriesStatusResults{
+		Results: make([]params.UpgradeSeriesStatusResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.UpgradeSeriesStatusResults{}, err
+	}
+	for i, entity := range args.Entities {
+		machine, err := u.unitMachine(canAccess, entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		lock, err := machine.UpgradeSeriesLock()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		unitTag, _ := names.ParseUnitTag(entity.Tag)
+		result.Results[i] = params.UpgradeSeriesStatusResult{
+			Status:     params.UpgradeSeriesStatus(lock.UnitStatuses()[unitTag.Id()]),
+			FromSeries: lock.FromSeries(),
+			ToSeries:   lock.ToSeries(),
+		}
+	}
+	return result, nil
+}
+
+// SetUpgradeSeriesStatus records the progress of each unit through the
+// series upgrade of the machine hosting it.
+func (u *UniterAPIV4) SetUpgradeSeriesStatus(args params.SetUpgradeSeriesStatusArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		machine, err := u.unitMachine(canAccess, arg.Entity.Tag)
+		if err == nil {
+			unitTag, _ := names.ParseUnitTag(arg.Entity.Tag)
+			err = machine.SetUpgradeSeriesUnitStatus(unitTag.Id(), state.UpgradeSeriesStatus(arg.Status))
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// unitMachine returns the machine hosting the unit with the given tag,
+// if the caller may access the unit.
+func (u *UniterAPIV4) unitMachine(canAccess common.AuthFunc, tagString string) (*state.Machine, error) {
+	tag, err := names.ParseUnitTag(tagString)
+	if err != nil || !canAccess(tag) {
+		return nil, common.ErrPerm
+	}
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineId, err := unit.AssignedMachineId()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return u.st.Machine(machineId)
+}
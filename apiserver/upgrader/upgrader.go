@@ -104,16 +104,18 @@ func (u *UpgraderAPI) WatchAPIVersion(args params.Entities) (params.NotifyWatchR
 		}
 		err = common.ErrPerm
 		if u.authorizer.AuthOwner(tag) {
-			watch := u.st.WatchForModelConfigChanges()
-			// Consume the initial event. Technically, API
-			// calls to Watch 'transmit' the initial event
-			// in the Watch response. But NotifyWatchers
-			// have no state to transmit.
-			if _, ok := <-watch.Changes(); ok {
-				result.Results[i].NotifyWatcherId = u.resources.Register(watch)
-				err = nil
-			} else {
-				err = watcher.EnsureErr(watch)
+			var watch state.NotifyWatcher
+			watch, err = u.watchAPIVersion(tag)
+			if err == nil {
+				// Consume the initial event. Technically, API
+				// calls to Watch 'transmit' the initial event
+				// in the Watch response. But NotifyWatchers
+				// have no state to transmit.
+				if _, ok := <-watch.Changes(); ok {
+					result.Results[i].NotifyWatcherId = u.resources.Register(watch)
+				} else {
+					err = watcher.EnsureErr(watch)
+				}
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -121,6 +123,24 @@ func (u *UpgraderAPI) WatchAPIVersion(args params.Entities) (params.NotifyWatchR
 	return result, nil
 }
 
+// watchAPIVersion returns a watcher that notifies of changes to the
+// model's config and, for a machine agent, to the machine itself, so
+// that changes to the machine's target agent version are noticed.
+func (u *UpgraderAPI) watchAPIVersion(tag names.Tag) (state.NotifyWatcher, error) {
+	machineTag, ok := tag.(names.MachineTag)
+	if !ok {
+		return u.st.WatchForModelConfigChanges(), nil
+	}
+	machine, err := u.st.Machine(machineTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewMultiNotifyWatcher(
+		u.st.WatchForModelConfigChanges(),
+		machine.Watch(),
+	), nil
+}
+
 func (u *UpgraderAPI) getGlobalAgentVersion() (version.Number, *config.Config, error) {
 	// Get the Agent Version requested in the Environment Config
 	cfg, err := u.st.ModelConfig()
@@ -150,18 +170,31 @@ func (u *UpgraderAPI) entityIsManager(tag names.Tag) bool {
 	}
 }
 
+// entityAgentVersion returns the agent version that the entity should
+// run, given the model's agent version. A machine that is part of a
+// staged upgrade runs its target agent version instead.
+func (u *UpgraderAPI) entityAgentVersion(tag names.Tag, agentVersion version.Number) version.Number {
+	entity, err := u.st.FindEntity(tag)
+	if err != nil {
+		return agentVersion
+	}
+	return common.TargetAgentVersion(entity, agentVersion)
+}
+
 // DesiredVersion reports the Agent Version that we want that agent to be running
 func (u *UpgraderAPI) DesiredVersion(args params.Entities) (params.VersionResults, error) {
 	results := make([]params.VersionResult, len(args.Entities))
 	if len(args.Entities) == 0 {
 		return params.VersionResults{}, nil
 	}
-	agentVersion, _, err := u.getGlobalAgentVersion()
+	globalVersion, _, err := u.getGlobalAgentVersion()
+	if err != nil {
+		return params.VersionResults{}, common.ServerError(err)
+	}
+	rollbackVersion, err := rolledBackVersion(u.st)
 	if err != nil {
 		return params.VersionResults{}, common.ServerError(err)
 	}
-	// Is the desired version greater than the current API server version?
-	isNewerVersion := agentVersion.Compare(jujuversion.Current) > 0
 	for i, entity := range args.Entities {
 		tag, err := names.ParseTag(entity.Tag)
 		if err != nil {
@@ -170,7 +203,10 @@ func (u *UpgraderAPI) DesiredVersion(args params.Entities) (params.VersionResult
 		}
 		err = common.ErrPerm
 		if u.authorizer.AuthOwner(tag) {
-			// Only return the globally desired agent version if the
+			agentVersion := u.entityAgentVersion(tag, globalVersion)
+			// Is the desired version greater than the current API server version?
+			isNewerVersion := agentVersion.Compare(jujuversion.Current) > 0
+			// Only return the desired agent version if the
 			// asking entity is a machine agent with JobManageModel or
 			// if this API server is running the globally desired agent
 			// version. Otherwise report this API server's current
@@ -182,6 +218,7 @@ func (u *UpgraderAPI) DesiredVersion(args params.Entities) (params.VersionResult
 			// agent version.
 			if !isNewerVersion || u.entityIsManager(tag) {
 				results[i].Version = &agentVersion
+				results[i].Rollback = rollbackVersion != nil && agentVersion == *rollbackVersion
 			} else {
 				logger.Debugf("desired version is %s, but current version is %s and agent is not a manager node", agentVersion, jujuversion.Current)
 				results[i].Version = &jujuversion.Current
@@ -192,3 +229,16 @@ func (u *UpgraderAPI) DesiredVersion(args params.Entities) (params.VersionResult
 	}
 	return params.VersionResults{Results: results}, nil
 }
+
+// rolledBackVersion returns the version the most recent upgrade was
+// rolled back to, or nil if it was not rolled back.
+func rolledBackVersion(st *state.State) (*version.Number, error) {
+	info, err := st.RolledBackUpgrade()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	previous := info.PreviousVersion()
+	return &previous, nil
+}
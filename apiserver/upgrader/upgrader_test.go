@@ -99,6 +99,30 @@ func (s *upgraderSuite) TestWatchAPIVersion(c *gc.C) {
 	wc.AssertClosed()
 }
 
+func (s *upgraderSuite) TestWatchAPIVersionNoticesTargetAgentVersion(c *gc.C) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}},
+	}
+	results, err := s.upgrader.WatchAPIVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	resource := s.resources.Get(results.Results[0].NotifyWatcherId)
+	c.Assert(resource, gc.NotNil)
+
+	w := resource.(state.NotifyWatcher)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertNoChange()
+
+	newer := jujuversion.Current
+	newer.Patch++
+	err = s.rawMachine.SetTargetAgentVersion(newer)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
 func (s *upgraderSuite) TestUpgraderAPIRefusesNonMachineAgent(c *gc.C) {
 	anAuthorizer := s.authorizer
 	anAuthorizer.Tag = names.NewUnitTag("ubuntu/1")
@@ -278,6 +302,24 @@ func (s *upgraderSuite) TestDesiredVersionNoticesMixedAgents(c *gc.C) {
 
 }
 
+func (s *upgraderSuite) TestDesiredVersionRollback(c *gc.C) {
+	previous := jujuversion.Current
+	previous.Major--
+	_, err := s.State.EnsureUpgradeInfo(s.apiMachine.Id(), previous, jujuversion.Current)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.RollbackUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}}}
+	results, err := s.upgrader.DesiredVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Version, gc.NotNil)
+	c.Check(*results.Results[0].Version, gc.Equals, previous)
+	c.Check(results.Results[0].Rollback, jc.IsTrue)
+}
+
 func (s *upgraderSuite) TestDesiredVersionForAgent(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}}}
 	results, err := s.upgrader.DesiredVersion(args)
@@ -341,3 +383,38 @@ func (s *upgraderSuite) TestDesiredVersionRestrictedForNonAPIAgents(c *gc.C) {
 	c.Assert(agentVersion, gc.NotNil)
 	c.Check(*agentVersion, gc.DeepEquals, jujuversion.Current)
 }
+
+func (s *upgraderSuite) TestDesiredVersionHonoursTargetAgentVersion(c *gc.C) {
+	newer := jujuversion.Current
+	newer.Patch++
+	err := s.rawMachine.SetTargetAgentVersion(newer)
+	c.Assert(err, jc.ErrorIsNil)
+	// Once the API server runs the target version, the machine
+	// should run it too, even though the model's version is older.
+	s.PatchValue(&jujuversion.Current, newer)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}}}
+	results, err := s.upgrader.DesiredVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	agentVersion := results.Results[0].Version
+	c.Assert(agentVersion, gc.NotNil)
+	c.Check(*agentVersion, gc.DeepEquals, newer)
+}
+
+func (s *upgraderSuite) TestDesiredVersionTargetAgentVersionRestrictedForNonAPIAgents(c *gc.C) {
+	newer := jujuversion.Current
+	newer.Patch++
+	err := s.rawMachine.SetTargetAgentVersion(newer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}}}
+	results, err := s.upgrader.DesiredVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	agentVersion := results.Results[0].Version
+	c.Assert(agentVersion, gc.NotNil)
+	c.Check(*agentVersion, gc.DeepEquals, jujuversion.Current)
+}
@@ -0,0 +1,146 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package upgradeseries implements the API facade used by the
+// upgradeseries worker, which prepares a machine's agents for an
+// in-place upgrade of the machine's series.
+package upgradeseries
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+func init() {
+	common.RegisterStandardFacade("UpgradeSeries", 1, newFacade)
+}
+
+// Backend defines the State API used by the upgradeseries facade.
+type Backend interface {
+	Machine(id string) (Machine, error)
+}
+
+// Machine defines the methods of state.Machine used by the
+// upgradeseries facade.
+type Machine interface {
+	WatchUpgradeSeriesNotifications() state.NotifyWatcher
+	UpgradeSeriesLock() (*state.UpgradeSeriesLock, error)
+	SetUpgradeSeriesMachineStatus(state.UpgradeSeriesStatus) error
+}
+
+// Facade implements the API required by the upgradeseries worker.
+type Facade struct {
+	backend   Backend
+	resources *common.Resources
+	canAccess common.GetAuthFunc
+}
+
+// New returns a new API facade for the upgradeseries worker.
+func New(backend Backend, resources *common.Resources, authorizer common.Authorizer) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:   backend,
+		resources: resources,
+		canAccess: func() (common.AuthFunc, error) {
+			return authorizer.AuthOwner, nil
+		},
+	}, nil
+}
+
+// WatchUpgradeSeriesNotifications returns a NotifyWatcher for observing
+// changes to the series upgrade lock of each machine.
+func (facade *Facade) WatchUpgradeSeriesNotifications(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := facade.canAccess()
+	if err != nil {
+		return results, err
+	}
+	for i, entity := range args.Entities {
+		machine, err := facade.machine(canAccess, entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		watch := machine.WatchUpgradeSeriesNotifications()
+		// Consume the initial event. Technically, API
+		// calls to Watch 'transmit' the initial event
+		// in the Watch response. But NotifyWatchers
+		// have no state to transmit.
+		if _, ok := <-watch.Changes(); ok {
+			results.Results[i].NotifyWatcherId = facade.resources.Register(watch)
+		} else {
+			results.Results[i].Error = common.ServerError(watcher.EnsureErr(watch))
+		}
+	}
+	return results, nil
+}
+
+// UpgradeSeriesStatus returns the progress of each machine agent
+// through the series upgrade of its machine, and the series the
+// machine is being upgraded to.
+func (facade *Facade) UpgradeSeriesStatus(args params.Entities) (params.UpgradeSeriesStatusResults, error) {
+	results := params.UpgradeSeriesStatusResults{
+		Results: make([]params.UpgradeSeriesStatusResult, len(args.Entities)),
+	}
+	canAccess, err := facade.canAccess()
+	if err != nil {
+		return results, err
+	}
+	for i, entity := range args.Entities {
+		machine, err := facade.machine(canAccess, entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		lock, err := machine.UpgradeSeriesLock()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = params.UpgradeSeriesStatusResult{
+			Status:     params.UpgradeSeriesStatus(lock.MachineStatus()),
+			FromSeries: lock.FromSeries(),
+			ToSeries:   lock.ToSeries(),
+		}
+	}
+	return results, nil
+}
+
+// SetUpgradeSeriesStatus records the progress of each machine agent
+// through the series upgrade of its machine.
+func (facade *Facade) SetUpgradeSeriesStatus(args params.SetUpgradeSeriesStatusArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := facade.canAccess()
+	if err != nil {
+		return results, err
+	}
+	for i, arg := range args.Args {
+		machine, err := facade.machine(canAccess, arg.Entity.Tag)
+		if err == nil {
+			err = machine.SetUpgradeSeriesMachineStatus(state.UpgradeSeriesStatus(arg.Status))
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (facade *Facade) machine(canAccess common.AuthFunc, tagString string) (Machine, error) {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil || !canAccess(tag) {
+		return nil, common.ErrPerm
+	}
+	return facade.backend.Machine(tag.Id())
+}
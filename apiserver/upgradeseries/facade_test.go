@@ -0,0 +1,118 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/apiserver/upgradeseries"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	testing.BaseSuite
+	backend    *mockBackend
+	authorizer *apiservertesting.FakeAuthorizer
+	facade     *upgradeseries.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{}
+	s.authorizer = &apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("1"),
+	}
+	facade, err := upgradeseries.New(s.backend, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *facadeSuite) TestNewNotMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	_, err := upgradeseries.New(s.backend, nil, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *facadeSuite) TestUpgradeSeriesStatusNotLocked(c *gc.C) {
+	result, err := s.facade.UpgradeSeriesStatus(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UpgradeSeriesStatusResults{
+		Results: []params.UpgradeSeriesStatusResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{},
+		},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Machine", []interface{}{"1"}},
+		{"UpgradeSeriesLock", nil},
+	})
+}
+
+func (s *facadeSuite) TestSetUpgradeSeriesStatus(c *gc.C) {
+	result, err := s.facade.SetUpgradeSeriesStatus(params.SetUpgradeSeriesStatusArgs{
+		Args: []params.SetUpgradeSeriesStatusArg{{
+			Entity: params.Entity{Tag: "machine-0"},
+			Status: params.UpgradeSeriesPrepareCompleted,
+		}, {
+			Entity: params.Entity{Tag: "machine-1"},
+			Status: params.UpgradeSeriesPrepareCompleted,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{},
+		},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Machine", []interface{}{"1"}},
+		{"SetUpgradeSeriesMachineStatus", []interface{}{state.UpgradeSeriesPrepareCompleted}},
+	})
+}
+
+type mockBackend struct {
+	stub jujutesting.Stub
+}
+
+func (b *mockBackend) Machine(id string) (upgradeseries.Machine, error) {
+	b.stub.AddCall("Machine", id)
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return &mockMachine{stub: &b.stub}, nil
+}
+
+type mockMachine struct {
+	upgradeseries.Machine
+	stub *jujutesting.Stub
+}
+
+func (m *mockMachine) UpgradeSeriesLock() (*state.UpgradeSeriesLock, error) {
+	m.stub.AddCall("UpgradeSeriesLock")
+	if err := m.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return nil, errors.NotFoundf("series upgrade lock")
+}
+
+func (m *mockMachine) SetUpgradeSeriesMachineStatus(status state.UpgradeSeriesStatus) error {
+	m.stub.AddCall("SetUpgradeSeriesMachineStatus", status)
+	return m.stub.NextErr()
+}
@@ -0,0 +1,23 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries
+
+import (
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+// newFacade wraps New to express the supplied *state.State as a Backend.
+func newFacade(st *state.State, res *common.Resources, auth common.Authorizer) (*Facade, error) {
+	return New(backendShim{st}, res, auth)
+}
+
+type backendShim struct {
+	st *state.State
+}
+
+// Machine is part of the Backend interface.
+func (b backendShim) Machine(id string) (Machine, error) {
+	return b.st.Machine(id)
+}
@@ -36,6 +36,7 @@ var allowedMethodsDuringUpgrades = map[string]set.Strings{
 		"PublicAddress",       // for "juju ssh"
 		"FindTools",           // for "juju upgrade-juju", before we can reset upgrade to re-run
 		"AbortCurrentUpgrade", // for "juju upgrade-juju", so that we can reset upgrade to re-run
+		"RollbackUpgrade",     // for "juju upgrade-juju --rollback", to revert a failed upgrade
 	),
 	"Pinger": set.NewStrings(
 		"Ping",
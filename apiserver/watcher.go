@@ -155,7 +155,10 @@ type srvStringsWatcher struct {
 }
 
 func newStringsWatcher(st *state.State, resources *common.Resources, auth common.Authorizer, id string) (interface{}, error) {
-	if !isAgent(auth) {
+	// Clients may only reach the watchers registered on their own
+	// connection, such as those created by the Action facade's
+	// WatchActionsProgress.
+	if !isAgent(auth) && !auth.AuthClient() {
 		return nil, common.ErrPerm
 	}
 	watcher, ok := resources.Get(id).(state.StringsWatcher)
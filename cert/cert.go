@@ -38,6 +38,32 @@ func ParseCert(certPEM string) (*x509.Certificate, error) {
 	return nil, errors.New("no certificates found")
 }
 
+// ParseCerts parses all the PEM-formatted X509 certificates in the
+// given bundle, in the order they appear.
+func ParseCerts(certsPEM string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	certPEMData := []byte(certsPEM)
+	for len(certPEMData) > 0 {
+		var certBlock *pem.Block
+		certBlock, certPEMData = pem.Decode(certPEMData)
+		if certBlock == nil {
+			break
+		}
+		if certBlock.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(certBlock.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no certificates found")
+	}
+	return certs, nil
+}
+
 // ParseCertAndKey parses the given PEM-formatted X509 certificate
 // and RSA private key.
 func ParseCertAndKey(certPEM, keyPEM string) (*x509.Certificate, *rsa.PrivateKey, error) {
@@ -59,24 +85,34 @@ func ParseCertAndKey(certPEM, keyPEM string) (*x509.Certificate, *rsa.PrivateKey
 }
 
 // Verify verifies that the given server certificate is valid with
-// respect to the given CA certificate at the given time.
+// respect to the given CA certificate at the given time. Any
+// certificates following the server certificate are treated as
+// intermediates, and any of the certificates in caCertPEM may be
+// used as the root.
 func Verify(srvCertPEM, caCertPEM string, when time.Time) error {
-	caCert, err := ParseCert(caCertPEM)
+	caCerts, err := ParseCerts(caCertPEM)
 	if err != nil {
 		return errors.Annotate(err, "cannot parse CA certificate")
 	}
-	srvCert, err := ParseCert(srvCertPEM)
+	srvCerts, err := ParseCerts(srvCertPEM)
 	if err != nil {
 		return errors.Annotate(err, "cannot parse server certificate")
 	}
 	pool := x509.NewCertPool()
-	pool.AddCert(caCert)
+	for _, caCert := range caCerts {
+		pool.AddCert(caCert)
+	}
+	intermediates := x509.NewCertPool()
+	for _, intermediate := range srvCerts[1:] {
+		intermediates.AddCert(intermediate)
+	}
 	opts := x509.VerifyOptions{
-		DNSName:     "anyServer",
-		Roots:       pool,
-		CurrentTime: when,
+		DNSName:       "anyServer",
+		Roots:         pool,
+		Intermediates: intermediates,
+		CurrentTime:   when,
 	}
-	_, err = srvCert.Verify(opts)
+	_, err = srvCerts[0].Verify(opts)
 	return err
 }
 
@@ -126,6 +162,57 @@ func NewCA(envName, UUID string, expiry time.Time) (certPEM, keyPEM string, err
 	return string(certPEMData), string(keyPEMData), nil
 }
 
+// CrossSign returns a copy of the given CA certificate, signed by the
+// signer CA instead of by itself. While a controller's CA is being
+// replaced, servers present the cross-signed certificate along with
+// certificates issued by the new CA, so that clients which only trust
+// the old CA can still validate them.
+func CrossSign(caCertPEM, signerCertPEM, signerKeyPEM string) (string, error) {
+	caCert, err := ParseCert(caCertPEM)
+	if err != nil {
+		return "", errors.Annotate(err, "cannot parse CA certificate")
+	}
+	if !caCert.BasicConstraintsValid || !caCert.IsCA {
+		return "", errors.New("certificate to cross-sign is not a valid CA")
+	}
+	signerCert, signerKey, err := ParseCertAndKey(signerCertPEM, signerKeyPEM)
+	if err != nil {
+		return "", errors.Annotate(err, "cannot parse signer CA certificate")
+	}
+	if !signerCert.BasicConstraintsValid || !signerCert.IsCA {
+		return "", errors.New("signer certificate is not a valid CA")
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 8*20))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate serial number: %s", err)
+	}
+	notAfter := caCert.NotAfter
+	if signerCert.NotAfter.Before(notAfter) {
+		// The cross-signed certificate is no use once
+		// the signer has expired.
+		notAfter = signerCert.NotAfter
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               caCert.Subject,
+		NotBefore:             caCert.NotBefore,
+		NotAfter:              notAfter,
+		SubjectKeyId:          caCert.SubjectKeyId,
+		KeyUsage:              caCert.KeyUsage,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, signerCert, caCert.PublicKey, signerKey)
+	if err != nil {
+		return "", fmt.Errorf("cannot create certificate: %v", err)
+	}
+	certPEMData := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: certDER,
+	})
+	return string(certPEMData), nil
+}
+
 // NewServer generates a certificate/key pair suitable for use by a server, with an
 // expiry time of 10 years.
 func NewDefaultServer(caCertPEM, caKeyPEM string, hostnames []string) (certPEM, keyPEM string, err error) {
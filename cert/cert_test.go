@@ -220,6 +220,61 @@ func (certSuite) TestVerify(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "x509: certificate signed by unknown authority")
 }
 
+func (certSuite) TestParseCerts(c *gc.C) {
+	caCert2, _, err := cert.NewCA("bar", "1", time.Now().Add(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+
+	certs, err := cert.ParseCerts(caCertPEM + caKeyPEM + caCert2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(certs, gc.HasLen, 2)
+	c.Check(certs[0].Subject.CommonName, gc.Equals, "juju testing")
+	c.Check(certs[1].Subject.CommonName, gc.Equals, `juju-generated CA for model "bar"`)
+
+	_, err = cert.ParseCerts(caKeyPEM)
+	c.Assert(err, gc.ErrorMatches, "no certificates found")
+}
+
+func (certSuite) TestCrossSign(c *gc.C) {
+	now := time.Now()
+	oldCACert, oldCAKey, err := cert.NewCA("foo", "1", now.AddDate(1, 0, 0))
+	c.Assert(err, jc.ErrorIsNil)
+	newCACert, newCAKey, err := cert.NewCA("foo", "1", now.AddDate(2, 0, 0))
+	c.Assert(err, jc.ErrorIsNil)
+
+	crossSigned, err := cert.CrossSign(newCACert, oldCACert, oldCAKey)
+	c.Assert(err, jc.ErrorIsNil)
+	xcert, err := cert.ParseCert(crossSigned)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(xcert.IsCA, jc.IsTrue)
+	c.Check(xcert.Subject.CommonName, gc.Equals, `juju-generated CA for model "foo"`)
+	// The cross-signed certificate expires with its signer.
+	checkNotAfter(c, xcert, now.AddDate(1, 0, 0))
+
+	var noHostnames []string
+	srvCert, _, err := cert.NewServer(newCACert, newCAKey, now.AddDate(1, 0, 0), noHostnames)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The server certificate alone is only trusted by the new CA.
+	err = cert.Verify(srvCert, oldCACert, now)
+	c.Check(err, gc.ErrorMatches, "x509: certificate signed by unknown authority")
+	err = cert.Verify(srvCert, newCACert, now)
+	c.Check(err, jc.ErrorIsNil)
+	err = cert.Verify(srvCert, oldCACert+newCACert, now)
+	c.Check(err, jc.ErrorIsNil)
+
+	// Presented along with the cross-signed CA, the old CA trusts it too.
+	err = cert.Verify(srvCert+crossSigned, oldCACert, now)
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (certSuite) TestCrossSignWithInvalidCert(c *gc.C) {
+	_, err := cert.CrossSign(nonCACert, caCertPEM, caKeyPEM)
+	c.Assert(err, gc.ErrorMatches, "certificate to cross-sign is not a valid CA")
+
+	_, err = cert.CrossSign(caCertPEM, nonCACert, nonCAKey)
+	c.Assert(err, gc.ErrorMatches, "signer certificate is not a valid CA")
+}
+
 // checkTLSConnection checks that we can correctly perform a TLS
 // handshake using the given credentials.
 func checkTLSConnection(c *gc.C, caCert, srvCert *x509.Certificate, srvKey *rsa.PrivateKey) (caName string) {
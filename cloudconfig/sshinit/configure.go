@@ -27,6 +27,10 @@ type ConfigureParams struct {
 	// If Client is nil, ssh.DefaultClient will be used.
 	Client ssh.Client
 
+	// Options holds the options used to connect to the host.
+	// If Options is nil, the default options will be used.
+	Options *ssh.Options
+
 	// Config is the cloudinit config to carry out.
 	Config cloudinit.CloudConfig
 
@@ -81,7 +85,7 @@ cat > $tmpfile
 			`/bin/bash -c "$(echo %s | base64 -d)"`,
 			utils.ShQuote(encoded),
 		),
-	}, nil)
+	}, params.Options)
 
 	cmd.Stdin = strings.NewReader(script)
 	cmd.Stderr = params.ProgressWriter
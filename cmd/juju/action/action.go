@@ -7,11 +7,13 @@ import (
 	"io"
 
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	"gopkg.in/juju/charm.v6-unstable"
 
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/watcher"
 )
 
 // type APIClient represents the action API functionality.
@@ -57,6 +59,10 @@ type APIClient interface {
 	// FindActionsByNames takes a list of names and finds a corresponding list of
 	// Actions for every name.
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
+
+	// WatchActionProgress returns a watcher that reports the JSON-encoded
+	// progress messages logged by the given action.
+	WatchActionProgress(names.ActionTag) (watcher.StringsWatcher, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/names"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -21,6 +22,7 @@ import (
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
 )
 
 const (
@@ -132,6 +134,7 @@ type fakeAPIClient struct {
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
 	charmActions       *charm.Actions
+	progress           []string
 	apiErr             error
 }
 
@@ -214,3 +217,35 @@ func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.Find
 func (c *fakeAPIClient) FindActionsByNames(args params.FindActionsByNames) (params.ActionsByNames, error) {
 	return c.actionsByNames, c.apiErr
 }
+
+func (c *fakeAPIClient) WatchActionProgress(tag names.ActionTag) (watcher.StringsWatcher, error) {
+	if c.apiErr != nil {
+		return nil, c.apiErr
+	}
+	w := &fakeStringsWatcher{
+		changes: make(chan []string, 1),
+		dying:   make(chan struct{}),
+	}
+	w.changes <- c.progress
+	return w, nil
+}
+
+// fakeStringsWatcher is a watcher.StringsWatcher that sends a single
+// event and then blocks until it is killed.
+type fakeStringsWatcher struct {
+	changes chan []string
+	dying   chan struct{}
+}
+
+func (w *fakeStringsWatcher) Changes() watcher.StringsChannel {
+	return w.changes
+}
+
+func (w *fakeStringsWatcher) Kill() {
+	close(w.dying)
+}
+
+func (w *fakeStringsWatcher) Wait() error {
+	<-w.dying
+	return nil
+}
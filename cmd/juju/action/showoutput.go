@@ -4,6 +4,8 @@
 package action
 
 import (
+	"encoding/json"
+	"fmt"
 	"regexp"
 	"time"
 
@@ -26,6 +28,7 @@ type showOutputCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	watch       bool
 }
 
 const showOutputDoc = `
@@ -37,12 +40,17 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+To follow the progress messages that a running action records with
+action-log, use the --watch flag. The messages are printed as they are
+logged, and the results are shown once the action is no longer running.
 `
 
 // Set up the output.
 func (c *showOutputCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "wait for results")
+	f.BoolVar(&c.watch, "watch", false, "stream progress messages until the action completes")
 }
 
 func (c *showOutputCommand) Info() *cmd.Info {
@@ -61,6 +69,9 @@ func (c *showOutputCommand) Init(args []string) error {
 		return errors.New("no action ID specified")
 	case 1:
 		c.requestedId = args[0]
+		if c.watch && c.wait != "-1s" {
+			return errors.New("--watch cannot be used with --wait")
+		}
 		return nil
 	default:
 		return cmd.CheckEmpty(args[1:])
@@ -88,6 +99,10 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	}
 	defer api.Close()
 
+	if c.watch {
+		return c.watchProgress(ctx, api)
+	}
+
 	wait := time.NewTimer(0 * time.Second)
 
 	switch {
@@ -110,6 +125,63 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	return c.out.Write(ctx, FormatActionResult(result))
 }
 
+// watchProgress prints the progress messages logged by the requested
+// action as they arrive, and writes the action's results once it is no
+// longer running.
+func (c *showOutputCommand) watchProgress(ctx *cmd.Context, api APIClient) error {
+	actionTag, err := getActionTagByPrefix(api, c.requestedId)
+	if err != nil {
+		return err
+	}
+	w, err := api.WatchActionProgress(actionTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer w.Kill()
+
+	// The watcher only reports progress messages, so the action's
+	// status is polled to find out when it is no longer running.
+	tick := time.NewTimer(0)
+	logged := 0
+	for {
+		select {
+		case changes, ok := <-w.Changes():
+			if !ok {
+				if err := w.Wait(); err != nil {
+					return errors.Annotate(err, "watching action progress")
+				}
+				return errors.New("action progress watcher stopped")
+			}
+			for _, change := range changes {
+				var message params.ActionMessage
+				if err := json.Unmarshal([]byte(change), &message); err != nil {
+					return errors.Annotate(err, "cannot decode action progress message")
+				}
+				fmt.Fprintln(ctx.Stdout, formatActionMessage(message))
+				logged++
+			}
+		case <-tick.C:
+			result, err := fetchResult(api, c.requestedId)
+			if err != nil {
+				return err
+			}
+			switch result.Status {
+			case params.ActionRunning, params.ActionPending:
+				tick.Reset(2 * time.Second)
+				continue
+			}
+			// Print any messages logged since the last watcher event.
+			if logged < len(result.Log) {
+				for _, message := range result.Log[logged:] {
+					fmt.Fprintln(ctx.Stdout, formatActionMessage(message))
+				}
+			}
+			result.Log = nil
+			return c.out.Write(ctx, FormatActionResult(result))
+		}
+	}
+}
+
 // GetActionResult tries to repeatedly fetch an action until it is
 // in a completed state and then it returns it.
 // It waits for a maximum of "wait" before returning with the latest action status.
@@ -202,6 +274,13 @@ func FormatActionResult(result params.ActionResult) map[string]interface{} {
 	if len(result.Output) != 0 {
 		response["results"] = result.Output
 	}
+	if len(result.Log) != 0 {
+		log := make([]string, len(result.Log))
+		for i, message := range result.Log {
+			log[i] = formatActionMessage(message)
+		}
+		response["log"] = log
+	}
 
 	if result.Enqueued.IsZero() && result.Started.IsZero() && result.Completed.IsZero() {
 		return response
@@ -221,3 +300,9 @@ func FormatActionResult(result params.ActionResult) map[string]interface{} {
 
 	return response
 }
+
+// formatActionMessage formats a progress message logged by an action
+// for display.
+func formatActionMessage(message params.ActionMessage) string {
+	return fmt.Sprintf("%s %s", message.Timestamp.UTC().Format(time.RFC3339), message.Message)
+}
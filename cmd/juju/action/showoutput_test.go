@@ -5,10 +5,12 @@ package action_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"strings"
 	"time"
 
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
@@ -40,6 +42,10 @@ func (s *ShowOutputSuite) TestInit(c *gc.C) {
 		should:      "fail with multiple args",
 		args:        []string{"12345", "54321"},
 		expectError: `unrecognized args: \["54321"\]`,
+	}, {
+		should:      "fail with --watch and --wait",
+		args:        []string{"12345", "--watch", "--wait", "5s"},
+		expectError: "--watch cannot be used with --wait",
 	}}
 
 	for i, t := range tests {
@@ -290,6 +296,87 @@ timing:
 	}
 }
 
+func (s *ShowOutputSuite) TestRunWatch(c *gc.C) {
+	messages := []params.ActionMessage{{
+		Timestamp: time.Date(2015, time.February, 14, 8, 15, 10, 0, time.UTC),
+		Message:   "starting backup",
+	}, {
+		Timestamp: time.Date(2015, time.February, 14, 8, 15, 20, 0, time.UTC),
+		Message:   "copying files",
+	}, {
+		Timestamp: time.Date(2015, time.February, 14, 8, 15, 29, 0, time.UTC),
+		Message:   "done",
+	}}
+	client := makeFakeClient(
+		0,
+		10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status:    "completed",
+			Log:       messages,
+			Enqueued:  time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+			Started:   time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC),
+			Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+		}},
+		params.ActionsByNames{},
+		"",
+	)
+	// The watcher reports the first two messages; the last one is
+	// only seen in the final result.
+	for _, message := range messages[:2] {
+		data, err := json.Marshal(message)
+		c.Assert(err, jc.ErrorIsNil)
+		client.progress = append(client.progress, string(data))
+	}
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, cmd, "-m", "admin", validActionId, "--watch")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, `
+2015-02-14T08:15:10Z starting backup
+2015-02-14T08:15:20Z copying files
+2015-02-14T08:15:29Z done
+status: completed
+timing:
+  completed: 2015-02-14 08:15:30 +0000 UTC
+  enqueued: 2015-02-14 08:13:00 +0000 UTC
+  started: 2015-02-14 08:15:00 +0000 UTC
+`[1:])
+}
+
+func (s *ShowOutputSuite) TestRunShowsLog(c *gc.C) {
+	client := makeFakeClient(
+		0,
+		10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status: "running",
+			Log: []params.ActionMessage{{
+				Timestamp: time.Date(2015, time.February, 14, 8, 15, 10, 0, time.UTC),
+				Message:   "starting backup",
+			}},
+			Started: time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC),
+		}},
+		params.ActionsByNames{},
+		"",
+	)
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	ctx, err := testing.RunCommand(c, cmd, "-m", "admin", validActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, `
+log:
+- 2015-02-14T08:15:10Z starting backup
+status: running
+timing:
+  started: 2015-02-14 08:15:00 +0000 UTC
+`[1:])
+}
+
 func testRunHelper(c *gc.C, s *ShowOutputSuite, client *fakeAPIClient, expectedErr, expectedOutput, wait, query, modelFlag string) {
 	unpatch := s.BaseActionSuite.patchAPIClient(client)
 	defer unpatch()
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -70,20 +71,59 @@ var newAPIClient = func(c *CommandBase) (APIClient, error) {
 
 // dumpMetadata writes the formatted backup metadata to stdout.
 func (c *CommandBase) dumpMetadata(ctx *cmd.Context, result *params.BackupsMetadataResult) {
-	fmt.Fprintf(ctx.Stdout, "backup ID:       %q\n", result.ID)
-	fmt.Fprintf(ctx.Stdout, "checksum:        %q\n", result.Checksum)
-	fmt.Fprintf(ctx.Stdout, "checksum format: %q\n", result.ChecksumFormat)
-	fmt.Fprintf(ctx.Stdout, "size (B):        %d\n", result.Size)
-	fmt.Fprintf(ctx.Stdout, "stored:          %v\n", result.Stored)
-
-	fmt.Fprintf(ctx.Stdout, "started:         %v\n", result.Started)
-	fmt.Fprintf(ctx.Stdout, "finished:        %v\n", result.Finished)
-	fmt.Fprintf(ctx.Stdout, "notes:           %q\n", result.Notes)
-
-	fmt.Fprintf(ctx.Stdout, "model ID:        %q\n", result.Model)
-	fmt.Fprintf(ctx.Stdout, "machine ID:      %q\n", result.Machine)
-	fmt.Fprintf(ctx.Stdout, "created on host: %q\n", result.Hostname)
-	fmt.Fprintf(ctx.Stdout, "juju version:    %v\n", result.Version)
+	writeMetadata(ctx.Stdout, newBackupMetadata(result))
+}
+
+// backupMetadata is the machine-readable form of backup metadata
+// written by the yaml and json output formats.
+type backupMetadata struct {
+	ID             string    `yaml:"id" json:"id"`
+	Checksum       string    `yaml:"checksum" json:"checksum"`
+	ChecksumFormat string    `yaml:"checksum-format" json:"checksum-format"`
+	Size           int64     `yaml:"size" json:"size"`
+	Stored         time.Time `yaml:"stored" json:"stored"`
+	Started        time.Time `yaml:"started" json:"started"`
+	Finished       time.Time `yaml:"finished" json:"finished"`
+	Notes          string    `yaml:"notes" json:"notes"`
+	Model          string    `yaml:"model" json:"model"`
+	Machine        string    `yaml:"machine" json:"machine"`
+	Hostname       string    `yaml:"hostname" json:"hostname"`
+	Version        string    `yaml:"version" json:"version"`
+}
+
+func newBackupMetadata(result *params.BackupsMetadataResult) backupMetadata {
+	return backupMetadata{
+		ID:             result.ID,
+		Checksum:       result.Checksum,
+		ChecksumFormat: result.ChecksumFormat,
+		Size:           result.Size,
+		Stored:         result.Stored,
+		Started:        result.Started,
+		Finished:       result.Finished,
+		Notes:          result.Notes,
+		Model:          result.Model,
+		Machine:        result.Machine,
+		Hostname:       result.Hostname,
+		Version:        result.Version.String(),
+	}
+}
+
+// writeMetadata writes the human-readable form of the backup metadata.
+func writeMetadata(w io.Writer, meta backupMetadata) {
+	fmt.Fprintf(w, "backup ID:       %q\n", meta.ID)
+	fmt.Fprintf(w, "checksum:        %q\n", meta.Checksum)
+	fmt.Fprintf(w, "checksum format: %q\n", meta.ChecksumFormat)
+	fmt.Fprintf(w, "size (B):        %d\n", meta.Size)
+	fmt.Fprintf(w, "stored:          %v\n", meta.Stored)
+
+	fmt.Fprintf(w, "started:         %v\n", meta.Started)
+	fmt.Fprintf(w, "finished:        %v\n", meta.Finished)
+	fmt.Fprintf(w, "notes:           %q\n", meta.Notes)
+
+	fmt.Fprintf(w, "model ID:        %q\n", meta.Model)
+	fmt.Fprintf(w, "machine ID:      %q\n", meta.Machine)
+	fmt.Fprintf(w, "created on host: %q\n", meta.Hostname)
+	fmt.Fprintf(w, "juju version:    %v\n", meta.Version)
 }
 
 // ArchiveReader can read a backup archive.
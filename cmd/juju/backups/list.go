@@ -4,16 +4,24 @@
 package backups
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
 
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
 const listDoc = `
 list-backups provides the metadata associated with all backups.
+
+By default the backup IDs are listed, or all metadata with --verbose.
+With --format yaml or json, a list of metadata objects is written,
+each with the keys id, checksum, checksum-format, size, stored,
+started, finished, notes, model, machine, hostname and version.
 `
 
 // NewListCommand returns a command used to list metadata for backups.
@@ -24,6 +32,7 @@ func NewListCommand() cmd.Command {
 // listCommand is the sub-command for listing all available backups.
 type listCommand struct {
 	CommandBase
+	out cmd.Output
 }
 
 // Info implements Command.Info.
@@ -37,6 +46,12 @@ func (c *listCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", common.Formatters(c.formatTabular))
+}
+
 // Init implements Command.Init.
 func (c *listCommand) Init(args []string) error {
 	if err := cmd.CheckEmpty(args); err != nil {
@@ -63,24 +78,33 @@ func (c *listCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
-	if len(result.List) == 0 {
-		fmt.Fprintln(ctx.Stdout, "(no backups found)")
-		return nil
+	metadata := make([]backupMetadata, len(result.List))
+	for i, resultItem := range result.List {
+		metadata[i] = newBackupMetadata(&resultItem)
 	}
+	return c.out.Write(ctx, metadata)
+}
 
-	verbose := c.Log != nil && c.Log.Verbose
-	if verbose {
-		c.dumpMetadata(ctx, &result.List[0])
-	} else {
-		fmt.Fprintln(ctx.Stdout, result.List[0].ID)
+func (c *listCommand) formatTabular(value interface{}) ([]byte, error) {
+	metadata, ok := value.([]backupMetadata)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", metadata, value)
 	}
-	for _, resultItem := range result.List[1:] {
-		if verbose {
-			fmt.Fprintln(ctx.Stdout)
-			c.dumpMetadata(ctx, &resultItem)
-		} else {
-			fmt.Fprintln(ctx.Stdout, resultItem.ID)
+	var out bytes.Buffer
+	if len(metadata) == 0 {
+		fmt.Fprintln(&out, "(no backups found)")
+		return out.Bytes(), nil
+	}
+	verbose := c.Log != nil && c.Log.Verbose
+	for i, meta := range metadata {
+		if !verbose {
+			fmt.Fprintln(&out, meta.ID)
+			continue
 		}
+		if i > 0 {
+			fmt.Fprintln(&out)
+		}
+		writeMetadata(&out, meta)
 	}
-	return nil
+	return out.Bytes(), nil
 }
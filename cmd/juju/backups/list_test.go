@@ -44,6 +44,27 @@ func (s *listSuite) TestBrief(c *gc.C) {
 	s.checkStd(c, ctx, out, "")
 }
 
+func (s *listSuite) TestYAML(c *gc.C) {
+	s.setSuccess()
+	ctx, err := testing.RunCommand(c, s.subcommand, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	out := `
+- id: spam
+  checksum: ""
+  checksum-format: ""
+  size: 0
+  stored: 0001-01-01T00:00:00Z
+  started: 0001-01-01T00:00:00Z
+  finished: 0001-01-01T00:00:00Z
+  notes: ""
+  model: ""
+  machine: ""
+  hostname: ""
+  version: 0.0.0
+`[1:]
+	s.checkStd(c, ctx, out, "")
+}
+
 func (s *listSuite) TestError(c *gc.C) {
 	s.setFailure("failed!")
 	_, err := testing.RunCommand(c, s.subcommand)
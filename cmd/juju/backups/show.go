@@ -4,14 +4,22 @@
 package backups
 
 import (
+	"bytes"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
 
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
 const showDoc = `
 show-backup provides the metadata associated with a backup.
+
+With --format yaml or json, the metadata is written as an object with
+the keys id, checksum, checksum-format, size, stored, started,
+finished, notes, model, machine, hostname and version.
 `
 
 // NewShowCommand returns a command used to show metadata for a backup.
@@ -23,7 +31,8 @@ func NewShowCommand() cmd.Command {
 type showCommand struct {
 	CommandBase
 	// ID is the backup ID to get.
-	ID string
+	ID  string
+	out cmd.Output
 }
 
 // Info implements Command.Info.
@@ -36,6 +45,12 @@ func (c *showCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *showCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", common.Formatters(formatMetadataTabular))
+}
+
 // Init implements Command.Init.
 func (c *showCommand) Init(args []string) error {
 	if len(args) == 0 {
@@ -67,6 +82,15 @@ func (c *showCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
-	c.dumpMetadata(ctx, result)
-	return nil
+	return c.out.Write(ctx, newBackupMetadata(result))
+}
+
+func formatMetadataTabular(value interface{}) ([]byte, error) {
+	meta, ok := value.(backupMetadata)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", meta, value)
+	}
+	var out bytes.Buffer
+	writeMetadata(&out, meta)
+	return out.Bytes(), nil
 }
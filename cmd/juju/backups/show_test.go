@@ -34,6 +34,18 @@ func (s *showSuite) TestOkay(c *gc.C) {
 	s.checkStd(c, ctx, out, "")
 }
 
+func (s *showSuite) TestJSON(c *gc.C) {
+	s.setSuccess()
+	ctx, err := testing.RunCommand(c, s.subcommand, "--format", "json", s.metaresult.ID)
+	c.Check(err, jc.ErrorIsNil)
+
+	out := `{"id":"spam","checksum":"","checksum-format":"","size":0,` +
+		`"stored":"0001-01-01T00:00:00Z","started":"0001-01-01T00:00:00Z",` +
+		`"finished":"0001-01-01T00:00:00Z","notes":"","model":"","machine":"",` +
+		`"hostname":"","version":"0.0.0"}` + "\n"
+	s.checkStd(c, ctx, out, "")
+}
+
 func (s *showSuite) TestError(c *gc.C) {
 	s.setFailure("failed!")
 	_, err := testing.RunCommand(c, s.subcommand, s.metaresult.ID)
@@ -4,13 +4,17 @@
 package cachedimages
 
 import (
+	"bytes"
 	"fmt"
+	"text/tabwriter"
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -33,6 +37,9 @@ Examples:
 
   # List all cached lxd images for xenial amd64.
   juju list-cache-images --kind lxd --series xenial --arch amd64
+
+With --format yaml or json, a list of images is written, each with the
+keys kind, series, arch, source-url and created.
 `
 
 // NewListCommand returns a command for listing chached images.
@@ -63,10 +70,7 @@ func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.Kind, "kind", "", "the image kind to list eg lxd")
 	f.StringVar(&c.Series, "series", "", "the series of the image to list eg xenial")
 	f.StringVar(&c.Arch, "arch", "", "the architecture of the image to list eg amd64")
-	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
-		"yaml": cmd.FormatYaml,
-		"json": cmd.FormatJson,
-	})
+	c.out.AddFlags(f, "tabular", common.Formatters(formatImagesTabular))
 }
 
 // Init implements Command.Init.
@@ -94,7 +98,7 @@ type ImageInfo struct {
 }
 
 func (c *listCommand) imageMetadataToImageInfo(images []params.ImageMetadata) []ImageInfo {
-	var output []ImageInfo
+	output := []ImageInfo{}
 	for _, metadata := range images {
 		imageInfo := ImageInfo{
 			Kind:      metadata.Kind,
@@ -121,10 +125,25 @@ func (c *listCommand) Run(ctx *cmd.Context) (err error) {
 		return err
 	}
 	imageInfo := c.imageMetadataToImageInfo(results)
-	if len(imageInfo) == 0 {
-		fmt.Fprintf(ctx.Stdout, "no matching images found\n")
-		return nil
-	}
-	fmt.Fprintf(ctx.Stdout, "Cached images:\n")
 	return c.out.Write(ctx, imageInfo)
 }
+
+// formatImagesTabular returns a tabular summary of cached images.
+func formatImagesTabular(value interface{}) ([]byte, error) {
+	images, ok := value.([]ImageInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", images, value)
+	}
+	var out bytes.Buffer
+	if len(images) == 0 {
+		fmt.Fprintln(&out, "no matching images found")
+		return out.Bytes(), nil
+	}
+	tw := tabwriter.NewWriter(&out, 0, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tSERIES\tARCH\tSOURCE\tCREATED")
+	for _, image := range images {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", image.Kind, image.Series, image.Arch, image.SourceURL, image.Created)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -62,10 +62,24 @@ func (*listImagesCommandSuite) TestListImagesNone(c *gc.C) {
 	c.Assert(testing.Stdout(context), gc.Equals, "no matching images found\n")
 }
 
+func (*listImagesCommandSuite) TestListImagesNoneJson(c *gc.C) {
+	context, err := runListCommand(c, "--format", "json", "--kind", "kvm")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, "[]\n")
+}
+
+func (*listImagesCommandSuite) TestListImagesTabular(c *gc.C) {
+	context, err := runListCommand(c, "--kind", "lxd", "--series", "trusty", "--arch", "amd64")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, ""+
+		"KIND  SERIES  ARCH   SOURCE        CREATED\n"+
+		"lxd   trusty  amd64  http://image  Thu, 01 Jan 2015 00:00:00 UTC\n")
+}
+
 func (*listImagesCommandSuite) TestListImagesFormatJson(c *gc.C) {
 	context, err := runListCommand(c, "--format", "json", "--kind", "lxd", "--series", "trusty", "--arch", "amd64")
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(testing.Stdout(context), gc.Equals, "Cached images:\n["+
+	c.Assert(testing.Stdout(context), gc.Equals, "["+
 		`{"kind":"lxd","series":"trusty","arch":"amd64","source-url":"http://image","created":"Thu, 01 Jan 2015 00:00:00 UTC"}`+
 		"]\n")
 }
@@ -73,7 +87,7 @@ func (*listImagesCommandSuite) TestListImagesFormatJson(c *gc.C) {
 func (*listImagesCommandSuite) TestListImagesFormatYaml(c *gc.C) {
 	context, err := runListCommand(c, "--format", "yaml", "--kind", "lxd", "--series", "trusty", "--arch", "amd64")
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(testing.Stdout(context), gc.Equals, "Cached images:\n"+
+	c.Assert(testing.Stdout(context), gc.Equals, ""+
 		"- kind: lxd\n"+
 		"  series: trusty\n"+
 		"  arch: amd64\n"+
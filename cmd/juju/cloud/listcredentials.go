@@ -5,6 +5,7 @@ package cloud
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
@@ -39,10 +40,17 @@ created.
 Credentials denoted with an asterisk '*' are currently set as the default
 for the given cloud.
 
+The yaml and json formats have the same structure: a "credentials"
+object mapping cloud names to objects that map credential names to
+the credential's "auth-type" and attributes. The optional keys
+"default-credential" and "default-region" appear alongside the
+credential names.
+
 Examples:
     juju list-credentials
     juju list-credentials aws
     juju list-credentials --format yaml --show-secrets
+    juju list-credentials --format json aws
 
 See also: 
     add-credential
@@ -65,6 +73,30 @@ type credentialsMap struct {
 	Credentials map[string]jujucloud.CloudCredential `yaml:"credentials" json:"credentials"`
 }
 
+// MarshalJSON implements json.Marshaler. The JSON output has the same
+// structure as the YAML output: each cloud maps credential names to
+// objects holding "auth-type" and the credential attributes, alongside
+// the optional "default-credential" and "default-region" keys.
+func (m credentialsMap) MarshalJSON() ([]byte, error) {
+	clouds := make(map[string]map[string]interface{})
+	for cloudName, cloudCredential := range m.Credentials {
+		details := make(map[string]interface{})
+		if cloudCredential.DefaultCredential != "" {
+			details["default-credential"] = cloudCredential.DefaultCredential
+		}
+		if cloudCredential.DefaultRegion != "" {
+			details["default-region"] = cloudCredential.DefaultRegion
+		}
+		for name, credential := range cloudCredential.AuthCredentials {
+			attrs := credential.Attributes()
+			attrs["auth-type"] = string(credential.AuthType())
+			details[name] = attrs
+		}
+		clouds[cloudName] = details
+	}
+	return json.Marshal(map[string]interface{}{"credentials": clouds})
+}
+
 // NewListCredentialsCommand returns a command to list cloud credentials.
 func NewListCredentialsCommand() cmd.Command {
 	return &listCredentialsCommand{
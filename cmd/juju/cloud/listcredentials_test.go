@@ -205,9 +205,9 @@ credentials:
 `[1:])
 }
 
-func (s *listCredentialsSuite) TestListCredentialsJSON(c *gc.C) {
-	// TODO(axw) test once json marshalling works properly
-	c.Skip("not implemented: credentials don't marshal to JSON yet")
+func (s *listCredentialsSuite) TestListCredentialsJSONFiltered(c *gc.C) {
+	out := s.listCredentials(c, "--format", "json", "azure")
+	c.Assert(out, gc.Equals, `{"credentials":{"azure":{"azhja":{"application-id":"app-id","auth-type":"userpass","subscription-id":"subscription-id","tenant-id":"tenant-id"}}}}`+"\n")
 }
 
 func (s *listCredentialsSuite) TestListCredentialsNone(c *gc.C) {
@@ -224,7 +224,11 @@ func (s *listCredentialsSuite) TestListCredentialsNone(c *gc.C) {
 	out = strings.Replace(testing.Stdout(ctx), "\n", "", -1)
 	c.Assert(out, gc.Equals, "credentials: {}")
 
-	// TODO(axw) test json once json marshaling works properly
+	ctx, err = testing.RunCommand(c, listCmd, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "")
+	out = strings.Replace(testing.Stdout(ctx), "\n", "", -1)
+	c.Assert(out, gc.Equals, `{"credentials":{}}`)
 }
 
 func (s *listCredentialsSuite) listCredentials(c *gc.C, args ...string) string {
@@ -38,9 +38,9 @@ func NewShowCloudCommand() cmd.Command {
 }
 
 func (c *showCloudCommand) SetFlags(f *gnuflag.FlagSet) {
-	// We only support yaml for display purposes.
 	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
 		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
 	})
 }
 
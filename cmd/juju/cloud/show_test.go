@@ -36,3 +36,10 @@ regions:
     endpoint: https://ec2.cn-north-1.amazonaws.com.cn/
 `[1:])
 }
+
+func (s *showSuite) TestShowJSON(c *gc.C) {
+	ctx, err := testing.RunCommand(c, cloud.NewShowCloudCommand(), "--format", "json", "aws-china")
+	c.Assert(err, jc.ErrorIsNil)
+	out := testing.Stdout(ctx)
+	c.Assert(out, gc.Equals, `{"defined":"public","type":"ec2","auth-types":["access-key"],"regions":{"cn-north-1":{"endpoint":"https://ec2.cn-north-1.amazonaws.com.cn/"}}}`+"\n")
+}
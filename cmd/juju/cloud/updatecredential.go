@@ -0,0 +1,123 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+var usageUpdateCredentialSummary = `
+Updates a credential stored in the current controller.`[1:]
+
+var usageUpdateCredentialDetails = `
+Cloud credentials used by models are stored in the controller. When a
+credential is changed locally, for example because a cloud access key
+has been rotated, this command uploads the new credential to the
+controller. Every model using the credential is updated, and the
+controller and machine agents pick up the new credential without
+being restarted.
+
+The credential is read from the local credential store, and so must
+first be updated with ` + "`juju add-credential`" + ` or ` + "`juju autoload-credentials`" + `.
+
+Examples:
+    juju update-credential aws my-credential
+
+See also:
+    add-credential
+    add-model
+    list-credentials`
+
+// UpdateCredentialAPI defines the API methods used by the
+// update-credential command.
+type UpdateCredentialAPI interface {
+	Close() error
+	UpdateCloudCredential(owner, cloudName, name string, credential jujucloud.Credential) ([]names.ModelTag, error)
+}
+
+type updateCredentialCommand struct {
+	modelcmd.ControllerCommandBase
+
+	api             UpdateCredentialAPI
+	credentialStore jujuclient.CredentialGetter
+	cloudByNameFunc func(string) (*jujucloud.Cloud, error)
+
+	cloud      string
+	credential string
+}
+
+// NewUpdateCredentialCommand returns a command to upload a cloud
+// credential to the current controller.
+func NewUpdateCredentialCommand() cmd.Command {
+	return modelcmd.WrapController(&updateCredentialCommand{
+		credentialStore: jujuclient.NewFileCredentialStore(),
+		cloudByNameFunc: jujucloud.CloudByName,
+	})
+}
+
+func (c *updateCredentialCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "update-credential",
+		Args:    "<cloud name> <credential name>",
+		Purpose: usageUpdateCredentialSummary,
+		Doc:     usageUpdateCredentialDetails,
+	}
+}
+
+func (c *updateCredentialCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("Usage: juju update-credential <cloud-name> <credential-name>")
+	}
+	c.cloud = args[0]
+	c.credential = args[1]
+	return cmd.CheckEmpty(args[2:])
+}
+
+func (c *updateCredentialCommand) getAPI() (UpdateCredentialAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewModelManagerAPIClient()
+}
+
+func (c *updateCredentialCommand) Run(ctx *cmd.Context) error {
+	cloud, err := c.cloudByNameFunc(c.cloud)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	credential, _, _, err := modelcmd.GetCredentials(
+		c.credentialStore, "", c.credential, c.cloud, cloud.Type,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	store := c.ClientStore()
+	account, err := store.AccountByName(c.ControllerName(), c.AccountName())
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	models, err := client.UpdateCloudCredential(account.User, c.cloud, c.credential, *credential)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Credential %q for cloud %q has been updated.", c.credential, c.cloud)
+	for _, model := range models {
+		ctx.Verbosef("updated model %s", model.Id())
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/cmd/juju/cloud"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	_ "github.com/juju/juju/provider/ec2"
+	"github.com/juju/juju/testing"
+)
+
+type updateCredentialSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store *jujuclienttesting.MemStore
+	api   *fakeUpdateCredentialAPI
+}
+
+var _ = gc.Suite(&updateCredentialSuite{})
+
+func (s *updateCredentialSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	controllerName := "local.test-master"
+	err := modelcmd.WriteCurrentController(controllerName)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.Controllers[controllerName] = jujuclient.ControllerDetails{}
+	s.store.Accounts[controllerName] = &jujuclient.ControllerAccounts{
+		Accounts: map[string]jujuclient.AccountDetails{
+			"bob@local": {User: "bob@local"},
+		},
+		CurrentAccount: "bob@local",
+	}
+	s.store.Credentials["aws"] = jujucloud.CloudCredential{
+		AuthCredentials: map[string]jujucloud.Credential{
+			"secrets": jujucloud.NewCredential(jujucloud.AccessKeyAuthType, map[string]string{
+				"access-key": "key",
+				"secret-key": "sekret",
+			}),
+		},
+	}
+	s.api = &fakeUpdateCredentialAPI{}
+}
+
+func (s *updateCredentialSuite) run(c *gc.C, args ...string) error {
+	cloudByName := func(name string) (*jujucloud.Cloud, error) {
+		if name != "aws" {
+			return nil, errors.NotFoundf("cloud %s", name)
+		}
+		return &jujucloud.Cloud{Type: "ec2"}, nil
+	}
+	command := cloud.NewUpdateCredentialCommandForTest(s.api, s.store, s.store, cloudByName)
+	_, err := testing.RunCommand(c, command, args...)
+	return err
+}
+
+func (s *updateCredentialSuite) TestBadArgs(c *gc.C) {
+	err := s.run(c, "aws")
+	c.Assert(err, gc.ErrorMatches, "Usage: juju update-credential <cloud-name> <credential-name>")
+	err = s.run(c, "aws", "secrets", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *updateCredentialSuite) TestUpdate(c *gc.C) {
+	err := s.run(c, "aws", "secrets")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.owner, gc.Equals, "bob@local")
+	c.Assert(s.api.cloud, gc.Equals, "aws")
+	c.Assert(s.api.name, gc.Equals, "secrets")
+	c.Assert(s.api.credential.AuthType(), gc.Equals, jujucloud.AccessKeyAuthType)
+	c.Assert(s.api.credential.Attributes(), jc.DeepEquals, map[string]string{
+		"access-key": "key",
+		"secret-key": "sekret",
+	})
+}
+
+func (s *updateCredentialSuite) TestUnknownCredential(c *gc.C) {
+	err := s.run(c, "aws", "missing")
+	c.Assert(err, gc.ErrorMatches, `"missing" credential for cloud "aws" not found`)
+	c.Assert(s.api.name, gc.Equals, "")
+}
+
+type fakeUpdateCredentialAPI struct {
+	owner      string
+	cloud      string
+	name       string
+	credential jujucloud.Credential
+}
+
+func (*fakeUpdateCredentialAPI) Close() error {
+	return nil
+}
+
+func (f *fakeUpdateCredentialAPI) UpdateCloudCredential(owner, cloudName, name string, credential jujucloud.Credential) ([]names.ModelTag, error) {
+	f.owner = owner
+	f.cloud = cloudName
+	f.name = name
+	f.credential = credential
+	return []names.ModelTag{names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d")}, nil
+}
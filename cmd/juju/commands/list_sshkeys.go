@@ -4,13 +4,15 @@
 package commands
 
 import (
+	"bytes"
 	"fmt"
-	"strings"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	"github.com/juju/utils/ssh"
 	"launchpad.net/gnuflag"
 
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -31,7 +33,10 @@ Examples:
 
 To examine the full key, use the '--full' option:
 
-    juju list-keys -m jujutest --full`[1:]
+    juju list-keys -m jujutest --full
+
+With --format yaml or json, an object is written with the keys "model",
+the model name, and "keys", the list of fingerprints or full keys.`[1:]
 
 // NewListKeysCommand returns a command used to list the authorized ssh keys.
 func NewListKeysCommand() cmd.Command {
@@ -43,6 +48,13 @@ type listKeysCommand struct {
 	SSHKeysBase
 	showFullKey bool
 	user        string
+	out         cmd.Output
+}
+
+// sshKeysOutput is the machine-readable output of list-ssh-keys.
+type sshKeysOutput struct {
+	Model string   `yaml:"model" json:"model"`
+	Keys  []string `yaml:"keys" json:"keys"`
 }
 
 // Info implements Command.Info.
@@ -58,6 +70,7 @@ func (c *listKeysCommand) Info() *cmd.Info {
 // SetFlags implements Command.SetFlags.
 func (c *listKeysCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.showFullKey, "full", false, "Show full key instead of just the fingerprint")
+	c.out.AddFlags(f, "tabular", common.Formatters(formatSSHKeysTabular))
 }
 
 // Run implements Command.Run.
@@ -83,7 +96,25 @@ func (c *listKeysCommand) Run(context *cmd.Context) error {
 	if result.Error != nil {
 		return result.Error
 	}
-	fmt.Fprintf(context.Stdout, "Keys used in model: %s\n", c.ConnectionName())
-	fmt.Fprintln(context.Stdout, strings.Join(result.Result, "\n"))
-	return nil
+	keys := result.Result
+	if keys == nil {
+		keys = []string{}
+	}
+	return c.out.Write(context, sshKeysOutput{
+		Model: c.ConnectionName(),
+		Keys:  keys,
+	})
+}
+
+func formatSSHKeysTabular(value interface{}) ([]byte, error) {
+	output, ok := value.(sshKeysOutput)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", output, value)
+	}
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "Keys used in model: %s\n", output.Model)
+	for _, key := range output.Keys {
+		fmt.Fprintln(&out, key)
+	}
+	return out.Bytes(), nil
 }
@@ -260,6 +260,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(machine.NewRemoveCommand())
 	r.Register(machine.NewListMachinesCommand())
 	r.Register(machine.NewShowMachineCommand())
+	r.Register(machine.NewUpgradeSeriesCommand())
 
 	// Manage model
 	r.Register(model.NewGetCommand())
@@ -294,6 +295,15 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(service.NewUnexposeCommand())
 	r.Register(service.NewServiceGetConstraintsCommand())
 	r.Register(service.NewServiceSetConstraintsCommand())
+	r.Register(service.NewServiceGetContainerProfileCommand())
+	r.Register(service.NewServiceSetContainerProfileCommand())
+	r.Register(service.NewSuspendRelationCommand())
+	r.Register(service.NewResumeRelationCommand())
+	r.Register(service.NewTransferLeaderCommand())
+	r.Register(service.NewPinLeaderCommand())
+	r.Register(service.NewUnpinLeaderCommand())
+	r.Register(service.NewOfferCommand())
+	r.Register(service.NewListOffersCommand())
 
 	// Operation protection commands
 	r.Register(block.NewSuperBlockCommand())
@@ -325,6 +335,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage controllers
 	r.Register(controller.NewAddModelCommand())
+	r.Register(controller.NewAuditLogCommand())
 	r.Register(controller.NewDestroyCommand())
 	r.Register(controller.NewListModelsCommand())
 	r.Register(controller.NewKillCommand())
@@ -332,11 +343,13 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewListBlocksCommand())
 	r.Register(controller.NewRegisterCommand())
 	r.Register(controller.NewRemoveBlocksCommand())
+	r.Register(controller.NewRotateCertificatesCommand())
 	r.Register(controller.NewShowControllerCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
 	r.Register(metricsdebug.NewCollectMetricsCommand())
+	r.Register(metricsdebug.NewMetricsCommand())
 	r.Register(setmeterstatus.New())
 
 	// Manage clouds and credentials
@@ -350,6 +363,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(cloud.NewSetDefaultCredentialCommand())
 	r.Register(cloud.NewAddCredentialCommand())
 	r.Register(cloud.NewRemoveCredentialCommand())
+	r.Register(cloud.NewUpdateCredentialCommand())
 
 	// Juju GUI commands.
 	r.Register(gui.NewGUICommand())
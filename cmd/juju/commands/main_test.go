@@ -342,6 +342,7 @@ var commandNames = []string{
 	"add-user",
 	"agree",
 	"allocate",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"block",
@@ -370,6 +371,7 @@ var commandNames = []string{
 	"get-config",
 	"get-configs",
 	"get-constraints",
+	"get-container-profile",
 	"get-model-config",
 	"get-model-constraints",
 	"grant",
@@ -391,6 +393,7 @@ var commandNames = []string{
 	"list-machine",
 	"list-machines",
 	"list-models",
+	"list-offers",
 	"list-plans",
 	"list-shares",
 	"list-ssh-key",
@@ -404,6 +407,10 @@ var commandNames = []string{
 	"logout",
 	"machine",
 	"machines",
+	"metrics",
+	"offer",
+	"offers",
+	"pin-leader",
 	"publish",
 	"register",
 	"remove-all-blocks",
@@ -419,8 +426,10 @@ var commandNames = []string{
 	"remove-unit", // alias for destroy-unit
 	"resolved",
 	"restore-backup",
+	"resume-relation",
 	"retry-provisioning",
 	"revoke",
+	"rotate-controller-certificates",
 	"run",
 	"run-action",
 	"scp",
@@ -428,6 +437,7 @@ var commandNames = []string{
 	"set-config",
 	"set-configs",
 	"set-constraints",
+	"set-container-profile",
 	"set-default-credential",
 	"set-default-region",
 	"set-meter-status",
@@ -455,17 +465,22 @@ var commandNames = []string{
 	"status-history",
 	"storage",
 	"subnets",
+	"suspend-relation",
 	"switch",
 	"sync-tools",
+	"transfer-leader",
 	"unblock",
 	"unexpose",
+	"unpin-leader",
 	"update-allocation",
 	"upload-backup",
 	"unset-model-config",
 	"update-clouds",
+	"update-credential",
 	"upgrade-charm",
 	"upgrade-gui",
 	"upgrade-juju",
+	"upgrade-series",
 	"version",
 }
 
@@ -6,6 +6,7 @@ package commands
 import (
 	"encoding/base64"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -13,8 +14,10 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/names"
+	"github.com/juju/utils/set"
 	"launchpad.net/gnuflag"
 
+	"github.com/juju/juju/api"
 	actionapi "github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/action"
@@ -36,6 +39,9 @@ type runCommand struct {
 	services []string
 	units    []string
 	commands string
+
+	maxParallel   int
+	stopOnFailure bool
 }
 
 const runDoc = `
@@ -64,6 +70,18 @@ the unit.
 in the model.  If you specify --all you cannot provide additional
 targets.
 
+Results are written out as each target finishes running the commands,
+except with --format=json, where they are all written once every target
+has finished.
+
+--max-parallel limits how many targets run the commands at once; targets
+are started as others finish, and --timeout applies to each target
+separately. Use --stop-on-failure to stop starting new targets, and
+cancel any that have not started running yet, once the commands fail on
+any target. For example
+  juju run --service mysql --max-parallel 2 --stop-on-failure "hooks/upgrade"
+runs the commands on two mysql units at a time until they fail on one.
+
 Since juju run creates actions, you can query for the status of commands
 started with juju run by calling "juju show-action-status --name juju-run".
 `
@@ -84,6 +102,8 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewStringsValue(nil, &c.machines), "machine", "one or more machine ids")
 	f.Var(cmd.NewStringsValue(nil, &c.services), "service", "one or more service names")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "one or more unit ids")
+	f.IntVar(&c.maxParallel, "max-parallel", 0, "the maximum number of targets to run the commands on at once (0 for no limit)")
+	f.BoolVar(&c.stopOnFailure, "stop-on-failure", false, "stop running the commands on further targets once they fail on any target")
 }
 
 func (c *runCommand) Init(args []string) error {
@@ -108,6 +128,10 @@ func (c *runCommand) Init(args []string) error {
 		}
 	}
 
+	if c.maxParallel < 0 {
+		return fmt.Errorf("--max-parallel cannot be negative")
+	}
+
 	var nameErrors []string
 	for _, machineId := range c.machines {
 		if !names.IsValidMachine(machineId) {
@@ -187,92 +211,106 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
-	var runResults []params.ActionResult
-	if c.all {
-		runResults, err = client.RunOnAllMachines(c.commands, c.timeout)
-	} else {
-		params := params.RunParams{
-			Commands: c.commands,
-			Timeout:  c.timeout,
-			Machines: c.machines,
-			Services: c.services,
-			Units:    c.units,
-		}
-		runResults, err = client.Run(params)
-	}
-
-	if err != nil {
-		return block.ProcessBlockedError(err, block.BlockChange)
-	}
-
-	actionsToQuery := []actionQuery{}
-	for _, result := range runResults {
-		if result.Error != nil {
-			fmt.Fprintf(ctx.GetStderr(), "couldn't queue one action: %v", result.Error)
-			continue
-		}
-		actionTag, err := names.ParseActionTag(result.Action.Tag)
+	// queue holds the targets that are still to be started when the
+	// number of targets running at once is limited.
+	var queue []params.RunParams
+	var running []actionQuery
+	if c.maxParallel > 0 {
+		queue, err = c.runTargets(client)
 		if err != nil {
-			fmt.Fprintf(ctx.GetStderr(), "got invalid action tag %v for receiver %v", result.Action.Tag, result.Action.Receiver)
-			continue
+			return errors.Trace(err)
+		}
+	} else {
+		var runResults []params.ActionResult
+		if c.all {
+			runResults, err = client.RunOnAllMachines(c.commands, c.timeout)
+		} else {
+			params := params.RunParams{
+				Commands: c.commands,
+				Timeout:  c.timeout,
+				Machines: c.machines,
+				Services: c.services,
+				Units:    c.units,
+			}
+			runResults, err = client.Run(params)
 		}
-
-		receiverTag, err := names.ActionReceiverFromTag(result.Action.Receiver)
 		if err != nil {
-			fmt.Fprintf(ctx.GetStderr(), "got invalid action receiver tag %v for action %v", result.Action.Receiver, result.Action.Tag)
-			continue
+			return block.ProcessBlockedError(err, block.BlockChange)
 		}
-		var receiverType string
-		switch receiverTag.(type) {
-		case names.UnitTag:
-			receiverType = "UnitId"
-		case names.MachineTag:
-			receiverType = "MachineId"
-		default:
-			receiverType = "ReceiverId"
+		running = actionQueries(ctx, runResults)
+		if len(running) == 0 {
+			return errors.New("no actions were successfully enqueued, aborting")
 		}
-		actionsToQuery = append(actionsToQuery, actionQuery{
-			actionTag: actionTag,
-			receiver: actionReceiver{
-				receiverType: receiverType,
-				tag:          receiverTag,
-			}})
 	}
 
-	if len(actionsToQuery) == 0 {
-		return errors.New("no actions were successfully enqueued, aborting")
-	}
+	// Results are written as soon as they arrive, unless there is
+	// only one of them or they must be written as a single JSON
+	// document.
+	total := len(running) + len(queue)
+	stream := total > 1 && c.out.Name() != "json"
 
+	enqueued := len(running)
 	values := []interface{}{}
-	for len(actionsToQuery) > 0 {
-		actionResults, err := client.Actions(entities(actionsToQuery))
+	var failedOn names.Tag
+	for {
+		for failedOn == nil && len(queue) > 0 && len(running) < c.maxParallel {
+			runResults, err := client.Run(queue[0])
+			if err != nil {
+				return block.ProcessBlockedError(err, block.BlockChange)
+			}
+			queue = queue[1:]
+			started := actionQueries(ctx, runResults)
+			running = append(running, started...)
+			enqueued += len(started)
+		}
+		if len(running) == 0 {
+			break
+		}
+
+		actionResults, err := client.Actions(entities(running))
 		if err != nil {
 			return errors.Trace(err)
 		}
 
-		newActionsToQuery := []actionQuery{}
+		newRunning := []actionQuery{}
 		for i, result := range actionResults.Results {
 			if result.Error == nil {
 				switch result.Status {
 				case params.ActionRunning, params.ActionPending:
-					newActionsToQuery = append(newActionsToQuery, actionsToQuery[i])
+					newRunning = append(newRunning, running[i])
 					continue
 				}
 			}
 
-			values = append(values, ConvertActionResults(result, actionsToQuery[i]))
+			value := ConvertActionResults(result, running[i])
+			if c.stopOnFailure && failedOn == nil && resultFailed(value) {
+				failedOn = running[i].receiver.tag
+				c.cancelPending(ctx, client, actionResults.Results, running)
+			}
+			if stream {
+				if err := c.out.Write(ctx, []interface{}{value}); err != nil {
+					return errors.Trace(err)
+				}
+			} else {
+				values = append(values, value)
+			}
 		}
 
-		actionsToQuery = newActionsToQuery
+		running = newRunning
+		if len(running) > 0 {
+			// TODO: use a watcher instead of sleeping
+			// this should be easier once we implement action grouping
+			<-afterFunc(1 * time.Second)
+		}
+	}
 
-		// TODO: use a watcher instead of sleeping
-		// this should be easier once we implement action grouping
-		<-afterFunc(1 * time.Second)
+	if enqueued == 0 {
+		return errors.New("no actions were successfully enqueued, aborting")
 	}
 
 	// If we are just dealing with one result, AND we are using the smart
 	// format, then pretend we were running it locally.
-	if len(values) == 1 && c.out.Name() == "smart" {
+	if len(values) == 1 && total == 1 && c.out.Name() == "smart" {
 		result, ok := values[0].(map[string]interface{})
 		if !ok {
 			return errors.New("couldn't read action output")
@@ -293,7 +331,163 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return nil
 	}
 
-	return c.out.Write(ctx, values)
+	if !stream {
+		if err := c.out.Write(ctx, values); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if failedOn != nil {
+		return errors.Errorf("commands failed on %s %s, %d remaining targets not run",
+			failedOn.Kind(), failedOn.Id(), len(queue))
+	}
+	return nil
+}
+
+// runTargets returns the parameters for running the commands on each
+// of the machines and units targeted, one at a time. Services, and
+// all machines when --all is specified, are expanded using the model
+// status.
+func (c *runCommand) runTargets(client RunClient) ([]params.RunParams, error) {
+	machines := c.machines
+	units := c.units
+	if c.all || len(c.services) > 0 {
+		status, err := client.Status(nil)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot get model status")
+		}
+		if c.all {
+			machines = statusMachines(status.Machines)
+		}
+		serviceUnits := make(map[string][]string)
+		for _, serviceStatus := range status.Services {
+			for unit, unitStatus := range serviceStatus.Units {
+				addServiceUnit(serviceUnits, unit)
+				for subordinate := range unitStatus.Subordinates {
+					addServiceUnit(serviceUnits, subordinate)
+				}
+			}
+		}
+		seen := set.NewStrings(units...)
+		for _, service := range c.services {
+			if _, ok := status.Services[service]; !ok {
+				return nil, errors.NotFoundf("service %q", service)
+			}
+			serviceUnit := serviceUnits[service]
+			sort.Strings(serviceUnit)
+			for _, unit := range serviceUnit {
+				if !seen.Contains(unit) {
+					seen.Add(unit)
+					units = append(units, unit)
+				}
+			}
+		}
+	}
+
+	var targets []params.RunParams
+	for _, machine := range machines {
+		targets = append(targets, params.RunParams{
+			Commands: c.commands,
+			Timeout:  c.timeout,
+			Machines: []string{machine},
+		})
+	}
+	for _, unit := range units {
+		targets = append(targets, params.RunParams{
+			Commands: c.commands,
+			Timeout:  c.timeout,
+			Units:    []string{unit},
+		})
+	}
+	return targets, nil
+}
+
+// cancelPending cancels the actions that have not started running
+// yet, so that they will be reported as cancelled.
+func (c *runCommand) cancelPending(ctx *cmd.Context, client RunClient, results []params.ActionResult, queries []actionQuery) {
+	var pending params.Actions
+	for i, result := range results {
+		if result.Error == nil && result.Status == params.ActionPending {
+			pending.Actions = append(pending.Actions, params.Action{
+				Tag: queries[i].actionTag.String(),
+			})
+		}
+	}
+	if len(pending.Actions) == 0 {
+		return
+	}
+	if _, err := client.Cancel(pending); err != nil {
+		fmt.Fprintf(ctx.GetStderr(), "couldn't cancel pending actions: %v\n", err)
+	}
+}
+
+// actionQueries returns the queries for the actions enqueued by a run
+// request, reporting those that could not be enqueued.
+func actionQueries(ctx *cmd.Context, runResults []params.ActionResult) []actionQuery {
+	actionsToQuery := []actionQuery{}
+	for _, result := range runResults {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.GetStderr(), "couldn't queue one action: %v", result.Error)
+			continue
+		}
+		actionTag, err := names.ParseActionTag(result.Action.Tag)
+		if err != nil {
+			fmt.Fprintf(ctx.GetStderr(), "got invalid action tag %v for receiver %v", result.Action.Tag, result.Action.Receiver)
+			continue
+		}
+
+		receiverTag, err := names.ActionReceiverFromTag(result.Action.Receiver)
+		if err != nil {
+			fmt.Fprintf(ctx.GetStderr(), "got invalid action receiver tag %v for action %v", result.Action.Receiver, result.Action.Tag)
+			continue
+		}
+		var receiverType string
+		switch receiverTag.(type) {
+		case names.UnitTag:
+			receiverType = "UnitId"
+		case names.MachineTag:
+			receiverType = "MachineId"
+		default:
+			receiverType = "ReceiverId"
+		}
+		actionsToQuery = append(actionsToQuery, actionQuery{
+			actionTag: actionTag,
+			receiver: actionReceiver{
+				receiverType: receiverType,
+				tag:          receiverTag,
+			}})
+	}
+	return actionsToQuery
+}
+
+// resultFailed reports whether the converted result of an action
+// shows that the commands failed.
+func resultFailed(value map[string]interface{}) bool {
+	for _, key := range []string{"Error", "ReturnCode", "Message"} {
+		if _, ok := value[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// statusMachines returns the sorted ids of the given machines and
+// all their containers.
+func statusMachines(machines map[string]params.MachineStatus) []string {
+	var ids []string
+	for id, machine := range machines {
+		ids = append(ids, id)
+		ids = append(ids, statusMachines(machine.Containers)...)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func addServiceUnit(serviceUnits map[string][]string, unit string) {
+	service, err := names.UnitService(unit)
+	if err != nil {
+		return
+	}
+	serviceUnits[service] = append(serviceUnits[service], unit)
 }
 
 type actionReceiver struct {
@@ -311,6 +505,19 @@ type RunClient interface {
 	action.APIClient
 	RunOnAllMachines(commands string, timeout time.Duration) ([]params.ActionResult, error)
 	Run(params.RunParams) ([]params.ActionResult, error)
+	Status(patterns []string) (*params.FullStatus, error)
+}
+
+// runAPIClient adds the model status, needed to find the individual
+// targets of the commands, to the actions API client.
+type runAPIClient struct {
+	*actionapi.Client
+	statusClient *api.Client
+}
+
+// Status implements RunClient.
+func (c *runAPIClient) Status(patterns []string) (*params.FullStatus, error) {
+	return c.statusClient.Status(patterns)
 }
 
 // In order to be able to easily mock out the API side for testing,
@@ -320,7 +527,10 @@ var getRunAPIClient = func(c *runCommand) (RunClient, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return actionapi.NewClient(root), errors.Trace(err)
+	return &runAPIClient{
+		Client:       actionapi.NewClient(root),
+		statusClient: root.Client(),
+	}, nil
 }
 
 // getActionResult abstracts over the action CLI function that we use here to fetch results
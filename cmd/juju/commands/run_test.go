@@ -158,6 +158,37 @@ func (*RunSuite) TestTimeoutArgParsing(c *gc.C) {
 	}
 }
 
+func (*RunSuite) TestMaxParallelArgParsing(c *gc.C) {
+	for i, test := range []struct {
+		message       string
+		args          []string
+		errMatch      string
+		maxParallel   int
+		stopOnFailure bool
+	}{{
+		message: "no limit by default",
+		args:    []string{"--all", "sudo reboot"},
+	}, {
+		message:       "limit and stop on failure",
+		args:          []string{"--max-parallel=2", "--stop-on-failure", "--all", "sudo reboot"},
+		maxParallel:   2,
+		stopOnFailure: true,
+	}, {
+		message:  "negative limit",
+		args:     []string{"--max-parallel=-1", "--all", "sudo reboot"},
+		errMatch: "--max-parallel cannot be negative",
+	}} {
+		c.Log(fmt.Sprintf("%v: %s", i, test.message))
+		cmd := &runCommand{}
+		runCmd := modelcmd.Wrap(cmd)
+		testing.TestInit(c, runCmd, test.args, test.errMatch)
+		if test.errMatch == "" {
+			c.Check(cmd.maxParallel, gc.Equals, test.maxParallel)
+			c.Check(cmd.stopOnFailure, gc.Equals, test.stopOnFailure)
+		}
+	}
+}
+
 func (s *RunSuite) TestConvertRunResults(c *gc.C) {
 	for i, test := range []struct {
 		message  string
@@ -401,6 +432,141 @@ func (s *RunSuite) TestSingleResponse(c *gc.C) {
 	}
 }
 
+func (s *RunSuite) TestStreamedOutput(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setResponse("0", mockResponse{stdout: "megatron\n", machineTag: "machine-0"})
+	mock.setResponse("unit/0", mockResponse{stdout: "bumblebee", unitTag: "unit-unit-0"})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]:      mock.runResponses["0"],
+		mock.receiverIdMap["unit/0"]: mock.runResponses["unit/0"],
+	}
+
+	context, err := testing.RunCommand(c, newRunCommand(),
+		"--format=yaml", "--machine=0", "--unit=unit/0", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Each result is written out on its own as soon as it arrives.
+	machineQuery := makeActionQuery(mock.receiverIdMap["0"], "MachineId", names.NewMachineTag("0"))
+	unitQuery := makeActionQuery(mock.receiverIdMap["unit/0"], "UnitId", names.NewUnitTag("unit/0"))
+	expect := ""
+	for _, value := range []interface{}{
+		ConvertActionResults(mock.runResponses["0"], machineQuery),
+		ConvertActionResults(mock.runResponses["unit/0"], unitQuery),
+	} {
+		formatted, err := cmd.FormatYaml([]interface{}{value})
+		c.Assert(err, jc.ErrorIsNil)
+		expect += string(formatted) + "\n"
+	}
+	c.Check(testing.Stdout(context), gc.Equals, expect)
+}
+
+func (s *RunSuite) TestMaxParallel(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setMachinesAlive("0")
+	mock.services = map[string][]string{
+		"mysql": {"mysql/1", "mysql/0"},
+	}
+	for _, id := range []string{"0", "mysql/0", "mysql/1"} {
+		response := mockResponse{stdout: id}
+		if id == "0" {
+			response.machineTag = names.NewMachineTag(id).String()
+		} else {
+			response.unitTag = names.NewUnitTag(id).String()
+		}
+		mock.setResponse(id, response)
+	}
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]:       mock.runResponses["0"],
+		mock.receiverIdMap["mysql/0"]: mock.runResponses["mysql/0"],
+		mock.receiverIdMap["mysql/1"]: mock.runResponses["mysql/1"],
+	}
+
+	_, err := testing.RunCommand(c, newRunCommand(),
+		"--max-parallel=1", "--machine=0", "--service=mysql", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The service is expanded into its units, and each target
+	// is started on its own.
+	c.Assert(mock.runCalls, gc.HasLen, 3)
+	for i, target := range [][]string{{"0"}, {"mysql/0"}, {"mysql/1"}} {
+		runParams := mock.runCalls[i]
+		c.Check(append(runParams.Machines, runParams.Units...), jc.DeepEquals, target)
+		c.Check(runParams.Services, gc.HasLen, 0)
+		c.Check(runParams.Commands, gc.Equals, "hostname")
+	}
+}
+
+func (s *RunSuite) TestMaxParallelUnknownService(c *gc.C) {
+	s.setupMockAPI()
+	_, err := testing.RunCommand(c, newRunCommand(),
+		"--max-parallel=1", "--service=mysql", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, `service "mysql" not found`)
+}
+
+func (s *RunSuite) TestStopOnFailure(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setMachinesAlive("0", "1", "2")
+	mock.setResponse("0", mockResponse{stdout: "ok", machineTag: "machine-0"})
+	mock.setResponse("1", mockResponse{stderr: "oops", code: "1", machineTag: "machine-1"})
+	mock.setResponse("2", mockResponse{stdout: "ok", machineTag: "machine-2"})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]: mock.runResponses["0"],
+		mock.receiverIdMap["1"]: mock.runResponses["1"],
+		mock.receiverIdMap["2"]: mock.runResponses["2"],
+	}
+
+	context, err := testing.RunCommand(c, newRunCommand(),
+		"--format=json", "--max-parallel=1", "--stop-on-failure", "--all", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, "commands failed on machine 1, 1 remaining targets not run")
+	c.Assert(mock.runCalls, gc.HasLen, 2)
+
+	// The results of the targets that were run are still written.
+	unformatted := []interface{}{
+		ConvertActionResults(mock.runResponses["0"],
+			makeActionQuery(mock.receiverIdMap["0"], "MachineId", names.NewMachineTag("0"))),
+		ConvertActionResults(mock.runResponses["1"],
+			makeActionQuery(mock.receiverIdMap["1"], "MachineId", names.NewMachineTag("1"))),
+	}
+	jsonFormatted, err := cmd.FormatJson(unformatted)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(testing.Stdout(context), gc.Equals, string(jsonFormatted)+"\n")
+}
+
+func (s *RunSuite) TestStopOnFailureCancelsPending(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setMachinesAlive("0", "1")
+	mock.setResponse("0", mockResponse{message: "command timed out", machineTag: "machine-0"})
+	mock.setResponse("1", mockResponse{machineTag: "machine-1"})
+	pending := mock.runResponses["1"]
+	pending.Status = params.ActionPending
+	cancelled := mock.runResponses["1"]
+	cancelled.Status = params.ActionCancelled
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]: mock.runResponses["0"],
+		mock.receiverIdMap["1"]: pending,
+	}
+	mock.cancelResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["1"]: cancelled,
+	}
+	s.PatchValue(&afterFunc, func(time.Duration) <-chan time.Time {
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	})
+
+	_, err := testing.RunCommand(c, newRunCommand(),
+		"--format=json", "--stop-on-failure", "--all", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, "commands failed on machine 0, 0 remaining targets not run")
+	c.Assert(mock.cancelled, jc.DeepEquals, []string{
+		names.NewActionTag(mock.receiverIdMap["1"]).String(),
+	})
+}
+
 func (s *RunSuite) setupMockAPI() *mockRunAPI {
 	mock := &mockRunAPI{}
 	s.PatchValue(&getRunAPIClient, func(_ *runCommand) (RunClient, error) {
@@ -416,10 +582,15 @@ type mockRunAPI struct {
 	code   int
 	// machines, services, units
 	machines        map[string]bool
+	services        map[string][]string
 	runResponses    map[string]params.ActionResult
 	actionResponses map[string]params.ActionResult
+	cancelResponses map[string]params.ActionResult
 	receiverIdMap   map[string]string
 	block           bool
+
+	runCalls  []params.RunParams
+	cancelled []string
 }
 
 type mockResponse struct {
@@ -529,6 +700,7 @@ func (m *mockRunAPI) Run(runParams params.RunParams) ([]params.ActionResult, err
 	if m.block {
 		return result, common.OperationBlockedError("the operation has been blocked")
 	}
+	m.runCalls = append(m.runCalls, runParams)
 	// Just add in ids that match in order.
 	for _, id := range runParams.Machines {
 		response, found := m.runResponses[id]
@@ -566,5 +738,38 @@ func (m *mockRunAPI) Actions(actionTags params.Entities) (params.ActionResults,
 	return results, nil
 }
 
+func (m *mockRunAPI) Cancel(args params.Actions) (params.ActionResults, error) {
+	results := params.ActionResults{Results: make([]params.ActionResult, len(args.Actions))}
+	for i, a := range args.Actions {
+		m.cancelled = append(m.cancelled, a.Tag)
+		id := a.Tag[len("action-"):]
+		if response, found := m.cancelResponses[id]; found {
+			m.actionResponses[id] = response
+		}
+		results.Results[i] = m.actionResponses[id]
+	}
+	return results, nil
+}
+
+func (m *mockRunAPI) Status(patterns []string) (*params.FullStatus, error) {
+	status := &params.FullStatus{
+		Machines: make(map[string]params.MachineStatus),
+		Services: make(map[string]params.ServiceStatus),
+	}
+	for id := range m.machines {
+		status.Machines[id] = params.MachineStatus{}
+	}
+	for service, units := range m.services {
+		serviceStatus := params.ServiceStatus{
+			Units: make(map[string]params.UnitStatus),
+		}
+		for _, unit := range units {
+			serviceStatus.Units[unit] = params.UnitStatus{}
+		}
+		status.Services[service] = serviceStatus
+	}
+	return status, nil
+}
+
 // validUUID is a UUID used in tests
 var validUUID = "01234567-89ab-cdef-0123-456789abcdef"
@@ -59,6 +59,11 @@ current working directory:
 
     juju scp bob@3:'file1 file2' .
 
+Copy file foo.txt to a mysql unit through the API server, when there is
+no network route to its machine:
+
+    juju scp --tunnel foo.txt mysql/0:
+
 See also: 
     ssh`
 
@@ -1,6 +1,7 @@
 // Copyright 2012, 2013 Canonical Ltd.
 // Licensed under the AGPLv3, see LICENCE file for details.
 
+//go:build !windows
 // +build !windows
 
 package commands
@@ -37,6 +38,7 @@ var scpTests = []struct {
 	args   []string
 	result string
 	proxy  bool
+	tunnel bool
 	error  string
 }{
 	{
@@ -84,6 +86,11 @@ var scpTests = []struct {
 		args:   []string{"0:foo", "mysql/0:/foo"},
 		result: commonArgsWithProxy + "ubuntu@admin-0.internal:foo ubuntu@admin-0.internal:/foo\n",
 		proxy:  true,
+	}, {
+		about:  "scp from machine 0 to unit mysql/0 through an API tunnel",
+		args:   []string{"0:foo", "mysql/0:/foo"},
+		result: commonArgsWithTunnel + "ubuntu@machine-0:foo ubuntu@unit-mysql-0:/foo\n",
+		tunnel: true,
 	}, {
 		args:   []string{"0:foo", ".", "-rv", "-o", "SomeOption"},
 		result: commonArgs + "ubuntu@admin-0.dns:foo . -rv -o SomeOption\n",
@@ -140,6 +147,7 @@ func (s *SCPSuite) TestSCPCommand(c *gc.C) {
 		ctx := coretesting.Context(c)
 		scpcmd := &scpCommand{}
 		scpcmd.proxy = t.proxy
+		scpcmd.tunnel = t.tunnel
 
 		err := modelcmd.Wrap(scpcmd).Init(t.args)
 		c.Check(err, jc.ErrorIsNil)
@@ -5,6 +5,7 @@ package commands
 
 import (
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"os/exec"
@@ -49,6 +50,14 @@ Connect to a jenkins unit as user jenkins:
 
     juju ssh jenkins@jenkins/0
 
+Connect to a mysql unit through the API server, when there is no network
+route to its machine:
+
+    juju ssh --tunnel mysql/0
+
+Sessions made with --tunnel are relayed over the API connection, need
+write access to the model, and are recorded in the controller's audit log.
+
 See also: 
     scp`
 
@@ -59,12 +68,14 @@ func newSSHCommand() cmd.Command {
 // sshCommand is responsible for launching a ssh shell on a given unit or machine.
 type sshCommand struct {
 	SSHCommon
+	tunnelStdio bool
 }
 
 // SSHCommon provides common methods for sshCommand, SCPCommand and DebugHooksCommand.
 type SSHCommon struct {
 	modelcmd.ModelCommandBase
 	proxy     bool
+	tunnel    bool
 	pty       bool
 	Target    string
 	Args      []string
@@ -74,9 +85,23 @@ type SSHCommon struct {
 
 func (c *SSHCommon) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.proxy, "proxy", false, "Proxy through the API server")
+	f.BoolVar(&c.tunnel, "tunnel", false, "Tunnel the connection over the API server connection")
 	f.BoolVar(&c.pty, "pty", true, "Enable pseudo-tty allocation")
 }
 
+// setTunnelCommand sets the proxy command option so that ssh
+// connects through the API server, relaying the connection over
+// the websocket opened by "juju ssh --tunnel-stdio".
+func (c *SSHCommon) setTunnelCommand(options *ssh.Options) error {
+	juju, err := getJujuExecutable()
+	if err != nil {
+		return fmt.Errorf("failed to get juju executable path: %v", err)
+	}
+	model := modelcmd.JoinModelName(c.ControllerName(), c.ModelName())
+	options.SetProxyCommand(juju, "ssh", "-m", model, "--tunnel-stdio", "%h")
+	return nil
+}
+
 // setProxyCommand sets the proxy command option.
 func (c *SSHCommon) setProxyCommand(options *ssh.Options) error {
 	apiServerHost, _, err := net.SplitHostPort(c.apiAddr)
@@ -91,6 +116,11 @@ func (c *SSHCommon) setProxyCommand(options *ssh.Options) error {
 	return nil
 }
 
+func (c *sshCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.SSHCommon.SetFlags(f)
+	f.BoolVar(&c.tunnelStdio, "tunnel-stdio", false, "Relay standard input and output to the target over the API server connection (used by --tunnel)")
+}
+
 func (c *sshCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "ssh",
@@ -124,6 +154,12 @@ func (c *SSHCommon) getSSHOptions(enablePty bool) (*ssh.Options, error) {
 	if enablePty {
 		options.EnablePTY()
 	}
+	if c.tunnel {
+		if err := c.setTunnelCommand(&options); err != nil {
+			return nil, err
+		}
+		return &options, nil
+	}
 	var err error
 	if c.proxy, err = c.proxySSH(); err != nil {
 		return nil, err
@@ -148,6 +184,9 @@ func (c *sshCommand) Run(ctx *cmd.Context) error {
 			}
 		}()
 	}
+	if c.tunnelStdio {
+		return c.relayTunnel(ctx)
+	}
 	options, err := c.getSSHOptions(c.pty)
 	if err != nil {
 		return err
@@ -164,6 +203,35 @@ func (c *sshCommand) Run(ctx *cmd.Context) error {
 	return cmd.Run()
 }
 
+// relayTunnel relays standard input and output to the SSH server of
+// the target, given by its tag, over the API server connection. It
+// runs as the proxy command of ssh when --tunnel is specified.
+func (c *sshCommand) relayTunnel(ctx *cmd.Context) error {
+	target, err := names.ParseTag(c.Target)
+	if err != nil {
+		return err
+	}
+	if _, err := c.ensureAPIClient(); err != nil {
+		return err
+	}
+	stream, err := c.apiClient.OpenSSHTunnel(target)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	done := make(chan error, 2)
+	go func() {
+		_, err := io.Copy(stream, ctx.Stdin)
+		done <- err
+	}()
+	go func() {
+		_, err := io.Copy(ctx.Stdout, stream)
+		done <- err
+	}()
+	return <-done
+}
+
 // proxySSH returns false if both c.proxy and
 // the proxy-ssh environment configuration
 // are false -- otherwise it returns true.
@@ -207,6 +275,7 @@ type sshAPIClient interface {
 	ModelGet() (map[string]interface{}, error)
 	PublicAddress(target string) (string, error)
 	PrivateAddress(target string) (string, error)
+	OpenSSHTunnel(target names.Tag) (io.ReadWriteCloser, error)
 	Close() error
 }
 
@@ -241,9 +310,21 @@ func (c *SSHCommon) userHostFromTarget(target string) (user, host string, err er
 	// If the target is neither a machine nor a unit,
 	// assume it's a hostname and try it directly.
 	if !names.IsValidMachine(target) && !names.IsValidUnit(target) {
+		if c.tunnel {
+			return "", "", fmt.Errorf("cannot tunnel to %q: not a machine or unit", target)
+		}
 		return user, target, nil
 	}
 
+	// Tunnelled connections are made to the target's tag, which
+	// the proxy command passes on to the API server.
+	if c.tunnel {
+		if names.IsValidMachine(target) {
+			return user, names.NewMachineTag(target).String(), nil
+		}
+		return user, names.NewUnitTag(target).String(), nil
+	}
+
 	// A target may not initially have an address (e.g. the
 	// address updater hasn't yet run), so we must do this in
 	// a loop.
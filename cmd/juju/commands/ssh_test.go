@@ -55,12 +55,15 @@ func (s *SSHCommonSuite) SetUpTest(c *gc.C) {
 }
 
 const (
-	args                = `-o StrictHostKeyChecking no -o PasswordAuthentication no -o ServerAliveInterval 30 `
-	withProxy           = `-o StrictHostKeyChecking no -o ProxyCommand juju ssh --proxy=false --pty=false localhost nc %h %p -o PasswordAuthentication no -o ServerAliveInterval 30 `
-	commonArgsWithProxy = withProxy + `-o UserKnownHostsFile /dev/null `
-	commonArgs          = args + `-o UserKnownHostsFile /dev/null `
-	sshArgs             = args + `-t -t -o UserKnownHostsFile /dev/null `
-	sshArgsWithProxy    = withProxy + `-t -t -o UserKnownHostsFile /dev/null `
+	args                 = `-o StrictHostKeyChecking no -o PasswordAuthentication no -o ServerAliveInterval 30 `
+	withProxy            = `-o StrictHostKeyChecking no -o ProxyCommand juju ssh --proxy=false --pty=false localhost nc %h %p -o PasswordAuthentication no -o ServerAliveInterval 30 `
+	withTunnel           = `-o StrictHostKeyChecking no -o ProxyCommand juju ssh -m kontroll:admin --tunnel-stdio %h -o PasswordAuthentication no -o ServerAliveInterval 30 `
+	commonArgsWithProxy  = withProxy + `-o UserKnownHostsFile /dev/null `
+	commonArgsWithTunnel = withTunnel + `-o UserKnownHostsFile /dev/null `
+	commonArgs           = args + `-o UserKnownHostsFile /dev/null `
+	sshArgs              = args + `-t -t -o UserKnownHostsFile /dev/null `
+	sshArgsWithProxy     = withProxy + `-t -t -o UserKnownHostsFile /dev/null `
+	sshArgsWithTunnel    = withTunnel + `-t -t -o UserKnownHostsFile /dev/null `
 )
 
 var sshTests = []struct {
@@ -98,6 +101,16 @@ var sshTests = []struct {
 		[]string{"ssh", "--proxy=true", "mysql/0"},
 		sshArgsWithProxy + "ubuntu@admin-0.internal",
 	},
+	{
+		"connect to unit mysql/0 through an API tunnel",
+		[]string{"ssh", "--tunnel", "mysql/0"},
+		sshArgsWithTunnel + "ubuntu@unit-mysql-0",
+	},
+	{
+		"connect to machine 0 through an API tunnel as the mongo user",
+		[]string{"ssh", "--tunnel", "mongo@0", "ls"},
+		sshArgsWithTunnel + "mongo@machine-0 ls",
+	},
 }
 
 func (s *SSHSuite) TestSSHCommand(c *gc.C) {
@@ -159,8 +172,8 @@ func (s *SSHSuite) TestSSHWillWorkInUpgrade(c *gc.C) {
 	for i := 0; i < t.NumMethod(); i++ {
 		name := t.Method(i).Name
 
-		// Close isn't an API method.
-		if name == "Close" {
+		// Close and OpenSSHTunnel aren't API methods.
+		if name == "Close" || name == "OpenSSHTunnel" {
 			continue
 		}
 		c.Logf("checking %q", name)
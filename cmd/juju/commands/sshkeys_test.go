@@ -4,6 +4,8 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -114,6 +116,22 @@ func (s *ListKeysSuite) TestListFullKeys(c *gc.C) {
 	c.Assert(output, gc.Matches, "Keys used in model: admin\n.*user@host\n.*another@host")
 }
 
+func (s *ListKeysSuite) TestListKeysJSON(c *gc.C) {
+	key1 := sshtesting.ValidKeyOne.Key + " user@host"
+	s.setAuthorizedKeys(c, key1)
+
+	context, err := coretesting.RunCommand(c, NewListKeysCommand(), "--full", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	var output struct {
+		Model string   `json:"model"`
+		Keys  []string `json:"keys"`
+	}
+	err = json.Unmarshal(context.Stdout.(*bytes.Buffer).Bytes(), &output)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output.Model, gc.Equals, "admin")
+	c.Assert(output.Keys, jc.DeepEquals, []string{key1})
+}
+
 func (s *ListKeysSuite) TestTooManyArgs(c *gc.C) {
 	_, err := coretesting.RunCommand(c, NewListKeysCommand(), "foo")
 	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
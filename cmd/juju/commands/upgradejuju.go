@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	"github.com/juju/utils/series"
 	"github.com/juju/version"
 	"launchpad.net/gnuflag"
@@ -50,14 +51,31 @@ a previous upgrade was not fully completed (e.g.: if one of the
 controllers in a high availability model failed to upgrade).
 If a failed upgrade has been resolved, '--reset-previous-upgrade' can be
 used to allow the upgrade to proceed.
+If an upgrade did not complete, '--rollback' abandons it and sets the
+model's agent version back to the version being upgraded from; agents
+that have already upgraded then revert to their previous software.
+Upgrade steps that can be undone are reverted when they fail. Before
+running upgrade steps, the controller tries to take a backup, which can
+be restored with ` + "`juju restore-backup`" + ` to undo any other changes they
+made. If the backup cannot be taken, the upgrade proceeds without one.
+By default, the agents on every machine are upgraded at once. With
+'--canary', the listed machines are upgraded first; with '--batch-size',
+the remaining machines are upgraded that many at a time. Each batch is
+started only once the agents in the previous one report that they are
+healthy and running the new version, and the upgrade halts if any of
+them fails. Controller machines are always upgraded first. Once every
+batch has been upgraded, the rest of the model is upgraded.
 Backups are recommended prior to upgrading.
 
 Examples:
     juju upgrade-juju --dry-run
     juju upgrade-juju --version 2.0.1
+    juju upgrade-juju --canary 3,7 --batch-size 5
+    juju upgrade-juju --rollback
     
 See also: 
-    sync-tools`
+    sync-tools
+    restore-backup`
 
 func newUpgradeJujuCommand(minUpgradeVers map[int]version.Number, options ...modelcmd.WrapEnvOption) cmd.Command {
 	if minUpgradeVers == nil {
@@ -74,7 +92,11 @@ type upgradeJujuCommand struct {
 	UploadTools   bool
 	DryRun        bool
 	ResetPrevious bool
+	Rollback      bool
 	AssumeYes     bool
+	canary        string
+	Canary        []string
+	BatchSize     int
 
 	// minMajorUpgradeVersion maps known major numbers to
 	// the minimum version that can be upgraded to that
@@ -96,8 +118,11 @@ func (c *upgradeJujuCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.UploadTools, "upload-tools", false, "Upload local version of tools; for development use only")
 	f.BoolVar(&c.DryRun, "dry-run", false, "Don't change anything, just report what would be changed")
 	f.BoolVar(&c.ResetPrevious, "reset-previous-upgrade", false, "Clear the previous (incomplete) upgrade status (use with care)")
+	f.BoolVar(&c.Rollback, "rollback", false, "Revert an incomplete upgrade to the previous agent version")
 	f.BoolVar(&c.AssumeYes, "y", false, "Answer 'yes' to confirmation prompts")
 	f.BoolVar(&c.AssumeYes, "yes", false, "")
+	f.StringVar(&c.canary, "canary", "", "Comma-separated machines to upgrade before the rest of the model")
+	f.IntVar(&c.BatchSize, "batch-size", 0, "Upgrade the model's machines this many at a time")
 }
 
 func (c *upgradeJujuCommand) Init(args []string) error {
@@ -117,6 +142,32 @@ func (c *upgradeJujuCommand) Init(args []string) error {
 		}
 		c.Version = vers
 	}
+	if c.canary != "" {
+		for _, id := range strings.Split(c.canary, ",") {
+			id = strings.TrimSpace(id)
+			if !names.IsValidMachine(id) {
+				return errors.NotValidf("canary machine %q", id)
+			}
+			c.Canary = append(c.Canary, id)
+		}
+	}
+	if c.BatchSize < 0 {
+		return errors.NotValidf("negative batch size")
+	}
+	if c.Rollback {
+		for flag, set := range map[string]bool{
+			"--version":                c.vers != "",
+			"--upload-tools":           c.UploadTools,
+			"--dry-run":                c.DryRun,
+			"--reset-previous-upgrade": c.ResetPrevious,
+			"--canary":                 c.canary != "",
+			"--batch-size":             c.BatchSize > 0,
+		} {
+			if set {
+				return errors.Errorf("--rollback cannot be used with %s", flag)
+			}
+		}
+	}
 	return cmd.CheckEmpty(args)
 }
 
@@ -168,7 +219,10 @@ type upgradeJujuAPI interface {
 	FindTools(majorVersion, minorVersion int, series, arch string) (result params.FindToolsResult, err error)
 	UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (coretools.List, error)
 	AbortCurrentUpgrade() error
+	RollbackUpgrade() (params.RollbackUpgradeResult, error)
 	SetModelAgentVersion(version version.Number) error
+	SetMachineAgentVersions(version version.Number, machines ...string) error
+	Status(patterns []string) (*params.FullStatus, error)
 	Close() error
 }
 
@@ -187,7 +241,16 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 	if err != nil {
 		return err
 	}
-	defer client.Close()
+	// A staged upgrade may replace the client, or leave none if it
+	// could not reconnect.
+	defer func() {
+		if client != nil {
+			client.Close()
+		}
+	}()
+	if c.Rollback {
+		return c.rollback(ctx, client)
+	}
 	defer func() {
 		if err == errUpToDate {
 			ctx.Infof(err.Error())
@@ -289,6 +352,11 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 		logger.Warningf("version %s incompatible with this client (%s)", context.chosen, jujuversion.Current)
 	}
 	if c.DryRun {
+		if c.staged() {
+			if err := c.describeUpgradeBatches(ctx, client); err != nil {
+				return err
+			}
+		}
 		ctx.Infof("upgrade to this version by running\n    juju upgrade-juju --version=\"%s\"\n", context.chosen)
 	} else {
 		if c.ResetPrevious {
@@ -303,6 +371,12 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 				return block.ProcessBlockedError(err, block.BlockChange)
 			}
 		}
+		if c.staged() {
+			client, err = c.upgradeInBatches(ctx, client, context.chosen)
+			if err != nil {
+				return err
+			}
+		}
 		if err := client.SetModelAgentVersion(context.chosen); err != nil {
 			if params.IsCodeUpgradeInProgress(err) {
 				return errors.Errorf("%s\n\n"+
@@ -319,6 +393,22 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 	return nil
 }
 
+// rollback abandons an incomplete upgrade, reverting the model agent
+// version to the version being upgraded from. Agents that have already
+// upgraded are then allowed to downgrade to their previous tools.
+func (c *upgradeJujuCommand) rollback(ctx *cmd.Context, client upgradeJujuAPI) error {
+	result, err := client.RollbackUpgrade()
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("rolled back model agent version to %s", result.PreviousVersion)
+	if result.SnapshotID != "" {
+		ctx.Infof("changes made by upgrade steps can be undone by running\n"+
+			"    juju restore-backup --id %s", result.SnapshotID)
+	}
+	return nil
+}
+
 const resetPreviousUpgradeMessage = `
 WARNING! using --reset-previous-upgrade when an upgrade is in progress
 will cause the upgrade to fail. Only use this option to clear an
@@ -0,0 +1,321 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+)
+
+var (
+	// upgradeBatchPollInterval is how often the status of a batch of
+	// upgrading machines is checked.
+	upgradeBatchPollInterval = 10 * time.Second
+
+	// upgradeBatchTimeout is how long the agents in a batch of
+	// upgrading machines have to report that they are running the
+	// new version.
+	upgradeBatchTimeout = 30 * time.Minute
+)
+
+// staged reports whether the model's machines should be upgraded in
+// batches, rather than all at once.
+func (c *upgradeJujuCommand) staged() bool {
+	return len(c.Canary) > 0 || c.BatchSize > 0
+}
+
+// planUpgradeBatches returns the batches of machines to be upgraded in
+// turn during a staged upgrade. Any controller machines are upgraded
+// first, then the canary machines, then the remaining machines in
+// batches of the requested size. Without a batch size, the remaining
+// machines are upgraded together when the model agent version is set.
+func (c *upgradeJujuCommand) planUpgradeBatches(fullStatus *params.FullStatus) ([][]string, error) {
+	machines := make(map[string]params.MachineStatus)
+	collectMachineStatus(fullStatus.Machines, machines)
+
+	planned := make(map[string]bool)
+	var controllers []string
+	for id, m := range machines {
+		for _, job := range m.Jobs {
+			if job == multiwatcher.JobManageModel {
+				controllers = append(controllers, id)
+				planned[id] = true
+				break
+			}
+		}
+	}
+	var canaries []string
+	for _, id := range c.Canary {
+		if _, ok := machines[id]; !ok {
+			return nil, errors.NotFoundf("canary machine %s", id)
+		}
+		if !planned[id] {
+			canaries = append(canaries, id)
+			planned[id] = true
+		}
+	}
+
+	var batches [][]string
+	for _, batch := range [][]string{controllers, canaries} {
+		if len(batch) > 0 {
+			sort.Sort(machineIds(batch))
+			batches = append(batches, batch)
+		}
+	}
+	if c.BatchSize == 0 {
+		return batches, nil
+	}
+	var rest []string
+	for id := range machines {
+		if !planned[id] {
+			rest = append(rest, id)
+		}
+	}
+	sort.Sort(machineIds(rest))
+	for len(rest) > 0 {
+		n := c.BatchSize
+		if n > len(rest) {
+			n = len(rest)
+		}
+		batches = append(batches, rest[:n])
+		rest = rest[n:]
+	}
+	return batches, nil
+}
+
+// collectMachineStatus adds the given machines, and the containers
+// they host, to the map of machines by id.
+func collectMachineStatus(in map[string]params.MachineStatus, out map[string]params.MachineStatus) {
+	for id, m := range in {
+		out[id] = m
+		collectMachineStatus(m.Containers, out)
+	}
+}
+
+// machineIds sorts machine ids by number, with each machine followed
+// by its containers.
+type machineIds []string
+
+func (ids machineIds) Len() int      { return len(ids) }
+func (ids machineIds) Swap(i, j int) { ids[i], ids[j] = ids[j], ids[i] }
+func (ids machineIds) Less(i, j int) bool {
+	parts1 := strings.Split(ids[i], "/")
+	parts2 := strings.Split(ids[j], "/")
+	for k := 0; k < len(parts1) && k < len(parts2); k++ {
+		if parts1[k] == parts2[k] {
+			continue
+		}
+		n1, err1 := strconv.Atoi(parts1[k])
+		n2, err2 := strconv.Atoi(parts2[k])
+		if err1 == nil && err2 == nil {
+			return n1 < n2
+		}
+		return parts1[k] < parts2[k]
+	}
+	return len(parts1) < len(parts2)
+}
+
+// upgradeInBatches upgrades the model's machines batch by batch,
+// starting each batch only once the agents in the previous one have
+// reported that they are healthy and running the new version. The
+// model agent version must still be set afterwards, to upgrade any
+// remaining agents.
+//
+// The connection to the controller may be replaced while the
+// controller machines restart, so upgradeInBatches returns the client
+// to be used from then on. The client is nil if it could not be
+// reconnected.
+func (c *upgradeJujuCommand) upgradeInBatches(ctx *cmd.Context, client upgradeJujuAPI, vers version.Number) (upgradeJujuAPI, error) {
+	fullStatus, err := client.Status(nil)
+	if err != nil {
+		return client, errors.Trace(err)
+	}
+	batches, err := c.planUpgradeBatches(fullStatus)
+	if err != nil {
+		return client, errors.Trace(err)
+	}
+	for i, batch := range batches {
+		machines := strings.Join(batch, ", ")
+		ctx.Infof("upgrading machines %s to %s (batch %d of %d)", machines, vers, i+1, len(batches))
+		if err := client.SetMachineAgentVersions(vers, batch...); err != nil {
+			return client, block.ProcessBlockedError(err, block.BlockChange)
+		}
+		client, err = c.waitForUpgradeBatch(client, batch, vers)
+		if err != nil {
+			return client, errors.Annotatef(err, "upgrade to %s halted at machines %s", vers, machines)
+		}
+		ctx.Infof("upgraded machines %s", machines)
+	}
+	return client, nil
+}
+
+// describeUpgradeBatches reports the batches in which a staged upgrade
+// would upgrade the model's machines.
+func (c *upgradeJujuCommand) describeUpgradeBatches(ctx *cmd.Context, client upgradeJujuAPI) error {
+	fullStatus, err := client.Status(nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	batches, err := c.planUpgradeBatches(fullStatus)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	lines := make([]string, len(batches))
+	for i, batch := range batches {
+		lines[i] = "    " + strings.Join(batch, ", ")
+	}
+	lines = append(lines, "    all remaining machines")
+	ctx.Infof("machines would be upgraded in batches:\n%s", strings.Join(lines, "\n"))
+	return nil
+}
+
+// waitForUpgradeBatch waits until the agents of the given machines,
+// and of the units they host, report that they are healthy and running
+// the given version. It fails if any of the agents reports a failure,
+// or if they have not all upgraded within upgradeBatchTimeout.
+//
+// The controller is unavailable while its own machines upgrade, so
+// losing the connection is not fatal: waitForUpgradeBatch reconnects
+// and keeps polling until the timeout. It returns the client to be
+// used from then on, which is nil if it could not be reconnected.
+func (c *upgradeJujuCommand) waitForUpgradeBatch(client upgradeJujuAPI, machines []string, vers version.Number) (upgradeJujuAPI, error) {
+	timeout := time.After(upgradeBatchTimeout)
+	pending := make([]string, len(machines))
+	for i, id := range machines {
+		pending[i] = "machine " + id
+	}
+	for {
+		if client == nil {
+			newClient, err := getUpgradeJujuAPI(c)
+			if err != nil {
+				logger.Debugf("cannot reconnect to controller: %v", err)
+			} else {
+				client = newClient
+			}
+		}
+		if client != nil {
+			fullStatus, err := client.Status(nil)
+			switch {
+			case isTransientStatusError(err):
+				logger.Infof("lost connection to controller, reconnecting: %v", err)
+				client.Close()
+				client = nil
+			case err != nil:
+				return client, errors.Trace(err)
+			default:
+				pending, err = pendingUpgradeAgents(fullStatus, machines, vers)
+				if err != nil {
+					return client, errors.Trace(err)
+				}
+				if len(pending) == 0 {
+					return client, nil
+				}
+			}
+		}
+		select {
+		case <-time.After(upgradeBatchPollInterval):
+		case <-timeout:
+			return client, errors.Errorf("timed out waiting for %s to upgrade", strings.Join(pending, ", "))
+		}
+	}
+}
+
+// isTransientStatusError reports whether an error getting the status
+// of the model is expected while the controller restarts: either the
+// connection has failed, or the controller is running upgrade steps.
+func isTransientStatusError(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch errors.Cause(err) {
+	case rpc.ErrShutdown, io.EOF, io.ErrUnexpectedEOF:
+		return true
+	}
+	return params.IsCodeUpgradeInProgress(err)
+}
+
+// pendingUpgradeAgents returns the agents of the given machines, and
+// of the units they host, that are not yet healthy and running the
+// given version. It returns an error if any of them has failed.
+func pendingUpgradeAgents(fullStatus *params.FullStatus, machines []string, vers version.Number) ([]string, error) {
+	all := make(map[string]params.MachineStatus)
+	collectMachineStatus(fullStatus.Machines, all)
+	inBatch := make(map[string]bool)
+	var pending []string
+	for _, id := range machines {
+		m, ok := all[id]
+		if !ok {
+			// The machine has been removed, so there is
+			// nothing left to upgrade.
+			continue
+		}
+		inBatch[id] = true
+		name := "machine " + id
+		if err := checkUpgradeAgent(name, m.AgentStatus); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if m.AgentStatus.Version != vers.String() || m.AgentStatus.Status != status.StatusStarted {
+			pending = append(pending, name)
+		}
+	}
+
+	var checkUnit func(name string, u params.UnitStatus) error
+	checkUnit = func(name string, u params.UnitStatus) error {
+		agentName := "unit " + name
+		if err := checkUpgradeAgent(agentName, u.AgentStatus); err != nil {
+			return errors.Trace(err)
+		}
+		if u.AgentStatus.Version != vers.String() {
+			pending = append(pending, agentName)
+		}
+		for subName, sub := range u.Subordinates {
+			if err := checkUnit(subName, sub); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	}
+	for _, service := range fullStatus.Services {
+		for name, u := range service.Units {
+			if !inBatch[u.Machine] {
+				continue
+			}
+			if err := checkUnit(name, u); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+	}
+	sort.Strings(pending)
+	return pending, nil
+}
+
+// checkUpgradeAgent returns an error if the named agent's status
+// indicates that it has failed.
+func checkUpgradeAgent(name string, agentStatus params.DetailedStatus) error {
+	if agentStatus.Err != nil {
+		return errors.Annotatef(agentStatus.Err, "cannot get status of %s", name)
+	}
+	switch agentStatus.Status {
+	case status.StatusError, status.StatusFailed, status.StatusLost, status.StatusDown:
+		message := string(agentStatus.Status)
+		if agentStatus.Info != "" {
+			message += ": " + agentStatus.Info
+		}
+		return errors.Errorf("%s agent status is %s", name, message)
+	}
+	return nil
+}
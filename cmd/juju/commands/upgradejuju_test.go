@@ -10,7 +10,9 @@ import (
 	"io"
 	"io/ioutil"
 	"strings"
+	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
 	"github.com/juju/utils/series"
@@ -31,7 +33,10 @@ import (
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
 	_ "github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
 	coretesting "github.com/juju/juju/testing"
 	coretools "github.com/juju/juju/tools"
 	jujuversion "github.com/juju/juju/version"
@@ -828,6 +833,152 @@ func (s *UpgradeJujuSuite) TestResetPreviousUpgrade(c *gc.C) {
 	}
 }
 
+func (s *UpgradeJujuSuite) TestUpgradeJujuStagedInitErrors(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--canary", "1,foo"},
+		err:  `canary machine "foo" not valid`,
+	}, {
+		args: []string{"--batch-size", "-1"},
+		err:  `negative batch size not valid`,
+	}} {
+		err := coretesting.InitCommand(newUpgradeJujuCommand(nil), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func fakeMachineStatus(version string, jobs ...multiwatcher.MachineJob) params.MachineStatus {
+	return params.MachineStatus{
+		AgentStatus: params.DetailedStatus{
+			Status:  status.StatusStarted,
+			Version: version,
+		},
+		Jobs: jobs,
+	}
+}
+
+func (s *UpgradeJujuSuite) setUpStagedUpgrade(c *gc.C) *fakeUpgradeJujuAPI {
+	s.PatchValue(&upgradeBatchPollInterval, time.Millisecond)
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	current := jujuversion.Current.String()
+	container := fakeMachineStatus(current, multiwatcher.JobHostUnits)
+	machine4 := fakeMachineStatus(current, multiwatcher.JobHostUnits)
+	machine4.Containers = map[string]params.MachineStatus{"4/lxc/0": container}
+	fakeAPI.status = &params.FullStatus{
+		Machines: map[string]params.MachineStatus{
+			"0":  fakeMachineStatus(current, multiwatcher.JobManageModel),
+			"1":  fakeMachineStatus(current, multiwatcher.JobHostUnits),
+			"2":  fakeMachineStatus(current, multiwatcher.JobHostUnits),
+			"3":  fakeMachineStatus(current, multiwatcher.JobHostUnits),
+			"4":  machine4,
+			"10": fakeMachineStatus(current, multiwatcher.JobHostUnits),
+		},
+		Services: map[string]params.ServiceStatus{
+			"mysql": {
+				Units: map[string]params.UnitStatus{
+					"mysql/0": {
+						Machine: "1",
+						AgentStatus: params.DetailedStatus{
+							Status:  status.StatusIdle,
+							Version: current,
+						},
+					},
+				},
+			},
+		},
+	}
+	fakeAPI.patch(s)
+	return fakeAPI
+}
+
+func (s *UpgradeJujuSuite) TestUpgradeJujuStaged(c *gc.C) {
+	fakeAPI := s.setUpStagedUpgrade(c)
+	ctx, err := coretesting.RunCommand(c, newUpgradeJujuCommand(nil), "--canary", "3", "--batch-size", "2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(fakeAPI.setMachineVersionsCalls, jc.DeepEquals, [][]string{
+		{"0"}, {"3"}, {"1", "2"}, {"4", "4/lxc/0"}, {"10"},
+	})
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, fakeAPI.nextVersion.Number)
+	c.Assert(fakeAPI.status.Services["mysql"].Units["mysql/0"].AgentStatus.Version, gc.Equals, fakeAPI.nextVersion.Number.String())
+	c.Assert(coretesting.Stderr(ctx), jc.Contains, "upgraded machines 4, 4/lxc/0\n")
+}
+
+func (s *UpgradeJujuSuite) TestUpgradeJujuCanaryOnly(c *gc.C) {
+	fakeAPI := s.setUpStagedUpgrade(c)
+	_, err := coretesting.RunCommand(c, newUpgradeJujuCommand(nil), "--canary", "3,2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(fakeAPI.setMachineVersionsCalls, jc.DeepEquals, [][]string{{"0"}, {"2", "3"}})
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, fakeAPI.nextVersion.Number)
+}
+
+func (s *UpgradeJujuSuite) TestUpgradeJujuStagedUnknownCanary(c *gc.C) {
+	fakeAPI := s.setUpStagedUpgrade(c)
+	_, err := coretesting.RunCommand(c, newUpgradeJujuCommand(nil), "--canary", "42")
+	c.Assert(err, gc.ErrorMatches, "canary machine 42 not found")
+	c.Assert(fakeAPI.setMachineVersionsCalls, gc.HasLen, 0)
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Number{})
+}
+
+func (s *UpgradeJujuSuite) TestUpgradeJujuStagedHaltsOnFailure(c *gc.C) {
+	fakeAPI := s.setUpStagedUpgrade(c)
+	fakeAPI.failMachine = "3"
+	_, err := coretesting.RunCommand(c, newUpgradeJujuCommand(nil), "--canary", "3", "--batch-size", "2")
+	c.Assert(err, gc.ErrorMatches, `upgrade to .* halted at machines 3: machine 3 agent status is error: cannot start`)
+
+	c.Assert(fakeAPI.setMachineVersionsCalls, jc.DeepEquals, [][]string{{"0"}, {"3"}})
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Number{})
+}
+
+func (s *UpgradeJujuSuite) TestUpgradeJujuStagedTimeout(c *gc.C) {
+	s.PatchValue(&upgradeBatchTimeout, 10*time.Millisecond)
+	fakeAPI := s.setUpStagedUpgrade(c)
+	fakeAPI.stuckUnits = true
+	_, err := coretesting.RunCommand(c, newUpgradeJujuCommand(nil), "--canary", "1")
+	c.Assert(err, gc.ErrorMatches, `upgrade to .* halted at machines 1: timed out waiting for unit mysql/0 to upgrade`)
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Number{})
+}
+
+func (s *UpgradeJujuSuite) TestUpgradeJujuStagedReconnects(c *gc.C) {
+	fakeAPI := s.setUpStagedUpgrade(c)
+	// The first Status call plans the batches; the controller then
+	// restarts while the first batch is upgrading.
+	fakeAPI.statusErrs = []error{nil, rpc.ErrShutdown, &params.Error{Code: params.CodeUpgradeInProgress}}
+	_, err := coretesting.RunCommand(c, newUpgradeJujuCommand(nil), "--canary", "3")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(fakeAPI.setMachineVersionsCalls, jc.DeepEquals, [][]string{{"0"}, {"3"}})
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, fakeAPI.nextVersion.Number)
+	c.Assert(fakeAPI.connections, gc.Equals, 3)
+}
+
+func (s *UpgradeJujuSuite) TestUpgradeJujuStagedStatusError(c *gc.C) {
+	fakeAPI := s.setUpStagedUpgrade(c)
+	fakeAPI.statusErrs = []error{nil, errors.New("boom")}
+	_, err := coretesting.RunCommand(c, newUpgradeJujuCommand(nil), "--canary", "3")
+	c.Assert(err, gc.ErrorMatches, `upgrade to .* halted at machines 0: boom`)
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Number{})
+}
+
+func (s *UpgradeJujuSuite) TestUpgradeJujuStagedDryRun(c *gc.C) {
+	fakeAPI := s.setUpStagedUpgrade(c)
+	ctx, err := coretesting.RunCommand(c, newUpgradeJujuCommand(nil), "--canary", "3", "--batch-size", "3", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stderr(ctx), jc.Contains, `
+machines would be upgraded in batches:
+    0
+    3
+    1, 2, 4
+    4/lxc/0, 10
+    all remaining machines
+`[1:])
+	c.Assert(fakeAPI.setMachineVersionsCalls, gc.HasLen, 0)
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Number{})
+}
+
 func NewFakeUpgradeJujuAPI(c *gc.C, st *state.State) *fakeUpgradeJujuAPI {
 	nextVersion := version.Binary{
 		Number: jujuversion.Current,
@@ -842,6 +993,73 @@ func NewFakeUpgradeJujuAPI(c *gc.C, st *state.State) *fakeUpgradeJujuAPI {
 	}
 }
 
+func (s *UpgradeJujuSuite) TestRollbackInitErrors(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--rollback", "--version", "2.0.0"},
+		err:  "--rollback cannot be used with --version",
+	}, {
+		args: []string{"--rollback", "--upload-tools"},
+		err:  "--rollback cannot be used with --upload-tools",
+	}, {
+		args: []string{"--rollback", "--dry-run"},
+		err:  "--rollback cannot be used with --dry-run",
+	}, {
+		args: []string{"--rollback", "--reset-previous-upgrade"},
+		err:  "--rollback cannot be used with --reset-previous-upgrade",
+	}, {
+		args: []string{"--rollback", "--canary", "1"},
+		err:  "--rollback cannot be used with --canary",
+	}, {
+		args: []string{"--rollback", "--batch-size", "2"},
+		err:  "--rollback cannot be used with --batch-size",
+	}} {
+		err := coretesting.InitCommand(newUpgradeJujuCommand(nil), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *UpgradeJujuSuite) TestRollback(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.rollbackResult = params.RollbackUpgradeResult{
+		PreviousVersion: version.MustParse("1.2.3"),
+		SnapshotID:      "backup-id",
+	}
+	fakeAPI.patch(s)
+
+	ctx, err := coretesting.RunCommand(c, newUpgradeJujuCommand(nil), "--rollback")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeAPI.rollbackCalled, jc.IsTrue)
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Number{})
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, ""+
+		"rolled back model agent version to 1.2.3\n"+
+		"changes made by upgrade steps can be undone by running\n"+
+		"    juju restore-backup --id backup-id\n")
+}
+
+func (s *UpgradeJujuSuite) TestRollbackWithoutSnapshot(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.rollbackResult = params.RollbackUpgradeResult{
+		PreviousVersion: version.MustParse("1.2.3"),
+	}
+	fakeAPI.patch(s)
+
+	ctx, err := coretesting.RunCommand(c, newUpgradeJujuCommand(nil), "--rollback")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, "rolled back model agent version to 1.2.3\n")
+}
+
+func (s *UpgradeJujuSuite) TestRollbackError(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.rollbackErr = errors.New("cannot roll back upgrade: no incomplete upgrade to roll back")
+	fakeAPI.patch(s)
+
+	_, err := coretesting.RunCommand(c, newUpgradeJujuCommand(nil), "--rollback")
+	c.Assert(err, gc.ErrorMatches, "cannot roll back upgrade: no incomplete upgrade to roll back")
+}
+
 type fakeUpgradeJujuAPI struct {
 	c                         *gc.C
 	st                        *state.State
@@ -851,6 +1069,15 @@ type fakeUpgradeJujuAPI struct {
 	setVersionCalledWith      version.Number
 	tools                     []string
 	findToolsCalled           bool
+	status                    *params.FullStatus
+	failMachine               string
+	stuckUnits                bool
+	statusErrs                []error
+	connections               int
+	setMachineVersionsCalls   [][]string
+	rollbackCalled            bool
+	rollbackResult            params.RollbackUpgradeResult
+	rollbackErr               error
 }
 
 func (a *fakeUpgradeJujuAPI) reset() {
@@ -859,10 +1086,13 @@ func (a *fakeUpgradeJujuAPI) reset() {
 	a.setVersionCalledWith = version.Number{}
 	a.tools = []string{}
 	a.findToolsCalled = false
+	a.setMachineVersionsCalls = nil
+	a.rollbackCalled = false
 }
 
 func (a *fakeUpgradeJujuAPI) patch(s *UpgradeJujuSuite) {
 	s.PatchValue(&getUpgradeJujuAPI, func(*upgradeJujuCommand) (upgradeJujuAPI, error) {
+		a.connections++
 		return a, nil
 	})
 }
@@ -902,11 +1132,73 @@ func (a *fakeUpgradeJujuAPI) AbortCurrentUpgrade() error {
 	return nil
 }
 
+func (a *fakeUpgradeJujuAPI) RollbackUpgrade() (params.RollbackUpgradeResult, error) {
+	a.rollbackCalled = true
+	return a.rollbackResult, a.rollbackErr
+}
+
 func (a *fakeUpgradeJujuAPI) SetModelAgentVersion(v version.Number) error {
 	a.setVersionCalledWith = v
 	return a.setVersionErr
 }
 
+// SetMachineAgentVersions records the call and updates the fake
+// status as though the machines' agents had upgraded; the agent of
+// failMachine reports an error instead, and if stuckUnits is set the
+// units' agents never upgrade.
+func (a *fakeUpgradeJujuAPI) SetMachineAgentVersions(v version.Number, machines ...string) error {
+	a.setMachineVersionsCalls = append(a.setMachineVersionsCalls, machines)
+	for _, id := range machines {
+		upgradeFakeMachine(a.status.Machines, id, v, id == a.failMachine)
+	}
+	if a.stuckUnits {
+		return nil
+	}
+	for _, service := range a.status.Services {
+		for name, unit := range service.Units {
+			for _, id := range machines {
+				if unit.Machine == id {
+					unit.AgentStatus.Version = v.String()
+					service.Units[name] = unit
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func upgradeFakeMachine(machines map[string]params.MachineStatus, id string, v version.Number, fail bool) {
+	for machineId, m := range machines {
+		if machineId == id {
+			if fail {
+				m.AgentStatus.Status = status.StatusError
+				m.AgentStatus.Info = "cannot start"
+			} else {
+				m.AgentStatus.Version = v.String()
+			}
+			machines[machineId] = m
+			return
+		}
+		upgradeFakeMachine(m.Containers, id, v, fail)
+	}
+}
+
+// Status returns the fake status, unless the next of statusErrs is
+// not nil.
+func (a *fakeUpgradeJujuAPI) Status(patterns []string) (*params.FullStatus, error) {
+	if len(a.statusErrs) > 0 {
+		err := a.statusErrs[0]
+		a.statusErrs = a.statusErrs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	if a.status == nil {
+		return &params.FullStatus{}, nil
+	}
+	return a.status, nil
+}
+
 func (a *fakeUpgradeJujuAPI) Close() error {
 	return nil
 }
@@ -7,9 +7,23 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/juju/cmd"
 	"github.com/juju/errors"
 )
 
+// Formatters returns the output formats supported by list and show
+// commands: "yaml" and "json", which marshal the command's documented
+// output type, and "tabular", which renders it with the supplied
+// formatter for humans. Scripts should use yaml or json, as the
+// tabular layout may change between releases.
+func Formatters(tabular cmd.Formatter) map[string]cmd.Formatter {
+	return map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": tabular,
+	}
+}
+
 // LastConnection turns the *time.Time returned from the API server
 // into a user facing string with either exact time or a user friendly
 // string based on the args.
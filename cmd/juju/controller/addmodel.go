@@ -4,7 +4,10 @@
 package controller
 
 import (
+	"bytes"
+	"fmt"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -38,6 +41,14 @@ type addModelCommand struct {
 	CloudType      string
 	CredentialName string
 	Config         common.ConfigFlag
+	out            cmd.Output
+}
+
+// addedModel is the machine-readable output of add-model.
+type addedModel struct {
+	Name  string `yaml:"name" json:"name"`
+	UUID  string `yaml:"model-uuid" json:"model-uuid"`
+	Owner string `yaml:"owner" json:"owner"`
 }
 
 const addModelHelpDoc = `
@@ -57,11 +68,17 @@ specified using a --config argument, either authorized=keys=value
 or via a config yaml file.
  
 Any credentials used must be for a cloud with the same provider
-type as the controller. Controller administrators do not have to
+type as the controller. Credentials are stored in the controller and
+referenced by the model, so that they may later be replaced on every
+model using them with "juju update-credential". Controller administrators do not have to
 specify credentials or ssh keys; by default, the credentials and
 keys used to bootstrap the controller are used if no others are
 specified.
 
+The new model's name, UUID and owner are written as a table; with
+--format yaml or json, they are written as an object with the keys
+name, model-uuid and owner.
+
 Examples:
 
     juju add-model new-model
@@ -87,6 +104,29 @@ func (c *addModelCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.Owner, "owner", "", "The owner of the new model if not the current user")
 	f.StringVar(&c.CredentialSpec, "credential", "", "The name of the cloud and credentials the new model uses to create cloud resources")
 	f.Var(&c.Config, "config", "Specify a controller config file, or one or more controller configuration options (--config config.yaml [--config k=v ...])")
+	c.out.AddFlags(f, "tabular", common.Formatters(formatAddModelTabular))
+}
+
+// formatAddModelTabular writes the new model as a single-row table.
+func formatAddModelTabular(value interface{}) ([]byte, error) {
+	model, ok := value.(addedModel)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", model, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "NAME\tMODEL UUID\tOWNER\n")
+	fmt.Fprintf(tw, "%s\t%s\t%s\n", model.Name, model.UUID, model.Owner)
+	tw.Flush()
+	return out.Bytes(), nil
 }
 
 func (c *addModelCommand) Init(args []string) error {
@@ -119,6 +159,8 @@ type CreateModelAPI interface {
 	Close() error
 	ConfigSkeleton(provider, region string) (params.ModelConfig, error)
 	CreateModel(owner string, account, config map[string]interface{}) (params.Model, error)
+	CreateModelWithCredential(owner, cloudName, credential string, config map[string]interface{}) (params.Model, error)
+	AddCloudCredential(owner, cloudName, name string, credential cloud.Credential) error
 }
 
 func (c *addModelCommand) getAPI() (CreateModelAPI, error) {
@@ -164,19 +206,28 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
-	accountDetails := map[string]interface{}{}
+	var model params.Model
 	if c.CredentialName != "" {
+		// The credential is stored in the controller, and referenced
+		// by the model, so that it can later be rotated with
+		// update-credential. A credential already stored is used
+		// as it is: replacing it would change every model using it.
 		cred, _, _, err := modelcmd.GetCredentials(
 			c.credentialStore, "", c.CredentialName, c.CloudName, c.CloudType,
 		)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		for k, v := range cred.Attributes() {
-			accountDetails[k] = v
+		err = client.AddCloudCredential(modelOwner, c.CloudName, c.CredentialName, *cred)
+		if params.IsCodeAlreadyExists(err) {
+			ctx.Infof("using credential %q already stored in the controller; run update-credential to replace it", c.CredentialName)
+		} else if err != nil {
+			return errors.Annotate(err, "cannot upload credential")
 		}
+		model, err = client.CreateModelWithCredential(modelOwner, c.CloudName, c.CredentialName, attrs)
+	} else {
+		model, err = client.CreateModel(modelOwner, map[string]interface{}{}, attrs)
 	}
-	model, err := client.CreateModel(modelOwner, accountDetails, attrs)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -196,7 +247,11 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 		ctx.Infof("added model %q for %q", c.Name, c.Owner)
 	}
 
-	return nil
+	return c.out.Write(ctx, addedModel{
+		Name:  c.Name,
+		UUID:  model.UUID,
+		Owner: modelOwner,
+	})
 }
 
 func (c *addModelCommand) getConfigValues(ctx *cmd.Context, serverSkeleton params.ModelConfig) (map[string]interface{}, error) {
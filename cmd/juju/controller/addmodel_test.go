@@ -139,15 +139,59 @@ func (s *addSuite) TestAddExistingName(c *gc.C) {
 	c.Assert(details, jc.DeepEquals, &jujuclient.ModelDetails{"fake-model-uuid"})
 }
 
+func (s *addSuite) TestFormatTabular(c *gc.C) {
+	ctx, err := s.run(c, "test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"NAME  MODEL UUID       OWNER\n"+
+		"test  fake-model-uuid  bob@local\n")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "added model \"test\"\n")
+}
+
+func (s *addSuite) TestFormatYAML(c *gc.C) {
+	ctx, err := s.run(c, "test", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+name: test
+model-uuid: fake-model-uuid
+owner: bob@local
+`[1:])
+}
+
+func (s *addSuite) TestFormatJSON(c *gc.C) {
+	ctx, err := s.run(c, "test", "--format", "json", "--owner", "alice")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `{"name":"test","model-uuid":"fake-model-uuid","owner":"alice@local"}`+"\n")
+}
+
 func (s *addSuite) TestCredentialsPassedThrough(c *gc.C) {
 	_, err := s.run(c, "test", "--credential", "aws:secrets")
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Assert(s.fake.config["type"], gc.Equals, "ec2")
-	c.Assert(s.fake.account, jc.DeepEquals, map[string]interface{}{
+	c.Assert(s.fake.cloud, gc.Equals, "aws")
+	c.Assert(s.fake.credentialName, gc.Equals, "secrets")
+	c.Assert(s.fake.credential.Attributes(), jc.DeepEquals, map[string]string{
 		"access-key": "key",
 		"secret-key": "sekret",
 	})
+	c.Assert(s.fake.account, gc.IsNil)
+}
+
+func (s *addSuite) TestExistingCredentialNotReplaced(c *gc.C) {
+	stored := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+		"access-key": "key",
+		"secret-key": "stored",
+	})
+	s.fake.cloud = "aws"
+	s.fake.credentialName = "secrets"
+	s.fake.credential = stored
+	ctx, err := s.run(c, "test", "--credential", "aws:secrets")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.fake.credential, jc.DeepEquals, stored)
+	c.Assert(s.fake.config["type"], gc.Equals, "ec2")
+	c.Assert(testing.Stderr(ctx), jc.Contains, `using credential "secrets" already stored in the controller`)
 }
 
 func (s *addSuite) TestComandLineConfigPassedThrough(c *gc.C) {
@@ -285,11 +329,14 @@ func (s *addSuite) TestNoEnvCacheOtherUser(c *gc.C) {
 // fakeCreateClient is used to mock out the behavior of the real
 // CreateModel command.
 type fakeCreateClient struct {
-	owner   string
-	account map[string]interface{}
-	config  map[string]interface{}
-	err     error
-	model   params.Model
+	owner          string
+	account        map[string]interface{}
+	config         map[string]interface{}
+	cloud          string
+	credentialName string
+	credential     cloud.Credential
+	err            error
+	model          params.Model
 }
 
 var _ controller.CreateModelAPI = (*fakeCreateClient)(nil)
@@ -316,3 +363,28 @@ func (f *fakeCreateClient) CreateModel(owner string, account, config map[string]
 	f.config = config
 	return f.model, nil
 }
+
+func (f *fakeCreateClient) CreateModelWithCredential(owner, cloudName, credential string, config map[string]interface{}) (params.Model, error) {
+	if f.err != nil {
+		return params.Model{}, f.err
+	}
+	if cloudName != f.cloud || credential != f.credentialName {
+		return params.Model{}, errors.NotFoundf("cloud credential %s:%s", cloudName, credential)
+	}
+	f.owner = owner
+	f.config = config
+	return f.model, nil
+}
+
+func (f *fakeCreateClient) AddCloudCredential(owner, cloudName, name string, credential cloud.Credential) error {
+	if f.err != nil {
+		return f.err
+	}
+	if f.credentialName == name && f.cloud == cloudName {
+		return &params.Error{Code: params.CodeAlreadyExists, Message: "cloud credential already exists"}
+	}
+	f.cloud = cloudName
+	f.credentialName = name
+	f.credential = credential
+	return nil
+}
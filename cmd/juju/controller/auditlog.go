@@ -0,0 +1,243 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewAuditLogCommand returns a command that shows the API calls
+// recorded in the controller's audit log.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{})
+}
+
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	api auditLogAPI
+	out cmd.Output
+
+	user   string
+	method string
+	from   string
+	to     string
+	limit  int
+
+	filter params.AuditLogFilter
+}
+
+type auditLogAPI interface {
+	Close() error
+	AuditLog(filter params.AuditLogFilter) ([]params.AuditLogEntry, error)
+}
+
+var auditLogDoc = `
+Show the API calls recorded in the controller's audit log.
+
+Every call made by a user or agent that may change a model or the
+controller is recorded, along with its arguments and any error it returned. Arguments
+that may include secrets, such as passwords, are not recorded. Entries
+are kept for the duration of the controller model's audit-log-max-age
+setting.
+
+The --from and --to options accept either a time in RFC3339 format, or
+a duration, which is taken to mean that long ago. The --method option
+accepts either a method name, or a facade and method name separated by
+a period.
+
+Examples:
+    juju audit-log
+    juju audit-log --user bob --from 24h
+    juju audit-log --method Service.Deploy --limit 10
+    juju audit-log --from 2016-05-01T00:00:00Z --to 2016-05-02T00:00:00Z
+`
+
+// Info implements Command.Info
+func (c *auditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "audit-log",
+		Purpose: "show the API calls recorded in the controller's audit log",
+		Doc:     auditLogDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.user, "user", "", "Only show calls made by this user")
+	f.StringVar(&c.method, "method", "", "Only show calls of this [<facade>.]<method>")
+	f.StringVar(&c.from, "from", "", "Only show calls made at or after this time")
+	f.StringVar(&c.to, "to", "", "Only show calls made before this time")
+	f.IntVar(&c.limit, "limit", 0, "Only show the most recent calls, up to this number")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	if c.user != "" {
+		if !names.IsValidUser(c.user) {
+			return errors.Errorf("%q is not a valid user name", c.user)
+		}
+		c.filter.UserTag = names.NewUserTag(c.user).String()
+	}
+	if c.method != "" {
+		if i := strings.Index(c.method, "."); i >= 0 {
+			c.filter.Facade, c.filter.Method = c.method[:i], c.method[i+1:]
+		} else {
+			c.filter.Method = c.method
+		}
+		if c.filter.Method == "" {
+			return errors.Errorf("%q is not a valid method", c.method)
+		}
+	}
+	now := time.Now()
+	var err error
+	if c.filter.From, err = parseAuditLogTime(c.from, now); err != nil {
+		return errors.Annotate(err, "invalid --from")
+	}
+	if c.filter.To, err = parseAuditLogTime(c.to, now); err != nil {
+		return errors.Annotate(err, "invalid --to")
+	}
+	if c.limit < 0 {
+		return errors.New("--limit must not be negative")
+	}
+	c.filter.Limit = c.limit
+	return cmd.CheckEmpty(args)
+}
+
+// parseAuditLogTime parses a time in RFC3339 format, or a duration
+// before now. An empty value yields a nil time.
+func parseAuditLogTime(value string, now time.Time) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return nil, errors.Errorf("negative duration %q", value)
+		}
+		t := now.Add(-d).UTC()
+		return &t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errors.Errorf("expected RFC3339 time or duration, got %q", value)
+	}
+	t = t.UTC()
+	return &t, nil
+}
+
+func (c *auditLogCommand) getAPI() (auditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+// Run implements Command.Run
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	entries, err := client.AuditLog(c.filter)
+	if err != nil {
+		return errors.Annotate(err, "cannot read audit log")
+	}
+	if len(entries) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("no audit log entries to display")
+		return nil
+	}
+	formatted := make([]auditLogEntry, len(entries))
+	for i, entry := range entries {
+		formatted[i], err = formatAuditLogEntry(entry)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return c.out.Write(ctx, formatted)
+}
+
+// auditLogEntry defines the serialization behaviour of an audit log
+// entry.
+type auditLogEntry struct {
+	Time    string `yaml:"time" json:"time"`
+	User    string `yaml:"user" json:"user"`
+	Model   string `yaml:"model-uuid" json:"model-uuid"`
+	Facade  string `yaml:"facade" json:"facade"`
+	Version int    `yaml:"version" json:"version"`
+	Method  string `yaml:"method" json:"method"`
+	Args    string `yaml:"args,omitempty" json:"args,omitempty"`
+	Error   string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+func formatAuditLogEntry(entry params.AuditLogEntry) (auditLogEntry, error) {
+	tag, err := names.ParseTag(entry.UserTag)
+	if err != nil {
+		return auditLogEntry{}, errors.Trace(err)
+	}
+	// Agents are shown by tag, and users by name.
+	user := tag.String()
+	if userTag, ok := tag.(names.UserTag); ok {
+		user = userTag.Canonical()
+	}
+	modelTag, err := names.ParseModelTag(entry.ModelTag)
+	if err != nil {
+		return auditLogEntry{}, errors.Trace(err)
+	}
+	return auditLogEntry{
+		Time:    entry.Time.UTC().Format(time.RFC3339),
+		User:    user,
+		Model:   modelTag.Id(),
+		Facade:  entry.Facade,
+		Version: entry.Version,
+		Method:  entry.Method,
+		Args:    entry.Args,
+		Error:   entry.Error,
+	}, nil
+}
+
+func formatAuditLogTabular(value interface{}) ([]byte, error) {
+	entries, ok := value.([]auditLogEntry)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "TIME\tUSER\tMODEL UUID\tCALL\tERROR\n")
+	for _, entry := range entries {
+		// Multi-line errors would break the columns.
+		callErr := strings.Replace(entry.Error, "\n", "; ", -1)
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s.%s\t%s\n",
+			entry.Time, entry.User, entry.Model,
+			entry.Facade, entry.Method, callErr,
+		)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	baseControllerSuite
+	api   *fakeAuditLogAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	err := modelcmd.WriteCurrentController("fake")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.api = &fakeAuditLogAPI{
+		entries: []params.AuditLogEntry{{
+			ModelTag: testing.ModelTag.String(),
+			Time:     time.Date(2016, 5, 1, 12, 0, 0, 0, time.UTC),
+			UserTag:  "user-bob@local",
+			Facade:   "Service",
+			Version:  3,
+			Method:   "Deploy",
+			Args:     `{"Services":[]}`,
+		}, {
+			ModelTag: testing.ModelTag.String(),
+			Time:     time.Date(2016, 5, 1, 12, 1, 0, 0, time.UTC),
+			UserTag:  "user-mary@local",
+			Facade:   "Service",
+			Version:  3,
+			Method:   "Destroy",
+			Error:    "boom",
+		}},
+	}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{
+		ControllerUUID: testing.ModelTag.Id(),
+	}
+}
+
+func (s *auditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, controller.NewAuditLogCommandForTest(s.api, s.store), args...)
+}
+
+func (s *auditLogSuite) TestAuditLogTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.filter, jc.DeepEquals, params.AuditLogFilter{})
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"TIME                  USER        MODEL UUID                            CALL             ERROR\n"+
+		"2016-05-01T12:00:00Z  bob@local   deadbeef-0bad-400d-8000-4b1d0d06f00d  Service.Deploy   \n"+
+		"2016-05-01T12:01:00Z  mary@local  deadbeef-0bad-400d-8000-4b1d0d06f00d  Service.Destroy  boom\n")
+}
+
+func (s *auditLogSuite) TestAuditLogAgent(c *gc.C) {
+	s.api.entries[1].UserTag = "machine-0"
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"TIME                  USER       MODEL UUID                            CALL             ERROR\n"+
+		"2016-05-01T12:00:00Z  bob@local  deadbeef-0bad-400d-8000-4b1d0d06f00d  Service.Deploy   \n"+
+		"2016-05-01T12:01:00Z  machine-0  deadbeef-0bad-400d-8000-4b1d0d06f00d  Service.Destroy  boom\n")
+}
+
+func (s *auditLogSuite) TestAuditLogJSON(c *gc.C) {
+	s.api.entries = s.api.entries[1:]
+	ctx, err := s.run(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `[{`+
+		`"time":"2016-05-01T12:01:00Z",`+
+		`"user":"mary@local",`+
+		`"model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d",`+
+		`"facade":"Service",`+
+		`"version":3,`+
+		`"method":"Destroy",`+
+		`"error":"boom"`+
+		"}]\n")
+}
+
+func (s *auditLogSuite) TestAuditLogEmpty(c *gc.C) {
+	s.api.entries = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "no audit log entries to display\n")
+}
+
+func (s *auditLogSuite) TestAuditLogFilters(c *gc.C) {
+	_, err := s.run(c,
+		"--user", "bob",
+		"--method", "Service.Deploy",
+		"--from", "2016-05-01T00:00:00Z",
+		"--to", "2016-05-02T00:00:00+01:00",
+		"--limit", "5",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	from := time.Date(2016, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2016, 5, 1, 23, 0, 0, 0, time.UTC)
+	c.Assert(s.api.filter, jc.DeepEquals, params.AuditLogFilter{
+		UserTag: "user-bob",
+		Facade:  "Service",
+		Method:  "Deploy",
+		From:    &from,
+		To:      &to,
+		Limit:   5,
+	})
+}
+
+func (s *auditLogSuite) TestAuditLogMethodOnly(c *gc.C) {
+	_, err := s.run(c, "--method", "Deploy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.filter.Facade, gc.Equals, "")
+	c.Assert(s.api.filter.Method, gc.Equals, "Deploy")
+}
+
+func (s *auditLogSuite) TestAuditLogFromDuration(c *gc.C) {
+	before := time.Now()
+	_, err := s.run(c, "--from", "2h")
+	c.Assert(err, jc.ErrorIsNil)
+	after := time.Now()
+	c.Assert(s.api.filter.From, gc.NotNil)
+	from := *s.api.filter.From
+	c.Assert(from.Before(before.Add(-2*time.Hour)), jc.IsFalse)
+	c.Assert(from.After(after.Add(-2*time.Hour)), jc.IsFalse)
+}
+
+func (s *auditLogSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--user", "not/valid"},
+		err:  `"not/valid" is not a valid user name`,
+	}, {
+		args: []string{"--method", "Service."},
+		err:  `"Service." is not a valid method`,
+	}, {
+		args: []string{"--from", "yesterday"},
+		err:  `invalid --from: expected RFC3339 time or duration, got "yesterday"`,
+	}, {
+		args: []string{"--to", "-1h"},
+		err:  `invalid --to: negative duration "-1h"`,
+	}, {
+		args: []string{"--limit", "-1"},
+		err:  "--limit must not be negative",
+	}, {
+		args: []string{"whoops"},
+		err:  `unrecognized args: \["whoops"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	c.Assert(s.api.called, jc.IsFalse)
+}
+
+func (s *auditLogSuite) TestAuditLogError(c *gc.C) {
+	s.api.err = common.ErrPerm
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "cannot read audit log: permission denied")
+}
+
+type fakeAuditLogAPI struct {
+	entries []params.AuditLogEntry
+	err     error
+	called  bool
+	filter  params.AuditLogFilter
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	return nil
+}
+
+func (f *fakeAuditLogAPI) AuditLog(filter params.AuditLogFilter) ([]params.AuditLogEntry, error) {
+	f.called = true
+	f.filter = filter
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.entries, nil
+}
@@ -74,6 +74,26 @@ func NewRemoveBlocksCommandForTest(api removeBlocksAPI, store jujuclient.ClientS
 	return modelcmd.WrapController(c)
 }
 
+// NewRotateCertificatesCommandForTest returns a RotateCertificatesCommand
+// with the function used to open the API connection mocked out.
+func NewRotateCertificatesCommandForTest(api rotateCertificatesAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &rotateCertificatesCommand{
+		api: api,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewAuditLogCommandForTest returns an AuditLogCommand with the
+// function used to open the API connection mocked out.
+func NewAuditLogCommandForTest(api auditLogAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &auditLogCommand{
+		api: api,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 // NewDestroyCommandForTest returns a DestroyCommand with the controller and
 // client endpoints mocked out.
 func NewDestroyCommandForTest(
@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewRotateCertificatesCommand returns a command that allows a
+// controller admin to rotate the controller's certificates.
+func NewRotateCertificatesCommand() cmd.Command {
+	return modelcmd.WrapController(&rotateCertificatesCommand{})
+}
+
+type rotateCertificatesCommand struct {
+	modelcmd.ControllerCommandBase
+	api rotateCertificatesAPI
+
+	newCA            bool
+	transitionPeriod time.Duration
+}
+
+type rotateCertificatesAPI interface {
+	Close() error
+	RotateControllerCertificates(newCA bool, transitionPeriod time.Duration) (params.RotateControllerCertificatesResult, error)
+}
+
+var rotateCertificatesDoc = `
+Rotate the certificates of the Juju controller.
+
+Each controller machine generates a new server certificate, signed by
+the controller's CA.
+
+If --new-ca is specified, a new CA is created first, and the server
+certificates are signed by it. The previous CA remains trusted for the
+transition period (a week by default); until then, the controllers also
+present the new CA signed by the previous one, so that agents and clients
+which only trust the previous CA can still connect while the new CA is
+distributed to them. The local copy of the controller's CA certificate
+is updated.
+
+Examples:
+    juju rotate-controller-certificates
+    juju rotate-controller-certificates --new-ca --transition-period 72h
+`
+
+// Info implements Command.Info
+func (c *rotateCertificatesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "rotate-controller-certificates",
+		Purpose: "rotate the certificates of the Juju controller",
+		Doc:     rotateCertificatesDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *rotateCertificatesCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.newCA, "new-ca", false, "Replace the controller's CA")
+	f.DurationVar(&c.transitionPeriod, "transition-period", 0, "How long the previous CA remains trusted")
+}
+
+// Init implements Command.Init.
+func (c *rotateCertificatesCommand) Init(args []string) error {
+	if c.transitionPeriod < 0 {
+		return errors.New("transition period must not be negative")
+	}
+	if c.transitionPeriod != 0 && !c.newCA {
+		return errors.New("--transition-period requires --new-ca")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *rotateCertificatesCommand) getAPI() (rotateCertificatesAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+// Run implements Command.Run
+func (c *rotateCertificatesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	result, err := client.RotateControllerCertificates(c.newCA, c.transitionPeriod)
+	if err != nil {
+		return errors.Annotate(err, "cannot rotate controller certificates")
+	}
+
+	store := c.ClientStore()
+	controllerName := c.ControllerName()
+	details, err := store.ControllerByName(controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	details.CACert = result.CACert
+	if err := store.UpdateController(controllerName, *details); err != nil {
+		return errors.Annotate(err, "cannot update controller details")
+	}
+
+	if result.TransitionEnd != nil {
+		fmt.Fprintf(ctx.Stdout, "previous CA trusted until %s\n", result.TransitionEnd.Format(time.RFC3339))
+	}
+	return nil
+}
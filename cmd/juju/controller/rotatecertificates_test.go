@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type rotateCertificatesSuite struct {
+	baseControllerSuite
+	api   *fakeRotateCertificatesAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&rotateCertificatesSuite{})
+
+func (s *rotateCertificatesSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	err := modelcmd.WriteCurrentController("fake")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.api = &fakeRotateCertificatesAPI{
+		result: params.RotateControllerCertificatesResult{CACert: "new-ca-cert"},
+	}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{
+		ControllerUUID: testing.ModelTag.Id(),
+		CACert:         testing.CACert,
+	}
+}
+
+func (s *rotateCertificatesSuite) newCommand() cmd.Command {
+	return controller.NewRotateCertificatesCommandForTest(s.api, s.store)
+}
+
+func (s *rotateCertificatesSuite) TestRotate(c *gc.C) {
+	ctx, err := testing.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.called, jc.IsTrue)
+	c.Assert(s.api.newCA, jc.IsFalse)
+	c.Assert(s.api.transitionPeriod, gc.Equals, time.Duration(0))
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(s.store.Controllers["fake"].CACert, gc.Equals, "new-ca-cert")
+}
+
+func (s *rotateCertificatesSuite) TestRotateNewCA(c *gc.C) {
+	transitionEnd := time.Date(2016, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.api.result.TransitionEnd = &transitionEnd
+	ctx, err := testing.RunCommand(c, s.newCommand(), "--new-ca", "--transition-period", "72h")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.newCA, jc.IsTrue)
+	c.Assert(s.api.transitionPeriod, gc.Equals, 72*time.Hour)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "previous CA trusted until 2016-05-01T12:00:00Z\n")
+	c.Assert(s.store.Controllers["fake"].CACert, gc.Equals, "new-ca-cert")
+}
+
+func (s *rotateCertificatesSuite) TestTransitionPeriodRequiresNewCA(c *gc.C) {
+	_, err := testing.RunCommand(c, s.newCommand(), "--transition-period", "72h")
+	c.Assert(err, gc.ErrorMatches, "--transition-period requires --new-ca")
+	c.Assert(s.api.called, jc.IsFalse)
+}
+
+func (s *rotateCertificatesSuite) TestNegativeTransitionPeriod(c *gc.C) {
+	_, err := testing.RunCommand(c, s.newCommand(), "--new-ca", "--transition-period", "-1h")
+	c.Assert(err, gc.ErrorMatches, "transition period must not be negative")
+	c.Assert(s.api.called, jc.IsFalse)
+}
+
+func (s *rotateCertificatesSuite) TestUnrecognizedArg(c *gc.C) {
+	_, err := testing.RunCommand(c, s.newCommand(), "whoops")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["whoops"\]`)
+	c.Assert(s.api.called, jc.IsFalse)
+}
+
+func (s *rotateCertificatesSuite) TestRotateError(c *gc.C) {
+	s.api.err = common.ErrPerm
+	_, err := testing.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "cannot rotate controller certificates: permission denied")
+	c.Assert(s.store.Controllers["fake"].CACert, gc.Equals, testing.CACert)
+}
+
+type fakeRotateCertificatesAPI struct {
+	result           params.RotateControllerCertificatesResult
+	err              error
+	called           bool
+	newCA            bool
+	transitionPeriod time.Duration
+}
+
+func (f *fakeRotateCertificatesAPI) Close() error {
+	return nil
+}
+
+func (f *fakeRotateCertificatesAPI) RotateControllerCertificates(newCA bool, transitionPeriod time.Duration) (params.RotateControllerCertificatesResult, error) {
+	f.called = true
+	f.newCA = newCA
+	f.transitionPeriod = transitionPeriod
+	if f.err != nil {
+		return params.RotateControllerCertificatesResult{}, f.err
+	}
+	return f.result, nil
+}
@@ -57,6 +57,22 @@ information about how to allocate the machine. For example, one can direct the
 MAAS provider to acquire a particular node by specifying its hostname.
 For more information on placement directives, see "juju help placement".
 
+Many existing machines may be manually provisioned at once by listing them
+in an inventory file given with --from-file. The file lists the hosts,
+each with an optional user to log in as and private key to log in with:
+
+   hosts:
+     - host: 10.10.0.3
+     - host: 10.10.0.4
+       user: admin
+       key: ~/.ssh/admin_rsa
+     - placement: ssh:admin@10.10.0.5
+
+Up to --parallel hosts are provisioned at once, and the outcome for each
+host is reported. The hosts must allow login with a key and passwordless
+sudo. Hosts that are already machines in the model are skipped, so the
+command may be run again to retry hosts that failed.
+
 Examples:
    juju add-machine                      (starts a new machine)
    juju add-machine -n 2                 (starts 2 new machines)
@@ -65,6 +81,7 @@ Examples:
    juju add-machine lxc:4                (starts a new lxc container on machine 4)
    juju add-machine --constraints mem=8G (starts a machine with at least 8GB RAM)
    juju add-machine ssh:user@10.10.0.3   (manually provisions a machine with ssh)
+   juju add-machine --from-file hosts.yaml (manually provisions the listed hosts)
    juju add-machine zone=us-east-1a      (start a machine in zone us-east-1a on AWS)
    juju add-machine maas2.name           (acquire machine maas2.name on MAAS)
 
@@ -107,6 +124,12 @@ type addCommand struct {
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
 	Disks []storage.Constraints
+	// FromFile is the path of an inventory file listing hosts to
+	// manually provision.
+	FromFile string
+	// Parallel is the number of hosts provisioned at once from an
+	// inventory file.
+	Parallel int
 }
 
 func (c *addCommand) Info() *cmd.Info {
@@ -124,12 +147,23 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.Var(constraints.ConstraintsValue{Target: &c.Constraints}, "constraints", "additional machine constraints")
 	f.Var(disksFlag{&c.Disks}, "disks", "constraints for disks to attach to the machine")
+	f.StringVar(&c.FromFile, "from-file", "", "manually provision the hosts listed in an inventory file")
+	f.IntVar(&c.Parallel, "parallel", defaultProvisioningParallelism, "the number of hosts to provision at once with --from-file")
 }
 
 func (c *addCommand) Init(args []string) error {
 	if c.Constraints.Container != nil {
 		return fmt.Errorf("container constraint %q not allowed when adding a machine", *c.Constraints.Container)
 	}
+	if c.Parallel < 1 {
+		return errors.New("--parallel must be at least 1")
+	}
+	if c.FromFile != "" {
+		if len(args) > 0 || c.NumMachines != 1 || c.Series != "" || len(c.Disks) > 0 || !constraints.IsEmpty(&c.Constraints) {
+			return errors.New("--from-file cannot be combined with a placement, -n, --series, --constraints or --disks")
+		}
+		return nil
+	}
 	placement, err := cmd.ZeroOrOneArgs(args)
 	if err != nil {
 		return err
@@ -155,6 +189,7 @@ type AddMachineAPI interface {
 	ModelGet() (map[string]interface{}, error)
 	ModelUUID() string
 	ProvisioningScript(params.ProvisioningScriptParams) (script string, err error)
+	Status(patterns []string) (*params.FullStatus, error)
 }
 
 type MachineManagerAPI interface {
@@ -216,18 +251,24 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
+	updateBehavior := &params.UpdateBehavior{
+		config.EnableOSRefreshUpdate(),
+		config.EnableOSUpgrade(),
+	}
+	if c.FromFile != "" {
+		logger.Infof("manual provisioning from inventory")
+		return c.addFromInventory(ctx, client, updateBehavior)
+	}
+
 	if c.Placement != nil && c.Placement.Scope == "ssh" {
 		logger.Infof("manual provisioning")
 		args := manual.ProvisionMachineArgs{
-			Host:   c.Placement.Directive,
-			Client: client,
-			Stdin:  ctx.Stdin,
-			Stdout: ctx.Stdout,
-			Stderr: ctx.Stderr,
-			UpdateBehavior: &params.UpdateBehavior{
-				config.EnableOSRefreshUpdate(),
-				config.EnableOSUpgrade(),
-			},
+			Host:           c.Placement.Directive,
+			Client:         client,
+			Stdin:          ctx.Stdin,
+			Stdout:         ctx.Stdout,
+			Stderr:         ctx.Stderr,
+			UpdateBehavior: updateBehavior,
 		}
 		machineId, err := manualProvisioner(args)
 		if err == nil {
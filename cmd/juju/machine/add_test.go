@@ -225,6 +225,7 @@ type fakeAddMachineAPI struct {
 	args         []params.AddMachineParams
 	addError     error
 	providerType string
+	status       params.FullStatus
 }
 
 func (f *fakeAddMachineAPI) Close() error {
@@ -266,6 +267,10 @@ func (f *fakeAddMachineAPI) ProvisioningScript(params.ProvisioningScriptParams)
 	return "", errors.NotImplementedf("ProvisioningScript")
 }
 
+func (f *fakeAddMachineAPI) Status(patterns []string) (*params.FullStatus, error) {
+	return &f.status, nil
+}
+
 func (f *fakeAddMachineAPI) ModelGet() (map[string]interface{}, error) {
 	providerType := "dummy"
 	if f.providerType != "" {
@@ -11,7 +11,8 @@ import (
 )
 
 var (
-	ManualProvisioner = &manualProvisioner
+	ManualProvisioner      = &manualProvisioner
+	UninstallManualMachine = &uninstallManualMachine
 )
 
 type AddCommand struct {
@@ -51,6 +52,19 @@ func NewRemoveCommandForTest(api RemoveMachineAPI) (cmd.Command, *RemoveCommand)
 	return modelcmd.Wrap(cmd), &RemoveCommand{cmd}
 }
 
+type UpgradeSeriesCommand struct {
+	*upgradeSeriesCommand
+}
+
+// NewUpgradeSeriesCommandForTest returns an UpgradeSeriesCommand with the
+// api provided as specified.
+func NewUpgradeSeriesCommandForTest(api UpgradeSeriesAPI) (cmd.Command, *UpgradeSeriesCommand) {
+	cmd := &upgradeSeriesCommand{
+		api: api,
+	}
+	return modelcmd.Wrap(cmd), &UpgradeSeriesCommand{cmd}
+}
+
 func NewDisksFlag(disks *[]storage.Constraints) *disksFlag {
 	return &disksFlag{disks}
 }
@@ -0,0 +1,183 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/instance"
+)
+
+// defaultProvisioningParallelism is the default number of hosts
+// provisioned at once from an inventory file.
+const defaultProvisioningParallelism = 10
+
+// inventory holds the contents of an inventory file, which lists the
+// hosts to be manually provisioned by add-machine --from-file.
+type inventory struct {
+	Hosts []inventoryHost `yaml:"hosts"`
+}
+
+// inventoryHost describes a host to be manually provisioned.
+type inventoryHost struct {
+	// Host is the address or hostname of the host.
+	Host string `yaml:"host,omitempty"`
+
+	// User is the user to log in as to initialise the ubuntu user,
+	// if it is not already initialised.
+	User string `yaml:"user,omitempty"`
+
+	// Key is the path of the private key to log in with. A relative
+	// path is taken to be relative to the inventory file.
+	Key string `yaml:"key,omitempty"`
+
+	// Placement may be given instead of Host and User, as a
+	// placement directive of the form ssh:[user@]host.
+	Placement string `yaml:"placement,omitempty"`
+}
+
+// readInventory reads and validates the inventory file at the given
+// path, returning the hosts it lists.
+func readInventory(path string) ([]inventoryHost, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var inv inventory
+	if err := yaml.Unmarshal(data, &inv); err != nil {
+		return nil, errors.Annotatef(err, "cannot parse inventory file %q", path)
+	}
+	if len(inv.Hosts) == 0 {
+		return nil, errors.Errorf("no hosts listed in inventory file %q", path)
+	}
+	seen := make(map[string]bool)
+	hosts := make([]inventoryHost, len(inv.Hosts))
+	for i, h := range inv.Hosts {
+		if h.Placement != "" {
+			if h.Host != "" || h.User != "" {
+				return nil, errors.Errorf("host %d: placement cannot be combined with host or user", i+1)
+			}
+			p, err := instance.ParsePlacement(h.Placement)
+			if err != nil || p.Scope != "ssh" {
+				return nil, errors.Errorf("host %d: expected placement ssh:[user@]host, got %q", i+1, h.Placement)
+			}
+			h.User, h.Host = manual.SplitUserHost(p.Directive)
+			h.Placement = ""
+		}
+		if h.Host == "" {
+			return nil, errors.Errorf("host %d: no host specified", i+1)
+		}
+		if seen[h.Host] {
+			return nil, errors.Errorf("host %q listed more than once", h.Host)
+		}
+		seen[h.Host] = true
+		if h.Key != "" {
+			key, err := utils.NormalizePath(h.Key)
+			if err != nil {
+				return nil, errors.Annotatef(err, "host %q: invalid key path", h.Host)
+			}
+			if !filepath.IsAbs(key) {
+				key = filepath.Join(filepath.Dir(path), key)
+			}
+			h.Key = key
+		}
+		hosts[i] = h
+	}
+	return hosts, nil
+}
+
+// inventoryResult records the outcome of provisioning a host listed
+// in an inventory file.
+type inventoryResult struct {
+	host    string
+	machine string
+	existed bool
+	err     error
+}
+
+// addFromInventory manually provisions the hosts listed in the
+// command's inventory file, at most c.Parallel at a time. Hosts that
+// are already machines in the model are skipped, so that a partially
+// failed run may simply be repeated.
+func (c *addCommand) addFromInventory(ctx *cmd.Context, client AddMachineAPI, updateBehavior *params.UpdateBehavior) error {
+	hosts, err := readInventory(ctx.AbsPath(c.FromFile))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	status, err := client.Status(nil)
+	if err != nil {
+		return errors.Annotate(err, "cannot get existing machines")
+	}
+	existing := make(map[string]string)
+	for id, m := range status.Machines {
+		if host, ok := manual.InstanceHost(m.InstanceId); ok {
+			existing[host] = id
+		}
+	}
+
+	results := make([]inventoryResult, len(hosts))
+	sem := make(chan struct{}, c.Parallel)
+	var wg sync.WaitGroup
+	for i, h := range hosts {
+		if id, ok := existing[h.Host]; ok {
+			results[i] = inventoryResult{host: h.Host, machine: id, existed: true}
+			continue
+		}
+		wg.Add(1)
+		go func(i int, h inventoryHost) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			host := h.Host
+			if h.User != "" {
+				host = h.User + "@" + host
+			}
+			// Provisioning is not interactive, so hosts must
+			// allow key-based login and passwordless sudo.
+			machineId, err := manualProvisioner(manual.ProvisionMachineArgs{
+				Host:           host,
+				KeyFile:        h.Key,
+				Client:         client,
+				Stdout:         ioutil.Discard,
+				Stderr:         ioutil.Discard,
+				UpdateBehavior: updateBehavior,
+			})
+			results[i] = inventoryResult{host: h.Host, machine: machineId, err: err}
+		}(i, h)
+	}
+	wg.Wait()
+
+	failed := 0
+	tw := tabwriter.NewWriter(ctx.Stdout, 0, 1, 2, ' ', 0)
+	fmt.Fprintf(tw, "HOST\tMACHINE\tRESULT\n")
+	for _, r := range results {
+		var result string
+		switch {
+		case r.err != nil:
+			failed++
+			result = "failed: " + r.err.Error()
+		case r.existed:
+			result = "already in model"
+		default:
+			result = "provisioned"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.host, r.machine, result)
+	}
+	tw.Flush()
+	if failed > 0 {
+		return errors.Errorf("failed to provision %d of %d hosts", failed, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/testing"
+)
+
+type InventorySuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fakeAddMachine *fakeAddMachineAPI
+	dir            string
+
+	mu          sync.Mutex
+	provisioned []manual.ProvisionMachineArgs
+}
+
+var _ = gc.Suite(&InventorySuite{})
+
+func (s *InventorySuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fakeAddMachine = &fakeAddMachineAPI{}
+	s.dir = c.MkDir()
+	s.provisioned = nil
+	s.PatchValue(machine.ManualProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.provisioned = append(s.provisioned, args)
+		switch args.Host {
+		case "10.0.0.1":
+			return "1", nil
+		case "admin@10.0.0.2":
+			return "2", nil
+		}
+		return "", errors.New("no route to host")
+	})
+}
+
+func (s *InventorySuite) writeInventory(c *gc.C, content string) string {
+	path := filepath.Join(s.dir, "hosts.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *InventorySuite) run(c *gc.C, args ...string) (string, error) {
+	add, _ := machine.NewAddCommandForTest(s.fakeAddMachine, &fakeMachineManagerAPI{})
+	ctx, err := testing.RunCommand(c, add, args...)
+	return testing.Stdout(ctx), err
+}
+
+func (s *InventorySuite) provisionedHosts() []string {
+	var hosts []string
+	for _, args := range s.provisioned {
+		hosts = append(hosts, args.Host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+func (s *InventorySuite) TestAddFromFile(c *gc.C) {
+	path := s.writeInventory(c, `
+hosts:
+  - host: 10.0.0.1
+  - host: 10.0.0.2
+    user: admin
+    key: keys/admin_rsa
+  - placement: ssh:10.0.0.3
+`)
+	stdout, err := s.run(c, "--from-file", path)
+	c.Assert(err, gc.ErrorMatches, "failed to provision 1 of 3 hosts")
+	c.Assert(stdout, gc.Equals, ""+
+		"HOST      MACHINE  RESULT\n"+
+		"10.0.0.1  1        provisioned\n"+
+		"10.0.0.2  2        provisioned\n"+
+		"10.0.0.3           failed: no route to host\n")
+	c.Assert(s.provisionedHosts(), jc.DeepEquals, []string{"10.0.0.1", "10.0.0.3", "admin@10.0.0.2"})
+	for _, args := range s.provisioned {
+		if args.Host == "admin@10.0.0.2" {
+			c.Check(args.KeyFile, gc.Equals, filepath.Join(s.dir, "keys", "admin_rsa"))
+		} else {
+			c.Check(args.KeyFile, gc.Equals, "")
+		}
+		c.Check(args.UpdateBehavior, gc.NotNil)
+	}
+}
+
+func (s *InventorySuite) TestAddFromFileSkipsExisting(c *gc.C) {
+	s.fakeAddMachine.status = params.FullStatus{
+		Machines: map[string]params.MachineStatus{
+			"5": {Id: "5", InstanceId: "manual:10.0.0.3"},
+			"6": {Id: "6", InstanceId: "i-6"},
+		},
+	}
+	path := s.writeInventory(c, `
+hosts:
+  - host: 10.0.0.1
+  - host: 10.0.0.3
+`)
+	stdout, err := s.run(c, "--from-file", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stdout, gc.Equals, ""+
+		"HOST      MACHINE  RESULT\n"+
+		"10.0.0.1  1        provisioned\n"+
+		"10.0.0.3  5        already in model\n")
+	c.Assert(s.provisionedHosts(), jc.DeepEquals, []string{"10.0.0.1"})
+}
+
+func (s *InventorySuite) TestAddFromFileParallel(c *gc.C) {
+	var mu sync.Mutex
+	var active, maxActive int
+	s.PatchValue(machine.ManualProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		mu.Lock()
+		active++
+		if active > maxActive {
+			maxActive = active
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		active--
+		mu.Unlock()
+		return "0", nil
+	})
+	path := s.writeInventory(c, `
+hosts:
+  - host: 10.0.0.1
+  - host: 10.0.0.2
+  - host: 10.0.0.3
+  - host: 10.0.0.4
+  - host: 10.0.0.5
+`)
+	_, err := s.run(c, "--from-file", path, "--parallel", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(maxActive <= 2, jc.IsTrue, gc.Commentf("%d hosts provisioned at once", maxActive))
+}
+
+func (s *InventorySuite) TestInvalidInventory(c *gc.C) {
+	for i, test := range []struct {
+		content string
+		err     string
+	}{{
+		content: "hosts: []",
+		err:     `no hosts listed in inventory file ".*"`,
+	}, {
+		content: "hosts: [{user: admin}]",
+		err:     "host 1: no host specified",
+	}, {
+		content: "hosts: [{host: 10.0.0.1}, {host: 10.0.0.1}]",
+		err:     `host "10.0.0.1" listed more than once`,
+	}, {
+		content: "hosts: [{placement: ssh:10.0.0.1, user: admin}]",
+		err:     "host 1: placement cannot be combined with host or user",
+	}, {
+		content: "hosts: [{placement: lxc:1}]",
+		err:     `host 1: expected placement ssh:\[user@\]host, got "lxc:1"`,
+	}, {
+		content: "hosts: {",
+		err:     `cannot parse inventory file ".*": .*`,
+	}} {
+		c.Logf("test %d", i)
+		path := s.writeInventory(c, test.content)
+		_, err := s.run(c, "--from-file", path)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	c.Assert(s.provisioned, gc.HasLen, 0)
+}
+
+func (s *InventorySuite) TestInitErrors(c *gc.C) {
+	for i, args := range [][]string{
+		{"--from-file", "hosts.yaml", "ssh:10.0.0.1"},
+		{"--from-file", "hosts.yaml", "-n", "2"},
+		{"--from-file", "hosts.yaml", "--series", "trusty"},
+		{"--from-file", "hosts.yaml", "--constraints", "mem=8G"},
+	} {
+		c.Logf("test %d", i)
+		_, err := s.run(c, args...)
+		c.Check(err, gc.ErrorMatches, "--from-file cannot be combined with a placement, -n, --series, --constraints or --disks")
+	}
+	_, err := s.run(c, "--from-file", "hosts.yaml", "--parallel", "0")
+	c.Assert(err, gc.ErrorMatches, "--parallel must be at least 1")
+}
@@ -7,11 +7,14 @@ import (
 	"fmt"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	"github.com/juju/names"
 	"launchpad.net/gnuflag"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/manual"
 )
 
 // NewRemoveCommand returns a command used to remove a specified machine.
@@ -22,9 +25,10 @@ func NewRemoveCommand() cmd.Command {
 // removeCommand causes an existing machine to be destroyed.
 type removeCommand struct {
 	modelcmd.ModelCommandBase
-	api        RemoveMachineAPI
-	MachineIds []string
-	Force      bool
+	api          RemoveMachineAPI
+	MachineIds   []string
+	Force        bool
+	KeepInstance bool
 }
 
 const destroyMachineDoc = `
@@ -33,12 +37,19 @@ running units or containers can only be removed with the --force flag; doing
 so will also remove all those units and containers without giving them any
 opportunity to shut down cleanly.
 
+When a manually provisioned machine is removed, the juju agents, services
+and data are also removed from its host, over SSH, unless --keep-instance
+is specified.
+
 Examples:
 	# Remove machine number 5 which has no running units or containers
 	$ juju remove-machine 5
 
 	# Remove machine 6 and any running units or containers
 	$ juju remove-machine 6 --force
+
+	# Remove manually provisioned machine 7, leaving its host untouched
+	$ juju remove-machine 7 --keep-instance
 `
 
 // Info implements Command.Info.
@@ -55,6 +66,7 @@ func (c *removeCommand) Info() *cmd.Info {
 // SetFlags implements Command.SetFlags.
 func (c *removeCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.Force, "force", false, "completely remove machine and all dependencies")
+	f.BoolVar(&c.KeepInstance, "keep-instance", false, "do not remove juju from the hosts of manually provisioned machines")
 }
 
 func (c *removeCommand) Init(args []string) error {
@@ -73,6 +85,7 @@ func (c *removeCommand) Init(args []string) error {
 type RemoveMachineAPI interface {
 	DestroyMachines(machines ...string) error
 	ForceDestroyMachines(machines ...string) error
+	Status(patterns []string) (*params.FullStatus, error)
 	Close() error
 }
 
@@ -84,16 +97,91 @@ func (c *removeCommand) getRemoveMachineAPI() (RemoveMachineAPI, error) {
 }
 
 // Run implements Command.Run.
-func (c *removeCommand) Run(_ *cmd.Context) error {
+func (c *removeCommand) Run(ctx *cmd.Context) error {
 	client, err := c.getRemoveMachineAPI()
 	if err != nil {
 		return err
 	}
 	defer client.Close()
+
+	var manualMachines []manualMachine
+	if !c.KeepInstance {
+		manualMachines, err = c.manualMachines(client)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
 	if c.Force {
 		err = client.ForceDestroyMachines(c.MachineIds...)
 	} else {
 		err = client.DestroyMachines(c.MachineIds...)
 	}
-	return block.ProcessBlockedError(err, block.BlockRemove)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	return c.uninstallManualMachines(ctx, client, manualMachines)
 }
+
+// manualMachine holds the details of a manually provisioned machine
+// needed to remove juju from its host.
+type manualMachine struct {
+	id     string
+	host   string
+	series string
+}
+
+// manualMachines returns the details of the manually provisioned
+// machines amongst those being removed.
+func (c *removeCommand) manualMachines(client RemoveMachineAPI) ([]manualMachine, error) {
+	status, err := client.Status(c.MachineIds)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get machine details")
+	}
+	var result []manualMachine
+	for _, id := range c.MachineIds {
+		machine, ok := status.Machines[id]
+		if !ok {
+			// Containers are never manually provisioned.
+			continue
+		}
+		if host, ok := manual.InstanceHost(machine.InstanceId); ok {
+			result = append(result, manualMachine{id, host, machine.Series})
+		}
+	}
+	return result, nil
+}
+
+// uninstallManualMachines removes juju from the hosts of the manually
+// provisioned machines being removed, reporting what was removed.
+func (c *removeCommand) uninstallManualMachines(ctx *cmd.Context, client RemoveMachineAPI, machines []manualMachine) error {
+	var uninstalled []string
+	var failed int
+	for _, m := range machines {
+		ctx.Infof("removing juju from machine %s (%s)", m.id, m.host)
+		err := uninstallManualMachine(manual.UninstallMachineArgs{
+			Host:   m.host,
+			Series: m.series,
+			Stdout: ctx.Stdout,
+		})
+		if err != nil {
+			fmt.Fprintf(ctx.Stderr, "ERROR %v\n", err)
+			failed++
+			continue
+		}
+		uninstalled = append(uninstalled, m.id)
+	}
+	if len(uninstalled) > 0 && !c.Force {
+		// The machine agents have been removed, so they cannot
+		// report the machines dead; ensure the machines are removed
+		// from the model regardless.
+		if err := client.ForceDestroyMachines(uninstalled...); err != nil {
+			return errors.Annotate(err, "cannot remove uninstalled machines")
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf("failed to remove juju from %d manually provisioned machine(s)", failed)
+	}
+	return nil
+}
+
+var uninstallManualMachine = manual.UninstallMachine
@@ -4,14 +4,18 @@
 package machine_test
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/environs/manual"
 	"github.com/juju/juju/testing"
 )
 
@@ -25,6 +29,9 @@ var _ = gc.Suite(&RemoveMachineSuite{})
 func (s *RemoveMachineSuite) SetUpTest(c *gc.C) {
 	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
 	s.fake = &fakeRemoveMachineAPI{}
+	s.PatchValue(machine.UninstallManualMachine, func(manual.UninstallMachineArgs) error {
+		panic("unexpected uninstall")
+	})
 }
 
 func (s *RemoveMachineSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
@@ -110,10 +117,95 @@ func (s *RemoveMachineSuite) TestForceBlockedError(c *gc.C) {
 	c.Assert(stripped, gc.Matches, ".*TestForceBlockedError.*")
 }
 
+func (s *RemoveMachineSuite) setManualMachines() {
+	s.fake.status = &params.FullStatus{
+		Machines: map[string]params.MachineStatus{
+			"1": {Id: "1", InstanceId: "manual:10.0.0.1", Series: "trusty"},
+			"2": {Id: "2", InstanceId: "i-2", Series: "trusty"},
+			"3": {Id: "3", InstanceId: "manual:10.0.0.3", Series: "xenial"},
+		},
+	}
+}
+
+func (s *RemoveMachineSuite) TestRemoveManual(c *gc.C) {
+	s.setManualMachines()
+	var uninstalled []manual.UninstallMachineArgs
+	s.PatchValue(machine.UninstallManualMachine, func(args manual.UninstallMachineArgs) error {
+		fmt.Fprintf(args.Stdout, "removed /var/lib/juju\n")
+		args.Stdout = nil
+		uninstalled = append(uninstalled, args)
+		return nil
+	})
+	ctx, err := s.run(c, "1", "2", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.statusPatterns, jc.DeepEquals, []string{"1", "2", "3"})
+	c.Assert(uninstalled, jc.DeepEquals, []manual.UninstallMachineArgs{
+		{Host: "10.0.0.1", Series: "trusty"},
+		{Host: "10.0.0.3", Series: "xenial"},
+	})
+	c.Assert(s.fake.calls, jc.DeepEquals, []string{
+		"DestroyMachines 1 2 3",
+		"ForceDestroyMachines 1 3",
+	})
+	c.Assert(testing.Stdout(ctx), gc.Equals, "removed /var/lib/juju\nremoved /var/lib/juju\n")
+	c.Assert(testing.Stderr(ctx), gc.Equals, ""+
+		"removing juju from machine 1 (10.0.0.1)\n"+
+		"removing juju from machine 3 (10.0.0.3)\n")
+}
+
+func (s *RemoveMachineSuite) TestRemoveManualForce(c *gc.C) {
+	s.setManualMachines()
+	var uninstalled []string
+	s.PatchValue(machine.UninstallManualMachine, func(args manual.UninstallMachineArgs) error {
+		uninstalled = append(uninstalled, args.Host)
+		return nil
+	})
+	_, err := s.run(c, "--force", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uninstalled, jc.DeepEquals, []string{"10.0.0.1"})
+	c.Assert(s.fake.calls, jc.DeepEquals, []string{"ForceDestroyMachines 1"})
+}
+
+func (s *RemoveMachineSuite) TestRemoveManualKeepInstance(c *gc.C) {
+	s.setManualMachines()
+	_, err := s.run(c, "--keep-instance", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.statusPatterns, gc.IsNil)
+	c.Assert(s.fake.calls, jc.DeepEquals, []string{"DestroyMachines 1"})
+}
+
+func (s *RemoveMachineSuite) TestRemoveManualUninstallError(c *gc.C) {
+	s.setManualMachines()
+	s.PatchValue(machine.UninstallManualMachine, func(args manual.UninstallMachineArgs) error {
+		if args.Host == "10.0.0.1" {
+			return errors.New("cannot uninstall juju from 10.0.0.1: boom")
+		}
+		return nil
+	})
+	ctx, err := s.run(c, "1", "3")
+	c.Assert(err, gc.ErrorMatches, `failed to remove juju from 1 manually provisioned machine\(s\)`)
+	c.Assert(s.fake.calls, jc.DeepEquals, []string{
+		"DestroyMachines 1 3",
+		"ForceDestroyMachines 3",
+	})
+	c.Assert(testing.Stderr(ctx), jc.Contains, "ERROR cannot uninstall juju from 10.0.0.1: boom\n")
+}
+
+func (s *RemoveMachineSuite) TestRemoveManualStatusError(c *gc.C) {
+	s.fake.statusError = errors.New("boom")
+	_, err := s.run(c, "1")
+	c.Assert(err, gc.ErrorMatches, "cannot get machine details: boom")
+	c.Assert(s.fake.calls, gc.HasLen, 0)
+}
+
 type fakeRemoveMachineAPI struct {
-	forced      bool
-	machines    []string
-	removeError error
+	forced         bool
+	machines       []string
+	removeError    error
+	calls          []string
+	status         *params.FullStatus
+	statusPatterns []string
+	statusError    error
 }
 
 func (f *fakeRemoveMachineAPI) Close() error {
@@ -123,11 +215,24 @@ func (f *fakeRemoveMachineAPI) Close() error {
 func (f *fakeRemoveMachineAPI) DestroyMachines(machines ...string) error {
 	f.forced = false
 	f.machines = machines
+	f.calls = append(f.calls, "DestroyMachines "+strings.Join(machines, " "))
 	return f.removeError
 }
 
 func (f *fakeRemoveMachineAPI) ForceDestroyMachines(machines ...string) error {
 	f.forced = true
 	f.machines = machines
+	f.calls = append(f.calls, "ForceDestroyMachines "+strings.Join(machines, " "))
 	return f.removeError
 }
+
+func (f *fakeRemoveMachineAPI) Status(patterns []string) (*params.FullStatus, error) {
+	f.statusPatterns = patterns
+	if f.statusError != nil {
+		return nil, f.statusError
+	}
+	if f.status == nil {
+		return &params.FullStatus{}, nil
+	}
+	return f.status, nil
+}
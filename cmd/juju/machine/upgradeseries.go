@@ -0,0 +1,133 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const (
+	prepareCommand  = "prepare"
+	completeCommand = "complete"
+)
+
+// NewUpgradeSeriesCommand returns a command used to upgrade the series
+// of a machine in place.
+func NewUpgradeSeriesCommand() cmd.Command {
+	return modelcmd.Wrap(&upgradeSeriesCommand{})
+}
+
+// UpgradeSeriesAPI defines the API methods used by the upgrade-series
+// command.
+type UpgradeSeriesAPI interface {
+	UpgradeSeriesPrepare(machineName, series string) error
+	UpgradeSeriesComplete(machineName string) error
+	Close() error
+}
+
+// upgradeSeriesCommand moves a machine, and the units it hosts, to a new
+// series without redeploying them.
+type upgradeSeriesCommand struct {
+	modelcmd.ModelCommandBase
+	api UpgradeSeriesAPI
+
+	MachineId string
+	Command   string
+	Series    string
+}
+
+const upgradeSeriesDoc = `
+Upgrading the series of a machine in place is a two step process.
+
+"prepare" locks the machine for the upgrade: the units on the machine
+run their pre-series-upgrade hooks, after which no other hooks are run
+on them, and the machine agent reinstalls the juju agent services for
+the init system used by the new series.
+
+Once "prepare" has finished, upgrade the operating system of the machine
+(for example with do-release-upgrade) and reboot it.
+
+"complete" then records the new series for the machine, and the units
+on the machine run their post-series-upgrade hooks before resuming
+normal operation.
+
+Controller machines cannot be upgraded this way.
+
+Examples:
+    juju upgrade-series 1 prepare xenial
+    juju upgrade-series 1 complete
+`
+
+// Info implements Command.Info.
+func (c *upgradeSeriesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "upgrade-series",
+		Args:    "<machine> prepare|complete [<series>]",
+		Purpose: "upgrade the series of a machine in place",
+		Doc:     upgradeSeriesDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *upgradeSeriesCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("expected a machine and either prepare or complete")
+	}
+	c.MachineId, c.Command, args = args[0], args[1], args[2:]
+	if !names.IsValidMachine(c.MachineId) {
+		return errors.Errorf("invalid machine id %q", c.MachineId)
+	}
+	switch c.Command {
+	case prepareCommand:
+		if len(args) == 0 {
+			return errors.New("no series specified")
+		}
+		c.Series, args = args[0], args[1:]
+	case completeCommand:
+	default:
+		return errors.Errorf("unknown command %q, expected prepare or complete", c.Command)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *upgradeSeriesCommand) getAPI() (UpgradeSeriesAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *upgradeSeriesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	switch c.Command {
+	case prepareCommand:
+		err = client.UpgradeSeriesPrepare(c.MachineId, c.Series)
+		if err == nil {
+			ctx.Infof("machine %s is being prepared for upgrade to series %q", c.MachineId, c.Series)
+			ctx.Infof(`upgrade the operating system, then run "juju upgrade-series %s complete"`, c.MachineId)
+		}
+	case completeCommand:
+		err = client.UpgradeSeriesComplete(c.MachineId)
+		if err == nil {
+			ctx.Infof("machine %s is completing its series upgrade", c.MachineId)
+		}
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
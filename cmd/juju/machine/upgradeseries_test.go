@@ -0,0 +1,121 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type UpgradeSeriesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeUpgradeSeriesAPI
+}
+
+var _ = gc.Suite(&UpgradeSeriesSuite{})
+
+func (s *UpgradeSeriesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeUpgradeSeriesAPI{}
+}
+
+func (s *UpgradeSeriesSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	upgrade, _ := machine.NewUpgradeSeriesCommandForTest(s.fake)
+	return testing.RunCommand(c, upgrade, args...)
+}
+
+func (s *UpgradeSeriesSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		machine     string
+		command     string
+		series      string
+		errorString string
+	}{{
+		errorString: "expected a machine and either prepare or complete",
+	}, {
+		args:        []string{"1"},
+		errorString: "expected a machine and either prepare or complete",
+	}, {
+		args:    []string{"1", "prepare", "xenial"},
+		machine: "1",
+		command: "prepare",
+		series:  "xenial",
+	}, {
+		args:    []string{"1/lxc/0", "complete"},
+		machine: "1/lxc/0",
+		command: "complete",
+	}, {
+		args:        []string{"1", "prepare"},
+		errorString: "no series specified",
+	}, {
+		args:        []string{"1", "complete", "xenial"},
+		errorString: `unrecognized args: \["xenial"\]`,
+	}, {
+		args:        []string{"1", "rollback"},
+		errorString: `unknown command "rollback", expected prepare or complete`,
+	}, {
+		args:        []string{"lxc", "complete"},
+		errorString: `invalid machine id "lxc"`,
+	}} {
+		c.Logf("test %d", i)
+		wrappedCommand, upgradeCmd := machine.NewUpgradeSeriesCommandForTest(s.fake)
+		err := testing.InitCommand(wrappedCommand, test.args)
+		if test.errorString == "" {
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(upgradeCmd.MachineId, gc.Equals, test.machine)
+			c.Check(upgradeCmd.Command, gc.Equals, test.command)
+			c.Check(upgradeCmd.Series, gc.Equals, test.series)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errorString)
+		}
+	}
+}
+
+func (s *UpgradeSeriesSuite) TestPrepare(c *gc.C) {
+	_, err := s.run(c, "1", "prepare", "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.calls, jc.DeepEquals, []string{"prepare 1 xenial"})
+}
+
+func (s *UpgradeSeriesSuite) TestComplete(c *gc.C) {
+	_, err := s.run(c, "1", "complete")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.calls, jc.DeepEquals, []string{"complete 1"})
+}
+
+func (s *UpgradeSeriesSuite) TestBlockedError(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockedError")
+	_, err := s.run(c, "1", "prepare", "xenial")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	// msg is logged
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Assert(stripped, gc.Matches, ".*TestBlockedError.*")
+}
+
+type fakeUpgradeSeriesAPI struct {
+	calls []string
+	err   error
+}
+
+func (f *fakeUpgradeSeriesAPI) Close() error {
+	return nil
+}
+
+func (f *fakeUpgradeSeriesAPI) UpgradeSeriesPrepare(machineName, series string) error {
+	f.calls = append(f.calls, "prepare "+machineName+" "+series)
+	return f.err
+}
+
+func (f *fakeUpgradeSeriesAPI) UpgradeSeriesComplete(machineName string) error {
+	f.calls = append(f.calls, "complete "+machineName)
+	return f.err
+}
@@ -12,8 +12,9 @@ import (
 )
 
 var (
-	NewClient    = &newClient
-	NewRunClient = &newRunClient
+	NewClient          = &newClient
+	NewRunClient       = &newRunClient
+	NewAggregateClient = &newAggregateClient
 )
 
 // NewRunClientFnc returns a function that returns a struct that implements the
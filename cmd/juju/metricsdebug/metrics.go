@@ -0,0 +1,206 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsdebug
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/metricsdebug"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const metricsDoc = `
+Aggregate the metrics collected by a service or unit.
+
+The metrics of all a service's units are aggregated together. Values
+are aggregated over each interval given by --interval; if no interval
+is given, all the values of each metric are aggregated together.
+
+Examples:
+    juju metrics mysql --key pings --since 1h --aggregate avg --interval 5m
+    juju metrics mysql/0 --format csv
+`
+
+// MetricsCommand retrieves aggregated metrics
+// stored in the juju controller.
+type MetricsCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	Tag       names.Tag
+	Key       string
+	Since     time.Duration
+	Aggregate string
+	Interval  time.Duration
+}
+
+// NewMetricsCommand creates a new MetricsCommand.
+func NewMetricsCommand() cmd.Command {
+	return modelcmd.Wrap(&MetricsCommand{})
+}
+
+// Info implements Command.Info.
+func (c *MetricsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "metrics",
+		Args:    "<service or unit>",
+		Purpose: "aggregate metrics collected by the given unit/service",
+		Doc:     metricsDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *MetricsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.Key, "key", "", "only aggregate the metric with this key")
+	f.DurationVar(&c.Since, "since", 0, "only aggregate metrics collected within this duration")
+	f.StringVar(&c.Aggregate, "aggregate", params.AggregateSum, "aggregation function: sum, avg or max")
+	f.DurationVar(&c.Interval, "interval", 0, "length of the intervals to aggregate over")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"tabular": formatMetricsTabular,
+		"json":    cmd.FormatJson,
+		"yaml":    cmd.FormatYaml,
+		"csv":     formatMetricsCSV,
+	})
+}
+
+// Init reads and verifies the cli arguments for the MetricsCommand.
+func (c *MetricsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("you need to specify a unit or service.")
+	}
+	if names.IsValidUnit(args[0]) {
+		c.Tag = names.NewUnitTag(args[0])
+	} else if names.IsValidService(args[0]) {
+		c.Tag = names.NewServiceTag(args[0])
+	} else {
+		return errors.Errorf("%q is not a valid unit or service", args[0])
+	}
+	switch c.Aggregate {
+	case params.AggregateSum, params.AggregateAvg, params.AggregateMax:
+	default:
+		return errors.Errorf("unknown aggregate %q, expected sum, avg or max", c.Aggregate)
+	}
+	if c.Since < 0 {
+		return errors.New("--since must not be negative")
+	}
+	if c.Interval < 0 {
+		return errors.New("--interval must not be negative")
+	}
+	if err := cmd.CheckEmpty(args[1:]); err != nil {
+		return errors.Errorf("unknown command line arguments: " + strings.Join(args, ","))
+	}
+	return nil
+}
+
+// AggregateMetricsClient defines the methods of the metricsdebug
+// API used by the metrics command.
+type AggregateMetricsClient interface {
+	AggregateMetrics(arg params.AggregateMetricsArg) ([]params.AggregatedMetric, error)
+	Close() error
+}
+
+var newAggregateClient = func(env modelcmd.ModelCommandBase) (AggregateMetricsClient, error) {
+	state, err := env.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return metricsdebug.NewClient(state), nil
+}
+
+// aggregatedMetric holds a metric's aggregated value over an interval.
+type aggregatedMetric struct {
+	Time  time.Time `json:"time" yaml:"time"`
+	Key   string    `json:"key" yaml:"key"`
+	Value float64   `json:"value" yaml:"value"`
+	Count int       `json:"count" yaml:"count"`
+}
+
+// Run implements Command.Run.
+func (c *MetricsCommand) Run(ctx *cmd.Context) error {
+	client, err := newAggregateClient(c.ModelCommandBase)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	arg := params.AggregateMetricsArg{
+		Tag:       c.Tag.String(),
+		Key:       c.Key,
+		Aggregate: c.Aggregate,
+		Interval:  c.Interval,
+	}
+	if c.Since > 0 {
+		arg.Since = time.Now().Add(-c.Since)
+	}
+	metrics, err := client.AggregateMetrics(arg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := make([]aggregatedMetric, len(metrics))
+	for i, m := range metrics {
+		out[i] = aggregatedMetric{
+			Time:  m.Time,
+			Key:   m.Key,
+			Value: m.Value,
+			Count: m.Count,
+		}
+	}
+	return c.out.Write(ctx, out)
+}
+
+func formatMetricsTabular(value interface{}) ([]byte, error) {
+	metrics, ok := value.([]aggregatedMetric)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", metrics, value)
+	}
+	var out bytes.Buffer
+	tw := tabwriter.NewWriter(&out, 0, 1, 1, ' ', 0)
+	fmt.Fprintf(tw, "TIME\tMETRIC\tVALUE\tCOUNT\n")
+	for _, m := range metrics {
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\n", m.Time.Format(time.RFC3339), m.Key, formatValue(m.Value), m.Count)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
+
+func formatMetricsCSV(value interface{}) ([]byte, error) {
+	metrics, ok := value.([]aggregatedMetric)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", metrics, value)
+	}
+	var out bytes.Buffer
+	w := csv.NewWriter(&out)
+	if err := w.Write([]string{"time", "metric", "value", "count"}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, m := range metrics {
+		record := []string{
+			m.Time.Format(time.RFC3339),
+			m.Key,
+			formatValue(m.Value),
+			strconv.Itoa(m.Count),
+		}
+		if err := w.Write(record); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	w.Flush()
+	return out.Bytes(), errors.Trace(w.Error())
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,136 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsdebug_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/metricsdebug"
+	"github.com/juju/juju/cmd/modelcmd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockAggregateMetricsClient struct {
+	testing.Stub
+	metrics []params.AggregatedMetric
+}
+
+func (m *mockAggregateMetricsClient) AggregateMetrics(arg params.AggregateMetricsArg) ([]params.AggregatedMetric, error) {
+	m.Stub.MethodCall(m, "AggregateMetrics", arg)
+	return m.metrics, m.NextErr()
+}
+
+func (m *mockAggregateMetricsClient) Close() error {
+	m.Stub.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+type MetricsSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	client *mockAggregateMetricsClient
+}
+
+var _ = gc.Suite(&MetricsSuite{})
+
+func (s *MetricsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	base := time.Date(2016, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.client = &mockAggregateMetricsClient{
+		metrics: []params.AggregatedMetric{
+			{Time: base, Key: "pings", Value: 12, Count: 2},
+			{Time: base.Add(5 * time.Minute), Key: "pings", Value: 10.5, Count: 1},
+		},
+	}
+	s.PatchValue(metricsdebug.NewAggregateClient, func(_ modelcmd.ModelCommandBase) (metricsdebug.AggregateMetricsClient, error) {
+		return s.client, nil
+	})
+}
+
+func (s *MetricsSuite) TestDefaults(c *gc.C) {
+	ctx, err := coretesting.RunCommand(c, metricsdebug.NewMetricsCommand(), "metered")
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.CheckCalls(c, []testing.StubCall{{
+		"AggregateMetrics", []interface{}{params.AggregateMetricsArg{
+			Tag:       "service-metered",
+			Aggregate: "sum",
+		}},
+	}, {
+		"Close", nil,
+	}})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+TIME                 METRIC VALUE COUNT
+2016-05-01T12:00:00Z pings  12    2
+2016-05-01T12:05:00Z pings  10.5  1
+`[1:])
+}
+
+func (s *MetricsSuite) TestOptions(c *gc.C) {
+	before := time.Now()
+	_, err := coretesting.RunCommand(c, metricsdebug.NewMetricsCommand(),
+		"metered/0", "--key", "pings", "--since", "1h", "--aggregate", "avg", "--interval", "5m",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.CheckCallNames(c, "AggregateMetrics", "Close")
+	arg := s.client.Calls()[0].Args[0].(params.AggregateMetricsArg)
+	c.Assert(arg.Since, jc.TimeBetween(before.Add(-time.Hour), time.Now().Add(-time.Hour)))
+	arg.Since = time.Time{}
+	c.Assert(arg, jc.DeepEquals, params.AggregateMetricsArg{
+		Tag:       "unit-metered-0",
+		Key:       "pings",
+		Aggregate: "avg",
+		Interval:  5 * time.Minute,
+	})
+}
+
+func (s *MetricsSuite) TestCSVOutput(c *gc.C) {
+	ctx, err := coretesting.RunCommand(c, metricsdebug.NewMetricsCommand(), "metered", "--format", "csv")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+time,metric,value,count
+2016-05-01T12:00:00Z,pings,12,2
+2016-05-01T12:05:00Z,pings,10.5,1
+`[1:])
+}
+
+func (s *MetricsSuite) TestJSONOutput(c *gc.C) {
+	ctx, err := coretesting.RunCommand(c, metricsdebug.NewMetricsCommand(), "metered", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `[`+
+		`{"time":"2016-05-01T12:00:00Z","key":"pings","value":12,"count":2},`+
+		`{"time":"2016-05-01T12:05:00Z","key":"pings","value":10.5,"count":1}]`+"\n")
+}
+
+func (s *MetricsSuite) TestInitErrors(c *gc.C) {
+	tests := []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "you need to specify a unit or service.",
+	}, {
+		args: []string{"!!!"},
+		err:  `"!!!" is not a valid unit or service`,
+	}, {
+		args: []string{"metered", "--aggregate", "median"},
+		err:  `unknown aggregate "median", expected sum, avg or max`,
+	}, {
+		args: []string{"metered", "--since", "-1h"},
+		err:  "--since must not be negative",
+	}, {
+		args: []string{"metered", "--interval", "-5m"},
+		err:  "--interval must not be negative",
+	}}
+	for i, test := range tests {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := coretesting.RunCommand(c, metricsdebug.NewMetricsCommand(), test.args...)
+		c.Assert(err, gc.ErrorMatches, test.err)
+	}
+	s.client.CheckNoCalls(c)
+}
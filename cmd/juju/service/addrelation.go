@@ -26,11 +26,23 @@ type addRelationCommand struct {
 	Endpoints []string
 }
 
+const addRelationDoc = `
+Either service may be given as the URL of an offer made by another model
+in the controller (see "juju offer" and "juju list-offers"). A remote
+service representing the offer is then added to this model, and relation
+settings are exchanged with the offering model by the controller.
+
+Examples:
+    juju add-relation wordpress mysql
+    juju add-relation wordpress local:/u/admin@local/databases/mysql
+`
+
 func (c *addRelationCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "add-relation",
 		Args:    "<service1>[:<relation name1>] <service2>[:<relation name2>]",
 		Purpose: "add a relation between two services",
+		Doc:     addRelationDoc,
 	}
 }
 
@@ -0,0 +1,216 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"gopkg.in/yaml.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/service"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/instance"
+)
+
+var usageGetContainerProfileSummary = `
+Displays the container profile for a service.`[1:]
+
+var usageGetContainerProfileDetails = `
+Shows the LXD and KVM customisations that are applied to new containers
+hosting units of the service, as set with ` + "`juju set-container-profile`" + `.
+
+Examples:
+    juju get-container-profile mysql
+    juju get-container-profile --format json nova-compute
+
+See also:
+    set-container-profile`
+
+var usageSetContainerProfileSummary = `
+Sets the container profile for a service.`[1:]
+
+var usageSetContainerProfileDetails = `
+Sets LXD and KVM customisations to apply to containers subsequently
+created to host units of the service. Existing containers are not
+changed. The profile is read from a YAML file, for example:
+
+    lxd-config:
+      security.nesting: "true"
+      linux.kernel_modules: openvswitch,nbd
+    lxd-devices:
+      kvm:
+        type: unix-char
+        path: /dev/kvm
+    kvm-cpu: <cpu mode='host-passthrough'/>
+    kvm-devices:
+      - <rng model='virtio'><backend model='random'>/dev/urandom</backend></rng>
+
+Only settings that cannot compromise the host are accepted:
+ - the LXD config keys linux.kernel_modules, security.nesting and
+   limits.*;
+ - LXD devices of type gpu and usb;
+ - LXD unix-char devices for /dev/fuse, /dev/kvm, /dev/net/tun,
+   /dev/vhost-net and /dev/vhost-vsock, and unix-block devices for
+   /dev/nbd*, identified by path rather than by major and minor number;
+ - a KVM <cpu> element;
+ - KVM <input> and <watchdog> devices, <rng> devices backed by
+   /dev/random, /dev/urandom or /dev/hwrng, and <hostdev> devices
+   assigning host USB or PCI devices in subsystem mode.
+
+Examples:
+    juju set-container-profile nova-compute profile.yaml
+    juju set-container-profile --reset nova-compute
+
+See also:
+    get-container-profile`
+
+type serviceContainerProfileAPI interface {
+	Close() error
+	GetContainerProfile(string) (instance.ContainerProfile, error)
+	SetContainerProfile(string, instance.ContainerProfile) error
+}
+
+type serviceContainerProfileCommand struct {
+	modelcmd.ModelCommandBase
+	ServiceName string
+	api         serviceContainerProfileAPI
+}
+
+func (c *serviceContainerProfileCommand) getAPI() (serviceContainerProfileAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return service.NewClient(root), nil
+}
+
+func (c *serviceContainerProfileCommand) initService(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no service name specified")
+	}
+	if !names.IsValidService(args[0]) {
+		return nil, errors.Errorf("invalid service name %q", args[0])
+	}
+	c.ServiceName = args[0]
+	return args[1:], nil
+}
+
+// NewServiceGetContainerProfileCommand returns a command which gets the
+// container profile of a service.
+func NewServiceGetContainerProfileCommand() cmd.Command {
+	return modelcmd.Wrap(&serviceGetContainerProfileCommand{})
+}
+
+type serviceGetContainerProfileCommand struct {
+	serviceContainerProfileCommand
+	out cmd.Output
+}
+
+func (c *serviceGetContainerProfileCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "get-container-profile",
+		Args:    "<service>",
+		Purpose: usageGetContainerProfileSummary,
+		Doc:     usageGetContainerProfileDetails,
+	}
+}
+
+func (c *serviceGetContainerProfileCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+func (c *serviceGetContainerProfileCommand) Init(args []string) error {
+	args, err := c.initService(args)
+	if err != nil {
+		return err
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *serviceGetContainerProfileCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer apiclient.Close()
+
+	profile, err := apiclient.GetContainerProfile(c.ServiceName)
+	if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, profile)
+}
+
+// NewServiceSetContainerProfileCommand returns a command which sets the
+// container profile of a service.
+func NewServiceSetContainerProfileCommand() cmd.Command {
+	return modelcmd.Wrap(&serviceSetContainerProfileCommand{})
+}
+
+type serviceSetContainerProfileCommand struct {
+	serviceContainerProfileCommand
+	ProfileFile string
+	Reset       bool
+}
+
+func (c *serviceSetContainerProfileCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-container-profile",
+		Args:    "<service> [<profile.yaml>]",
+		Purpose: usageSetContainerProfileSummary,
+		Doc:     usageSetContainerProfileDetails,
+	}
+}
+
+func (c *serviceSetContainerProfileCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.Reset, "reset", false, "Remove all customisations from the service's container profile")
+}
+
+func (c *serviceSetContainerProfileCommand) Init(args []string) error {
+	args, err := c.initService(args)
+	if err != nil {
+		return err
+	}
+	if c.Reset {
+		return cmd.CheckEmpty(args)
+	}
+	if len(args) == 0 {
+		return errors.New("no profile file specified")
+	}
+	c.ProfileFile, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+func (c *serviceSetContainerProfileCommand) Run(ctx *cmd.Context) error {
+	var profile instance.ContainerProfile
+	if !c.Reset {
+		data, err := ioutil.ReadFile(ctx.AbsPath(c.ProfileFile))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := yaml.Unmarshal(data, &profile); err != nil {
+			return errors.Annotatef(err, "cannot parse container profile %q", c.ProfileFile)
+		}
+		if err := profile.Validate(); err != nil {
+			return errors.Annotatef(err, "invalid container profile %q", c.ProfileFile)
+		}
+	}
+
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer apiclient.Close()
+
+	err = apiclient.SetContainerProfile(c.ServiceName, profile)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,125 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/service"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/testing"
+)
+
+type ContainerProfileCommandsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeContainerProfileAPI
+}
+
+var _ = gc.Suite(&ContainerProfileCommandsSuite{})
+
+func (s *ContainerProfileCommandsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeContainerProfileAPI{}
+}
+
+func (s *ContainerProfileCommandsSuite) TestSetInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `no service name specified`,
+	}, {
+		args: []string{"mysql/0"},
+		err:  `invalid service name "mysql/0"`,
+	}, {
+		args: []string{"mysql"},
+		err:  `no profile file specified`,
+	}, {
+		args: []string{"--reset", "mysql", "profile.yaml"},
+		err:  `unrecognized args: \["profile.yaml"\]`,
+	}} {
+		c.Logf("test %d", i)
+		cmd := service.NewSetContainerProfileCommandForTest(s.api)
+		err := testing.InitCommand(cmd, test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ContainerProfileCommandsSuite) TestSet(c *gc.C) {
+	dir := c.MkDir()
+	path := filepath.Join(dir, "profile.yaml")
+	err := ioutil.WriteFile(path, []byte(`
+lxd-config:
+  security.nesting: "true"
+lxd-devices:
+  kvm:
+    type: unix-char
+    path: /dev/kvm
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = testing.RunCommand(c, service.NewSetContainerProfileCommandForTest(s.api), "mysql", path)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"SetContainerProfile", []interface{}{"mysql", instance.ContainerProfile{
+			LXDConfig: map[string]string{"security.nesting": "true"},
+			LXDDevices: map[string]map[string]string{
+				"kvm": {"type": "unix-char", "path": "/dev/kvm"},
+			},
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *ContainerProfileCommandsSuite) TestSetRejectsUnsafeKeys(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "profile.yaml")
+	err := ioutil.WriteFile(path, []byte("lxd-config:\n  security.privileged: \"true\"\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = testing.RunCommand(c, service.NewSetContainerProfileCommandForTest(s.api), "mysql", path)
+	c.Assert(err, gc.ErrorMatches, `invalid container profile ".*profile.yaml": LXD config key "security.privileged" not valid`)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *ContainerProfileCommandsSuite) TestReset(c *gc.C) {
+	_, err := testing.RunCommand(c, service.NewSetContainerProfileCommandForTest(s.api), "--reset", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "SetContainerProfile", "mysql", instance.ContainerProfile{})
+}
+
+func (s *ContainerProfileCommandsSuite) TestGet(c *gc.C) {
+	s.api.profile = instance.ContainerProfile{
+		LXDConfig: map[string]string{"security.nesting": "true"},
+	}
+	ctx, err := testing.RunCommand(c, service.NewGetContainerProfileCommandForTest(s.api), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "lxd-config:\n  security.nesting: \"true\"\n")
+	s.api.CheckCallNames(c, "GetContainerProfile", "Close")
+}
+
+type fakeContainerProfileAPI struct {
+	jujutesting.Stub
+	profile instance.ContainerProfile
+}
+
+func (f *fakeContainerProfileAPI) Close() error {
+	f.AddCall("Close")
+	return f.NextErr()
+}
+
+func (f *fakeContainerProfileAPI) GetContainerProfile(service string) (instance.ContainerProfile, error) {
+	f.AddCall("GetContainerProfile", service)
+	return f.profile, f.NextErr()
+}
+
+func (f *fakeContainerProfileAPI) SetContainerProfile(service string, profile instance.ContainerProfile) error {
+	f.AddCall("SetContainerProfile", service, profile)
+	return f.NextErr()
+}
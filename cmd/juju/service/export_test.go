@@ -32,6 +32,68 @@ func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	})
 }
 
+// NewGetContainerProfileCommandForTest returns a get-container-profile
+// command with the api provided as specified.
+func NewGetContainerProfileCommandForTest(api serviceContainerProfileAPI) cmd.Command {
+	c := &serviceGetContainerProfileCommand{}
+	c.api = api
+	return modelcmd.Wrap(c)
+}
+
+// NewSetContainerProfileCommandForTest returns a set-container-profile
+// command with the api provided as specified.
+func NewSetContainerProfileCommandForTest(api serviceContainerProfileAPI) cmd.Command {
+	c := &serviceSetContainerProfileCommand{}
+	c.api = api
+	return modelcmd.Wrap(c)
+}
+
+// NewOfferCommandForTest returns an offer command with the api
+// provided as specified.
+func NewOfferCommandForTest(api offerAPI) cmd.Command {
+	c := &offerCommand{}
+	c.api = api
+	return modelcmd.Wrap(c)
+}
+
+// NewListOffersCommandForTest returns a list-offers command with the api
+// provided as specified.
+func NewListOffersCommandForTest(api offerAPI) cmd.Command {
+	c := &listOffersCommand{}
+	c.api = api
+	return modelcmd.Wrap(c)
+}
+
+// NewSuspendRelationCommandForTest returns a suspend-relation command
+// with the api provided as specified.
+func NewSuspendRelationCommandForTest(api serviceSuspendRelationAPI) cmd.Command {
+	return modelcmd.Wrap(&suspendRelationCommand{suspended: true, api: api})
+}
+
+// NewResumeRelationCommandForTest returns a resume-relation command with
+// the api provided as specified.
+func NewResumeRelationCommandForTest(api serviceSuspendRelationAPI) cmd.Command {
+	return modelcmd.Wrap(&suspendRelationCommand{api: api})
+}
+
+// NewTransferLeaderCommandForTest returns a transfer-leader command with
+// the api provided as specified.
+func NewTransferLeaderCommandForTest(api serviceLeadershipAPI) cmd.Command {
+	return modelcmd.Wrap(&transferLeaderCommand{leadershipCommandBase: leadershipCommandBase{api: api}})
+}
+
+// NewPinLeaderCommandForTest returns a pin-leader command with the api
+// provided as specified.
+func NewPinLeaderCommandForTest(api serviceLeadershipAPI) cmd.Command {
+	return modelcmd.Wrap(&pinLeaderCommand{leadershipCommandBase: leadershipCommandBase{api: api}, pinned: true})
+}
+
+// NewUnpinLeaderCommandForTest returns an unpin-leader command with the
+// api provided as specified.
+func NewUnpinLeaderCommandForTest(api serviceLeadershipAPI) cmd.Command {
+	return modelcmd.Wrap(&pinLeaderCommand{leadershipCommandBase: leadershipCommandBase{api: api}})
+}
+
 type Patcher interface {
 	PatchValue(dest, value interface{})
 }
@@ -0,0 +1,190 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+
+	apiservice "github.com/juju/juju/api/service"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageTransferLeaderSummary = `
+Hands leadership of a service to one of its units.`[1:]
+
+var usageTransferLeaderDetails = `
+The service's current leader remains leader until its leadership lease
+expires, but it cannot renew the lease, and runs its leader-deposed hook.
+Leadership then passes directly to the specified unit, which runs its
+leader-elected hook; the other units run leader-settings-changed hooks as
+usual. This may be used to move leadership away from a unit before taking
+down its machine.
+
+The service must currently have a leader, and its leadership must not be
+pinned.
+
+Examples:
+    juju transfer-leader mysql/1
+
+See also:
+    pin-leader`
+
+var usagePinLeaderSummary = `
+Prevents a service's leader from losing leadership.`[1:]
+
+var usagePinLeaderDetails = `
+While pinned, the service's current leader keeps leadership even if it
+stops renewing its leadership lease, so no other unit is elected leader
+while, for example, the leader's machine is down for maintenance.
+Leadership is unpinned with ` + "`juju unpin-leader`" + `.
+
+Examples:
+    juju pin-leader mysql
+
+See also:
+    unpin-leader
+    transfer-leader`
+
+var usageUnpinLeaderSummary = `
+Allows a pinned service's leader to lose leadership again.`[1:]
+
+var usageUnpinLeaderDetails = `
+If the leader has stopped renewing its leadership lease while pinned,
+another unit is elected leader once the lease expires.
+
+Examples:
+    juju unpin-leader mysql
+
+See also:
+    pin-leader`
+
+type serviceLeadershipAPI interface {
+	Close() error
+	TransferLeadership(unitName string) error
+	SetLeadershipPinned(serviceName string, pinned bool) error
+}
+
+// leadershipCommandBase holds what is common to the commands which
+// influence service leadership.
+type leadershipCommandBase struct {
+	modelcmd.ModelCommandBase
+	api serviceLeadershipAPI
+}
+
+func (c *leadershipCommandBase) getAPI() (serviceLeadershipAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apiservice.NewClient(root), nil
+}
+
+// NewTransferLeaderCommand returns a command which hands leadership of
+// a service to one of its units.
+func NewTransferLeaderCommand() cmd.Command {
+	return modelcmd.Wrap(&transferLeaderCommand{})
+}
+
+// transferLeaderCommand hands leadership of a service to one of its units.
+type transferLeaderCommand struct {
+	leadershipCommandBase
+	UnitName string
+}
+
+func (c *transferLeaderCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "transfer-leader",
+		Args:    "<unit>",
+		Purpose: usageTransferLeaderSummary,
+		Doc:     usageTransferLeaderDetails,
+	}
+}
+
+func (c *transferLeaderCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no unit specified")
+	}
+	unitName, args := args[0], args[1:]
+	if !names.IsValidUnit(unitName) {
+		return errors.NotValidf("unit name %q", unitName)
+	}
+	c.UnitName = unitName
+	return cmd.CheckEmpty(args)
+}
+
+func (c *transferLeaderCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.TransferLeadership(c.UnitName)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("leadership will pass to %s when the current leader's lease expires", c.UnitName)
+	return nil
+}
+
+// NewPinLeaderCommand returns a command which prevents a service's
+// leader from losing leadership.
+func NewPinLeaderCommand() cmd.Command {
+	return modelcmd.Wrap(&pinLeaderCommand{pinned: true})
+}
+
+// NewUnpinLeaderCommand returns a command which allows a pinned service's
+// leader to lose leadership again.
+func NewUnpinLeaderCommand() cmd.Command {
+	return modelcmd.Wrap(&pinLeaderCommand{})
+}
+
+// pinLeaderCommand pins or unpins the leadership of a service.
+type pinLeaderCommand struct {
+	leadershipCommandBase
+	ServiceName string
+	pinned      bool
+}
+
+func (c *pinLeaderCommand) Info() *cmd.Info {
+	info := &cmd.Info{
+		Name:    "unpin-leader",
+		Args:    "<service>",
+		Purpose: usageUnpinLeaderSummary,
+		Doc:     usageUnpinLeaderDetails,
+	}
+	if c.pinned {
+		info.Name = "pin-leader"
+		info.Purpose = usagePinLeaderSummary
+		info.Doc = usagePinLeaderDetails
+	}
+	return info
+}
+
+func (c *pinLeaderCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no service specified")
+	}
+	serviceName, args := args[0], args[1:]
+	if !names.IsValidService(serviceName) {
+		return errors.NotValidf("service name %q", serviceName)
+	}
+	c.ServiceName = serviceName
+	return cmd.CheckEmpty(args)
+}
+
+func (c *pinLeaderCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.SetLeadershipPinned(c.ServiceName, c.pinned)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/service"
+	"github.com/juju/juju/testing"
+)
+
+type LeadershipSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeLeadershipAPI
+}
+
+var _ = gc.Suite(&LeadershipSuite{})
+
+func (s *LeadershipSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeLeadershipAPI{}
+}
+
+func (s *LeadershipSuite) TestTransferLeaderInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no unit specified",
+	}, {
+		args: []string{"mysql"},
+		err:  `unit name "mysql" not valid`,
+	}, {
+		args: []string{"mysql/0", "mysql/1"},
+		err:  `unrecognized args: \["mysql/1"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := testing.InitCommand(service.NewTransferLeaderCommandForTest(s.api), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *LeadershipSuite) TestTransferLeader(c *gc.C) {
+	ctx, err := testing.RunCommand(c, service.NewTransferLeaderCommandForTest(s.api), "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"TransferLeadership", []interface{}{"mysql/1"}},
+		{"Close", nil},
+	})
+	c.Check(testing.Stderr(ctx), gc.Equals, "leadership will pass to mysql/1 when the current leader's lease expires\n")
+}
+
+func (s *LeadershipSuite) TestTransferLeaderError(c *gc.C) {
+	s.api.SetErrors(errors.New(`service "mysql" has no leader`))
+	_, err := testing.RunCommand(c, service.NewTransferLeaderCommandForTest(s.api), "mysql/1")
+	c.Assert(err, gc.ErrorMatches, `service "mysql" has no leader`)
+}
+
+func (s *LeadershipSuite) TestPinLeaderInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no service specified",
+	}, {
+		args: []string{"mysql/0"},
+		err:  `service name "mysql/0" not valid`,
+	}, {
+		args: []string{"mysql", "wordpress"},
+		err:  `unrecognized args: \["wordpress"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := testing.InitCommand(service.NewPinLeaderCommandForTest(s.api), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+		err = testing.InitCommand(service.NewUnpinLeaderCommandForTest(s.api), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *LeadershipSuite) TestPinLeader(c *gc.C) {
+	_, err := testing.RunCommand(c, service.NewPinLeaderCommandForTest(s.api), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"SetLeadershipPinned", []interface{}{"mysql", true}},
+		{"Close", nil},
+	})
+}
+
+func (s *LeadershipSuite) TestUnpinLeader(c *gc.C) {
+	_, err := testing.RunCommand(c, service.NewUnpinLeaderCommandForTest(s.api), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "SetLeadershipPinned", "mysql", false)
+}
+
+type fakeLeadershipAPI struct {
+	jujutesting.Stub
+}
+
+func (f *fakeLeadershipAPI) Close() error {
+	f.AddCall("Close")
+	return f.NextErr()
+}
+
+func (f *fakeLeadershipAPI) TransferLeadership(unitName string) error {
+	f.AddCall("TransferLeadership", unitName)
+	return f.NextErr()
+}
+
+func (f *fakeLeadershipAPI) SetLeadershipPinned(serviceName string, pinned bool) error {
+	f.AddCall("SetLeadershipPinned", serviceName, pinned)
+	return f.NextErr()
+}
@@ -0,0 +1,243 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/service"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageOfferSummary = `
+Offers a service's endpoints to other models.`[1:]
+
+var usageOfferDetails = `
+Makes some or all of a service's endpoints available for relations with
+services in other models hosted by the same controller. If no endpoints
+are specified, all of the service's non-peer endpoints are offered. The
+offer is published under the service name unless another name is given.
+
+The URL of the offer is printed, and may be passed to ` + "`juju add-relation`" + `
+in any model of the controller to relate a service to the offered one.
+Relation settings are exchanged between the models by the controller.
+
+Examples:
+    juju offer mysql
+    juju offer mysql:server,admin db --description "Production database"
+
+See also:
+    list-offers
+    add-relation`
+
+var usageListOffersSummary = `
+Lists the services offered by models in the controller.`[1:]
+
+var usageListOffersDetails = `
+Shows the offers made with ` + "`juju offer`" + ` by all models hosted by
+the controller, together with their URLs and offered endpoints.
+
+Examples:
+    juju list-offers
+    juju list-offers --format yaml
+
+See also:
+    offer`
+
+type offerAPI interface {
+	Close() error
+	Offer(service string, endpoints []string, offerName, description string) (params.ServiceOfferDetails, error)
+	ListOffers() ([]params.ServiceOfferDetails, error)
+}
+
+type offerCommandBase struct {
+	modelcmd.ModelCommandBase
+	api offerAPI
+}
+
+func (c *offerCommandBase) getAPI() (offerAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return service.NewClient(root), nil
+}
+
+// NewOfferCommand returns a command which offers a service's endpoints
+// to other models.
+func NewOfferCommand() cmd.Command {
+	return modelcmd.Wrap(&offerCommand{})
+}
+
+type offerCommand struct {
+	offerCommandBase
+	ServiceName string
+	Endpoints   []string
+	OfferName   string
+	Description string
+}
+
+func (c *offerCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "offer",
+		Args:    "<service>[:<endpoint>[,<endpoint>...]] [<offer name>]",
+		Purpose: usageOfferSummary,
+		Doc:     usageOfferDetails,
+	}
+}
+
+func (c *offerCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.Description, "description", "", "Description of the offer")
+}
+
+func (c *offerCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no service specified")
+	}
+	parts := strings.SplitN(args[0], ":", 2)
+	c.ServiceName = parts[0]
+	if !names.IsValidService(c.ServiceName) {
+		return errors.Errorf("invalid service name %q", c.ServiceName)
+	}
+	if len(parts) == 2 {
+		for _, ep := range strings.Split(parts[1], ",") {
+			if ep == "" {
+				return errors.Errorf("invalid endpoints %q", parts[1])
+			}
+			c.Endpoints = append(c.Endpoints, ep)
+		}
+	}
+	args = args[1:]
+	if len(args) > 0 {
+		c.OfferName, args = args[0], args[1:]
+		if !names.IsValidService(c.OfferName) {
+			return errors.Errorf("invalid offer name %q", c.OfferName)
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *offerCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer apiclient.Close()
+
+	offer, err := apiclient.Offer(c.ServiceName, c.Endpoints, c.OfferName, c.Description)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	fmt.Fprintln(ctx.Stdout, offer.URL)
+	return nil
+}
+
+// NewListOffersCommand returns a command which lists the offers made by
+// models in the controller.
+func NewListOffersCommand() cmd.Command {
+	return modelcmd.Wrap(&listOffersCommand{})
+}
+
+type listOffersCommand struct {
+	offerCommandBase
+	out cmd.Output
+}
+
+// OfferInfo holds the details of an offer for display.
+type OfferInfo struct {
+	URL         string   `yaml:"url" json:"url"`
+	Service     string   `yaml:"service" json:"service"`
+	Model       string   `yaml:"model" json:"model"`
+	Endpoints   []string `yaml:"endpoints" json:"endpoints"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Owner       string   `yaml:"owner" json:"owner"`
+}
+
+func (c *listOffersCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list-offers",
+		Purpose: usageListOffersSummary,
+		Doc:     usageListOffersDetails,
+		Aliases: []string{"offers"},
+	}
+}
+
+func (c *listOffersCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatOffersTabular,
+	})
+}
+
+func (c *listOffersCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *listOffersCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer apiclient.Close()
+
+	offers, err := apiclient.ListOffers()
+	if err != nil {
+		return err
+	}
+	info := make([]OfferInfo, len(offers))
+	for i, offer := range offers {
+		info[i] = OfferInfo{
+			URL:         offer.URL,
+			Service:     offer.ServiceName,
+			Description: offer.Description,
+		}
+		if tag, err := names.ParseModelTag(offer.SourceModelTag); err == nil {
+			info[i].Model = tag.Id()
+		}
+		if tag, err := names.ParseUserTag(offer.OwnerTag); err == nil {
+			info[i].Owner = tag.Canonical()
+		}
+		for _, ep := range offer.Endpoints {
+			info[i].Endpoints = append(info[i].Endpoints, fmt.Sprintf("%s:%s", ep.Interface, ep.Name))
+		}
+	}
+	return c.out.Write(ctx, info)
+}
+
+func formatOffersTabular(value interface{}) ([]byte, error) {
+	offers, ok := value.([]OfferInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", offers, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "URL\tSERVICE\tENDPOINTS\tDESCRIPTION\n")
+	for _, offer := range offers {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", offer.URL, offer.Service, strings.Join(offer.Endpoints, ","), offer.Description)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service_test
+
+import (
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/service"
+	"github.com/juju/juju/testing"
+)
+
+type OfferCommandsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeOfferAPI
+}
+
+var _ = gc.Suite(&OfferCommandsSuite{})
+
+func (s *OfferCommandsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeOfferAPI{
+		offers: []params.ServiceOfferDetails{{
+			URL:            "local:/u/admin@local/default/db",
+			OfferName:      "db",
+			SourceModelTag: "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			ServiceName:    "mysql",
+			Endpoints: []params.RemoteEndpoint{{
+				Name:      "server",
+				Role:      charm.RoleProvider,
+				Interface: "mysql",
+			}},
+			Description: "a database",
+			OwnerTag:    "user-admin@local",
+		}},
+	}
+}
+
+func (s *OfferCommandsSuite) TestOfferInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  `no service specified`,
+	}, {
+		args: []string{"mysql/0"},
+		err:  `invalid service name "mysql/0"`,
+	}, {
+		args: []string{"mysql:"},
+		err:  `invalid endpoints ""`,
+	}, {
+		args: []string{"mysql:server,,admin"},
+		err:  `invalid endpoints "server,,admin"`,
+	}, {
+		args: []string{"mysql", "db/0"},
+		err:  `invalid offer name "db/0"`,
+	}, {
+		args: []string{"mysql", "db", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d", i)
+		cmd := service.NewOfferCommandForTest(s.api)
+		err := testing.InitCommand(cmd, test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *OfferCommandsSuite) TestOffer(c *gc.C) {
+	ctx, err := testing.RunCommand(c, service.NewOfferCommandForTest(s.api),
+		"mysql:server,admin", "db", "--description", "a database")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "local:/u/admin@local/default/db\n")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"Offer", []interface{}{"mysql", []string{"server", "admin"}, "db", "a database"}},
+		{"Close", nil},
+	})
+}
+
+func (s *OfferCommandsSuite) TestOfferAllEndpoints(c *gc.C) {
+	_, err := testing.RunCommand(c, service.NewOfferCommandForTest(s.api), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Offer", "mysql", []string(nil), "", "")
+}
+
+func (s *OfferCommandsSuite) TestListOffersTabular(c *gc.C) {
+	ctx, err := testing.RunCommand(c, service.NewListOffersCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"URL                              SERVICE  ENDPOINTS     DESCRIPTION\n"+
+		"local:/u/admin@local/default/db  mysql    mysql:server  a database\n")
+	s.api.CheckCallNames(c, "ListOffers", "Close")
+}
+
+func (s *OfferCommandsSuite) TestListOffersYAML(c *gc.C) {
+	ctx, err := testing.RunCommand(c, service.NewListOffersCommandForTest(s.api), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+- url: local:/u/admin@local/default/db
+  service: mysql
+  model: deadbeef-0bad-400d-8000-4b1d0d06f00d
+  endpoints:
+  - mysql:server
+  description: a database
+  owner: admin@local
+`[1:])
+}
+
+type fakeOfferAPI struct {
+	jujutesting.Stub
+	offers []params.ServiceOfferDetails
+}
+
+func (f *fakeOfferAPI) Close() error {
+	f.AddCall("Close")
+	return f.NextErr()
+}
+
+func (f *fakeOfferAPI) Offer(service string, endpoints []string, offerName, description string) (params.ServiceOfferDetails, error) {
+	f.AddCall("Offer", service, endpoints, offerName, description)
+	return f.offers[0], f.NextErr()
+}
+
+func (f *fakeOfferAPI) ListOffers() ([]params.ServiceOfferDetails, error) {
+	f.AddCall("ListOffers")
+	return f.offers, f.NextErr()
+}
@@ -0,0 +1,118 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	apiservice "github.com/juju/juju/api/service"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageSuspendRelationSummary = `
+Suspends a relation between two services.`[1:]
+
+var usageSuspendRelationDetails = `
+The units of both services depart the relation, running their
+relation-departed and relation-broken hooks, but the relation and the
+units' relation settings are kept. This may be used to temporarily cut
+off a service, for example during maintenance of a database. The relation
+is restored with ` + "`juju resume-relation`" + `.
+
+Suspended relations are marked as such in ` + "`juju status`" + `.
+Container-scoped (subordinate) and peer relations cannot be suspended.
+
+Examples:
+    juju suspend-relation wordpress mysql
+    juju suspend-relation mediawiki:db mariadb
+
+See also:
+    resume-relation
+    remove-relation`
+
+var usageResumeRelationSummary = `
+Resumes a suspended relation between two services.`[1:]
+
+var usageResumeRelationDetails = `
+The units of both services join the relation again, running their
+relation-joined and relation-changed hooks with the settings they had
+when the relation was suspended.
+
+Examples:
+    juju resume-relation wordpress mysql
+
+See also:
+    suspend-relation`
+
+type serviceSuspendRelationAPI interface {
+	Close() error
+	SetRelationSuspended(suspended bool, endpoints ...string) error
+}
+
+// NewSuspendRelationCommand returns a command which suspends a relation
+// between two services.
+func NewSuspendRelationCommand() cmd.Command {
+	return modelcmd.Wrap(&suspendRelationCommand{suspended: true})
+}
+
+// NewResumeRelationCommand returns a command which resumes a suspended
+// relation between two services.
+func NewResumeRelationCommand() cmd.Command {
+	return modelcmd.Wrap(&suspendRelationCommand{})
+}
+
+// suspendRelationCommand suspends or resumes a relation between two
+// service endpoints.
+type suspendRelationCommand struct {
+	modelcmd.ModelCommandBase
+	Endpoints []string
+	suspended bool
+	api       serviceSuspendRelationAPI
+}
+
+func (c *suspendRelationCommand) Info() *cmd.Info {
+	info := &cmd.Info{
+		Name:    "resume-relation",
+		Args:    "<service1>[:<relation name1>] <service2>[:<relation name2>]",
+		Purpose: usageResumeRelationSummary,
+		Doc:     usageResumeRelationDetails,
+	}
+	if c.suspended {
+		info.Name = "suspend-relation"
+		info.Purpose = usageSuspendRelationSummary
+		info.Doc = usageSuspendRelationDetails
+	}
+	return info
+}
+
+func (c *suspendRelationCommand) Init(args []string) error {
+	if len(args) != 2 {
+		return errors.Errorf("a relation must involve two services")
+	}
+	c.Endpoints = args
+	return nil
+}
+
+func (c *suspendRelationCommand) getAPI() (serviceSuspendRelationAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apiservice.NewClient(root), nil
+}
+
+func (c *suspendRelationCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.SetRelationSuspended(c.suspended, c.Endpoints...)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,63 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package service_test
+
+import (
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/service"
+	"github.com/juju/juju/testing"
+)
+
+type SuspendRelationSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeSuspendRelationAPI
+}
+
+var _ = gc.Suite(&SuspendRelationSuite{})
+
+func (s *SuspendRelationSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeSuspendRelationAPI{}
+}
+
+func (s *SuspendRelationSuite) TestInit(c *gc.C) {
+	for _, args := range [][]string{{}, {"mysql"}, {"mysql", "wordpress", "nginx"}} {
+		err := testing.InitCommand(service.NewSuspendRelationCommandForTest(s.api), args)
+		c.Check(err, gc.ErrorMatches, "a relation must involve two services")
+		err = testing.InitCommand(service.NewResumeRelationCommandForTest(s.api), args)
+		c.Check(err, gc.ErrorMatches, "a relation must involve two services")
+	}
+}
+
+func (s *SuspendRelationSuite) TestSuspend(c *gc.C) {
+	_, err := testing.RunCommand(c, service.NewSuspendRelationCommandForTest(s.api), "wordpress", "mysql:server")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"SetRelationSuspended", []interface{}{true, []string{"wordpress", "mysql:server"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *SuspendRelationSuite) TestResume(c *gc.C) {
+	_, err := testing.RunCommand(c, service.NewResumeRelationCommandForTest(s.api), "wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "SetRelationSuspended", false, []string{"wordpress", "mysql"})
+}
+
+type fakeSuspendRelationAPI struct {
+	jujutesting.Stub
+}
+
+func (f *fakeSuspendRelationAPI) Close() error {
+	f.AddCall("Close")
+	return f.NextErr()
+}
+
+func (f *fakeSuspendRelationAPI) SetRelationSuspended(suspended bool, endpoints ...string) error {
+	f.AddCall("SetRelationSuspended", suspended, endpoints)
+	return f.NextErr()
+}
@@ -4,15 +4,19 @@
 package space
 
 import (
+	"bytes"
 	"fmt"
 	"net"
+	"sort"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -39,7 +43,7 @@ output to be redirected to a file. `
 func (c *listCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "list-spaces",
-		Args:    "[--short] [--format yaml|json] [--output <path>]",
+		Args:    "[--short] [--format yaml|json|tabular] [--output <path>]",
 		Purpose: "List known spaces, including associated subnets",
 		Doc:     strings.TrimSpace(listCommandDoc),
 		Aliases: []string{"spaces"},
@@ -49,10 +53,7 @@ func (c *listCommand) Info() *cmd.Info {
 // SetFlags is defined on the cmd.Command interface.
 func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.SpaceCommandBase.SetFlags(f)
-	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
-		"yaml": cmd.FormatYaml,
-		"json": cmd.FormatJson,
-	})
+	c.out.AddFlags(f, "yaml", common.Formatters(formatListTabular))
 
 	f.BoolVar(&c.Short, "short", false, "only display spaces.")
 }
@@ -161,3 +162,38 @@ type formattedSubnet struct {
 	Status     string   `json:"status,omitempty" yaml:"status,omitempty"`
 	Zones      []string `json:"zones" yaml:"zones"`
 }
+
+// formatListTabular returns a tabular summary of spaces and their
+// subnets, sorted by name.
+func formatListTabular(value interface{}) ([]byte, error) {
+	var out bytes.Buffer
+	tw := tabwriter.NewWriter(&out, 0, 1, 2, ' ', 0)
+	switch list := value.(type) {
+	case nil:
+		return nil, nil
+	case formattedShortList:
+		fmt.Fprintln(tw, "SPACE")
+		for _, name := range list.Spaces {
+			fmt.Fprintln(tw, name)
+		}
+	case formattedList:
+		names := make([]string, 0, len(list.Spaces))
+		for name := range list.Spaces {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintln(tw, "SPACE\tSUBNETS")
+		for _, name := range names {
+			cidrs := make([]string, 0, len(list.Spaces[name]))
+			for cidr := range list.Spaces[name] {
+				cidrs = append(cidrs, cidr)
+			}
+			sort.Strings(cidrs)
+			fmt.Fprintf(tw, "%s\t%s\n", name, strings.Join(cidrs, ","))
+		}
+	default:
+		return nil, errors.Errorf("unexpected value of type %T", value)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
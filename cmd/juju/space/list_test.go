@@ -61,6 +61,10 @@ func (s *ListSuite) TestInit(c *gc.C) {
 		about:        "yaml format",
 		args:         s.Strings("--format", "yaml"),
 		expectFormat: "yaml",
+	}, {
+		about:        "tabular format",
+		args:         s.Strings("--format", "tabular"),
+		expectFormat: "tabular",
 	}, {
 		// --output and -o are tested separately in TestOutputFormats.
 		about:        "both --output and -o specified (latter overrides former)",
@@ -175,6 +179,17 @@ spaces:
 }
 `, "") + "\n"
 
+	expectedTabular := `
+SPACE   SUBNETS
+space1  2001:db8::/32,invalid
+space2  10.1.2.0/24,4.3.2.0/28
+`[1:]
+	expectedShortTabular := `
+SPACE
+space1
+space2
+`[1:]
+
 	assertAPICalls := func() {
 		// Verify the API calls and reset the recorded calls.
 		s.api.CheckCallNames(c, "ListSpaces", "Close")
@@ -242,9 +257,11 @@ spaces:
 		{"", expectedYAML, false}, // default format is YAML
 		{"yaml", expectedYAML, false},
 		{"json", expectedJSON, false},
+		{"tabular", expectedTabular, false},
 		{"", expectedShortYAML, true}, // default format is YAML
 		{"yaml", expectedShortYAML, true},
 		{"json", expectedShortJSON, true},
+		{"tabular", expectedShortTabular, true},
 	} {
 		c.Logf("test #%d: format %q, short %v", i, test.format, test.short)
 		assertOutput(test.format, test.expected, test.short)
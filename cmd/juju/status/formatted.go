@@ -15,6 +15,8 @@ type formattedStatus struct {
 	ModelStatus *modelStatus             `json:"model-status,omitempty" yaml:"model-status,omitempty"`
 	Machines    map[string]machineStatus `json:"machines"`
 	Services    map[string]serviceStatus `json:"services"`
+
+	RemoteServices map[string]remoteServiceStatus `json:"remote-services,omitempty" yaml:"remote-services,omitempty"`
 }
 
 type formattedMachineStatus struct {
@@ -27,7 +29,8 @@ type errorStatus struct {
 }
 
 type modelStatus struct {
-	AvailableVersion string `json:"upgrade-available,omitempty" yaml:"upgrade-available,omitempty"`
+	AvailableVersion    string   `json:"upgrade-available,omitempty" yaml:"upgrade-available,omitempty"`
+	CertificateWarnings []string `json:"certificate-warnings,omitempty" yaml:"certificate-warnings,omitempty"`
 }
 
 type machineStatus struct {
@@ -69,6 +72,7 @@ type serviceStatus struct {
 	Life          string                `json:"life,omitempty" yaml:"life,omitempty"`
 	StatusInfo    statusInfoContents    `json:"service-status,omitempty" yaml:"service-status"`
 	Relations     map[string][]string   `json:"relations,omitempty" yaml:"relations,omitempty"`
+	Suspended     map[string][]string   `json:"suspended-relations,omitempty" yaml:"suspended-relations,omitempty"`
 	SubordinateTo []string              `json:"subordinate-to,omitempty" yaml:"subordinate-to,omitempty"`
 	Units         map[string]unitStatus `json:"units,omitempty" yaml:"units,omitempty"`
 }
@@ -89,6 +93,35 @@ func (s serviceStatus) MarshalYAML() (interface{}, error) {
 	return serviceStatusNoMarshal(s), nil
 }
 
+type remoteServiceStatus struct {
+	Err       error                     `json:"-" yaml:",omitempty"`
+	URL       string                    `json:"url,omitempty" yaml:"url,omitempty"`
+	Endpoints map[string]remoteEndpoint `json:"endpoints" yaml:"endpoints"`
+	Life      string                    `json:"life,omitempty" yaml:"life,omitempty"`
+	Relations map[string][]string       `json:"relations,omitempty" yaml:"relations,omitempty"`
+}
+
+type remoteEndpoint struct {
+	Interface string `json:"interface" yaml:"interface"`
+	Role      string `json:"role" yaml:"role"`
+}
+
+type remoteServiceStatusNoMarshal remoteServiceStatus
+
+func (s remoteServiceStatus) MarshalJSON() ([]byte, error) {
+	if s.Err != nil {
+		return json.Marshal(errorStatus{s.Err.Error()})
+	}
+	return json.Marshal(remoteServiceStatusNoMarshal(s))
+}
+
+func (s remoteServiceStatus) MarshalYAML() (interface{}, error) {
+	if s.Err != nil {
+		return errorStatus{s.Err.Error()}, nil
+	}
+	return remoteServiceStatusNoMarshal(s), nil
+}
+
 type meterStatus struct {
 	Color   string `json:"color,omitempty" yaml:"color,omitempty"`
 	Message string `json:"message,omitempty" yaml:"message,omitempty"`
@@ -4,6 +4,8 @@
 package status
 
 import (
+	"sort"
+
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/state/multiwatcher"
@@ -39,9 +41,10 @@ func (sf *statusFormatter) format() formattedStatus {
 		Machines: make(map[string]machineStatus),
 		Services: make(map[string]serviceStatus),
 	}
-	if sf.status.AvailableVersion != "" {
+	if sf.status.AvailableVersion != "" || len(sf.status.CertificateWarnings) > 0 {
 		out.ModelStatus = &modelStatus{
-			AvailableVersion: sf.status.AvailableVersion,
+			AvailableVersion:    sf.status.AvailableVersion,
+			CertificateWarnings: sf.status.CertificateWarnings,
 		}
 	}
 
@@ -51,6 +54,12 @@ func (sf *statusFormatter) format() formattedStatus {
 	for sn, s := range sf.status.Services {
 		out.Services[sn] = sf.formatService(sn, s)
 	}
+	if len(sf.status.RemoteServices) > 0 {
+		out.RemoteServices = make(map[string]remoteServiceStatus)
+		for sn, s := range sf.status.RemoteServices {
+			out.RemoteServices[sn] = sf.formatRemoteService(s)
+		}
+	}
 	return out
 }
 
@@ -111,6 +120,7 @@ func (sf *statusFormatter) formatService(name string, service params.ServiceStat
 		Exposed:       service.Exposed,
 		Life:          service.Life,
 		Relations:     service.Relations,
+		Suspended:     sf.suspendedRelations(name),
 		CanUpgradeTo:  service.CanUpgradeTo,
 		SubordinateTo: service.SubordinateTo,
 		Units:         make(map[string]unitStatus),
@@ -127,6 +137,53 @@ func (sf *statusFormatter) formatService(name string, service params.ServiceStat
 	return out
 }
 
+// suspendedRelations returns the services related to the named service
+// through suspended relations, keyed by the service's relation name.
+func (sf *statusFormatter) suspendedRelations(name string) map[string][]string {
+	var out map[string][]string
+	for _, relation := range sf.relations {
+		if !relation.Suspended {
+			continue
+		}
+		for _, ep := range relation.Endpoints {
+			if ep.ServiceName != name {
+				continue
+			}
+			for _, other := range relation.Endpoints {
+				if other.ServiceName == name {
+					continue
+				}
+				if out == nil {
+					out = make(map[string][]string)
+				}
+				out[ep.Name] = append(out[ep.Name], other.ServiceName)
+			}
+		}
+	}
+	for relationName, services := range out {
+		sort.Strings(services)
+		out[relationName] = services
+	}
+	return out
+}
+
+func (sf *statusFormatter) formatRemoteService(service params.RemoteServiceStatus) remoteServiceStatus {
+	out := remoteServiceStatus{
+		Err:       service.Err,
+		URL:       service.URL,
+		Endpoints: make(map[string]remoteEndpoint),
+		Life:      service.Life,
+		Relations: service.Relations,
+	}
+	for _, ep := range service.Endpoints {
+		out.Endpoints[ep.Name] = remoteEndpoint{
+			Interface: ep.Interface,
+			Role:      string(ep.Role),
+		}
+	}
+	return out
+}
+
 func (sf *statusFormatter) getServiceStatusInfo(service params.ServiceStatus) statusInfoContents {
 	info := statusInfoContents{
 		Err:     service.Status.Err,
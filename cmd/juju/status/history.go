@@ -4,9 +4,11 @@
 package status
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -47,6 +49,10 @@ The statuses are available for the following types.
     container: will show statuses for containers.
  and sorted by time of occurrence.
  The default is unit.
+
+With --format yaml or json, a list of entries is written, each with
+the keys "since" (an RFC3339 timestamp), "type", "status", "message"
+and, if the status has any, "data".
 `
 
 func (c *statusHistoryCommand) Info() *cmd.Info {
@@ -62,6 +68,17 @@ func (c *statusHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.outputContent, "type", "unit", "type of statuses to be displayed [agent|workload|combined|machine|machineInstance|container|containerinstance].")
 	f.IntVar(&c.backlogSize, "n", 20, "size of logs backlog.")
 	f.BoolVar(&c.isoTime, "utc", false, "display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", common.Formatters(c.formatTabular))
+}
+
+// statusHistoryEntry is the machine-readable form of a status
+// history entry.
+type statusHistoryEntry struct {
+	Since   *time.Time             `yaml:"since" json:"since"`
+	Kind    string                 `yaml:"type" json:"type"`
+	Status  string                 `yaml:"status" json:"status"`
+	Message string                 `yaml:"message" json:"message"`
+	Data    map[string]interface{} `yaml:"data,omitempty" json:"data,omitempty"`
 }
 
 func (c *statusHistoryCommand) Init(args []string) error {
@@ -94,8 +111,18 @@ func (c *statusHistoryCommand) Init(args []string) error {
 	return errors.Errorf("unexpected status type %q", c.outputContent)
 }
 
+// statusHistoryAPI is the API used by the status-history command.
+type statusHistoryAPI interface {
+	StatusHistory(kind params.HistoryKind, name string, size int) (*params.StatusHistoryResults, error)
+	Close() error
+}
+
+var newApiClientForStatusHistory = func(c *statusHistoryCommand) (statusHistoryAPI, error) {
+	return c.NewAPIClient()
+}
+
 func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
-	apiclient, err := c.NewAPIClient()
+	apiclient, err := newApiClientForStatusHistory(c)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -112,10 +139,28 @@ func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
 	} else if len(statuses.Statuses) == 0 {
 		return errors.Errorf("no status history available")
 	}
+	entries := make([]statusHistoryEntry, len(statuses.Statuses))
+	for i, v := range statuses.Statuses {
+		entries[i] = statusHistoryEntry{
+			Since:   v.Since,
+			Kind:    string(v.Kind),
+			Status:  string(v.Status),
+			Message: v.Info,
+			Data:    v.Data,
+		}
+	}
+	return c.out.Write(ctx, entries)
+}
+
+func (c *statusHistoryCommand) formatTabular(value interface{}) ([]byte, error) {
+	entries, ok := value.([]statusHistoryEntry)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
 	table := [][]string{{"TIME", "TYPE", "STATUS", "MESSAGE"}}
 	lengths := []int{1, 1, 1, 1}
-	for _, v := range statuses.Statuses {
-		fields := []string{common.FormatTime(v.Since, c.isoTime), string(v.Kind), string(v.Status), v.Info}
+	for _, v := range entries {
+		fields := []string{common.FormatTime(v.Since, c.isoTime), v.Kind, v.Status, v.Message}
 		for k, v := range fields {
 			if len(v) > lengths[k] {
 				lengths[k] = len(v)
@@ -123,9 +168,10 @@ func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
 		}
 		table = append(table, fields)
 	}
+	var out bytes.Buffer
 	f := fmt.Sprintf("%%-%ds\t%%-%ds\t%%-%ds\t%%-%ds\n", lengths[0], lengths[1], lengths[2], lengths[3])
 	for _, v := range table {
-		fmt.Printf(f, v[0], v[1], v[2], v[3])
+		fmt.Fprintf(&out, f, v[0], v[1], v[2], v[3])
 	}
-	return nil
+	return out.Bytes(), nil
 }
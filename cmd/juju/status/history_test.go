@@ -0,0 +1,97 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/cmd"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type fakeStatusHistoryAPI struct {
+	kind    params.HistoryKind
+	name    string
+	size    int
+	results *params.StatusHistoryResults
+}
+
+func (a *fakeStatusHistoryAPI) StatusHistory(kind params.HistoryKind, name string, size int) (*params.StatusHistoryResults, error) {
+	a.kind, a.name, a.size = kind, name, size
+	return a.results, nil
+}
+
+func (a *fakeStatusHistoryAPI) Close() error {
+	return nil
+}
+
+func (s *StatusSuite) runStatusHistory(c *gc.C, args ...string) (code int, stdout, stderr string, api *fakeStatusHistoryAPI) {
+	since := time.Date(2016, 5, 4, 3, 2, 1, 0, time.UTC)
+	api = &fakeStatusHistoryAPI{
+		results: &params.StatusHistoryResults{
+			Statuses: []params.DetailedStatus{{
+				Status: status.StatusMaintenance,
+				Info:   "installing",
+				Data:   map[string]interface{}{"progress": "50%"},
+				Since:  &since,
+				Kind:   params.KindWorkload,
+			}, {
+				Status: status.StatusIdle,
+				Since:  &since,
+				Kind:   params.KindUnitAgent,
+			}},
+		},
+	}
+	s.PatchValue(&newApiClientForStatusHistory, func(*statusHistoryCommand) (statusHistoryAPI, error) {
+		return api, nil
+	})
+	ctx := coretesting.Context(c)
+	code = cmd.Main(NewStatusHistoryCommand(), ctx, args)
+	stdout = ctx.Stdout.(*bytes.Buffer).String()
+	stderr = ctx.Stderr.(*bytes.Buffer).String()
+	return code, stdout, stderr, api
+}
+
+func (s *StatusSuite) TestStatusHistoryTabular(c *gc.C) {
+	code, stdout, stderr, api := s.runStatusHistory(c, "--utc", "-n", "5", "mysql/0")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("stderr: %s", stderr))
+	c.Check(api.kind, gc.Equals, params.KindUnit)
+	c.Check(api.name, gc.Equals, "mysql/0")
+	c.Check(api.size, gc.Equals, 5)
+	c.Check(stdout, gc.Equals, ""+
+		"TIME                \tTYPE     \tSTATUS     \tMESSAGE   \n"+
+		"2016-05-04 03:02:01Z\tworkload \tmaintenance\tinstalling\n"+
+		"2016-05-04 03:02:01Z\tjuju-unit\tidle       \t          \n")
+}
+
+func (s *StatusSuite) TestStatusHistoryYAML(c *gc.C) {
+	code, stdout, stderr, _ := s.runStatusHistory(c, "--format", "yaml", "mysql/0")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("stderr: %s", stderr))
+	c.Check(stdout, gc.Equals, `
+- since: 2016-05-04T03:02:01Z
+  type: workload
+  status: maintenance
+  message: installing
+  data:
+    progress: 50%
+- since: 2016-05-04T03:02:01Z
+  type: juju-unit
+  status: idle
+  message: ""
+`[1:])
+}
+
+func (s *StatusSuite) TestStatusHistoryJSON(c *gc.C) {
+	code, stdout, stderr, _ := s.runStatusHistory(c, "--format", "json", "mysql/0")
+	c.Assert(code, gc.Equals, 0, gc.Commentf("stderr: %s", stderr))
+	c.Check(stdout, gc.Equals, `[`+
+		`{"since":"2016-05-04T03:02:01Z","type":"workload","status":"maintenance","message":"installing","data":{"progress":"50%"}},`+
+		`{"since":"2016-05-04T03:02:01Z","type":"juju-unit","status":"idle","message":""}`+
+		`]`+"\n")
+}
@@ -25,6 +25,7 @@ type statusRelation struct {
 	service2    string
 	relation    string
 	subordinate bool
+	suspended   bool
 }
 
 func (s *statusRelation) relationType() string {
@@ -32,6 +33,8 @@ func (s *statusRelation) relationType() string {
 		return "subordinate"
 	} else if s.service1 == s.service2 {
 		return "peer"
+	} else if s.suspended {
+		return "suspended"
 	}
 	return "regular"
 }
@@ -52,7 +55,7 @@ func (r *relationFormatter) len() int {
 	return r.relationIndex.Size()
 }
 
-func (r *relationFormatter) add(rel1, rel2, relation string, is2SubOf1 bool) {
+func (r *relationFormatter) add(rel1, rel2, relation string, is2SubOf1, suspended bool) {
 	rel := []string{rel1, rel2}
 	if !is2SubOf1 {
 		sort.Sort(sort.StringSlice(rel))
@@ -63,6 +66,7 @@ func (r *relationFormatter) add(rel1, rel2, relation string, is2SubOf1 bool) {
 		service2:    rel[1],
 		relation:    relation,
 		subordinate: is2SubOf1,
+		suspended:   suspended,
 	}
 	r.relationIndex.Add(k)
 }
@@ -99,6 +103,12 @@ func FormatTabular(value interface{}) ([]byte, error) {
 			p("UPGRADE-AVAILABLE")
 			p(envStatus.AvailableVersion)
 		}
+		if len(envStatus.CertificateWarnings) > 0 {
+			p("CERTIFICATE-WARNINGS")
+			for _, warning := range envStatus.CertificateWarnings {
+				p(warning)
+			}
+		}
 		p()
 		tw.Flush()
 	}
@@ -120,12 +130,22 @@ func FormatTabular(value interface{}) ([]byte, error) {
 		subs := set.NewStrings(svc.SubordinateTo...)
 		p(svcName, svc.StatusInfo.Current, fmt.Sprintf("%t", svc.Exposed), svc.Charm)
 		for relType, relatedUnits := range svc.Relations {
+			suspended := set.NewStrings(svc.Suspended[relType]...)
 			for _, related := range relatedUnits {
-				relations.add(related, svcName, relType, subs.Contains(related))
+				relations.add(related, svcName, relType, subs.Contains(related), suspended.Contains(related))
 			}
 		}
 
 	}
+	if len(fs.RemoteServices) > 0 {
+		p()
+		p("[Remote services]")
+		p("NAME\tURL\tENDPOINTS")
+		for _, name := range common.SortStringsNaturally(stringKeysFromMap(fs.RemoteServices)) {
+			svc := fs.RemoteServices[name]
+			p(name, svc.URL, strings.Join(common.SortStringsNaturally(stringKeysFromMap(svc.Endpoints)), ","))
+		}
+	}
 	if relations.len() > 0 {
 		p()
 		p("[Relations]")
@@ -36,6 +36,7 @@ type statusCommand struct {
 	out      cmd.Output
 	patterns []string
 	isoTime  bool
+	watch    bool
 	api      statusAPI
 }
 
@@ -67,10 +68,17 @@ Explanation of the different formats:
 - yaml: Displays information on machines, services, and units in yaml format.
 Note: AZ above is the cloud region's availability zone.
 
+With --watch, the tabular status is redrawn whenever the model changes,
+and lines that changed since the previous update are highlighted. Press
+Ctrl-C to stop watching. --watch cannot be combined with other formats.
+Each redraw fetches the full status from the controller, at most once a
+second; changes made in between are shown together in the next redraw.
+
 Examples:
     juju status
     juju status mysql
     juju status nova-*
+    juju status --watch
 `
 
 func (c *statusCommand) Info() *cmd.Info {
@@ -85,6 +93,7 @@ func (c *statusCommand) Info() *cmd.Info {
 
 func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	f.BoolVar(&c.watch, "watch", false, "Redraw the tabular status whenever the model changes")
 
 	defaultFormat := "tabular"
 
@@ -101,6 +110,9 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 func (c *statusCommand) Init(args []string) error {
 	c.patterns = args
+	if c.watch && c.out.Name() != "tabular" {
+		return errors.Errorf("--watch is only supported with tabular format")
+	}
 	// If use of ISO time not specified on command line,
 	// check env var.
 	if !c.isoTime {
@@ -126,6 +138,10 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
+	if c.watch {
+		return c.watchStatus(ctx, apiclient)
+	}
+
 	status, err := apiclient.Status(c.patterns)
 	if err != nil {
 		if status == nil {
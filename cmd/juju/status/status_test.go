@@ -3241,6 +3241,32 @@ ID         STATE DNS INS-ID SERIES AZ
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatTabularCertificateWarnings(c *gc.C) {
+	status := formattedStatus{
+		ModelStatus: &modelStatus{
+			CertificateWarnings: []string{
+				"controller CA certificate expires at 2016-05-01T12:00:00Z",
+			},
+		},
+	}
+	out, err := FormatTabular(status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, `
+[Model]                                                   
+CERTIFICATE-WARNINGS                                      
+controller CA certificate expires at 2016-05-01T12:00:00Z 
+
+[Services] 
+NAME       STATUS EXPOSED CHARM 
+
+[Units] 
+ID      WORKLOAD-STATUS JUJU-STATUS VERSION MACHINE PORTS PUBLIC-ADDRESS MESSAGE 
+
+[Machines] 
+ID         STATE DNS INS-ID SERIES AZ 
+`[1:])
+}
+
 func (s *StatusSuite) TestStatusWithNilStatusApi(c *gc.C) {
 	ctx := s.newContext(c)
 	defer s.resetContext(c, ctx)
@@ -3312,6 +3338,50 @@ ID         STATE DNS INS-ID SERIES AZ
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatSuspendedRelations(c *gc.C) {
+	fullStatus := &params.FullStatus{
+		Services: map[string]params.ServiceStatus{
+			"mysql": {
+				Relations: map[string][]string{"server": {"wordpress"}},
+			},
+			"wordpress": {
+				Relations: map[string][]string{"db": {"mysql"}},
+			},
+		},
+		Relations: []params.RelationStatus{{
+			Id:  0,
+			Key: "wordpress:db mysql:server",
+			Endpoints: []params.EndpointStatus{
+				{ServiceName: "wordpress", Name: "db", Role: "requirer"},
+				{ServiceName: "mysql", Name: "server", Role: "provider"},
+			},
+			Suspended: true,
+		}},
+	}
+	formatted := NewStatusFormatter(fullStatus, false).format()
+	c.Assert(formatted.Services["mysql"].Suspended, jc.DeepEquals, map[string][]string{"server": {"wordpress"}})
+	c.Assert(formatted.Services["wordpress"].Suspended, jc.DeepEquals, map[string][]string{"db": {"mysql"}})
+
+	out, err := FormatTabular(formatted)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, `
+[Services] 
+NAME       STATUS EXPOSED CHARM 
+mysql             false         
+wordpress         false         
+
+[Relations] 
+SERVICE1    SERVICE2  RELATION TYPE      
+mysql       wordpress db       suspended 
+
+[Units] 
+ID      WORKLOAD-STATUS JUJU-STATUS VERSION MACHINE PORTS PUBLIC-ADDRESS MESSAGE 
+
+[Machines] 
+ID         STATE DNS INS-ID SERIES AZ 
+`[1:])
+}
+
 //
 // Filtering Feature
 //
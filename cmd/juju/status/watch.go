@@ -0,0 +1,161 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+const (
+	// clearScreen moves the cursor to the top left of the terminal
+	// and clears it, so each frame replaces the previous one.
+	clearScreen = "\x1b[H\x1b[2J"
+
+	// highlightStart and highlightEnd surround lines that changed
+	// since the previous frame, rendering them in reverse video.
+	highlightStart = "\x1b[7m"
+	highlightEnd   = "\x1b[0m"
+)
+
+// watchRefreshInterval is the shortest time between refreshes of the
+// status, so that a busy model does not have the controller computing
+// status continuously. Changes made in the meantime are coalesced into
+// a single refresh.
+var watchRefreshInterval = time.Second
+
+// allWatcher is the part of *api.AllWatcher used by status --watch.
+type allWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+var newAllWatcherForStatus = func(apiclient statusAPI) (allWatcher, error) {
+	client, ok := apiclient.(*api.Client)
+	if !ok {
+		return nil, errors.NotSupportedf("watching status")
+	}
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return watcher, nil
+}
+
+// watchStatus renders the tabular status each time the model changes, until
+// the watcher fails or the command is interrupted. Rather than polling,
+// it waits for deltas from the model's AllWatcher, so the controller
+// is only asked for status when something has changed, and at most
+// once per watchRefreshInterval.
+//
+// The deltas are used only as a signal: each refresh fetches the full
+// status again rather than applying them to the previous one. The full
+// status includes much that the deltas do not carry, such as available
+// upgrades, meter statuses, remote services and certificate warnings,
+// and derives service status from unit status on the controller; the
+// cost is one Status call, for the whole model, per refresh.
+func (c *statusCommand) watchStatus(ctx *cmd.Context, apiclient statusAPI) error {
+	watcher, err := newAllWatcherForStatus(apiclient)
+	if err != nil {
+		return errors.Annotate(err, "cannot watch status")
+	}
+	defer watcher.Stop()
+	done := make(chan struct{})
+	defer close(done)
+	changes := watchChanges(watcher, done)
+
+	var previous []string
+	var refreshed time.Time
+	for {
+		// The first call returns deltas describing the entire model,
+		// so the initial frame is rendered immediately.
+		if err := <-changes; err != nil {
+			return errors.Annotate(err, "watching status")
+		}
+		if wait := watchRefreshInterval - time.Since(refreshed); wait > 0 {
+			if err := coalesceChanges(changes, time.After(wait)); err != nil {
+				return errors.Annotate(err, "watching status")
+			}
+		}
+		refreshed = time.Now()
+		status, err := apiclient.Status(c.patterns)
+		if err != nil {
+			if status == nil {
+				return errors.Trace(err)
+			}
+			fmt.Fprintf(ctx.Stderr, "%v\n", err)
+		} else if status == nil {
+			return errors.Errorf("unable to obtain the current status")
+		}
+		formatted, err := FormatTabular(NewStatusFormatter(status, c.isoTime).format())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		lines := strings.Split(strings.TrimRight(string(formatted), "\n"), "\n")
+		writeFrame(ctx.Stdout, lines, previous)
+		previous = lines
+	}
+}
+
+// watchChanges calls Next on the watcher until it fails or done is
+// closed, sending nil on the returned channel for each set of deltas,
+// and finally the error that stopped the watcher.
+func watchChanges(watcher allWatcher, done <-chan struct{}) <-chan error {
+	changes := make(chan error)
+	go func() {
+		for {
+			_, err := watcher.Next()
+			select {
+			case changes <- err:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return changes
+}
+
+// coalesceChanges discards changes until the refresh is due, returning
+// early only if the watcher fails.
+func coalesceChanges(changes <-chan error, due <-chan time.Time) error {
+	for {
+		select {
+		case err := <-changes:
+			if err != nil {
+				return err
+			}
+		case <-due:
+			return nil
+		}
+	}
+}
+
+// writeFrame clears the terminal and writes the given lines,
+// highlighting each non-empty line that does not appear in the
+// previous frame. Nothing is highlighted in the first frame.
+func writeFrame(w io.Writer, lines, previous []string) {
+	seen := make(map[string]bool, len(previous))
+	for _, line := range previous {
+		seen[line] = true
+	}
+	fmt.Fprint(w, clearScreen)
+	for _, line := range lines {
+		if previous != nil && !seen[line] && strings.TrimSpace(line) != "" {
+			fmt.Fprintln(w, highlightStart+line+highlightEnd)
+		} else {
+			fmt.Fprintln(w, line)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state/multiwatcher"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type WatchSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&WatchSuite{})
+
+func (s *WatchSuite) TestWriteFrameFirstFrameNotHighlighted(c *gc.C) {
+	var buf bytes.Buffer
+	writeFrame(&buf, []string{"a", "b"}, nil)
+	c.Assert(buf.String(), gc.Equals, clearScreen+"a\nb\n")
+}
+
+func (s *WatchSuite) TestWriteFrameHighlightsChangedLines(c *gc.C) {
+	var buf bytes.Buffer
+	writeFrame(&buf, []string{"a", "", "c"}, []string{"a", "", "b"})
+	c.Assert(buf.String(), gc.Equals, clearScreen+"a\n\n"+highlightStart+"c"+highlightEnd+"\n")
+}
+
+// fakeStatusSequence returns each of returns in turn, closing last
+// (if set) when it returns the last of them.
+type fakeStatusSequence struct {
+	fakeApiClient
+	returns []*params.FullStatus
+	last    chan struct{}
+}
+
+func (a *fakeStatusSequence) Status(patterns []string) (*params.FullStatus, error) {
+	a.patternsUsed = patterns
+	status := a.returns[0]
+	a.returns = a.returns[1:]
+	if len(a.returns) == 0 && a.last != nil {
+		close(a.last)
+	}
+	return status, nil
+}
+
+// fakeAllWatcher returns remaining sets of deltas, then fails once
+// unblock (if set) is closed.
+type fakeAllWatcher struct {
+	remaining int
+	stopped   bool
+	unblock   chan struct{}
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	if w.remaining == 0 {
+		if w.unblock != nil {
+			<-w.unblock
+		}
+		return nil, errors.New("watcher was stopped")
+	}
+	w.remaining--
+	return []multiwatcher.Delta{{}}, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
+
+func machineStatus(dnsName string) *params.FullStatus {
+	return &params.FullStatus{
+		Machines: map[string]params.MachineStatus{
+			"0": {
+				Id:         "0",
+				DNSName:    dnsName,
+				InstanceId: instance.Id("i-0"),
+				Series:     "trusty",
+			},
+		},
+	}
+}
+
+func (s *StatusSuite) TestStatusWatchRequiresTabular(c *gc.C) {
+	code, _, stderr := runStatus(c, "--watch", "--format", "yaml")
+	c.Check(code, gc.Equals, 2)
+	c.Check(string(stderr), gc.Equals, "error: --watch is only supported with tabular format\n")
+}
+
+func (s *StatusSuite) TestStatusWatch(c *gc.C) {
+	s.PatchValue(&watchRefreshInterval, time.Duration(0))
+	client := &fakeStatusSequence{
+		returns: []*params.FullStatus{
+			machineStatus("10.0.0.1"),
+			machineStatus("10.0.0.2"),
+		},
+	}
+	watcher := &fakeAllWatcher{remaining: 2}
+	s.PatchValue(&newApiClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+	s.PatchValue(&newAllWatcherForStatus, func(statusAPI) (allWatcher, error) {
+		return watcher, nil
+	})
+
+	code, stdout, stderr := runStatus(c, "--watch", "mysql")
+	c.Check(code, gc.Equals, 1)
+	c.Check(string(stderr), gc.Equals, "error: watching status: watcher was stopped\n")
+	c.Check(watcher.stopped, jc.IsTrue)
+	c.Check(client.patternsUsed, jc.DeepEquals, []string{"mysql"})
+
+	frames := strings.Split(string(stdout), clearScreen)
+	c.Assert(frames, gc.HasLen, 3)
+	c.Check(frames[0], gc.Equals, "")
+	c.Check(strings.Contains(frames[1], highlightStart), jc.IsFalse)
+	c.Check(strings.Contains(frames[1], "10.0.0.1"), jc.IsTrue)
+
+	// Only the machine row changed, so only it is highlighted.
+	c.Check(strings.Count(frames[2], highlightStart), gc.Equals, 1)
+	for _, line := range strings.Split(frames[2], "\n") {
+		if strings.Contains(line, "10.0.0.2") {
+			c.Check(strings.HasPrefix(line, highlightStart), jc.IsTrue)
+			c.Check(strings.HasSuffix(line, highlightEnd), jc.IsTrue)
+		}
+	}
+}
+
+func (s *StatusSuite) TestStatusWatchCoalescesChanges(c *gc.C) {
+	s.PatchValue(&watchRefreshInterval, coretesting.ShortWait)
+	client := &fakeStatusSequence{
+		returns: []*params.FullStatus{
+			machineStatus("10.0.0.1"),
+			machineStatus("10.0.0.2"),
+		},
+		last: make(chan struct{}),
+	}
+	watcher := &fakeAllWatcher{remaining: 3, unblock: client.last}
+	s.PatchValue(&newApiClientForStatus, func(_ *statusCommand) (statusAPI, error) {
+		return client, nil
+	})
+	s.PatchValue(&newAllWatcherForStatus, func(statusAPI) (allWatcher, error) {
+		return watcher, nil
+	})
+
+	code, stdout, stderr := runStatus(c, "--watch")
+	c.Check(code, gc.Equals, 1)
+	c.Check(string(stderr), gc.Equals, "error: watching status: watcher was stopped\n")
+
+	// The second and third sets of deltas arrive before the status
+	// may be refreshed again, so they cause a single refresh.
+	frames := strings.Split(string(stdout), clearScreen)
+	c.Assert(frames, gc.HasLen, 3)
+	c.Check(strings.Contains(frames[2], "10.0.0.2"), jc.IsTrue)
+}
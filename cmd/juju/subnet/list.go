@@ -4,8 +4,12 @@
 package subnet
 
 import (
+	"bytes"
+	"fmt"
 	"net"
+	"sort"
 	"strings"
+	"text/tabwriter"
 
 	"launchpad.net/gnuflag"
 
@@ -14,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/names"
 
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -60,10 +65,7 @@ func (c *listCommand) Info() *cmd.Info {
 // SetFlags is defined on the cmd.Command interface.
 func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.SubnetCommandBase.SetFlags(f)
-	c.Out.AddFlags(f, "yaml", map[string]cmd.Formatter{
-		"yaml": cmd.FormatYaml,
-		"json": cmd.FormatJson,
-	})
+	c.Out.AddFlags(f, "yaml", common.Formatters(formatListTabular))
 
 	f.StringVar(&c.SpaceName, "space", "", "filter results by space name")
 	f.StringVar(&c.ZoneName, "zone", "", "filter results by zone name")
@@ -172,3 +174,27 @@ type formattedSubnet struct {
 	Space      string   `json:"space" yaml:"space"`
 	Zones      []string `json:"zones" yaml:"zones"`
 }
+
+// formatListTabular returns a tabular summary of subnets, sorted by
+// CIDR.
+func formatListTabular(value interface{}) ([]byte, error) {
+	list, ok := value.(formattedList)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", list, value)
+	}
+	cidrs := make([]string, 0, len(list.Subnets))
+	for cidr := range list.Subnets {
+		cidrs = append(cidrs, cidr)
+	}
+	sort.Strings(cidrs)
+
+	var out bytes.Buffer
+	tw := tabwriter.NewWriter(&out, 0, 1, 2, ' ', 0)
+	fmt.Fprintln(tw, "SUBNET\tTYPE\tSPACE\tSTATUS\tZONES")
+	for _, cidr := range cidrs {
+		sub := list.Subnets[cidr]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", cidr, sub.Type, sub.Space, sub.Status, strings.Join(sub.Zones, ","))
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -76,6 +76,10 @@ func (s *ListSuite) TestInit(c *gc.C) {
 		about:        "yaml format",
 		args:         s.Strings("--format", "yaml"),
 		expectFormat: "yaml",
+	}, {
+		about:        "tabular format",
+		args:         s.Strings("--format", "tabular"),
+		expectFormat: "tabular",
 	}, {
 		// --output and -o are tested separately in TestOutputFormats.
 		about:        "both --output and -o specified (latter overrides former)",
@@ -149,6 +153,12 @@ subnets:
 		`"space":"dmz",` +
 		`"zones":["zone2"]}}}
 `
+	expectedTabular := `
+SUBNET         TYPE  SPACE    STATUS       ZONES
+10.10.0.0/16   ipv4  vlan-42  terminating  zone1
+10.20.0.0/24   ipv4  public   in-use       zone1,zone2
+2001:db8::/32  ipv6  dmz      terminating  zone2
+`[1:]
 
 	assertAPICalls := func() {
 		// Verify the API calls and reset the recorded calls.
@@ -212,6 +222,7 @@ subnets:
 		{"", expectedYAML}, // default format is YAML
 		{"yaml", expectedYAML},
 		{"json", expectedJSON},
+		{"tabular", expectedTabular},
 	} {
 		c.Logf("test #%d: format %q", i, test.format)
 		assertOutput(test.format, test.expected)
@@ -95,6 +95,7 @@ var (
 	newSingularRunner     = singular.New
 	peergrouperNew        = peergrouper.New
 	newCertificateUpdater = certupdater.NewCertificateUpdater
+	newCertificateRotator = certupdater.NewCertificateRotator
 	newMetadataUpdater    = imagemetadataworker.NewWorker
 	newUpgradeMongoWorker = mongoupgrader.New
 	reportOpenedState     = func(io.Closer) {}
@@ -954,8 +955,12 @@ func (a *MachineAgent) startStateWorkers(st *state.State) (worker.Worker, error)
 					}
 				})
 			}
+			servingInfoGetter := agent.StateServingInfoGetter{a}
 			a.startWorkerAfterUpgrade(runner, "certupdater", func() (worker.Worker, error) {
-				return newCertificateUpdater(m, agentConfig, st, st, stateServingSetter), nil
+				return newCertificateUpdater(m, servingInfoGetter, st, st, stateServingSetter), nil
+			})
+			a.startWorkerAfterUpgrade(runner, "certrotator", func() (worker.Worker, error) {
+				return newCertificateRotator(servingInfoGetter, st, stateServingSetter, clock.WallClock), nil
 			})
 
 			a.startWorkerAfterUpgrade(singularRunner, "dblogpruner", func() (worker.Worker, error) {
@@ -1049,12 +1054,7 @@ func (a *MachineAgent) newApiserverWorker(st *state.State, certChanged chan para
 	dataDir := agentConfig.DataDir()
 	logDir := agentConfig.LogDir()
 
-	endpoint := net.JoinHostPort("", strconv.Itoa(info.APIPort))
-	listener, err := net.Listen("tcp", endpoint)
-	if err != nil {
-		return nil, err
-	}
-	w, err := apiserver.NewServer(st, listener, apiserver.ServerConfig{
+	serverConfig := apiserver.ServerConfig{
 		Cert:        cert,
 		Key:         key,
 		Tag:         tag,
@@ -1062,13 +1062,51 @@ func (a *MachineAgent) newApiserverWorker(st *state.State, certChanged chan para
 		LogDir:      logDir,
 		Validator:   a.limitLogins,
 		CertChanged: certChanged,
-	})
+		Clock:       clock.WallClock,
+	}
+	if err := setAPIServerLimits(agentConfig, &serverConfig); err != nil {
+		return nil, &cmdutil.FatalError{err.Error()}
+	}
+
+	endpoint := net.JoinHostPort("", strconv.Itoa(info.APIPort))
+	listener, err := net.Listen("tcp", endpoint)
+	if err != nil {
+		return nil, err
+	}
+	w, err := apiserver.NewServer(st, listener, serverConfig)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot start api server worker")
 	}
 	return w, nil
 }
 
+// setAPIServerLimits sets the API server's admission control limits
+// from those specified in the agent configuration, if any.
+func setAPIServerLimits(agentConfig agent.Config, cfg *apiserver.ServerConfig) error {
+	if value := agentConfig.Value(agent.APIMaxConnections); value != "" {
+		maxConnections, err := strconv.Atoi(value)
+		if err != nil || maxConnections < 0 {
+			return errors.Errorf("invalid API max connections: %q", value)
+		}
+		cfg.MaxConnections = maxConnections
+	}
+	if value := agentConfig.Value(agent.APIRequestRate); value != "" {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 {
+			return errors.Errorf("invalid API request rate: %q", value)
+		}
+		cfg.RequestRate = rate
+	}
+	if value := agentConfig.Value(agent.APIRequestBurst); value != "" {
+		burst, err := strconv.Atoi(value)
+		if err != nil || burst < 0 {
+			return errors.Errorf("invalid API request burst: %q", value)
+		}
+		cfg.RequestBurst = burst
+	}
+	return nil
+}
+
 // limitLogins is called by the API server for each login attempt.
 // it returns an error if upgrades or restore are running.
 func (a *MachineAgent) limitLogins(req params.LoginRequest) error {
@@ -32,6 +32,7 @@ import (
 	"github.com/juju/juju/worker/migrationminion"
 	"github.com/juju/juju/worker/proxyupdater"
 	"github.com/juju/juju/worker/reboot"
+	"github.com/juju/juju/worker/remoterelations"
 	"github.com/juju/juju/worker/resumer"
 	workerstate "github.com/juju/juju/worker/state"
 	"github.com/juju/juju/worker/stateconfigwatcher"
@@ -39,6 +40,7 @@ import (
 	"github.com/juju/juju/worker/terminationworker"
 	"github.com/juju/juju/worker/toolsversionchecker"
 	"github.com/juju/juju/worker/upgrader"
+	"github.com/juju/juju/worker/upgradeseries"
 	"github.com/juju/juju/worker/upgradesteps"
 	"github.com/juju/utils/clock"
 	"github.com/juju/version"
@@ -397,6 +399,23 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     hostkeyreporter.NewFacade,
 			NewWorker:     hostkeyreporter.NewWorker,
 		})),
+
+		upgradeSeriesName: ifFullyUpgraded(upgradeseries.Manifold(upgradeseries.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			NewFacade:     upgradeseries.NewFacade,
+			NewWorker:     upgradeseries.NewWorker,
+		})),
+
+		// The remoteRelations worker exchanges relation units and
+		// settings between models related through service offers.
+		// It only runs on controllers, as it depends on the state
+		// manifold.
+		remoteRelationsName: ifFullyUpgraded(remoterelations.Manifold(remoterelations.ManifoldConfig{
+			StateName: stateName,
+			Clock:     config.Clock,
+			NewWorker: remoterelations.New,
+		})),
 	}
 }
 
@@ -443,4 +462,6 @@ const (
 	apiConfigWatcherName     = "api-config-watcher"
 	machineActionName        = "machine-action-runner"
 	hostKeyReporterName      = "host-key-reporter"
+	upgradeSeriesName        = "upgrade-series"
+	remoteRelationsName      = "remote-relations"
 )
@@ -57,6 +57,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"migration-minion",
 		"proxy-config-updater",
 		"reboot-executor",
+		"remote-relations",
 		"serving-info-setter",
 		"ssh-authkeys-updater",
 		"ssh-identity-writer",
@@ -70,6 +71,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"unit-agent-deployer",
 		"upgrade-check-flag",
 		"upgrade-check-gate",
+		"upgrade-series",
 		"upgrade-steps-flag",
 		"upgrade-steps-gate",
 		"upgrade-steps-runner",
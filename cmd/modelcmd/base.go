@@ -181,9 +181,10 @@ func (c *JujuCommandBase) setCmdContext(ctx *cmd.Context) {
 }
 
 // apiOpen establishes a connection to the API server using the
-// the give api.Info and api.DialOpts.
+// the give api.Info and api.DialOpts. The connection fails over
+// to another controller if the one it is connected to goes away.
 func (c *JujuCommandBase) apiOpen(info *api.Info, opts api.DialOpts) (api.Connection, error) {
-	return api.Open(info, opts)
+	return api.OpenWithFailover(info, opts)
 }
 
 // WrapBase wraps the specified CommandBase, returning a Command
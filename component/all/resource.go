@@ -64,6 +64,11 @@ func (r resources) registerPublicFacade() {
 		return
 	}
 
+	common.RegisterStandardFacade(
+		resource.ComponentName,
+		1,
+		resourceadapters.NewPublicFacadeV1,
+	)
 	common.RegisterStandardFacade(
 		resource.ComponentName,
 		server.Version,
@@ -43,12 +43,14 @@ type StatusCallback func(settableStatus status.Status, info string, data map[str
 // containers that it has started.
 type Manager interface {
 	// CreateContainer creates and starts a new container for the specified
-	// machine.
+	// machine. The profile, if not nil, holds validated customisations
+	// to apply to the container.
 	CreateContainer(
 		instanceConfig *instancecfg.InstanceConfig,
 		series string,
 		network *NetworkConfig,
 		storage *StorageConfig,
+		profile *instance.ContainerProfile,
 		callback StatusCallback) (instance.Instance, *instance.HardwareCharacteristics, error)
 
 	// DestroyContainer stops and destroyes the container identified by
@@ -56,6 +56,8 @@ func (c *kvmContainer) Start(params StartParams) error {
 		CpuCores:      params.CpuCores,
 		RootDisk:      params.RootDisk,
 		Interfaces:    interfaces,
+		CPU:           params.CPU,
+		ExtraDevices:  params.Devices,
 	}); err != nil {
 		return err
 	}
@@ -17,6 +17,11 @@ type StartParams struct {
 	CpuCores         uint64
 	RootDisk         uint64 // GB
 	ImageDownloadUrl string
+
+	// CPU and Devices hold libvirt domain XML fragments taken from
+	// the container profile.
+	CPU     string
+	Devices []string
 }
 
 // Container represents a virtualized container instance and provides
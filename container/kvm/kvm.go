@@ -106,6 +106,7 @@ func (manager *containerManager) CreateContainer(
 	series string,
 	networkConfig *container.NetworkConfig,
 	storageConfig *container.StorageConfig,
+	profile *instance.ContainerProfile,
 	callback container.StatusCallback,
 ) (_ instance.Instance, _ *instance.HardwareCharacteristics, err error) {
 
@@ -147,6 +148,10 @@ func (manager *containerManager) CreateContainer(
 	startParams.Series = series
 	startParams.Network = networkConfig
 	startParams.UserDataFile = userDataFilename
+	if profile != nil {
+		startParams.CPU = profile.KVMCPU
+		startParams.Devices = profile.KVMDevices
+	}
 
 	// If the Simplestream requested is anything but released, update
 	// our StartParams to request it.
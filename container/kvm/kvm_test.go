@@ -129,6 +129,27 @@ func (s *KVMSuite) TestWriteTemplate(c *gc.C) {
 	c.Assert(strings.Count(string(template), "<interface type='bridge'>"), gc.Equals, 1)
 }
 
+func (s *KVMSuite) TestWriteTemplateWithProfile(c *gc.C) {
+	params := kvm.CreateMachineParams{
+		Hostname:      "foo-bar",
+		NetworkBridge: "br0",
+		CPU:           "<cpu mode='host-passthrough'/>",
+		ExtraDevices:  []string{"<rng model='virtio'/>"},
+	}
+	templatePath := filepath.Join(c.MkDir(), "kvm.xml")
+	err := kvm.WriteTemplate(templatePath, params)
+	c.Assert(err, jc.ErrorIsNil)
+	templateBytes, err := ioutil.ReadFile(templatePath)
+	c.Assert(err, jc.ErrorIsNil)
+
+	template := string(templateBytes)
+
+	c.Assert(template, jc.Contains, "<cpu mode='host-passthrough'/>")
+	c.Assert(template, jc.Contains, "<rng model='virtio'/>")
+	c.Assert(template, jc.Contains, "<source bridge='br0'/>")
+	c.Assert(strings.Count(template, "<interface type='bridge'>"), gc.Equals, 1)
+}
+
 func (s *KVMSuite) TestCreateMachineUsesTemplate(c *gc.C) {
 	const uvtKvmBinName = "uvt-kvm"
 	testing.PatchExecutableAsEchoArgs(c, s, uvtKvmBinName)
@@ -70,6 +70,20 @@ type CreateMachineParams struct {
 	CpuCores      uint64
 	RootDisk      uint64
 	Interfaces    []network.InterfaceInfo
+
+	// CPU and ExtraDevices hold validated libvirt domain XML
+	// fragments from the container profile, if any.
+	CPU          string
+	ExtraDevices []string
+}
+
+// needsTemplate returns whether the machine must be created from a
+// domain template rather than from the uvt-kvm defaults.
+func (params CreateMachineParams) needsTemplate() bool {
+	if params.CPU != "" || len(params.ExtraDevices) != 0 {
+		return true
+	}
+	return params.NetworkBridge != "" && len(params.Interfaces) != 0
 }
 
 // CreateMachine creates a virtual machine and starts it.
@@ -93,20 +107,18 @@ func CreateMachine(params CreateMachineParams) error {
 	if params.RootDisk != 0 {
 		args = append(args, "--disk", fmt.Sprint(params.RootDisk))
 	}
-	if params.NetworkBridge != "" {
-		if len(params.Interfaces) != 0 {
-			templateDir := filepath.Dir(params.UserDataFile)
-
-			templatePath := filepath.Join(templateDir, "kvm-template.xml")
-			err := WriteTemplate(templatePath, params)
-			if err != nil {
-				return errors.Trace(err)
-			}
-
-			args = append(args, "--template", templatePath)
-		} else {
-			args = append(args, "--bridge", params.NetworkBridge)
+	if params.needsTemplate() {
+		templateDir := filepath.Dir(params.UserDataFile)
+
+		templatePath := filepath.Join(templateDir, "kvm-template.xml")
+		err := WriteTemplate(templatePath, params)
+		if err != nil {
+			return errors.Trace(err)
 		}
+
+		args = append(args, "--template", templatePath)
+	} else if params.NetworkBridge != "" {
+		args = append(args, "--bridge", params.NetworkBridge)
 	}
 
 	args = append(args, params.Hostname)
@@ -23,6 +23,7 @@ var kvmTemplate = `
     <apic/>
     <pae/>
   </features>
+  {{if .CPU}}{{.CPU}}{{end}}
   <devices>
     <controller type='usb' index='0'>
       <address type='pci' domain='0x0000' bus='0x00' slot='0x01' function='0x2'/>
@@ -50,6 +51,14 @@ var kvmTemplate = `
       <model type='virtio'/>
       <source bridge='{{$nic.ParentInterfaceName}}'/>
     </interface>
+    {{else}}{{if $bridge}}
+    <interface type='bridge'>
+      <model type='virtio'/>
+      <source bridge='{{$bridge}}'/>
+    </interface>
+    {{end}}{{end}}
+    {{range $device := .ExtraDevices}}
+    {{$device}}
     {{end}}
   </devices>
 </domain>
@@ -167,6 +167,7 @@ func (manager *containerManager) CreateContainer(
 	series string,
 	networkConfig *container.NetworkConfig,
 	storageConfig *container.StorageConfig,
+	profile *instance.ContainerProfile,
 	callback container.StatusCallback,
 ) (inst instance.Instance, _ *instance.HardwareCharacteristics, err error) {
 	// Check our preconditions
@@ -181,6 +182,9 @@ func (manager *containerManager) CreateContainer(
 	} else if callback == nil {
 		panic("status callback is nil")
 	}
+	if profile != nil && !profile.IsEmpty() {
+		return nil, nil, errors.NotSupportedf("container profiles for LXC containers")
+	}
 
 	// Log how long the start took
 	defer func(start time.Time) {
@@ -7,6 +7,7 @@ package lxd
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -79,6 +80,7 @@ func (manager *containerManager) CreateContainer(
 	series string,
 	networkConfig *container.NetworkConfig,
 	storageConfig *container.StorageConfig,
+	profile *instance.ContainerProfile,
 	callback container.StatusCallback,
 ) (inst instance.Instance, _ *instance.HardwareCharacteristics, err error) {
 
@@ -147,13 +149,25 @@ func (manager *containerManager) CreateContainer(
 		networkProfile = "default"
 	}
 
+	profiles := []string{networkProfile}
+	if profile != nil && !profile.IsEmpty() {
+		customProfile := customProfileName(name)
+		if err = createCustomProfile(manager.client, customProfile, profile); err != nil {
+			return
+		}
+		defer func() {
+			if err != nil {
+				manager.client.ProfileDelete(customProfile)
+			}
+		}()
+		profiles = append(profiles, customProfile)
+	}
+
 	spec := lxdclient.InstanceSpec{
 		Name:     name,
 		Image:    manager.client.ImageNameForSeries(series),
 		Metadata: metadata,
-		Profiles: []string{
-			networkProfile,
-		},
+		Profiles: profiles,
 	}
 
 	logger.Infof("starting instance %q (image %q)...", spec.Name, spec.Image)
@@ -178,7 +192,10 @@ func (manager *containerManager) DestroyContainer(id instance.Id) error {
 		}
 	}
 	manager.deleteNetworkProfile()
-	return errors.Trace(manager.client.RemoveInstances(manager.name, string(id)))
+	if err := manager.client.RemoveInstances(manager.name, string(id)); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(deleteCustomProfile(manager.client, customProfileName(string(id))))
 }
 
 func (manager *containerManager) ListContainers() (result []instance.Instance, err error) {
@@ -312,3 +329,53 @@ func (manager *containerManager) deleteNetworkProfile() {
 		manager.networkProfile = ""
 	}
 }
+
+// customProfileName returns the name of the LXD profile holding the
+// container profile customisations of the named container.
+func customProfileName(containerName string) string {
+	return fmt.Sprintf("%s-profile", containerName)
+}
+
+// createCustomProfile creates an LXD profile holding the configuration
+// and devices of the given container profile, replacing any existing
+// profile of the same name.
+func createCustomProfile(client *lxdclient.Client, name string, profile *instance.ContainerProfile) error {
+	if err := profile.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := deleteCustomProfile(client, name); err != nil {
+		return errors.Trace(err)
+	}
+	if err := client.CreateProfile(name, profile.LXDConfig); err != nil {
+		return errors.Annotatef(err, "cannot create container profile %q", name)
+	}
+	for deviceName, device := range profile.LXDDevices {
+		var props []string
+		for key, value := range device {
+			if key == "type" {
+				continue
+			}
+			props = append(props, fmt.Sprintf("%s=%s", key, value))
+		}
+		sort.Strings(props)
+		logger.Infof("adding %s device %q with properties %+v to profile %q", device["type"], deviceName, props, name)
+		if _, err := client.ProfileDeviceAdd(name, deviceName, device["type"], props); err != nil {
+			return errors.Annotatef(err, "cannot add device %q to container profile %q", deviceName, name)
+		}
+	}
+	logger.Infof("created container profile %q", name)
+	return nil
+}
+
+// deleteCustomProfile deletes the named LXD profile, if it exists.
+func deleteCustomProfile(client *lxdclient.Client, name string) error {
+	found, err := client.HasProfile(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !found {
+		return nil
+	}
+	logger.Infof("deleting container profile %q", name)
+	return errors.Trace(client.ProfileDelete(name))
+}
@@ -81,6 +81,7 @@ func (t *LxdSuite) TestNotAllContainersAreDeleted(c *gc.C) {
 		"xenial",
 		networkConfig,
 		storageConfig,
+		nil,
 		callback,
 	)
 	c.Assert(err, jc.ErrorIsNil)
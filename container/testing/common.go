@@ -84,7 +84,7 @@ func CreateContainerWithMachineAndNetworkAndStorageConfig(
 		EnsureLXCRootFSEtcNetwork(c, name)
 	}
 	callback := func(settableStatus status.Status, info string, data map[string]interface{}) error { return nil }
-	inst, hardware, err := manager.CreateContainer(instanceConfig, "quantal", networkConfig, storageConfig, callback)
+	inst, hardware, err := manager.CreateContainer(instanceConfig, "quantal", networkConfig, storageConfig, nil, callback)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(hardware, gc.NotNil)
 	c.Assert(hardware.String(), gc.Not(gc.Equals), "")
@@ -129,7 +129,7 @@ func CreateContainerTest(c *gc.C, manager container.Manager, machineId string) (
 	storage := &container.StorageConfig{}
 
 	callback := func(settableStatus status.Status, info string, data map[string]interface{}) error { return nil }
-	inst, hardware, err := manager.CreateContainer(instanceConfig, "quantal", network, storage, callback)
+	inst, hardware, err := manager.CreateContainer(instanceConfig, "quantal", network, storage, nil, callback)
 
 	if err != nil {
 		return nil, errors.Trace(err)
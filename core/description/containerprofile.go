@@ -0,0 +1,132 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package description
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+)
+
+// ContainerProfileArgs is an argument struct to construct a
+// ContainerProfile.
+type ContainerProfileArgs struct {
+	LXDConfig  map[string]string
+	LXDDevices map[string]map[string]string
+	KVMCPU     string
+	KVMDevices []string
+}
+
+func newContainerProfile(args ContainerProfileArgs) *containerProfile {
+	// If the ContainerProfileArgs are all empty, then we return
+	// nil to indicate that there is no container profile.
+	if args.empty() {
+		return nil
+	}
+	return &containerProfile{
+		Version:     1,
+		LXDConfig_:  args.LXDConfig,
+		LXDDevices_: args.LXDDevices,
+		KVMCPU_:     args.KVMCPU,
+		KVMDevices_: args.KVMDevices,
+	}
+}
+
+type containerProfile struct {
+	Version int `yaml:"version"`
+
+	LXDConfig_  map[string]string            `yaml:"lxd-config,omitempty"`
+	LXDDevices_ map[string]map[string]string `yaml:"lxd-devices,omitempty"`
+	KVMCPU_     string                       `yaml:"kvm-cpu,omitempty"`
+	KVMDevices_ []string                     `yaml:"kvm-devices,omitempty"`
+}
+
+// LXDConfig implements ContainerProfile.
+func (p *containerProfile) LXDConfig() map[string]string {
+	return p.LXDConfig_
+}
+
+// LXDDevices implements ContainerProfile.
+func (p *containerProfile) LXDDevices() map[string]map[string]string {
+	return p.LXDDevices_
+}
+
+// KVMCPU implements ContainerProfile.
+func (p *containerProfile) KVMCPU() string {
+	return p.KVMCPU_
+}
+
+// KVMDevices implements ContainerProfile.
+func (p *containerProfile) KVMDevices() []string {
+	return p.KVMDevices_
+}
+
+func importContainerProfile(source map[string]interface{}) (*containerProfile, error) {
+	version, err := getVersion(source)
+	if err != nil {
+		return nil, errors.Annotate(err, "container profile version schema check failed")
+	}
+
+	importFunc, ok := containerProfileDeserializationFuncs[version]
+	if !ok {
+		return nil, errors.NotValidf("version %d", version)
+	}
+
+	return importFunc(source)
+}
+
+type containerProfileDeserializationFunc func(map[string]interface{}) (*containerProfile, error)
+
+var containerProfileDeserializationFuncs = map[int]containerProfileDeserializationFunc{
+	1: importContainerProfileV1,
+}
+
+func importContainerProfileV1(source map[string]interface{}) (*containerProfile, error) {
+	fields := schema.Fields{
+		"lxd-config":  schema.StringMap(schema.String()),
+		"lxd-devices": schema.StringMap(schema.StringMap(schema.String())),
+		"kvm-cpu":     schema.String(),
+		"kvm-devices": schema.List(schema.String()),
+	}
+	// Some values don't have to be there.
+	defaults := schema.Defaults{
+		"lxd-config":  schema.Omit,
+		"lxd-devices": schema.Omit,
+		"kvm-cpu":     "",
+		"kvm-devices": schema.Omit,
+	}
+	checker := schema.FieldMap(fields, defaults)
+
+	coerced, err := checker.Coerce(source, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "container profile v1 schema check failed")
+	}
+	valid := coerced.(map[string]interface{})
+	// From here we know that the map returned from the schema coercion
+	// contains fields of the right type.
+	result := &containerProfile{
+		Version:     1,
+		LXDConfig_:  convertToStringMap(valid["lxd-config"]),
+		KVMCPU_:     valid["kvm-cpu"].(string),
+		KVMDevices_: convertToStringSlice(valid["kvm-devices"]),
+	}
+	if devices, ok := valid["lxd-devices"]; ok {
+		result.LXDDevices_ = make(map[string]map[string]string)
+		for name, device := range devices.(map[string]interface{}) {
+			result.LXDDevices_[name] = convertToStringMap(device)
+		}
+	}
+	return result, nil
+}
+
+func addContainerProfileSchema(fields schema.Fields, defaults schema.Defaults) {
+	fields["container-profile"] = schema.StringMap(schema.Any())
+	defaults["container-profile"] = schema.Omit
+}
+
+func (a ContainerProfileArgs) empty() bool {
+	return len(a.LXDConfig) == 0 &&
+		len(a.LXDDevices) == 0 &&
+		a.KVMCPU == "" &&
+		len(a.KVMDevices) == 0
+}
@@ -226,6 +226,9 @@ type Service interface {
 
 	MetricsCredentials() []byte
 
+	ContainerProfile() ContainerProfile
+	SetContainerProfile(ContainerProfileArgs)
+
 	Status() Status
 	SetStatus(StatusArgs)
 
@@ -235,6 +238,15 @@ type Service interface {
 	Validate() error
 }
 
+// ContainerProfile represents the customisations applied to the
+// containers hosting the units of a service.
+type ContainerProfile interface {
+	LXDConfig() map[string]string
+	LXDDevices() map[string]map[string]string
+	KVMCPU() string
+	KVMDevices() []string
+}
+
 // Unit represents an instance of a service in a model.
 type Unit interface {
 	HasAnnotations
@@ -252,6 +264,8 @@ type Unit interface {
 	MeterStatusCode() string
 	MeterStatusInfo() string
 
+	CharmState() map[string]string
+
 	// TODO: storage
 
 	Tools() AgentTools
@@ -277,6 +291,9 @@ type Unit interface {
 type Relation interface {
 	Id() int
 	Key() string
+	// Suspended reports whether the relation is suspended, in which
+	// case its units have departed it.
+	Suspended() bool
 
 	Endpoints() []Endpoint
 	AddEndpoint(EndpointArgs) Endpoint
@@ -17,19 +17,22 @@ type relations struct {
 type relation struct {
 	Id_        int        `yaml:"id"`
 	Key_       string     `yaml:"key"`
+	Suspended_ bool       `yaml:"suspended,omitempty"`
 	Endpoints_ *endpoints `yaml:"endpoints"`
 }
 
 // RelationArgs is an argument struct used to specify a relation.
 type RelationArgs struct {
-	Id  int
-	Key string
+	Id        int
+	Key       string
+	Suspended bool
 }
 
 func newRelation(args RelationArgs) *relation {
 	relation := &relation{
-		Id_:  args.Id,
-		Key_: args.Key,
+		Id_:        args.Id,
+		Key_:       args.Key,
+		Suspended_: args.Suspended,
 	}
 	relation.setEndpoints(nil)
 	return relation
@@ -45,6 +48,11 @@ func (r *relation) Key() string {
 	return r.Key_
 }
 
+// Suspended implements Relation.
+func (r *relation) Suspended() bool {
+	return r.Suspended_
+}
+
 // Endpoints implements Relation.
 func (r *relation) Endpoints() []Endpoint {
 	result := make([]Endpoint, len(r.Endpoints_.Endpoints_))
@@ -111,10 +119,14 @@ func importRelationV1(source map[string]interface{}) (*relation, error) {
 	fields := schema.Fields{
 		"id":        schema.Int(),
 		"key":       schema.String(),
+		"suspended": schema.Bool(),
 		"endpoints": schema.StringMap(schema.Any()),
 	}
 
-	checker := schema.FieldMap(fields, nil) // no defaults
+	defaults := schema.Defaults{
+		"suspended": false,
+	}
+	checker := schema.FieldMap(fields, defaults)
 
 	coerced, err := checker.Coerce(source, nil)
 	if err != nil {
@@ -124,8 +136,9 @@ func importRelationV1(source map[string]interface{}) (*relation, error) {
 	// From here we know that the map returned from the schema coercion
 	// contains fields of the right type.
 	result := &relation{
-		Id_:  int(valid["id"].(int64)),
-		Key_: valid["key"].(string),
+		Id_:        int(valid["id"].(int64)),
+		Key_:       valid["key"].(string),
+		Suspended_: valid["suspended"].(bool),
 	}
 
 	endpoints, err := importEndpoints(valid["endpoints"].(map[string]interface{}))
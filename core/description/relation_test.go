@@ -56,9 +56,35 @@ func (s *RelationSerializationSuite) TestNewRelation(c *gc.C) {
 
 	c.Assert(relation.Id(), gc.Equals, 42)
 	c.Assert(relation.Key(), gc.Equals, "special")
+	c.Assert(relation.Suspended(), jc.IsFalse)
 	c.Assert(relation.Endpoints(), gc.HasLen, 0)
 }
 
+func (s *RelationSerializationSuite) TestSuspended(c *gc.C) {
+	relation := newRelation(RelationArgs{
+		Id:        42,
+		Key:       "special",
+		Suspended: true,
+	})
+	c.Assert(relation.Suspended(), jc.IsTrue)
+
+	initial := relations{
+		Version:    1,
+		Relations_: []*relation{relation},
+	}
+	bytes, err := yaml.Marshal(initial)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var source map[string]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+
+	relations, err := importRelations(source)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relations, gc.HasLen, 1)
+	c.Assert(relations[0].Suspended(), jc.IsTrue)
+}
+
 func (s *RelationSerializationSuite) TestRelationEndpoints(c *gc.C) {
 	relation := s.completeRelation()
 
@@ -50,6 +50,8 @@ type service struct {
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
 
+	ContainerProfile_ *containerProfile `yaml:"container-profile,omitempty"`
+
 	// Storage Constraints
 }
 
@@ -232,6 +234,20 @@ func (s *service) SetConstraints(args ConstraintsArgs) {
 	s.Constraints_ = newConstraints(args)
 }
 
+// ContainerProfile implements Service.
+func (s *service) ContainerProfile() ContainerProfile {
+	// To avoid typed nils check nil here.
+	if s.ContainerProfile_ == nil {
+		return nil
+	}
+	return s.ContainerProfile_
+}
+
+// SetContainerProfile implements Service.
+func (s *service) SetContainerProfile(args ContainerProfileArgs) {
+	s.ContainerProfile_ = newContainerProfile(args)
+}
+
 // Validate implements Service.
 func (s *service) Validate() error {
 	if s.Name_ == "" {
@@ -327,6 +343,7 @@ func importServiceV1(source map[string]interface{}) (*service, error) {
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
+	addContainerProfileSchema(fields, defaults)
 	addStatusHistorySchema(fields)
 	checker := schema.FieldMap(fields, defaults)
 
@@ -366,6 +383,14 @@ func importServiceV1(source map[string]interface{}) (*service, error) {
 		result.Constraints_ = constraints
 	}
 
+	if profileMap, ok := valid["container-profile"]; ok {
+		profile, err := importContainerProfile(profileMap.(map[string]interface{}))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.ContainerProfile_ = profile
+	}
+
 	encodedCreds := valid["metrics-creds"].(string)
 	// The model stores the creds encoded, but we want to make sure that
 	// we are storing something that can be decoded.
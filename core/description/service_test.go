@@ -205,6 +205,31 @@ func (s *ServiceSerializationSuite) TestConstraints(c *gc.C) {
 	c.Assert(service.Constraints(), jc.DeepEquals, newConstraints(args))
 }
 
+func (s *ServiceSerializationSuite) TestContainerProfile(c *gc.C) {
+	initial := minimalService()
+	args := ContainerProfileArgs{
+		LXDConfig: map[string]string{"security.nesting": "true"},
+		LXDDevices: map[string]map[string]string{
+			"kvm": {"type": "unix-char", "path": "/dev/kvm"},
+		},
+		KVMCPU:     "<cpu mode='host-passthrough'/>",
+		KVMDevices: []string{"<watchdog model='i6300esb'/>"},
+	}
+	initial.SetContainerProfile(args)
+
+	service := s.exportImport(c, initial)
+	c.Assert(service.ContainerProfile(), jc.DeepEquals, newContainerProfile(args))
+}
+
+func (s *ServiceSerializationSuite) TestNoContainerProfile(c *gc.C) {
+	initial := minimalService()
+	initial.SetContainerProfile(ContainerProfileArgs{})
+	c.Assert(initial.ContainerProfile(), gc.IsNil)
+
+	service := s.exportImport(c, initial)
+	c.Assert(service.ContainerProfile(), gc.IsNil)
+}
+
 func (s *ServiceSerializationSuite) TestLeaderValid(c *gc.C) {
 	args := minimalServiceArgs()
 	args.Leader = "ubuntu/1"
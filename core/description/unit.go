@@ -38,6 +38,8 @@ type unit struct {
 	MeterStatusCode_ string `yaml:"meter-status-code,omitempty"`
 	MeterStatusInfo_ string `yaml:"meter-status-info,omitempty"`
 
+	CharmState_ map[string]string `yaml:"charm-state,omitempty"`
+
 	Annotations_ `yaml:"annotations,omitempty"`
 
 	Constraints_ *constraints `yaml:"constraints,omitempty"`
@@ -54,6 +56,8 @@ type UnitArgs struct {
 	MeterStatusCode string
 	MeterStatusInfo string
 
+	CharmState map[string]string
+
 	// TODO: storage attachment count
 }
 
@@ -70,6 +74,7 @@ func newUnit(args UnitArgs) *unit {
 		Subordinates_:          subordinates,
 		MeterStatusCode_:       args.MeterStatusCode,
 		MeterStatusInfo_:       args.MeterStatusInfo,
+		CharmState_:            args.CharmState,
 		WorkloadStatusHistory_: newStatusHistory(),
 		AgentStatusHistory_:    newStatusHistory(),
 	}
@@ -122,6 +127,11 @@ func (u *unit) MeterStatusInfo() string {
 	return u.MeterStatusInfo_
 }
 
+// CharmState implements Unit.
+func (u *unit) CharmState() map[string]string {
+	return u.CharmState_
+}
+
 // Tools implements Unit.
 func (u *unit) Tools() AgentTools {
 	// To avoid a typed nil, check before returning.
@@ -271,12 +281,15 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 
 		"meter-status-code": schema.String(),
 		"meter-status-info": schema.String(),
+
+		"charm-state": schema.StringMap(schema.String()),
 	}
 	defaults := schema.Defaults{
 		"principal":         "",
 		"subordinates":      schema.Omit,
 		"meter-status-code": "",
 		"meter-status-info": "",
+		"charm-state":       schema.Omit,
 	}
 	addAnnotationSchema(fields, defaults)
 	addConstraintsSchema(fields, defaults)
@@ -321,6 +334,10 @@ func importUnitV1(source map[string]interface{}) (*unit, error) {
 
 	result.Subordinates_ = convertToStringSlice(valid["subordinates"])
 
+	if charmState, ok := valid["charm-state"]; ok {
+		result.CharmState_ = convertToStringMap(charmState)
+	}
+
 	// Tools and status are required, so we expect them to be there.
 	tools, err := importAgentTools(valid["tools"].(map[string]interface{}))
 	if err != nil {
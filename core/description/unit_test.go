@@ -72,6 +72,7 @@ func (s *UnitSerializationSuite) completeUnit() *unit {
 		},
 		MeterStatusCode: "meter code",
 		MeterStatusInfo: "meter info",
+		CharmState:      map[string]string{"foo": "bar"},
 	}
 	unit := newUnit(args)
 	unit.SetAgentStatus(minimalStatusArgs())
@@ -94,6 +95,7 @@ func (s *UnitSerializationSuite) TestNewUnit(c *gc.C) {
 	})
 	c.Assert(unit.MeterStatusCode(), gc.Equals, "meter code")
 	c.Assert(unit.MeterStatusInfo(), gc.Equals, "meter info")
+	c.Assert(unit.CharmState(), jc.DeepEquals, map[string]string{"foo": "bar"})
 	c.Assert(unit.Tools(), gc.NotNil)
 	c.Assert(unit.WorkloadStatus(), gc.NotNil)
 	c.Assert(unit.AgentStatus(), gc.NotNil)
@@ -15,6 +15,9 @@ var ErrClaimDenied = errors.New("lease claim denied")
 // ErrNotHeld indicates that some holder does not hold some lease.
 var ErrNotHeld = errors.New("lease not held")
 
+// ErrPinned indicates that a lease cannot change hands because it is pinned.
+var ErrPinned = errors.New("lease pinned")
+
 // Claimer exposes lease acquisition and expiry notification capabilities.
 type Claimer interface {
 
@@ -25,11 +28,32 @@ type Claimer interface {
 	// error, no reasonable inferences may be made.
 	Claim(leaseName, holderName string, duration time.Duration) error
 
-	// WaitUntilExpired returns nil when the named lease is no longer held. If it
-	// returns any other error, no reasonable inferences may be made.
+	// WaitUntilExpired returns nil when the named lease is no longer held by
+	// the holder that held it when the call was made. If it returns any other
+	// error, no reasonable inferences may be made.
 	WaitUntilExpired(leaseName string) error
 }
 
+// Director exposes capabilities for influencing which holder keeps a lease.
+type Director interface {
+
+	// Pin prevents the named lease from expiring, so that its holder keeps
+	// it even if it stops extending it; for example, while the holder's
+	// machine is down for maintenance. It returns ErrNotHeld if the lease
+	// is not held.
+	Pin(leaseName string) error
+
+	// Unpin allows the named lease to expire once more. It returns
+	// ErrNotHeld if the lease is not held.
+	Unpin(leaseName string) error
+
+	// Transfer arranges for the named lease to be handed to the named holder.
+	// The current holder keeps the lease until it expires, but its claims to
+	// extend it will be denied. It returns ErrNotHeld if the lease is not
+	// held, and ErrPinned if the lease is pinned.
+	Transfer(leaseName, holderName string) error
+}
+
 // Checker exposes facts about lease ownership.
 type Checker interface {
 
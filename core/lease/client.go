@@ -32,6 +32,28 @@ type Client interface {
 	// have passed. If it returns ErrInvalid, check Leases() for updated state.
 	ExpireLease(lease string) error
 
+	// HandOverLease records the vacation of the supplied lease and its claim
+	// by the lease's successor, which must be the supplied holder, as a single
+	// operation. It will fail under the same conditions as ExpireLease; if it
+	// succeeds, the successor's claim is guaranteed as for ClaimLease. If it
+	// returns ErrInvalid, check Leases() for updated state.
+	HandOverLease(lease string, request Request) error
+
+	// PinLease records that the supplied lease must not be expired, even
+	// once its expiry time has passed, until it is unpinned. If it returns
+	// ErrInvalid, check Leases() for updated state.
+	PinLease(lease string) error
+
+	// UnpinLease records that the supplied lease may once again be expired.
+	// If it returns ErrInvalid, check Leases() for updated state.
+	UnpinLease(lease string) error
+
+	// TransferLease records that the supplied lease should be handed to the
+	// supplied successor once it expires; the current holder will no longer
+	// be able to extend it. If it returns ErrInvalid, check Leases() for
+	// updated state.
+	TransferLease(lease, successor string) error
+
 	// Leases returns a recent snapshot of lease state. Expiry times are
 	// expressed according to the Clock the client was configured with.
 	Leases() map[string]Info
@@ -51,6 +73,13 @@ type Info struct {
 	// be valid. Attempting to expire the lease before this time will fail.
 	Expiry time.Time
 
+	// Pinned is true if the lease must not be expired.
+	Pinned bool
+
+	// Successor, if not empty, is the name of the holder to which the lease
+	// will be handed when it expires.
+	Successor string
+
 	// Trapdoor exposes the originating Client's persistence substrate, if the
 	// substrate exposes any such capability. It's useful specifically for
 	// integrating mgo/txn-based components: which thus get a mechanism for
@@ -67,6 +67,11 @@ type StartInstanceParams struct {
 	// that may be used to start this instance.
 	ImageMetadata []*imagemetadata.ImageMetadata
 
+	// ContainerProfile, if non-nil, holds customisations requested by
+	// the services whose units will be hosted on the instance. It is
+	// only used by container brokers; cloud providers ignore it.
+	ContainerProfile *instance.ContainerProfile
+
 	// StatusCallback is a callback to be used by the instance to report changes in status.
 	StatusCallback func(settableStatus status.Status, info string, data map[string]interface{}) error
 }
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -46,6 +47,28 @@ const (
 	// instance security groups.
 	FwNone = "none"
 
+	// MetricsSenderCollector requests that charm metrics be sent to
+	// the charm store's metrics collector.
+	MetricsSenderCollector = "collector"
+
+	// MetricsSenderHTTP requests that charm metrics be posted, in the
+	// collector's wire format, to the HTTP endpoint specified by
+	// metrics-sender-target.
+	MetricsSenderHTTP = "http"
+
+	// MetricsSenderFile requests that charm metrics be appended to the
+	// local file specified by metrics-sender-target, which must be
+	// within MetricsFileDir.
+	MetricsSenderFile = "file"
+
+	// MetricsFileDir is the directory on each controller within which
+	// the file metrics sender may write.
+	MetricsFileDir = "/var/log/juju/metrics"
+
+	// MetricsSenderPushgateway requests that charm metrics be pushed to
+	// the Prometheus pushgateway specified by metrics-sender-target.
+	MetricsSenderPushgateway = "pushgateway"
+
 	// DefaultStatePort is the default port the controller is listening on.
 	DefaultStatePort int = 37017
 
@@ -202,6 +225,20 @@ const (
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
 
+	// MetricsSenderKey sets where the controller sends charm metrics;
+	// it is only used in the controller model's configuration.
+	MetricsSenderKey = "metrics-sender"
+
+	// MetricsSenderTargetKey sets the URL or path that charm metrics
+	// are sent to, for those metrics senders that require one; it is
+	// only used in the controller model's configuration.
+	MetricsSenderTargetKey = "metrics-sender-target"
+
+	// AuditLogMaxAgeKey sets how long entries are kept in the audit log
+	// of API calls; it is only used in the controller model's
+	// configuration.
+	AuditLogMaxAgeKey = "audit-log-max-age"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -639,6 +676,20 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if err := cfg.validateMetricsSender(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if v, ok := cfg.defined[AuditLogMaxAgeKey].(string); ok && v != "" {
+		maxAge, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", AuditLogMaxAgeKey)
+		}
+		if maxAge <= 0 {
+			return errors.Errorf("%s must be positive, got %q", AuditLogMaxAgeKey, v)
+		}
+	}
+
 	caCert, caCertOK := cfg.CACert()
 	caKey, caKeyOK := cfg.CAPrivateKey()
 	if caCertOK || caKeyOK {
@@ -1188,6 +1239,70 @@ func (c *Config) AllowLXCLoopMounts() (bool, bool) {
 	return v, ok
 }
 
+// ControllerOnlyAttributes lists the attributes that are only used in
+// the controller model's configuration, and cannot be set in hosted
+// models.
+var ControllerOnlyAttributes = []string{
+	MetricsSenderKey,
+	MetricsSenderTargetKey,
+	AuditLogMaxAgeKey,
+}
+
+// MetricsSender returns where the controller sends charm metrics
+// (MetricsSenderCollector, MetricsSenderHTTP, MetricsSenderFile or
+// MetricsSenderPushgateway), and the URL or path to send them to.
+func (c *Config) MetricsSender() (sender, target string) {
+	sender = c.asString(MetricsSenderKey)
+	if sender == "" {
+		sender = MetricsSenderCollector
+	}
+	return sender, c.asString(MetricsSenderTargetKey)
+}
+
+func (c *Config) validateMetricsSender() error {
+	sender, target := c.MetricsSender()
+	switch sender {
+	case MetricsSenderCollector:
+		if target != "" {
+			return errors.Errorf("%s not valid with %s %q", MetricsSenderTargetKey, MetricsSenderKey, sender)
+		}
+	case MetricsSenderHTTP, MetricsSenderPushgateway:
+		if target == "" {
+			return errors.Errorf("%s %q requires %s", MetricsSenderKey, sender, MetricsSenderTargetKey)
+		}
+		u, err := url.Parse(target)
+		if err != nil {
+			return errors.Annotate(err, "invalid metrics sender target")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("metrics sender target %q is not an http or https URL", target)
+		}
+	case MetricsSenderFile:
+		// Controllers always run on Linux, whatever the OS of the
+		// client validating the configuration.
+		if target != path.Clean(target) || !strings.HasPrefix(target, MetricsFileDir+"/") {
+			return errors.Errorf("metrics sender target %q is not a file within %s", target, MetricsFileDir)
+		}
+	default:
+		return errors.NotValidf("%s %q", MetricsSenderKey, sender)
+	}
+	return nil
+}
+
+// DefaultAuditLogMaxAge is how long entries are kept in the audit log
+// if audit-log-max-age is not set.
+const DefaultAuditLogMaxAge = 90 * 24 * time.Hour
+
+// AuditLogMaxAge returns how long entries are kept in the audit log.
+func (c *Config) AuditLogMaxAge() time.Duration {
+	// Validate has already checked the value.
+	maxAge, err := time.ParseDuration(c.asString(AuditLogMaxAgeKey))
+	if err != nil || maxAge <= 0 {
+		return DefaultAuditLogMaxAge
+	}
+	return maxAge
+}
+
 // CloudImageBaseURL returns the specified override url that the 'ubuntu-
 // cloudimg-query' executable uses to find container images. The empty string
 // means that the default URL is used.
@@ -1329,6 +1444,9 @@ var alwaysOptional = schema.Defaults{
 	AllowLXCLoopMounts:           false,
 	ResourceTagsKey:              schema.Omit,
 	CloudImageBaseURL:            schema.Omit,
+	MetricsSenderKey:             schema.Omit,
+	MetricsSenderTargetKey:       schema.Omit,
+	AuditLogMaxAgeKey:            schema.Omit,
 
 	// AutomaticallyRetryHooks is assumed to be true if missing
 	AutomaticallyRetryHooks: schema.Omit,
@@ -1914,6 +2032,34 @@ data of the store. (default false)`,
 		Group:       environschema.JujuGroup,
 		Immutable:   true,
 	},
+	MetricsSenderKey: {
+		Description: `Where the controller sends charm metrics. Only used in the controller model.
+
+'collector' sends metrics to the charm store's metrics collector.
+
+'http' posts metrics, in the collector's format, to the URL given
+by metrics-sender-target.
+
+'file' appends metrics, one JSON batch per line, to the local file
+on each controller given by metrics-sender-target, which must be
+within /var/log/juju/metrics.
+
+'pushgateway' pushes metrics to the Prometheus pushgateway at the URL
+given by metrics-sender-target.`,
+		Type:   environschema.Tstring,
+		Values: []interface{}{MetricsSenderCollector, MetricsSenderHTTP, MetricsSenderFile, MetricsSenderPushgateway},
+		Group:  environschema.JujuGroup,
+	},
+	MetricsSenderTargetKey: {
+		Description: "The URL or path that the metrics sender sends charm metrics to. Only used in the controller model.",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	AuditLogMaxAgeKey: {
+		Description: "How long entries are kept in the audit log of API calls, e.g. 2160h. Only used in the controller model.",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
 	AutomaticallyRetryHooks: {
 		Description: "Determines whether the uniter should automatically retry failed hooks",
 		Type:        environschema.Tbool,
@@ -828,6 +828,79 @@ var configTests = []configTest{
 			"identity-url":        "https://test-identity",
 			"identity-public-key": "o/yOqSNWncMo1GURWuez/dGR30TscmmuIxgjztpoHEY=",
 		}),
+	}, {
+		about:       "Valid metrics sender",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"metrics-sender":        "pushgateway",
+			"metrics-sender-target": "http://10.0.0.1:9091",
+		}),
+	}, {
+		about:       "Metrics sender target with collector",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"metrics-sender-target": "http://10.0.0.1:9091",
+		}),
+		err: `metrics-sender-target not valid with metrics-sender "collector"`,
+	}, {
+		about:       "Metrics sender missing target",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"metrics-sender": "http",
+		}),
+		err: `metrics-sender "http" requires metrics-sender-target`,
+	}, {
+		about:       "Metrics sender target not an http URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"metrics-sender":        "http",
+			"metrics-sender-target": "ftp://10.0.0.1/metrics",
+		}),
+		err: `metrics sender target "ftp://10.0.0.1/metrics" is not an http or https URL`,
+	}, {
+		about:       "Metrics sender target not an absolute path",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"metrics-sender":        "file",
+			"metrics-sender-target": "metrics.log",
+		}),
+		err: `metrics sender target "metrics.log" is not a file within /var/log/juju/metrics`,
+	}, {
+		about:       "Metrics sender target outside the metrics directory",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"metrics-sender":        "file",
+			"metrics-sender-target": "/etc/cron.d/metrics",
+		}),
+		err: `metrics sender target "/etc/cron.d/metrics" is not a file within /var/log/juju/metrics`,
+	}, {
+		about:       "Metrics sender target escaping the metrics directory",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"metrics-sender":        "file",
+			"metrics-sender-target": "/var/log/juju/metrics/../../../../etc/passwd",
+		}),
+		err: `metrics sender target "/var/log/juju/metrics/../../../../etc/passwd" is not a file within /var/log/juju/metrics`,
+	}, {
+		about:       "Valid audit log max age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"audit-log-max-age": "720h",
+		}),
+	}, {
+		about:       "Invalid audit log max age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"audit-log-max-age": "a month",
+		}),
+		err: `invalid audit-log-max-age: time: invalid duration a month`,
+	}, {
+		about:       "Non-positive audit log max age",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"audit-log-max-age": "0s",
+		}),
+		err: `audit-log-max-age must be positive, got "0s"`,
 	},
 }
 
@@ -1591,6 +1664,33 @@ func (s *ConfigSuite) TestAutoHookRetryTrueEnv(c *gc.C) {
 	c.Assert(config.AutomaticallyRetryHooks(), gc.Equals, true)
 }
 
+func (s *ConfigSuite) TestMetricsSenderDefault(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{})
+	sender, target := config.MetricsSender()
+	c.Assert(sender, gc.Equals, "collector")
+	c.Assert(target, gc.Equals, "")
+}
+
+func (s *ConfigSuite) TestMetricsSender(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{
+		"metrics-sender":        "file",
+		"metrics-sender-target": "/var/log/juju/metrics/metrics.log",
+	})
+	sender, target := config.MetricsSender()
+	c.Assert(sender, gc.Equals, "file")
+	c.Assert(target, gc.Equals, "/var/log/juju/metrics/metrics.log")
+}
+
+func (s *ConfigSuite) TestAuditLogMaxAge(c *gc.C) {
+	s.addJujuFiles(c)
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.AuditLogMaxAge(), gc.Equals, config.DefaultAuditLogMaxAge)
+	cfg = newTestConfig(c, testing.Attrs{"audit-log-max-age": "720h"})
+	c.Assert(cfg.AuditLogMaxAge(), gc.Equals, 720*time.Hour)
+}
+
 func (s *ConfigSuite) TestCloudImageBaseURL(c *gc.C) {
 	s.addJujuFiles(c)
 	config := newTestConfig(c, testing.Attrs{})
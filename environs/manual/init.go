@@ -29,14 +29,18 @@ cat /proc/cpuinfo`
 
 // CheckProvisioned checks if any juju init service already
 // exist on the host machine.
-var CheckProvisioned = checkProvisioned
+var CheckProvisioned = func(host string) (bool, error) {
+	return checkProvisioned(host, "")
+}
 
-func checkProvisioned(host string) (bool, error) {
+// checkProvisioned is CheckProvisioned, logging in with the private
+// key in keyFile if it is not empty.
+func checkProvisioned(host, keyFile string) (bool, error) {
 	logger.Infof("Checking if %s is already provisioned", host)
 
 	script := service.ListServicesScript()
 
-	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, nil)
+	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, sshOptions(keyFile))
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -61,11 +65,16 @@ func checkProvisioned(host string) (bool, error) {
 // DetectSeriesAndHardwareCharacteristics detects the OS
 // series and hardware characteristics of the remote machine
 // by connecting to the machine and executing a bash script.
-var DetectSeriesAndHardwareCharacteristics = detectSeriesAndHardwareCharacteristics
+var DetectSeriesAndHardwareCharacteristics = func(host string) (instance.HardwareCharacteristics, string, error) {
+	return detectSeriesAndHardwareCharacteristics(host, "")
+}
 
-func detectSeriesAndHardwareCharacteristics(host string) (hc instance.HardwareCharacteristics, series string, err error) {
+// detectSeriesAndHardwareCharacteristics is
+// DetectSeriesAndHardwareCharacteristics, logging in with the private
+// key in keyFile if it is not empty.
+func detectSeriesAndHardwareCharacteristics(host, keyFile string) (hc instance.HardwareCharacteristics, series string, err error) {
 	logger.Infof("Detecting series and characteristics on %s", host)
-	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, nil)
+	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, sshOptions(keyFile))
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -136,6 +145,12 @@ func detectSeriesAndHardwareCharacteristics(host string) (hc instance.HardwareCh
 // stdin and stdout will be used for remote sudo prompts,
 // if the ubuntu user must be created/updated.
 func InitUbuntuUser(host, login, authorizedKeys string, stdin io.Reader, stdout io.Writer) error {
+	return initUbuntuUser(host, login, "", authorizedKeys, stdin, stdout)
+}
+
+// initUbuntuUser is InitUbuntuUser, logging in with the private key in
+// keyFile if it is not empty.
+func initUbuntuUser(host, login, keyFile, authorizedKeys string, stdin io.Reader, stdout io.Writer) error {
 	logger.Infof("initialising %q, user %q", host, login)
 
 	// To avoid unnecessary prompting for the specified login,
@@ -145,7 +160,7 @@ func InitUbuntuUser(host, login, authorizedKeys string, stdin io.Reader, stdout
 	//
 	// Note that we explicitly do not allocate a PTY, so we
 	// get a failure if sudo prompts.
-	cmd := ssh.Command("ubuntu@"+host, []string{"sudo", "-n", "true"}, nil)
+	cmd := ssh.Command("ubuntu@"+host, []string{"sudo", "-n", "true"}, sshOptions(keyFile))
 	if cmd.Run() == nil {
 		logger.Infof("ubuntu user is already initialised")
 		return nil
@@ -160,6 +175,9 @@ func InitUbuntuUser(host, login, authorizedKeys string, stdin io.Reader, stdout
 	var options ssh.Options
 	options.AllowPasswordAuthentication()
 	options.EnablePTY()
+	if keyFile != "" {
+		options.SetIdentities(keyFile)
+	}
 	cmd = ssh.Command(host, []string{"sudo", "/bin/bash -c " + utils.ShQuote(script)}, &options)
 	var stderr bytes.Buffer
 	cmd.Stdin = stdin
@@ -187,3 +205,15 @@ export authorized_keys=%s
 if [ ! -z "$authorized_keys" ]; then
     su ubuntu -c 'printf "%%s\n" "$authorized_keys" >> ~/.ssh/authorized_keys'
 fi`
+
+// sshOptions returns the options used to log in to a host with the
+// private key in keyFile, or nil to use the default keys if keyFile
+// is empty.
+func sshOptions(keyFile string) *ssh.Options {
+	if keyFile == "" {
+		return nil
+	}
+	var options ssh.Options
+	options.SetIdentities(keyFile)
+	return &options
+}
@@ -42,6 +42,10 @@ type ProvisionMachineArgs struct {
 	// Host is the SSH host: [user@]host
 	Host string
 
+	// KeyFile is the path of the private key used to log in to the
+	// host as the specified user. If empty, the default keys are used.
+	KeyFile string
+
 	// DataDir is the root directory for juju data.
 	// If left blank, the default location "/var/lib/juju" will be used.
 	DataDir string
@@ -87,13 +91,13 @@ func ProvisionMachine(args ProvisionMachineArgs) (machineId string, err error) {
 	// the ubuntu user's authorized_keys file with the public keys in the current
 	// user's ~/.ssh directory. The authenticationworker will later update the
 	// ubuntu user's authorized_keys.
-	user, hostname := splitUserHost(args.Host)
+	user, hostname := SplitUserHost(args.Host)
 	authorizedKeys, err := config.ReadAuthorizedKeys("")
-	if err := InitUbuntuUser(hostname, user, authorizedKeys, args.Stdin, args.Stdout); err != nil {
+	if err := initUbuntuUser(hostname, user, args.KeyFile, authorizedKeys, args.Stdin, args.Stdout); err != nil {
 		return "", err
 	}
 
-	machineParams, err := gatherMachineParams(hostname)
+	machineParams, err := gatherMachineParams(hostname, args.KeyFile)
 	if err != nil {
 		return "", err
 	}
@@ -116,7 +120,7 @@ func ProvisionMachine(args ProvisionMachineArgs) (machineId string, err error) {
 	}
 
 	// Finally, provision the machine agent.
-	err = runProvisionScript(provisioningScript, hostname, args.Stderr)
+	err = runProvisionScript(provisioningScript, hostname, args.KeyFile, args.Stderr)
 	if err != nil {
 		return machineId, err
 	}
@@ -125,7 +129,9 @@ func ProvisionMachine(args ProvisionMachineArgs) (machineId string, err error) {
 	return machineId, nil
 }
 
-func splitUserHost(host string) (string, string) {
+// SplitUserHost splits an SSH host of the form [user@]host into its
+// user and host parts. The user is empty if none is specified.
+func SplitUserHost(host string) (string, string) {
 	if at := strings.Index(host, "@"); at != -1 {
 		return host[:at], host[at+1:]
 	}
@@ -147,10 +153,11 @@ func recordMachineInState(client ProvisioningClientAPI, machineParams params.Add
 
 // gatherMachineParams collects all the information we know about the machine
 // we are about to provision. It will SSH into that machine as the ubuntu user.
-// The hostname supplied should not include a username.
+// The hostname supplied should not include a username. If keyFile is
+// not empty, the private key it holds is used to log in.
 // If we can, we will reverse lookup the hostname by its IP address, and use
 // the DNS resolved name, rather than the name that was supplied
-func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
+func gatherMachineParams(hostname, keyFile string) (*params.AddMachineParams, error) {
 
 	// Generate a unique nonce for the machine.
 	uuid, err := utils.NewUUID()
@@ -165,7 +172,7 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 		addrs = append(addrs, addr)
 	}
 
-	provisioned, err := checkProvisioned(hostname)
+	provisioned, err := checkProvisioned(hostname, keyFile)
 	if err != nil {
 		err = fmt.Errorf("error checking if provisioned: %v", err)
 		return nil, err
@@ -174,7 +181,7 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 		return nil, ErrProvisioned
 	}
 
-	hc, series, err := DetectSeriesAndHardwareCharacteristics(hostname)
+	hc, series, err := detectSeriesAndHardwareCharacteristics(hostname, keyFile)
 	if err != nil {
 		err = fmt.Errorf("error detecting hardware characteristics: %v", err)
 		return nil, err
@@ -208,7 +215,7 @@ var provisionMachineAgent = func(host string, icfg *instancecfg.InstanceConfig,
 	if err != nil {
 		return err
 	}
-	return runProvisionScript(script, host, progressWriter)
+	return runProvisionScript(script, host, "", progressWriter)
 }
 
 // ProvisioningScript generates a bash script that can be
@@ -244,9 +251,10 @@ func ProvisioningScript(icfg *instancecfg.InstanceConfig) (string, error) {
 	return buf.String(), nil
 }
 
-func runProvisionScript(script, host string, progressWriter io.Writer) error {
+func runProvisionScript(script, host, keyFile string, progressWriter io.Writer) error {
 	params := sshinit.ConfigureParams{
 		Host:           "ubuntu@" + host,
+		Options:        sshOptions(keyFile),
 		ProgressWriter: progressWriter,
 	}
 	return sshinit.RunConfigureScript(script, params)
@@ -185,3 +185,13 @@ func (s *provisionerSuite) TestProvisioningScript(c *gc.C) {
 	expectedScript := removeLogFile + shell.DumpFileOnErrorScript("/var/log/cloud-init-output.log") + provisioningScript
 	c.Assert(script, gc.Equals, expectedScript)
 }
+
+func (s *provisionerSuite) TestSplitUserHost(c *gc.C) {
+	user, host := manual.SplitUserHost("ubuntu@10.0.0.1")
+	c.Check(user, gc.Equals, "ubuntu")
+	c.Check(host, gc.Equals, "10.0.0.1")
+
+	user, host = manual.SplitUserHost("10.0.0.1")
+	c.Check(user, gc.Equals, "")
+	c.Check(host, gc.Equals, "10.0.0.1")
+}
@@ -0,0 +1,156 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package manual
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	jujuos "github.com/juju/utils/os"
+	"github.com/juju/utils/series"
+	"github.com/juju/utils/ssh"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/juju/paths"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/service"
+	"github.com/juju/juju/worker/terminationworker"
+)
+
+// InstanceHost returns the host of a manually provisioned machine
+// with the given instance id, and whether the instance id is that of
+// a manually provisioned machine with a known host.
+func InstanceHost(id instance.Id) (string, bool) {
+	s := string(id)
+	if !strings.HasPrefix(s, manualInstancePrefix) {
+		return "", false
+	}
+	host := strings.TrimPrefix(s, manualInstancePrefix)
+	return host, host != ""
+}
+
+// UninstallMachineArgs holds the arguments for UninstallMachine.
+type UninstallMachineArgs struct {
+	// Host is the host of the manually provisioned machine.
+	Host string
+
+	// Series is the series of the machine, which determines where
+	// juju's files are on the host.
+	Series string
+
+	// Stdout receives a report of what was removed from the host.
+	Stdout io.Writer
+}
+
+// UninstallMachine removes the juju agents, services and data from a
+// manually provisioned machine, via an SSH connection to its host.
+// It should only be used once the machine has been removed from, or
+// is being removed from, the model.
+var UninstallMachine = uninstallMachine
+
+func uninstallMachine(args UninstallMachineArgs) error {
+	script, err := UninstallScript(args.Series)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("uninstalling juju from %s", args.Host)
+	cmd := ssh.Command("ubuntu@"+args.Host, []string{"sudo", "/bin/bash"}, nil)
+	var stderr bytes.Buffer
+	cmd.Stdin = strings.NewReader(script)
+	cmd.Stdout = args.Stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() != 0 {
+			err = fmt.Errorf("%v (%v)", err, strings.TrimSpace(stderr.String()))
+		}
+		return errors.Annotatef(err, "cannot uninstall juju from %s", args.Host)
+	}
+	return nil
+}
+
+// uninstallScript is the template for the script run on a manually
+// provisioned machine to uninstall juju. It reports each service and
+// path that it removes.
+const uninstallScript = `#!/bin/bash
+# Ask any running machine agent to uninstall itself first.
+touch %[1]s
+pkill -%[2]d jujud && sleep 5
+
+init_system=$(%[3]s)
+services=$( (%[4]s) | grep -E '^(jujud-|%[5]s$)')
+for svc in $services; do
+    case "$init_system" in
+    systemd)
+        systemctl stop "$svc" >/dev/null 2>&1
+        systemctl disable "$svc" >/dev/null 2>&1
+        rm -f /etc/systemd/system/"$svc".service /lib/systemd/system/"$svc".service
+        ;;
+    upstart)
+        stop "$svc" >/dev/null 2>&1
+        rm -f /etc/init/"$svc".conf
+        ;;
+    esac
+    echo "removed service $svc"
+done
+if [ "$init_system" = systemd ]; then
+    systemctl daemon-reload
+fi
+pkill -9 jujud
+
+for p in %[6]s; do
+    if [ -e "$p" ] || [ -L "$p" ]; then
+        rm -rf "$p"
+        echo "removed $p"
+    fi
+done
+exit 0
+`
+
+// UninstallScript returns the script that uninstalls juju from a
+// manually provisioned machine running the given series.
+func UninstallScript(machineSeries string) (string, error) {
+	if os, err := series.GetOSFromSeries(machineSeries); err != nil {
+		return "", errors.Trace(err)
+	} else if os == jujuos.Windows {
+		return "", errors.NotSupportedf("uninstalling juju from %s machines", os)
+	}
+	dataDir, err := paths.DataDir(machineSeries)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	logDir, err := paths.LogDir(machineSeries)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	jujuRun, err := paths.JujuRun(machineSeries)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	jujuDumpLogs, err := paths.JujuDumpLogs(machineSeries)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var removePaths []string
+	for _, p := range []string{dataDir, path.Join(logDir, "juju"), jujuRun, jujuDumpLogs} {
+		removePaths = append(removePaths, utils.ShQuote(p))
+	}
+	return fmt.Sprintf(
+		uninstallScript,
+		// WARNING: this is linked with the use of uninstallFile in
+		// the agent package. Don't change it without extreme care,
+		// and handling for mismatches with already-deployed agents.
+		utils.ShQuote(path.Join(dataDir, agent.UninstallFile)),
+		terminationworker.TerminationSignal,
+		service.DiscoverInitSystemScript(),
+		service.ListServicesScript(),
+		mongo.ServiceName,
+		strings.Join(removePaths, " "),
+	), nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package manual_test
+
+import (
+	"bytes"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/instance"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type uninstallSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+}
+
+var _ = gc.Suite(&uninstallSuite{})
+
+func (s *uninstallSuite) TestInstanceHost(c *gc.C) {
+	for _, test := range []struct {
+		id     instance.Id
+		host   string
+		manual bool
+	}{
+		{"manual:10.0.0.1", "10.0.0.1", true},
+		{"manual:example.com", "example.com", true},
+		{"manual:", "", false},
+		{"i-1234", "", false},
+	} {
+		host, ok := manual.InstanceHost(test.id)
+		c.Check(host, gc.Equals, test.host)
+		c.Check(ok, gc.Equals, test.manual)
+	}
+}
+
+func (s *uninstallSuite) TestUninstallScript(c *gc.C) {
+	script, err := manual.UninstallScript("trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(script, jc.Contains, "touch '/var/lib/juju/uninstall-agent'")
+	c.Assert(script, jc.Contains, "pkill -6 jujud")
+	c.Assert(script, jc.Contains, "grep -E '^(jujud-|juju-db$)'")
+	c.Assert(script, jc.Contains,
+		"for p in '/var/lib/juju' '/var/log/juju' '/usr/bin/juju-run' '/usr/bin/juju-dumplogs'; do")
+}
+
+func (s *uninstallSuite) TestUninstallScriptWindows(c *gc.C) {
+	_, err := manual.UninstallScript("win2012r2")
+	c.Assert(err, gc.ErrorMatches, "uninstalling juju from Windows machines not supported")
+}
+
+func (s *uninstallSuite) TestUninstallMachine(c *gc.C) {
+	script, err := manual.UninstallScript("trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	output := "removed service jujud-machine-1\nremoved /var/lib/juju"
+	defer installFakeSSH(c, script, output, 0)()
+
+	var stdout bytes.Buffer
+	err = manual.UninstallMachine(manual.UninstallMachineArgs{
+		Host:   "example.com",
+		Series: "trusty",
+		Stdout: &stdout,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stdout.String(), gc.Equals, output+"\n")
+}
+
+func (s *uninstallSuite) TestUninstallMachineError(c *gc.C) {
+	script, err := manual.UninstallScript("trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	defer installFakeSSH(c, script, []string{"", "permission denied"}, 1)()
+
+	err = manual.UninstallMachine(manual.UninstallMachineArgs{
+		Host:   "example.com",
+		Series: "trusty",
+		Stdout: &bytes.Buffer{},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot uninstall juju from example.com: exit status 1 \(permission denied\)`)
+}
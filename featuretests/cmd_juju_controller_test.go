@@ -144,7 +144,9 @@ func (s *cmdControllerSuite) TestAddModel(c *gc.C) {
 	// so fake one on the command line.  The dummy provider also expects
 	// a config value for 'controller'.
 	context := s.run(c, "add-model", "new-model", "authorized-keys=fake-key", "controller=false")
-	c.Check(testing.Stdout(context), gc.Equals, "")
+	c.Check(testing.Stdout(context), gc.Matches, ""+
+		"NAME       MODEL UUID +OWNER\n"+
+		"new-model  [-0-9a-f]+  admin@local\n")
 	c.Check(testing.Stderr(context), gc.Equals, "added model \"new-model\"\n")
 
 	// Make sure that the saved server details are sufficient to connect
@@ -0,0 +1,345 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instance
+
+import (
+	"encoding/xml"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// ContainerProfile holds customisations, supplied for a service, that
+// are applied to the containers hosting its units in addition to the
+// configuration juju itself applies.
+type ContainerProfile struct {
+	// LXDConfig holds LXD container configuration keys and values.
+	LXDConfig map[string]string `json:"lxd-config,omitempty" yaml:"lxd-config,omitempty"`
+
+	// LXDDevices holds LXD devices keyed by device name. Each device
+	// must have a "type" property.
+	LXDDevices map[string]map[string]string `json:"lxd-devices,omitempty" yaml:"lxd-devices,omitempty"`
+
+	// KVMCPU holds a libvirt domain <cpu> element, replacing the
+	// default CPU model of KVM containers.
+	KVMCPU string `json:"kvm-cpu,omitempty" yaml:"kvm-cpu,omitempty"`
+
+	// KVMDevices holds libvirt domain XML fragments, each a single
+	// element, that are added to the <devices> of KVM containers.
+	KVMDevices []string `json:"kvm-devices,omitempty" yaml:"kvm-devices,omitempty"`
+}
+
+// allowedLXDConfig holds the LXD configuration keys that may be set
+// by a container profile. Keys that juju manages itself, and keys
+// that would grant the container elevated access to the host (such
+// as security.privileged or raw.lxc), are deliberately absent.
+var allowedLXDConfig = []string{
+	"linux.kernel_modules",
+	"security.nesting",
+}
+
+// allowedLXDConfigPrefixes holds the prefixes of LXD configuration
+// keys that may be set by a container profile.
+var allowedLXDConfigPrefixes = []string{
+	"limits.",
+}
+
+// allowedLXDDeviceProperties holds, for each LXD device type that may
+// be added by a container profile, the device properties that may be
+// set. Networking and disks are managed by juju. Properties that
+// identify unix devices by number (major and minor) are absent, so
+// that the devices passed through can be checked by path.
+var allowedLXDDeviceProperties = map[string][]string{
+	"gpu":        {"type", "vendorid", "productid", "id", "pci", "uid", "gid", "mode"},
+	"unix-block": {"type", "source", "path", "uid", "gid", "mode", "required"},
+	"unix-char":  {"type", "source", "path", "uid", "gid", "mode", "required"},
+	"usb":        {"type", "vendorid", "productid", "uid", "gid", "mode", "required"},
+}
+
+// allowedUnixDevices matches, for each LXD unix device type, the host
+// device nodes that may be passed through to a container. Devices
+// that would give access to the host's disks or memory are absent.
+var allowedUnixDevices = map[string]*regexp.Regexp{
+	"unix-block": regexp.MustCompile(`^/dev/nbd[0-9]+$`),
+	"unix-char":  regexp.MustCompile(`^/dev/(fuse|kvm|net/tun|vhost-net|vhost-vsock)$`),
+}
+
+// allowedKVMDevices holds the libvirt device elements that may be
+// added by a container profile.
+var allowedKVMDevices = []string{
+	"hostdev",
+	"input",
+	"rng",
+	"watchdog",
+}
+
+// allowedKVMHostdevTypes holds the types of host devices that may be
+// assigned to a KVM container. Only USB and PCI devices may be
+// assigned, and only in subsystem mode: capabilities mode would pass
+// host block and character devices through by path.
+var allowedKVMHostdevTypes = []string{
+	"pci",
+	"usb",
+}
+
+// allowedKVMRNGSources holds the host sources of randomness that may
+// back a KVM container's random number generator.
+var allowedKVMRNGSources = []string{
+	"/dev/hwrng",
+	"/dev/random",
+	"/dev/urandom",
+}
+
+// IsEmpty returns whether the profile holds no customisations.
+func (p ContainerProfile) IsEmpty() bool {
+	return len(p.LXDConfig) == 0 &&
+		len(p.LXDDevices) == 0 &&
+		p.KVMCPU == "" &&
+		len(p.KVMDevices) == 0
+}
+
+// Validate returns an error if the profile holds any customisation
+// that juju does not allow to be applied to a container.
+func (p ContainerProfile) Validate() error {
+	for _, key := range sortedKeys(p.LXDConfig) {
+		if !lxdConfigAllowed(key) {
+			return errors.NotValidf("LXD config key %q", key)
+		}
+	}
+	for _, name := range sortedDeviceNames(p.LXDDevices) {
+		if err := validateLXDDevice(name, p.LXDDevices[name]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if p.KVMCPU != "" {
+		element, err := xmlFragmentElement(p.KVMCPU)
+		if err != nil {
+			return errors.Annotate(err, "invalid KVM cpu")
+		}
+		if element != "cpu" {
+			return errors.NotValidf("KVM cpu element <%s>", element)
+		}
+	}
+	for _, fragment := range p.KVMDevices {
+		element, err := xmlFragmentElement(fragment)
+		if err != nil {
+			return errors.Annotate(err, "invalid KVM device")
+		}
+		if !contains(allowedKVMDevices, element) {
+			return errors.NotValidf("KVM device element <%s>", element)
+		}
+		if err := validateKVMDevice(element, fragment); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// validateLXDDevice returns an error if the LXD device may not be
+// added to a container.
+func validateLXDDevice(name string, device map[string]string) error {
+	deviceType := device["type"]
+	if deviceType == "" {
+		return errors.NotValidf("LXD device %q without type", name)
+	}
+	properties, ok := allowedLXDDeviceProperties[deviceType]
+	if !ok {
+		return errors.NotValidf("LXD device %q of type %q", name, deviceType)
+	}
+	for _, key := range sortedKeys(device) {
+		if !contains(properties, key) {
+			return errors.NotValidf("LXD device %q property %q", name, key)
+		}
+	}
+	allowedPaths, ok := allowedUnixDevices[deviceType]
+	if !ok {
+		return nil
+	}
+	if device["source"] == "" && device["path"] == "" {
+		return errors.NotValidf("LXD device %q without path", name)
+	}
+	for _, key := range []string{"source", "path"} {
+		if path := device[key]; path != "" && !allowedPaths.MatchString(path) {
+			return errors.NotValidf("LXD device %q %s %q", name, key, path)
+		}
+	}
+	return nil
+}
+
+// kvmHostdev holds the parts of a libvirt <hostdev> element that are
+// checked before it is added to a container.
+type kvmHostdev struct {
+	Mode string `xml:"mode,attr"`
+	Type string `xml:"type,attr"`
+}
+
+// kvmRNG holds the parts of a libvirt <rng> element that are checked
+// before it is added to a container.
+type kvmRNG struct {
+	Backend struct {
+		Model  string `xml:"model,attr"`
+		Source string `xml:",chardata"`
+	} `xml:"backend"`
+}
+
+// validateKVMDevice returns an error if the libvirt device fragment,
+// whose element is allowed, may not be added to a container.
+func validateKVMDevice(element, fragment string) error {
+	switch element {
+	case "hostdev":
+		var hostdev kvmHostdev
+		if err := xml.Unmarshal([]byte(fragment), &hostdev); err != nil {
+			return errors.Annotate(err, "invalid KVM device")
+		}
+		if hostdev.Mode != "subsystem" || !contains(allowedKVMHostdevTypes, hostdev.Type) {
+			return errors.NotValidf("KVM hostdev with mode %q and type %q", hostdev.Mode, hostdev.Type)
+		}
+	case "rng":
+		var rng kvmRNG
+		if err := xml.Unmarshal([]byte(fragment), &rng); err != nil {
+			return errors.Annotate(err, "invalid KVM device")
+		}
+		source := strings.TrimSpace(rng.Backend.Source)
+		// An empty source selects the libvirt default, /dev/random.
+		if rng.Backend.Model != "random" || (source != "" && !contains(allowedKVMRNGSources, source)) {
+			return errors.NotValidf("KVM rng backend %q with source %q", rng.Backend.Model, source)
+		}
+	}
+	return nil
+}
+
+// Merge returns a profile holding the customisations of both p and
+// other. It is an error for the two profiles to set conflicting values.
+func (p ContainerProfile) Merge(other ContainerProfile) (ContainerProfile, error) {
+	result := ContainerProfile{
+		KVMCPU: p.KVMCPU,
+	}
+	for _, config := range []map[string]string{p.LXDConfig, other.LXDConfig} {
+		for key, value := range config {
+			if existing, ok := result.LXDConfig[key]; ok && existing != value {
+				return ContainerProfile{}, errors.Errorf("conflicting values for LXD config key %q", key)
+			}
+			if result.LXDConfig == nil {
+				result.LXDConfig = make(map[string]string)
+			}
+			result.LXDConfig[key] = value
+		}
+	}
+	for _, devices := range []map[string]map[string]string{p.LXDDevices, other.LXDDevices} {
+		for name, device := range devices {
+			if existing, ok := result.LXDDevices[name]; ok && !sameDevice(existing, device) {
+				return ContainerProfile{}, errors.Errorf("conflicting definitions for LXD device %q", name)
+			}
+			if result.LXDDevices == nil {
+				result.LXDDevices = make(map[string]map[string]string)
+			}
+			result.LXDDevices[name] = device
+		}
+	}
+	if other.KVMCPU != "" {
+		if result.KVMCPU != "" && result.KVMCPU != other.KVMCPU {
+			return ContainerProfile{}, errors.New("conflicting values for KVM cpu")
+		}
+		result.KVMCPU = other.KVMCPU
+	}
+	result.KVMDevices = append(result.KVMDevices, p.KVMDevices...)
+	for _, fragment := range other.KVMDevices {
+		if !contains(result.KVMDevices, fragment) {
+			result.KVMDevices = append(result.KVMDevices, fragment)
+		}
+	}
+	return result, nil
+}
+
+func lxdConfigAllowed(key string) bool {
+	if contains(allowedLXDConfig, key) {
+		return true
+	}
+	for _, prefix := range allowedLXDConfigPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// xmlFragmentElement returns the name of the single root element of
+// the given XML fragment.
+func xmlFragmentElement(fragment string) (string, error) {
+	decoder := xml.NewDecoder(strings.NewReader(fragment))
+	var root string
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return "", errors.Trace(err)
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				if root != "" {
+					return "", errors.New("expected a single element")
+				}
+				root = token.Name.Local
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			if depth == 0 && strings.TrimSpace(string(token)) != "" {
+				return "", errors.New("unexpected text outside element")
+			}
+		case xml.ProcInst, xml.Directive:
+			return "", errors.New("unexpected XML declaration or directive")
+		}
+	}
+	if root == "" {
+		return "", errors.New("expected a single element")
+	}
+	return root, nil
+}
+
+func sameDevice(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if other, ok := b[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedDeviceNames(devices map[string]map[string]string) []string {
+	names := make([]string, 0, len(devices))
+	for name := range devices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
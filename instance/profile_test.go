@@ -0,0 +1,147 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instance_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+)
+
+type ProfileSuite struct{}
+
+var _ = gc.Suite(&ProfileSuite{})
+
+func (s *ProfileSuite) TestIsEmpty(c *gc.C) {
+	c.Assert(instance.ContainerProfile{}.IsEmpty(), jc.IsTrue)
+	c.Assert(instance.ContainerProfile{KVMCPU: "<cpu/>"}.IsEmpty(), jc.IsFalse)
+}
+
+func (s *ProfileSuite) TestValidateAllowed(c *gc.C) {
+	profile := instance.ContainerProfile{
+		LXDConfig: map[string]string{
+			"security.nesting":     "true",
+			"linux.kernel_modules": "openvswitch,nbd",
+			"limits.cpu":           "2",
+		},
+		LXDDevices: map[string]map[string]string{
+			"kvm": {"type": "unix-char", "path": "/dev/kvm"},
+			"tun": {"type": "unix-char", "source": "/dev/net/tun", "path": "/dev/net/tun", "mode": "0660"},
+			"nbd": {"type": "unix-block", "path": "/dev/nbd0"},
+			"gpu": {"type": "gpu", "vendorid": "10de"},
+		},
+		KVMCPU: "<cpu mode='host-passthrough'/>",
+		KVMDevices: []string{
+			"<rng model='virtio'><backend model='random'>/dev/urandom</backend></rng>",
+			"<hostdev mode='subsystem' type='usb'><source><vendor id='0x1234'/><product id='0xbeef'/></source></hostdev>",
+		},
+	}
+	c.Assert(profile.Validate(), jc.ErrorIsNil)
+}
+
+func (s *ProfileSuite) TestValidateRejected(c *gc.C) {
+	for i, test := range []struct {
+		profile instance.ContainerProfile
+		err     string
+	}{{
+		profile: instance.ContainerProfile{LXDConfig: map[string]string{"security.privileged": "true"}},
+		err:     `LXD config key "security.privileged" not valid`,
+	}, {
+		profile: instance.ContainerProfile{LXDConfig: map[string]string{"raw.lxc": "lxc.aa_profile=unconfined"}},
+		err:     `LXD config key "raw.lxc" not valid`,
+	}, {
+		profile: instance.ContainerProfile{LXDDevices: map[string]map[string]string{
+			"root": {"type": "disk", "source": "/", "path": "/host"},
+		}},
+		err: `LXD device "root" of type "disk" not valid`,
+	}, {
+		profile: instance.ContainerProfile{LXDDevices: map[string]map[string]string{
+			"kvm": {"path": "/dev/kvm"},
+		}},
+		err: `LXD device "kvm" without type not valid`,
+	}, {
+		profile: instance.ContainerProfile{LXDDevices: map[string]map[string]string{
+			"disk": {"type": "unix-block", "path": "/dev/sda"},
+		}},
+		err: `LXD device "disk" path "/dev/sda" not valid`,
+	}, {
+		profile: instance.ContainerProfile{LXDDevices: map[string]map[string]string{
+			"mem": {"type": "unix-char", "source": "/dev/mem", "path": "/dev/kvm"},
+		}},
+		err: `LXD device "mem" source "/dev/mem" not valid`,
+	}, {
+		profile: instance.ContainerProfile{LXDDevices: map[string]map[string]string{
+			"kvm": {"type": "unix-char"},
+		}},
+		err: `LXD device "kvm" without path not valid`,
+	}, {
+		profile: instance.ContainerProfile{LXDDevices: map[string]map[string]string{
+			"kvm": {"type": "unix-char", "path": "/dev/kvm", "major": "8", "minor": "0"},
+		}},
+		err: `LXD device "kvm" property "major" not valid`,
+	}, {
+		profile: instance.ContainerProfile{KVMDevices: []string{
+			"<hostdev mode='capabilities' type='storage'><source><block>/dev/sda</block></source></hostdev>",
+		}},
+		err: `KVM hostdev with mode "capabilities" and type "storage" not valid`,
+	}, {
+		profile: instance.ContainerProfile{KVMDevices: []string{
+			"<rng model='virtio'><backend model='random'>/etc/shadow</backend></rng>",
+		}},
+		err: `KVM rng backend "random" with source "/etc/shadow" not valid`,
+	}, {
+		profile: instance.ContainerProfile{KVMDevices: []string{
+			"<rng model='virtio'><backend model='egd' type='tcp'/></rng>",
+		}},
+		err: `KVM rng backend "egd" with source "" not valid`,
+	}, {
+		profile: instance.ContainerProfile{KVMCPU: "<features/>"},
+		err:     `KVM cpu element <features> not valid`,
+	}, {
+		profile: instance.ContainerProfile{KVMDevices: []string{"<disk type='file'/>"}},
+		err:     `KVM device element <disk> not valid`,
+	}, {
+		profile: instance.ContainerProfile{KVMDevices: []string{"<rng/><rng/>"}},
+		err:     `invalid KVM device: expected a single element`,
+	}, {
+		profile: instance.ContainerProfile{KVMDevices: []string{"<rng>"}},
+		err:     `invalid KVM device: .*`,
+	}} {
+		c.Logf("test %d", i)
+		c.Check(test.profile.Validate(), gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ProfileSuite) TestMerge(c *gc.C) {
+	a := instance.ContainerProfile{
+		LXDConfig:  map[string]string{"security.nesting": "true"},
+		KVMDevices: []string{"<rng model='virtio'/>"},
+	}
+	b := instance.ContainerProfile{
+		LXDConfig: map[string]string{"security.nesting": "true", "limits.cpu": "2"},
+		LXDDevices: map[string]map[string]string{
+			"kvm": {"type": "unix-char", "path": "/dev/kvm"},
+		},
+		KVMCPU:     "<cpu mode='host-passthrough'/>",
+		KVMDevices: []string{"<rng model='virtio'/>"},
+	}
+	merged, err := a.Merge(b)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(merged, jc.DeepEquals, instance.ContainerProfile{
+		LXDConfig: map[string]string{"security.nesting": "true", "limits.cpu": "2"},
+		LXDDevices: map[string]map[string]string{
+			"kvm": {"type": "unix-char", "path": "/dev/kvm"},
+		},
+		KVMCPU:     "<cpu mode='host-passthrough'/>",
+		KVMDevices: []string{"<rng model='virtio'/>"},
+	})
+}
+
+func (s *ProfileSuite) TestMergeConflict(c *gc.C) {
+	a := instance.ContainerProfile{LXDConfig: map[string]string{"limits.cpu": "1"}}
+	b := instance.ContainerProfile{LXDConfig: map[string]string{"limits.cpu": "2"}}
+	_, err := a.Merge(b)
+	c.Assert(err, gc.ErrorMatches, `conflicting values for LXD config key "limits.cpu"`)
+}
@@ -9,10 +9,12 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
+	"github.com/juju/names"
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/api"
@@ -23,6 +25,7 @@ import (
 // FacadeCaller has the api/base.FacadeCaller methods needed for the component.
 type FacadeCaller interface {
 	FacadeCall(request string, params, response interface{}) error
+	BestAPIVersion() int
 }
 
 // Doer
@@ -83,6 +86,67 @@ func (c Client) ListResources(services []string) ([]resource.ServiceResources, e
 	return results, nil
 }
 
+// ListResourceHistory calls the ListResourceHistory API server method
+// with the given service name.
+func (c Client) ListResourceHistory(service string) ([]resource.HistoryEntry, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotImplementedf("resource history")
+	}
+	args, err := api.NewListResourcesArgs([]string{service})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var apiResults api.ResourceHistoryResults
+	if err := c.FacadeCall("ListResourceHistory", &args, &apiResults); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(apiResults.Results) != 1 {
+		return nil, errors.Errorf("got invalid data from server (expected 1 result, got %d)", len(apiResults.Results))
+	}
+	apiResult := apiResults.Results[0]
+	if apiResult.Error != nil {
+		err := common.RestoreError(apiResult.Error)
+		return nil, errors.Trace(err)
+	}
+
+	var entries []resource.HistoryEntry
+	for _, apiEntry := range apiResult.Entries {
+		entry, err := api.API2HistoryEntry(apiEntry)
+		if err != nil {
+			return nil, errors.Annotate(err, "got bad data from server")
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// RollbackResource makes the identified revision from the resource's
+// history the service's current resource.
+func (c Client) RollbackResource(service, name string, number int) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotImplementedf("resource rollback")
+	}
+	if !names.IsValidService(service) {
+		return errors.Errorf("invalid service %q", service)
+	}
+	args := api.RollbackResourceArgs{
+		Entity: params.Entity{Tag: names.NewServiceTag(service).String()},
+		Name:   name,
+		Number: number,
+	}
+
+	var result params.ErrorResult
+	if err := c.FacadeCall("RollbackResource", &args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		err := common.RestoreError(result.Error)
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // Upload sends the provided resource blob up to Juju.
 func (c Client) Upload(service, name, filename string, reader io.ReadSeeker) error {
 	uReq, err := api.NewUploadRequest(service, name, filename, reader)
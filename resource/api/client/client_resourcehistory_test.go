@@ -0,0 +1,120 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/resource/api"
+	"github.com/juju/juju/resource/api/client"
+)
+
+var _ = gc.Suite(&ResourceHistorySuite{})
+
+type ResourceHistorySuite struct {
+	BaseSuite
+}
+
+func (s *ResourceHistorySuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade.ReturnBestAPIVersion = 2
+}
+
+func (s *ResourceHistorySuite) TestListResourceHistory(c *gc.C) {
+	res, apiRes := newResource(c, "spam", "a-user", "spamspamspam")
+	s.facade.historyResult = api.ResourceHistoryResult{
+		Entries: []api.ResourceHistoryEntry{{
+			Resource: apiRes,
+			Number:   3,
+			Current:  true,
+		}},
+	}
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	entries, err := cl.ListResourceHistory("a-service")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(entries, jc.DeepEquals, []resource.HistoryEntry{{
+		Resource: res,
+		Number:   3,
+		Current:  true,
+	}})
+	s.stub.CheckCallNames(c, "BestAPIVersion", "FacadeCall")
+	s.stub.CheckCall(c, 1, "FacadeCall",
+		"ListResourceHistory",
+		&api.ListResourcesArgs{[]params.Entity{{
+			Tag: "service-a-service",
+		}}},
+		&api.ResourceHistoryResults{
+			Results: []api.ResourceHistoryResult{s.facade.historyResult},
+		},
+	)
+}
+
+func (s *ResourceHistorySuite) TestListResourceHistoryServerError(c *gc.C) {
+	s.facade.historyResult.Error = &params.Error{
+		Message: `service "a-service" not found`,
+		Code:    params.CodeNotFound,
+	}
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	_, err := cl.ListResourceHistory("a-service")
+
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ResourceHistorySuite) TestRollbackResource(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.RollbackResource("a-service", "spam", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "BestAPIVersion", "FacadeCall")
+	s.stub.CheckCall(c, 1, "FacadeCall",
+		"RollbackResource",
+		&api.RollbackResourceArgs{
+			Entity: params.Entity{Tag: "service-a-service"},
+			Name:   "spam",
+			Number: 2,
+		},
+		&params.ErrorResult{},
+	)
+}
+
+func (s *ResourceHistorySuite) TestRollbackResourceServerError(c *gc.C) {
+	s.facade.rollbackResult.Error = &params.Error{
+		Message: `revision 2 of resource "a-service/spam" not found`,
+		Code:    params.CodeNotFound,
+	}
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.RollbackResource("a-service", "spam", 2)
+
+	c.Check(err, gc.ErrorMatches, `revision 2 of resource "a-service/spam" not found`)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ResourceHistorySuite) TestListResourceHistoryV1(c *gc.C) {
+	s.facade.ReturnBestAPIVersion = 1
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	_, err := cl.ListResourceHistory("a-service")
+
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+	s.stub.CheckCallNames(c, "BestAPIVersion")
+}
+
+func (s *ResourceHistorySuite) TestRollbackResourceV1(c *gc.C) {
+	s.facade.ReturnBestAPIVersion = 1
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.RollbackResource("a-service", "spam", 2)
+
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+	s.stub.CheckCallNames(c, "BestAPIVersion")
+}
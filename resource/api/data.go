@@ -126,6 +126,49 @@ type UnitResources struct {
 	DownloadProgress map[string]int64
 }
 
+// ResourceHistoryResults holds the resource history that results
+// from a bulk API call.
+type ResourceHistoryResults struct {
+	// Results is the list of resource history results.
+	Results []ResourceHistoryResult
+}
+
+// ResourceHistoryResult holds the resource history for a single
+// service.
+type ResourceHistoryResult struct {
+	params.ErrorResult
+
+	// Entries is the list of retained revisions of each of the
+	// service's resources.
+	Entries []ResourceHistoryEntry
+}
+
+// ResourceHistoryEntry contains info about a retained revision of
+// a resource.
+type ResourceHistoryEntry struct {
+	Resource
+
+	// Number identifies the revision among the resource's history.
+	Number int `json:"number"`
+
+	// Current indicates whether the revision is the one currently in
+	// use by the service.
+	Current bool `json:"current"`
+}
+
+// RollbackResourceArgs holds the arguments to the RollbackResource
+// API endpoint.
+type RollbackResourceArgs struct {
+	params.Entity
+
+	// Name identifies the service's resource to roll back.
+	Name string
+
+	// Number identifies the revision from the resource's history
+	// to roll back to.
+	Number int
+}
+
 // UploadResult is the response from an upload request.
 type UploadResult struct {
 	params.ErrorResult
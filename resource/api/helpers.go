@@ -144,6 +144,39 @@ func API2Resource(apiRes Resource) (resource.Resource, error) {
 	return res, nil
 }
 
+// HistoryEntry2API converts a resource.HistoryEntry into
+// a ResourceHistoryEntry struct.
+func HistoryEntry2API(entry resource.HistoryEntry) ResourceHistoryEntry {
+	return ResourceHistoryEntry{
+		Resource: Resource2API(entry.Resource),
+		Number:   entry.Number,
+		Current:  entry.Current,
+	}
+}
+
+// API2HistoryEntry converts an API ResourceHistoryEntry struct into
+// a resource.HistoryEntry.
+func API2HistoryEntry(apiEntry ResourceHistoryEntry) (resource.HistoryEntry, error) {
+	var entry resource.HistoryEntry
+
+	res, err := API2Resource(apiEntry.Resource)
+	if err != nil {
+		return entry, errors.Trace(err)
+	}
+
+	entry = resource.HistoryEntry{
+		Resource: res,
+		Number:   apiEntry.Number,
+		Current:  apiEntry.Current,
+	}
+
+	if err := entry.Validate(); err != nil {
+		return entry, errors.Trace(err)
+	}
+
+	return entry, nil
+}
+
 // CharmResource2API converts a charm resource into
 // a CharmResource struct.
 func CharmResource2API(res charmresource.Resource) CharmResource {
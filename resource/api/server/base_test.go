@@ -80,6 +80,8 @@ type stubDataStore struct {
 	ReturnGetPendingResource    resource.Resource
 	ReturnSetResource           resource.Resource
 	ReturnUpdatePendingResource resource.Resource
+	ReturnListResourceHistory   []resource.HistoryEntry
+	ReturnRollbackResource      resource.Resource
 }
 
 func (s *stubDataStore) ListResources(service string) (resource.ServiceResources, error) {
@@ -100,6 +102,24 @@ func (s *stubDataStore) AddPendingResource(service, userID string, chRes charmre
 	return s.ReturnAddPendingResource, nil
 }
 
+func (s *stubDataStore) ListResourceHistory(service string) ([]resource.HistoryEntry, error) {
+	s.stub.AddCall("ListResourceHistory", service)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnListResourceHistory, nil
+}
+
+func (s *stubDataStore) RollbackResource(service, name string, number int) (resource.Resource, error) {
+	s.stub.AddCall("RollbackResource", service, name, number)
+	if err := s.stub.NextErr(); err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+
+	return s.ReturnRollbackResource, nil
+}
+
 func (s *stubDataStore) GetResource(service, name string) (resource.Resource, error) {
 	s.stub.AddCall("GetResource", service, name)
 	if err := s.stub.NextErr(); err != nil {
@@ -25,7 +25,7 @@ var logger = loggo.GetLogger("juju.resource.api.server")
 
 const (
 	// Version is the version number of the current Facade.
-	Version = 1
+	Version = 2
 )
 
 // DataStore is the functionality of Juju's state needed for the resources API.
@@ -74,6 +74,29 @@ func NewFacade(store DataStore, newClient func() (CharmStore, error)) (*Facade,
 	return f, nil
 }
 
+// FacadeV1 is version 1 of the public API facade for resources, which
+// predates resource history.
+type FacadeV1 struct {
+	facade *Facade
+}
+
+// NewFacadeV1 returns a version 1 resources facade that exposes the
+// given facade's version 1 methods.
+func NewFacadeV1(facade *Facade) *FacadeV1 {
+	return &FacadeV1{facade}
+}
+
+// ListResources returns the list of resources for the given service.
+func (f FacadeV1) ListResources(args api.ListResourcesArgs) (api.ResourcesResults, error) {
+	return f.facade.ListResources(args)
+}
+
+// AddPendingResources adds the provided resources (info) to the Juju
+// model in a pending state.
+func (f FacadeV1) AddPendingResources(args api.AddPendingResourcesArgs) (api.AddPendingResourcesResult, error) {
+	return f.facade.AddPendingResources(args)
+}
+
 // resourceInfoStore is the portion of Juju's "state" needed
 // for the resources facade.
 type resourceInfoStore interface {
@@ -85,6 +108,14 @@ type resourceInfoStore interface {
 	// it is resolved. The returned ID is used to identify the pending
 	// resources when resolving it.
 	AddPendingResource(serviceID, userID string, chRes charmresource.Resource, r io.Reader) (string, error)
+
+	// ListResourceHistory returns the retained revisions of each of
+	// the service's resources.
+	ListResourceHistory(service string) ([]resource.HistoryEntry, error)
+
+	// RollbackResource makes the identified revision from the
+	// resource's history the service's current resource.
+	RollbackResource(service, name string, number int) (resource.Resource, error)
 }
 
 // ListResources returns the list of resources for the given service.
@@ -115,6 +146,50 @@ func (f Facade) ListResources(args api.ListResourcesArgs) (api.ResourcesResults,
 	return r, nil
 }
 
+// ListResourceHistory returns the retained revisions of each of the
+// given services' resources.
+func (f Facade) ListResourceHistory(args api.ListResourcesArgs) (api.ResourceHistoryResults, error) {
+	var r api.ResourceHistoryResults
+	r.Results = make([]api.ResourceHistoryResult, len(args.Entities))
+
+	for i, e := range args.Entities {
+		tag, apierr := parseServiceTag(e.Tag)
+		if apierr != nil {
+			r.Results[i].Error = apierr
+			continue
+		}
+
+		entries, err := f.store.ListResourceHistory(tag.Id())
+		if err != nil {
+			r.Results[i].Error = common.ServerError(err)
+			continue
+		}
+
+		for _, entry := range entries {
+			r.Results[i].Entries = append(r.Results[i].Entries, api.HistoryEntry2API(entry))
+		}
+	}
+	return r, nil
+}
+
+// RollbackResource makes the identified revision from the resource's
+// history the service's current resource. The service's units will
+// pick up the change when they next run upgrade-charm.
+func (f Facade) RollbackResource(args api.RollbackResourceArgs) (params.ErrorResult, error) {
+	var result params.ErrorResult
+
+	tag, apiErr := parseServiceTag(args.Tag)
+	if apiErr != nil {
+		result.Error = apiErr
+		return result, nil
+	}
+
+	if _, err := f.store.RollbackResource(tag.Id(), args.Name, args.Number); err != nil {
+		result.Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // AddPendingResources adds the provided resources (info) to the Juju
 // model in a pending state, meaning they are not available until
 // resolved.
@@ -0,0 +1,114 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package server_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/resource/api"
+	"github.com/juju/juju/resource/api/server"
+)
+
+var _ = gc.Suite(&ResourceHistorySuite{})
+
+type ResourceHistorySuite struct {
+	BaseSuite
+}
+
+func (s *ResourceHistorySuite) TestListResourceHistoryOkay(c *gc.C) {
+	res1, apiRes1 := newResource(c, "spam", "a-user", "spamspamspam")
+	res2, apiRes2 := newResource(c, "spam", "a-user", "spam")
+	s.data.ReturnListResourceHistory = []resource.HistoryEntry{{
+		Resource: res1,
+		Number:   1,
+	}, {
+		Resource: res2,
+		Number:   2,
+		Current:  true,
+	}}
+	facade, err := server.NewFacade(s.data, s.newCSClient)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := facade.ListResourceHistory(api.ListResourcesArgs{
+		Entities: []params.Entity{{
+			Tag: "service-a-service",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(results, jc.DeepEquals, api.ResourceHistoryResults{
+		Results: []api.ResourceHistoryResult{{
+			Entries: []api.ResourceHistoryEntry{{
+				Resource: apiRes1,
+				Number:   1,
+			}, {
+				Resource: apiRes2,
+				Number:   2,
+				Current:  true,
+			}},
+		}},
+	})
+	s.stub.CheckCallNames(c, "ListResourceHistory")
+	s.stub.CheckCall(c, 0, "ListResourceHistory", "a-service")
+}
+
+func (s *ResourceHistorySuite) TestListResourceHistoryError(c *gc.C) {
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(failure)
+	facade, err := server.NewFacade(s.data, s.newCSClient)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := facade.ListResourceHistory(api.ListResourcesArgs{
+		Entities: []params.Entity{{
+			Tag: "service-a-service",
+		}, {
+			Tag: "unit-a-service-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, "<failure>")
+	c.Check(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `"unit-a-service-0" is not a valid service tag`,
+		Code:    params.CodeBadRequest,
+	})
+	s.stub.CheckCallNames(c, "ListResourceHistory")
+}
+
+func (s *ResourceHistorySuite) TestRollbackResourceOkay(c *gc.C) {
+	facade, err := server.NewFacade(s.data, s.newCSClient)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.RollbackResource(api.RollbackResourceArgs{
+		Entity: params.Entity{Tag: "service-a-service"},
+		Name:   "spam",
+		Number: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result.Error, gc.IsNil)
+	s.stub.CheckCallNames(c, "RollbackResource")
+	s.stub.CheckCall(c, 0, "RollbackResource", "a-service", "spam", 2)
+}
+
+func (s *ResourceHistorySuite) TestRollbackResourceNotFound(c *gc.C) {
+	s.stub.SetErrors(errors.NotFoundf(`revision 2 of resource "a-service/spam"`))
+	facade, err := server.NewFacade(s.data, s.newCSClient)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := facade.RollbackResource(api.RollbackResourceArgs{
+		Entity: params.Entity{Tag: "service-a-service"},
+		Name:   "spam",
+		Number: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(result.Error, gc.ErrorMatches, `revision 2 of resource "a-service/spam" not found`)
+	c.Check(result.Error.Code, gc.Equals, params.CodeNotFound)
+}
@@ -74,3 +74,17 @@ type FormattedServiceDetails struct {
 // FormattedDetailResource is the data for the tabular output for juju resources
 // <unit> --details.
 type FormattedUnitDetails []FormattedDetailResource
+
+// FormattedHistoryEntry holds the formatted representation of a retained
+// revision of a service's resource.
+type FormattedHistoryEntry struct {
+	Name        string    `json:"name" yaml:"name"`
+	Number      int       `json:"number" yaml:"number"`
+	Revision    int       `json:"revision" yaml:"revision"`
+	Fingerprint string    `json:"fingerprint" yaml:"fingerprint"`
+	Size        int64     `json:"size" yaml:"size"`
+	Origin      string    `json:"origin" yaml:"origin"`
+	Username    string    `json:"username,omitempty" yaml:"username,omitempty"`
+	Timestamp   time.Time `json:"timestamp" yaml:"timestamp"`
+	Current     bool      `json:"current" yaml:"current"`
+}
@@ -77,6 +77,26 @@ func FormatSvcResource(res resource.Resource) FormattedSvcResource {
 	}
 }
 
+// FormatHistory converts the resource history entries into a
+// formatted value for display on the command line.
+func FormatHistory(entries []resource.HistoryEntry) []FormattedHistoryEntry {
+	formatted := make([]FormattedHistoryEntry, len(entries))
+	for i, entry := range entries {
+		formatted[i] = FormattedHistoryEntry{
+			Name:        entry.Name,
+			Number:      entry.Number,
+			Revision:    entry.Revision,
+			Fingerprint: entry.Fingerprint.String(),
+			Size:        entry.Size,
+			Origin:      entry.Origin.String(),
+			Username:    entry.Username,
+			Timestamp:   entry.Timestamp,
+			Current:     entry.Current,
+		}
+	}
+	return formatted
+}
+
 func formatServiceResources(sr resource.ServiceResources) (FormattedServiceInfo, error) {
 	var formatted FormattedServiceInfo
 	updates, err := sr.Updates()
@@ -54,6 +54,8 @@ func FormatSvcTabular(value interface{}) ([]byte, error) {
 		return formatServiceDetailTabular(resources), nil
 	case FormattedUnitDetails:
 		return formatUnitDetailTabular(resources), nil
+	case []FormattedHistoryEntry:
+		return formatHistoryTabular(resources), nil
 	default:
 		return nil, errors.Errorf("unexpected type for data: %T", resources)
 	}
@@ -196,3 +198,40 @@ func (b byUnitID) Less(i, j int) bool {
 	}
 	return b[i].Expected.Name < b[j].Expected.Name
 }
+
+// historyFingerprintLen is the number of characters of each fingerprint
+// shown in the tabular resource history. It is enough to tell the
+// revisions apart.
+const historyFingerprintLen = 12
+
+func formatHistoryTabular(entries []FormattedHistoryEntry) []byte {
+	var out bytes.Buffer
+
+	fmt.Fprintln(&out, "[History]")
+	tw := tabwriter.NewWriter(&out, 0, 1, 1, ' ', 0)
+	fmt.Fprintln(tw, "RESOURCE\tHISTORY\tREVISION\tFINGERPRINT\tUPLOADED BY\tTIMESTAMP\tCURRENT")
+
+	for _, e := range entries {
+		fingerprint := e.Fingerprint
+		if len(fingerprint) > historyFingerprintLen {
+			fingerprint = fingerprint[:historyFingerprintLen]
+		}
+		uploadedBy := e.Username
+		if uploadedBy == "" {
+			uploadedBy = "-"
+		}
+		// the column headers must be kept in sync with these.
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+			e.Name,
+			e.Number,
+			e.Revision,
+			fingerprint,
+			uploadedBy,
+			e.Timestamp.UTC().Format("2006-01-02T15:04"),
+			usedYesNo(e.Current),
+		)
+	}
+	tw.Flush()
+
+	return out.Bytes()
+}
@@ -17,6 +17,9 @@ import (
 type ShowServiceClient interface {
 	// ListResources returns info about resources for services in the model.
 	ListResources(services []string) ([]resource.ServiceResources, error)
+	// ListResourceHistory returns the retained revisions of each of
+	// the service's resources.
+	ListResourceHistory(service string) ([]resource.HistoryEntry, error)
 	// Close closes the connection.
 	Close() error
 }
@@ -34,6 +37,7 @@ type ShowServiceCommand struct {
 	modelcmd.ModelCommandBase
 
 	details bool
+	history bool
 	deps    ShowServiceDeps
 	out     cmd.Output
 	target  string
@@ -56,6 +60,10 @@ func (c *ShowServiceCommand) Info() *cmd.Info {
 This command shows the resources required by and those in use by an existing
 service or unit in your model.  When run for a service, it will also show any
 updates available for resources from the charmstore.
+
+With --history, the revisions of each of the service's resources that are
+retained by the controller are shown instead. The service may be rolled back
+to any of them with "juju attach --revision".
 `,
 	}
 }
@@ -70,6 +78,7 @@ func (c *ShowServiceCommand) SetFlags(f *gnuflag.FlagSet) {
 	})
 
 	f.BoolVar(&c.details, "details", false, "show detailed information about resources used by each unit.")
+	f.BoolVar(&c.history, "history", false, "show the retained revisions of each of the service's resources.")
 }
 
 // Init implements cmd.Command.Init. It will return an error satisfying
@@ -82,6 +91,14 @@ func (c *ShowServiceCommand) Init(args []string) error {
 	if err := cmd.CheckEmpty(args[1:]); err != nil {
 		return errors.NewBadRequest(err, "")
 	}
+	if c.history {
+		if c.details {
+			return errors.NewBadRequest(nil, "--history and --details cannot be used together")
+		}
+		if !names.IsValidService(c.target) {
+			return errors.NewBadRequest(nil, "--history requires a service name")
+		}
+	}
 	return nil
 }
 
@@ -93,6 +110,14 @@ func (c *ShowServiceCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
+	if c.history {
+		entries, err := apiclient.ListResourceHistory(c.target)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return c.out.Write(ctx, FormatHistory(entries))
+	}
+
 	var unit string
 	var service string
 	if names.IsValidService(c.target) {
@@ -4,6 +4,8 @@
 package cmd
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	jujucmd "github.com/juju/cmd"
@@ -56,6 +58,20 @@ func (*ShowServiceSuite) TestInitTooManyArgs(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
 }
 
+func (*ShowServiceSuite) TestInitHistoryUnit(c *gc.C) {
+	s := ShowServiceCommand{history: true}
+
+	err := s.Init([]string{"foo/0"})
+	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
+}
+
+func (*ShowServiceSuite) TestInitHistoryDetails(c *gc.C) {
+	s := ShowServiceCommand{history: true, details: true}
+
+	err := s.Init([]string{"foo"})
+	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
+}
+
 func (s *ShowServiceSuite) TestInfo(c *gc.C) {
 	var command ShowServiceCommand
 	info := command.Info()
@@ -69,6 +85,10 @@ func (s *ShowServiceSuite) TestInfo(c *gc.C) {
 This command shows the resources required by and those in use by an existing
 service or unit in your model.  When run for a service, it will also show any
 updates available for resources from the charmstore.
+
+With --history, the revisions of each of the service's resources that are
+retained by the controller are shown instead. The service may be rolled back
+to any of them with "juju attach --revision".
 `,
 	})
 }
@@ -552,6 +572,53 @@ charlie  2011-11-11T11:11 2012-12-12T12:12 (fetching: 0%)
 	s.stubDeps.stub.CheckCall(c, 1, "ListResources", []string{"svc"})
 }
 
+func (s *ShowServiceSuite) TestRunHistory(c *gc.C) {
+	fp1, err := charmresource.GenerateFingerprint(strings.NewReader("old data"))
+	c.Assert(err, jc.ErrorIsNil)
+	fp2, err := charmresource.GenerateFingerprint(strings.NewReader("new data"))
+	c.Assert(err, jc.ErrorIsNil)
+	website := func(fp charmresource.Fingerprint, day int) resource.Resource {
+		return resource.Resource{
+			Resource: charmresource.Resource{
+				Meta: charmresource.Meta{
+					Name: "website",
+				},
+				Origin:      charmresource.OriginUpload,
+				Fingerprint: fp,
+			},
+			Username:  "Bill User",
+			Timestamp: time.Date(2012, 12, day, 12, 12, 12, 0, time.UTC),
+		}
+	}
+	s.stubDeps.client.ReturnHistory = []resource.HistoryEntry{{
+		Resource: website(fp1, 11),
+		Number:   1,
+		Current:  true,
+	}, {
+		Resource: website(fp2, 12),
+		Number:   2,
+	}}
+
+	cmd := &ShowServiceCommand{
+		deps: ShowServiceDeps{
+			NewClient: s.stubDeps.NewClient,
+		},
+	}
+
+	code, stdout, stderr := runCmd(c, cmd, "svc", "--history")
+	c.Check(code, gc.Equals, 0)
+	c.Check(stderr, gc.Equals, "")
+
+	c.Check(stdout, gc.Equals, fmt.Sprintf(`
+[History]
+RESOURCE HISTORY REVISION FINGERPRINT  UPLOADED BY TIMESTAMP        CURRENT
+website  1       0        %s Bill User   2012-12-11T12:12 yes
+website  2       0        %s Bill User   2012-12-12T12:12 no
+`[1:], fp1.String()[:12], fp2.String()[:12]))
+
+	s.stubDeps.stub.CheckCall(c, 1, "ListResourceHistory", "svc")
+}
+
 type stubShowServiceDeps struct {
 	stub   *testing.Stub
 	client *stubServiceClient
@@ -569,6 +636,15 @@ func (s *stubShowServiceDeps) NewClient(c *ShowServiceCommand) (ShowServiceClien
 type stubServiceClient struct {
 	stub            *testing.Stub
 	ReturnResources []resource.ServiceResources
+	ReturnHistory   []resource.HistoryEntry
+}
+
+func (s *stubServiceClient) ListResourceHistory(service string) ([]resource.HistoryEntry, error) {
+	s.stub.AddCall("ListResourceHistory", service)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.ReturnHistory, nil
 }
 
 func (s *stubServiceClient) ListResources(services []string) ([]resource.ServiceResources, error) {
@@ -5,9 +5,11 @@ package cmd
 
 import (
 	"io"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -17,6 +19,10 @@ type UploadClient interface {
 	// Upload sends the resource to Juju.
 	Upload(service, name, filename string, resource io.ReadSeeker) error
 
+	// RollbackResource makes the revision with the given number
+	// from the resource's history the service's current resource.
+	RollbackResource(service, name string, number int) error
+
 	// Close closes the client.
 	Close() error
 }
@@ -43,6 +49,9 @@ type UploadCommand struct {
 	modelcmd.ModelCommandBase
 	service      string
 	resourceFile resourceFile
+	// revision identifies the revision from the resource's history
+	// to roll back to, if any.
+	revision int
 }
 
 // NewUploadCommand returns a new command that lists resources defined
@@ -60,10 +69,24 @@ func (c *UploadCommand) Info() *cmd.Info {
 		Doc: `
 This command uploads a file from your local disk to the juju controller to be
 used as a resource for a service.
+
+With --revision, no file is uploaded. Instead the service is rolled back to
+the given revision from the resource's history, as numbered in the HISTORY
+column of "juju list-resources --history", and its units run upgrade-charm to
+pick it up:
+
+    juju attach mysql data --revision 3
 `,
 	}
 }
 
+// SetFlags implements cmd.Command.SetFlags.
+func (c *UploadCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.IntVar(&c.revision, "revision", 0, "roll the resource back to this revision from its history")
+	f.IntVar(&c.revision, "history", 0, "")
+}
+
 // Init implements cmd.Command.Init. It will return an error satisfying
 // errors.BadRequest if you give it an incorrect number of arguments.
 func (c *UploadCommand) Init(args []string) error {
@@ -80,7 +103,11 @@ func (c *UploadCommand) Init(args []string) error {
 	}
 	c.service = service
 
-	if err := c.addResourceFile(args[1]); err != nil {
+	if c.revision != 0 {
+		if err := c.setRollbackResource(args[1]); err != nil {
+			return errors.Trace(err)
+		}
+	} else if err := c.addResourceFile(args[1]); err != nil {
 		return errors.Trace(err)
 	}
 	if err := cmd.CheckEmpty(args[2:]); err != nil {
@@ -106,6 +133,22 @@ func (c *UploadCommand) addResourceFile(arg string) error {
 	return nil
 }
 
+// setRollbackResource saves the name of the resource to roll back
+// to c.revision.
+func (c *UploadCommand) setRollbackResource(name string) error {
+	if c.revision < 0 {
+		return errors.NotValidf("revision %d", c.revision)
+	}
+	if name == "" || strings.Contains(name, "=") {
+		return errors.NewBadRequest(nil, "--revision requires a resource name instead of name=file")
+	}
+	c.resourceFile = resourceFile{
+		service: c.service,
+		name:    name,
+	}
+	return nil
+}
+
 // Run implements cmd.Command.Run.
 func (c *UploadCommand) Run(*cmd.Context) error {
 	apiclient, err := c.deps.NewClient(c)
@@ -114,6 +157,14 @@ func (c *UploadCommand) Run(*cmd.Context) error {
 	}
 	defer apiclient.Close()
 
+	if c.revision != 0 {
+		err := apiclient.RollbackResource(c.service, c.resourceFile.name, c.revision)
+		if err != nil {
+			return errors.Annotatef(err, "failed to roll back resource %q", c.resourceFile.name)
+		}
+		return nil
+	}
+
 	if err := c.upload(c.resourceFile, apiclient); err != nil {
 		return errors.Annotatef(err, "failed to upload resource %q", c.resourceFile.name)
 	}
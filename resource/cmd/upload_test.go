@@ -84,6 +84,31 @@ func (*UploadSuite) TestInitTwoResources(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
 }
 
+func (*UploadSuite) TestInitRevision(c *gc.C) {
+	u := UploadCommand{revision: 3}
+
+	err := u.Init([]string{"foo", "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(u.resourceFile, gc.DeepEquals, resourceFile{
+		service: "foo",
+		name:    "bar",
+	})
+}
+
+func (*UploadSuite) TestInitRevisionWithFile(c *gc.C) {
+	u := UploadCommand{revision: 3}
+
+	err := u.Init([]string{"foo", "bar=baz"})
+	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
+}
+
+func (*UploadSuite) TestInitNegativeRevision(c *gc.C) {
+	u := UploadCommand{revision: -1}
+
+	err := u.Init([]string{"foo", "bar"})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
 func (s *UploadSuite) TestInfo(c *gc.C) {
 	var command UploadCommand
 	info := command.Info()
@@ -95,6 +120,13 @@ func (s *UploadSuite) TestInfo(c *gc.C) {
 		Doc: `
 This command uploads a file from your local disk to the juju controller to be
 used as a resource for a service.
+
+With --revision, no file is uploaded. Instead the service is rolled back to
+the given revision from the resource's history, as numbered in the HISTORY
+column of "juju list-resources --history", and its units run upgrade-charm to
+pick it up:
+
+    juju attach mysql data --revision 3
 `,
 	})
 }
@@ -129,6 +161,31 @@ func (s *UploadSuite) TestRun(c *gc.C) {
 	s.stub.CheckCall(c, 2, "Upload", "svc", "foo", "bar", file)
 }
 
+func (s *UploadSuite) TestRunRevision(c *gc.C) {
+	u := UploadCommand{
+		deps: UploadDeps{
+			NewClient:    s.stubDeps.NewClient,
+			OpenResource: s.stubDeps.OpenResource,
+		},
+		resourceFile: resourceFile{
+			service: "svc",
+			name:    "foo",
+		},
+		service:  "svc",
+		revision: 2,
+	}
+
+	err := u.Run(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"NewClient",
+		"RollbackResource",
+		"Close",
+	)
+	s.stub.CheckCall(c, 1, "RollbackResource", "svc", "foo", 2)
+}
+
 type stubUploadDeps struct {
 	stub   *testing.Stub
 	file   ReadSeekCloser
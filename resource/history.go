@@ -0,0 +1,36 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resource
+
+import (
+	"github.com/juju/errors"
+)
+
+// HistoryEntry is a previously stored revision of a service's resource.
+// The controller retains the blobs for a limited number of the most
+// recent revisions of each resource, so that a service may be rolled
+// back to one of them.
+type HistoryEntry struct {
+	Resource
+
+	// Number identifies the revision among the resource's history.
+	// Numbers start at 1 and increase with each new blob stored for
+	// the resource; they are never reused.
+	Number int
+
+	// Current indicates whether the revision is the one currently in
+	// use by the service.
+	Current bool
+}
+
+// Validate ensures that the entry is valid.
+func (entry HistoryEntry) Validate() error {
+	if err := entry.Resource.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if entry.Number <= 0 {
+		return errors.NewNotValid(nil, "history number must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resource_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/resource"
+)
+
+type HistoryEntrySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&HistoryEntrySuite{})
+
+func newHistoryEntry(c *gc.C, number int) resource.HistoryEntry {
+	return resource.HistoryEntry{
+		Resource: resource.Resource{
+			Resource:  newFullCharmResource(c, "spam"),
+			ID:        "a-service/spam",
+			ServiceID: "a-service",
+			Username:  "a-user",
+			Timestamp: time.Now(),
+		},
+		Number: number,
+	}
+}
+
+func (HistoryEntrySuite) TestValidateOkay(c *gc.C) {
+	entry := newHistoryEntry(c, 1)
+
+	err := entry.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (HistoryEntrySuite) TestValidateBadNumber(c *gc.C) {
+	entry := newHistoryEntry(c, 0)
+
+	err := entry.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `history number must be positive`)
+}
+
+func (HistoryEntrySuite) TestValidateBadResource(c *gc.C) {
+	entry := newHistoryEntry(c, 1)
+	entry.ServiceID = ""
+
+	err := entry.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `missing service ID`)
+}
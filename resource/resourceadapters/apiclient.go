@@ -10,7 +10,6 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/api/client"
-	"github.com/juju/juju/resource/api/server"
 )
 
 // NewAPIClient is mostly a copy of the newClient code in
@@ -26,7 +25,7 @@ func NewAPIClient(newAPICaller func() (api.Connection, error)) (*client.Client,
 }
 
 func newAPIClient(apiCaller api.Connection) (*client.Client, error) {
-	caller := base.NewFacadeCallerForVersion(apiCaller, resource.ComponentName, server.Version)
+	caller := base.NewFacadeCaller(apiCaller, resource.ComponentName)
 
 	httpClient, err := apiCaller.HTTPClient()
 	if err != nil {
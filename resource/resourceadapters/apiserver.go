@@ -38,6 +38,16 @@ func NewPublicFacade(st *corestate.State, _ *common.Resources, authorizer common
 	return facade, nil
 }
 
+// NewPublicFacadeV1 provides version 1 of the public API facade for
+// resources, which predates resource history.
+func NewPublicFacadeV1(st *corestate.State, resources *common.Resources, authorizer common.Authorizer) (*server.FacadeV1, error) {
+	facade, err := NewPublicFacade(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return server.NewFacadeV1(facade), nil
+}
+
 // NewUploadHandler returns a new HTTP handler for the given args.
 func NewUploadHandler(args apihttp.NewHandlerArgs) http.Handler {
 	return server.NewLegacyHTTPHandler(
@@ -56,6 +56,14 @@ type resourcePersistence interface {
 	// NewResolvePendingResourceOps generates mongo transaction operations
 	// to set the identified resource as active.
 	NewResolvePendingResourceOps(resID, pendingID string) ([]txn.Op, error)
+
+	// ListResourceHistory returns the retained revisions of each of
+	// the service's resources.
+	ListResourceHistory(serviceID string) ([]resource.HistoryEntry, error)
+
+	// RollbackResource makes the identified revision from the
+	// resource's history the service's current resource.
+	RollbackResource(id string, number int) (resource.Resource, error)
 }
 
 // StagedResource represents resource info that has been added to the
@@ -247,7 +255,17 @@ func (st resourceState) storeResource(res resource.Resource, r io.Reader) error
 	// is stored separately and adding to both should be an atomic
 	// operation.
 
-	storagePath := storagePath(res.Name, res.ServiceID, res.PendingID)
+	uniqueID := res.PendingID
+	if uniqueID == "" {
+		// Each blob is stored separately so that those of earlier
+		// revisions remain available in the resource's history.
+		var err error
+		uniqueID, err = st.newPendingID()
+		if err != nil {
+			return errors.Annotate(err, "could not generate storage ID")
+		}
+	}
+	storagePath := storagePath(res.Name, res.ServiceID, uniqueID)
 	staged, err := st.persist.StageResource(res, storagePath)
 	if err != nil {
 		return errors.Trace(err)
@@ -274,6 +292,35 @@ func (st resourceState) storeResource(res resource.Resource, r io.Reader) error
 	return nil
 }
 
+// ListResourceHistory returns the retained revisions of each of the
+// service's resources.
+func (st resourceState) ListResourceHistory(serviceID string) ([]resource.HistoryEntry, error) {
+	entries, err := st.persist.ListResourceHistory(serviceID)
+	if err != nil {
+		if err := st.raw.VerifyService(serviceID); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return nil, errors.Trace(err)
+	}
+	return entries, nil
+}
+
+// RollbackResource makes the identified revision from the resource's
+// history the service's current resource. The service's units will
+// pick up the change when they next run upgrade-charm.
+func (st resourceState) RollbackResource(serviceID, name string, number int) (resource.Resource, error) {
+	logger.Tracef("rolling back resource %q for service %q to revision %d", name, serviceID, number)
+	id := newResourceID(serviceID, name)
+	res, err := st.persist.RollbackResource(id, number)
+	if err != nil {
+		if err := st.raw.VerifyService(serviceID); err != nil {
+			return resource.Resource{}, errors.Trace(err)
+		}
+		return resource.Resource{}, errors.Trace(err)
+	}
+	return res, nil
+}
+
 // OpenResource returns metadata about the resource, and a reader for
 // the resource.
 func (st resourceState) OpenResource(serviceID, name string) (resource.Resource, io.ReadCloser, error) {
@@ -406,12 +453,12 @@ func newResourceID(serviceID, name string) string {
 // be unique and that it be organized in a structured way. In this case
 // we start with a top-level (the service), then under that service use
 // the "resources" section. The provided ID is located under there.
-func storagePath(name, serviceID, pendingID string) string {
+func storagePath(name, serviceID, uniqueID string) string {
 	// TODO(ericsnow) Use services/<service>/resources/<resource>?
 	id := name
-	if pendingID != "" {
+	if uniqueID != "" {
 		// TODO(ericsnow) How to resolve this later?
-		id += "-" + pendingID
+		id += "-" + uniqueID
 	}
 	return path.Join("service-"+serviceID, "resources", id)
 }
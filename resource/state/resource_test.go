@@ -149,10 +149,12 @@ func (s *ResourceSuite) TestSetResourceOkay(c *gc.C) {
 	expected.Timestamp = s.timestamp
 	chRes := expected.Resource
 	hash := chRes.Fingerprint.String()
-	path := "service-a-service/resources/spam"
+	path := "service-a-service/resources/spam-some-unique-id"
 	file := &stubReader{stub: s.stub}
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
+	st.newPendingID = s.newPendingID
+	s.pendingID = "some-unique-id"
 	s.stub.ResetCalls()
 
 	res, err := st.SetResource("a-service", "a-user", chRes, file)
@@ -160,12 +162,13 @@ func (s *ResourceSuite) TestSetResourceOkay(c *gc.C) {
 
 	s.stub.CheckCallNames(c,
 		"currentTimestamp",
+		"newPendingID",
 		"StageResource",
 		"PutAndCheckHash",
 		"Activate",
 	)
-	s.stub.CheckCall(c, 1, "StageResource", expected, path)
-	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, res.Size, hash)
+	s.stub.CheckCall(c, 2, "StageResource", expected, path)
+	s.stub.CheckCall(c, 3, "PutAndCheckHash", path, file, res.Size, hash)
 	c.Check(res, jc.DeepEquals, resource.Resource{
 		Resource:  chRes,
 		ID:        "a-service/" + res.Name,
@@ -216,133 +219,147 @@ func (s *ResourceSuite) TestSetResourceBadResource(c *gc.C) {
 func (s *ResourceSuite) TestSetResourceStagingFailure(c *gc.C) {
 	expected := newUploadResource(c, "spam", "spamspamspam")
 	expected.Timestamp = s.timestamp
-	path := "service-a-service/resources/spam"
+	path := "service-a-service/resources/spam-some-unique-id"
 	file := &stubReader{stub: s.stub}
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
+	st.newPendingID = s.newPendingID
+	s.pendingID = "some-unique-id"
 	s.stub.ResetCalls()
 	failure := errors.New("<failure>")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, failure, ignoredErr)
+	s.stub.SetErrors(nil, nil, failure, ignoredErr)
 
 	_, err := st.SetResource("a-service", "a-user", expected.Resource, file)
 
 	c.Check(errors.Cause(err), gc.Equals, failure)
-	s.stub.CheckCallNames(c, "currentTimestamp", "StageResource")
-	s.stub.CheckCall(c, 1, "StageResource", expected, path)
+	s.stub.CheckCallNames(c, "currentTimestamp", "newPendingID", "StageResource")
+	s.stub.CheckCall(c, 2, "StageResource", expected, path)
 }
 
 func (s *ResourceSuite) TestSetResourcePutFailureBasic(c *gc.C) {
 	expected := newUploadResource(c, "spam", "spamspamspam")
 	expected.Timestamp = s.timestamp
 	hash := expected.Fingerprint.String()
-	path := "service-a-service/resources/spam"
+	path := "service-a-service/resources/spam-some-unique-id"
 	file := &stubReader{stub: s.stub}
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
+	st.newPendingID = s.newPendingID
+	s.pendingID = "some-unique-id"
 	s.stub.ResetCalls()
 	failure := errors.New("<failure>")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, failure, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, failure, nil, ignoredErr)
 
 	_, err := st.SetResource("a-service", "a-user", expected.Resource, file)
 
 	c.Check(errors.Cause(err), gc.Equals, failure)
 	s.stub.CheckCallNames(c,
 		"currentTimestamp",
+		"newPendingID",
 		"StageResource",
 		"PutAndCheckHash",
 		"Unstage",
 	)
-	s.stub.CheckCall(c, 1, "StageResource", expected, path)
-	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, expected.Size, hash)
+	s.stub.CheckCall(c, 2, "StageResource", expected, path)
+	s.stub.CheckCall(c, 3, "PutAndCheckHash", path, file, expected.Size, hash)
 }
 
 func (s *ResourceSuite) TestSetResourcePutFailureExtra(c *gc.C) {
 	expected := newUploadResource(c, "spam", "spamspamspam")
 	expected.Timestamp = s.timestamp
 	hash := expected.Fingerprint.String()
-	path := "service-a-service/resources/spam"
+	path := "service-a-service/resources/spam-some-unique-id"
 	file := &stubReader{stub: s.stub}
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
+	st.newPendingID = s.newPendingID
+	s.pendingID = "some-unique-id"
 	s.stub.ResetCalls()
 	failure := errors.New("<failure>")
 	extraErr := errors.New("<just not your day>")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, failure, extraErr, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, failure, extraErr, ignoredErr)
 
 	_, err := st.SetResource("a-service", "a-user", expected.Resource, file)
 
 	c.Check(errors.Cause(err), gc.Equals, failure)
 	s.stub.CheckCallNames(c,
 		"currentTimestamp",
+		"newPendingID",
 		"StageResource",
 		"PutAndCheckHash",
 		"Unstage",
 	)
-	s.stub.CheckCall(c, 1, "StageResource", expected, path)
-	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, expected.Size, hash)
+	s.stub.CheckCall(c, 2, "StageResource", expected, path)
+	s.stub.CheckCall(c, 3, "PutAndCheckHash", path, file, expected.Size, hash)
 }
 
 func (s *ResourceSuite) TestSetResourceSetFailureBasic(c *gc.C) {
 	expected := newUploadResource(c, "spam", "spamspamspam")
 	expected.Timestamp = s.timestamp
 	hash := expected.Fingerprint.String()
-	path := "service-a-service/resources/spam"
+	path := "service-a-service/resources/spam-some-unique-id"
 	file := &stubReader{stub: s.stub}
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
+	st.newPendingID = s.newPendingID
+	s.pendingID = "some-unique-id"
 	s.stub.ResetCalls()
 	failure := errors.New("<failure>")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, nil, failure, nil, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, nil, failure, nil, nil, ignoredErr)
 
 	_, err := st.SetResource("a-service", "a-user", expected.Resource, file)
 
 	c.Check(errors.Cause(err), gc.Equals, failure)
 	s.stub.CheckCallNames(c,
 		"currentTimestamp",
+		"newPendingID",
 		"StageResource",
 		"PutAndCheckHash",
 		"Activate",
 		"Remove",
 		"Unstage",
 	)
-	s.stub.CheckCall(c, 1, "StageResource", expected, path)
-	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, expected.Size, hash)
-	s.stub.CheckCall(c, 4, "Remove", path)
+	s.stub.CheckCall(c, 2, "StageResource", expected, path)
+	s.stub.CheckCall(c, 3, "PutAndCheckHash", path, file, expected.Size, hash)
+	s.stub.CheckCall(c, 5, "Remove", path)
 }
 
 func (s *ResourceSuite) TestSetResourceSetFailureExtra(c *gc.C) {
 	expected := newUploadResource(c, "spam", "spamspamspam")
 	expected.Timestamp = s.timestamp
 	hash := expected.Fingerprint.String()
-	path := "service-a-service/resources/spam"
+	path := "service-a-service/resources/spam-some-unique-id"
 	file := &stubReader{stub: s.stub}
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
+	st.newPendingID = s.newPendingID
+	s.pendingID = "some-unique-id"
 	s.stub.ResetCalls()
 	failure := errors.New("<failure>")
 	extraErr1 := errors.New("<just not your day>")
 	extraErr2 := errors.New("<wow...just wow>")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, nil, failure, extraErr1, extraErr2, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, nil, failure, extraErr1, extraErr2, ignoredErr)
 
 	_, err := st.SetResource("a-service", "a-user", expected.Resource, file)
 
 	c.Check(errors.Cause(err), gc.Equals, failure)
 	s.stub.CheckCallNames(c,
 		"currentTimestamp",
+		"newPendingID",
 		"StageResource",
 		"PutAndCheckHash",
 		"Activate",
 		"Remove",
 		"Unstage",
 	)
-	s.stub.CheckCall(c, 1, "StageResource", expected, path)
-	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, expected.Size, hash)
-	s.stub.CheckCall(c, 4, "Remove", path)
+	s.stub.CheckCall(c, 2, "StageResource", expected, path)
+	s.stub.CheckCall(c, 3, "PutAndCheckHash", path, file, expected.Size, hash)
+	s.stub.CheckCall(c, 5, "Remove", path)
 }
 
 func (s *ResourceSuite) TestUpdatePendingResourceOkay(c *gc.C) {
@@ -605,6 +622,51 @@ func (s *ResourceSuite) TestNewResourcePendingResourcesOps(c *gc.C) {
 	c.Check(ops, jc.DeepEquals, expected)
 }
 
+func (s *ResourceSuite) TestListResourceHistory(c *gc.C) {
+	res := newUploadResource(c, "spam", "spamspamspam")
+	expected := []resource.HistoryEntry{{
+		Resource: res,
+		Number:   1,
+		Current:  true,
+	}}
+	s.persist.ReturnListResourceHistory = expected
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+
+	entries, err := st.ListResourceHistory("a-service")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(entries, jc.DeepEquals, expected)
+	s.stub.CheckCallNames(c, "ListResourceHistory")
+	s.stub.CheckCall(c, 0, "ListResourceHistory", "a-service")
+}
+
+func (s *ResourceSuite) TestRollbackResource(c *gc.C) {
+	expected := newUploadResource(c, "spam", "spamspamspam")
+	s.persist.ReturnRollbackResource = expected
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+
+	res, err := st.RollbackResource("a-service", "spam", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(res, jc.DeepEquals, expected)
+	s.stub.CheckCallNames(c, "RollbackResource")
+	s.stub.CheckCall(c, 0, "RollbackResource", "a-service/spam", 2)
+}
+
+func (s *ResourceSuite) TestRollbackResourceError(c *gc.C) {
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(failure)
+
+	_, err := st.RollbackResource("a-service", "spam", 2)
+
+	c.Check(errors.Cause(err), gc.Equals, failure)
+	s.stub.CheckCallNames(c, "RollbackResource", "VerifyService")
+}
+
 func (s *ResourceSuite) TestUnitSetterEOF(c *gc.C) {
 	r := unitSetter{
 		ReadCloser: ioutil.NopCloser(&bytes.Buffer{}),
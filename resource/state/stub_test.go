@@ -66,6 +66,8 @@ type stubPersistence struct {
 	ReturnGetResourcePath              string
 	ReturnStageResource                *stubStagedResource
 	ReturnNewResolvePendingResourceOps [][]txn.Op
+	ReturnListResourceHistory          []resource.HistoryEntry
+	ReturnRollbackResource             resource.Resource
 
 	CallsForNewResolvePendingResourceOps map[string]string
 }
@@ -161,6 +163,24 @@ func (s *stubPersistence) NewResolvePendingResourceOps(resID, pendingID string)
 	return ops, nil
 }
 
+func (s *stubPersistence) ListResourceHistory(serviceID string) ([]resource.HistoryEntry, error) {
+	s.stub.AddCall("ListResourceHistory", serviceID)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnListResourceHistory, nil
+}
+
+func (s *stubPersistence) RollbackResource(id string, number int) (resource.Resource, error) {
+	s.stub.AddCall("RollbackResource", id, number)
+	if err := s.stub.NextErr(); err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+
+	return s.ReturnRollbackResource, nil
+}
+
 type stubStagedResource struct {
 	stub *testing.Stub
 }
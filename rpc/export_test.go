@@ -4,3 +4,5 @@
 package rpc
 
 const CodeNotImplemented = codeNotImplemented
+
+const CodeRetryAfter = codeRetryAfter
@@ -249,6 +249,31 @@ func (a *ChangeAPIMethods) RemoveAPI() {
 	a.r.conn.Serve(nil, nil)
 }
 
+func (a *ChangeAPIMethods) LimitRate() {
+	a.r.conn.SetRateLimiter(&fakeRateLimiter{allow: 1})
+}
+
+func (a *ChangeAPIMethods) RemoveRateLimit() {
+	a.r.conn.SetRateLimiter(nil)
+}
+
+// fakeRateLimiter allows a fixed number of requests, other than
+// those to ChangeAPIMethods.
+type fakeRateLimiter struct {
+	allow int
+}
+
+func (l *fakeRateLimiter) Allow(req rpc.Request) bool {
+	if req.Type == "ChangeAPIMethods" {
+		return true
+	}
+	if l.allow == 0 {
+		return false
+	}
+	l.allow--
+	return true
+}
+
 type changedAPIRoot struct{}
 
 func (r *changedAPIRoot) NewlyAvailable(string) (newlyAvailableMethods, error) {
@@ -1169,10 +1194,42 @@ func (*rpcSuite) TestChangeAPIWhileServingRequest(c *gc.C) {
 	}
 }
 
+func (*rpcSuite) TestRateLimiter(c *gc.C) {
+	root := &Root{
+		simple: make(map[string]*SimpleMethods),
+	}
+	root.simple["a0"] = &SimpleMethods{root: root, id: "a0"}
+	client, srvDone, _, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	call := func() error {
+		return client.Call(rpc.Request{"SimpleMethods", 0, "a0", "Call0r0"}, nil, nil)
+	}
+	err := client.Call(rpc.Request{"ChangeAPIMethods", 0, "", "LimitRate"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = call()
+	c.Assert(err, jc.ErrorIsNil)
+	err = call()
+	c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
+		Message: "request rate limit exceeded",
+		Code:    rpc.CodeRetryAfter,
+	})
+	c.Assert(root.calls, gc.HasLen, 1)
+
+	err = client.Call(rpc.Request{"ChangeAPIMethods", 0, "", "RemoveRateLimit"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = call()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (*rpcSuite) TestCodeNotImplementedMatchesApiserverParams(c *gc.C) {
 	c.Assert(rpc.CodeNotImplemented, gc.Equals, params.CodeNotImplemented)
 }
 
+func (*rpcSuite) TestCodeRetryAfterMatchesApiserverParams(c *gc.C) {
+	c.Assert(rpc.CodeRetryAfter, gc.Equals, params.CodeRetryAfter)
+}
+
 func chanReadError(c *gc.C, ch <-chan error, what string) error {
 	select {
 	case e := <-ch:
@@ -17,6 +17,10 @@ import (
 
 const codeNotImplemented = "not implemented"
 
+// codeRetryAfter is the error code sent when a request is rejected by
+// the connection's rate limiter.
+const codeRetryAfter = "retry after"
+
 var logger = loggo.GetLogger("juju.rpc")
 
 // A Codec implements reading and writing of messages in an RPC
@@ -124,6 +128,10 @@ type Conn struct {
 	// transformErrors is used to transform returned errors.
 	transformErrors func(error) error
 
+	// limiter, if non-nil, decides whether server requests may be
+	// served.
+	limiter RateLimiter
+
 	// reqId holds the latest client request id.
 	reqId uint64
 
@@ -299,6 +307,17 @@ func noopTransform(err error) error {
 	return err
 }
 
+// SetRateLimiter sets the rate limiter used to decide whether each
+// subsequent server request on the connection may be served. Requests
+// that are not allowed fail immediately with a "retry after" error,
+// without being passed to the methods being served. If limiter is nil,
+// requests are not limited.
+func (conn *Conn) SetRateLimiter(limiter RateLimiter) {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	conn.limiter = limiter
+}
+
 // Dead returns a channel that is closed when the connection
 // has been closed or the underlying transport has received
 // an error. There may still be outstanding requests.
@@ -374,6 +393,15 @@ type MethodFinder interface {
 	FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error)
 }
 
+// RateLimiter represents a type that can decide whether a server
+// request may be served now.
+type RateLimiter interface {
+	// Allow reports whether the given request may be served. It is
+	// called with the connection locked, so it must not block or
+	// interact with the Conn.
+	Allow(req Request) bool
+}
+
 // Killer represents a type that can be asked to abort any outstanding
 // requests.  The Kill method should return immediately.
 type Killer interface {
@@ -484,7 +512,8 @@ func (conn *Conn) handleRequest(hdr *Header) error {
 	}
 	conn.mutex.Lock()
 	closing := conn.closing
-	if !closing {
+	allowed := conn.limiter == nil || conn.limiter.Allow(hdr.Request)
+	if !closing && allowed {
 		conn.srvPending.Add(1)
 		go conn.runRequest(req, arg, startTime)
 	}
@@ -493,6 +522,11 @@ func (conn *Conn) handleRequest(hdr *Header) error {
 		// We're closing down - no new requests may be initiated.
 		return conn.writeErrorResponse(hdr, req.transformErrors(ErrShutdown), startTime)
 	}
+	if !allowed {
+		// As with unknown methods, we don't transform the error;
+		// the client must see the code to know to back off.
+		return conn.writeErrorResponse(hdr, &rateLimitedError{}, startTime)
+	}
 	return nil
 }
 
@@ -585,3 +619,15 @@ func (e *serverError) ErrorCode() string {
 	// serverError only knows one error code.
 	return codeNotImplemented
 }
+
+// rateLimitedError is returned for requests rejected by the
+// connection's rate limiter.
+type rateLimitedError struct{}
+
+func (*rateLimitedError) Error() string {
+	return "request rate limit exceeded"
+}
+
+func (*rateLimitedError) ErrorCode() string {
+	return codeRetryAfter
+}
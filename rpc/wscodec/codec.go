@@ -0,0 +1,436 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The wscodec package provides an rpc codec that sends messages over
+// a websocket as JSON, optionally compressed with deflate. Until the
+// format is changed - for example once the peer has said at login
+// which formats it can read - messages are sent as JSON text exactly
+// as jsoncodec sends them, and only such messages are accepted, so
+// the codec can talk to peers that only use jsoncodec.
+//
+// Message bodies are always JSON. There is no binary body encoding
+// such as msgpack or CBOR: the params types are only described by
+// their json tags, and none of the decoders in our dependencies
+// honours those tags and bounds its allocations on untrusted input.
+// Compression gives most of the bandwidth saving a binary encoding
+// would. A binary encoding can be added later as another format
+// feature, so peers that don't know it keep falling back to JSON.
+package wscodec
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"sync"
+	"sync/atomic"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"golang.org/x/net/websocket"
+
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/jsoncodec"
+)
+
+// The codec logs messages with the same logger as jsoncodec, so that
+// message tracing is enabled in the same way whichever is in use.
+var logger = loggo.GetLogger("juju.rpc.jsoncodec")
+
+// FeatureDeflate names the format feature that compresses large
+// messages with deflate.
+const FeatureDeflate = "deflate"
+
+// Features holds the names of all the format features supported by
+// the codec.
+var Features = []string{FeatureDeflate}
+
+// Binary messages start with a byte holding flags which describe
+// how the rest of the message is encoded. Text messages always hold
+// plain JSON.
+const flagDeflate = 1 << 0
+
+const (
+	// compressThreshold holds the size, in bytes, above which
+	// messages are compressed when the format allows it. Smaller
+	// messages gain too little to be worth the CPU.
+	compressThreshold = 1024
+
+	// maxInflatedSize holds the maximum size, in bytes, that a
+	// compressed message may expand to.
+	maxInflatedSize = 16 * 1024 * 1024
+
+	// maxNestingDepth holds the maximum depth of nested arrays
+	// and objects in a received message. Decoding recurses once
+	// for each level, so deeper messages could exhaust the stack.
+	maxNestingDepth = 10000
+)
+
+// Format describes how messages are encoded.
+type Format struct {
+	// Deflate specifies that large messages are compressed.
+	Deflate bool
+}
+
+// NewFormat returns the format that uses the given features. Unknown
+// features are ignored.
+func NewFormat(features []string) Format {
+	var f Format
+	for _, feature := range features {
+		switch feature {
+		case FeatureDeflate:
+			f.Deflate = true
+		}
+	}
+	return f
+}
+
+// SupportedFeatures returns the given features that are supported
+// by the codec, omitting any duplicates.
+func SupportedFeatures(features []string) []string {
+	f := NewFormat(features)
+	var supported []string
+	if f.Deflate {
+		supported = append(supported, FeatureDeflate)
+	}
+	return supported
+}
+
+// Codec implements rpc.Codec for a websocket connection.
+type Codec struct {
+	// msg holds the message that's just been read by ReadHeader, so
+	// that the body can be read by ReadBody.
+	msg         inMsg
+	conn        *websocket.Conn
+	logMessages int32
+	mu          sync.Mutex
+	closing     bool
+	format      Format
+	accept      Format
+}
+
+// New returns an rpc codec that uses the given websocket connection
+// to send and receive messages. Messages are sent, and only accepted,
+// as JSON text until SetFormat or Accept is called.
+func New(conn *websocket.Conn) *Codec {
+	return &Codec{
+		conn: conn,
+	}
+}
+
+// SetFormat sets the format used to send subsequent messages, and
+// accepts received messages in that format.
+func (c *Codec) SetFormat(format Format) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.format = format
+	c.accept = format
+}
+
+// Accept accepts received messages in the given format, without
+// changing the format used to send messages. A client uses it to
+// accept the reply to a login request that asks for the format.
+func (c *Codec) Accept(format Format) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accept = format
+}
+
+func (c *Codec) accepted() Format {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.accept
+}
+
+// Format returns the format used to send messages.
+func (c *Codec) Format() Format {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.format
+}
+
+// SetLogging sets whether messages will be logged
+// by the codec.
+func (c *Codec) SetLogging(on bool) {
+	val := int32(0)
+	if on {
+		val = 1
+	}
+	atomic.StoreInt32(&c.logMessages, val)
+}
+
+func (c *Codec) isLogging() bool {
+	return atomic.LoadInt32(&c.logMessages) != 0
+}
+
+// inMsg holds an incoming message. We don't know the type of the
+// parameters or response yet, so we delay decoding them by storing
+// them as raw JSON.
+type inMsg struct {
+	header   rpc.Header
+	params   json.RawMessage
+	response json.RawMessage
+}
+
+// jsonInMsg holds an incoming JSON message.
+type jsonInMsg struct {
+	RequestId uint64
+	Type      string
+	Version   int
+	Id        string
+	Request   string
+	Params    json.RawMessage
+	Error     string
+	ErrorCode string
+	Response  json.RawMessage
+}
+
+// outMsg holds an outgoing message.
+type outMsg struct {
+	RequestId uint64
+	Type      string      `json:",omitempty"`
+	Version   int         `json:",omitempty"`
+	Id        string      `json:",omitempty"`
+	Request   string      `json:",omitempty"`
+	Params    interface{} `json:",omitempty"`
+	Error     string      `json:",omitempty"`
+	ErrorCode string      `json:",omitempty"`
+	Response  interface{} `json:",omitempty"`
+}
+
+// init fills out the receiving outMsg with information from the given
+// header and body.
+func (m *outMsg) init(hdr *rpc.Header, body interface{}) {
+	m.RequestId = hdr.RequestId
+	m.Type = hdr.Request.Type
+	m.Version = hdr.Request.Version
+	m.Id = hdr.Request.Id
+	m.Request = hdr.Request.Action
+	m.Error = hdr.Error
+	m.ErrorCode = hdr.ErrorCode
+	if hdr.IsRequest() {
+		m.Params = body
+	} else {
+		m.Response = body
+	}
+}
+
+// frame holds a websocket message.
+type frame struct {
+	// flags holds the flags describing the encoding of data.
+	// Messages with no flags are sent as text.
+	flags byte
+	data  []byte
+}
+
+// frameCodec sends and receives frames, using text messages for plain
+// JSON and binary messages for everything else.
+var frameCodec = websocket.Codec{
+	Marshal: func(v interface{}) ([]byte, byte, error) {
+		f := v.(*frame)
+		if f.flags == 0 {
+			return f.data, websocket.TextFrame, nil
+		}
+		data := make([]byte, 1+len(f.data))
+		data[0] = f.flags
+		copy(data[1:], f.data)
+		return data, websocket.BinaryFrame, nil
+	},
+	Unmarshal: func(data []byte, payloadType byte, v interface{}) error {
+		f := v.(*frame)
+		if payloadType != websocket.BinaryFrame {
+			f.flags, f.data = 0, data
+			return nil
+		}
+		if len(data) == 0 {
+			return errors.New("empty binary message")
+		}
+		f.flags, f.data = data[0], data[1:]
+		return nil
+	},
+}
+
+func (c *Codec) Close() error {
+	c.mu.Lock()
+	c.closing = true
+	c.mu.Unlock()
+	return c.conn.Close()
+}
+
+func (c *Codec) isClosing() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closing
+}
+
+func (c *Codec) ReadHeader(hdr *rpc.Header) error {
+	c.msg = inMsg{} // avoid any potential cross-message contamination.
+	var f frame
+	if err := frameCodec.Receive(c.conn, &f); err != nil {
+		if c.isLogging() {
+			logger.Tracef("<- error: %v (closing %v)", err, c.isClosing())
+		}
+		// If we've closed the connection, we may get a spurious error,
+		// so ignore it.
+		if c.isClosing() || err == io.EOF {
+			return io.EOF
+		}
+		return errors.Annotate(err, "error receiving message")
+	}
+	data := f.data
+	switch f.flags {
+	case 0:
+	case flagDeflate:
+		if !c.accepted().Deflate {
+			return errors.New("compressed message not accepted")
+		}
+		var err error
+		if data, err = inflate(data); err != nil {
+			return errors.Annotate(err, "cannot decompress message")
+		}
+	default:
+		return errors.Errorf("unknown message flags %#x", f.flags)
+	}
+	if c.isLogging() {
+		logger.Tracef("<- %s", data)
+	}
+	if err := checkNesting(data); err != nil {
+		return errors.Annotate(err, "cannot decode message")
+	}
+	if err := c.msg.readJSON(data); err != nil {
+		return errors.Annotate(err, "cannot decode message")
+	}
+	*hdr = c.msg.header
+	return nil
+}
+
+// checkNesting returns an error if the arrays and objects in the given
+// JSON are nested more than maxNestingDepth deep.
+func checkNesting(data []byte) error {
+	depth := 0
+	inString := false
+	escaped := false
+	for _, b := range data {
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			switch b {
+			case '\\':
+				escaped = true
+			case '"':
+				inString = false
+			}
+		case b == '"':
+			inString = true
+		case b == '[' || b == '{':
+			depth++
+			if depth > maxNestingDepth {
+				return errors.Errorf("message nested more than %d deep", maxNestingDepth)
+			}
+		case b == ']' || b == '}':
+			depth--
+		}
+	}
+	return nil
+}
+
+// readJSON fills out the receiving inMsg from the given JSON message.
+func (m *inMsg) readJSON(data []byte) error {
+	var jm jsonInMsg
+	if err := json.Unmarshal(data, &jm); err != nil {
+		return errors.Trace(err)
+	}
+	m.header = rpc.Header{
+		RequestId: jm.RequestId,
+		Request: rpc.Request{
+			Type:    jm.Type,
+			Version: jm.Version,
+			Id:      jm.Id,
+			Action:  jm.Request,
+		},
+		Error:     jm.Error,
+		ErrorCode: jm.ErrorCode,
+	}
+	m.params = jm.Params
+	m.response = jm.Response
+	return nil
+}
+
+func (c *Codec) ReadBody(body interface{}, isRequest bool) error {
+	if body == nil {
+		return nil
+	}
+	rawBody := c.msg.response
+	if isRequest {
+		rawBody = c.msg.params
+	}
+	if len(rawBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(rawBody, body)
+}
+
+func (c *Codec) WriteMessage(hdr *rpc.Header, body interface{}) error {
+	var m outMsg
+	m.init(hdr, body)
+	if c.isLogging() {
+		logger.Tracef("-> %s", jsoncodec.DumpRequest(hdr, body))
+	}
+	format := c.Format()
+	var f frame
+	var err error
+	f.data, err = json.Marshal(&m)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if format.Deflate && len(f.data) > compressThreshold {
+		f.flags |= flagDeflate
+		if f.data, err = deflate(f.data); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return frameCodec.Send(c.conn, &f)
+}
+
+// deflaters holds *flate.Writers for reuse, as they are
+// expensive to allocate.
+var deflaters = sync.Pool{
+	New: func() interface{} {
+		w, err := flate.NewWriter(nil, flate.BestSpeed)
+		if err != nil {
+			panic(err)
+		}
+		return w
+	},
+}
+
+// deflate returns data compressed with deflate.
+func deflate(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := deflaters.Get().(*flate.Writer)
+	defer deflaters.Put(w)
+	w.Reset(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// inflate returns data decompressed with deflate.
+func inflate(data []byte) ([]byte, error) {
+	r := flate.NewReader(bytes.NewReader(data))
+	defer r.Close()
+	inflated, err := ioutil.ReadAll(io.LimitReader(r, maxInflatedSize+1))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(inflated) > maxInflatedSize {
+		return nil, errors.Errorf("message exceeds %d bytes", maxInflatedSize)
+	}
+	return inflated, nil
+}
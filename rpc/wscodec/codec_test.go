@@ -0,0 +1,221 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package wscodec_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	stdtesting "testing"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"golang.org/x/net/websocket"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/wscodec"
+)
+
+func TestPackage(t *stdtesting.T) {
+	gc.TestingT(t)
+}
+
+type codecSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&codecSuite{})
+
+type body struct {
+	Name   string            `json:"name"`
+	Values map[string]int    `json:"values"`
+	Items  []string          `json:"items,omitempty"`
+	Attrs  map[string]string `json:"attrs,omitempty"`
+}
+
+// pipe returns the client end of a websocket connection and a codec
+// for the server end.
+func (s *codecSuite) pipe(c *gc.C) (*websocket.Conn, *wscodec.Codec) {
+	serverCodec := make(chan *wscodec.Codec, 1)
+	done := make(chan struct{})
+	srv := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		serverCodec <- wscodec.New(conn)
+		// The connection is closed when the handler returns.
+		<-done
+	}))
+	s.AddCleanup(func(*gc.C) {
+		close(done)
+		srv.Close()
+	})
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), "", "http://localhost/")
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { conn.Close() })
+	return conn, <-serverCodec
+}
+
+func (s *codecSuite) TestFormats(c *gc.C) {
+	conn, server := s.pipe(c)
+	client := wscodec.New(conn)
+	for i, format := range []wscodec.Format{
+		{},
+		{Deflate: true},
+	} {
+		c.Logf("test %d: %+v", i, format)
+		client.SetFormat(format)
+		server.SetFormat(format)
+		c.Assert(client.Format(), gc.Equals, format)
+
+		// Send both a small message and one large
+		// enough to be compressed.
+		for _, n := range []int{1, 5000} {
+			hdr := rpc.Header{
+				RequestId: uint64(n),
+				Request: rpc.Request{
+					Type:    "Facade",
+					Version: 2,
+					Id:      "id",
+					Action:  "Method",
+				},
+			}
+			sent := body{
+				Name:   strings.Repeat("x", n),
+				Values: map[string]int{"a": 1, "b": -2},
+			}
+			err := client.WriteMessage(&hdr, sent)
+			c.Assert(err, jc.ErrorIsNil)
+
+			var obtainedHdr rpc.Header
+			err = server.ReadHeader(&obtainedHdr)
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(obtainedHdr, jc.DeepEquals, hdr)
+			var obtained body
+			err = server.ReadBody(&obtained, true)
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(obtained, jc.DeepEquals, sent)
+		}
+	}
+}
+
+func (s *codecSuite) TestErrorResponse(c *gc.C) {
+	conn, server := s.pipe(c)
+	client := wscodec.New(conn)
+	server.SetFormat(wscodec.Format{Deflate: true})
+	hdr := rpc.Header{
+		RequestId: 3,
+		Error:     "an error",
+		ErrorCode: "a code",
+	}
+	err := server.WriteMessage(&hdr, struct{}{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var obtainedHdr rpc.Header
+	err = client.ReadHeader(&obtainedHdr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtainedHdr, jc.DeepEquals, hdr)
+	var obtained body
+	err = client.ReadBody(&obtained, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtained, jc.DeepEquals, body{})
+}
+
+func (s *codecSuite) TestPlainJSONCompatible(c *gc.C) {
+	conn, server := s.pipe(c)
+	err := websocket.Message.Send(conn,
+		`{"RequestId": 1, "Type": "foo", "Id": "id", "Request": "frob", "Params": {"name": "param"}}`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var hdr rpc.Header
+	err = server.ReadHeader(&hdr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hdr, jc.DeepEquals, rpc.Header{
+		RequestId: 1,
+		Request: rpc.Request{
+			Type:   "foo",
+			Id:     "id",
+			Action: "frob",
+		},
+	})
+	var obtained body
+	err = server.ReadBody(&obtained, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtained, jc.DeepEquals, body{Name: "param"})
+}
+
+func (s *codecSuite) TestClose(c *gc.C) {
+	conn, _ := s.pipe(c)
+	client := wscodec.New(conn)
+	err := client.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	var hdr rpc.Header
+	err = client.ReadHeader(&hdr)
+	c.Assert(err, gc.Equals, io.EOF)
+}
+
+func (*codecSuite) TestNegotiation(c *gc.C) {
+	c.Assert(wscodec.NewFormat([]string{"deflate", "unknown"}), gc.Equals, wscodec.Format{Deflate: true})
+	c.Assert(wscodec.NewFormat(nil), gc.Equals, wscodec.Format{})
+	c.Assert(wscodec.SupportedFeatures([]string{"cbor", "brotli", "deflate", "deflate"}), jc.DeepEquals, []string{"deflate"})
+	c.Assert(wscodec.SupportedFeatures([]string{"brotli"}), gc.HasLen, 0)
+}
+
+func (s *codecSuite) TestRejectsCompressedUntilNegotiated(c *gc.C) {
+	conn, server := s.pipe(c)
+	client := wscodec.New(conn)
+	client.SetFormat(wscodec.Format{Deflate: true})
+	hdr := rpc.Header{RequestId: 1, Request: rpc.Request{Type: "Admin", Action: "Login"}}
+	err := client.WriteMessage(&hdr, body{Name: strings.Repeat("x", 5000)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var obtained rpc.Header
+	err = server.ReadHeader(&obtained)
+	c.Assert(err, gc.ErrorMatches, "compressed message not accepted")
+}
+
+func (s *codecSuite) TestAcceptDoesNotChangeFormat(c *gc.C) {
+	conn, server := s.pipe(c)
+	client := wscodec.New(conn)
+	client.Accept(wscodec.Format{Deflate: true})
+	c.Assert(client.Format(), gc.Equals, wscodec.Format{})
+
+	server.SetFormat(wscodec.Format{Deflate: true})
+	hdr := rpc.Header{RequestId: 1}
+	sent := body{Name: strings.Repeat("x", 5000)}
+	err := server.WriteMessage(&hdr, sent)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var obtainedHdr rpc.Header
+	err = client.ReadHeader(&obtainedHdr)
+	c.Assert(err, jc.ErrorIsNil)
+	var obtained body
+	err = client.ReadBody(&obtained, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtained, jc.DeepEquals, sent)
+}
+
+func (s *codecSuite) TestRejectsDeepNesting(c *gc.C) {
+	conn, server := s.pipe(c)
+	deep := strings.Repeat("[", 10001) + strings.Repeat("]", 10001)
+	err := websocket.Message.Send(conn, `{"RequestId": 1, "Params": `+deep+`}`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var hdr rpc.Header
+	err = server.ReadHeader(&hdr)
+	c.Assert(err, gc.ErrorMatches, "cannot decode message: message nested more than 10000 deep")
+}
+
+func (s *codecSuite) TestNestingIgnoresStrings(c *gc.C) {
+	conn, server := s.pipe(c)
+	brackets := strings.Repeat("[", 20000)
+	err := websocket.Message.Send(conn, `{"RequestId": 1, "Params": {"name": "\"`+brackets+`"}}`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var hdr rpc.Header
+	err = server.ReadHeader(&hdr)
+	c.Assert(err, jc.ErrorIsNil)
+	var obtained body
+	err = server.ReadBody(&obtained, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtained.Name, gc.Equals, `"`+brackets)
+}
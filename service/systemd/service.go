@@ -18,6 +18,16 @@ import (
 	"github.com/juju/juju/service/common"
 )
 
+const (
+	// etcSystemdDir holds the units configured by the system
+	// administrator, and the links to units installed elsewhere.
+	etcSystemdDir = "/etc/systemd/system"
+
+	// multiUserTargetWantsDir holds the links to the units which are
+	// started at boot.
+	multiUserTargetWantsDir = etcSystemdDir + "/multi-user.target.wants"
+)
+
 var (
 	logger = loggo.GetLogger("juju.service.systemd")
 
@@ -512,6 +522,33 @@ func (s *Service) writeConf() (string, error) {
 	return filename, nil
 }
 
+// WriteService writes the service's unit file and links it into the
+// systemd configuration so that the service starts at boot. Unlike
+// Install, it does not talk to systemd, so it can be used to prepare a
+// host that is not yet running systemd.
+func (s *Service) WriteService() error {
+	if s.NoConf() {
+		return s.errorf(nil, "missing conf")
+	}
+	filename, err := s.writeConf()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// These are the links "systemctl link" and "systemctl enable"
+	// would create.
+	for _, dirname := range []string{etcSystemdDir, multiUserTargetWantsDir} {
+		if err := mkdirAll(dirname); err != nil {
+			return s.errorf(err, "failed to create systemd dir %q", dirname)
+		}
+		link := path.Join(dirname, s.UnitName)
+		if err := symlink(filename, link); err != nil {
+			return s.errorf(err, "failed to link %q to %q", link, filename)
+		}
+	}
+	logger.Debugf("service %q successfully written", s.Name())
+	return nil
+}
+
 var mkdirAll = func(dirname string) error {
 	return os.MkdirAll(dirname, 0755)
 }
@@ -520,6 +557,15 @@ var createFile = func(filename string, data []byte, perm os.FileMode) error {
 	return ioutil.WriteFile(filename, data, perm)
 }
 
+// symlink creates newname as a link to oldname, replacing any file
+// already at newname.
+var symlink = func(oldname, newname string) error {
+	if err := os.Remove(newname); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.Symlink(oldname, newname)
+}
+
 // InstallCommands implements Service.
 func (s *Service) InstallCommands() ([]string, error) {
 	if s.NoConf() {
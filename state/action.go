@@ -25,6 +25,10 @@ var (
 	// NewUUID wraps the utils.NewUUID() call, and exposes it as a var to
 	// facilitate patching.
 	NewUUID = func() (utils.UUID, error) { return utils.NewUUID() }
+
+	// maxActionMessages is the number of progress messages kept for
+	// an action; older messages are discarded as new ones are logged.
+	maxActionMessages = 1000
 )
 
 // ActionStatus represents the possible end states for an action.
@@ -104,6 +108,20 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Messages holds the most recent progress messages logged by
+	// the action while it is running.
+	Messages []ActionMessage `bson:"messages"`
+
+	// MessageCount is the number of progress messages logged by the
+	// action, including any discarded from Messages.
+	MessageCount int `bson:"message-count"`
+}
+
+// ActionMessage represents a progress message logged by an action.
+type ActionMessage struct {
+	Timestamp time.Time `bson:"timestamp" json:"timestamp"`
+	Message   string    `bson:"message" json:"message"`
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -162,6 +180,12 @@ func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Messages returns the most recent progress messages logged by the
+// action.
+func (a *action) Messages() []ActionMessage {
+	return a.doc.Messages
+}
+
 // Tag implements the Entity interface and returns a names.Tag that
 // is a names.ActionTag.
 func (a *action) Tag() names.Tag {
@@ -201,6 +225,33 @@ func (a *action) Begin() (Action, error) {
 	return a.st.Action(a.Id())
 }
 
+// Log adds a timestamped progress message to the action, discarding
+// the oldest message if it already has maxActionMessages. It asserts
+// that the action is currently running.
+func (a *action) Log(message string) error {
+	err := a.st.runTransaction([]txn.Op{{
+		C:      actionsC,
+		Id:     a.doc.DocId,
+		Assert: bson.D{{"status", ActionRunning}},
+		Update: bson.D{
+			{"$push", bson.D{{"messages", bson.D{
+				{"$each", []ActionMessage{{
+					Timestamp: nowToTheSecond(),
+					Message:   message,
+				}}},
+				{"$slice", -maxActionMessages},
+			}}}},
+			{"$inc", bson.D{{"message-count", 1}}},
+		},
+	}})
+	if err == txn.ErrAborted {
+		return errors.Errorf("cannot log message to action %q: action is not running", a.Id())
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot log message to action %q", a.Id())
+	}
+	return nil
+}
+
 // Finish removes action from the pending queue and captures the output
 // and end state of the action.
 func (a *action) Finish(results ActionResults) (Action, error) {
@@ -5,6 +5,7 @@ package state_test
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -730,6 +731,120 @@ func (s *ActionSuite) TestMakeIdFilter(c *gc.C) {
 	}
 }
 
+func (s *ActionSuite) TestLog(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log("first")
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("second")
+	c.Assert(err, jc.ErrorIsNil)
+
+	action, err := s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Check(messages[0].Message, gc.Equals, "first")
+	c.Check(messages[0].Timestamp.IsZero(), jc.IsFalse)
+	c.Check(messages[1].Message, gc.Equals, "second")
+	c.Check(messages[1].Timestamp.IsZero(), jc.IsFalse)
+}
+
+func (s *ActionSuite) TestLogKeepsRecentMessages(c *gc.C) {
+	s.PatchValue(state.MaxActionMessages, 2)
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, message := range []string{"first", "second", "third"} {
+		err = a.Log(message)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	action, err := s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Check(messages[0].Message, gc.Equals, "second")
+	c.Check(messages[1].Message, gc.Equals, "third")
+}
+
+func (s *ActionSuite) TestLogNotRunning(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log("too early")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action is not running`)
+
+	_, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log("too late")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action is not running`)
+
+	action, err := s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(action.Messages(), gc.HasLen, 0)
+}
+
+func (s *ActionSuite) TestWatchActionLogs(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("first")
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchActionLogs(a.Id())
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+
+	// The initial event holds the messages logged so far.
+	wc.AssertChange(s.encodedMessages(c, a.Id())...)
+	wc.AssertNoChange()
+
+	// Subsequent events hold only new messages.
+	err = a.Log("second")
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("third")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(s.encodedMessages(c, a.Id())[1:]...)
+	wc.AssertNoChange()
+
+	// Once old messages are discarded, new ones are still reported.
+	s.PatchValue(state.MaxActionMessages, 2)
+	err = a.Log("fourth")
+	c.Assert(err, jc.ErrorIsNil)
+	messages := s.encodedMessages(c, a.Id())
+	c.Assert(messages, gc.HasLen, 2)
+	wc.AssertChange(messages[1])
+	wc.AssertNoChange()
+
+	// Other changes to the action do not cause events.
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+// encodedMessages returns the progress messages of the action with the
+// given id, encoded as reported by the action logs watcher.
+func (s *ActionSuite) encodedMessages(c *gc.C, id string) []string {
+	action, err := s.State.Action(id)
+	c.Assert(err, jc.ErrorIsNil)
+	var messages []string
+	for _, message := range action.Messages() {
+		data, err := json.Marshal(message)
+		c.Assert(err, jc.ErrorIsNil)
+		messages = append(messages, string(data))
+	}
+	return messages
+}
+
 func (s *ActionSuite) TestWatchActionNotifications(c *gc.C) {
 	svc := s.AddTestingService(c, "dummy2", s.charm)
 	u, err := svc.AddUnit()
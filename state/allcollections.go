@@ -103,6 +103,19 @@ func allCollections() collectionSchema {
 		// Life and its UUID.
 		modelsC: {global: true},
 
+		// This collection holds the cloud credentials that models
+		// reference, keyed on owner, cloud and credential name.
+		cloudCredentialsC: {global: true},
+
+		// This collection holds the service endpoints offered by each
+		// model for relations with services in other models.
+		serviceOffersC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"source-model-uuid"},
+			}},
+		},
+
 		// This collection holds references to entities owned by a
 		// model. We use this to determine whether or not we can safely
 		// destroy empty models.
@@ -251,6 +264,10 @@ func allCollections() collectionSchema {
 		rebootC:        {},
 		sshHostKeysC:   {},
 
+		// This collection holds the locks that pin a machine, and the
+		// units it hosts, while its series is upgraded in place.
+		upgradeSeriesLocksC: {},
+
 		// -----
 
 		// These collections hold information associated with storage.
@@ -330,6 +347,14 @@ func allCollections() collectionSchema {
 		endpointBindingsC: {},
 		openedPortsC:      {},
 
+		// This collection holds the LXD and KVM customisations applied
+		// to containers hosting the units of each service.
+		containerProfilesC: {},
+
+		// This collection holds proxies for services hosted by other
+		// models, to which services in this model may be related.
+		remoteServicesC: {},
+
 		// -----
 
 		// These collections hold information associated with actions.
@@ -437,6 +462,10 @@ const (
 	sequenceC                = "sequence"
 	servicesC                = "services"
 	endpointBindingsC        = "endpointbindings"
+	containerProfilesC       = "containerprofiles"
+	cloudCredentialsC        = "cloudcredentials"
+	serviceOffersC           = "serviceoffers"
+	remoteServicesC          = "remoteservices"
 	settingsC                = "settings"
 	settingsrefsC            = "settingsrefs"
 	sshHostKeysC             = "sshhostkeys"
@@ -455,6 +484,7 @@ const (
 	txnsC                    = "txns"
 	unitsC                   = "units"
 	upgradeInfoC             = "upgradeInfo"
+	upgradeSeriesLocksC      = "upgradeSeriesLocks"
 	userLastLoginC           = "userLastLogin"
 	usermodelnameC           = "usermodelname"
 	usersC                   = "users"
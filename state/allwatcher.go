@@ -530,6 +530,7 @@ func (r *backingRelation) updated(st *State, store *multiwatcherStore, id string
 		Key:       r.Key,
 		Id:        r.Id,
 		Endpoints: eps,
+		Suspended: r.Suspended,
 	}
 	store.Update(info)
 	return nil
@@ -0,0 +1,191 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// auditLogC holds the audit log. It lives alongside the logs collection
+// in the logs database, but is pruned independently of it.
+const auditLogC = "audit"
+
+// AuditEntry records a single API call made by a user or agent.
+type AuditEntry struct {
+	// ModelUUID identifies the model the call was made against.
+	ModelUUID string
+
+	// Time is when the call completed.
+	Time time.Time
+
+	// User is the canonical name of the user that made the call, or
+	// the tag of the agent that made it, as in "machine-0".
+	User string
+
+	// Facade, Version and Method identify the API method called.
+	Facade  string
+	Version int
+	Method  string
+
+	// Args holds the call's arguments, serialised as JSON.
+	Args string
+
+	// Error holds the error the call failed with, if any.
+	Error string
+}
+
+// auditDoc is used to serialise audit log entries.
+type auditDoc struct {
+	Id        bson.ObjectId `bson:"_id"`
+	ModelUUID string        `bson:"model-uuid"`
+	Time      time.Time     `bson:"time"`
+	User      string        `bson:"user"`
+	Facade    string        `bson:"facade"`
+	Version   int           `bson:"version"`
+	Method    string        `bson:"method"`
+	Args      string        `bson:"args"`
+	Error     string        `bson:"error,omitempty"`
+}
+
+// initDbAuditLog sets up the indexes for the audit log collection.
+func initDbAuditLog(session *mgo.Session) error {
+	auditColl := session.DB(logsDB).C(auditLogC)
+	for _, key := range [][]string{{"time"}, {"model-uuid", "time"}, {"user", "time"}} {
+		err := auditColl.EnsureIndex(mgo.Index{Key: key})
+		if err != nil {
+			return errors.Annotate(err, "cannot create index for audit log collection")
+		}
+	}
+	return nil
+}
+
+// AddAuditEntry records the supplied entry in the audit log. If the
+// entry's ModelUUID is empty, the state's model is used.
+func (st *State) AddAuditEntry(entry AuditEntry) error {
+	if entry.User == "" {
+		return errors.NotValidf("audit entry with no user")
+	}
+	if entry.Facade == "" || entry.Method == "" {
+		return errors.NotValidf("audit entry with no method")
+	}
+	if entry.ModelUUID == "" {
+		entry.ModelUUID = st.ModelUUID()
+	}
+	session := st.MongoSession().Copy()
+	defer session.Close()
+	err := session.DB(logsDB).C(auditLogC).Insert(&auditDoc{
+		Id:        bson.NewObjectId(),
+		ModelUUID: entry.ModelUUID,
+		Time:      entry.Time.UTC(),
+		User:      entry.User,
+		Facade:    entry.Facade,
+		Version:   entry.Version,
+		Method:    entry.Method,
+		Args:      entry.Args,
+		Error:     entry.Error,
+	})
+	return errors.Annotate(err, "cannot add audit log entry")
+}
+
+// AuditLogFilter restricts the entries returned by AuditLog. Zero-valued
+// fields do not restrict the entries.
+type AuditLogFilter struct {
+	// ModelUUID restricts entries to calls made against the model.
+	ModelUUID string
+
+	// User restricts entries to calls made by the user.
+	User string
+
+	// Facade and Method restrict entries to calls of the facade and
+	// method.
+	Facade string
+	Method string
+
+	// From and To restrict entries to calls made no earlier than From,
+	// and earlier than To.
+	From time.Time
+	To   time.Time
+
+	// Limit restricts the number of entries returned to the most recent
+	// Limit entries.
+	Limit int
+}
+
+// AuditLog returns the audit log entries, for all models in the
+// controller, matching the supplied filter, oldest first.
+func (st *State) AuditLog(filter AuditLogFilter) ([]AuditEntry, error) {
+	if filter.Limit < 0 {
+		return nil, errors.NotValidf("negative limit")
+	}
+	query := bson.D{}
+	if filter.ModelUUID != "" {
+		query = append(query, bson.DocElem{"model-uuid", filter.ModelUUID})
+	}
+	if filter.User != "" {
+		query = append(query, bson.DocElem{"user", filter.User})
+	}
+	if filter.Facade != "" {
+		query = append(query, bson.DocElem{"facade", filter.Facade})
+	}
+	if filter.Method != "" {
+		query = append(query, bson.DocElem{"method", filter.Method})
+	}
+	timeQuery := bson.D{}
+	if !filter.From.IsZero() {
+		timeQuery = append(timeQuery, bson.DocElem{"$gte", filter.From.UTC()})
+	}
+	if !filter.To.IsZero() {
+		timeQuery = append(timeQuery, bson.DocElem{"$lt", filter.To.UTC()})
+	}
+	if len(timeQuery) > 0 {
+		query = append(query, bson.DocElem{"time", timeQuery})
+	}
+
+	session := st.MongoSession().Copy()
+	defer session.Close()
+	q := session.DB(logsDB).C(auditLogC).Find(query).Sort("-time", "-_id")
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit)
+	}
+	var docs []auditDoc
+	if err := q.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read audit log")
+	}
+	entries := make([]AuditEntry, len(docs))
+	for i, doc := range docs {
+		// The query returns the most recent entries first.
+		entries[len(docs)-1-i] = AuditEntry{
+			ModelUUID: doc.ModelUUID,
+			Time:      doc.Time.UTC(),
+			User:      doc.User,
+			Facade:    doc.Facade,
+			Version:   doc.Version,
+			Method:    doc.Method,
+			Args:      doc.Args,
+			Error:     doc.Error,
+		}
+	}
+	return entries, nil
+}
+
+// PruneAuditLog removes audit log entries, for all models, recorded
+// before minTime.
+func PruneAuditLog(st LoggingState, minTime time.Time) error {
+	session := st.MongoSession().Copy()
+	defer session.Close()
+	removeInfo, err := session.DB(logsDB).C(auditLogC).RemoveAll(bson.M{
+		"time": bson.M{"$lt": minTime.UTC()},
+	})
+	if err != nil {
+		return errors.Annotate(err, "failed to prune audit log")
+	}
+	if removeInfo.Removed > 0 {
+		logger.Debugf("pruned %d audit log entries", removeInfo.Removed)
+	}
+	return nil
+}
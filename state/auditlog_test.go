@@ -0,0 +1,130 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type AuditLogSuite struct {
+	ConnSuite
+	now time.Time
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	// Mongo stores times with millisecond precision.
+	s.now = time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *AuditLogSuite) addEntry(c *gc.C, user, facade, method string, age time.Duration) state.AuditEntry {
+	entry := state.AuditEntry{
+		ModelUUID: s.State.ModelUUID(),
+		Time:      s.now.Add(-age),
+		User:      user,
+		Facade:    facade,
+		Version:   1,
+		Method:    method,
+		Args:      `{"foo":"bar"}`,
+	}
+	err := s.State.AddAuditEntry(entry)
+	c.Assert(err, jc.ErrorIsNil)
+	return entry
+}
+
+func (s *AuditLogSuite) TestAddAuditEntry(c *gc.C) {
+	err := s.State.AddAuditEntry(state.AuditEntry{
+		Time:    s.now,
+		User:    "admin@local",
+		Facade:  "Service",
+		Version: 3,
+		Method:  "Deploy",
+		Args:    `{"Services":[]}`,
+		Error:   "boom",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries, err := s.State.AuditLog(state.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []state.AuditEntry{{
+		ModelUUID: s.State.ModelUUID(),
+		Time:      s.now,
+		User:      "admin@local",
+		Facade:    "Service",
+		Version:   3,
+		Method:    "Deploy",
+		Args:      `{"Services":[]}`,
+		Error:     "boom",
+	}})
+}
+
+func (s *AuditLogSuite) TestAddAuditEntryValidates(c *gc.C) {
+	err := s.State.AddAuditEntry(state.AuditEntry{Facade: "Service", Method: "Deploy"})
+	c.Assert(err, gc.ErrorMatches, "audit entry with no user not valid")
+	err = s.State.AddAuditEntry(state.AuditEntry{User: "admin@local"})
+	c.Assert(err, gc.ErrorMatches, "audit entry with no method not valid")
+}
+
+func (s *AuditLogSuite) TestAuditLogFilter(c *gc.C) {
+	deploy := s.addEntry(c, "admin@local", "Service", "Deploy", 3*time.Hour)
+	expose := s.addEntry(c, "bob@local", "Service", "Expose", 2*time.Hour)
+	addMachines := s.addEntry(c, "admin@local", "Client", "AddMachines", time.Hour)
+
+	for i, test := range []struct {
+		filter state.AuditLogFilter
+		expect []state.AuditEntry
+	}{{
+		filter: state.AuditLogFilter{},
+		expect: []state.AuditEntry{deploy, expose, addMachines},
+	}, {
+		filter: state.AuditLogFilter{User: "admin@local"},
+		expect: []state.AuditEntry{deploy, addMachines},
+	}, {
+		filter: state.AuditLogFilter{Facade: "Service"},
+		expect: []state.AuditEntry{deploy, expose},
+	}, {
+		filter: state.AuditLogFilter{Facade: "Service", Method: "Expose"},
+		expect: []state.AuditEntry{expose},
+	}, {
+		filter: state.AuditLogFilter{From: s.now.Add(-2 * time.Hour)},
+		expect: []state.AuditEntry{expose, addMachines},
+	}, {
+		filter: state.AuditLogFilter{To: s.now.Add(-2 * time.Hour)},
+		expect: []state.AuditEntry{deploy},
+	}, {
+		filter: state.AuditLogFilter{Limit: 2},
+		expect: []state.AuditEntry{expose, addMachines},
+	}, {
+		filter: state.AuditLogFilter{ModelUUID: "another-model"},
+		expect: []state.AuditEntry{},
+	}} {
+		c.Logf("test %d: %+v", i, test.filter)
+		entries, err := s.State.AuditLog(test.filter)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(entries, jc.DeepEquals, test.expect)
+	}
+}
+
+func (s *AuditLogSuite) TestAuditLogNegativeLimit(c *gc.C) {
+	_, err := s.State.AuditLog(state.AuditLogFilter{Limit: -1})
+	c.Assert(err, gc.ErrorMatches, "negative limit not valid")
+}
+
+func (s *AuditLogSuite) TestPruneAuditLog(c *gc.C) {
+	s.addEntry(c, "admin@local", "Service", "Deploy", 3*time.Hour)
+	expose := s.addEntry(c, "admin@local", "Service", "Expose", time.Hour)
+
+	err := state.PruneAuditLog(s.State, s.now.Add(-2*time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	entries, err := s.State.AuditLog(state.AuditLogFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []state.AuditEntry{expose})
+}
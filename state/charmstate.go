@@ -0,0 +1,112 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// maxCharmStateSize is the maximum combined size, in bytes, of the
+// keys and values that a unit's charm may persist.
+const maxCharmStateSize = 64 * 1024
+
+// unitCharmStateKey returns the settings key for the charm state of
+// the named unit.
+func unitCharmStateKey(name string) string {
+	return "u#" + name + "#state"
+}
+
+// charmStateKey returns the settings key for the unit's charm state.
+func (u *Unit) charmStateKey() string {
+	return unitCharmStateKey(u.doc.Name)
+}
+
+// CharmState returns the key-value pairs that the unit's charm has
+// persisted between hooks. A unit whose charm has stored nothing has
+// an empty charm state.
+func (u *Unit) CharmState() (map[string]string, error) {
+	doc, err := readSettingsDoc(u.st, u.charmStateKey())
+	if errors.IsNotFound(err) {
+		return make(map[string]string), nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot read charm state of unit %q", u)
+	}
+	return charmStateFromSettings(doc.Settings), nil
+}
+
+// SetCharmState replaces the key-value pairs persisted by the unit's
+// charm. The combined size of the keys and values is limited to
+// maxCharmStateSize bytes.
+func (u *Unit) SetCharmState(state map[string]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set charm state of unit %q", u)
+	if size := charmStateSize(state); size > maxCharmStateSize {
+		return errors.Errorf("size %d exceeds maximum of %d bytes", size, maxCharmStateSize)
+	}
+	values := make(map[string]interface{}, len(state))
+	for key, value := range state {
+		values[key] = value
+	}
+	key := u.charmStateKey()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if notDead, err := isNotDead(u.st, unitsC, u.doc.DocID); err != nil {
+				return nil, errors.Trace(err)
+			} else if !notDead {
+				return nil, ErrDead
+			}
+		}
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		// The charm state is only created when it is first written.
+		_, err := readSettingsDoc(u.st, key)
+		if errors.IsNotFound(err) {
+			return append(ops, createSettingsOp(key, values)), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		op, _, err := replaceSettingsOp(u.st, key, values)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, op), nil
+	}
+	return u.st.run(buildTxn)
+}
+
+// removeCharmStateOp returns the operation to remove the charm state
+// with the given key. The charm state may never have been written, so
+// the operation does not assert that it exists.
+func removeCharmStateOp(key string) txn.Op {
+	return txn.Op{
+		C:      settingsC,
+		Id:     key,
+		Remove: true,
+	}
+}
+
+// charmStateSize returns the combined size of the keys and values of
+// the given charm state.
+func charmStateSize(state map[string]string) int {
+	size := 0
+	for key, value := range state {
+		size += len(key) + len(value)
+	}
+	return size
+}
+
+// charmStateFromSettings converts the settings stored for a charm
+// state to the key-value pairs persisted by the charm.
+func charmStateFromSettings(settings map[string]interface{}) map[string]string {
+	state := make(map[string]string, len(settings))
+	for key, value := range settings {
+		if s, ok := value.(string); ok {
+			state[key] = s
+		}
+	}
+	return state
+}
@@ -0,0 +1,82 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type CharmStateSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&CharmStateSuite{})
+
+func (s *CharmStateSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, &factory.UnitParams{})
+}
+
+func (s *CharmStateSuite) TestCharmStateInitiallyEmpty(c *gc.C) {
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+}
+
+func (s *CharmStateSuite) TestSetCharmState(c *gc.C) {
+	err := s.unit.SetCharmState(map[string]string{"foo": "bar", "a.b$c": "d"})
+	c.Assert(err, jc.ErrorIsNil)
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "bar", "a.b$c": "d"})
+
+	// Keys missing from the new state are removed.
+	err = s.unit.SetCharmState(map[string]string{"baz": "qux"})
+	c.Assert(err, jc.ErrorIsNil)
+	charmState, err = s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"baz": "qux"})
+}
+
+func (s *CharmStateSuite) TestSetCharmStateTooLarge(c *gc.C) {
+	err := s.unit.SetCharmState(map[string]string{"foo": strings.Repeat("x", 64*1024)})
+	c.Assert(err, gc.ErrorMatches, `cannot set charm state of unit ".*": size 65539 exceeds maximum of 65536 bytes`)
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+}
+
+func (s *CharmStateSuite) TestSetCharmStateDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetCharmState(map[string]string{"foo": "bar"})
+	c.Assert(err, gc.ErrorMatches, `cannot set charm state of unit ".*": not found or dead`)
+}
+
+func (s *CharmStateSuite) TestRemoveUnitRemovesCharmState(c *gc.C) {
+	err := s.unit.SetCharmState(map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.ReadSettings("u#" + s.unit.Name() + "#state")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CharmStateSuite) TestRemoveUnitWithoutCharmState(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,283 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs/config"
+)
+
+// CloudCredentialRef identifies a cloud credential stored in the
+// controller. Credentials are owned by a user, and are named uniquely
+// for each cloud and owner.
+type CloudCredentialRef struct {
+	Owner names.UserTag
+	Cloud string
+	Name  string
+}
+
+// IsZero returns whether the reference identifies no credential.
+func (r CloudCredentialRef) IsZero() bool {
+	return r == CloudCredentialRef{}
+}
+
+// Validate returns an error if the reference is incomplete.
+func (r CloudCredentialRef) Validate() error {
+	if r.Owner.Id() == "" {
+		return errors.NotValidf("cloud credential with empty owner")
+	}
+	if r.Cloud == "" {
+		return errors.NotValidf("cloud credential with empty cloud")
+	}
+	if r.Name == "" {
+		return errors.NotValidf("cloud credential with empty name")
+	}
+	for _, part := range []string{r.Cloud, r.Name} {
+		if strings.Contains(part, "#") {
+			return errors.NotValidf("cloud credential %q", part)
+		}
+	}
+	return nil
+}
+
+// String returns a human readable representation of the reference.
+func (r CloudCredentialRef) String() string {
+	return r.Cloud + ":" + r.Owner.Canonical() + "/" + r.Name
+}
+
+func (r CloudCredentialRef) id() string {
+	return r.Owner.Canonical() + "#" + r.Cloud + "#" + r.Name
+}
+
+func parseCloudCredentialID(id string) (CloudCredentialRef, error) {
+	parts := strings.Split(id, "#")
+	if len(parts) != 3 || !names.IsValidUser(parts[0]) {
+		return CloudCredentialRef{}, errors.NotValidf("cloud credential id %q", id)
+	}
+	return CloudCredentialRef{
+		Owner: names.NewUserTag(parts[0]),
+		Cloud: parts[1],
+		Name:  parts[2],
+	}, nil
+}
+
+// cloudCredentialDoc records a cloud credential stored in the
+// controller.
+type cloudCredentialDoc struct {
+	DocID      string            `bson:"_id"`
+	Owner      string            `bson:"owner"`
+	Cloud      string            `bson:"cloud"`
+	Name       string            `bson:"name"`
+	AuthType   string            `bson:"auth-type"`
+	Attributes map[string]string `bson:"attributes"`
+}
+
+// CloudCredential returns the stored cloud credential identified by
+// the given reference, or an error satisfying errors.IsNotFound if
+// there is no such credential.
+func (st *State) CloudCredential(ref CloudCredentialRef) (cloud.Credential, error) {
+	if err := ref.Validate(); err != nil {
+		return cloud.Credential{}, errors.Trace(err)
+	}
+	coll, closer := st.getCollection(cloudCredentialsC)
+	defer closer()
+
+	var doc cloudCredentialDoc
+	if err := coll.FindId(ref.id()).One(&doc); err == mgo.ErrNotFound {
+		return cloud.Credential{}, errors.NotFoundf("cloud credential %q", ref)
+	} else if err != nil {
+		return cloud.Credential{}, errors.Annotatef(err, "cannot get cloud credential %q", ref)
+	}
+	return cloud.NewCredential(cloud.AuthType(doc.AuthType), doc.Attributes), nil
+}
+
+// AddCloudCredential stores the given cloud credential, identified by
+// the given reference. An error satisfying errors.IsAlreadyExists is
+// returned if there is already a credential with the same reference;
+// stored credentials are only ever replaced by UpdateCloudCredential.
+func (st *State) AddCloudCredential(ref CloudCredentialRef, credential cloud.Credential) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add cloud credential %q", ref)
+	if err := st.validateCloudCredentialOwner(ref); err != nil {
+		return errors.Trace(err)
+	}
+	ops := []txn.Op{addCloudCredentialOp(ref, credential)}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.AlreadyExistsf("cloud credential %q", ref)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// UpdateCloudCredential adds or replaces the stored cloud credential
+// identified by the given reference. The credential attributes are
+// written into the config of every model that uses the credential, in
+// the same transaction, so that workers using those models' environs
+// pick up the new secret without restarting; attributes that are no
+// longer part of the credential are removed. The tags of the updated
+// models are returned.
+func (st *State) UpdateCloudCredential(ref CloudCredentialRef, credential cloud.Credential) (_ []names.ModelTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot update cloud credential %q", ref)
+	if err := st.validateCloudCredentialOwner(ref); err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := ref.id()
+	var updated []names.ModelTag
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		var ops []txn.Op
+		var oldAttrs map[string]string
+		existing, err := st.CloudCredential(ref)
+		if err == nil {
+			oldAttrs = existing.Attributes()
+			ops = append(ops, txn.Op{
+				C:      cloudCredentialsC,
+				Id:     id,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{
+					{"auth-type", string(credential.AuthType())},
+					{"attributes", credential.Attributes()},
+				}}},
+			})
+		} else if errors.IsNotFound(err) {
+			ops = append(ops, addCloudCredentialOp(ref, credential))
+		} else {
+			return nil, errors.Trace(err)
+		}
+		modelOps, models, err := st.cloudCredentialModelOps(id, oldAttrs, credential.Attributes())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		updated = models
+		return append(ops, modelOps...), nil
+	}
+	if err := st.runRaw(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return updated, nil
+}
+
+// validateCloudCredentialOwner returns an error if the reference is
+// invalid, or if it refers to a local user that does not exist.
+func (st *State) validateCloudCredentialOwner(ref CloudCredentialRef) error {
+	if err := ref.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if ref.Owner.IsLocal() {
+		if _, err := st.User(ref.Owner); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func addCloudCredentialOp(ref CloudCredentialRef, credential cloud.Credential) txn.Op {
+	id := ref.id()
+	return txn.Op{
+		C:      cloudCredentialsC,
+		Id:     id,
+		Assert: txn.DocMissing,
+		Insert: &cloudCredentialDoc{
+			DocID:      id,
+			Owner:      ref.Owner.Canonical(),
+			Cloud:      ref.Cloud,
+			Name:       ref.Name,
+			AuthType:   string(credential.AuthType()),
+			Attributes: credential.Attributes(),
+		},
+	}
+}
+
+// cloudCredentialModelOps returns the operations needed to replace the
+// old credential attributes with the new ones in the config of each
+// live model that uses the credential with the given id, and the tags
+// of those models. The operations refer to documents in several
+// models, so must be run without model filtering.
+func (st *State) cloudCredentialModelOps(id string, oldAttrs, newAttrs map[string]string) ([]txn.Op, []names.ModelTag, error) {
+	models, closer := st.getCollection(modelsC)
+	defer closer()
+
+	var docs []modelDoc
+	err := models.Find(bson.D{
+		{"cloud-credential", id},
+		{"life", Alive},
+	}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	updateAttrs := make(map[string]interface{})
+	for key, value := range newAttrs {
+		updateAttrs[key] = value
+	}
+	var removeAttrs []string
+	for key := range oldAttrs {
+		if _, ok := newAttrs[key]; !ok {
+			removeAttrs = append(removeAttrs, key)
+		}
+	}
+	var ops []txn.Op
+	var tags []names.ModelTag
+	for _, doc := range docs {
+		tag := names.NewModelTag(doc.UUID)
+		op, err := st.updateModelConfigOp(tag, updateAttrs, removeAttrs)
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "updating model %q", doc.UUID)
+		}
+		ops = append(ops, txn.Op{
+			C:  modelsC,
+			Id: doc.UUID,
+			Assert: bson.D{
+				{"cloud-credential", id},
+				{"life", Alive},
+			},
+		}, op)
+		tags = append(tags, tag)
+	}
+	return ops, tags, nil
+}
+
+// updateModelConfigOp returns an operation that applies the given
+// changes to the config of the model with the given tag, asserting
+// that the config has not otherwise changed.
+func (st *State) updateModelConfigOp(tag names.ModelTag, updateAttrs map[string]interface{}, removeAttrs []string) (txn.Op, error) {
+	modelSt, err := st.ForModel(tag)
+	if err != nil {
+		return txn.Op{}, errors.Trace(err)
+	}
+	defer modelSt.Close()
+
+	settings, err := readSettings(modelSt, modelGlobalKey)
+	if err != nil {
+		return txn.Op{}, errors.Trace(err)
+	}
+	oldConfig, err := config.New(config.NoDefaults, settings.Map())
+	if err != nil {
+		return txn.Op{}, errors.Trace(err)
+	}
+	validCfg, err := modelSt.buildAndValidateModelConfig(updateAttrs, removeAttrs, oldConfig)
+	if err != nil {
+		return txn.Op{}, errors.Trace(err)
+	}
+	validAttrs := validCfg.AllAttrs()
+	unset := bson.M{}
+	for key := range settings.disk {
+		if _, ok := validAttrs[key]; !ok {
+			unset[escapeReplacer.Replace(key)] = 1
+		}
+	}
+	set := bson.M(copyMap(validAttrs, escapeReplacer.Replace))
+	return txn.Op{
+		C:      settingsC,
+		Id:     modelSt.docID(modelGlobalKey),
+		Assert: bson.D{{"version", settings.version}},
+		Update: setUnsetUpdateSettings(set, unset),
+	}, nil
+}
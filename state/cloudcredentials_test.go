@@ -0,0 +1,180 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type CloudCredentialsSuite struct {
+	ConnSuite
+	ref state.CloudCredentialRef
+}
+
+var _ = gc.Suite(&CloudCredentialsSuite{})
+
+func (s *CloudCredentialsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.ref = state.CloudCredentialRef{
+		Owner: s.Owner,
+		Cloud: "dummy",
+		Name:  "default",
+	}
+}
+
+func (s *CloudCredentialsSuite) newModel(c *gc.C, name string, ref state.CloudCredentialRef) (*state.Model, *state.State) {
+	uuid, err := utils.NewUUID()
+	c.Assert(err, jc.ErrorIsNil)
+	cfg := testing.CustomModelConfig(c, testing.Attrs{
+		"name": name,
+		"uuid": uuid.String(),
+	})
+	model, st, err := s.State.NewModel(state.ModelArgs{
+		Config:          cfg,
+		Owner:           s.Owner,
+		CloudCredential: ref,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return model, st
+}
+
+func (s *CloudCredentialsSuite) TestCloudCredentialNotFound(c *gc.C) {
+	_, err := s.State.CloudCredential(s.ref)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `cloud credential "dummy:test-admin@local/default" not found`)
+}
+
+func (s *CloudCredentialsSuite) TestUpdateCloudCredential(c *gc.C) {
+	cred := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		"username": "bob",
+		"password": "hunter2",
+	})
+	models, err := s.State.UpdateCloudCredential(s.ref, cred)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, gc.HasLen, 0)
+
+	stored, err := s.State.CloudCredential(s.ref)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored.AuthType(), gc.Equals, cloud.UserPassAuthType)
+	c.Assert(stored.Attributes(), jc.DeepEquals, cred.Attributes())
+
+	cred = cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		"username": "bob",
+		"password": "correct horse battery staple",
+	})
+	_, err = s.State.UpdateCloudCredential(s.ref, cred)
+	c.Assert(err, jc.ErrorIsNil)
+	stored, err = s.State.CloudCredential(s.ref)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored.Attributes(), jc.DeepEquals, cred.Attributes())
+}
+
+func (s *CloudCredentialsSuite) TestUpdateCloudCredentialInvalidRef(c *gc.C) {
+	_, err := s.State.UpdateCloudCredential(state.CloudCredentialRef{
+		Owner: s.Owner,
+		Cloud: "dummy",
+	}, cloud.NewEmptyCredential())
+	c.Assert(err, gc.ErrorMatches, `cannot update cloud credential .*: cloud credential with empty name not valid`)
+}
+
+func (s *CloudCredentialsSuite) TestNewModelMissingCredential(c *gc.C) {
+	uuid, err := utils.NewUUID()
+	c.Assert(err, jc.ErrorIsNil)
+	cfg := testing.CustomModelConfig(c, testing.Attrs{
+		"name": "testing",
+		"uuid": uuid.String(),
+	})
+	_, _, err = s.State.NewModel(state.ModelArgs{
+		Config:          cfg,
+		Owner:           s.Owner,
+		CloudCredential: s.ref,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot create model: cloud credential .* not found`)
+}
+
+func (s *CloudCredentialsSuite) TestUpdateCloudCredentialUpdatesModels(c *gc.C) {
+	_, err := s.State.UpdateCloudCredential(s.ref, cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		"username": "bob",
+		"password": "hunter2",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, st := s.newModel(c, "uses-credential", s.ref)
+	defer st.Close()
+	ref, ok := model.CloudCredential()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(ref, jc.DeepEquals, s.ref)
+
+	_, other := s.newModel(c, "no-credential", state.CloudCredentialRef{})
+	defer other.Close()
+
+	models, err := s.State.UpdateCloudCredential(s.ref, cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		"username": "bob",
+		"password": "rotated",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, jc.DeepEquals, []names.ModelTag{model.ModelTag()})
+
+	cfg, err := st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllAttrs()["password"], gc.Equals, "rotated")
+
+	cfg, err = other.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = cfg.AllAttrs()["password"]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *CloudCredentialsSuite) TestUpdateCloudCredentialRemovesDroppedAttributes(c *gc.C) {
+	_, err := s.State.UpdateCloudCredential(s.ref, cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		"username": "bob",
+		"password": "hunter2",
+		"token":    "sekrit",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	_, st := s.newModel(c, "uses-credential", s.ref)
+	defer st.Close()
+	cfg, err := st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllAttrs()["token"], gc.Equals, "sekrit")
+
+	_, err = s.State.UpdateCloudCredential(s.ref, cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		"username": "bob",
+		"password": "rotated",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err = st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AllAttrs()["password"], gc.Equals, "rotated")
+	_, ok := cfg.AllAttrs()["token"]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *CloudCredentialsSuite) TestAddCloudCredential(c *gc.C) {
+	cred := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		"username": "bob",
+		"password": "hunter2",
+	})
+	err := s.State.AddCloudCredential(s.ref, cred)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Adding a credential never replaces one already stored.
+	err = s.State.AddCloudCredential(s.ref, cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		"username": "bob",
+		"password": "other",
+	}))
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `cannot add cloud credential .*: cloud credential .* already exists`)
+	stored, err := s.State.CloudCredential(s.ref)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored.Attributes(), jc.DeepEquals, cred.Attributes())
+}
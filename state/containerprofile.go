@@ -0,0 +1,195 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/instance"
+)
+
+// containerProfileDoc is the mongodb representation of the container
+// profile of a service. LXD configuration keys commonly contain dots,
+// so maps are stored as lists of key/value pairs.
+type containerProfileDoc struct {
+	ModelUUID  string                    `bson:"model-uuid"`
+	LXDConfig  []containerProfileItemDoc `bson:"lxd-config"`
+	LXDDevices []lxdDeviceDoc            `bson:"lxd-devices"`
+	KVMCPU     string                    `bson:"kvm-cpu"`
+	KVMDevices []string                  `bson:"kvm-devices"`
+}
+
+type containerProfileItemDoc struct {
+	Key   string `bson:"key"`
+	Value string `bson:"value"`
+}
+
+type lxdDeviceDoc struct {
+	Name       string                    `bson:"name"`
+	Properties []containerProfileItemDoc `bson:"properties"`
+}
+
+func newContainerProfileDoc(profile instance.ContainerProfile) containerProfileDoc {
+	doc := containerProfileDoc{
+		LXDConfig:  profileItemDocs(profile.LXDConfig),
+		KVMCPU:     profile.KVMCPU,
+		KVMDevices: profile.KVMDevices,
+	}
+	names := make([]string, 0, len(profile.LXDDevices))
+	for name := range profile.LXDDevices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		doc.LXDDevices = append(doc.LXDDevices, lxdDeviceDoc{
+			Name:       name,
+			Properties: profileItemDocs(profile.LXDDevices[name]),
+		})
+	}
+	return doc
+}
+
+func (doc containerProfileDoc) value() instance.ContainerProfile {
+	profile := instance.ContainerProfile{
+		LXDConfig:  profileItemMap(doc.LXDConfig),
+		KVMCPU:     doc.KVMCPU,
+		KVMDevices: doc.KVMDevices,
+	}
+	for _, device := range doc.LXDDevices {
+		if profile.LXDDevices == nil {
+			profile.LXDDevices = make(map[string]map[string]string)
+		}
+		profile.LXDDevices[device.Name] = profileItemMap(device.Properties)
+	}
+	return profile
+}
+
+func profileItemDocs(items map[string]string) []containerProfileItemDoc {
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var docs []containerProfileItemDoc
+	for _, key := range keys {
+		docs = append(docs, containerProfileItemDoc{Key: key, Value: items[key]})
+	}
+	return docs
+}
+
+func profileItemMap(docs []containerProfileItemDoc) map[string]string {
+	if len(docs) == 0 {
+		return nil
+	}
+	items := make(map[string]string, len(docs))
+	for _, doc := range docs {
+		items[doc.Key] = doc.Value
+	}
+	return items
+}
+
+func removeContainerProfileOp(key string) txn.Op {
+	return txn.Op{
+		C:      containerProfilesC,
+		Id:     key,
+		Remove: true,
+	}
+}
+
+// readContainerProfile returns the container profile stored under the
+// given service global key, or an error satisfying errors.IsNotFound().
+func readContainerProfile(st *State, key string) (instance.ContainerProfile, error) {
+	containerProfiles, closer := st.getCollection(containerProfilesC)
+	defer closer()
+
+	var doc containerProfileDoc
+	if err := containerProfiles.FindId(key).One(&doc); err == mgo.ErrNotFound {
+		return instance.ContainerProfile{}, errors.NotFoundf("container profile")
+	} else if err != nil {
+		return instance.ContainerProfile{}, errors.Trace(err)
+	}
+	return doc.value(), nil
+}
+
+// ContainerProfile returns the customisations applied to the containers
+// hosting the service's units. An empty profile is returned if none has
+// been set.
+func (s *Service) ContainerProfile() (instance.ContainerProfile, error) {
+	profile, err := readContainerProfile(s.st, s.globalKey())
+	if errors.IsNotFound(err) {
+		return instance.ContainerProfile{}, nil
+	}
+	return profile, errors.Trace(err)
+}
+
+// SetContainerProfile replaces the customisations applied to containers
+// subsequently created to host the service's units. An empty profile
+// removes any customisations. Containers that already exist are not
+// changed.
+func (s *Service) SetContainerProfile(profile instance.ContainerProfile) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set container profile for service %q", s.Name())
+	if err := profile.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if s.doc.Subordinate {
+		return errors.New("subordinate services run in the containers of their principals")
+	}
+	key := s.globalKey()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if s.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		_, err := readContainerProfile(s.st, key)
+		exists := err == nil
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      servicesC,
+			Id:     s.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		doc := newContainerProfileDoc(profile)
+		switch {
+		case profile.IsEmpty() && !exists:
+			return nil, jujutxn.ErrNoOperations
+		case profile.IsEmpty():
+			op := removeContainerProfileOp(key)
+			op.Assert = txn.DocExists
+			ops = append(ops, op)
+		case exists:
+			ops = append(ops, txn.Op{
+				C:      containerProfilesC,
+				Id:     key,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{
+					{"lxd-config", doc.LXDConfig},
+					{"lxd-devices", doc.LXDDevices},
+					{"kvm-cpu", doc.KVMCPU},
+					{"kvm-devices", doc.KVMDevices},
+				}}},
+			})
+		default:
+			ops = append(ops, txn.Op{
+				C:      containerProfilesC,
+				Id:     key,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			})
+		}
+		return ops, nil
+	}
+	return s.st.run(buildTxn)
+}
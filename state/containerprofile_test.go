@@ -0,0 +1,89 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+type ContainerProfileSuite struct {
+	ConnSuite
+	mysql *state.Service
+}
+
+var _ = gc.Suite(&ContainerProfileSuite{})
+
+func (s *ContainerProfileSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.mysql = s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+}
+
+func (s *ContainerProfileSuite) TestNoProfile(c *gc.C) {
+	profile, err := s.mysql.ContainerProfile()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile.IsEmpty(), jc.IsTrue)
+}
+
+func (s *ContainerProfileSuite) TestSetContainerProfile(c *gc.C) {
+	profile := instance.ContainerProfile{
+		LXDConfig: map[string]string{
+			"security.nesting":     "true",
+			"linux.kernel_modules": "openvswitch",
+		},
+		LXDDevices: map[string]map[string]string{
+			"kvm": {"type": "unix-char", "path": "/dev/kvm"},
+		},
+		KVMCPU: "<cpu mode='host-passthrough'/>",
+	}
+	err := s.mysql.SetContainerProfile(profile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stored, err := s.mysql.ContainerProfile()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, jc.DeepEquals, profile)
+
+	// Replacing the profile drops customisations no longer present.
+	replacement := instance.ContainerProfile{
+		KVMDevices: []string{"<rng model='virtio'/>"},
+	}
+	err = s.mysql.SetContainerProfile(replacement)
+	c.Assert(err, jc.ErrorIsNil)
+	stored, err = s.mysql.ContainerProfile()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, jc.DeepEquals, replacement)
+
+	err = s.mysql.SetContainerProfile(instance.ContainerProfile{})
+	c.Assert(err, jc.ErrorIsNil)
+	stored, err = s.mysql.ContainerProfile()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored.IsEmpty(), jc.IsTrue)
+}
+
+func (s *ContainerProfileSuite) TestSetContainerProfileRejectsUnsafeKeys(c *gc.C) {
+	err := s.mysql.SetContainerProfile(instance.ContainerProfile{
+		LXDConfig: map[string]string{"raw.lxc": "lxc.aa_profile=unconfined"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set container profile for service "mysql": LXD config key "raw.lxc" not valid`)
+}
+
+func (s *ContainerProfileSuite) TestSetContainerProfileSubordinate(c *gc.C) {
+	logging := s.AddTestingService(c, "logging", s.AddTestingCharm(c, "logging"))
+	err := logging.SetContainerProfile(instance.ContainerProfile{
+		LXDConfig: map[string]string{"security.nesting": "true"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set container profile for service "logging": subordinate services run in the containers of their principals`)
+}
+
+func (s *ContainerProfileSuite) TestSetContainerProfileDeadService(c *gc.C) {
+	err := s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetContainerProfile(instance.ContainerProfile{
+		LXDConfig: map[string]string{"security.nesting": "true"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set container profile for service "mysql": .*not found.*`)
+}
@@ -0,0 +1,270 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+const controllerCertificatesKey = "controllerCertificates"
+
+// controllerCertificatesDoc records the rotation of a controller's
+// certificates.
+type controllerCertificatesDoc struct {
+	CACert            string `bson:"cacert"`
+	PreviousCACert    string `bson:"previouscacert"`
+	CrossSignedCACert string `bson:"crosssignedcacert"`
+	TransitionEnd     int64  `bson:"transitionend"`
+	Serial            int    `bson:"serial"`
+}
+
+// ControllerCertificates describes the CA certificates used to sign
+// and validate a controller's server certificates.
+type ControllerCertificates struct {
+	// CACert holds the CA certificate that signs the controller's
+	// server certificates.
+	CACert string
+
+	// PreviousCACert holds the CA certificate replaced by CACert,
+	// if any. It remains trusted until TransitionEnd.
+	PreviousCACert string
+
+	// CrossSignedCACert holds CACert signed by the previous CA.
+	// Until TransitionEnd, controllers present it along with their
+	// server certificates, so that agents and clients which only
+	// trust the previous CA can still connect.
+	CrossSignedCACert string
+
+	// TransitionEnd holds the time at which the previous CA
+	// stops being trusted.
+	TransitionEnd time.Time
+
+	// Serial is incremented every time the certificates are
+	// rotated, so that controllers know to regenerate their
+	// server certificates.
+	Serial int
+}
+
+// InTransition reports whether the previous CA is still trusted at
+// the given time.
+func (c ControllerCertificates) InTransition(now time.Time) bool {
+	return c.PreviousCACert != "" && now.Before(c.TransitionEnd)
+}
+
+// TrustedCACerts returns the bundle of CA certificates that should
+// be trusted at the given time, the current CA first.
+func (c ControllerCertificates) TrustedCACerts(now time.Time) string {
+	if c.InTransition(now) {
+		return c.CACert + c.PreviousCACert
+	}
+	return c.CACert
+}
+
+// ServerCertChain returns the given server certificate along with
+// any intermediate certificates that should be presented with it at
+// the given time.
+func (c ControllerCertificates) ServerCertChain(certPEM string, now time.Time) string {
+	if c.InTransition(now) && c.CrossSignedCACert != "" {
+		return certPEM + c.CrossSignedCACert
+	}
+	return certPEM
+}
+
+// ControllerCertificates returns the controller's current certificates.
+// Controllers whose certificates have never been rotated report the CA
+// certificate they were bootstrapped with.
+func (st *State) ControllerCertificates() (ControllerCertificates, error) {
+	doc, err := st.controllerCertificatesDoc()
+	if errors.IsNotFound(err) {
+		return ControllerCertificates{CACert: st.mongoInfo.CACert}, nil
+	} else if err != nil {
+		return ControllerCertificates{}, errors.Trace(err)
+	}
+	return doc.certificates(), nil
+}
+
+// controllerCertificatesCache holds the controller's certificates so
+// that they need not be read for every connection. The certificates
+// are read again whenever the watcher reports a change.
+type controllerCertificatesCache struct {
+	mu      sync.Mutex
+	watcher NotifyWatcher
+	certs   *ControllerCertificates
+}
+
+// cachedControllerCertificates returns the controller's certificates,
+// reading them only if they have changed since they were last read.
+func (st *State) cachedControllerCertificates() (ControllerCertificates, error) {
+	cache := &st.certsCache
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.watcher == nil {
+		cache.watcher = st.WatchControllerCertificates()
+	}
+	select {
+	case _, ok := <-cache.watcher.Changes():
+		if !ok {
+			// The watcher has failed; start another on the next
+			// call, and read the certificates in the meantime.
+			if err := cache.watcher.Stop(); err != nil {
+				logger.Warningf("controller certificates watcher failed: %v", err)
+			}
+			cache.watcher = nil
+		}
+		cache.certs = nil
+	default:
+	}
+	if cache.certs == nil {
+		certs, err := st.ControllerCertificates()
+		if err != nil {
+			return ControllerCertificates{}, errors.Trace(err)
+		}
+		cache.certs = &certs
+	}
+	return *cache.certs, nil
+}
+
+// invalidateCachedControllerCertificates causes the certificates to be
+// read again on the next call to cachedControllerCertificates, without
+// waiting for the watcher to report the change.
+func (st *State) invalidateCachedControllerCertificates() {
+	st.certsCache.mu.Lock()
+	st.certsCache.certs = nil
+	st.certsCache.mu.Unlock()
+}
+
+func (doc *controllerCertificatesDoc) certificates() ControllerCertificates {
+	certs := ControllerCertificates{
+		CACert:            doc.CACert,
+		PreviousCACert:    doc.PreviousCACert,
+		CrossSignedCACert: doc.CrossSignedCACert,
+		Serial:            doc.Serial,
+	}
+	if doc.TransitionEnd != 0 {
+		certs.TransitionEnd = time.Unix(0, doc.TransitionEnd).UTC()
+	}
+	return certs
+}
+
+func (st *State) controllerCertificatesDoc() (*controllerCertificatesDoc, error) {
+	controllers, closer := st.getCollection(controllersC)
+	defer closer()
+
+	var doc controllerCertificatesDoc
+	err := controllers.FindId(controllerCertificatesKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("controller certificates")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &doc, nil
+}
+
+// RotateControllerCertificates causes every controller to regenerate
+// its server certificate, signed by the current CA.
+func (st *State) RotateControllerCertificates() error {
+	return st.updateControllerCertificates(func(certs *ControllerCertificates) ([]txn.Op, error) {
+		return nil, nil
+	})
+}
+
+// ReplaceControllerCA replaces the CA that signs the controller's
+// server certificates with the given one, and causes every controller
+// to regenerate its server certificate. Until transitionEnd, the
+// previous CA remains trusted and controllers present the given
+// cross-signed CA certificate along with their server certificates.
+//
+// The CA cannot be replaced again until the previous transition has
+// ended, because only one previous CA is kept, and agents that have
+// not yet learned of the current CA would lose the ability to connect.
+func (st *State) ReplaceControllerCA(caCert, caPrivateKey, crossSignedCACert string, transitionEnd time.Time) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot replace controller CA")
+	if caCert == "" || caPrivateKey == "" {
+		return errors.New("CA certificate and private key must be specified")
+	}
+	now := GetClock().Now()
+	return st.updateControllerCertificates(func(certs *ControllerCertificates) ([]txn.Op, error) {
+		if certs.InTransition(now) {
+			return nil, errors.Errorf(
+				"previous CA still trusted until %s",
+				certs.TransitionEnd.Format(time.RFC3339),
+			)
+		}
+		if _, err := st.StateServingInfo(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		certs.PreviousCACert = certs.CACert
+		certs.CACert = caCert
+		certs.CrossSignedCACert = crossSignedCACert
+		certs.TransitionEnd = transitionEnd
+		// The CA private key is distributed to the controllers
+		// along with the rest of the state serving info.
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     stateServingInfoKey,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"caprivatekey", caPrivateKey}}}},
+		}}, nil
+	})
+}
+
+// updateControllerCertificates updates the controller certificates
+// with the given function, which may also return further operations
+// to run in the same transaction, and increments their serial.
+func (st *State) updateControllerCertificates(update func(*ControllerCertificates) ([]txn.Op, error)) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, err := st.controllerCertificatesDoc()
+		exists := err == nil
+		if errors.IsNotFound(err) {
+			doc = &controllerCertificatesDoc{CACert: st.mongoInfo.CACert}
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		certs := doc.certificates()
+		ops, err := update(&certs)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		newDoc := controllerCertificatesDoc{
+			CACert:            certs.CACert,
+			PreviousCACert:    certs.PreviousCACert,
+			CrossSignedCACert: certs.CrossSignedCACert,
+			Serial:            doc.Serial + 1,
+		}
+		if !certs.TransitionEnd.IsZero() {
+			newDoc.TransitionEnd = certs.TransitionEnd.UnixNano()
+		}
+		if !exists {
+			return append(ops, txn.Op{
+				C:      controllersC,
+				Id:     controllerCertificatesKey,
+				Assert: txn.DocMissing,
+				Insert: &newDoc,
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      controllersC,
+			Id:     controllerCertificatesKey,
+			Assert: bson.D{{"serial", doc.Serial}},
+			Update: bson.D{{"$set", newDoc}},
+		}), nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	st.invalidateCachedControllerCertificates()
+	return nil
+}
+
+// WatchControllerCertificates returns a NotifyWatcher that notifies
+// when the controller's certificates are rotated.
+func (st *State) WatchControllerCertificates() NotifyWatcher {
+	return newEntityWatcher(st, controllersC, controllerCertificatesKey)
+}
@@ -0,0 +1,180 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ControllerCertificatesSuite struct {
+	ConnSuite
+	clock *coretesting.Clock
+}
+
+var _ = gc.Suite(&ControllerCertificatesSuite{})
+
+func (s *ControllerCertificatesSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = coretesting.NewClock(time.Now().Truncate(time.Second))
+	s.PatchValue(&state.GetClock, func() clock.Clock {
+		return s.clock
+	})
+}
+
+func (s *ControllerCertificatesSuite) setStateServingInfo(c *gc.C) {
+	err := s.State.SetStateServingInfo(state.StateServingInfo{
+		APIPort:      69,
+		StatePort:    80,
+		Cert:         coretesting.ServerCert,
+		PrivateKey:   coretesting.ServerKey,
+		CAPrivateKey: coretesting.CAKey,
+		SharedSecret: "Some Keyfile",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ControllerCertificatesSuite) TestControllerCertificatesNeverRotated(c *gc.C) {
+	certs, err := s.State.ControllerCertificates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(certs, jc.DeepEquals, state.ControllerCertificates{
+		CACert: coretesting.CACert,
+	})
+	c.Assert(s.State.CACert(), gc.Equals, coretesting.CACert)
+}
+
+func (s *ControllerCertificatesSuite) TestRotateControllerCertificates(c *gc.C) {
+	err := s.State.RotateControllerCertificates()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RotateControllerCertificates()
+	c.Assert(err, jc.ErrorIsNil)
+
+	certs, err := s.State.ControllerCertificates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(certs, jc.DeepEquals, state.ControllerCertificates{
+		CACert: coretesting.CACert,
+		Serial: 2,
+	})
+}
+
+func (s *ControllerCertificatesSuite) TestReplaceControllerCA(c *gc.C) {
+	s.setStateServingInfo(c)
+	transitionEnd := s.clock.Now().Add(time.Hour).UTC()
+	err := s.State.ReplaceControllerCA(
+		coretesting.OtherCACert, coretesting.OtherCAKey, "cross-signed", transitionEnd,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	certs, err := s.State.ControllerCertificates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(certs, jc.DeepEquals, state.ControllerCertificates{
+		CACert:            coretesting.OtherCACert,
+		PreviousCACert:    coretesting.CACert,
+		CrossSignedCACert: "cross-signed",
+		TransitionEnd:     transitionEnd,
+		Serial:            1,
+	})
+	info, err := s.State.StateServingInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.CAPrivateKey, gc.Equals, coretesting.OtherCAKey)
+
+	// Both CAs are trusted until the end of the transition.
+	c.Assert(s.State.CACert(), gc.Equals, coretesting.OtherCACert+coretesting.CACert)
+	c.Assert(certs.ServerCertChain("server", s.clock.Now()), gc.Equals, "servercross-signed")
+
+	s.clock.Advance(time.Hour)
+	c.Assert(s.State.CACert(), gc.Equals, coretesting.OtherCACert)
+	c.Assert(certs.ServerCertChain("server", s.clock.Now()), gc.Equals, "server")
+}
+
+func (s *ControllerCertificatesSuite) TestReplaceControllerCAInTransition(c *gc.C) {
+	s.setStateServingInfo(c)
+	transitionEnd := s.clock.Now().Add(time.Hour).UTC()
+	err := s.State.ReplaceControllerCA(
+		coretesting.OtherCACert, coretesting.OtherCAKey, "", transitionEnd,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ReplaceControllerCA(
+		coretesting.CACert, coretesting.CAKey, "", transitionEnd.Add(time.Hour),
+	)
+	c.Assert(err, gc.ErrorMatches, "cannot replace controller CA: previous CA still trusted until .*")
+	certs, err := s.State.ControllerCertificates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(certs.CACert, gc.Equals, coretesting.OtherCACert)
+	c.Assert(certs.Serial, gc.Equals, 1)
+
+	// Once the transition has ended, the CA can be replaced again.
+	s.clock.Advance(time.Hour)
+	err = s.State.ReplaceControllerCA(
+		coretesting.CACert, coretesting.CAKey, "", s.clock.Now().Add(time.Hour),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ControllerCertificatesSuite) TestCACertRefreshedByWatcher(c *gc.C) {
+	s.setStateServingInfo(c)
+	c.Assert(s.State.CACert(), gc.Equals, coretesting.CACert)
+
+	// Replace the CA through another State, so that s.State only
+	// learns of the change through its watcher.
+	otherSt, err := s.State.ForModel(s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	defer otherSt.Close()
+	err = otherSt.ReplaceControllerCA(
+		coretesting.OtherCACert, coretesting.OtherCAKey, "", s.clock.Now().Add(time.Hour),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	expect := coretesting.OtherCACert + coretesting.CACert
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		s.State.StartSync()
+		if s.State.CACert() == expect {
+			return
+		}
+	}
+	c.Fatalf("CACert not refreshed, got %q", s.State.CACert())
+}
+
+func (s *ControllerCertificatesSuite) TestReplaceControllerCANoStateServingInfo(c *gc.C) {
+	err := s.State.ReplaceControllerCA(
+		coretesting.OtherCACert, coretesting.OtherCAKey, "", s.clock.Now(),
+	)
+	c.Assert(err, gc.ErrorMatches, "cannot replace controller CA: state serving info not found")
+}
+
+func (s *ControllerCertificatesSuite) TestReplaceControllerCAMissingKey(c *gc.C) {
+	err := s.State.ReplaceControllerCA(coretesting.OtherCACert, "", "", s.clock.Now())
+	c.Assert(err, gc.ErrorMatches, "cannot replace controller CA: CA certificate and private key must be specified")
+}
+
+func (s *ControllerCertificatesSuite) TestWatchControllerCertificates(c *gc.C) {
+	s.setStateServingInfo(c)
+	w := s.State.WatchControllerCertificates()
+	defer statetesting.AssertStop(c, w)
+
+	// Initial event.
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.RotateControllerCertificates()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.ReplaceControllerCA(
+		coretesting.OtherCACert, coretesting.OtherCAKey, "", s.clock.Now(),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
@@ -57,6 +57,7 @@ var (
 	ServiceGlobalKey       = serviceGlobalKey
 	MergeBindings          = mergeBindings
 	UpgradeInProgressError = errUpgradeInProgress
+	MaxActionMessages      = &maxActionMessages
 )
 
 type (
@@ -34,6 +34,56 @@ func (st *State) LeadershipChecker() leadership.Checker {
 	return leadershipChecker{st.leadershipManager}
 }
 
+// TransferLeadership arranges for leadership of the named unit's service to
+// be handed to that unit. The current leader remains leader until its lease
+// expires, but will not be able to extend it; the unit then becomes leader
+// without any other unit having the opportunity to claim leadership.
+func (st *State) TransferLeadership(unitName string) error {
+	unit, err := st.Unit(unitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if unit.Life() != Alive {
+		return errors.Errorf("unit %q is not alive", unitName)
+	}
+	serviceName := unit.ServiceName()
+	err = st.leadershipManager.Transfer(serviceName, unitName)
+	return errors.Annotatef(leadershipControlError(serviceName, err), "cannot transfer leadership to %q", unitName)
+}
+
+// PinLeadership prevents the named service's current leader from losing
+// leadership, even if it stops renewing its claim, until UnpinLeadership
+// is called.
+func (st *State) PinLeadership(serviceName string) error {
+	if _, err := st.Service(serviceName); err != nil {
+		return errors.Trace(err)
+	}
+	err := st.leadershipManager.Pin(serviceName)
+	return errors.Annotatef(leadershipControlError(serviceName, err), "cannot pin leadership")
+}
+
+// UnpinLeadership allows the named service's leader to lose leadership once
+// more, as it would have before PinLeadership was called.
+func (st *State) UnpinLeadership(serviceName string) error {
+	if _, err := st.Service(serviceName); err != nil {
+		return errors.Trace(err)
+	}
+	err := st.leadershipManager.Unpin(serviceName)
+	return errors.Annotatef(leadershipControlError(serviceName, err), "cannot unpin leadership")
+}
+
+// leadershipControlError converts errors from the leadership manager's
+// lease.Director methods into errors that make sense in terms of services.
+func leadershipControlError(serviceName string, err error) error {
+	switch errors.Cause(err) {
+	case corelease.ErrNotHeld:
+		return errors.Errorf("service %q has no leader", serviceName)
+	case corelease.ErrPinned:
+		return errors.Errorf("leadership of service %q is pinned", serviceName)
+	}
+	return errors.Trace(err)
+}
+
 // HackLeadership stops the state's internal leadership manager to prevent it
 // from interfering with apiserver shutdown.
 func (st *State) HackLeadership() {
@@ -68,9 +68,11 @@ func (client *client) Leases() map[string]lease.Info {
 	for name, entry := range client.entries {
 		skew := client.skews[entry.writer]
 		leases[name] = lease.Info{
-			Holder:   entry.holder,
-			Expiry:   skew.Latest(entry.expiry),
-			Trapdoor: client.assertOpTrapdoor(name, entry.holder),
+			Holder:    entry.holder,
+			Expiry:    skew.Latest(entry.expiry),
+			Pinned:    entry.pinned,
+			Successor: entry.successor,
+			Trapdoor:  client.assertOpTrapdoor(name, entry.holder),
 		}
 	}
 	return leases
@@ -89,7 +91,12 @@ func (client *client) ExtendLease(name string, request lease.Request) error {
 // opsFunc is used to make the signature of the request method somewhat readable.
 type opsFunc func(name string, request lease.Request) ([]txn.Op, entry, error)
 
-// request implements ClaimLease and ExtendLease.
+// HandOverLease is part of the lease.Client interface.
+func (client *client) HandOverLease(name string, request lease.Request) error {
+	return client.request(name, request, client.handOverLeaseOps, "handing over")
+}
+
+// request implements ClaimLease, ExtendLease and HandOverLease.
 func (client *client) request(name string, request lease.Request, getOps opsFunc, verb string) error {
 	if err := lease.ValidateString(name); err != nil {
 		return errors.Annotatef(err, "invalid name")
@@ -173,6 +180,71 @@ func (client *client) ExpireLease(name string) error {
 	return nil
 }
 
+// PinLease is part of the Client interface.
+func (client *client) PinLease(name string) error {
+	return client.update(name, "pinning", func(lastEntry entry) entry {
+		lastEntry.pinned = true
+		return lastEntry
+	})
+}
+
+// UnpinLease is part of the Client interface.
+func (client *client) UnpinLease(name string) error {
+	return client.update(name, "unpinning", func(lastEntry entry) entry {
+		lastEntry.pinned = false
+		return lastEntry
+	})
+}
+
+// TransferLease is part of the Client interface.
+func (client *client) TransferLease(name, successor string) error {
+	if err := lease.ValidateString(successor); err != nil {
+		return errors.Annotatef(err, "invalid successor")
+	}
+	return client.update(name, "transferring", func(lastEntry entry) entry {
+		lastEntry.successor = successor
+		return lastEntry
+	})
+}
+
+// update implements PinLease, UnpinLease and TransferLease, by writing the
+// entry returned by the supplied func over the existing lease entry.
+func (client *client) update(name, verb string, change func(entry) entry) error {
+	if err := lease.ValidateString(name); err != nil {
+		return errors.Annotatef(err, "invalid name")
+	}
+
+	// Close over cacheEntry to record in case of success.
+	var cacheEntry entry
+	err := client.config.Mongo.RunTransaction(func(attempt int) ([]txn.Op, error) {
+		client.logger.Tracef("%s lease %q (attempt %d)", verb, name, attempt)
+
+		// On the first attempt, assume cache is good.
+		if attempt > 0 {
+			if err := client.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		ops, nextEntry, err := client.updateLeaseOps(name, change)
+		cacheEntry = nextEntry
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return ops, nil
+	})
+
+	if err != nil {
+		if errors.Cause(err) == lease.ErrInvalid {
+			return lease.ErrInvalid
+		}
+		return errors.Annotatef(err, "cannot update lease %q", name)
+	}
+
+	// Update the cache for this lease only.
+	client.entries[name] = cacheEntry
+	return nil
+}
+
 // Refresh is part of the Client interface.
 func (client *client) Refresh() error {
 	client.logger.Tracef("refreshing")
@@ -385,9 +457,11 @@ func (client *client) extendLeaseOps(name string, request lease.Request) ([]txn.
 	// We know we need to write a lease; we know when it needs to expire; we
 	// know what needs to go into the local cache:
 	nextEntry := entry{
-		holder: lastEntry.holder,
-		expiry: expiry,
-		writer: client.config.Id,
+		holder:    lastEntry.holder,
+		expiry:    expiry,
+		writer:    client.config.Id,
+		pinned:    lastEntry.pinned,
+		successor: lastEntry.successor,
 	}
 
 	// ...and what needs to change in the database, and how to ensure the
@@ -451,6 +525,116 @@ func (client *client) expireLeaseOps(name string) ([]txn.Op, error) {
 	return ops, nil
 }
 
+// handOverLeaseOps returns the []txn.Op necessary to replace the holder of an
+// expired lease with its successor, claiming it until duration in the future,
+// and a cache entry corresponding to the values that will be written if the
+// transaction succeeds. If the handover would conflict with cached state, it
+// will return an error with a Cause of ErrInvalid.
+func (client *client) handOverLeaseOps(name string, request lease.Request) ([]txn.Op, entry, error) {
+
+	// We can only hand a lease that exists to its recorded successor.
+	lastEntry, found := client.entries[name]
+	if !found {
+		return nil, entry{}, lease.ErrInvalid
+	}
+	if lastEntry.successor != request.Holder {
+		return nil, entry{}, lease.ErrInvalid
+	}
+
+	// We also can't hand over a lease whose expiry time may be in the future.
+	skew := client.skews[lastEntry.writer]
+	latestExpiry := skew.Latest(lastEntry.expiry)
+	now := client.config.Clock.Now()
+	if !now.After(latestExpiry) {
+		return nil, entry{}, errors.Annotatef(lease.ErrInvalid, "lease %q expires in the future", name)
+	}
+	nextEntry := entry{
+		holder: request.Holder,
+		expiry: now.Add(request.Duration),
+		writer: client.config.Id,
+	}
+
+	// The old holder's lease is rewritten in place, rather than removed and
+	// reclaimed, so nobody else can claim it in between; it depends on the
+	// lease doc being untouched since we looked.
+	handOverLeaseOp := txn.Op{
+		C:  client.config.Collection,
+		Id: client.leaseDocId(name),
+		Assert: bson.M{
+			fieldLeaseHolder:    lastEntry.holder,
+			fieldLeaseExpiry:    toInt64(lastEntry.expiry),
+			fieldLeaseWriter:    lastEntry.writer,
+			fieldLeaseSuccessor: lastEntry.successor,
+		},
+		Update: bson.M{
+			"$set": bson.M{
+				fieldLeaseHolder: nextEntry.holder,
+				fieldLeaseExpiry: toInt64(nextEntry.expiry),
+				fieldLeaseWriter: nextEntry.writer,
+			},
+			"$unset": bson.M{
+				fieldLeasePinned:    nil,
+				fieldLeaseSuccessor: nil,
+			},
+		},
+	}
+
+	// We always write a clock-update operation *before* writing lease info.
+	writeClockOp := client.writeClockOp(now)
+	ops := []txn.Op{writeClockOp, handOverLeaseOp}
+	return ops, nextEntry, nil
+}
+
+// updateLeaseOps returns the []txn.Op necessary to replace the pinned and
+// successor fields of the supplied lease with those of the entry returned by
+// change, and a cache entry corresponding to the values that will be written
+// if the transaction succeeds. If the lease doesn't exist, or the update would
+// conflict with cached state, it returns lease.ErrInvalid.
+func (client *client) updateLeaseOps(name string, change func(entry) entry) ([]txn.Op, entry, error) {
+
+	// We can't update a lease that doesn't exist.
+	lastEntry, found := client.entries[name]
+	if !found {
+		return nil, entry{}, lease.ErrInvalid
+	}
+	nextEntry := change(lastEntry)
+	if nextEntry == lastEntry {
+		return nil, lastEntry, jujutxn.ErrNoOperations
+	}
+
+	// The holder and expiry are unchanged, so there's no need to write
+	// a clock update; but the lease must be untouched since we looked.
+	set, unset := bson.M{}, bson.M{}
+	if nextEntry.pinned {
+		set[fieldLeasePinned] = true
+	} else {
+		unset[fieldLeasePinned] = nil
+	}
+	if nextEntry.successor != "" {
+		set[fieldLeaseSuccessor] = nextEntry.successor
+	} else {
+		unset[fieldLeaseSuccessor] = nil
+	}
+	update := bson.M{}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	updateLeaseOp := txn.Op{
+		C:  client.config.Collection,
+		Id: client.leaseDocId(name),
+		Assert: bson.M{
+			fieldLeaseHolder: lastEntry.holder,
+			fieldLeaseExpiry: toInt64(lastEntry.expiry),
+			fieldLeaseWriter: lastEntry.writer,
+		},
+		Update: update,
+	}
+	return []txn.Op{updateLeaseOp}, nextEntry, nil
+}
+
 // writeClockOp returns a txn.Op which writes the supplied time to the writer's
 // field in the skew doc, and aborts if a more recent time has been recorded for
 // that writer.
@@ -514,6 +698,13 @@ type entry struct {
 
 	// writer identifies the client that wrote the lease.
 	writer string
+
+	// pinned is true if the lease must not be expired.
+	pinned bool
+
+	// successor, if not empty, identifies the holder to which the lease
+	// will be handed when it expires.
+	successor string
 }
 
 // errNoExtension is used internally to avoid running unnecessary transactions.
@@ -164,3 +164,110 @@ func (s *ClientOperationSuite) TestCannotExpireUnheldLease(c *gc.C) {
 	err := fix.Client.ExpireLease("name")
 	c.Assert(err, gc.Equals, lease.ErrInvalid)
 }
+
+func (s *ClientOperationSuite) TestPinLease(c *gc.C) {
+	fix := s.EasyFixture(c)
+	err := fix.Client.ClaimLease("name", lease.Request{"holder", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = fix.Client.PinLease("name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fix.Client.Leases()["name"].Pinned, jc.IsTrue)
+
+	// The pin is persisted, and survives extension.
+	fix.Clock.Advance(time.Second)
+	err = fix.Client.ExtendLease("name", lease.Request{"holder", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fix.Client.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fix.Client.Leases()["name"].Pinned, jc.IsTrue)
+
+	err = fix.Client.UnpinLease("name")
+	c.Assert(err, jc.ErrorIsNil)
+	err = fix.Client.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fix.Client.Leases()["name"].Pinned, jc.IsFalse)
+}
+
+func (s *ClientOperationSuite) TestCannotPinUnheldLease(c *gc.C) {
+	fix := s.EasyFixture(c)
+	err := fix.Client.PinLease("name")
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+}
+
+func (s *ClientOperationSuite) TestTransferLease(c *gc.C) {
+	fix := s.EasyFixture(c)
+	err := fix.Client.ClaimLease("name", lease.Request{"holder", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = fix.Client.TransferLease("name", "successor")
+	c.Assert(err, jc.ErrorIsNil)
+	err = fix.Client.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	info := fix.Client.Leases()["name"]
+	c.Check(info.Holder, gc.Equals, "holder")
+	c.Check(info.Successor, gc.Equals, "successor")
+
+	// Expiring the lease forgets the successor.
+	fix.Clock.Advance(time.Minute + time.Nanosecond)
+	err = fix.Client.ExpireLease("name")
+	c.Assert(err, jc.ErrorIsNil)
+	err = fix.Client.ClaimLease("name", lease.Request{"successor", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fix.Client.Leases()["name"].Successor, gc.Equals, "")
+}
+
+func (s *ClientOperationSuite) TestHandOverLease(c *gc.C) {
+	fix := s.EasyFixture(c)
+	err := fix.Client.ClaimLease("name", lease.Request{"holder", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fix.Client.TransferLease("name", "successor")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The lease goes straight to the successor, who holds it for the
+	// requested duration; the successor is forgotten.
+	fix.Clock.Advance(time.Minute + time.Nanosecond)
+	err = fix.Client.HandOverLease("name", lease.Request{"successor", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check("name", fix.Holder(), "successor")
+	c.Check("name", fix.Expiry(), fix.Zero.Add(2*time.Minute+time.Nanosecond))
+	c.Check(fix.Client.Leases()["name"].Successor, gc.Equals, "")
+
+	// The change is persisted.
+	err = fix.Client.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check("name", fix.Holder(), "successor")
+	c.Check(fix.Client.Leases()["name"].Successor, gc.Equals, "")
+}
+
+func (s *ClientOperationSuite) TestCannotHandOverLeaseBeforeExpiry(c *gc.C) {
+	fix := s.EasyFixture(c)
+	err := fix.Client.ClaimLease("name", lease.Request{"holder", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fix.Client.TransferLease("name", "successor")
+	c.Assert(err, jc.ErrorIsNil)
+
+	fix.Clock.Advance(time.Minute)
+	err = fix.Client.HandOverLease("name", lease.Request{"successor", time.Minute})
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+	c.Check("name", fix.Holder(), "holder")
+}
+
+func (s *ClientOperationSuite) TestCannotHandOverLeaseToOtherHolder(c *gc.C) {
+	fix := s.EasyFixture(c)
+	err := fix.Client.ClaimLease("name", lease.Request{"holder", time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fix.Client.TransferLease("name", "successor")
+	c.Assert(err, jc.ErrorIsNil)
+
+	fix.Clock.Advance(time.Minute + time.Nanosecond)
+	err = fix.Client.HandOverLease("name", lease.Request{"other", time.Minute})
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+	c.Check("name", fix.Holder(), "holder")
+}
+
+func (s *ClientOperationSuite) TestCannotTransferUnheldLease(c *gc.C) {
+	fix := s.EasyFixture(c)
+	err := fix.Client.TransferLease("name", "successor")
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+}
@@ -301,6 +301,29 @@ func (s *ClientTrickyRaceSuite) TestExpireLease_BlockedBy_ExpireThenReclaim(c *g
 	c.Check("name", s.sut.Expiry(), s.sut.Zero.Add(150*time.Second))
 }
 
+func (s *ClientTrickyRaceSuite) TestHandOverLease_BlockedBy_ExpireThenReclaim(c *gc.C) {
+
+	// Mark the lease for transfer, and set up a hook to expire it and
+	// let someone else claim it before the handover gets a chance.
+	err := s.sut.Client.TransferLease("name", "successor")
+	c.Assert(err, jc.ErrorIsNil)
+	defer txntesting.SetBeforeHooks(c, s.sut.Runner, func() {
+		s.blocker.Clock.Advance(90 * time.Second)
+		err := s.blocker.Client.ExpireLease("name")
+		c.Check(err, jc.ErrorIsNil)
+		err = s.blocker.Client.ClaimLease("name", corelease.Request{"other", time.Minute})
+		c.Check(err, jc.ErrorIsNil)
+	})()
+
+	// Try to hand over; check it aborts.
+	s.sut.Clock.Advance(90 * time.Second)
+	err = s.sut.Client.HandOverLease("name", corelease.Request{"successor", time.Minute})
+	c.Check(err, gc.Equals, corelease.ErrInvalid)
+
+	// The SUT has been refreshed, and you can see why the operation was invalid.
+	c.Check("name", s.sut.Holder(), "other")
+}
+
 // ClientNTPSuite tests what happens when ntp messes with the clock.
 type ClientNTPSuite struct {
 	FixtureSuite
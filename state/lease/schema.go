@@ -27,9 +27,11 @@ const (
 	typeClock = "clock"
 
 	// fieldLease* identify the fields in a leaseDoc.
-	fieldLeaseHolder = "holder"
-	fieldLeaseExpiry = "expiry"
-	fieldLeaseWriter = "writer"
+	fieldLeaseHolder    = "holder"
+	fieldLeaseExpiry    = "expiry"
+	fieldLeaseWriter    = "writer"
+	fieldLeasePinned    = "pinned"
+	fieldLeaseSuccessor = "successor"
 
 	// fieldClock* identify the fields in a clockDoc.
 	fieldClockWriters = "writers"
@@ -68,6 +70,11 @@ type leaseDoc struct {
 	Holder string `bson:"holder"`
 	Expiry int64  `bson:"expiry"`
 	Writer string `bson:"writer"`
+
+	// Pinned and Successor map directly to entry; they are written only
+	// when a lease's holder is being protected or replaced.
+	Pinned    bool   `bson:"pinned,omitempty"`
+	Successor string `bson:"successor,omitempty"`
 }
 
 // validate returns an error if any fields are invalid or inconsistent.
@@ -89,6 +96,11 @@ func (doc leaseDoc) validate() error {
 	if err := lease.ValidateString(doc.Writer); err != nil {
 		return errors.Annotatef(err, "invalid writer")
 	}
+	if doc.Successor != "" {
+		if err := lease.ValidateString(doc.Successor); err != nil {
+			return errors.Annotatef(err, "invalid successor")
+		}
+	}
 	return nil
 }
 
@@ -99,9 +111,11 @@ func (doc leaseDoc) entry() (string, entry, error) {
 		return "", entry{}, errors.Trace(err)
 	}
 	entry := entry{
-		holder: doc.Holder,
-		expiry: toTime(doc.Expiry),
-		writer: doc.Writer,
+		holder:    doc.Holder,
+		expiry:    toTime(doc.Expiry),
+		writer:    doc.Writer,
+		pinned:    doc.Pinned,
+		successor: doc.Successor,
 	}
 	return doc.Name, entry, nil
 }
@@ -117,6 +131,8 @@ func newLeaseDoc(namespace, name string, entry entry) (*leaseDoc, error) {
 		Holder:    entry.holder,
 		Expiry:    toInt64(entry.expiry),
 		Writer:    entry.writer,
+		Pinned:    entry.pinned,
+		Successor: entry.successor,
 	}
 	if err := doc.validate(); err != nil {
 		return nil, errors.Trace(err)
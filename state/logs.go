@@ -43,7 +43,7 @@ type LoggingState interface {
 	IsController() bool
 }
 
-// InitDbLogs sets up the indexes for the logs collection. It should
+// InitDbLogs sets up the indexes for the logs and audit log collections. It should
 // be called as state is opened. It is idempotent.
 func InitDbLogs(session *mgo.Session) error {
 	logsColl := session.DB(logsDB).C(logsC)
@@ -53,7 +53,7 @@ func InitDbLogs(session *mgo.Session) error {
 			return errors.Annotate(err, "cannot create index for logs collection")
 		}
 	}
-	return nil
+	return initDbAuditLog(session)
 }
 
 // lastSentDoc captures timestamp of the last log record forwarded
@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/state/presence"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/tools"
+	jujuversion "github.com/juju/juju/version"
 )
 
 // Machine represents the state of a machine.
@@ -156,6 +157,11 @@ type machineDoc struct {
 	// StopMongoUntilVersion holds the version that must be checked to
 	// know if mongo must be stopped.
 	StopMongoUntilVersion string `bson:",omitempty"`
+
+	// TargetAgentVersion holds the version that the machine's agents
+	// should run ahead of the rest of the model, during a staged
+	// upgrade.
+	TargetAgentVersion string `bson:"targetagentversion,omitempty"`
 }
 
 func newMachine(st *State, doc *machineDoc) *Machine {
@@ -337,6 +343,55 @@ func (m *Machine) StopMongoUntilVersion() (mongo.Version, error) {
 	return mongo.NewVersion(m.doc.StopMongoUntilVersion)
 }
 
+// SetTargetAgentVersion records that the machine's agents should be
+// upgraded to the given version ahead of the rest of the model, as
+// part of a staged upgrade. The version must be newer than the
+// model's agent version. The target is cleared when the model's agent
+// version is next set.
+func (m *Machine) SetTargetAgentVersion(v version.Number) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set target agent version of machine %v", m)
+	if v.Compare(jujuversion.Current) > 0 && !m.st.IsController() {
+		return errors.Errorf("a hosted model cannot have a higher version than the server model: %s > %s", v, jujuversion.Current)
+	}
+	cfg, err := m.st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	agentVersion, ok := cfg.AgentVersion()
+	if !ok {
+		return errors.New("no agent version set in the model")
+	}
+	if v.Compare(agentVersion) <= 0 {
+		return errors.Errorf("version %s is not newer than the model agent version %s", v, agentVersion)
+	}
+	ops := []txn.Op{{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"targetagentversion", v.String()}}}},
+	}}
+	if err := m.st.runTransaction(ops); err != nil {
+		return onAbort(err, ErrDead)
+	}
+	m.doc.TargetAgentVersion = v.String()
+	return nil
+}
+
+// TargetAgentVersion returns the version that the machine's agents
+// should run ahead of the rest of the model, and whether such a
+// version has been set.
+func (m *Machine) TargetAgentVersion() (version.Number, bool) {
+	if m.doc.TargetAgentVersion == "" {
+		return version.Zero, false
+	}
+	v, err := version.Parse(m.doc.TargetAgentVersion)
+	if err != nil {
+		logger.Warningf("machine %v has invalid target agent version %q", m, m.doc.TargetAgentVersion)
+		return version.Zero, false
+	}
+	return v, true
+}
+
 // IsManager returns true if the machine has JobManageModel.
 func (m *Machine) IsManager() bool {
 	return hasJob(m.doc.Jobs, JobManageModel)
@@ -856,6 +911,7 @@ func (m *Machine) Remove() (err error) {
 		removeConstraintsOp(m.st, m.globalKey()),
 		annotationRemoveOp(m.st, m.globalKey()),
 		removeRebootDocOp(m.st, m.globalKey()),
+		removeUpgradeSeriesLockOp(m.doc.DocID),
 		removeMachineBlockDevicesOp(m.Id()),
 		removeModelMachineRefOp(m.st, m.Id()),
 		removeSSHHostKeyOp(m.st, m.globalKey()),
@@ -209,6 +209,37 @@ func (s *MachineSuite) TestMachineIsManager(c *gc.C) {
 	c.Assert(s.machine.IsManager(), jc.IsFalse)
 }
 
+func (s *MachineSuite) TestSetTargetAgentVersion(c *gc.C) {
+	_, ok := s.machine.TargetAgentVersion()
+	c.Assert(ok, jc.IsFalse)
+
+	err := s.machine.SetTargetAgentVersion(version.MustParse("1.2.4"))
+	c.Assert(err, jc.ErrorIsNil)
+	vers, ok := s.machine.TargetAgentVersion()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(vers, gc.Equals, version.MustParse("1.2.4"))
+
+	machine, err := s.State.Machine(s.machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	vers, ok = machine.TargetAgentVersion()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(vers, gc.Equals, version.MustParse("1.2.4"))
+}
+
+func (s *MachineSuite) TestSetTargetAgentVersionNotNewer(c *gc.C) {
+	err := s.machine.SetTargetAgentVersion(version.MustParse("1.2.3"))
+	c.Assert(err, gc.ErrorMatches, `cannot set target agent version of machine 1: version 1.2.3 is not newer than the model agent version 1.2.3`)
+	_, ok := s.machine.TargetAgentVersion()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *MachineSuite) TestSetTargetAgentVersionDead(c *gc.C) {
+	err := s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetTargetAgentVersion(version.MustParse("1.2.4"))
+	c.Assert(err, gc.ErrorMatches, `cannot set target agent version of machine 1: not found or dead`)
+}
+
 func (s *MachineSuite) TestMachineIsManualBootstrap(c *gc.C) {
 	cfg, err := s.State.ModelConfig()
 	c.Assert(err, jc.ErrorIsNil)
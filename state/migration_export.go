@@ -466,6 +466,17 @@ func (e *exporter) addService(service *Service, refcounts map[string]int, units
 	}
 	exService.SetConstraints(constraintsArgs)
 
+	profile, err := readContainerProfile(e.st, globalKey)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotatef(err, "container profile for service %s", service.Name())
+	}
+	exService.SetContainerProfile(description.ContainerProfileArgs{
+		LXDConfig:  profile.LXDConfig,
+		LXDDevices: profile.LXDDevices,
+		KVMCPU:     profile.KVMCPU,
+		KVMDevices: profile.KVMDevices,
+	})
+
 	for _, unit := range units {
 		agentKey := unit.globalAgentKey()
 		unitMeterStatus, found := meterStatus[agentKey]
@@ -480,6 +491,11 @@ func (e *exporter) addService(service *Service, refcounts map[string]int, units
 			MeterStatusCode: unitMeterStatus.Code,
 			MeterStatusInfo: unitMeterStatus.Info,
 		}
+		// The charm state only exists once the unit's charm has
+		// written to it.
+		if charmStateDoc, found := e.settings[unit.charmStateKey()]; found {
+			args.CharmState = charmStateFromSettings(charmStateDoc.Settings)
+		}
 		if principalName, isSubordinate := unit.PrincipalName(); isSubordinate {
 			args.Principal = names.NewUnitTag(principalName)
 		}
@@ -541,8 +557,9 @@ func (e *exporter) relations() error {
 
 	for _, relation := range rels {
 		exRelation := e.model.AddRelation(description.RelationArgs{
-			Id:  relation.Id(),
-			Key: relation.String(),
+			Id:        relation.Id(),
+			Key:       relation.String(),
+			Suspended: relation.Suspended(),
 		})
 		for _, ep := range relation.Endpoints() {
 			exEndPoint := exRelation.AddEndpoint(description.EndpointArgs{
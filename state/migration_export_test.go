@@ -14,6 +14,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing/factory"
@@ -202,6 +203,7 @@ func (s *MigrationExportSuite) TestMachines(c *gc.C) {
 	c.Assert(exported.Tag(), gc.Equals, machine1.MachineTag())
 	c.Assert(exported.Series(), gc.Equals, machine1.Series())
 	c.Assert(exported.Annotations(), jc.DeepEquals, testAnnotations)
+	c.Assert(exported.CharmState(), jc.DeepEquals, map[string]string{"foo": "bar"})
 	constraints := exported.Constraints()
 	c.Assert(constraints, gc.NotNil)
 	c.Assert(constraints.Architecture(), gc.Equals, "amd64")
@@ -236,6 +238,13 @@ func (s *MigrationExportSuite) TestServices(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	err = service.SetMetricCredentials([]byte("sekrit"))
 	c.Assert(err, jc.ErrorIsNil)
+	err = service.SetContainerProfile(instance.ContainerProfile{
+		LXDConfig: map[string]string{"security.nesting": "true"},
+		LXDDevices: map[string]map[string]string{
+			"kvm": {"type": "unix-char", "path": "/dev/kvm"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
 	err = s.State.SetAnnotations(service, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, service, status.StatusActive, addedHistoryCount)
@@ -266,6 +275,13 @@ func (s *MigrationExportSuite) TestServices(c *gc.C) {
 	c.Assert(constraints.Architecture(), gc.Equals, "amd64")
 	c.Assert(constraints.Memory(), gc.Equals, 8*gig)
 
+	profile := exported.ContainerProfile()
+	c.Assert(profile, gc.NotNil)
+	c.Assert(profile.LXDConfig(), jc.DeepEquals, map[string]string{"security.nesting": "true"})
+	c.Assert(profile.LXDDevices(), jc.DeepEquals, map[string]map[string]string{
+		"kvm": {"type": "unix-char", "path": "/dev/kvm"},
+	})
+
 	history := exported.StatusHistory()
 	c.Assert(history, gc.HasLen, expectedHistoryCount)
 	s.checkStatusHistory(c, history[:addedHistoryCount], status.StatusActive)
@@ -291,6 +307,8 @@ func (s *MigrationExportSuite) TestUnits(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	err = s.State.SetAnnotations(unit, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetCharmState(map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, unit, status.StatusActive, addedHistoryCount)
 	s.primeStatusHistory(c, unit.Agent(), status.StatusIdle, addedHistoryCount)
 
@@ -393,6 +411,8 @@ func (s *MigrationExportSuite) TestRelations(c *gc.C) {
 	}
 	err = ru.EnterScope(mysqlSettings)
 	c.Assert(err, jc.ErrorIsNil)
+	err = rel.SetSuspended(true)
+	c.Assert(err, jc.ErrorIsNil)
 
 	model, err := s.State.Export()
 	c.Assert(err, jc.ErrorIsNil)
@@ -403,6 +423,7 @@ func (s *MigrationExportSuite) TestRelations(c *gc.C) {
 	exRel := rels[0]
 	c.Assert(exRel.Id(), gc.Equals, rel.Id())
 	c.Assert(exRel.Key(), gc.Equals, rel.String())
+	c.Assert(exRel.Suspended(), jc.IsTrue)
 
 	exEps := exRel.Endpoints()
 	c.Assert(exEps, gc.HasLen, 2)
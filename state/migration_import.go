@@ -546,6 +546,19 @@ func (i *importer) service(s description.Service) error {
 		settingsRefCount:   s.SettingsRefCount(),
 		leadershipSettings: s.LeadershipSettings(),
 	})
+	if profile := s.ContainerProfile(); profile != nil {
+		ops = append(ops, txn.Op{
+			C:      containerProfilesC,
+			Id:     serviceGlobalKey(s.Name()),
+			Assert: txn.DocMissing,
+			Insert: newContainerProfileDoc(instance.ContainerProfile{
+				LXDConfig:  profile.LXDConfig(),
+				LXDDevices: profile.LXDDevices(),
+				KVMCPU:     profile.KVMCPU(),
+				KVMDevices: profile.KVMDevices(),
+			}),
+		})
+	}
 
 	if err := i.st.runTransaction(ops); err != nil {
 		return errors.Trace(err)
@@ -629,6 +642,14 @@ func (i *importer) unit(s description.Service, u description.Unit) error {
 		ops = append(ops, createConstraintsOp(i.st, agentGlobalKey, i.constraints(cons)))
 	}
 
+	if state := u.CharmState(); len(state) > 0 {
+		values := make(map[string]interface{}, len(state))
+		for key, value := range state {
+			values[key] = value
+		}
+		ops = append(ops, createSettingsOp(unitCharmStateKey(u.Name()), values))
+	}
+
 	if err := i.st.runTransaction(ops); err != nil {
 		return errors.Trace(err)
 	}
@@ -782,6 +803,7 @@ func (i *importer) makeRelationDoc(rel description.Relation) *relationDoc {
 		Id:        rel.Id(),
 		Endpoints: make([]Endpoint, len(endpoints)),
 		Life:      Alive,
+		Suspended: rel.Suspended(),
 	}
 	for i, ep := range endpoints {
 		doc.Endpoints[i] = Endpoint{
@@ -15,6 +15,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -285,6 +286,13 @@ func (s *MigrationImportSuite) TestServices(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	err = service.SetMetricCredentials([]byte("sekrit"))
 	c.Assert(err, jc.ErrorIsNil)
+	err = service.SetContainerProfile(instance.ContainerProfile{
+		LXDConfig: map[string]string{"security.nesting": "true"},
+		LXDDevices: map[string]map[string]string{
+			"kvm": {"type": "unix-char", "path": "/dev/kvm"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
 	// Expose the service.
 	c.Assert(service.SetExposed(), jc.ErrorIsNil)
 	err = s.State.SetAnnotations(service, testAnnotations)
@@ -329,6 +337,12 @@ func (s *MigrationImportSuite) TestServices(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	// Can't test the constraints directly, so go through the string repr.
 	c.Assert(newCons.String(), gc.Equals, cons.String())
+
+	exportedProfile, err := exported.ContainerProfile()
+	c.Assert(err, jc.ErrorIsNil)
+	importedProfile, err := imported.ContainerProfile()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(importedProfile, jc.DeepEquals, exportedProfile)
 }
 
 func (s *MigrationImportSuite) TestServiceLeaders(c *gc.C) {
@@ -357,6 +371,8 @@ func (s *MigrationImportSuite) TestUnits(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	err = s.State.SetAnnotations(exported, testAnnotations)
 	c.Assert(err, jc.ErrorIsNil)
+	err = exported.SetCharmState(map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
 	s.primeStatusHistory(c, exported, status.StatusActive, 5)
 	s.primeStatusHistory(c, exported.Agent(), status.StatusIdle, 5)
 
@@ -392,6 +408,9 @@ func (s *MigrationImportSuite) TestUnits(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(meterStatus, gc.Equals, state.MeterStatus{state.MeterGreen, "some info"})
 	s.assertAnnotations(c, newSt, imported)
+	charmState, err := imported.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "bar"})
 	s.checkStatusHistory(c, exported, imported, 5)
 	s.checkStatusHistory(c, exported.Agent(), imported.Agent(), 5)
 
@@ -419,6 +438,8 @@ func (s *MigrationImportSuite) TestRelations(c *gc.C) {
 	}
 	err = ru.EnterScope(relSettings)
 	c.Assert(err, jc.ErrorIsNil)
+	err = rel.SetSuspended(true)
+	c.Assert(err, jc.ErrorIsNil)
 
 	_, newSt := s.importModel(c)
 	defer newSt.Close()
@@ -429,6 +450,7 @@ func (s *MigrationImportSuite) TestRelations(c *gc.C) {
 	rels, err := newWordpress.Relations()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(rels, gc.HasLen, 1)
+	c.Assert(rels[0].Suspended(), jc.IsTrue)
 	units, err := newWordpress.AllUnits()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(units, gc.HasLen, 1)
@@ -38,6 +38,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		servicesC,
 		unitsC,
 		meterStatusC, // red / green status for metrics of units
+		containerProfilesC,
 
 		// settings reference counts are only used for services
 		settingsrefsC,
@@ -57,6 +58,12 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		guimetadataC,
 		// This is controller global, not migrated.
 		guisettingsC,
+		// Cloud credentials are controller global, and are
+		// referenced by models rather than owned by them.
+		cloudCredentialsC,
+		// Service offers are controller global, and cannot be
+		// consumed across controllers.
+		serviceOffersC,
 		// Users aren't migrated.
 		usersC,
 		userLastLoginC,
@@ -112,6 +119,11 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// The SSH host keys for each machine will be reported as each
 		// machine agent starts up.
 		sshHostKeysC,
+
+		// Series upgrade locks only exist while a series upgrade is in
+		// progress, and CreateModelMigration refuses to start a
+		// migration during one.
+		upgradeSeriesLocksC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -127,6 +139,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		"payloads",
 		"resources",
 		endpointBindingsC,
+		remoteServicesC,
 
 		// storage
 		blockDevicesC,
@@ -183,6 +196,9 @@ func (s *MigrationSuite) TestModelDocFields(c *gc.C) {
 		"MigrationMode",
 		"Owner",
 		"LatestAvailableTools",
+		// CloudCredential refers to a credential stored in the
+		// source controller, which is not migrated.
+		"CloudCredential",
 	)
 	s.AssertExportedFields(c, modelDoc{}, fields)
 }
@@ -248,6 +264,10 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		// Ignored at this stage, could be an issue if mongo 3.0 isn't
 		// available.
 		"StopMongoUntilVersion",
+
+		// Staged upgrades are superseded by the model agent
+		// version, which is migrated.
+		"TargetAgentVersion",
 	)
 	todo := set.NewStrings(
 		"Volumes",
@@ -407,6 +427,7 @@ func (s *MigrationSuite) TestRelationDocFields(c *gc.C) {
 		// UnitCount isn't explicitly exported, but defined by the stored
 		// unit settings data for the relation endpoint.
 		"UnitCount",
+		"Suspended",
 	)
 	s.AssertExportedFields(c, relationDoc{}, fields)
 	// We also need to check the Endpoint and nested charm.Relation field.
@@ -63,6 +63,10 @@ type modelDoc struct {
 	// LatestAvailableTools is a string representing the newest version
 	// found while checking streams for new versions.
 	LatestAvailableTools string `bson:"available-tools,omitempty"`
+
+	// CloudCredential is the id of the controller-stored cloud
+	// credential used by the model, if any.
+	CloudCredential string `bson:"cloud-credential,omitempty"`
 }
 
 // modelEntityRefsDoc records references to the top-level entities
@@ -148,6 +152,10 @@ type ModelArgs struct {
 	Config        *config.Config
 	Owner         names.UserTag
 	MigrationMode MigrationMode
+
+	// CloudCredential, if set, identifies the controller-stored cloud
+	// credential that the model uses. The credential must exist.
+	CloudCredential CloudCredentialRef
 }
 
 // NewModel creates a new model with its own UUID and
@@ -183,10 +191,24 @@ func (st *State) NewModel(args ModelArgs) (_ *Model, _ *State, err error) {
 		}
 	}()
 
-	ops, err := newState.modelSetupOps(args.Config, uuid, ssEnv.UUID(), owner, args.MigrationMode)
+	var credential string
+	if !args.CloudCredential.IsZero() {
+		credential = args.CloudCredential.id()
+	}
+	ops, err := newState.modelSetupOps(args.Config, uuid, ssEnv.UUID(), owner, args.MigrationMode, credential)
 	if err != nil {
 		return nil, nil, errors.Annotate(err, "failed to create new model")
 	}
+	if credential != "" {
+		if _, err := st.CloudCredential(args.CloudCredential); err != nil {
+			return nil, nil, errors.Annotate(err, "cannot create model")
+		}
+		ops = append(ops, txn.Op{
+			C:      cloudCredentialsC,
+			Id:     credential,
+			Assert: txn.DocExists,
+		})
+	}
 	err = newState.runTransaction(ops)
 	if err == txn.ErrAborted {
 
@@ -291,6 +313,21 @@ func (m *Model) Owner() names.UserTag {
 	return names.NewUserTag(m.doc.Owner)
 }
 
+// CloudCredential returns a reference to the controller-stored cloud
+// credential used by the model. The boolean result is false if the
+// model does not use a stored credential.
+func (m *Model) CloudCredential() (CloudCredentialRef, bool) {
+	if m.doc.CloudCredential == "" {
+		return CloudCredentialRef{}, false
+	}
+	ref, err := parseCloudCredentialID(m.doc.CloudCredential)
+	if err != nil {
+		logger.Warningf("model %q: %v", m.doc.UUID, err)
+		return CloudCredentialRef{}, false
+	}
+	return ref, true
+}
+
 // Status returns the status of the model.
 func (m *Model) Status() (status.StatusInfo, error) {
 	st, closeState, err := m.getState()
@@ -742,14 +779,15 @@ func ensureDestroyable(st *State) error {
 
 // createModelOp returns the operation needed to create
 // an model document with the given name and UUID.
-func createModelOp(st *State, owner names.UserTag, name, uuid, server string, mode MigrationMode) txn.Op {
+func createModelOp(st *State, owner names.UserTag, name, uuid, server string, mode MigrationMode, credential string) txn.Op {
 	doc := &modelDoc{
-		UUID:          uuid,
-		Name:          name,
-		Life:          Alive,
-		Owner:         owner.Canonical(),
-		ServerUUID:    server,
-		MigrationMode: mode,
+		UUID:            uuid,
+		Name:            name,
+		Life:            Alive,
+		Owner:           owner.Canonical(),
+		ServerUUID:      server,
+		MigrationMode:   mode,
+		CloudCredential: credential,
 	}
 	return txn.Op{
 		C:      modelsC,
@@ -146,6 +146,41 @@ func (s *ModelSuite) TestNewModel(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *ModelSuite) TestNewModelControllerOnlyConfig(c *gc.C) {
+	cfg, _ := s.createTestEnvConfig(c)
+	cfg, err := cfg.Apply(map[string]interface{}{
+		"metrics-sender":        "file",
+		"metrics-sender-target": "/var/log/juju/metrics/metrics.log",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	owner := names.NewUserTag("test@remote")
+
+	_, _, err = s.State.NewModel(state.ModelArgs{Config: cfg, Owner: owner})
+	c.Assert(err, gc.ErrorMatches, "failed to create new model: metrics-sender can only be set in the controller model")
+}
+
+func (s *ModelSuite) TestUpdateHostedModelConfigControllerOnly(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	err := st.UpdateModelConfig(map[string]interface{}{
+		"metrics-sender":        "http",
+		"metrics-sender-target": "https://metrics.example.com/v1",
+	}, nil, nil)
+	c.Assert(err, gc.ErrorMatches, "metrics-sender can only be set in the controller model")
+	err = st.UpdateModelConfig(map[string]interface{}{
+		"audit-log-max-age": "24h",
+	}, nil, nil)
+	c.Assert(err, gc.ErrorMatches, "audit-log-max-age can only be set in the controller model")
+
+	// The controller model may set them.
+	err = s.State.UpdateModelConfig(map[string]interface{}{
+		"metrics-sender":        "http",
+		"metrics-sender-target": "https://metrics.example.com/v1",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *ModelSuite) TestNewModelImportingMode(c *gc.C) {
 	cfg, _ := s.createTestEnvConfig(c)
 	owner := names.NewUserTag("test@remote")
@@ -367,6 +367,11 @@ func (st *State) CreateModelMigration(spec ModelMigrationSpec) (ModelMigration,
 		} else if isActive {
 			return nil, errors.New("already in progress")
 		}
+		if upgrading, err := st.isUpgradingSeries(); err != nil {
+			return nil, errors.Trace(err)
+		} else if upgrading {
+			return nil, errors.New("model has machines being upgraded to a new series")
+		}
 
 		seq, err := st.sequence("modelmigration")
 		if err != nil {
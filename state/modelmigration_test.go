@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type ModelMigrationSuite struct {
@@ -204,6 +205,26 @@ func (s *ModelMigrationSuite) TestCreateMigrationWhenModelNotAlive(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "failed to create migration: model is not alive")
 }
 
+func (s *ModelMigrationSuite) TestCreateMigrationDuringSeriesUpgrade(c *gc.C) {
+	f := factory.NewFactory(s.State2)
+	machine := f.MakeMachine(c, &factory.MachineParams{Series: "quantal"})
+	c.Assert(machine.PrepareUpgradeSeries("xenial"), jc.ErrorIsNil)
+
+	mig, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Check(mig, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "failed to create migration: model has machines being upgraded to a new series")
+}
+
+func (s *ModelMigrationSuite) TestPrepareUpgradeSeriesDuringMigration(c *gc.C) {
+	f := factory.NewFactory(s.State2)
+	machine := f.MakeMachine(c, &factory.MachineParams{Series: "quantal"})
+	_, err := s.State2.CreateModelMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.PrepareUpgradeSeries("xenial")
+	c.Assert(err, gc.ErrorMatches, `cannot prepare series upgrade of machine "0": model is being migrated`)
+}
+
 func (s *ModelMigrationSuite) TestMigrationToSameController(c *gc.C) {
 	spec := s.stdSpec
 	spec.TargetInfo.ControllerTag = s.State.ModelTag()
@@ -22,10 +22,18 @@ type Multiwatcher struct {
 	// used indicates that the watcher was used (i.e. Next() called).
 	used bool
 
+	// filter, if not nil, restricts the deltas returned by Next.
+	filter *multiwatcher.Filter
+
 	// The following fields are maintained by the storeManager
 	// goroutine.
 	revno   int64
 	stopped bool
+
+	// reported holds the values of the filter's fields of interest
+	// in each entity most recently reported by the watcher, when
+	// the filter has any.
+	reported map[multiwatcher.EntityId][]interface{}
 }
 
 // NewMultiwatcher creates a new watcher that can observe
@@ -46,6 +54,47 @@ func NewMultiwatcher(all *storeManager) *Multiwatcher {
 	}
 }
 
+// NewFilteredMultiwatcher is like NewMultiwatcher, except that the
+// returned watcher only reports deltas that match the given filter.
+func NewFilteredMultiwatcher(all *storeManager, filter multiwatcher.Filter) *Multiwatcher {
+	w := NewMultiwatcher(all)
+	if !filter.IsEmpty() {
+		w.filter = &filter
+	}
+	if len(filter.Fields) > 0 {
+		w.reported = make(map[multiwatcher.EntityId][]interface{})
+	}
+	return w
+}
+
+// filterDeltas returns the deltas that the watcher should report.
+// It must only be called by the storeManager goroutine.
+func (w *Multiwatcher) filterDeltas(deltas []multiwatcher.Delta) []multiwatcher.Delta {
+	if w.filter == nil {
+		return deltas
+	}
+	filtered := deltas[:0]
+	for _, delta := range deltas {
+		if !w.filter.Match(delta.Entity) {
+			continue
+		}
+		if w.reported != nil {
+			id := delta.Entity.EntityId()
+			if delta.Removed {
+				delete(w.reported, id)
+			} else {
+				values := w.filter.FieldValues(delta.Entity)
+				if old, ok := w.reported[id]; ok && reflect.DeepEqual(old, values) {
+					continue
+				}
+				w.reported[id] = values
+			}
+		}
+		filtered = append(filtered, delta)
+	}
+	return filtered
+}
+
 // Stop stops the watcher.
 func (w *Multiwatcher) Stop() error {
 	select {
@@ -285,11 +334,21 @@ func (sm *storeManager) respond() {
 			continue
 		}
 
-		req.changes = changes
 		w.revno = sm.all.latestRevno
+		sm.seen(revno)
+		if changes = w.filterDeltas(changes); len(changes) == 0 {
+			// Nothing the watcher is interested in has
+			// changed, so keep the request waiting unless
+			// it's the first.
+			if req.noChanges != nil {
+				req.noChanges <- struct{}{}
+				sm.removeWaitingReq(w, req)
+			}
+			continue
+		}
+		req.changes = changes
 		req.reply <- true
 		sm.removeWaitingReq(w, req)
-		sm.seen(revno)
 	}
 }
 
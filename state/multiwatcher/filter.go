@@ -0,0 +1,158 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package multiwatcher
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/utils/set"
+)
+
+// entityKinds holds the kinds of all the entities tracked by the
+// multiwatcherStore.
+var entityKinds = set.NewStrings(
+	"model",
+	"machine",
+	"service",
+	"unit",
+	"relation",
+	"annotation",
+	"block",
+	"action",
+)
+
+// Filter restricts the deltas returned by a Multiwatcher. The zero
+// Filter matches every delta.
+type Filter struct {
+	// Kinds, if not empty, holds the kinds of the entities
+	// of interest, such as "unit" or "service".
+	Kinds []string
+
+	// ModelUUIDs, if not empty, holds the UUIDs of the models
+	// whose entities are of interest.
+	ModelUUIDs []string
+
+	// Services, if not empty, holds the names of the services
+	// whose entities are of interest. Entities that do not belong
+	// to a service, such as machines, are not affected.
+	Services []string
+
+	// Fields, if not empty, holds the names of the entity info
+	// fields of interest, such as "WorkloadStatus". Once an entity
+	// has been reported, its later changes are only reported if
+	// they affect one of these fields. Removals are always reported.
+	Fields []string
+}
+
+// Validate returns an error if the filter refers to unknown entity
+// kinds.
+func (f *Filter) Validate() error {
+	for _, kind := range f.Kinds {
+		if !entityKinds.Contains(kind) {
+			return errors.NotValidf("entity kind %q", kind)
+		}
+	}
+	return nil
+}
+
+// IsEmpty reports whether the filter matches every delta.
+func (f *Filter) IsEmpty() bool {
+	return len(f.Kinds) == 0 &&
+		len(f.ModelUUIDs) == 0 &&
+		len(f.Services) == 0 &&
+		len(f.Fields) == 0
+}
+
+// Match reports whether changes to the given entity are of interest.
+func (f *Filter) Match(info EntityInfo) bool {
+	id := info.EntityId()
+	if len(f.Kinds) > 0 && !contains(f.Kinds, id.Kind) {
+		return false
+	}
+	if len(f.ModelUUIDs) > 0 && !contains(f.ModelUUIDs, id.ModelUUID) {
+		return false
+	}
+	if len(f.Services) > 0 {
+		services := serviceNames(info)
+		if services == nil {
+			return true
+		}
+		for _, service := range services {
+			if contains(f.Services, service) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// FieldValues returns the values of the fields of interest in the
+// given entity, so that they can be compared with those of a later
+// version of the entity to find out if any of them have changed.
+// Fields that the entity does not have are ignored. It returns nil
+// if the filter has no Fields.
+func (f *Filter) FieldValues(info EntityInfo) []interface{} {
+	if len(f.Fields) == 0 {
+		return nil
+	}
+	v := reflect.Indirect(reflect.ValueOf(info))
+	values := make([]interface{}, len(f.Fields))
+	for i, name := range f.Fields {
+		if field := v.FieldByName(name); field.IsValid() {
+			values[i] = field.Interface()
+		}
+	}
+	return values
+}
+
+// serviceNames returns the names of the services that the given
+// entity belongs to, or nil if it doesn't belong to any.
+func serviceNames(info EntityInfo) []string {
+	switch info := info.(type) {
+	case *ServiceInfo:
+		return []string{info.Name}
+	case *UnitInfo:
+		return []string{info.Service}
+	case *RelationInfo:
+		services := make([]string, len(info.Endpoints))
+		for i, ep := range info.Endpoints {
+			services[i] = ep.ServiceName
+		}
+		return services
+	case *ActionInfo:
+		if names.IsValidUnit(info.Receiver) {
+			service, err := names.UnitService(info.Receiver)
+			if err == nil {
+				return []string{service}
+			}
+		}
+	case *AnnotationInfo:
+		tag, err := names.ParseTag(info.Tag)
+		if err != nil {
+			return nil
+		}
+		switch tag := tag.(type) {
+		case names.ServiceTag:
+			return []string{tag.Id()}
+		case names.UnitTag:
+			service, err := names.UnitService(tag.Id())
+			if err == nil {
+				return []string{service}
+			}
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
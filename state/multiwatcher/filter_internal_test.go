@@ -0,0 +1,69 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package multiwatcher
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type FilterSuite struct{}
+
+var _ = gc.Suite(&FilterSuite{})
+
+func (s *FilterSuite) TestValidate(c *gc.C) {
+	f := Filter{Kinds: []string{"unit", "service"}}
+	c.Assert(f.Validate(), jc.ErrorIsNil)
+	f.Kinds = append(f.Kinds, "units")
+	c.Assert(f.Validate(), gc.ErrorMatches, `entity kind "units" not valid`)
+}
+
+func (s *FilterSuite) TestMatchEmpty(c *gc.C) {
+	var f Filter
+	c.Assert(f.IsEmpty(), jc.IsTrue)
+	c.Assert(f.Match(&MachineInfo{Id: "0"}), jc.IsTrue)
+}
+
+func (s *FilterSuite) TestMatchServices(c *gc.C) {
+	f := Filter{Services: []string{"wordpress"}}
+	c.Assert(f.IsEmpty(), jc.IsFalse)
+	for i, test := range []struct {
+		info   EntityInfo
+		expect bool
+	}{
+		{&ServiceInfo{Name: "wordpress"}, true},
+		{&ServiceInfo{Name: "mysql"}, false},
+		{&UnitInfo{Name: "wordpress/0", Service: "wordpress"}, true},
+		{&UnitInfo{Name: "mysql/0", Service: "mysql"}, false},
+		{&RelationInfo{Endpoints: []Endpoint{{ServiceName: "mysql"}, {ServiceName: "wordpress"}}}, true},
+		{&RelationInfo{Endpoints: []Endpoint{{ServiceName: "mysql"}}}, false},
+		{&ActionInfo{Receiver: "wordpress/1"}, true},
+		{&ActionInfo{Receiver: "mysql/1"}, false},
+		{&AnnotationInfo{Tag: "service-wordpress"}, true},
+		{&AnnotationInfo{Tag: "unit-mysql-0"}, false},
+		{&AnnotationInfo{Tag: "machine-0"}, true},
+		{&MachineInfo{Id: "0"}, true},
+	} {
+		c.Logf("test %d: %#v", i, test.info)
+		c.Check(f.Match(test.info), gc.Equals, test.expect)
+	}
+}
+
+func (s *FilterSuite) TestMatchKindsAndModels(c *gc.C) {
+	f := Filter{
+		Kinds:      []string{"unit"},
+		ModelUUIDs: []string{"uuid0"},
+	}
+	c.Assert(f.Match(&UnitInfo{ModelUUID: "uuid0", Name: "wordpress/0"}), jc.IsTrue)
+	c.Assert(f.Match(&UnitInfo{ModelUUID: "uuid1", Name: "wordpress/0"}), jc.IsFalse)
+	c.Assert(f.Match(&MachineInfo{ModelUUID: "uuid0", Id: "0"}), jc.IsFalse)
+}
+
+func (s *FilterSuite) TestFieldValues(c *gc.C) {
+	var f Filter
+	c.Assert(f.FieldValues(&MachineInfo{Id: "0"}), gc.IsNil)
+	f.Fields = []string{"InstanceId", "Service"}
+	c.Assert(f.FieldValues(&MachineInfo{Id: "0", InstanceId: "i-0"}), jc.DeepEquals, []interface{}{"i-0", nil})
+	c.Assert(f.FieldValues(&UnitInfo{Service: "wordpress"}), jc.DeepEquals, []interface{}{nil, "wordpress"})
+}
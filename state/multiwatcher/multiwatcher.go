@@ -250,6 +250,7 @@ type RelationInfo struct {
 	Key       string
 	Id        int
 	Endpoints []Endpoint
+	Suspended bool
 }
 
 // Endpoint holds a service-relation pair.
@@ -695,6 +695,89 @@ func (*storeManagerSuite) TestMultipleEnvironments(c *gc.C) {
 	}, "")
 }
 
+func (*storeManagerSuite) TestRunFiltered(c *gc.C) {
+	b := newTestBacking([]multiwatcher.EntityInfo{
+		&multiwatcher.MachineInfo{ModelUUID: "uuid0", Id: "0"},
+		&multiwatcher.ServiceInfo{ModelUUID: "uuid0", Name: "logging"},
+		&multiwatcher.ServiceInfo{ModelUUID: "uuid0", Name: "wordpress"},
+		&multiwatcher.UnitInfo{ModelUUID: "uuid0", Name: "wordpress/0", Service: "wordpress"},
+		&multiwatcher.UnitInfo{ModelUUID: "uuid0", Name: "logging/0", Service: "logging"},
+		&multiwatcher.ServiceInfo{ModelUUID: "uuid1", Name: "wordpress"},
+	})
+	sm := newStoreManager(b)
+	defer func() {
+		c.Check(sm.Stop(), gc.IsNil)
+	}()
+	w := NewFilteredMultiwatcher(sm, multiwatcher.Filter{
+		Kinds:      []string{"service", "unit"},
+		ModelUUIDs: []string{"uuid0"},
+		Services:   []string{"wordpress"},
+	})
+	checkNext(c, w, []multiwatcher.Delta{
+		{Entity: &multiwatcher.ServiceInfo{ModelUUID: "uuid0", Name: "wordpress"}},
+		{Entity: &multiwatcher.UnitInfo{ModelUUID: "uuid0", Name: "wordpress/0", Service: "wordpress"}},
+	}, "")
+
+	// Changes that don't match the filter are not reported.
+	b.updateEntity(&multiwatcher.MachineInfo{ModelUUID: "uuid0", Id: "0", InstanceId: "i-0"})
+	b.updateEntity(&multiwatcher.ServiceInfo{ModelUUID: "uuid0", Name: "logging", Exposed: true})
+	b.updateEntity(&multiwatcher.ServiceInfo{ModelUUID: "uuid1", Name: "wordpress", Exposed: true})
+	b.updateEntity(&multiwatcher.ServiceInfo{ModelUUID: "uuid0", Name: "wordpress", Exposed: true})
+	checkNext(c, w, []multiwatcher.Delta{
+		{Entity: &multiwatcher.ServiceInfo{ModelUUID: "uuid0", Name: "wordpress", Exposed: true}},
+	}, "")
+	b.deleteEntity(multiwatcher.EntityId{"unit", "uuid0", "logging/0"})
+	b.deleteEntity(multiwatcher.EntityId{"unit", "uuid0", "wordpress/0"})
+	checkNext(c, w, []multiwatcher.Delta{
+		{Removed: true, Entity: &multiwatcher.UnitInfo{ModelUUID: "uuid0", Name: "wordpress/0", Service: "wordpress"}},
+	}, "")
+}
+
+func (*storeManagerSuite) TestRunFilteredEmpty(c *gc.C) {
+	b := newTestBacking([]multiwatcher.EntityInfo{
+		&multiwatcher.MachineInfo{ModelUUID: "uuid", Id: "0"},
+	})
+	sm := newStoreManager(b)
+	defer func() {
+		c.Check(sm.Stop(), gc.IsNil)
+	}()
+	w := NewFilteredMultiwatcher(sm, multiwatcher.Filter{Kinds: []string{"unit"}})
+	checkNext(c, w, nil, "")
+}
+
+func (*storeManagerSuite) TestRunFilteredFields(c *gc.C) {
+	b := newTestBacking([]multiwatcher.EntityInfo{
+		&multiwatcher.UnitInfo{ModelUUID: "uuid", Name: "wordpress/0", Service: "wordpress"},
+		&multiwatcher.MachineInfo{ModelUUID: "uuid", Id: "0"},
+	})
+	sm := newStoreManager(b)
+	defer func() {
+		c.Check(sm.Stop(), gc.IsNil)
+	}()
+	w := NewFilteredMultiwatcher(sm, multiwatcher.Filter{
+		Fields: []string{"WorkloadStatus"},
+	})
+	checkNext(c, w, []multiwatcher.Delta{
+		{Entity: &multiwatcher.UnitInfo{ModelUUID: "uuid", Name: "wordpress/0", Service: "wordpress"}},
+		{Entity: &multiwatcher.MachineInfo{ModelUUID: "uuid", Id: "0"}},
+	}, "")
+
+	// Changes to other fields are not reported.
+	b.updateEntity(&multiwatcher.UnitInfo{ModelUUID: "uuid", Name: "wordpress/0", Service: "wordpress", MachineId: "0"})
+	b.updateEntity(&multiwatcher.MachineInfo{ModelUUID: "uuid", Id: "0", InstanceId: "i-0"})
+	workloadStatus := multiwatcher.StatusInfo{Current: "active"}
+	b.updateEntity(&multiwatcher.UnitInfo{ModelUUID: "uuid", Name: "wordpress/0", Service: "wordpress", MachineId: "0", WorkloadStatus: workloadStatus})
+	checkNext(c, w, []multiwatcher.Delta{
+		{Entity: &multiwatcher.UnitInfo{ModelUUID: "uuid", Name: "wordpress/0", Service: "wordpress", MachineId: "0", WorkloadStatus: workloadStatus}},
+	}, "")
+
+	// Removals are always reported.
+	b.deleteEntity(multiwatcher.EntityId{"machine", "uuid", "0"})
+	checkNext(c, w, []multiwatcher.Delta{
+		{Removed: true, Entity: &multiwatcher.MachineInfo{ModelUUID: "uuid", Id: "0", InstanceId: "i-0"}},
+	}, "")
+}
+
 func (*storeManagerSuite) TestMultiwatcherStop(c *gc.C) {
 	sm := newStoreManager(newTestBacking(nil))
 	defer func() {
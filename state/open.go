@@ -133,7 +133,7 @@ func Initialize(owner names.UserTag, info *mongo.MongoInfo, cfg *config.Config,
 	// When creating the controller model, the new model
 	// UUID is also used as the controller UUID.
 	logger.Infof("initializing controller model %s", uuid)
-	modelOps, err := st.modelSetupOps(cfg, uuid, uuid, owner, MigrationModeActive)
+	modelOps, err := st.modelSetupOps(cfg, uuid, uuid, owner, MigrationModeActive, "")
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -181,10 +181,15 @@ func Initialize(owner names.UserTag, info *mongo.MongoInfo, cfg *config.Config,
 	return st, nil
 }
 
-func (st *State) modelSetupOps(cfg *config.Config, modelUUID, serverUUID string, owner names.UserTag, mode MigrationMode) ([]txn.Op, error) {
+func (st *State) modelSetupOps(cfg *config.Config, modelUUID, serverUUID string, owner names.UserTag, mode MigrationMode, credential string) ([]txn.Op, error) {
 	if err := checkModelConfig(cfg); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if serverUUID != "" && serverUUID != modelUUID {
+		if err := checkHostedModelConfig(cfg); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 
 	modelStatusDoc := statusDoc{
 		ModelUUID: modelUUID,
@@ -212,7 +217,7 @@ func (st *State) modelSetupOps(cfg *config.Config, modelUUID, serverUUID string,
 	}
 	ops = append(ops,
 		createModelEntityRefsOp(st, modelUUID),
-		createModelOp(st, owner, cfg.Name(), modelUUID, serverUUID, mode),
+		createModelOp(st, owner, cfg.Name(), modelUUID, serverUUID, mode, credential),
 		createUniqueOwnerModelNameOp(owner, cfg.Name()),
 		modelUserOp,
 	)
@@ -279,9 +284,16 @@ func (st *State) MongoConnectionInfo() *mongo.MongoInfo {
 	return st.mongoInfo
 }
 
-// CACert returns the certificate used to validate the state connection.
+// CACert returns the certificates used to validate the state and API
+// connections. While the controller's CA is being replaced, both the
+// current and the previous CA certificates are returned.
 func (st *State) CACert() string {
-	return st.mongoInfo.CACert
+	certs, err := st.cachedControllerCertificates()
+	if err != nil {
+		logger.Errorf("cannot read controller certificates: %v", err)
+		return st.mongoInfo.CACert
+	}
+	return certs.TrustedCACerts(GetClock().Now())
 }
 
 // Close the connection to the database.
@@ -298,6 +310,11 @@ func (st *State) Close() (err error) {
 		}
 	}
 
+	st.certsCache.mu.Lock()
+	if st.certsCache.watcher != nil {
+		handle("controller certificates watcher", st.certsCache.watcher.Stop())
+	}
+	st.certsCache.mu.Unlock()
 	handle("transaction watcher", st.watcher.Stop())
 	if st.pwatcher != nil {
 		handle("presence watcher", st.pwatcher.Stop())
@@ -44,6 +44,7 @@ type relationDoc struct {
 	Endpoints []Endpoint
 	Life      Life
 	UnitCount int
+	Suspended bool `bson:"suspended,omitempty"`
 }
 
 // Relation represents a relation between one or two service endpoints.
@@ -98,6 +99,57 @@ func (r *Relation) Life() Life {
 	return r.doc.Life
 }
 
+// Suspended returns whether the relation is suspended. The units of a
+// suspended relation depart it as though it were broken, but remain in
+// its scope so that their settings are kept until it is resumed.
+func (r *Relation) Suspended() bool {
+	return r.doc.Suspended
+}
+
+// SetSuspended suspends or resumes the relation. Only Alive relations
+// may be suspended or resumed.
+func (r *Relation) SetSuspended(suspended bool) (err error) {
+	action := "resume"
+	if suspended {
+		action = "suspend"
+	}
+	defer errors.DeferredAnnotatef(&err, "cannot %s relation %q", action, r)
+	if len(r.doc.Endpoints) == 1 && r.doc.Endpoints[0].Role == charm.RolePeer {
+		return errors.Errorf("is a peer relation")
+	}
+	for _, ep := range r.doc.Endpoints {
+		if ep.Scope == charm.ScopeContainer {
+			// Subordinate units cannot outlive their relations
+			// with their principals.
+			return errors.Errorf("is a container-scoped relation")
+		}
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := r.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if r.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		if r.doc.Suspended == suspended {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      relationsC,
+			Id:     r.doc.DocID,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"suspended", suspended}}}},
+		}}, nil
+	}
+	if err := r.st.run(buildTxn); err != nil {
+		return err
+	}
+	r.doc.Suspended = suspended
+	return nil
+}
+
 // Destroy ensures that the relation will be removed at some point; if no units
 // are currently in scope, it will be removed immediately.
 func (r *Relation) Destroy() (err error) {
@@ -147,7 +199,7 @@ func (r *Relation) destroyOps(ignoreService string) (ops []txn.Op, isRemove bool
 		return nil, false, errAlreadyDying
 	}
 	if r.doc.UnitCount == 0 {
-		removeOps, err := r.removeOps(ignoreService, nil)
+		removeOps, err := r.removeOps(ignoreService, "")
 		if err != nil {
 			return nil, false, err
 		}
@@ -163,16 +215,17 @@ func (r *Relation) destroyOps(ignoreService string) (ops []txn.Op, isRemove bool
 
 // removeOps returns the operations necessary to remove the relation. If
 // ignoreService is not empty, no operations affecting that service will be
-// included; if departingUnit is not nil, this implies that the relation's
+// included; if departingService is not empty, this implies that the last
+// unit of that service is leaving the relation, and that the relation's
 // services may be Dying and otherwise unreferenced, and may thus require
 // removal themselves.
-func (r *Relation) removeOps(ignoreService string, departingUnit *Unit) ([]txn.Op, error) {
+func (r *Relation) removeOps(ignoreService, departingService string) ([]txn.Op, error) {
 	relOp := txn.Op{
 		C:      relationsC,
 		Id:     r.doc.DocID,
 		Remove: true,
 	}
-	if departingUnit != nil {
+	if departingService != "" {
 		relOp.Assert = bson.D{{"life", Dying}, {"unitcount", 1}}
 	} else {
 		relOp.Assert = bson.D{{"life", Alive}, {"unitcount", 0}}
@@ -182,14 +235,24 @@ func (r *Relation) removeOps(ignoreService string, departingUnit *Unit) ([]txn.O
 		if ep.ServiceName == ignoreService {
 			continue
 		}
+		if remote, err := isRemoteService(r.st, ep.ServiceName); err != nil {
+			return nil, errors.Trace(err)
+		} else if remote {
+			remoteOps, err := r.removeRemoteServiceOps(ep.ServiceName, departingService)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, remoteOps...)
+			continue
+		}
 		var asserts bson.D
 		hasRelation := bson.D{{"relationcount", bson.D{{"$gt", 0}}}}
-		if departingUnit == nil {
+		if departingService == "" {
 			// We're constructing a destroy operation, either of the relation
 			// or one of its services, and can therefore be assured that both
 			// services are Alive.
 			asserts = append(hasRelation, isAliveDoc...)
-		} else if ep.ServiceName == departingUnit.ServiceName() {
+		} else if ep.ServiceName == departingService {
 			// This service must have at least one unit -- the one that's
 			// departing the relation -- so it cannot be ready for removal.
 			cannotDieYet := bson.D{{"unitcount", bson.D{{"$gt", 0}}}}
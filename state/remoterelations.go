@@ -0,0 +1,470 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// RemoteRelationUnit represents a unit of a remote service in a
+// relation. Remote units have no agent in this model; they enter and
+// leave scope, and have their settings updated, on behalf of the units
+// of the service in its own model.
+type RemoteRelationUnit struct {
+	st       *State
+	relation *Relation
+	unitName string
+	endpoint Endpoint
+}
+
+// RemoteUnit returns a RemoteRelationUnit for the named unit of a
+// remote service taking part in the relation.
+func (r *Relation) RemoteUnit(unitName string) (*RemoteRelationUnit, error) {
+	serviceName, err := names.UnitService(unitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if remote, err := isRemoteService(r.st, serviceName); err != nil {
+		return nil, errors.Trace(err)
+	} else if !remote {
+		return nil, errors.NotFoundf("remote service %q", serviceName)
+	}
+	ep, err := r.Endpoint(serviceName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &RemoteRelationUnit{
+		st:       r.st,
+		relation: r,
+		unitName: unitName,
+		endpoint: ep,
+	}, nil
+}
+
+// UnitName returns the name of the remote unit.
+func (ru *RemoteRelationUnit) UnitName() string {
+	return ru.unitName
+}
+
+// key returns the key used for the remote unit within the relation in
+// the settings and relationScopes collections. Remote services cannot
+// take part in container-scoped relations, so the key never includes
+// a container.
+func (ru *RemoteRelationUnit) key() string {
+	return relationUnitKey(ru.relation.doc.Id, string(ru.endpoint.Role), ru.unitName)
+}
+
+func relationUnitKey(relationId int, role, unitName string) string {
+	return fmt.Sprintf("r#%d#%s#%s", relationId, role, unitName)
+}
+
+// InScope returns whether the remote unit has entered scope and not
+// left it.
+func (ru *RemoteRelationUnit) InScope() (bool, error) {
+	relationScopes, closer := ru.st.getCollection(relationScopesC)
+	defer closer()
+
+	count, err := relationScopes.FindId(ru.key()).Count()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return count > 0, nil
+}
+
+// Settings returns the remote unit's settings within the relation.
+func (ru *RemoteRelationUnit) Settings() (*Settings, error) {
+	return readSettings(ru.st, ru.key())
+}
+
+// EnterScope ensures that the remote unit has entered scope in the
+// relation, with the supplied settings. If the remote unit is already
+// in scope, its settings are updated to match those supplied.
+func (ru *RemoteRelationUnit) EnterScope(settings map[string]interface{}) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot enter scope for remote unit %q in relation %q", ru.unitName, ru.relation)
+	if inScope, err := ru.InScope(); err != nil {
+		return errors.Trace(err)
+	} else if inScope {
+		return errors.Trace(ru.replaceSettings(settings))
+	}
+	key := ru.key()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := ru.relation.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if inScope, err := ru.InScope(); err != nil {
+				return nil, errors.Trace(err)
+			} else if inScope {
+				return nil, jujutxn.ErrNoOperations
+			}
+		}
+		if ru.relation.doc.Life != Alive {
+			return nil, ErrCannotEnterScope
+		}
+		ops := []txn.Op{{
+			C:      remoteServicesC,
+			Id:     ru.st.docID(ru.endpoint.ServiceName),
+			Assert: isAliveDoc,
+		}, {
+			C:      relationsC,
+			Id:     ru.relation.doc.DocID,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$inc", bson.D{{"unitcount", 1}}}},
+		}}
+		settingsColl, closer := ru.st.getCollection(settingsC)
+		defer closer()
+		if count, err := settingsColl.FindId(key).Count(); err != nil {
+			return nil, errors.Trace(err)
+		} else if count == 0 {
+			ops = append(ops, createSettingsOp(key, settings))
+		} else {
+			op, _, err := replaceSettingsOp(ru.st, key, settings)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, op)
+		}
+		return append(ops, txn.Op{
+			C:      relationScopesC,
+			Id:     key,
+			Assert: txn.DocMissing,
+			Insert: relationScopeDoc{
+				Key: key,
+			},
+		}), nil
+	}
+	return ru.st.run(buildTxn)
+}
+
+// replaceSettings replaces the remote unit's settings in the relation,
+// writing only those values that have changed.
+func (ru *RemoteRelationUnit) replaceSettings(settings map[string]interface{}) error {
+	node, err := readSettings(ru.st, ru.key())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, k := range node.Keys() {
+		if _, ok := settings[k]; !ok {
+			node.Delete(k)
+		}
+	}
+	node.Update(settings)
+	_, err = node.Write()
+	return errors.Trace(err)
+}
+
+// LeaveScope signals that the remote unit has left its scope in the
+// relation. If the relation is dying when its last member unit leaves,
+// it is removed immediately. It is not an error to leave a scope that
+// the remote unit is not, or never was, a member of.
+func (ru *RemoteRelationUnit) LeaveScope() error {
+	key := ru.key()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := ru.relation.Refresh(); errors.IsNotFound(err) {
+				return nil, jujutxn.ErrNoOperations
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if inScope, err := ru.InScope(); err != nil {
+			return nil, errors.Trace(err)
+		} else if !inScope {
+			return nil, jujutxn.ErrNoOperations
+		}
+		ops := []txn.Op{{
+			C:      relationScopesC,
+			Id:     key,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}
+		if ru.relation.doc.Life == Alive {
+			ops = append(ops, txn.Op{
+				C:      relationsC,
+				Id:     ru.relation.doc.DocID,
+				Assert: bson.D{{"life", Alive}},
+				Update: bson.D{{"$inc", bson.D{{"unitcount", -1}}}},
+			})
+		} else if ru.relation.doc.UnitCount > 1 {
+			ops = append(ops, txn.Op{
+				C:      relationsC,
+				Id:     ru.relation.doc.DocID,
+				Assert: bson.D{{"unitcount", bson.D{{"$gt", 1}}}},
+				Update: bson.D{{"$inc", bson.D{{"unitcount", -1}}}},
+			})
+		} else {
+			relOps, err := ru.relation.removeOps("", ru.endpoint.ServiceName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, relOps...)
+		}
+		return ops, nil
+	}
+	if err := ru.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot leave scope for remote unit %q in relation %q", ru.unitName, ru.relation)
+	}
+	return nil
+}
+
+// joinedUnits returns the names of the units of the named service that
+// have joined the relation and are not preparing to leave it.
+func (r *Relation) joinedUnits(serviceName string) ([]string, error) {
+	ep, err := r.Endpoint(serviceName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relationScopes, closer := r.st.getCollection(relationScopesC)
+	defer closer()
+
+	prefix := relationUnitKey(r.doc.Id, string(ep.Role), "")
+	sel := bson.D{
+		{"key", bson.D{{"$regex", "^" + regexp.QuoteMeta(prefix)}}},
+		{"departing", bson.D{{"$ne", true}}},
+	}
+	var docs []relationScopeDoc
+	if err := relationScopes.Find(sel).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	units := make([]string, len(docs))
+	for i, doc := range docs {
+		units[i] = doc.unitName()
+	}
+	return units, nil
+}
+
+// SyncRemoteRelations exchanges relation settings between services
+// related across models hosted by the controller. For each relation
+// with a remote service, the units of the service in its own model are
+// represented in the relation by remote units, whose settings mirror
+// those of the units they represent. The counterpart relation in the
+// other model is created when the consumer relates to an offer, and
+// destroyed when either side of the relation is destroyed.
+//
+// Problems with individual relations are logged rather than returned,
+// so that one broken relation does not prevent others from being
+// synchronised.
+func (st *State) SyncRemoteRelations() error {
+	// Only models with remote services take part in cross-model
+	// relations, so there is no need to look at any other model.
+	remoteServices, closer := st.getRawCollection(remoteServicesC)
+	defer closer()
+	var modelUUIDs []string
+	if err := remoteServices.Find(nil).Distinct("model-uuid", &modelUUIDs); err != nil {
+		return errors.Annotate(err, "cannot get models with remote services")
+	}
+	states := make(map[string]*State)
+	defer func() {
+		for _, st := range states {
+			st.Close()
+		}
+	}()
+	getState := func(uuid string) (*State, error) {
+		if st, ok := states[uuid]; ok {
+			return st, nil
+		}
+		modelSt, err := st.ForModel(names.NewModelTag(uuid))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		states[uuid] = modelSt
+		return modelSt, nil
+	}
+	for _, modelUUID := range modelUUIDs {
+		modelSt, err := getState(modelUUID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		model, err := modelSt.Model()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if model.Life() != Alive {
+			continue
+		}
+		remotes, err := modelSt.AllRemoteServices()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, remote := range remotes {
+			sourceSt, err := getState(remote.doc.SourceModelUUID)
+			if errors.IsNotFound(err) {
+				logger.Warningf("model hosting remote service %q not found", remote)
+				continue
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+			rels, err := remote.Relations()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			for _, rel := range rels {
+				if err := syncRemoteRelation(rel, remote, sourceSt); err != nil {
+					logger.Warningf("cannot synchronise relation %q in model %q: %v", rel, model.Name(), err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// syncRemoteRelation brings the remote units of the given relation up
+// to date with the units in its counterpart relation in the model
+// hosting the remote service.
+func syncRemoteRelation(rel *Relation, remote *RemoteService, sourceSt *State) error {
+	remoteEp, err := rel.Endpoint(remote.Name())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	related, err := rel.RelatedEndpoints(remote.Name())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	localEp := related[0]
+	sourceEp := Endpoint{remote.SourceServiceName(), remoteEp.Relation}
+
+	counterpart, _, err := counterpartRelation(rel.st.ModelUUID(), localEp, sourceEp, sourceSt)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if counterpart == nil && rel.Life() == Alive && remote.URL() != "" {
+		// The relation was made by consuming an offer; relate the
+		// offered service to a proxy for the consumer.
+		return errors.Trace(addCounterpartRelation(localEp, sourceEp, rel.st.ModelUUID(), sourceSt))
+	}
+	if counterpart == nil || counterpart.Life() != Alive || rel.Life() != Alive {
+		// One side of the relation has been destroyed, so both must go.
+		if err := leaveRemoteUnits(rel, remote.Name(), nil); err != nil {
+			return errors.Trace(err)
+		}
+		if counterpart != nil && counterpart.Life() == Alive {
+			if err := counterpart.Destroy(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if err := rel.Refresh(); errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(rel.Destroy())
+	}
+
+	// Mirror the joined source units, and their settings, as remote
+	// units in this relation.
+	sourceUnits, err := counterpart.joinedUnits(sourceEp.ServiceName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	joined := make(map[string]bool)
+	for _, sourceUnit := range sourceUnits {
+		settings, err := readSettings(sourceSt, relationUnitKey(counterpart.Id(), string(sourceEp.Role), sourceUnit))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		unitName := remote.Name() + sourceUnit[strings.Index(sourceUnit, "/"):]
+		ru, err := rel.RemoteUnit(unitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := ru.EnterScope(settings.Map()); err != nil {
+			return errors.Trace(err)
+		}
+		joined[unitName] = true
+	}
+	return errors.Trace(leaveRemoteUnits(rel, remote.Name(), joined))
+}
+
+// leaveRemoteUnits makes the remote units of the named service leave
+// the relation's scope, except for those in keep.
+func leaveRemoteUnits(rel *Relation, remoteName string, keep map[string]bool) error {
+	units, err := rel.joinedUnits(remoteName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, unitName := range units {
+		if keep[unitName] {
+			continue
+		}
+		ru, err := rel.RemoteUnit(unitName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := ru.LeaveScope(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// counterpartRelation returns the relation in sourceSt between the
+// source endpoint and the proxy for the local endpoint's service, and
+// the proxy itself. Both are nil if they do not exist.
+func counterpartRelation(modelUUID string, localEp, sourceEp Endpoint, sourceSt *State) (*Relation, *RemoteService, error) {
+	remotes, err := sourceSt.AllRemoteServices()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	for _, proxy := range remotes {
+		if proxy.doc.SourceModelUUID != modelUUID || proxy.SourceServiceName() != localEp.ServiceName {
+			continue
+		}
+		proxyEp := Endpoint{proxy.Name(), localEp.Relation}
+		rel, err := sourceSt.EndpointsRelation(sourceEp, proxyEp)
+		if errors.IsNotFound(err) {
+			return nil, proxy, nil
+		} else if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		return rel, proxy, nil
+	}
+	return nil, nil, nil
+}
+
+// addCounterpartRelation relates the source endpoint in sourceSt to a
+// proxy for the local endpoint's service, adding the proxy if needed.
+func addCounterpartRelation(localEp, sourceEp Endpoint, modelUUID string, sourceSt *State) error {
+	_, proxy, err := counterpartRelation(modelUUID, localEp, sourceEp, sourceSt)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if proxy == nil {
+		proxy, err = sourceSt.AddRemoteService(AddRemoteServiceArgs{
+			Name:              proxyServiceName(modelUUID, localEp.ServiceName),
+			SourceModel:       names.NewModelTag(modelUUID),
+			SourceServiceName: localEp.ServiceName,
+			Endpoints:         []charm.Relation{localEp.Relation},
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+	} else if _, err := proxy.Endpoint(localEp.Name); err != nil {
+		if err := proxy.addEndpoint(localEp.Relation); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	_, err = sourceSt.AddRelation(sourceEp, Endpoint{proxy.Name(), localEp.Relation})
+	return errors.Trace(err)
+}
+
+// proxyServiceName returns the name of the remote service representing
+// the named service of the given model in the models it is related to
+// through offers. Services in different models may share a name, so the
+// model's UUID is included to keep the proxy's name unique; it is
+// prefixed with a letter, as each part of a service name must contain
+// one.
+func proxyServiceName(modelUUID, serviceName string) string {
+	return fmt.Sprintf("%s-m%s", serviceName, strings.Replace(modelUUID, "-", "", -1))
+}
@@ -0,0 +1,453 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// remoteServiceDoc is the mongodb representation of a remote service:
+// a proxy in this model for a service hosted by another model in the
+// same controller.
+type remoteServiceDoc struct {
+	DocID             string              `bson:"_id"`
+	Name              string              `bson:"name"`
+	ModelUUID         string              `bson:"model-uuid"`
+	URL               string              `bson:"url,omitempty"`
+	SourceModelUUID   string              `bson:"source-model-uuid"`
+	SourceServiceName string              `bson:"source-service-name"`
+	Endpoints         []remoteEndpointDoc `bson:"endpoints"`
+	Life              Life                `bson:"life"`
+	RelationCount     int                 `bson:"relationcount"`
+}
+
+// remoteEndpointDoc represents one of the endpoints of a remote service.
+type remoteEndpointDoc struct {
+	Name      string              `bson:"name"`
+	Role      charm.RelationRole  `bson:"role"`
+	Interface string              `bson:"interface"`
+	Limit     int                 `bson:"limit"`
+	Scope     charm.RelationScope `bson:"scope"`
+}
+
+// RemoteService represents a service hosted by another model, with
+// which services in this model may be related.
+type RemoteService struct {
+	st  *State
+	doc remoteServiceDoc
+}
+
+func newRemoteService(st *State, doc *remoteServiceDoc) *RemoteService {
+	return &RemoteService{
+		st:  st,
+		doc: *doc,
+	}
+}
+
+// Name returns the name of the remote service in this model.
+func (s *RemoteService) Name() string {
+	return s.doc.Name
+}
+
+// URL returns the URL of the offer through which the service was
+// consumed. It is empty for remote services representing consumers
+// of this model's offers.
+func (s *RemoteService) URL() string {
+	return s.doc.URL
+}
+
+// SourceModel returns the tag of the model hosting the service.
+func (s *RemoteService) SourceModel() names.ModelTag {
+	return names.NewModelTag(s.doc.SourceModelUUID)
+}
+
+// SourceServiceName returns the name of the service in its own model.
+func (s *RemoteService) SourceServiceName() string {
+	return s.doc.SourceServiceName
+}
+
+// Life returns the remote service's current life state.
+func (s *RemoteService) Life() Life {
+	return s.doc.Life
+}
+
+// String returns the remote service name.
+func (s *RemoteService) String() string {
+	return s.doc.Name
+}
+
+// Endpoints returns the remote service's endpoints.
+func (s *RemoteService) Endpoints() []Endpoint {
+	eps := make([]Endpoint, len(s.doc.Endpoints))
+	for i, ep := range s.doc.Endpoints {
+		eps[i] = Endpoint{
+			ServiceName: s.doc.Name,
+			Relation: charm.Relation{
+				Name:      ep.Name,
+				Role:      ep.Role,
+				Interface: ep.Interface,
+				Limit:     ep.Limit,
+				Scope:     ep.Scope,
+			},
+		}
+	}
+	sort.Sort(epSlice(eps))
+	return eps
+}
+
+// Endpoint returns the remote service's endpoint with the given name.
+func (s *RemoteService) Endpoint(relationName string) (Endpoint, error) {
+	for _, ep := range s.Endpoints() {
+		if ep.Name == relationName {
+			return ep, nil
+		}
+	}
+	return Endpoint{}, errors.Errorf("remote service %q has no %q relation", s, relationName)
+}
+
+// Relations returns the relations in which the remote service
+// participates.
+func (s *RemoteService) Relations() ([]*Relation, error) {
+	return serviceRelations(s.st, s.doc.Name)
+}
+
+// Refresh refreshes the contents of the remote service from the
+// underlying state. It returns an error that satisfies
+// errors.IsNotFound if the remote service has been removed.
+func (s *RemoteService) Refresh() error {
+	remoteServices, closer := s.st.getCollection(remoteServicesC)
+	defer closer()
+
+	err := remoteServices.FindId(s.doc.DocID).One(&s.doc)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("remote service %q", s)
+	}
+	if err != nil {
+		return errors.Annotatef(err, "cannot refresh remote service %q", s)
+	}
+	return nil
+}
+
+// Destroy ensures that the remote service and all its relations will
+// be removed at some point; if it has no relations, it is removed
+// immediately.
+func (s *RemoteService) Destroy() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot destroy remote service %q", s)
+	svc := &RemoteService{st: s.st, doc: s.doc}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := svc.Refresh(); errors.IsNotFound(err) {
+				return nil, jujutxn.ErrNoOperations
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		switch ops, err := svc.destroyOps(); err {
+		case errRefresh:
+		case errAlreadyDying:
+			return nil, jujutxn.ErrNoOperations
+		case nil:
+			return ops, nil
+		default:
+			return nil, errors.Trace(err)
+		}
+		return nil, jujutxn.ErrTransientFailure
+	}
+	if err := s.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	s.doc.Life = Dying
+	return nil
+}
+
+// destroyOps returns the operations required to destroy the remote
+// service, mirroring those used to destroy a service.
+func (s *RemoteService) destroyOps() ([]txn.Op, error) {
+	if s.doc.Life == Dying {
+		return nil, errAlreadyDying
+	}
+	rels, err := s.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(rels) != s.doc.RelationCount {
+		return nil, errRefresh
+	}
+	var ops []txn.Op
+	removeCount := 0
+	for _, rel := range rels {
+		relOps, isRemove, err := rel.destroyOps(s.doc.Name)
+		if err == errAlreadyDying {
+			relOps = []txn.Op{{
+				C:      relationsC,
+				Id:     rel.doc.DocID,
+				Assert: bson.D{{"life", Dying}},
+			}}
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if isRemove {
+			removeCount++
+		}
+		ops = append(ops, relOps...)
+	}
+	if s.doc.RelationCount == removeCount {
+		hasLastRefs := bson.D{{"life", Alive}, {"relationcount", removeCount}}
+		return append(ops, s.removeOps(hasLastRefs)...), nil
+	}
+	update := bson.D{{"$set", bson.D{{"life", Dying}}}}
+	if removeCount != 0 {
+		update = append(update, bson.D{{"$inc", bson.D{{"relationcount", -removeCount}}}}...)
+	}
+	return append(ops, txn.Op{
+		C:      remoteServicesC,
+		Id:     s.doc.DocID,
+		Assert: bson.D{{"life", Alive}, {"relationcount", s.doc.RelationCount}},
+		Update: update,
+	}), nil
+}
+
+// removeOps returns the operations required to remove the remote
+// service, asserting the supplied conditions.
+func (s *RemoteService) removeOps(asserts bson.D) []txn.Op {
+	return []txn.Op{{
+		C:      remoteServicesC,
+		Id:     s.doc.DocID,
+		Assert: asserts,
+		Remove: true,
+	}}
+}
+
+// addEndpoint adds an endpoint to the remote service, so that the
+// service's other endpoints may be related remotely.
+func (s *RemoteService) addEndpoint(ep charm.Relation) error {
+	if ep.Role == charm.RolePeer {
+		return errors.Errorf("peer endpoint %q cannot be related remotely", ep.Name)
+	}
+	ops := []txn.Op{{
+		C:  remoteServicesC,
+		Id: s.doc.DocID,
+		Assert: append(bson.D{
+			{"endpoints.name", bson.D{{"$ne", ep.Name}}},
+		}, isAliveDoc...),
+		Update: bson.D{{"$push", bson.D{{"endpoints", remoteEndpointDoc{
+			Name:      ep.Name,
+			Role:      ep.Role,
+			Interface: ep.Interface,
+			Limit:     ep.Limit,
+			Scope:     ep.Scope,
+		}}}}},
+	}}
+	if err := s.st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.Errorf("cannot add endpoint %q to remote service %q", ep.Name, s)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.Refresh())
+}
+
+// AddRemoteServiceArgs holds the parameters for adding a remote service.
+type AddRemoteServiceArgs struct {
+	// Name is the name of the remote service in this model.
+	Name string
+
+	// URL is the URL of the offer being consumed, if any.
+	URL string
+
+	// SourceModel identifies the model hosting the service.
+	SourceModel names.ModelTag
+
+	// SourceServiceName is the name of the service in its own model.
+	SourceServiceName string
+
+	// Endpoints holds the endpoints through which the service may be
+	// related.
+	Endpoints []charm.Relation
+}
+
+// AddRemoteService adds a proxy for a service hosted by another model,
+// so that services in this model may be related to it.
+func (st *State) AddRemoteService(args AddRemoteServiceArgs) (_ *RemoteService, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add remote service %q", args.Name)
+	if !names.IsValidService(args.Name) {
+		return nil, errors.NotValidf("name %q", args.Name)
+	}
+	if args.SourceModel.Id() == st.ModelUUID() {
+		return nil, errors.Errorf("source service is hosted by this model")
+	}
+	if len(args.Endpoints) == 0 {
+		return nil, errors.Errorf("no endpoints")
+	}
+	if err := checkModelActive(st); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if exists, err := isNotDead(st, servicesC, args.Name); err != nil {
+		return nil, errors.Trace(err)
+	} else if exists {
+		return nil, errors.Errorf("service already exists")
+	}
+	docID := st.docID(args.Name)
+	doc := &remoteServiceDoc{
+		DocID:             docID,
+		Name:              args.Name,
+		ModelUUID:         st.ModelUUID(),
+		URL:               args.URL,
+		SourceModelUUID:   args.SourceModel.Id(),
+		SourceServiceName: args.SourceServiceName,
+		Life:              Alive,
+	}
+	for _, ep := range args.Endpoints {
+		if ep.Role == charm.RolePeer {
+			return nil, errors.Errorf("peer endpoint %q cannot be related remotely", ep.Name)
+		}
+		doc.Endpoints = append(doc.Endpoints, remoteEndpointDoc{
+			Name:      ep.Name,
+			Role:      ep.Role,
+			Interface: ep.Interface,
+			Limit:     ep.Limit,
+			Scope:     ep.Scope,
+		})
+	}
+	ops := []txn.Op{
+		assertModelActiveOp(st.ModelUUID()),
+		{
+			C:      servicesC,
+			Id:     docID,
+			Assert: txn.DocMissing,
+		}, {
+			C:      remoteServicesC,
+			Id:     docID,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		},
+	}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		if err := checkModelActive(st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return nil, errors.Errorf("service already exists")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newRemoteService(st, doc), nil
+}
+
+// RemoteService returns the remote service with the given name.
+func (st *State) RemoteService(name string) (*RemoteService, error) {
+	if !names.IsValidService(name) {
+		return nil, errors.NotValidf("remote service name %q", name)
+	}
+	remoteServices, closer := st.getCollection(remoteServicesC)
+	defer closer()
+
+	doc := &remoteServiceDoc{}
+	err := remoteServices.FindId(name).One(doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("remote service %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get remote service %q", name)
+	}
+	return newRemoteService(st, doc), nil
+}
+
+// AllRemoteServices returns all the remote services in the model.
+func (st *State) AllRemoteServices() ([]*RemoteService, error) {
+	remoteServices, closer := st.getCollection(remoteServicesC)
+	defer closer()
+
+	var docs []remoteServiceDoc
+	if err := remoteServices.Find(nil).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all remote services")
+	}
+	services := make([]*RemoteService, len(docs))
+	for i := range docs {
+		services[i] = newRemoteService(st, &docs[i])
+	}
+	return services, nil
+}
+
+// isRemoteService returns whether the named service is a remote service.
+func isRemoteService(st *State, name string) (bool, error) {
+	remoteServices, closer := st.getCollection(remoteServicesC)
+	defer closer()
+
+	count, err := remoteServices.FindId(name).Count()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return count > 0, nil
+}
+
+// addRemoteRelationOps returns the operations required to relate the
+// given endpoint of a remote service.
+func (st *State) addRemoteRelationOps(ep Endpoint) ([]txn.Op, error) {
+	remote, err := st.RemoteService(ep.ServiceName)
+	if errors.IsNotFound(err) {
+		return nil, errors.Errorf("service %q does not exist", ep.ServiceName)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	} else if remote.doc.Life != Alive {
+		return nil, errors.Errorf("remote service %q is not alive", ep.ServiceName)
+	}
+	if ep.Scope == charm.ScopeContainer {
+		return nil, errors.Errorf("remote service %q cannot be related with container scope", ep.ServiceName)
+	}
+	if _, err := remote.Endpoint(ep.Name); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []txn.Op{{
+		C:      remoteServicesC,
+		Id:     remote.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$inc", bson.D{{"relationcount", 1}}}},
+	}}, nil
+}
+
+// removeRemoteServiceOps returns the operations required to release the
+// named remote service's reference to the relation, removing the remote
+// service if it is Dying and the relation was its last.
+func (r *Relation) removeRemoteServiceOps(name, departingService string) ([]txn.Op, error) {
+	hasRelation := bson.D{{"relationcount", bson.D{{"$gt", 0}}}}
+	if departingService == "" || departingService == name {
+		// Either the relation is being destroyed, in which case the
+		// remote service is Alive, or the departing unit represents
+		// the remote service, which therefore cannot be removed yet.
+		asserts := hasRelation
+		if departingService == "" {
+			asserts = append(hasRelation, isAliveDoc...)
+		}
+		return []txn.Op{{
+			C:      remoteServicesC,
+			Id:     r.st.docID(name),
+			Assert: asserts,
+			Update: bson.D{{"$inc", bson.D{{"relationcount", -1}}}},
+		}}, nil
+	}
+	remote, err := r.st.RemoteService(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	hasLastRef := bson.D{{"life", Dying}, {"relationcount", 1}}
+	if remote.doc.Life == Dying && remote.doc.RelationCount == 1 {
+		return remote.removeOps(hasLastRef), nil
+	}
+	return []txn.Op{{
+		C:  remoteServicesC,
+		Id: remote.doc.DocID,
+		Assert: bson.D{{"$or", []bson.D{
+			{{"life", Alive}},
+			{{"relationcount", bson.D{{"$gt", 1}}}},
+		}}},
+		Update: bson.D{{"$inc", bson.D{{"relationcount", -1}}}},
+	}}, nil
+}
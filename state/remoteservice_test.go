@@ -0,0 +1,322 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type RemoteServiceSuite struct {
+	ConnSuite
+	mysql    *state.Service
+	offer    *state.ServiceOffer
+	consumer *state.State
+}
+
+var _ = gc.Suite(&RemoteServiceSuite{})
+
+func (s *RemoteServiceSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.mysql = s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	offer, err := s.State.AddServiceOffer(state.ServiceOfferArgs{
+		ServiceName: "mysql",
+		Description: "a database",
+		Owner:       s.Owner,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.offer = offer
+	s.consumer = s.NewStateForModelNamed(c, "consumer")
+}
+
+// consume adds a remote service named "db" to the consuming model,
+// representing the offered mysql service.
+func (s *RemoteServiceSuite) consume(c *gc.C) *state.RemoteService {
+	remote, err := s.consumer.AddRemoteService(state.AddRemoteServiceArgs{
+		Name:              "db",
+		URL:               s.offer.URL(),
+		SourceModel:       s.State.ModelTag(),
+		SourceServiceName: "mysql",
+		Endpoints:         s.offer.Endpoints(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return remote
+}
+
+// relateWordpress deploys wordpress to the consuming model and relates
+// it to the remote service.
+func (s *RemoteServiceSuite) relateWordpress(c *gc.C) (*state.Service, *state.Relation) {
+	wordpress := state.AddTestingService(c, s.consumer, "wordpress", state.AddTestingCharm(c, s.consumer, "wordpress"), s.Owner)
+	eps, err := s.consumer.InferEndpoints("wordpress", "db")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.consumer.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	return wordpress, rel
+}
+
+func (s *RemoteServiceSuite) TestAddServiceOffer(c *gc.C) {
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.offer.URL(), gc.Equals, "local:/u/test-admin@local/"+model.Name()+"/mysql")
+	c.Assert(s.offer.OfferName(), gc.Equals, "mysql")
+	c.Assert(s.offer.ServiceName(), gc.Equals, "mysql")
+	c.Assert(s.offer.SourceModelUUID(), gc.Equals, s.State.ModelUUID())
+	ep, err := s.mysql.Endpoint("server")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.offer.Endpoints(), jc.DeepEquals, []charm.Relation{ep.Relation})
+	c.Assert(s.offer.Description(), gc.Equals, "a database")
+	c.Assert(s.offer.Owner(), gc.Equals, s.Owner)
+
+	// Offers are visible from every model in the controller.
+	offer, err := s.consumer.ServiceOffer(s.offer.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.ServiceName(), gc.Equals, "mysql")
+	offers, err := s.consumer.ServiceOffers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offers, gc.HasLen, 1)
+	c.Assert(offers[0].URL(), gc.Equals, s.offer.URL())
+}
+
+func (s *RemoteServiceSuite) TestAddServiceOfferDuplicate(c *gc.C) {
+	_, err := s.State.AddServiceOffer(state.ServiceOfferArgs{
+		ServiceName: "mysql",
+		Owner:       s.Owner,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *RemoteServiceSuite) TestAddServiceOfferUnknownEndpoint(c *gc.C) {
+	_, err := s.State.AddServiceOffer(state.ServiceOfferArgs{
+		OfferName:   "other",
+		ServiceName: "mysql",
+		Endpoints:   []string{"admin"},
+		Owner:       s.Owner,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot offer service "mysql": endpoint "admin" not found`)
+}
+
+func (s *RemoteServiceSuite) TestRemoveServiceOffer(c *gc.C) {
+	err := s.State.RemoveServiceOffer(s.offer.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ServiceOffer(s.offer.URL())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.State.RemoveServiceOffer(s.offer.URL())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RemoteServiceSuite) TestAddRemoteService(c *gc.C) {
+	remote := s.consume(c)
+	c.Assert(remote.Name(), gc.Equals, "db")
+	c.Assert(remote.URL(), gc.Equals, s.offer.URL())
+	c.Assert(remote.SourceModel(), gc.Equals, s.State.ModelTag())
+	c.Assert(remote.SourceServiceName(), gc.Equals, "mysql")
+	c.Assert(remote.Life(), gc.Equals, state.Alive)
+	eps := remote.Endpoints()
+	c.Assert(eps, gc.HasLen, 1)
+	c.Assert(eps[0].ServiceName, gc.Equals, "db")
+	c.Assert(eps[0].Name, gc.Equals, "server")
+
+	all, err := s.consumer.AllRemoteServices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Assert(all[0].Name(), gc.Equals, "db")
+}
+
+func (s *RemoteServiceSuite) TestAddRemoteServiceNameClash(c *gc.C) {
+	s.consume(c)
+	_, err := s.consumer.AddRemoteService(state.AddRemoteServiceArgs{
+		Name:              "db",
+		SourceModel:       s.State.ModelTag(),
+		SourceServiceName: "mysql",
+		Endpoints:         []charm.Relation{{Name: "server", Role: charm.RoleProvider, Interface: "mysql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add remote service "db": service already exists`)
+
+	ch := state.AddTestingCharm(c, s.consumer, "mysql")
+	_, err = s.consumer.AddService(state.AddServiceArgs{Name: "db", Owner: s.Owner.String(), Charm: ch})
+	c.Assert(err, gc.ErrorMatches, `cannot add service "db": remote service with same name already exists`)
+}
+
+func (s *RemoteServiceSuite) TestAddRelation(c *gc.C) {
+	remote := s.consume(c)
+	_, rel := s.relateWordpress(c)
+	c.Assert(rel.String(), gc.Equals, "wordpress:db db:server")
+
+	rels, err := remote.Relations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rels, gc.HasLen, 1)
+}
+
+func (s *RemoteServiceSuite) TestDestroyRemoteServiceRemovesRelations(c *gc.C) {
+	remote := s.consume(c)
+	_, rel := s.relateWordpress(c)
+
+	err := remote.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.consumer.RemoteService("db")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RemoteServiceSuite) TestRemoteUnitScope(c *gc.C) {
+	s.consume(c)
+	wordpress, rel := s.relateWordpress(c)
+	unit, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+
+	remoteUnit, err := rel.RemoteUnit("db/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = remoteUnit.EnterScope(map[string]interface{}{"host": "db.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := ru.ReadSettings("db/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]interface{}{"host": "db.example.com"})
+
+	// Entering scope again replaces the settings.
+	err = remoteUnit.EnterScope(map[string]interface{}{"host": "db2.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err = ru.ReadSettings("db/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]interface{}{"host": "db2.example.com"})
+
+	err = remoteUnit.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+	inScope, err := remoteUnit.InScope()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inScope, jc.IsFalse)
+
+	_, err = rel.RemoteUnit("wordpress/0")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RemoteServiceSuite) TestSyncRemoteRelations(c *gc.C) {
+	s.consume(c)
+	wordpress, rel := s.relateWordpress(c)
+
+	// The first sync relates the offered service to a proxy for the
+	// consumer in the offering model.
+	err := s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	proxyName := "wordpress-m" + strings.Replace(s.consumer.ModelUUID(), "-", "", -1)
+	proxy, err := s.State.RemoteService(proxyName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(proxy.SourceModel(), gc.Equals, s.consumer.ModelTag())
+	c.Assert(proxy.SourceServiceName(), gc.Equals, "wordpress")
+	c.Assert(proxy.URL(), gc.Equals, "")
+	eps, err := s.State.InferEndpoints("mysql", proxyName)
+	c.Assert(err, jc.ErrorIsNil)
+	counterpart, err := s.State.EndpointsRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Units on both sides join, and their settings are exchanged.
+	wpUnit, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	wpRU, err := rel.Unit(wpUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = wpRU.EnterScope(map[string]interface{}{"user": "wp"})
+	c.Assert(err, jc.ErrorIsNil)
+	mysqlUnit, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	mysqlRU, err := counterpart.Unit(mysqlUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysqlRU.EnterScope(map[string]interface{}{"host": "db.example.com"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := wpRU.ReadSettings("db/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]interface{}{"host": "db.example.com"})
+	settings, err = mysqlRU.ReadSettings(proxyName + "/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]interface{}{"user": "wp"})
+
+	// Destroying the consumer's relation destroys its counterpart.
+	err = rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	err = counterpart.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(counterpart.Life(), gc.Equals, state.Dying)
+	remoteUnit, err := rel.RemoteUnit("db/0")
+	c.Assert(err, jc.ErrorIsNil)
+	inScope, err := remoteUnit.InScope()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inScope, jc.IsFalse)
+}
+
+func (s *RemoteServiceSuite) TestSyncRemoteRelationsDistinctProxies(c *gc.C) {
+	s.consume(c)
+	s.relateWordpress(c)
+
+	// A service with the same name in another model consumes the
+	// same offer.
+	other := s.NewStateForModelNamed(c, "other")
+	_, err := other.AddRemoteService(state.AddRemoteServiceArgs{
+		Name:              "db",
+		URL:               s.offer.URL(),
+		SourceModel:       s.State.ModelTag(),
+		SourceServiceName: "mysql",
+		Endpoints:         s.offer.Endpoints(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	state.AddTestingService(c, other, "wordpress", state.AddTestingCharm(c, other, "wordpress"), s.Owner)
+	eps, err := other.InferEndpoints("wordpress", "db")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = other.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SyncRemoteRelations()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, st := range []*state.State{s.consumer, other} {
+		proxyName := "wordpress-m" + strings.Replace(st.ModelUUID(), "-", "", -1)
+		proxy, err := s.State.RemoteService(proxyName)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(proxy.SourceModel(), gc.Equals, st.ModelTag())
+		eps, err := s.State.InferEndpoints("mysql", proxyName)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = s.State.EndpointsRelation(eps...)
+		c.Check(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *RemoteServiceSuite) TestWatchRemoteRelations(c *gc.C) {
+	w := s.State.WatchRemoteRelations()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Changes in any model are reported.
+	s.consume(c)
+	wc.AssertOneChange()
+	wordpress, rel := s.relateWordpress(c)
+	wc.AssertOneChange()
+
+	unit, err := wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{"user": "wp"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	settings, err := ru.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	settings.Set("user", "wp2")
+	_, err = settings.Write()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -47,6 +47,14 @@ type Resources interface {
 	// service to the provided values.
 	SetCharmStoreResources(serviceID string, info []charmresource.Resource, lastPolled time.Time) error
 
+	// ListResourceHistory returns the retained revisions of each of
+	// the service's resources.
+	ListResourceHistory(serviceID string) ([]resource.HistoryEntry, error)
+
+	// RollbackResource makes the identified revision from the
+	// resource's history the service's current resource.
+	RollbackResource(serviceID, name string, number int) (resource.Resource, error)
+
 	// TODO(ericsnow) Move this down to ResourcesPersistence.
 
 	// NewResolvePendingResourcesOps generates mongo transaction operations
@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/juju/errors"
@@ -38,6 +39,10 @@ func pendingResourceID(id, pendingID string) string {
 	return resourceID(id, "pending", pendingID)
 }
 
+func historyResourceID(id string, number int) string {
+	return resourceID(id, "history", strconv.Itoa(number))
+}
+
 func charmStoreResourceID(id string) string {
 	return serviceResourceID(id) + resourcesCharmstoreIDSuffix
 }
@@ -173,6 +178,28 @@ func newUpdateUnitResourceOps(unitID string, stored storedResource, progress *in
 	}}, newInsertUnitResourceOps(unitID, stored, progress)...)
 }
 
+func newInsertResourceHistoryOps(stored storedResource, number int) []txn.Op {
+	doc := newResourceHistoryDoc(stored, number)
+
+	return []txn.Op{{
+		C:      resourcesC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+}
+
+func newUpdateResourceHistoryOps(stored storedResource, number int) []txn.Op {
+	doc := newResourceHistoryDoc(stored, number)
+
+	return append([]txn.Op{{
+		C:      resourcesC,
+		Id:     doc.DocID,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}, newInsertResourceHistoryOps(stored, number)...)
+}
+
 func newRemoveResourcesOps(docs []resourceDoc) []txn.Op {
 	// The likelihood of a race is small and the consequences are minor,
 	// so we don't worry about the corner case of missing a doc here.
@@ -245,6 +272,15 @@ func newResourceDoc(stored storedResource) *resourceDoc {
 	return resource2doc(fullID, stored)
 }
 
+// newResourceHistoryDoc generates a doc that records the given
+// resource in the resource's history.
+func newResourceHistoryDoc(stored storedResource, number int) *resourceDoc {
+	fullID := historyResourceID(stored.ID, number)
+	doc := resource2doc(fullID, stored)
+	doc.HistoryNumber = number
+	return doc
+}
+
 // newStagedResourceDoc generates a staging doc that represents
 // the given resource.
 func newStagedResourceDoc(stored storedResource) *resourceDoc {
@@ -264,6 +300,18 @@ func (p ResourcePersistence) resources(serviceID string) ([]resourceDoc, error)
 	return docs, nil
 }
 
+// resourceDocs returns all the docs for the given resource, including
+// those recording its history and its use by units.
+func (p ResourcePersistence) resourceDocs(resID string) ([]resourceDoc, error) {
+	logger.Tracef("querying db for docs of resource %q", resID)
+	var docs []resourceDoc
+	query := bson.D{{"resource-id", resID}}
+	if err := p.base.All(resourcesC, query, &docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return docs, nil
+}
+
 func (p ResourcePersistence) unitResources(unitID string) ([]resourceDoc, error) {
 	var docs []resourceDoc
 	query := bson.D{{"unit-id", unitID}}
@@ -295,6 +343,31 @@ func (p ResourcePersistence) getOnePending(resID, pendingID string) (resourceDoc
 	return doc, nil
 }
 
+// getOneHistory returns the identified revision from the history of
+// the resource that matches the provided model ID.
+func (p ResourcePersistence) getOneHistory(resID string, number int) (resourceDoc, error) {
+	logger.Tracef("querying db for resource %q (history %d)", resID, number)
+	id := historyResourceID(resID, number)
+	var doc resourceDoc
+	if err := p.base.One(resourcesC, id, &doc); err != nil {
+		return doc, errors.Trace(err)
+	}
+	return doc, nil
+}
+
+// resourceHistoryDocs sorts history docs by resource and then from
+// oldest to newest.
+type resourceHistoryDocs []resourceDoc
+
+func (docs resourceHistoryDocs) Len() int      { return len(docs) }
+func (docs resourceHistoryDocs) Swap(i, j int) { docs[i], docs[j] = docs[j], docs[i] }
+func (docs resourceHistoryDocs) Less(i, j int) bool {
+	if docs[i].ID != docs[j].ID {
+		return docs[i].ID < docs[j].ID
+	}
+	return docs[i].HistoryNumber < docs[j].HistoryNumber
+}
+
 // resourceDoc is the top-level document for resources.
 type resourceDoc struct {
 	DocID     string `bson:"_id"`
@@ -322,6 +395,8 @@ type resourceDoc struct {
 
 	DownloadProgress *int64 `bson:"download-progress,omitempty"`
 
+	HistoryNumber int `bson:"history-number,omitempty"`
+
 	LastPolled time.Time `bson:"timestamp-when-last-polled"`
 }
 
@@ -4,6 +4,8 @@
 package state
 
 import (
+	"bytes"
+	"sort"
 	"time"
 
 	"github.com/juju/errors"
@@ -21,6 +23,10 @@ const (
 	CleanupKindResourceBlob = "resourceBlob"
 )
 
+// resourceHistoryLimit is the number of revisions of each resource
+// whose blobs are retained in the resource's history.
+const resourceHistoryLimit = 5
+
 // ResourcePersistenceBase exposes the core persistence functionality
 // needed for resources.
 type ResourcePersistenceBase interface {
@@ -81,6 +87,9 @@ func (p ResourcePersistence) ListResources(serviceID string) (resource.ServiceRe
 		if doc.PendingID != "" {
 			continue
 		}
+		if doc.HistoryNumber != 0 {
+			continue
+		}
 
 		res, err := doc2basicResource(doc)
 		if err != nil {
@@ -132,7 +141,7 @@ func (p ResourcePersistence) ListPendingResources(serviceID string) ([]resource.
 		if doc.PendingID == "" {
 			continue
 		}
-		// doc.UnitID will always be empty here.
+		// doc.UnitID and doc.HistoryNumber will always be empty here.
 
 		res, err := doc2basicResource(doc)
 		if err != nil {
@@ -304,6 +313,11 @@ func (p ResourcePersistence) setUnitResource(unitID string, res resource.Resourc
 			ops = newInsertUnitResourceOps(unitID, stored, progress)
 		case 1:
 			ops = newUpdateUnitResourceOps(unitID, stored, progress)
+			cleanupOps, err := p.newReplacedUnitResourceOps(unitID, stored)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, cleanupOps...)
 		default:
 			// Either insert or update will work so we should not get here.
 			return nil, errors.New("setting the resource failed")
@@ -366,9 +380,206 @@ func (p ResourcePersistence) NewResolvePendingResourceOps(resID, pendingID strin
 	}
 
 	ops := newResolvePendingResourceOps(pending, exists)
+	if pending.storagePath != "" {
+		resolved := pending // a copy
+		resolved.PendingID = ""
+		historyOps, err := p.newResourceHistoryOps(resolved)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, historyOps...)
+	}
 	return ops, nil
 }
 
+// ListResourceHistory returns the retained revisions of each of the
+// identified service's resources, ordered by resource and then from
+// oldest to newest.
+func (p ResourcePersistence) ListResourceHistory(serviceID string) ([]resource.HistoryEntry, error) {
+	docs, err := p.resources(serviceID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	currentPaths := make(map[string]string)
+	var historyDocs []resourceDoc
+	for _, doc := range docs {
+		switch {
+		case doc.HistoryNumber != 0:
+			historyDocs = append(historyDocs, doc)
+		case doc.DocID == serviceResourceID(doc.ID):
+			currentPaths[doc.ID] = doc.StoragePath
+		}
+	}
+	sort.Sort(resourceHistoryDocs(historyDocs))
+
+	var entries []resource.HistoryEntry
+	for _, doc := range historyDocs {
+		res, err := doc2basicResource(doc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		entries = append(entries, resource.HistoryEntry{
+			Resource: res,
+			Number:   doc.HistoryNumber,
+			Current:  doc.StoragePath == currentPaths[doc.ID],
+		})
+	}
+	return entries, nil
+}
+
+// RollbackResource makes the identified revision from the resource's
+// history the service's current resource. If that changes the content
+// of the resource then the service's CharmModifiedVersion is
+// incremented, so that its units run upgrade-charm and pick up the
+// restored resource.
+func (p ResourcePersistence) RollbackResource(id string, number int) (resource.Resource, error) {
+	doc, err := p.getOneHistory(id, number)
+	if errors.IsNotFound(err) {
+		return resource.Resource{}, errors.NotFoundf("revision %d of resource %q", number, id)
+	}
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	stored, err := doc2resource(doc)
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			// The revision may have been pruned from the history,
+			// and its blob queued for removal, by a new upload.
+			if _, err := p.getOneHistory(id, number); errors.IsNotFound(err) {
+				return nil, errors.NotFoundf("revision %d of resource %q", number, id)
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		ops := []txn.Op{{
+			C:      resourcesC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+		}}
+		incVersion := true
+		current, err := p.getOne(id)
+		switch {
+		case errors.IsNotFound(err):
+			ops = append(ops, newInsertResourceOps(stored)...)
+		case err != nil:
+			return nil, errors.Trace(err)
+		case current.StoragePath == stored.storagePath:
+			// The revision is already the current one.
+			return nil, jujutxn.ErrNoOperations
+		default:
+			ops = append(ops, newUpdateResourceOps(stored)...)
+			incVersion = !bytes.Equal(current.Fingerprint, doc.Fingerprint)
+		}
+		ops = append(ops, p.base.ServiceExistsOps(stored.ServiceID)...)
+		if incVersion {
+			ops = append(ops, p.base.IncCharmModifiedVersionOps(stored.ServiceID)...)
+		}
+		return ops, nil
+	}
+	if err := p.base.Run(buildTxn); err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	return stored.Resource, nil
+}
+
+// newResourceHistoryOps returns the operations that record the given
+// resource, which is about to become the service's current resource,
+// in the resource's history. If its content is the same as that of the
+// newest revision, that revision records the new blob instead of a new
+// revision being added. Revisions beyond resourceHistoryLimit are
+// dropped, oldest first. Blobs that are no longer referenced, including
+// that of the resource being replaced if it is not in the history, are
+// queued for removal.
+func (p ResourcePersistence) newResourceHistoryOps(stored storedResource) ([]txn.Op, error) {
+	docs, err := p.resourceDocs(stored.ID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var history []resourceDoc
+	historyPaths := make(map[string]bool)
+	unitPaths := make(map[string]bool)
+	for _, doc := range docs {
+		switch {
+		case doc.HistoryNumber != 0:
+			history = append(history, doc)
+			historyPaths[doc.StoragePath] = true
+		case doc.UnitID != "":
+			unitPaths[doc.StoragePath] = true
+		}
+	}
+	sort.Sort(resourceHistoryDocs(history))
+
+	current, err := p.getOne(stored.ID)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	var unusedPaths []string
+	if replacedPath := current.StoragePath; replacedPath != "" && !historyPaths[replacedPath] {
+		// The replaced blob was stored before history was kept.
+		unusedPaths = append(unusedPaths, replacedPath)
+	}
+
+	var ops []txn.Op
+	n := len(history)
+	if n > 0 && bytes.Equal(history[n-1].Fingerprint, stored.Fingerprint.Bytes()) {
+		newest := history[n-1]
+		ops = newUpdateResourceHistoryOps(stored, newest.HistoryNumber)
+		unusedPaths = append(unusedPaths, newest.StoragePath)
+	} else {
+		number := 1
+		if n > 0 {
+			number = history[n-1].HistoryNumber + 1
+		}
+		ops = newInsertResourceHistoryOps(stored, number)
+		if excess := n + 1 - resourceHistoryLimit; excess > 0 {
+			pruned := history[:excess]
+			ops = append(ops, newRemoveResourcesOps(pruned)...)
+			for _, doc := range pruned {
+				unusedPaths = append(unusedPaths, doc.StoragePath)
+			}
+		}
+	}
+
+	for _, path := range unusedPaths {
+		if path == stored.storagePath || unitPaths[path] {
+			// Blobs still in use by units are queued for removal
+			// once the units no longer use them.
+			continue
+		}
+		ops = append(ops, p.base.NewCleanupOp(CleanupKindResourceBlob, path))
+	}
+	return ops, nil
+}
+
+// newReplacedUnitResourceOps returns the operations that queue the
+// removal of the blob used by the given unit's resource, which is
+// about to be replaced, if nothing else refers to it.
+func (p ResourcePersistence) newReplacedUnitResourceOps(unitID string, stored storedResource) ([]txn.Op, error) {
+	docs, err := p.resourceDocs(stored.ID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unitDocID := unitResourceID(stored.ID, unitID)
+	var replacedPath string
+	inUse := make(map[string]bool)
+	for _, doc := range docs {
+		if doc.DocID == unitDocID {
+			replacedPath = doc.StoragePath
+		} else {
+			inUse[doc.StoragePath] = true
+		}
+	}
+	if replacedPath == "" || replacedPath == stored.storagePath || inUse[replacedPath] {
+		return nil, nil
+	}
+	return []txn.Op{p.base.NewCleanupOp(CleanupKindResourceBlob, replacedPath)}, nil
+}
+
 // NewRemoveUnitResourcesOps returns mgo transaction operations
 // that remove resource information specific to the unit from state.
 func (p ResourcePersistence) NewRemoveUnitResourcesOps(unitID string) ([]txn.Op, error) {
@@ -392,7 +603,14 @@ func (p ResourcePersistence) NewRemoveResourcesOps(serviceID string) ([]txn.Op,
 	}
 
 	ops := newRemoveResourcesOps(docs)
+	// The current resource and unit resources share their blob with
+	// a revision in the history, so each blob is only removed once.
+	removed := make(map[string]bool)
 	for _, doc := range docs {
+		if doc.StoragePath == "" || removed[doc.StoragePath] {
+			continue
+		}
+		removed[doc.StoragePath] = true
 		ops = append(ops, p.base.NewCleanupOp(CleanupKindResourceBlob, doc.StoragePath))
 	}
 	return ops, nil
@@ -96,6 +96,15 @@ func (staged StagedResource) Activate() error {
 				incOps := staged.base.IncCharmModifiedVersionOps(staged.stored.ServiceID)
 				ops = append(ops, incOps...)
 			}
+
+			// The blob is retained in the resource's history so that
+			// the service may later be rolled back to it.
+			p := NewResourcePersistence(staged.base)
+			historyOps, err := p.newResourceHistoryOps(staged.stored)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, historyOps...)
 		}
 		logger.Debugf("activate ops: %#v", ops)
 		return ops, nil
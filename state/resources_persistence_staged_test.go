@@ -4,6 +4,8 @@
 package state
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -117,15 +119,18 @@ func (s *StagedResourceSuite) TestUnstageOkay(c *gc.C) {
 
 func (s *StagedResourceSuite) TestActivateOkay(c *gc.C) {
 	staged, doc := s.newStagedResource(c, "a-service", "spam")
+	historyDoc := doc // a copy
+	historyDoc.DocID += "#history-1"
+	historyDoc.HistoryNumber = 1
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, nil, nil, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, nil, nil, nil, nil, ignoredErr)
 
 	err := staged.Activate()
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "Run", "ServiceExistsOps", "One", "IncCharmModifiedVersionOps", "RunTransaction")
+	s.stub.CheckCallNames(c, "Run", "ServiceExistsOps", "One", "IncCharmModifiedVersionOps", "All", "One", "RunTransaction")
 	s.stub.CheckCall(c, 3, "IncCharmModifiedVersionOps", "a-service")
-	s.stub.CheckCall(c, 4, "RunTransaction", []txn.Op{{
+	s.stub.CheckCall(c, 6, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-service/spam",
 		Assert: txn.DocMissing,
@@ -138,20 +143,101 @@ func (s *StagedResourceSuite) TestActivateOkay(c *gc.C) {
 		C:      "resources",
 		Id:     "resource#a-service/spam#staged",
 		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#history-1",
+		Assert: txn.DocMissing,
+		Insert: &historyDoc,
 	}})
 }
 
 func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
 	staged, doc := s.newStagedResource(c, "a-service", "spam")
+	historyDoc := doc // a copy
+	historyDoc.DocID += "#history-1"
+	historyDoc.HistoryNumber = 1
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, nil, nil, txn.ErrAborted, nil, nil, nil, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, nil, nil, nil, txn.ErrAborted, nil, nil, nil, nil, nil, nil, ignoredErr)
 
 	err := staged.Activate()
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "Run", "ServiceExistsOps", "One", "IncCharmModifiedVersionOps", "RunTransaction", "ServiceExistsOps", "One", "IncCharmModifiedVersionOps", "RunTransaction")
+	s.stub.CheckCallNames(c,
+		"Run", "ServiceExistsOps", "One", "IncCharmModifiedVersionOps", "All", "One", "RunTransaction",
+		"ServiceExistsOps", "One", "IncCharmModifiedVersionOps", "All", "One", "RunTransaction",
+	)
 	s.stub.CheckCall(c, 3, "IncCharmModifiedVersionOps", "a-service")
-	s.stub.CheckCall(c, 4, "RunTransaction", []txn.Op{{
+	s.stub.CheckCall(c, 6, "RunTransaction", []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-service/spam",
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}, {
+		C:      "service",
+		Id:     "a-service",
+		Assert: txn.DocExists,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#staged",
+		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#history-1",
+		Assert: txn.DocMissing,
+		Insert: &historyDoc,
+	}})
+	s.stub.CheckCall(c, 9, "IncCharmModifiedVersionOps", "a-service")
+	s.stub.CheckCall(c, 12, "RunTransaction", []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-service/spam",
+		Assert: txn.DocExists,
+		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam",
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}, {
+		C:      "service",
+		Id:     "a-service",
+		Assert: txn.DocExists,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#staged",
+		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#history-1",
+		Assert: txn.DocMissing,
+		Insert: &historyDoc,
+	}})
+}
+
+func (s *StagedResourceSuite) TestActivatePrunesHistory(c *gc.C) {
+	staged, doc := s.newStagedResource(c, "a-service", "spam")
+	var history []resourceDoc
+	for i := 1; i <= 5; i++ {
+		old := doc // a copy
+		old.DocID += fmt.Sprintf("#history-%d", i)
+		old.HistoryNumber = i
+		old.StoragePath += fmt.Sprintf("-old%d", i)
+		old.Fingerprint = []byte(fmt.Sprintf("old%d", i))
+		history = append(history, old)
+	}
+	s.base.ReturnAll = history
+	s.base.ReturnNewCleanupOp = &txn.Op{C: "cleanups"}
+	historyDoc := doc // a copy
+	historyDoc.DocID += "#history-6"
+	historyDoc.HistoryNumber = 6
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, nil, nil, nil, nil, nil, nil, ignoredErr)
+
+	err := staged.Activate()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "ServiceExistsOps", "One", "IncCharmModifiedVersionOps", "All", "One", "NewCleanupOp", "RunTransaction")
+	s.stub.CheckCall(c, 6, "NewCleanupOp", CleanupKindResourceBlob, "service-a-service/resources/spam-old1")
+	s.stub.CheckCall(c, 7, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-service/spam",
 		Assert: txn.DocMissing,
@@ -164,14 +250,96 @@ func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
 		C:      "resources",
 		Id:     "resource#a-service/spam#staged",
 		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#history-6",
+		Assert: txn.DocMissing,
+		Insert: &historyDoc,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#history-1",
+		Remove: true,
+	}, {
+		C: "cleanups",
 	}})
-	s.stub.CheckCall(c, 7, "IncCharmModifiedVersionOps", "a-service")
-	s.stub.CheckCall(c, 8, "RunTransaction", []txn.Op{{
+}
+
+func (s *StagedResourceSuite) TestActivateUnchangedContent(c *gc.C) {
+	staged, doc := s.newStagedResource(c, "a-service", "spam")
+	old := doc // a copy
+	old.DocID += "#history-1"
+	old.HistoryNumber = 1
+	old.StoragePath += "-old1"
+	s.base.ReturnAll = []resourceDoc{old}
+	s.base.ReturnNewCleanupOp = &txn.Op{C: "cleanups"}
+	historyDoc := doc // a copy
+	historyDoc.DocID += "#history-1"
+	historyDoc.HistoryNumber = 1
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, nil, nil, nil, nil, nil, nil, ignoredErr)
+
+	err := staged.Activate()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "ServiceExistsOps", "One", "IncCharmModifiedVersionOps", "All", "One", "NewCleanupOp", "RunTransaction")
+	s.stub.CheckCall(c, 6, "NewCleanupOp", CleanupKindResourceBlob, "service-a-service/resources/spam-old1")
+	s.stub.CheckCall(c, 7, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-service/spam",
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}, {
+		C:      "service",
+		Id:     "a-service",
+		Assert: txn.DocExists,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#staged",
+		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#history-1",
 		Assert: txn.DocExists,
 		Remove: true,
 	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#history-1",
+		Assert: txn.DocMissing,
+		Insert: &historyDoc,
+	}, {
+		C: "cleanups",
+	}})
+}
+
+func (s *StagedResourceSuite) TestActivatePrunesHistoryInUseByUnit(c *gc.C) {
+	staged, doc := s.newStagedResource(c, "a-service", "spam")
+	var docs []resourceDoc
+	for i := 1; i <= 5; i++ {
+		old := doc // a copy
+		old.DocID += fmt.Sprintf("#history-%d", i)
+		old.HistoryNumber = i
+		old.StoragePath += fmt.Sprintf("-old%d", i)
+		old.Fingerprint = []byte(fmt.Sprintf("old%d", i))
+		docs = append(docs, old)
+	}
+	unitDoc := docs[0] // a copy
+	unitDoc.DocID = "resource#a-service/spam#unit-a-service/0"
+	unitDoc.UnitID = "a-service/0"
+	unitDoc.HistoryNumber = 0
+	s.base.ReturnAll = append(docs, unitDoc)
+	historyDoc := doc // a copy
+	historyDoc.DocID += "#history-6"
+	historyDoc.HistoryNumber = 6
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, nil, nil, nil, nil, nil, ignoredErr)
+
+	err := staged.Activate()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The pruned blob is still in use by the unit, so it is not
+	// queued for removal.
+	s.stub.CheckCallNames(c, "Run", "ServiceExistsOps", "One", "IncCharmModifiedVersionOps", "All", "One", "RunTransaction")
+	s.stub.CheckCall(c, 6, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-service/spam",
 		Assert: txn.DocMissing,
@@ -184,5 +352,14 @@ func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
 		C:      "resources",
 		Id:     "resource#a-service/spam#staged",
 		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#history-6",
+		Assert: txn.DocMissing,
+		Insert: &historyDoc,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#history-1",
+		Remove: true,
 	}})
 }
@@ -378,12 +378,12 @@ func (s *ResourcePersistenceSuite) TestSetUnitResourceExists(c *gc.C) {
 	s.base.ReturnOne = doc
 	p := NewResourcePersistence(s.base)
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, nil, txn.ErrAborted, nil, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, txn.ErrAborted, nil, nil, nil, ignoredErr)
 
 	err := p.SetUnitResource("a-service/0", res)
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "One", "Run", "ServiceExistsOps", "RunTransaction", "ServiceExistsOps", "RunTransaction")
+	s.stub.CheckCallNames(c, "One", "Run", "ServiceExistsOps", "RunTransaction", "All", "ServiceExistsOps", "RunTransaction")
 	s.stub.CheckCall(c, 3, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-service/spam#unit-a-service/0",
@@ -394,7 +394,42 @@ func (s *ResourcePersistenceSuite) TestSetUnitResourceExists(c *gc.C) {
 		Id:     "a-service",
 		Assert: txn.DocExists,
 	}})
-	s.stub.CheckCall(c, 5, "RunTransaction", []txn.Op{{
+	s.stub.CheckCall(c, 6, "RunTransaction", []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-service/spam#unit-a-service/0",
+		Assert: txn.DocExists,
+		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam#unit-a-service/0",
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}, {
+		C:      "service",
+		Id:     "a-service",
+		Assert: txn.DocExists,
+	}})
+}
+
+func (s *ResourcePersistenceSuite) TestSetUnitResourceReplacesUnusedBlob(c *gc.C) {
+	res, doc := newPersistenceUnitResource(c, "a-service", "a-service/0", "spam")
+	s.base.ReturnOne = doc
+	old := doc // a copy
+	old.StoragePath += "-old"
+	s.base.ReturnAll = []resourceDoc{old}
+	s.base.ReturnNewCleanupOp = &txn.Op{C: "cleanups"}
+	p := NewResourcePersistence(s.base)
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, nil, txn.ErrAborted, nil, nil, nil, nil, ignoredErr)
+
+	err := p.SetUnitResource("a-service/0", res)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The blob the unit used before is no longer referenced by the
+	// resource's history, so it is queued for removal.
+	s.stub.CheckCallNames(c, "One", "Run", "ServiceExistsOps", "RunTransaction", "All", "NewCleanupOp", "ServiceExistsOps", "RunTransaction")
+	s.stub.CheckCall(c, 5, "NewCleanupOp", CleanupKindResourceBlob, old.StoragePath)
+	s.stub.CheckCall(c, 7, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-service/spam#unit-a-service/0",
 		Assert: txn.DocExists,
@@ -404,6 +439,8 @@ func (s *ResourcePersistenceSuite) TestSetUnitResourceExists(c *gc.C) {
 		Id:     "resource#a-service/spam#unit-a-service/0",
 		Assert: txn.DocMissing,
 		Insert: &doc,
+	}, {
+		C: "cleanups",
 	}, {
 		C:      "service",
 		Id:     "a-service",
@@ -481,7 +518,11 @@ func (s *ResourcePersistenceSuite) TestNewResourcePendingResourceOpsExists(c *gc
 	res := ops[4].Insert.(*resourceDoc)
 	res.LastPolled = res.LastPolled.Round(time.Second)
 
-	s.stub.CheckCallNames(c, "One", "One")
+	historyDoc := expected
+	historyDoc.DocID = "resource#a-service/spam#history-1"
+	historyDoc.HistoryNumber = 1
+
+	s.stub.CheckCallNames(c, "One", "One", "All", "One")
 	s.stub.CheckCall(c, 0, "One", "resources", "resource#a-service/spam#pending-some-unique-ID-001", &doc)
 	c.Check(ops, jc.DeepEquals, []txn.Op{
 		{
@@ -512,6 +553,12 @@ func (s *ResourcePersistenceSuite) TestNewResourcePendingResourceOpsExists(c *gc
 			Assert: txn.DocMissing,
 			Insert: &csresourceDoc,
 		},
+		{
+			C:      "resources",
+			Id:     historyDoc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &historyDoc,
+		},
 	})
 }
 
@@ -531,9 +578,13 @@ func (s *ResourcePersistenceSuite) TestNewResourcePendingResourceOpsNotFound(c *
 	ops, err := p.NewResolvePendingResourceOps(stored.ID, stored.PendingID)
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "One", "One")
+	s.stub.CheckCallNames(c, "One", "One", "All", "One")
 	s.stub.CheckCall(c, 0, "One", "resources", "resource#a-service/spam#pending-some-unique-ID-001", &doc)
 
+	historyDoc := expected
+	historyDoc.DocID = "resource#a-service/spam#history-1"
+	historyDoc.HistoryNumber = 1
+
 	csresourceDoc := expected
 	csresourceDoc.DocID = "resource#a-service/spam#charmstore"
 	csresourceDoc.Username = ""
@@ -562,9 +613,138 @@ func (s *ResourcePersistenceSuite) TestNewResourcePendingResourceOpsNotFound(c *
 			Assert: txn.DocMissing,
 			Insert: &csresourceDoc,
 		},
+		{
+			C:      "resources",
+			Id:     historyDoc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &historyDoc,
+		},
 	})
 }
 
+func (s *ResourcePersistenceSuite) TestListResourceHistory(c *gc.C) {
+	expected, docs := newPersistenceResources(c, "a-service", "spam")
+	current := docs[0]
+	old := current // a copy
+	old.DocID += "#history-1"
+	old.HistoryNumber = 1
+	old.StoragePath += "-old"
+	latest := current // a copy
+	latest.DocID += "#history-2"
+	latest.HistoryNumber = 2
+	s.base.ReturnAll = append(docs, latest, old)
+	p := NewResourcePersistence(s.base)
+
+	entries, err := p.ListResourceHistory("a-service")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "All")
+	c.Check(entries, jc.DeepEquals, []resource.HistoryEntry{{
+		Resource: expected.Resources[0],
+		Number:   1,
+	}, {
+		Resource: expected.Resources[0],
+		Number:   2,
+		Current:  true,
+	}})
+}
+
+func (s *ResourcePersistenceSuite) TestListResourcesIgnoreHistory(c *gc.C) {
+	expected, docs := newPersistenceResources(c, "a-service", "spam")
+	historyDoc := docs[0] // a copy
+	historyDoc.DocID += "#history-1"
+	historyDoc.HistoryNumber = 1
+	s.base.ReturnAll = append(docs, historyDoc)
+	p := NewResourcePersistence(s.base)
+
+	resources, err := p.ListResources("a-service")
+	c.Assert(err, jc.ErrorIsNil)
+
+	checkResources(c, resources, expected)
+}
+
+func (s *ResourcePersistenceSuite) TestRollbackResource(c *gc.C) {
+	stored, doc := newPersistenceResource(c, "a-service", "spam")
+	historyDoc := doc // a copy
+	historyDoc.DocID += "#history-1"
+	historyDoc.HistoryNumber = 1
+	s.base.ReturnOne = historyDoc
+	s.base.ReturnIncCharmModifiedVersionOps = []txn.Op{{
+		C:      "service",
+		Id:     "a-service",
+		Update: bson.D{{"$inc", bson.D{{"charmmodifiedversion", 1}}}},
+	}}
+	notFound := errors.NewNotFound(nil, "")
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, notFound, nil, nil, nil, ignoredErr)
+	p := NewResourcePersistence(s.base)
+
+	res, err := p.RollbackResource(stored.ID, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(res, jc.DeepEquals, stored.Resource)
+	s.stub.CheckCallNames(c, "One", "Run", "One", "ServiceExistsOps", "IncCharmModifiedVersionOps", "RunTransaction")
+	s.stub.CheckCall(c, 0, "One", "resources", "resource#a-service/spam#history-1", &historyDoc)
+	s.stub.CheckCall(c, 5, "RunTransaction", []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-service/spam#history-1",
+		Assert: txn.DocExists,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-service/spam",
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}, {
+		C:      "service",
+		Id:     "a-service",
+		Assert: txn.DocExists,
+	}, {
+		C:      "service",
+		Id:     "a-service",
+		Update: bson.D{{"$inc", bson.D{{"charmmodifiedversion", 1}}}},
+	}})
+}
+
+func (s *ResourcePersistenceSuite) TestRollbackResourcePruned(c *gc.C) {
+	stored, doc := newPersistenceResource(c, "a-service", "spam")
+	historyDoc := doc // a copy
+	historyDoc.DocID += "#history-1"
+	historyDoc.HistoryNumber = 1
+	s.base.ReturnOne = historyDoc
+	notFound := errors.NewNotFound(nil, "")
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, notFound, nil, nil, txn.ErrAborted, notFound, ignoredErr)
+	p := NewResourcePersistence(s.base)
+
+	// The revision is pruned from the history between reading it
+	// and running the transaction.
+	_, err := p.RollbackResource(stored.ID, 1)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(err, gc.ErrorMatches, `revision 1 of resource "a-service/spam" not found`)
+
+	s.stub.CheckCallNames(c, "One", "Run", "One", "ServiceExistsOps", "IncCharmModifiedVersionOps", "RunTransaction", "One")
+}
+
+func (s *ResourcePersistenceSuite) TestRollbackResourceAlreadyCurrent(c *gc.C) {
+	stored, doc := newPersistenceResource(c, "a-service", "spam")
+	s.base.ReturnOne = doc
+	p := NewResourcePersistence(s.base)
+
+	_, err := p.RollbackResource(stored.ID, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "One", "Run", "One")
+}
+
+func (s *ResourcePersistenceSuite) TestRollbackResourceNotFound(c *gc.C) {
+	p := NewResourcePersistence(s.base)
+
+	_, err := p.RollbackResource("a-service/spam", 3)
+
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(err, gc.ErrorMatches, `revision 3 of resource "a-service/spam" not found`)
+}
+
 func newPersistenceUnitResources(c *gc.C, serviceID, unitID string, resources []resource.Resource) ([]resource.Resource, []resourceDoc) {
 	var unitResources []resource.Resource
 	var docs []resourceDoc
@@ -264,6 +264,7 @@ func (s *Service) removeOps(asserts bson.D) []txn.Op {
 			Remove: true,
 		},
 		removeEndpointBindingsOp(s.globalKey()),
+		removeContainerProfileOp(s.globalKey()),
 		removeStorageConstraintsOp(s.globalKey()),
 		removeConstraintsOp(s.st, s.globalKey()),
 		annotationRemoveOp(s.st, s.globalKey()),
@@ -1099,6 +1100,7 @@ func (s *Service) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 		removeStatusOp(s.st, u.globalKey()),
 		removeConstraintsOp(s.st, u.globalAgentKey()),
 		annotationRemoveOp(s.st, u.globalKey()),
+		removeCharmStateOp(u.charmStateKey()),
 		s.st.newCleanupOp(cleanupRemovedUnit, u.doc.Name),
 	)
 	ops = append(ops, portsOps...)
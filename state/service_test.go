@@ -1930,6 +1930,16 @@ func (s *ServiceSuite) TestWatchRelations(c *gc.C) {
 	wc.AssertChange(rel1.String())
 	wc.AssertNoChange()
 
+	// Suspend and resume a relation; check changes.
+	err = rel1.SetSuspended(true)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(rel1.String())
+	wc.AssertNoChange()
+	err = rel1.SetSuspended(false)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(rel1.String())
+	wc.AssertNoChange()
+
 	// Destroy a relation; check change.
 	err = rel0.Destroy()
 	c.Assert(err, jc.ErrorIsNil)
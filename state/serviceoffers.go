@@ -0,0 +1,242 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// serviceOfferDoc is the mongodb representation of a service endpoint
+// offered to other models hosted by the controller. Offers are keyed
+// on their URL.
+type serviceOfferDoc struct {
+	DocID           string              `bson:"_id"`
+	URL             string              `bson:"url"`
+	OfferName       string              `bson:"offer-name"`
+	SourceModelUUID string              `bson:"source-model-uuid"`
+	ServiceName     string              `bson:"service-name"`
+	Endpoints       []remoteEndpointDoc `bson:"endpoints"`
+	Description     string              `bson:"description,omitempty"`
+	Owner           string              `bson:"owner"`
+}
+
+// ServiceOffer represents some of a service's endpoints made available
+// for relations with services in other models.
+type ServiceOffer struct {
+	st  *State
+	doc serviceOfferDoc
+}
+
+// URL returns the URL by which other models refer to the offer.
+func (o *ServiceOffer) URL() string {
+	return o.doc.URL
+}
+
+// OfferName returns the name under which the service is offered.
+func (o *ServiceOffer) OfferName() string {
+	return o.doc.OfferName
+}
+
+// SourceModelUUID returns the UUID of the model hosting the offered
+// service.
+func (o *ServiceOffer) SourceModelUUID() string {
+	return o.doc.SourceModelUUID
+}
+
+// ServiceName returns the name of the offered service.
+func (o *ServiceOffer) ServiceName() string {
+	return o.doc.ServiceName
+}
+
+// Endpoints returns the offered endpoints.
+func (o *ServiceOffer) Endpoints() []charm.Relation {
+	eps := make([]charm.Relation, len(o.doc.Endpoints))
+	for i, ep := range o.doc.Endpoints {
+		eps[i] = charm.Relation{
+			Name:      ep.Name,
+			Role:      ep.Role,
+			Interface: ep.Interface,
+			Limit:     ep.Limit,
+			Scope:     ep.Scope,
+		}
+	}
+	return eps
+}
+
+// Description returns the offer's description.
+func (o *ServiceOffer) Description() string {
+	return o.doc.Description
+}
+
+// Owner returns the user that made the offer.
+func (o *ServiceOffer) Owner() names.UserTag {
+	return names.NewUserTag(o.doc.Owner)
+}
+
+// ServiceOfferArgs holds the parameters for offering a service.
+type ServiceOfferArgs struct {
+	// OfferName is the name under which the service is offered. If
+	// empty, the service name is used.
+	OfferName string
+
+	// ServiceName is the name of the service to offer.
+	ServiceName string
+
+	// Endpoints holds the names of the endpoints to offer. If empty,
+	// all of the service's non-peer endpoints are offered.
+	Endpoints []string
+
+	// Description describes the offer to prospective consumers.
+	Description string
+
+	// Owner is the user making the offer.
+	Owner names.UserTag
+}
+
+// serviceOfferURL returns the URL for an offer made from the given model.
+func serviceOfferURL(model *Model, offerName string) string {
+	return fmt.Sprintf("local:/u/%s/%s/%s", model.Owner().Canonical(), model.Name(), offerName)
+}
+
+// AddServiceOffer offers some of a service's endpoints to other models
+// hosted by the controller.
+func (st *State) AddServiceOffer(args ServiceOfferArgs) (_ *ServiceOffer, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot offer service %q", args.ServiceName)
+	if args.OfferName == "" {
+		args.OfferName = args.ServiceName
+	}
+	if !names.IsValidService(args.OfferName) {
+		return nil, errors.NotValidf("offer name %q", args.OfferName)
+	}
+	svc, err := st.Service(args.ServiceName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	eps, err := svc.Endpoints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	available := make(map[string]Endpoint)
+	var all []string
+	for _, ep := range eps {
+		if ep.Role == charm.RolePeer || ep.IsImplicit() {
+			continue
+		}
+		available[ep.Name] = ep
+		all = append(all, ep.Name)
+	}
+	endpoints := args.Endpoints
+	if len(endpoints) == 0 {
+		endpoints = all
+	}
+	var offered []remoteEndpointDoc
+	for _, name := range endpoints {
+		ep, ok := available[name]
+		if !ok {
+			return nil, errors.NotFoundf("endpoint %q", name)
+		}
+		if ep.Scope == charm.ScopeContainer {
+			return nil, errors.Errorf("cannot offer container-scoped endpoint %q", name)
+		}
+		offered = append(offered, remoteEndpointDoc{
+			Name:      ep.Name,
+			Role:      ep.Role,
+			Interface: ep.Interface,
+			Limit:     ep.Limit,
+			Scope:     ep.Scope,
+		})
+	}
+	if len(endpoints) == 0 {
+		return nil, errors.Errorf("service has no endpoints to offer")
+	}
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	url := serviceOfferURL(model, args.OfferName)
+	doc := serviceOfferDoc{
+		DocID:           url,
+		URL:             url,
+		OfferName:       args.OfferName,
+		SourceModelUUID: st.ModelUUID(),
+		ServiceName:     args.ServiceName,
+		Endpoints:       offered,
+		Description:     args.Description,
+		Owner:           args.Owner.Canonical(),
+	}
+	ops := []txn.Op{{
+		C:      servicesC,
+		Id:     svc.doc.DocID,
+		Assert: isAliveDoc,
+	}, {
+		C:      serviceOffersC,
+		Id:     url,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		if _, err := st.ServiceOffer(url); err == nil {
+			return nil, errors.AlreadyExistsf("offer %q", url)
+		}
+		return nil, errors.Errorf("service is no longer alive")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ServiceOffer{st: st, doc: doc}, nil
+}
+
+// ServiceOffer returns the offer with the given URL. Offers made from
+// any model hosted by the controller may be retrieved.
+func (st *State) ServiceOffer(url string) (*ServiceOffer, error) {
+	serviceOffers, closer := st.getCollection(serviceOffersC)
+	defer closer()
+
+	var doc serviceOfferDoc
+	if err := serviceOffers.FindId(url).One(&doc); err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("service offer %q", url)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get service offer %q", url)
+	}
+	return &ServiceOffer{st: st, doc: doc}, nil
+}
+
+// ServiceOffers returns all offers made from models hosted by the
+// controller, ordered by URL.
+func (st *State) ServiceOffers() ([]*ServiceOffer, error) {
+	serviceOffers, closer := st.getCollection(serviceOffersC)
+	defer closer()
+
+	var docs []serviceOfferDoc
+	if err := serviceOffers.Find(nil).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get service offers")
+	}
+	offers := make([]*ServiceOffer, len(docs))
+	for i, doc := range docs {
+		offers[i] = &ServiceOffer{st: st, doc: doc}
+	}
+	return offers, nil
+}
+
+// RemoveServiceOffer withdraws the offer with the given URL. Existing
+// relations made through the offer are not affected.
+func (st *State) RemoveServiceOffer(url string) error {
+	ops := []txn.Op{{
+		C:      serviceOffersC,
+		Id:     url,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("service offer %q", url)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove service offer %q", url)
+	}
+	return nil
+}
@@ -39,6 +39,7 @@ import (
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state/cloudimagemetadata"
 	statelease "github.com/juju/juju/state/lease"
+	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/state/presence"
 	"github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/status"
@@ -96,6 +97,9 @@ type State struct {
 	allModelManager        *storeManager
 	allModelWatcherBacking Backing
 
+	// certsCache holds the controller certificates reported by CACert.
+	certsCache controllerCertificatesCache
+
 	// TODO(anastasiamac 2015-07-16) As state gets broken up, remove this.
 	CloudImageMetadataStorage cloudimagemetadata.Storage
 }
@@ -374,22 +378,43 @@ func (st *State) MongoSession() *mgo.Session {
 }
 
 func (st *State) Watch() *Multiwatcher {
+	return NewMultiwatcher(st.modelStoreManager())
+}
+
+// WatchFiltered is like Watch, but the returned watcher only reports
+// changes to the model that match the given filter.
+func (st *State) WatchFiltered(filter multiwatcher.Filter) *Multiwatcher {
+	return NewFilteredMultiwatcher(st.modelStoreManager(), filter)
+}
+
+func (st *State) modelStoreManager() *storeManager {
 	st.mu.Lock()
+	defer st.mu.Unlock()
 	if st.allManager == nil {
 		st.allManager = newStoreManager(newAllWatcherStateBacking(st))
 	}
-	st.mu.Unlock()
-	return NewMultiwatcher(st.allManager)
+	return st.allManager
 }
 
 func (st *State) WatchAllModels() *Multiwatcher {
+	return NewMultiwatcher(st.allModelStoreManager())
+}
+
+// WatchAllModelsFiltered is like WatchAllModels, but the returned
+// watcher only reports changes that match the given filter, which
+// may restrict them to a subset of the models in the controller.
+func (st *State) WatchAllModelsFiltered(filter multiwatcher.Filter) *Multiwatcher {
+	return NewFilteredMultiwatcher(st.allModelStoreManager(), filter)
+}
+
+func (st *State) allModelStoreManager() *storeManager {
 	st.mu.Lock()
+	defer st.mu.Unlock()
 	if st.allModelManager == nil {
 		st.allModelWatcherBacking = NewAllModelWatcherStateBacking(st)
 		st.allModelManager = newStoreManager(st.allModelWatcherBacking)
 	}
-	st.mu.Unlock()
-	return NewMultiwatcher(st.allModelManager)
+	return st.allModelManager
 }
 
 func (st *State) ModelConfig() (*config.Config, error) {
@@ -412,6 +437,18 @@ func checkModelConfig(cfg *config.Config) error {
 	return nil
 }
 
+// checkHostedModelConfig returns an error if the configuration of a
+// hosted model sets any attribute only used by the controller model.
+func checkHostedModelConfig(cfg *config.Config) error {
+	attrs := cfg.AllAttrs()
+	for _, key := range config.ControllerOnlyAttributes {
+		if _, ok := attrs[key]; ok {
+			return errors.Errorf("%s can only be set in the controller model", key)
+		}
+	}
+	return nil
+}
+
 // versionInconsistentError indicates one or more agents have a
 // different version from the current one (even empty, when not yet
 // set).
@@ -543,7 +580,12 @@ func (st *State) SetModelAgentVersion(newVersion version.Number) (err error) {
 				},
 			},
 		}
-		return ops, nil
+		// Any staged upgrade is superseded by the new model agent version.
+		clearOps, err := st.clearTargetAgentVersionOps()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, clearOps...), nil
 	}
 	if err = st.run(buildTxn); err == jujutxn.ErrExcessiveContention {
 		// Although there is a small chance of a race here, try to
@@ -558,6 +600,30 @@ func (st *State) SetModelAgentVersion(newVersion version.Number) (err error) {
 	return errors.Trace(err)
 }
 
+// clearTargetAgentVersionOps returns the operations required to clear
+// the target agent version of every machine that has one.
+func (st *State) clearTargetAgentVersionOps() ([]txn.Op, error) {
+	machines, closer := st.getCollection(machinesC)
+	defer closer()
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	sel := bson.D{{"targetagentversion", bson.D{{"$exists", true}}}}
+	if err := machines.Find(sel).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      machinesC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$unset", bson.D{{"targetagentversion", nil}}}},
+		}
+	}
+	return ops, nil
+}
+
 func (st *State) buildAndValidateModelConfig(updateAttrs map[string]interface{}, removeAttrs []string, oldConfig *config.Config) (validCfg *config.Config, err error) {
 	newConfig, err := oldConfig.Apply(updateAttrs)
 	if err != nil {
@@ -572,6 +638,11 @@ func (st *State) buildAndValidateModelConfig(updateAttrs map[string]interface{},
 	if err := checkModelConfig(newConfig); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if !st.IsController() {
+		if err := checkHostedModelConfig(newConfig); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	return st.validate(newConfig, oldConfig)
 }
 
@@ -1216,6 +1287,11 @@ func (st *State) AddService(args AddServiceArgs) (service *Service, err error) {
 	} else if exists {
 		return nil, errors.Errorf("service already exists")
 	}
+	if remote, err := isRemoteService(st, args.Name); err != nil {
+		return nil, errors.Trace(err)
+	} else if remote {
+		return nil, errors.Errorf("remote service with same name already exists")
+	}
 	if err := checkModelActive(st); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -1358,6 +1434,11 @@ func (st *State) AddService(args AddServiceArgs) (service *Service, err error) {
 		[]txn.Op{
 			assertModelActiveOp(st.ModelUUID()),
 			endpointBindingsOp,
+			{
+				C:      remoteServicesC,
+				Id:     serviceID,
+				Assert: txn.DocMissing,
+			},
 		},
 		addServiceOps(st, addServiceOpsArgs{
 			serviceDoc:       svcDoc,
@@ -1898,22 +1979,21 @@ func (st *State) endpoints(name string, filter func(ep Endpoint) bool) ([]Endpoi
 	} else {
 		return nil, errors.Errorf("invalid endpoint %q", name)
 	}
-	svc, err := st.Service(svcName)
+	eps, err := st.serviceEndpoints(svcName)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	eps := []Endpoint{}
 	if relName != "" {
-		ep, err := svc.Endpoint(relName)
-		if err != nil {
-			return nil, errors.Trace(err)
+		var named []Endpoint
+		for _, ep := range eps {
+			if ep.Name == relName {
+				named = append(named, ep)
+			}
 		}
-		eps = append(eps, ep)
-	} else {
-		eps, err = svc.Endpoints()
-		if err != nil {
-			return nil, errors.Trace(err)
+		if len(named) == 0 {
+			return nil, errors.Errorf("service %q has no %q relation", svcName, relName)
 		}
+		eps = named
 	}
 	final := []Endpoint{}
 	for _, ep := range eps {
@@ -1924,6 +2004,24 @@ func (st *State) endpoints(name string, filter func(ep Endpoint) bool) ([]Endpoi
 	return final, nil
 }
 
+// serviceEndpoints returns the endpoints of the named service, which
+// may be a remote service.
+func (st *State) serviceEndpoints(name string) ([]Endpoint, error) {
+	svc, err := st.Service(name)
+	if err == nil {
+		return svc.Endpoints()
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	remote, err := st.RemoteService(name)
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("service %q", name)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return remote.Endpoints(), nil
+}
+
 // AddRelation creates a new relation with the given endpoints.
 func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 	key := relationKey(eps)
@@ -1968,7 +2066,12 @@ func (st *State) AddRelation(eps ...Endpoint) (r *Relation, err error) {
 		for _, ep := range eps {
 			svc, err := st.Service(ep.ServiceName)
 			if errors.IsNotFound(err) {
-				return nil, errors.Errorf("service %q does not exist", ep.ServiceName)
+				remoteOps, err := st.addRemoteRelationOps(ep)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, remoteOps...)
+				continue
 			} else if err != nil {
 				return nil, errors.Trace(err)
 			} else if svc.doc.Life != Alive {
@@ -121,6 +121,115 @@ func (s *LeadershipSuite) TestCheck(c *gc.C) {
 	c.Check(ops2, gc.IsNil)
 }
 
+func (s *LeadershipSuite) addUnits(c *gc.C) (*state.Unit, *state.Unit) {
+	service := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit0, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	unit1, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	return unit0, unit1
+}
+
+func (s *LeadershipSuite) TestTransferLeadership(c *gc.C) {
+	unit0, unit1 := s.addUnits(c)
+	err := s.claimer.ClaimLeadership("wordpress", unit0.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.TransferLeadership(unit1.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The current leader keeps leadership, but cannot extend it; nor
+	// can the successor claim it yet.
+	err = s.checker.LeadershipCheck("wordpress", unit0.Name()).Check(nil)
+	c.Check(err, jc.ErrorIsNil)
+	err = s.claimer.ClaimLeadership("wordpress", unit0.Name(), time.Minute)
+	c.Check(err, gc.Equals, leadership.ErrClaimDenied)
+	err = s.claimer.ClaimLeadership("wordpress", unit1.Name(), time.Minute)
+	c.Check(err, gc.Equals, leadership.ErrClaimDenied)
+
+	// Once the lease expires, it's handed to the successor.
+	s.clock.Advance(time.Hour)
+	token := s.checker.LeadershipCheck("wordpress", unit1.Name())
+	for a := coretesting.LongAttempt.Start(); ; {
+		err := token.Check(nil)
+		if err == nil {
+			break
+		}
+		if !a.Next() {
+			c.Fatalf("leadership never transferred: %v", err)
+		}
+	}
+	err = s.claimer.ClaimLeadership("wordpress", unit1.Name(), time.Minute)
+	c.Check(err, jc.ErrorIsNil)
+	err = s.claimer.ClaimLeadership("wordpress", unit0.Name(), time.Minute)
+	c.Check(err, gc.Equals, leadership.ErrClaimDenied)
+}
+
+func (s *LeadershipSuite) TestTransferLeadershipNoLeader(c *gc.C) {
+	_, unit1 := s.addUnits(c)
+	err := s.State.TransferLeadership(unit1.Name())
+	c.Check(err, gc.ErrorMatches, `cannot transfer leadership to "wordpress/1": service "wordpress" has no leader`)
+}
+
+func (s *LeadershipSuite) TestTransferLeadershipAlreadyLeader(c *gc.C) {
+	unit0, _ := s.addUnits(c)
+	err := s.claimer.ClaimLeadership("wordpress", unit0.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.TransferLeadership(unit0.Name())
+	c.Check(err, gc.ErrorMatches, `cannot transfer leadership to "wordpress/0": lease already held by "wordpress/0"`)
+}
+
+func (s *LeadershipSuite) TestTransferLeadershipUnitNotFound(c *gc.C) {
+	err := s.State.TransferLeadership("wordpress/0")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *LeadershipSuite) TestPinLeadership(c *gc.C) {
+	unit0, unit1 := s.addUnits(c)
+	err := s.claimer.ClaimLeadership("wordpress", unit0.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.PinLeadership("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The leader keeps leadership long after its lease would have expired.
+	s.clock.Advance(time.Hour)
+	err = s.claimer.ClaimLeadership("wordpress", unit1.Name(), time.Minute)
+	c.Check(err, gc.Equals, leadership.ErrClaimDenied)
+	err = s.checker.LeadershipCheck("wordpress", unit0.Name()).Check(nil)
+	c.Check(err, jc.ErrorIsNil)
+
+	// Leadership cannot be transferred while pinned.
+	err = s.State.TransferLeadership(unit1.Name())
+	c.Check(err, gc.ErrorMatches, `cannot transfer leadership to "wordpress/1": leadership of service "wordpress" is pinned`)
+
+	// Once unpinned, the lease can expire and be claimed by another unit.
+	err = s.State.UnpinLeadership("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock.Advance(time.Hour)
+	for a := coretesting.LongAttempt.Start(); ; {
+		err := s.claimer.ClaimLeadership("wordpress", unit1.Name(), time.Minute)
+		if err == nil {
+			break
+		}
+		c.Assert(err, gc.Equals, leadership.ErrClaimDenied)
+		if !a.Next() {
+			c.Fatalf("leadership never released")
+		}
+	}
+}
+
+func (s *LeadershipSuite) TestPinLeadershipNoLeader(c *gc.C) {
+	s.addUnits(c)
+	err := s.State.PinLeadership("wordpress")
+	c.Check(err, gc.ErrorMatches, `cannot pin leadership: service "wordpress" has no leader`)
+}
+
+func (s *LeadershipSuite) TestPinLeadershipServiceNotFound(c *gc.C) {
+	err := s.State.PinLeadership("wordpress")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *LeadershipSuite) TestHackLeadershipUnblocksClaimer(c *gc.C) {
 	err := s.claimer.ClaimLeadership("blah", "blah/0", time.Minute)
 	c.Assert(err, jc.ErrorIsNil)
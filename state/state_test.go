@@ -3771,6 +3771,25 @@ func (s *StateSuite) TestSetEnvironAgentVersionSucceedsWithSameVersion(c *gc.C)
 	assertAgentVersion(c, s.State, "4.5.6")
 }
 
+func (s *StateSuite) TestSetEnvironAgentVersionClearsTargetAgentVersions(c *gc.C) {
+	s.prepareAgentVersionTests(c, s.State)
+	machines, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 1)
+	machine := machines[0]
+	err = machine.SetTargetAgentVersion(version.MustParse("4.5.6"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetModelAgentVersion(version.MustParse("4.5.6"))
+	c.Assert(err, jc.ErrorIsNil)
+	assertAgentVersion(c, s.State, "4.5.6")
+
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := machine.TargetAgentVersion()
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *StateSuite) TestSetEnvironAgentVersionOnOtherEnviron(c *gc.C) {
 	current := version.MustParseBinary("1.24.7-trusty-amd64")
 	s.PatchValue(&jujuversion.Current, current.Number)
@@ -41,6 +41,18 @@ func (st *State) runRawTransaction(ops []txn.Op) error {
 	return runner.RunTransaction(ops)
 }
 
+// runRaw is a convenience method that will run the transactions from
+// the given source using a "raw" transaction runner that won't perform
+// model filtering.
+func (st *State) runRaw(transactions jujutxn.TransactionSource) error {
+	runner, closer := st.database.TransactionRunner()
+	defer closer()
+	if multiRunner, ok := runner.(*multiModelRunner); ok {
+		runner = multiRunner.rawRunner
+	}
+	return runner.Run(transactions)
+}
+
 // run is a convenience method delegating to the state's Database.
 func (st *State) run(transactions jujutxn.TransactionSource) error {
 	runner, closer := st.database.TransactionRunner()
@@ -1164,6 +1164,7 @@ var (
 	unitNotAliveErr    = errors.New("unit is not alive")
 	alreadyAssignedErr = errors.New("unit is already assigned to a machine")
 	inUseErr           = errors.New("machine is not unused")
+	upgradingSeriesErr = errors.New("machine is locked for series upgrade")
 )
 
 // assignToMachine is the internal version of AssignToMachine,
@@ -1173,6 +1174,7 @@ var (
 // - unitNotAliveErr when the unit is not alive.
 // - alreadyAssignedErr when the unit has already been assigned
 // - inUseErr when the machine already has a unit assigned (if unused is true)
+// - upgradingSeriesErr when a series upgrade of the machine is in progress
 func (u *Unit) assignToMachine(m *Machine, unused bool) (err error) {
 	originalm := m
 	buildTxn := func(attempt int) ([]txn.Op, error) {
@@ -1207,6 +1209,13 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 	if unused && !m.doc.Clean {
 		return nil, inUseErr
 	}
+	// Units added to a machine during a series upgrade would not be
+	// part of it, and would run their hooks against the wrong series.
+	if locked, err := m.IsLockedForSeriesUpgrade(); err != nil {
+		return nil, errors.Trace(err)
+	} else if locked {
+		return nil, upgradingSeriesErr
+	}
 	storageParams, err := u.machineStorageParams()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -1257,6 +1266,10 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 		Id:     m.doc.DocID,
 		Assert: massert,
 		Update: bson.D{{"$addToSet", bson.D{{"principals", u.doc.Name}}}, {"$set", bson.D{{"clean", false}}}},
+	}, {
+		C:      upgradeSeriesLocksC,
+		Id:     m.doc.DocID,
+		Assert: txn.DocMissing,
 	},
 		removeStagedAssignmentOp(u.doc.DocID),
 	}
@@ -2011,7 +2024,7 @@ func (u *Unit) assignToCleanMaybeEmptyMachine(requireEmpty bool) (m *Machine, er
 			return m, nil
 		}
 		switch errors.Cause(err) {
-		case inUseErr, machineNotAliveErr:
+		case inUseErr, machineNotAliveErr, upgradingSeriesErr:
 		default:
 			assignContextf(&err, u.Name(), context)
 			return nil, err
@@ -82,6 +82,7 @@ type upgradeInfoDoc struct {
 	Started          time.Time      `bson:"started"`
 	ControllersReady []string       `bson:"controllersReady"`
 	ControllersDone  []string       `bson:"controllersDone"`
+	SnapshotID       string         `bson:"snapshotId,omitempty"`
 }
 
 // UpgradeInfo is used to synchronise controller upgrades.
@@ -126,6 +127,35 @@ func (info *UpgradeInfo) ControllersDone() []string {
 	return result
 }
 
+// SnapshotID returns the id of the backup taken of the controller
+// before any upgrade steps were run, or "" if none has been recorded.
+func (info *UpgradeInfo) SnapshotID() string {
+	return info.doc.SnapshotID
+}
+
+// SetSnapshotID records the id of the backup taken of the controller
+// before running upgrade steps, so that the upgrade's changes to state
+// can be undone if it is rolled back.
+func (info *UpgradeInfo) SetSnapshotID(id string) error {
+	if info.doc.Id != currentUpgradeId {
+		return errors.New("cannot set snapshot id on non-current upgrade")
+	}
+	ops := []txn.Op{{
+		C:      upgradeInfoC,
+		Id:     currentUpgradeId,
+		Assert: assertExpectedVersions(info.doc.PreviousVersion, info.doc.TargetVersion),
+		Update: bson.D{{"$set", bson.D{{"snapshotId", id}}}},
+	}}
+	err := info.st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.New("cannot set upgrade snapshot id: upgrade has changed")
+	} else if err != nil {
+		return errors.Annotate(err, "cannot set upgrade snapshot id")
+	}
+	info.doc.SnapshotID = id
+	return nil
+}
+
 // Refresh updates the contents of the UpgradeInfo from underlying state.
 func (info *UpgradeInfo) Refresh() error {
 	doc, err := currentUpgradeInfoDoc(info.st)
@@ -480,6 +510,69 @@ func (st *State) AbortCurrentUpgrade() error {
 
 }
 
+// RollbackUpgrade abandons the current upgrade, which must not have
+// completed, and sets the model agent version back to the version
+// being upgraded from. Agents that have already upgraded are then
+// permitted to downgrade to their previous tools. The UpgradeInfo
+// returned describes the archived upgrade, including the id of any
+// snapshot taken before its upgrade steps were run.
+func (st *State) RollbackUpgrade() (*UpgradeInfo, error) {
+	var doc *upgradeInfoDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		var err error
+		doc, err = currentUpgradeInfoDoc(st)
+		if errors.IsNotFound(err) {
+			return nil, errors.New("no incomplete upgrade to roll back")
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		settings, err := readSettings(st, modelGlobalKey)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info := &UpgradeInfo{st: st}
+		ops := info.makeArchiveOps(doc, UpgradeAborted)
+		ops = append(ops, txn.Op{
+			C:      settingsC,
+			Id:     st.docID(modelGlobalKey),
+			Assert: bson.D{{"version", settings.version}},
+			Update: bson.D{
+				{"$set", bson.D{{"settings.agent-version", doc.PreviousVersion.String()}}},
+			},
+		})
+		clearOps, err := st.clearTargetAgentVersionOps()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, clearOps...), nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, errors.Annotate(err, "cannot roll back upgrade")
+	}
+	return &UpgradeInfo{st: st, doc: *doc}, nil
+}
+
+// RolledBackUpgrade returns the most recent upgrade if it was rolled
+// back, and so agents may need to downgrade from its target version
+// to its previous version. It returns a NotFound error if there has
+// been no upgrade since, and including, the last one to be aborted,
+// or if an upgrade is in progress.
+func (st *State) RolledBackUpgrade() (*UpgradeInfo, error) {
+	upgradeInfo, closer := st.getCollection(upgradeInfoC)
+	defer closer()
+	var doc upgradeInfoDoc
+	err := upgradeInfo.Find(nil).Sort("-started").One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("rolled back upgrade")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot read upgrade info")
+	}
+	if doc.Id == currentUpgradeId || doc.Status != UpgradeAborted {
+		return nil, errors.NotFoundf("rolled back upgrade")
+	}
+	return &UpgradeInfo{st: st, doc: doc}, nil
+}
+
 func currentUpgradeInfoDoc(st *State) (*upgradeInfoDoc, error) {
 	var doc upgradeInfoDoc
 	upgradeInfo, closer := st.getCollection(upgradeInfoC)
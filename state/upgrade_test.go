@@ -620,6 +620,85 @@ func (s *UpgradeSuite) TestAbortRace(c *gc.C) {
 	s.checkUpgradeInfoArchived(c, info, state.UpgradeAborted, 0)
 }
 
+func (s *UpgradeSuite) TestSetSnapshotID(c *gc.C) {
+	info, err := s.State.EnsureUpgradeInfo(s.serverIdA, vers("1.2.3"), vers("2.3.4"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.SnapshotID(), gc.Equals, "")
+
+	err = info.SetSnapshotID("backup-id")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.SnapshotID(), gc.Equals, "backup-id")
+
+	info, err = s.State.EnsureUpgradeInfo(s.serverIdA, vers("1.2.3"), vers("2.3.4"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.SnapshotID(), gc.Equals, "backup-id")
+}
+
+func (s *UpgradeSuite) TestSetSnapshotIDNonCurrent(c *gc.C) {
+	info, err := s.State.EnsureUpgradeInfo(s.serverIdA, vers("1.2.3"), vers("2.3.4"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = info.Abort()
+	c.Assert(err, jc.ErrorIsNil)
+
+	info = s.getOneUpgradeInfo(c)
+	err = info.SetSnapshotID("backup-id")
+	c.Assert(err, gc.ErrorMatches, "cannot set snapshot id on non-current upgrade")
+}
+
+func (s *UpgradeSuite) TestRollbackUpgrade(c *gc.C) {
+	current := s.setModelAgentVersion(c, "2.3.4")
+	info, err := s.State.EnsureUpgradeInfo(s.serverIdA, vers("1.2.3"), current)
+	c.Assert(err, jc.ErrorIsNil)
+	err = info.SetSnapshotID("backup-id")
+	c.Assert(err, jc.ErrorIsNil)
+
+	rolledBack, err := s.State.RollbackUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rolledBack.Status(), gc.Equals, state.UpgradeAborted)
+	c.Assert(rolledBack.PreviousVersion(), gc.Equals, vers("1.2.3"))
+	c.Assert(rolledBack.SnapshotID(), gc.Equals, "backup-id")
+
+	s.checkUpgradeInfoArchived(c, info, state.UpgradeAborted, 0)
+	s.assertUpgrading(c, false)
+	assertAgentVersion(c, s.State, "1.2.3")
+}
+
+func (s *UpgradeSuite) TestRollbackUpgradeNotUpgrading(c *gc.C) {
+	_, err := s.State.RollbackUpgrade()
+	c.Assert(err, gc.ErrorMatches, "cannot roll back upgrade: no incomplete upgrade to roll back")
+}
+
+func (s *UpgradeSuite) TestRolledBackUpgrade(c *gc.C) {
+	_, err := s.State.RolledBackUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	current := s.setModelAgentVersion(c, "2.3.4")
+	_, err = s.State.EnsureUpgradeInfo(s.serverIdA, vers("1.2.3"), current)
+	c.Assert(err, jc.ErrorIsNil)
+	// An upgrade in progress has not been rolled back.
+	_, err = s.State.RolledBackUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = s.State.RollbackUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	rolledBack, err := s.State.RolledBackUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rolledBack.PreviousVersion(), gc.Equals, vers("1.2.3"))
+	c.Assert(rolledBack.TargetVersion(), gc.Equals, vers("2.3.4"))
+
+	// Once another upgrade has started, the rollback no longer applies.
+	_, err = s.State.EnsureUpgradeInfo(s.serverIdA, vers("1.2.3"), vers("2.3.5"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.RolledBackUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UpgradeSuite) setModelAgentVersion(c *gc.C, v string) version.Number {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"agent-version": v}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	return vers(v)
+}
+
 func (s *UpgradeSuite) checkUpgradeInfoArchived(
 	c *gc.C,
 	initialInfo *state.UpgradeInfo,
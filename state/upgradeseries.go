@@ -0,0 +1,358 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// UpgradeSeriesStatus describes how far a machine, or one of the units
+// it hosts, has progressed through an in-place series upgrade.
+type UpgradeSeriesStatus string
+
+const (
+	// UpgradeSeriesNotStarted indicates that no series upgrade is in
+	// progress.
+	UpgradeSeriesNotStarted UpgradeSeriesStatus = ""
+
+	// UpgradeSeriesPrepareStarted indicates that the operator has
+	// asked for the machine to be prepared for a series upgrade.
+	UpgradeSeriesPrepareStarted UpgradeSeriesStatus = "prepare started"
+
+	// UpgradeSeriesPrepareCompleted indicates that the preparation
+	// has finished; for units, that the pre-series-upgrade hook has
+	// run, and for machines, that the agent services have been
+	// reinstalled for the target series.
+	UpgradeSeriesPrepareCompleted UpgradeSeriesStatus = "prepare completed"
+
+	// UpgradeSeriesCompleteStarted indicates that the operator has
+	// upgraded the operating system and asked juju to finish the
+	// series upgrade.
+	UpgradeSeriesCompleteStarted UpgradeSeriesStatus = "complete started"
+
+	// UpgradeSeriesCompleted indicates that the series upgrade has
+	// finished; for units, that the post-series-upgrade hook has run.
+	UpgradeSeriesCompleted UpgradeSeriesStatus = "completed"
+)
+
+// upgradeSeriesLockDoc records the progress of an in-place series
+// upgrade of a machine and of each of the units it hosts. While the
+// document exists, the machine's units will not run any hooks other
+// than those that make up the series upgrade.
+type upgradeSeriesLockDoc struct {
+	DocID         string                         `bson:"_id"`
+	Id            string                         `bson:"machineid"`
+	ModelUUID     string                         `bson:"model-uuid"`
+	FromSeries    string                         `bson:"from-series"`
+	ToSeries      string                         `bson:"to-series"`
+	MachineStatus UpgradeSeriesStatus            `bson:"machine-status"`
+	UnitStatuses  map[string]UpgradeSeriesStatus `bson:"unit-statuses"`
+}
+
+// UpgradeSeriesLock describes the state of an in-place series upgrade
+// of a machine.
+type UpgradeSeriesLock struct {
+	doc upgradeSeriesLockDoc
+}
+
+// FromSeries returns the series the machine was running when the
+// upgrade was prepared.
+func (l *UpgradeSeriesLock) FromSeries() string {
+	return l.doc.FromSeries
+}
+
+// ToSeries returns the series the machine is being upgraded to.
+func (l *UpgradeSeriesLock) ToSeries() string {
+	return l.doc.ToSeries
+}
+
+// MachineStatus returns the progress of the machine agent through the
+// series upgrade.
+func (l *UpgradeSeriesLock) MachineStatus() UpgradeSeriesStatus {
+	return l.doc.MachineStatus
+}
+
+// UnitStatuses returns the progress of each unit on the machine
+// through the series upgrade, keyed on unit name.
+func (l *UpgradeSeriesLock) UnitStatuses() map[string]UpgradeSeriesStatus {
+	statuses := make(map[string]UpgradeSeriesStatus, len(l.doc.UnitStatuses))
+	for name, status := range l.doc.UnitStatuses {
+		statuses[name] = status
+	}
+	return statuses
+}
+
+// assertUnchanged returns an assertion that the lock has not changed
+// since it was read.
+func (l *UpgradeSeriesLock) assertUnchanged() bson.D {
+	assert := bson.D{{"machine-status", l.doc.MachineStatus}}
+	for name, status := range l.doc.UnitStatuses {
+		assert = append(assert, bson.DocElem{"unit-statuses." + name, status})
+	}
+	return assert
+}
+
+// allAt reports whether the machine and all of its units have reached
+// the given status.
+func (l *UpgradeSeriesLock) allAt(status UpgradeSeriesStatus) bool {
+	if l.doc.MachineStatus != status {
+		return false
+	}
+	for _, unitStatus := range l.doc.UnitStatuses {
+		if unitStatus != status {
+			return false
+		}
+	}
+	return true
+}
+
+func removeUpgradeSeriesLockOp(docID string) txn.Op {
+	return txn.Op{
+		C:      upgradeSeriesLocksC,
+		Id:     docID,
+		Remove: true,
+	}
+}
+
+// UpgradeSeriesLock returns the lock recording the progress of an
+// in-place series upgrade of the machine. A NotFound error is returned
+// if no series upgrade is in progress.
+func (m *Machine) UpgradeSeriesLock() (*UpgradeSeriesLock, error) {
+	locks, closer := m.st.getCollection(upgradeSeriesLocksC)
+	defer closer()
+
+	var doc upgradeSeriesLockDoc
+	err := locks.FindId(m.doc.DocID).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("series upgrade lock for machine %q", m.Id())
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get series upgrade lock for machine %q", m.Id())
+	}
+	return &UpgradeSeriesLock{doc}, nil
+}
+
+// IsLockedForSeriesUpgrade reports whether a series upgrade of the
+// machine is in progress.
+func (m *Machine) IsLockedForSeriesUpgrade() (bool, error) {
+	_, err := m.UpgradeSeriesLock()
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+// isUpgradingSeries reports whether a series upgrade of any machine in
+// the model is in progress.
+func (st *State) isUpgradingSeries() (bool, error) {
+	locks, closer := st.getCollection(upgradeSeriesLocksC)
+	defer closer()
+
+	n, err := locks.Find(nil).Count()
+	if err != nil {
+		return false, errors.Annotate(err, "cannot count series upgrade locks")
+	}
+	return n > 0, nil
+}
+
+// PrepareUpgradeSeries locks the machine and the units it
+// hosts for an in-place upgrade to the given series. It fails if the
+// machine is a controller, is already running the given series, or is
+// already being upgraded, or if the model is being migrated. Until the
+// upgrade completes, no further units can be assigned to the machine.
+func (m *Machine) PrepareUpgradeSeries(toSeries string) error {
+	if toSeries == "" {
+		return errors.NotValidf("empty series")
+	}
+	if m.IsManager() {
+		return errors.NotSupportedf("upgrading the series of controller machine %q", m.Id())
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.Life() != Alive {
+			return nil, errors.Errorf("machine %q is not alive", m.Id())
+		}
+		if m.Series() == toSeries {
+			return nil, errors.Errorf("machine %q is already running series %q", m.Id(), toSeries)
+		}
+		locked, err := m.IsLockedForSeriesUpgrade()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if locked {
+			return nil, errors.AlreadyExistsf("series upgrade lock for machine %q", m.Id())
+		}
+		if migrating, err := m.st.IsModelMigrationActive(); err != nil {
+			return nil, errors.Trace(err)
+		} else if migrating {
+			return nil, errors.New("model is being migrated")
+		}
+		unitStatuses := make(map[string]UpgradeSeriesStatus)
+		for _, name := range m.Principals() {
+			unit, err := m.st.Unit(name)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			unitStatuses[name] = UpgradeSeriesPrepareStarted
+			for _, subordinate := range unit.SubordinateNames() {
+				unitStatuses[subordinate] = UpgradeSeriesPrepareStarted
+			}
+		}
+		return []txn.Op{{
+			C:  machinesC,
+			Id: m.doc.DocID,
+			Assert: bson.D{
+				{"life", Alive},
+				{"series", m.Series()},
+				{"principals", m.Principals()},
+			},
+		}, {
+			C:      upgradeSeriesLocksC,
+			Id:     m.doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &upgradeSeriesLockDoc{
+				Id:            m.Id(),
+				FromSeries:    m.Series(),
+				ToSeries:      toSeries,
+				MachineStatus: UpgradeSeriesPrepareStarted,
+				UnitStatuses:  unitStatuses,
+			},
+		}, {
+			C:      migrationsActiveC,
+			Id:     m.st.ModelUUID(),
+			Assert: txn.DocMissing,
+		}}, nil
+	}
+	err := m.st.run(buildTxn)
+	return errors.Annotatef(err, "cannot prepare series upgrade of machine %q", m.Id())
+}
+
+// CompleteUpgradeSeries records that the operating system of the machine
+// has been upgraded: the machine's series is set to the target series,
+// and the machine agent and units are asked to finish the upgrade. It
+// fails unless the machine and all of its units have completed the
+// preparation.
+func (m *Machine) CompleteUpgradeSeries() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		lock, err := m.UpgradeSeriesLock()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !lock.allAt(UpgradeSeriesPrepareCompleted) {
+			return nil, errors.Errorf("machine %q is not ready: preparation has not completed", m.Id())
+		}
+		unitStatuses := make(map[string]UpgradeSeriesStatus)
+		for name := range lock.doc.UnitStatuses {
+			unitStatuses[name] = UpgradeSeriesCompleteStarted
+		}
+		return []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: notDeadDoc,
+			Update: bson.D{{"$set", bson.D{{"series", lock.ToSeries()}}}},
+		}, {
+			C:      upgradeSeriesLocksC,
+			Id:     m.doc.DocID,
+			Assert: lock.assertUnchanged(),
+			Update: bson.D{{"$set", bson.D{
+				{"machine-status", UpgradeSeriesCompleteStarted},
+				{"unit-statuses", unitStatuses},
+			}}},
+		}}, nil
+	}
+	if err := m.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot complete series upgrade of machine %q", m.Id())
+	}
+	return m.Refresh()
+}
+
+// SetUpgradeSeriesMachineStatus records the progress of the machine
+// agent through the series upgrade.
+func (m *Machine) SetUpgradeSeriesMachineStatus(status UpgradeSeriesStatus) error {
+	return m.setUpgradeSeriesStatus("machine-status", status, func(lock *UpgradeSeriesLock) error {
+		if !validUpgradeSeriesTransition(lock.doc.MachineStatus, status) {
+			return errors.Errorf("cannot move machine from %q to %q", lock.doc.MachineStatus, status)
+		}
+		lock.doc.MachineStatus = status
+		return nil
+	})
+}
+
+// SetUpgradeSeriesUnitStatus records the progress of the named unit
+// through the series upgrade of the machine hosting it.
+func (m *Machine) SetUpgradeSeriesUnitStatus(unitName string, status UpgradeSeriesStatus) error {
+	return m.setUpgradeSeriesStatus("unit-statuses."+unitName, status, func(lock *UpgradeSeriesLock) error {
+		current, ok := lock.doc.UnitStatuses[unitName]
+		if !ok {
+			return errors.NotFoundf("unit %q in series upgrade of machine %q", unitName, m.Id())
+		}
+		if !validUpgradeSeriesTransition(current, status) {
+			return errors.Errorf("cannot move unit %q from %q to %q", unitName, current, status)
+		}
+		lock.doc.UnitStatuses[unitName] = status
+		return nil
+	})
+}
+
+// setUpgradeSeriesStatus sets the given field of the machine's series
+// upgrade lock, after apply has validated the change and recorded it
+// in the lock. Once the machine and all of its units have completed
+// the upgrade, the lock is removed.
+func (m *Machine) setUpgradeSeriesStatus(
+	field string, status UpgradeSeriesStatus, apply func(*UpgradeSeriesLock) error,
+) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		lock, err := m.UpgradeSeriesLock()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		assert := lock.assertUnchanged()
+		if err := apply(lock); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if lock.allAt(UpgradeSeriesCompleted) {
+			op := removeUpgradeSeriesLockOp(m.doc.DocID)
+			op.Assert = assert
+			return []txn.Op{op}, nil
+		}
+		return []txn.Op{{
+			C:      upgradeSeriesLocksC,
+			Id:     m.doc.DocID,
+			Assert: assert,
+			Update: bson.D{{"$set", bson.D{{field, status}}}},
+		}}, nil
+	}
+	err := m.st.run(buildTxn)
+	return errors.Annotatef(err, "cannot set series upgrade status of machine %q", m.Id())
+}
+
+// validUpgradeSeriesTransition reports whether an agent may move from
+// one series upgrade status to the next. Setting the current status
+// again is allowed, so that agents may safely retry.
+func validUpgradeSeriesTransition(from, to UpgradeSeriesStatus) bool {
+	if from == to {
+		return true
+	}
+	switch from {
+	case UpgradeSeriesPrepareStarted:
+		return to == UpgradeSeriesPrepareCompleted
+	case UpgradeSeriesCompleteStarted:
+		return to == UpgradeSeriesCompleted
+	}
+	return false
+}
+
+// WatchUpgradeSeriesNotifications returns a NotifyWatcher that fires
+// when the series upgrade lock of the machine changes.
+func (m *Machine) WatchUpgradeSeriesNotifications() NotifyWatcher {
+	return newEntityWatcher(m.st, upgradeSeriesLocksC, m.doc.DocID)
+}
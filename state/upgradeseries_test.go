@@ -0,0 +1,151 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type UpgradeSeriesSuite struct {
+	ConnSuite
+
+	machine *state.Machine
+	unit    *state.Unit
+}
+
+var _ = gc.Suite(&UpgradeSeriesSuite{})
+
+func (s *UpgradeSeriesSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, &factory.MachineParams{Series: "quantal"})
+	s.unit = s.Factory.MakeUnit(c, &factory.UnitParams{Machine: s.machine})
+	err := s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradeSeriesSuite) TestNotLocked(c *gc.C) {
+	locked, err := s.machine.IsLockedForSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsFalse)
+
+	_, err = s.machine.UpgradeSeriesLock()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UpgradeSeriesSuite) TestPrepareUpgradeSeries(c *gc.C) {
+	err := s.machine.PrepareUpgradeSeries("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+
+	lock, err := s.machine.UpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lock.FromSeries(), gc.Equals, "quantal")
+	c.Assert(lock.ToSeries(), gc.Equals, "xenial")
+	c.Assert(lock.MachineStatus(), gc.Equals, state.UpgradeSeriesPrepareStarted)
+	c.Assert(lock.UnitStatuses(), jc.DeepEquals, map[string]state.UpgradeSeriesStatus{
+		s.unit.Name(): state.UpgradeSeriesPrepareStarted,
+	})
+}
+
+func (s *UpgradeSeriesSuite) TestPrepareUpgradeSeriesSameSeries(c *gc.C) {
+	err := s.machine.PrepareUpgradeSeries("quantal")
+	c.Assert(err, gc.ErrorMatches, `cannot prepare series upgrade of machine "0": machine "0" is already running series "quantal"`)
+}
+
+func (s *UpgradeSeriesSuite) TestPrepareUpgradeSeriesAlreadyLocked(c *gc.C) {
+	err := s.machine.PrepareUpgradeSeries("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.PrepareUpgradeSeries("xenial")
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *UpgradeSeriesSuite) addUnit(c *gc.C) *state.Unit {
+	service, err := s.unit.Service()
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := service.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	return unit
+}
+
+func (s *UpgradeSeriesSuite) TestAssignToLockedMachine(c *gc.C) {
+	unit := s.addUnit(c)
+	err := s.machine.PrepareUpgradeSeries("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = unit.AssignToMachine(s.machine)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit ".*" to machine 0: machine is locked for series upgrade`)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.Principals(), jc.DeepEquals, []string{s.unit.Name()})
+}
+
+func (s *UpgradeSeriesSuite) TestAssignToMachineLockedConcurrently(c *gc.C) {
+	unit := s.addUnit(c)
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.machine.PrepareUpgradeSeries("xenial")
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err := unit.AssignToMachine(s.machine)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit ".*" to machine 0: machine is locked for series upgrade`)
+	lock, err := s.machine.UpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lock.UnitStatuses(), jc.DeepEquals, map[string]state.UpgradeSeriesStatus{
+		s.unit.Name(): state.UpgradeSeriesPrepareStarted,
+	})
+}
+
+func (s *UpgradeSeriesSuite) TestCompleteUpgradeSeriesNotPrepared(c *gc.C) {
+	err := s.machine.PrepareUpgradeSeries("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetUpgradeSeriesMachineStatus(state.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.CompleteUpgradeSeries()
+	c.Assert(err, gc.ErrorMatches, `cannot complete series upgrade of machine "0": machine "0" is not ready: preparation has not completed`)
+}
+
+func (s *UpgradeSeriesSuite) TestInvalidTransition(c *gc.C) {
+	err := s.machine.PrepareUpgradeSeries("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetUpgradeSeriesUnitStatus(s.unit.Name(), state.UpgradeSeriesCompleted)
+	c.Assert(err, gc.ErrorMatches, `.*cannot move unit "mysql/0" from "prepare started" to "completed"`)
+}
+
+func (s *UpgradeSeriesSuite) TestFullUpgrade(c *gc.C) {
+	w := s.machine.WatchUpgradeSeriesNotifications()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.machine.PrepareUpgradeSeries("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.machine.SetUpgradeSeriesMachineStatus(state.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetUpgradeSeriesUnitStatus(s.unit.Name(), state.UpgradeSeriesPrepareCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.machine.CompleteUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.Series(), gc.Equals, "xenial")
+	wc.AssertOneChange()
+
+	err = s.machine.SetUpgradeSeriesMachineStatus(state.UpgradeSeriesCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetUpgradeSeriesUnitStatus(s.unit.Name(), state.UpgradeSeriesCompleted)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	locked, err := s.machine.IsLockedForSeriesUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, jc.IsFalse)
+}
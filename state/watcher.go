@@ -4,6 +4,7 @@
 package state
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -155,8 +156,9 @@ var _ Watcher = (*lifecycleWatcher)(nil)
 // lifecycleWatcher notifies about lifecycle changes for a set of entities of
 // the same kind. The first event emitted will contain the ids of all
 // entities; subsequent events are emitted whenever one or more entities are
-// added, or change their lifecycle state. After an entity is found to be
-// Dead, no further event will include it.
+// added, or change their lifecycle state. Entities that may be suspended,
+// such as relations, are also reported when suspended or resumed. After an
+// entity is found to be Dead, no further event will include it.
 type lifecycleWatcher struct {
 	commonWatcher
 	out chan []string
@@ -175,6 +177,9 @@ type lifecycleWatcher struct {
 	transform func(string) string
 	// life holds the most recent known life states of interesting entities.
 	life map[string]Life
+	// suspended holds the most recent known suspension states of
+	// interesting entities.
+	suspended map[string]bool
 }
 
 func collFactory(st *State, collName string) func() (mongo.Collection, func()) {
@@ -424,6 +429,7 @@ func newLifecycleWatcher(
 		filter:        filter,
 		transform:     transform,
 		life:          make(map[string]Life),
+		suspended:     make(map[string]bool),
 		out:           make(chan []string),
 	}
 	go func() {
@@ -435,11 +441,12 @@ func newLifecycleWatcher(
 }
 
 type lifeDoc struct {
-	Id   string `bson:"_id"`
-	Life Life
+	Id        string `bson:"_id"`
+	Life      Life
+	Suspended bool `bson:"suspended"`
 }
 
-var lifeFields = bson.D{{"_id", 1}, {"life", 1}}
+var lifeFields = bson.D{{"_id", 1}, {"life", 1}, {"suspended", 1}}
 
 // Changes returns the event channel for the LifecycleWatcher.
 func (w *lifecycleWatcher) Changes() <-chan []string {
@@ -458,6 +465,7 @@ func (w *lifecycleWatcher) initial() (set.Strings, error) {
 		ids.Add(id)
 		if doc.Life != Dead {
 			w.life[id] = doc.Life
+			w.suspended[id] = doc.Suspended
 		}
 	}
 	return ids, iter.Close()
@@ -470,6 +478,7 @@ func (w *lifecycleWatcher) merge(ids set.Strings, updates map[interface{}]bool)
 	// Separate ids into those thought to exist and those known to be removed.
 	var changed []string
 	latest := make(map[string]Life)
+	suspended := make(map[string]bool)
 	for docID, exists := range updates {
 		switch docID := docID.(type) {
 		case string:
@@ -490,7 +499,9 @@ func (w *lifecycleWatcher) merge(ids set.Strings, updates map[interface{}]bool)
 	iter := coll.Find(bson.D{{"_id", bson.D{{"$in", changed}}}}).Select(lifeFields).Iter()
 	var doc lifeDoc
 	for iter.Next(&doc) {
-		latest[w.st.localID(doc.Id)] = doc.Life
+		id := w.st.localID(doc.Id)
+		latest[id] = doc.Life
+		suspended[id] = doc.Suspended
 	}
 	if err := iter.Close(); err != nil {
 		return err
@@ -503,10 +514,13 @@ func (w *lifecycleWatcher) merge(ids set.Strings, updates map[interface{}]bool)
 		switch {
 		case known && gone:
 			delete(w.life, id)
+			delete(w.suspended, id)
 		case !known && !gone:
 			w.life[id] = newLife
-		case known && newLife != oldLife:
+			w.suspended[id] = suspended[id]
+		case known && (newLife != oldLife || suspended[id] != w.suspended[id]):
 			w.life[id] = newLife
+			w.suspended[id] = suspended[id]
 		default:
 			continue
 		}
@@ -1879,6 +1893,83 @@ func (w *cleanupWatcher) loop() (err error) {
 	}
 }
 
+// remoteRelationsWatcher notifies of changes that may need to be
+// propagated between models related through service offers.
+type remoteRelationsWatcher struct {
+	commonWatcher
+	out chan struct{}
+}
+
+var _ Watcher = (*remoteRelationsWatcher)(nil)
+
+// WatchRemoteRelations returns a NotifyWatcher that notifies of changes
+// to remote services, relations, relation scopes and relation settings
+// in every model hosted by the controller.
+func (st *State) WatchRemoteRelations() NotifyWatcher {
+	return newRemoteRelationsWatcher(st)
+}
+
+func newRemoteRelationsWatcher(st *State) NotifyWatcher {
+	w := &remoteRelationsWatcher{
+		commonWatcher: commonWatcher{st: st},
+		out:           make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for w.
+func (w *remoteRelationsWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *remoteRelationsWatcher) loop() (err error) {
+	in := make(chan watcher.Change)
+	filters := map[string]func(interface{}) bool{
+		remoteServicesC: nil,
+		relationsC:      nil,
+		relationScopesC: nil,
+		settingsC:       isRelationSettings,
+	}
+	for coll, filter := range filters {
+		w.st.watcher.WatchCollectionWithFilter(coll, in, filter)
+		defer w.st.watcher.UnwatchCollection(coll, in)
+	}
+
+	// Send an initial event, so that relations changed while no one
+	// was watching are synchronised.
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.st.watcher.Dead():
+			return stateWatcherDeadError(w.st.watcher.Err())
+		case ch := <-in:
+			if _, ok := collect(ch, in, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			out = w.out
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
+// isRelationSettings returns whether the settings document with the
+// given id holds the settings of a unit in a relation.
+func isRelationSettings(id interface{}) bool {
+	docID, ok := id.(string)
+	if !ok {
+		return false
+	}
+	return strings.Contains(docID, ":r#")
+}
+
 // actionStatusWatcher is a StringsWatcher that filters notifications
 // to Action Id's that match the ActionReceiver and ActionStatus set
 // provided.
@@ -2363,6 +2454,117 @@ func (st *State) WatchActionResultsFilteredBy(receivers ...ActionReceiver) Strin
 	return newActionStatusWatcher(st, receivers, []ActionStatus{ActionCompleted, ActionCancelled, ActionFailed}...)
 }
 
+// WatchActionLogs starts and returns a StringsWatcher that notifies
+// of the progress messages logged by the action with the given id. The
+// initial event holds all messages logged so far, and each subsequent
+// event holds the messages logged since the last event. Each message
+// is a JSON-encoded ActionMessage.
+func (st *State) WatchActionLogs(actionId string) StringsWatcher {
+	return newActionLogsWatcher(st, actionId)
+}
+
+// actionLogsWatcher notifies of the progress messages logged by a
+// single action.
+type actionLogsWatcher struct {
+	commonWatcher
+	actionId string
+	out      chan []string
+}
+
+var _ Watcher = (*actionLogsWatcher)(nil)
+
+func newActionLogsWatcher(st *State, actionId string) StringsWatcher {
+	w := &actionLogsWatcher{
+		commonWatcher: commonWatcher{st: st},
+		actionId:      actionId,
+		out:           make(chan []string),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for the actionLogsWatcher.
+func (w *actionLogsWatcher) Changes() <-chan []string {
+	return w.out
+}
+
+// messages returns the JSON-encoded progress messages currently kept
+// for the action, and the number of messages it has logged in total.
+func (w *actionLogsWatcher) messages() ([]string, int, error) {
+	actions, closer := w.st.getCollection(actionsC)
+	defer closer()
+
+	var doc actionDoc
+	err := actions.FindId(w.actionId).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, 0, errors.NotFoundf("action %q", w.actionId)
+	} else if err != nil {
+		return nil, 0, errors.Annotatef(err, "cannot get action %q", w.actionId)
+	}
+	messages := make([]string, len(doc.Messages))
+	for i, message := range doc.Messages {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return nil, 0, errors.Trace(err)
+		}
+		messages[i] = string(data)
+	}
+	return messages, doc.MessageCount, nil
+}
+
+func (w *actionLogsWatcher) loop() error {
+	actions, closer := w.st.getCollection(actionsC)
+	txnRevno, err := getTxnRevno(actions, w.actionId)
+	closer()
+	if err != nil {
+		return err
+	}
+	in := make(chan watcher.Change)
+	docId := w.st.docID(w.actionId)
+	w.st.watcher.Watch(actionsC, docId, txnRevno, in)
+	defer w.st.watcher.Unwatch(actionsC, docId, in)
+
+	pending, known, err := w.messages()
+	if err != nil {
+		return err
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.st.watcher.Dead():
+			return stateWatcherDeadError(w.st.watcher.Err())
+		case ch := <-in:
+			if _, ok := collect(ch, in, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			messages, count, err := w.messages()
+			if err != nil {
+				return err
+			}
+			if count > known {
+				// Only the most recent messages are kept, so
+				// some may have been discarded unseen.
+				added := count - known
+				if added > len(messages) {
+					added = len(messages)
+				}
+				pending = append(pending, messages[len(messages)-added:]...)
+				known = count
+				out = w.out
+			}
+		case out <- pending:
+			pending = nil
+			out = nil
+		}
+	}
+}
+
 // openedPortsWatcher notifies of changes in the openedPorts
 // collection
 type openedPortsWatcher struct {
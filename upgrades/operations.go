@@ -16,6 +16,10 @@ import (
 //
 // All state-based operations are run before API-based operations
 // (below).
+//
+// Steps whose changes can be undone should implement ReversibleStep
+// (see reversibleUpgradeStep), so that they are reverted if the
+// upgrade fails.
 var stateUpgradeOperations = func() []Operation {
 	steps := []Operation{
 		upgradeToVersion{
@@ -25,6 +25,16 @@ type Step interface {
 	Run(Context) error
 }
 
+// ReversibleStep is a Step that declares how to undo its changes, so
+// that they may be reverted if the upgrade fails.
+type ReversibleStep interface {
+	Step
+
+	// Revert undoes the changes made by Run. Like Run, it must be
+	// idempotent, and must cope with Run not having been called.
+	Revert(Context) error
+}
+
 // Operation defines what steps to perform to upgrade to a target version.
 type Operation interface {
 	// The Juju version for which this operation is applicable.
@@ -109,6 +119,29 @@ func PerformUpgrade(from version.Number, targets []Target, context Context) erro
 	return nil
 }
 
+// RevertUpgrade reverts the steps run by PerformUpgrade to upgrade
+// from the "from" version to this version of Juju on the "target" type
+// of machine, in the reverse of the order in which they are run. Only
+// steps that implement ReversibleStep are reverted; the changes made
+// to state by other steps can only be undone by restoring the snapshot
+// that the controller takes before running upgrade steps.
+func RevertUpgrade(from version.Number, targets []Target, context Context) error {
+	ops := newUpgradeOpsIterator(from)
+	if err := revertUpgradeSteps(ops, targets, context.APIContext()); err != nil {
+		return err
+	}
+
+	if hasStateTarget(targets) {
+		ops := newStateUpgradeOpsIterator(from)
+		if err := revertUpgradeSteps(ops, targets, context.StateContext()); err != nil {
+			return err
+		}
+	}
+
+	logger.Infof("All upgrade steps reverted successfully")
+	return nil
+}
+
 func hasStateTarget(targets []Target) bool {
 	for _, target := range targets {
 		if target == Controller || target == DatabaseMaster {
@@ -143,6 +176,38 @@ func runUpgradeSteps(ops *opsIterator, targets []Target, context Context) error
 	return nil
 }
 
+// revertUpgradeSteps finds all the upgrade operations relevant to the
+// targets given and reverts the associated reversible upgrade steps,
+// most recent first. As soon as any error is encountered, the revert
+// is aborted.
+func revertUpgradeSteps(ops *opsIterator, targets []Target, context Context) error {
+	var steps []ReversibleStep
+	for ops.Next() {
+		for _, step := range ops.Get().Steps() {
+			if !targetsMatch(targets, step.Targets()) {
+				continue
+			}
+			if reversible, ok := step.(ReversibleStep); ok {
+				steps = append(steps, reversible)
+			} else {
+				logger.Infof("upgrade step cannot be reverted: %v", step.Description())
+			}
+		}
+	}
+	for i := len(steps) - 1; i >= 0; i-- {
+		step := steps[i]
+		logger.Infof("reverting upgrade step: %v", step.Description())
+		if err := step.Revert(context); err != nil {
+			logger.Errorf("reverting upgrade step %q failed: %v", step.Description(), err)
+			return &upgradeError{
+				description: "reverting " + step.Description(),
+				err:         err,
+			}
+		}
+	}
+	return nil
+}
+
 // targetsMatch returns true if any machineTargets match any of
 // stepTargets.
 func targetsMatch(machineTargets []Target, stepTargets []Target) bool {
@@ -179,3 +244,16 @@ func (step *upgradeStep) Targets() []Target {
 func (step *upgradeStep) Run(context Context) error {
 	return step.run(context)
 }
+
+// reversibleUpgradeStep is a default ReversibleStep implementation.
+type reversibleUpgradeStep struct {
+	upgradeStep
+	revert func(Context) error
+}
+
+var _ ReversibleStep = (*reversibleUpgradeStep)(nil)
+
+// Revert is defined on the ReversibleStep interface.
+func (step *reversibleUpgradeStep) Revert(context Context) error {
+	return step.revert(context)
+}
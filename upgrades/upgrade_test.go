@@ -120,6 +120,23 @@ func newUpgradeStep(msg string, targets ...upgrades.Target) *mockUpgradeStep {
 	}
 }
 
+type mockReversibleStep struct {
+	mockUpgradeStep
+}
+
+func (u *mockReversibleStep) Revert(ctx upgrades.Context) error {
+	if strings.HasSuffix(u.msg, "revert error") {
+		return errors.New("revert error occurred")
+	}
+	context := ctx.(*mockContext)
+	context.messages = append(context.messages, "revert "+u.msg)
+	return nil
+}
+
+func newReversibleStep(msg string, targets ...upgrades.Target) *mockReversibleStep {
+	return &mockReversibleStep{*newUpgradeStep(msg, targets...)}
+}
+
 type mockContext struct {
 	messages        []string
 	agentConfig     *mockAgentConfig
@@ -560,6 +577,77 @@ func (s *upgradeSuite) TestPerformUpgrade(c *gc.C) {
 	}
 }
 
+func (s *upgradeSuite) patchReversibleOperations() {
+	s.PatchValue(upgrades.StateUpgradeOperations, func() []upgrades.Operation {
+		return []upgrades.Operation{
+			&mockUpgradeOperation{
+				targetVersion: version.MustParse("1.21.0"),
+				steps: []upgrades.Step{
+					newReversibleStep("state step 1", upgrades.DatabaseMaster),
+					newUpgradeStep("state step 2", upgrades.DatabaseMaster),
+				},
+			},
+		}
+	})
+	s.PatchValue(upgrades.UpgradeOperations, func() []upgrades.Operation {
+		return []upgrades.Operation{
+			&mockUpgradeOperation{
+				targetVersion: version.MustParse("1.20.0"),
+				steps: []upgrades.Step{
+					newReversibleStep("step 1", upgrades.AllMachines),
+				},
+			},
+			&mockUpgradeOperation{
+				targetVersion: version.MustParse("1.21.0"),
+				steps: []upgrades.Step{
+					newReversibleStep("step 2", upgrades.HostMachine),
+					newUpgradeStep("step 3", upgrades.AllMachines),
+					newReversibleStep("step 4", upgrades.AllMachines),
+				},
+			},
+		}
+	})
+	s.PatchValue(&jujuversion.Current, version.MustParse("1.21.0"))
+}
+
+func (s *upgradeSuite) TestRevertUpgrade(c *gc.C) {
+	s.patchReversibleOperations()
+	ctx := &mockContext{}
+	err := upgrades.RevertUpgrade(version.MustParse("1.18.0"), targets(upgrades.DatabaseMaster), ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.messages, jc.DeepEquals, []string{
+		"revert step 4", "revert step 1", "revert state step 1",
+	})
+}
+
+func (s *upgradeSuite) TestRevertUpgradeSkipsOlderSteps(c *gc.C) {
+	s.patchReversibleOperations()
+	ctx := &mockContext{}
+	err := upgrades.RevertUpgrade(version.MustParse("1.20.0"), targets(upgrades.HostMachine), ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.messages, jc.DeepEquals, []string{"revert step 4", "revert step 2"})
+}
+
+func (s *upgradeSuite) TestRevertUpgradeError(c *gc.C) {
+	s.PatchValue(upgrades.StateUpgradeOperations, func() []upgrades.Operation { return nil })
+	s.PatchValue(upgrades.UpgradeOperations, func() []upgrades.Operation {
+		return []upgrades.Operation{
+			&mockUpgradeOperation{
+				targetVersion: version.MustParse("1.21.0"),
+				steps: []upgrades.Step{
+					newReversibleStep("step 1", upgrades.AllMachines),
+					newReversibleStep("step 2 revert error", upgrades.AllMachines),
+				},
+			},
+		}
+	})
+	s.PatchValue(&jujuversion.Current, version.MustParse("1.21.0"))
+	ctx := &mockContext{}
+	err := upgrades.RevertUpgrade(version.MustParse("1.20.0"), targets(upgrades.HostMachine), ctx)
+	c.Assert(err, gc.ErrorMatches, "reverting step 2 revert error: revert error occurred")
+	c.Assert(ctx.messages, gc.HasLen, 0)
+}
+
 type contextStep struct {
 	useAPI bool
 }
@@ -16,7 +16,8 @@ import (
 
 var logger = loggo.GetLogger("juju.worker.apiaddressupdater")
 
-// APIAddressUpdater is responsible for propagating API addresses.
+// APIAddressUpdater is responsible for propagating API addresses, and
+// the CA certificates used to validate connections to them.
 //
 // In practice, APIAddressUpdater is used by a machine agent to watch
 // API addresses in state and write the changes to the agent's config file.
@@ -29,13 +30,16 @@ type APIAddressUpdater struct {
 // which can be used to watch for API address changes.
 type APIAddresser interface {
 	APIHostPorts() ([][]network.HostPort, error)
+	CACert() (string, error)
 	WatchAPIHostPorts() (watcher.NotifyWatcher, error)
 }
 
 // APIAddressSetter is an interface that is provided to NewAPIAddressUpdater
-// whose SetAPIHostPorts method will be invoked whenever address changes occur.
+// whose SetAPIHostPorts and SetCACert methods will be invoked whenever
+// address or certificate changes occur.
 type APIAddressSetter interface {
 	SetAPIHostPorts(servers [][]network.HostPort) error
+	SetCACert(caCert string) error
 }
 
 // NewAPIAddressUpdater returns a worker.Worker that watches for changes to
@@ -88,6 +92,18 @@ func (c *APIAddressUpdater) Handle(_ <-chan struct{}) error {
 	if err := c.setter.SetAPIHostPorts(hpsToSet); err != nil {
 		return fmt.Errorf("error setting addresses: %v", err)
 	}
+
+	// The controller's CA certificates change when they are rotated.
+	caCert, err := c.addresser.CACert()
+	if err != nil {
+		return fmt.Errorf("error getting CA certificate: %v", err)
+	}
+	if caCert == "" {
+		return nil
+	}
+	if err := c.setter.SetCACert(caCert); err != nil {
+		return fmt.Errorf("error setting CA certificate: %v", err)
+	}
 	return nil
 }
 
@@ -39,6 +39,7 @@ func (s *APIAddressUpdaterSuite) SetUpTest(c *gc.C) {
 
 type apiAddressSetter struct {
 	servers chan [][]network.HostPort
+	caCerts chan string
 	err     error
 }
 
@@ -47,6 +48,13 @@ func (s *apiAddressSetter) SetAPIHostPorts(servers [][]network.HostPort) error {
 	return s.err
 }
 
+func (s *apiAddressSetter) SetCACert(caCert string) error {
+	if s.caCerts != nil {
+		s.caCerts <- caCert
+	}
+	return s.err
+}
+
 func (s *APIAddressUpdaterSuite) TestStartStop(c *gc.C) {
 	st, _ := s.OpenAPIAsNewMachine(c, state.JobHostUnits)
 	worker, err := apiaddressupdater.NewAPIAddressUpdater(apimachiner.NewState(st), &apiAddressSetter{})
@@ -108,6 +116,36 @@ func (s *APIAddressUpdaterSuite) TestAddressChange(c *gc.C) {
 	}
 }
 
+func (s *APIAddressUpdaterSuite) TestCACertChange(c *gc.C) {
+	setter := &apiAddressSetter{
+		servers: make(chan [][]network.HostPort, 1),
+		caCerts: make(chan string, 1),
+	}
+	st, _ := s.OpenAPIAsNewMachine(c, state.JobHostUnits)
+	worker, err := apiaddressupdater.NewAPIAddressUpdater(apimachiner.NewState(st), setter)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+	s.BackingState.StartSync()
+
+	// SetCACert should be called with the initial value, and again
+	// whenever the controller's certificates are rotated.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for SetCACert to be called")
+		case caCert := <-setter.caCerts:
+			c.Assert(caCert, gc.Equals, coretesting.CACert)
+		}
+		<-setter.servers
+		if i == 0 {
+			err = s.State.RotateControllerCertificates()
+			c.Assert(err, jc.ErrorIsNil)
+			s.BackingState.StartSync()
+		}
+	}
+}
+
 func (s *APIAddressUpdaterSuite) TestLXCBridgeAddressesFiltering(c *gc.C) {
 	lxcFakeNetConfig := filepath.Join(c.MkDir(), "lxc-net")
 	netConf := []byte(`
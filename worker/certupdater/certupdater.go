@@ -5,6 +5,7 @@ package certupdater
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -12,7 +13,6 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cert"
-	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/watcher/legacy"
@@ -30,7 +30,7 @@ type CertificateUpdater struct {
 	addressWatcher  AddressWatcher
 	getter          StateServingInfoGetter
 	setter          StateServingInfoSetter
-	configGetter    ModelConfigGetter
+	certsGetter     ControllerCertificatesGetter
 	hostPortsGetter APIHostPortsGetter
 	addresses       []network.Address
 }
@@ -42,10 +42,14 @@ type AddressWatcher interface {
 	Addresses() (addresses []network.Address)
 }
 
-// ModelConfigGetter is an interface that is provided to NewCertificateUpdater
-// which can be used to get environment config.
-type ModelConfigGetter interface {
-	ModelConfig() (*config.Config, error)
+// ControllerCertificatesGetter is an interface that is provided to
+// NewCertificateUpdater and NewCertificateRotator which can be used to
+// get and watch the controller's CA certificates, and to get the CA
+// private key that signs server certificates.
+type ControllerCertificatesGetter interface {
+	ControllerCertificates() (state.ControllerCertificates, error)
+	WatchControllerCertificates() state.NotifyWatcher
+	StateServingInfo() (state.StateServingInfo, error)
 }
 
 // StateServingInfoGetter is an interface that is provided to NewCertificateUpdater
@@ -68,11 +72,11 @@ type APIHostPortsGetter interface {
 // machine addresses and then generates a new controller certificate with those
 // addresses in the certificate's SAN value.
 func NewCertificateUpdater(addressWatcher AddressWatcher, getter StateServingInfoGetter,
-	configGetter ModelConfigGetter, hostPortsGetter APIHostPortsGetter, setter StateServingInfoSetter,
+	certsGetter ControllerCertificatesGetter, hostPortsGetter APIHostPortsGetter, setter StateServingInfoSetter,
 ) worker.Worker {
 	return legacy.NewNotifyWorker(&CertificateUpdater{
 		addressWatcher:  addressWatcher,
-		configGetter:    configGetter,
+		certsGetter:     certsGetter,
 		hostPortsGetter: hostPortsGetter,
 		getter:          getter,
 		setter:          setter,
@@ -118,27 +122,21 @@ func (c *CertificateUpdater) updateCertificate(addresses []network.Address, done
 	logger.Debugf("new machine addresses: %#v", addresses)
 	c.addresses = addresses
 
-	// Older Juju deployments will not have the CA cert private key
-	// available.
 	stateInfo, ok := c.getter.StateServingInfo()
 	if !ok {
 		logger.Warningf("no state serving info, cannot regenerate server certificate")
 		return nil
 	}
-	caPrivateKey := stateInfo.CAPrivateKey
+	certs, caPrivateKey, err := controllerCA(c.certsGetter)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	if caPrivateKey == "" {
+		// Older Juju deployments will not have the CA cert
+		// private key available.
 		logger.Warningf("no CA cert private key, cannot regenerate server certificate")
 		return nil
 	}
-	// Grab the env config and update a copy with ca cert private key.
-	envConfig, err := c.configGetter.ModelConfig()
-	if err != nil {
-		return errors.Annotate(err, "cannot read model config")
-	}
-	envConfig, err = envConfig.Apply(map[string]interface{}{"ca-private-key": caPrivateKey})
-	if err != nil {
-		return errors.Annotate(err, "cannot add CA private key to model config")
-	}
 
 	// For backwards compatibility, we must include "anything", "juju-apiserver"
 	// and "juju-mongodb" as hostnames as that is what clients specify
@@ -162,12 +160,10 @@ func (c *CertificateUpdater) updateCertificate(addresses []network.Address, done
 	}
 
 	// Generate a new controller certificate with the machine addresses in the SAN value.
-	newCert, newKey, err := envConfig.GenerateControllerCertAndKey(newServerAddrs)
+	stateInfo, err = newServerCertificate(stateInfo, certs, caPrivateKey, newServerAddrs, time.Now())
 	if err != nil {
-		return errors.Annotate(err, "cannot generate controller certificate")
+		return errors.Trace(err)
 	}
-	stateInfo.Cert = string(newCert)
-	stateInfo.PrivateKey = string(newKey)
 	err = c.setter(stateInfo, done)
 	if err != nil {
 		return errors.Annotate(err, "cannot write agent config")
@@ -200,3 +196,35 @@ func updateRequired(serverCert string, newAddrs []string) ([]string, bool, error
 func (c *CertificateUpdater) TearDown() error {
 	return nil
 }
+
+// controllerCA returns the controller's certificates and the private
+// key of the CA that currently signs its server certificates.
+func controllerCA(getter ControllerCertificatesGetter) (state.ControllerCertificates, string, error) {
+	certs, err := getter.ControllerCertificates()
+	if err != nil {
+		return state.ControllerCertificates{}, "", errors.Annotate(err, "cannot read controller certificates")
+	}
+	info, err := getter.StateServingInfo()
+	if err != nil {
+		return state.ControllerCertificates{}, "", errors.Annotate(err, "cannot read state serving info")
+	}
+	return certs, info.CAPrivateKey, nil
+}
+
+// newServerCertificate returns the given state serving info updated
+// with a new server certificate for the given hostnames, signed by
+// the controller's current CA, as it should be presented at the given
+// time.
+func newServerCertificate(
+	info params.StateServingInfo, certs state.ControllerCertificates,
+	caPrivateKey string, hostnames []string, now time.Time,
+) (params.StateServingInfo, error) {
+	newCert, newKey, err := cert.NewDefaultServer(certs.CACert, caPrivateKey, hostnames)
+	if err != nil {
+		return params.StateServingInfo{}, errors.Annotate(err, "cannot generate controller certificate")
+	}
+	info.Cert = certs.ServerCertChain(newCert, now)
+	info.PrivateKey = newKey
+	info.CAPrivateKey = caPrivateKey
+	return info, nil
+}
@@ -14,7 +14,6 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cert"
-	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
@@ -88,11 +87,30 @@ func (s *CertUpdaterSuite) StateServingInfo() (params.StateServingInfo, bool) {
 	return s.stateServingInfo, true
 }
 
-type mockConfigGetter struct{}
+type mockCertsGetter struct {
+	certs        state.ControllerCertificates
+	caPrivateKey string
+	changes      chan struct{}
+}
+
+func newMockCertsGetter() *mockCertsGetter {
+	return &mockCertsGetter{
+		certs:        state.ControllerCertificates{CACert: coretesting.CACert},
+		caPrivateKey: coretesting.CAKey,
+		changes:      make(chan struct{}),
+	}
+}
 
-func (g *mockConfigGetter) ModelConfig() (*config.Config, error) {
-	return config.New(config.NoDefaults, coretesting.FakeConfig())
+func (g *mockCertsGetter) ControllerCertificates() (state.ControllerCertificates, error) {
+	return g.certs, nil
+}
 
+func (g *mockCertsGetter) WatchControllerCertificates() state.NotifyWatcher {
+	return newMockNotifyWatcher(g.changes)
+}
+
+func (g *mockCertsGetter) StateServingInfo() (state.StateServingInfo, error) {
+	return state.StateServingInfo{CAPrivateKey: g.caPrivateKey}, nil
 }
 
 type mockAPIHostGetter struct{}
@@ -123,7 +141,7 @@ func (s *CertUpdaterSuite) TestStartStop(c *gc.C) {
 	}
 	changes := make(chan struct{})
 	worker := certupdater.NewCertificateUpdater(
-		&mockMachine{changes}, s, &mockConfigGetter{}, &mockAPIHostGetter{}, setter,
+		&mockMachine{changes}, s, newMockCertsGetter(), &mockAPIHostGetter{}, setter,
 	)
 	worker.Kill()
 	c.Assert(worker.Wait(), gc.IsNil)
@@ -151,7 +169,7 @@ func (s *CertUpdaterSuite) TestAddressChange(c *gc.C) {
 	}
 	changes := make(chan struct{})
 	worker := certupdater.NewCertificateUpdater(
-		&mockMachine{changes}, s, &mockConfigGetter{}, &mockAPIHostGetter{}, setter,
+		&mockMachine{changes}, s, newMockCertsGetter(), &mockAPIHostGetter{}, setter,
 	)
 	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
 	defer worker.Kill()
@@ -172,18 +190,6 @@ func (s *CertUpdaterSuite) TestAddressChange(c *gc.C) {
 		[]string{"localhost", "juju-apiserver", "juju-mongodb", "anything"})
 }
 
-type mockStateServingGetterNoCAKey struct{}
-
-func (g *mockStateServingGetterNoCAKey) StateServingInfo() (params.StateServingInfo, bool) {
-	return params.StateServingInfo{
-		Cert:       coretesting.ServerCert,
-		PrivateKey: coretesting.ServerKey,
-		StatePort:  123,
-		APIPort:    456,
-	}, true
-
-}
-
 func (s *CertUpdaterSuite) TestAddressChangeNoCAKey(c *gc.C) {
 	updated := make(chan struct{})
 	setter := func(info params.StateServingInfo, dying <-chan struct{}) error {
@@ -191,8 +197,10 @@ func (s *CertUpdaterSuite) TestAddressChangeNoCAKey(c *gc.C) {
 		return nil
 	}
 	changes := make(chan struct{})
+	certsGetter := newMockCertsGetter()
+	certsGetter.caPrivateKey = ""
 	worker := certupdater.NewCertificateUpdater(
-		&mockMachine{changes}, &mockStateServingGetterNoCAKey{}, &mockConfigGetter{}, &mockAPIHostGetter{}, setter,
+		&mockMachine{changes}, s, certsGetter, &mockAPIHostGetter{}, setter,
 	)
 	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
 	defer worker.Kill()
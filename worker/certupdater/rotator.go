@@ -0,0 +1,144 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package certupdater
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/cert"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+	"github.com/juju/juju/worker"
+)
+
+// certificateRotator regenerates a controller's server certificate
+// whenever the controller's certificates are rotated, and once any
+// transition from a previous CA has ended.
+type certificateRotator struct {
+	getter      StateServingInfoGetter
+	certsGetter ControllerCertificatesGetter
+	setter      StateServingInfoSetter
+	clock       clock.Clock
+
+	// serial records the serial of the controller certificates
+	// that the server certificate was last generated for.
+	serial int
+}
+
+// NewCertificateRotator returns a worker.Worker that watches for
+// rotations of the controller's certificates, and then generates a
+// new server certificate signed by the controller's current CA.
+func NewCertificateRotator(
+	getter StateServingInfoGetter, certsGetter ControllerCertificatesGetter,
+	setter StateServingInfoSetter, clock clock.Clock,
+) worker.Worker {
+	r := &certificateRotator{
+		getter:      getter,
+		certsGetter: certsGetter,
+		setter:      setter,
+		clock:       clock,
+		serial:      -1,
+	}
+	return worker.NewSimpleWorker(r.loop)
+}
+
+func (r *certificateRotator) loop(stop <-chan struct{}) (err error) {
+	w := r.certsGetter.WatchControllerCertificates()
+	defer func() {
+		if stopErr := w.Stop(); err == nil {
+			err = stopErr
+		}
+	}()
+	var transitionEnd <-chan time.Time
+	for {
+		select {
+		case <-stop:
+			return nil
+		case _, ok := <-w.Changes():
+			if !ok {
+				return watcher.EnsureErr(w)
+			}
+		case <-transitionEnd:
+		}
+		certs, err := r.rotate(stop)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		transitionEnd = nil
+		if now := r.clock.Now(); certs.InTransition(now) {
+			transitionEnd = r.clock.After(certs.TransitionEnd.Sub(now))
+		}
+	}
+}
+
+// rotate generates a new server certificate if the current one was
+// not generated for the controller's current certificates.
+func (r *certificateRotator) rotate(stop <-chan struct{}) (state.ControllerCertificates, error) {
+	certs, caPrivateKey, err := controllerCA(r.certsGetter)
+	if err != nil {
+		return state.ControllerCertificates{}, errors.Trace(err)
+	}
+	info, ok := r.getter.StateServingInfo()
+	if !ok {
+		logger.Warningf("no state serving info, cannot rotate server certificate")
+		return certs, nil
+	}
+	if caPrivateKey == "" {
+		logger.Warningf("no CA cert private key, cannot rotate server certificate")
+		return certs, nil
+	}
+	// The first time around, the server certificate may well have
+	// been generated for the current certificates already.
+	upToDate, err := serverCertUpToDate(info.Cert, certs, r.clock.Now())
+	if err != nil {
+		return state.ControllerCertificates{}, errors.Trace(err)
+	}
+	if upToDate && (r.serial == -1 || r.serial == certs.Serial) {
+		r.serial = certs.Serial
+		logger.Debugf("no certificate rotation required")
+		return certs, nil
+	}
+
+	srvCert, err := cert.ParseCert(info.Cert)
+	if err != nil {
+		return state.ControllerCertificates{}, errors.Annotate(err, "cannot parse existing TLS certificate")
+	}
+	hostnames := srvCert.DNSNames
+	for _, ip := range srvCert.IPAddresses {
+		hostnames = append(hostnames, ip.String())
+	}
+	info, err = newServerCertificate(info, certs, caPrivateKey, hostnames, r.clock.Now())
+	if err != nil {
+		return state.ControllerCertificates{}, errors.Trace(err)
+	}
+	if err := r.setter(info, stop); err != nil {
+		return state.ControllerCertificates{}, errors.Annotate(err, "cannot write agent config")
+	}
+	r.serial = certs.Serial
+	logger.Infof("controller certificate rotated")
+	return certs, nil
+}
+
+// serverCertUpToDate reports whether the given server certificate
+// chain is signed by the controller's current CA, and includes the
+// cross-signed CA certificate only while it is needed.
+func serverCertUpToDate(certChainPEM string, certs state.ControllerCertificates, now time.Time) (bool, error) {
+	chain, err := cert.ParseCerts(certChainPEM)
+	if err != nil {
+		return false, errors.Annotate(err, "cannot parse existing TLS certificate")
+	}
+	caCert, err := cert.ParseCert(certs.CACert)
+	if err != nil {
+		return false, errors.Annotate(err, "cannot parse CA certificate")
+	}
+	if err := chain[0].CheckSignatureFrom(caCert); err != nil {
+		// Signed by a previous CA.
+		return false, nil
+	}
+	wantChain := certs.ServerCertChain("", now) != ""
+	return wantChain == (len(chain) > 1), nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package certupdater_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cert"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/certupdater"
+)
+
+type RotatorSuite struct {
+	coretesting.BaseSuite
+	stateServingInfo params.StateServingInfo
+	certsGetter      *mockCertsGetter
+	clock            *coretesting.Clock
+	updated          chan params.StateServingInfo
+}
+
+var _ = gc.Suite(&RotatorSuite{})
+
+func (s *RotatorSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.stateServingInfo = params.StateServingInfo{
+		Cert:         coretesting.ServerCert,
+		PrivateKey:   coretesting.ServerKey,
+		CAPrivateKey: coretesting.CAKey,
+		StatePort:    123,
+		APIPort:      456,
+	}
+	s.certsGetter = newMockCertsGetter()
+	s.clock = coretesting.NewClock(time.Now())
+	s.updated = make(chan params.StateServingInfo, 1)
+}
+
+func (s *RotatorSuite) StateServingInfo() (params.StateServingInfo, bool) {
+	return s.stateServingInfo, true
+}
+
+func (s *RotatorSuite) setStateServingInfo(info params.StateServingInfo, done <-chan struct{}) error {
+	s.stateServingInfo = info
+	s.updated <- info
+	return nil
+}
+
+func (s *RotatorSuite) startRotator(c *gc.C) {
+	w := certupdater.NewCertificateRotator(s, s.certsGetter, s.setStateServingInfo, s.clock)
+	s.AddCleanup(func(c *gc.C) {
+		w.Kill()
+		c.Assert(w.Wait(), jc.ErrorIsNil)
+	})
+}
+
+func (s *RotatorSuite) notifyChange(c *gc.C) {
+	select {
+	case s.certsGetter.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out notifying change")
+	}
+}
+
+func (s *RotatorSuite) waitUpdated(c *gc.C) params.StateServingInfo {
+	select {
+	case info := <-s.updated:
+		return info
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for certificate to be updated")
+	}
+	panic("unreachable")
+}
+
+func (s *RotatorSuite) assertNotUpdated(c *gc.C) {
+	select {
+	case <-s.updated:
+		c.Fatalf("set state serving info unexpectedly called")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *RotatorSuite) TestUpToDate(c *gc.C) {
+	s.startRotator(c)
+	s.notifyChange(c)
+	s.assertNotUpdated(c)
+}
+
+func (s *RotatorSuite) TestRotateWithSameCA(c *gc.C) {
+	srvCert, srvKey, err := cert.NewServer(
+		coretesting.CACert, coretesting.CAKey, s.clock.Now().AddDate(1, 0, 0),
+		[]string{"juju-apiserver", "10.0.0.1"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stateServingInfo.Cert = srvCert
+	s.stateServingInfo.PrivateKey = srvKey
+
+	s.startRotator(c)
+	s.notifyChange(c)
+	s.assertNotUpdated(c)
+
+	s.certsGetter.certs.Serial = 1
+	s.notifyChange(c)
+	info := s.waitUpdated(c)
+	c.Assert(info.Cert, gc.Not(gc.Equals), srvCert)
+	c.Assert(info.PrivateKey, gc.Not(gc.Equals), srvKey)
+
+	// The new server certificate keeps the old one's addresses.
+	newCert, err := cert.ParseCert(info.Cert)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(newCert.CheckSignatureFrom(coretesting.CACertX509), jc.ErrorIsNil)
+	c.Assert(newCert.DNSNames, jc.DeepEquals, []string{"juju-apiserver"})
+	c.Assert(newCert.IPAddresses, gc.HasLen, 1)
+	c.Assert(newCert.IPAddresses[0].String(), gc.Equals, "10.0.0.1")
+}
+
+func (s *RotatorSuite) TestRotateWithNewCA(c *gc.C) {
+	newCACert, newCAKey, err := cert.NewCA("foo", "1", s.clock.Now().AddDate(1, 0, 0))
+	c.Assert(err, jc.ErrorIsNil)
+	crossSigned, err := cert.CrossSign(newCACert, coretesting.CACert, coretesting.CAKey)
+	c.Assert(err, jc.ErrorIsNil)
+	s.certsGetter.certs = state.ControllerCertificates{
+		CACert:            newCACert,
+		PreviousCACert:    coretesting.CACert,
+		CrossSignedCACert: crossSigned,
+		TransitionEnd:     s.clock.Now().Add(time.Hour),
+		Serial:            1,
+	}
+	s.certsGetter.caPrivateKey = newCAKey
+
+	s.startRotator(c)
+	s.notifyChange(c)
+	info := s.waitUpdated(c)
+	c.Assert(info.CAPrivateKey, gc.Equals, newCAKey)
+	chain, err := cert.ParseCerts(info.Cert)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(chain, gc.HasLen, 2)
+	// Agents which only trust the old CA can still validate the chain.
+	err = cert.Verify(info.Cert, coretesting.CACert, s.clock.Now())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Once the transition ends, the cross-signed CA is dropped.
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for transition timer")
+	}
+	s.clock.Advance(time.Hour)
+	info = s.waitUpdated(c)
+	chain, err = cert.ParseCerts(info.Cert)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(chain, gc.HasLen, 1)
+	err = cert.Verify(info.Cert, newCACert, s.clock.Now())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RotatorSuite) TestRotateNoCAKey(c *gc.C) {
+	s.certsGetter.certs.Serial = 1
+	s.certsGetter.caPrivateKey = ""
+	s.startRotator(c)
+	s.notifyChange(c)
+	s.assertNotUpdated(c)
+}
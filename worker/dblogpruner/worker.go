@@ -35,8 +35,10 @@ func NewLogPruneParams() *LogPruneParams {
 }
 
 // New returns a worker which periodically wakes up to remove old log
-// entries stored in MongoDB. This worker is intended to run just
-// once, on the MongoDB master.
+// entries stored in MongoDB. It also removes audit log entries older
+// than the controller's audit-log-max-age, which are kept independently
+// of the logs. This worker is intended to run just once, on the MongoDB
+// master.
 func New(st *state.State, params *LogPruneParams) worker.Worker {
 	w := &pruneWorker{
 		st:     st,
@@ -63,6 +65,25 @@ func (w *pruneWorker) loop(stopCh <-chan struct{}) error {
 			if err != nil {
 				return errors.Trace(err)
 			}
+			if err := w.pruneAuditLog(); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	}
 }
+
+// pruneAuditLog removes audit log entries older than the controller
+// model's audit-log-max-age.
+func (w *pruneWorker) pruneAuditLog() error {
+	controllerModel, err := w.st.ControllerModel()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cfg, err := controllerModel.Config()
+	if err != nil {
+		return errors.Annotate(err, "cannot get controller model config")
+	}
+	// TODO(fwereade): 2016-03-17 lp:1558657
+	minTime := time.Now().Add(-cfg.AuditLogMaxAge())
+	return state.PruneAuditLog(w.st, minTime)
+}
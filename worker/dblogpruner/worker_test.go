@@ -106,6 +106,44 @@ func (s *suite) TestPrunesLogsBySize(c *gc.C) {
 	c.Fatal("pruning didn't happen as expected")
 }
 
+func (s *suite) TestPrunesOldAuditLogEntries(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"audit-log-max-age": "1h",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The logs are kept for far longer than the audit log; but their
+	// retention doesn't affect it.
+	now := time.Now()
+	s.addLogs(c, now.Add(-2*time.Hour), "keep", 5)
+	s.addAuditEntry(c, now.Add(-2*time.Hour), "Deploy")
+	s.addAuditEntry(c, now, "Expose")
+	s.StartWorker(c, 999*time.Hour, int(1e9))
+
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		entries, err := s.State.AuditLog(state.AuditLogFilter{})
+		c.Assert(err, jc.ErrorIsNil)
+		if len(entries) == 1 {
+			c.Assert(entries[0].Method, gc.Equals, "Expose")
+			keepCount, err := s.logsColl.Find(bson.M{"x": "keep"}).Count()
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(keepCount, gc.Equals, 5)
+			return
+		}
+	}
+	c.Fatal("audit log pruning didn't happen as expected")
+}
+
+func (s *suite) addAuditEntry(c *gc.C, t time.Time, method string) {
+	err := s.State.AddAuditEntry(state.AuditEntry{
+		Time:   t,
+		User:   "admin@local",
+		Facade: "Service",
+		Method: method,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *suite) addLogs(c *gc.C, t0 time.Time, text string, count int) {
 	dbLogger := state.NewDbLogger(s.State, names.NewMachineTag("0"))
 	defer dbLogger.Close()
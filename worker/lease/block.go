@@ -26,21 +26,35 @@ func (b block) invoke(ch chan<- block) error {
 	}
 }
 
+// blockList holds the expiry-notification channels for a lease, and the
+// holder of the lease when they were added.
+type blockList struct {
+	holder   string
+	unblocks []chan struct{}
+}
+
 // blocks is used to keep track of expiry-notification channels for
 // each lease name.
-type blocks map[string][]chan struct{}
+type blocks map[string]blockList
 
-// add records the block's unblock channel under the block's lease name.
-func (b blocks) add(block block) {
-	b[block.leaseName] = append(b[block.leaseName], block.unblock)
+// add records the block's unblock channel under the block's lease name. The
+// holder is recorded only for the first block added under that name; the
+// manager unblocks all of them as soon as the holder changes.
+func (b blocks) add(block block, holder string) {
+	list, found := b[block.leaseName]
+	if !found {
+		list.holder = holder
+	}
+	list.unblocks = append(list.unblocks, block.unblock)
+	b[block.leaseName] = list
 }
 
 // unblock closes all channels added under the supplied name and removes
 // them from blocks.
 func (b blocks) unblock(leaseName string) {
-	unblocks := b[leaseName]
+	list := b[leaseName]
 	delete(b, leaseName)
-	for _, unblock := range unblocks {
+	for _, unblock := range list.unblocks {
 		close(unblock)
 	}
 }
@@ -0,0 +1,50 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease
+
+import (
+	"github.com/juju/errors"
+)
+
+// controlOperation identifies the change a control makes to a lease.
+type controlOperation int
+
+const (
+	pinLease controlOperation = iota
+	unpinLease
+	transferLease
+)
+
+// control is used to deliver lease-control requests to a manager's loop
+// goroutine on behalf of Pin, Unpin and Transfer.
+type control struct {
+	operation  controlOperation
+	leaseName  string
+	holderName string
+	response   chan error
+	abort      <-chan struct{}
+}
+
+// invoke sends the control on the supplied channel and waits for an error
+// response.
+func (c control) invoke(ch chan<- control) error {
+	for {
+		select {
+		case <-c.abort:
+			return errStopped
+		case ch <- c:
+			ch = nil
+		case err := <-c.response:
+			return errors.Trace(err)
+		}
+	}
+}
+
+// respond notifies the originating invoke of completion status.
+func (c control) respond(err error) {
+	select {
+	case <-c.abort:
+	case c.response <- err:
+	}
+}
@@ -21,6 +21,11 @@ var logger = loggo.GetLogger("juju.worker.lease")
 // the manager has started (and possibly finished) shutdown.
 var errStopped = errors.New("lease manager stopped")
 
+// transferDuration is the duration of the lease claimed on behalf of a
+// successor when a transferred lease expires. The successor is expected
+// to extend it in the usual way.
+const transferDuration = time.Minute
+
 // NewManager returns a new *Manager configured as supplied. The caller takes
 // responsibility for killing, and handling errors from, the returned Worker.
 func NewManager(config ManagerConfig) (*Manager, error) {
@@ -28,10 +33,11 @@ func NewManager(config ManagerConfig) (*Manager, error) {
 		return nil, errors.Trace(err)
 	}
 	manager := &Manager{
-		config: config,
-		claims: make(chan claim),
-		checks: make(chan check),
-		blocks: make(chan block),
+		config:   config,
+		claims:   make(chan claim),
+		checks:   make(chan check),
+		blocks:   make(chan block),
+		controls: make(chan control),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &manager.catacomb,
@@ -43,7 +49,8 @@ func NewManager(config ManagerConfig) (*Manager, error) {
 	return manager, nil
 }
 
-// Manager implements lease.Claimer, lease.Checker, and worker.Worker.
+// Manager implements lease.Claimer, lease.Checker, lease.Director, and
+// worker.Worker.
 type Manager struct {
 	catacomb catacomb.Catacomb
 
@@ -58,6 +65,10 @@ type Manager struct {
 
 	// blocks is used to deliver expiry block requests to the loop.
 	blocks chan block
+
+	// controls is used to deliver pin, unpin and transfer requests to
+	// the loop.
+	controls chan control
 }
 
 // Kill is part of the worker.Worker interface.
@@ -79,8 +90,9 @@ func (manager *Manager) loop() error {
 		}
 
 		leases := manager.config.Client.Leases()
-		for leaseName := range blocks {
-			if _, found := leases[leaseName]; !found {
+		for leaseName, list := range blocks {
+			info, found := leases[leaseName]
+			if !found || info.Holder != list.holder {
 				blocks.unblock(leaseName)
 			}
 		}
@@ -99,8 +111,11 @@ func (manager *Manager) choose(blocks blocks) error {
 	case check := <-manager.checks:
 		return manager.handleCheck(check)
 	case block := <-manager.blocks:
-		blocks.add(block)
+		info := manager.config.Client.Leases()[block.leaseName]
+		blocks.add(block, info.Holder)
 		return nil
+	case control := <-manager.controls:
+		return manager.handleControl(control)
 	}
 }
 
@@ -140,7 +155,7 @@ func (manager *Manager) handleClaim(claim claim) error {
 			switch {
 			case !found:
 				err = client.ClaimLease(claim.leaseName, request)
-			case info.Holder == claim.holderName:
+			case info.Holder == claim.holderName && info.Successor == "":
 				err = client.ExtendLease(claim.leaseName, request)
 			default:
 				claim.respond(false)
@@ -201,6 +216,94 @@ func (manager *Manager) WaitUntilExpired(leaseName string) error {
 	}.invoke(manager.blocks)
 }
 
+// Pin is part of the lease.Director interface.
+func (manager *Manager) Pin(leaseName string) error {
+	if err := manager.config.Secretary.CheckLease(leaseName); err != nil {
+		return errors.Annotatef(err, "cannot pin lease %q", leaseName)
+	}
+	return manager.control(pinLease, leaseName, "")
+}
+
+// Unpin is part of the lease.Director interface.
+func (manager *Manager) Unpin(leaseName string) error {
+	if err := manager.config.Secretary.CheckLease(leaseName); err != nil {
+		return errors.Annotatef(err, "cannot unpin lease %q", leaseName)
+	}
+	return manager.control(unpinLease, leaseName, "")
+}
+
+// Transfer is part of the lease.Director interface.
+func (manager *Manager) Transfer(leaseName, holderName string) error {
+	if err := manager.config.Secretary.CheckLease(leaseName); err != nil {
+		return errors.Annotatef(err, "cannot transfer lease %q", leaseName)
+	}
+	if err := manager.config.Secretary.CheckHolder(holderName); err != nil {
+		return errors.Annotatef(err, "cannot transfer lease to holder %q", holderName)
+	}
+	return manager.control(transferLease, leaseName, holderName)
+}
+
+// control delivers the described control request to the loop and waits
+// for it to be handled.
+func (manager *Manager) control(operation controlOperation, leaseName, holderName string) error {
+	return control{
+		operation:  operation,
+		leaseName:  leaseName,
+		holderName: holderName,
+		response:   make(chan error),
+		abort:      manager.catacomb.Dying(),
+	}.invoke(manager.controls)
+}
+
+// handleControl processes and responds to the supplied control. It will only
+// return unrecoverable errors; a control of a lease that isn't held, or which
+// cannot be applied to the lease's current state, is communicated back to the
+// control's originator.
+func (manager *Manager) handleControl(control control) error {
+	client := manager.config.Client
+	if _, found := client.Leases()[control.leaseName]; !found {
+		if err := client.Refresh(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	err := lease.ErrInvalid
+	for err == lease.ErrInvalid {
+		select {
+		case <-manager.catacomb.Dying():
+			return manager.catacomb.ErrDying()
+		default:
+			info, found := client.Leases()[control.leaseName]
+			if !found {
+				control.respond(lease.ErrNotHeld)
+				return nil
+			}
+			switch control.operation {
+			case pinLease:
+				err = client.PinLease(control.leaseName)
+			case unpinLease:
+				err = client.UnpinLease(control.leaseName)
+			case transferLease:
+				switch {
+				case info.Pinned:
+					control.respond(lease.ErrPinned)
+					return nil
+				case info.Holder == control.holderName:
+					control.respond(errors.Errorf("lease already held by %q", control.holderName))
+					return nil
+				}
+				err = client.TransferLease(control.leaseName, control.holderName)
+			default:
+				return errors.Errorf("unknown lease control operation %d", control.operation)
+			}
+		}
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	control.respond(nil)
+	return nil
+}
+
 // nextTick returns a channel that will send a value at some point when
 // we expect to have to do some work; either because at least one lease
 // may be ready to expire, or because enough enough time has passed that
@@ -209,7 +312,7 @@ func (manager *Manager) nextTick() <-chan time.Time {
 	now := manager.config.Clock.Now()
 	nextTick := now.Add(manager.config.MaxSleep)
 	for _, info := range manager.config.Client.Leases() {
-		if info.Expiry.After(nextTick) {
+		if info.Pinned || info.Expiry.After(nextTick) {
 			continue
 		}
 		nextTick = info.Expiry
@@ -218,7 +321,8 @@ func (manager *Manager) nextTick() <-chan time.Time {
 	return clock.Alarm(manager.config.Clock, nextTick)
 }
 
-// tick snapshots recent leases and expires any that it can. There
+// tick snapshots recent leases and expires any that it can, handing
+// transferred leases to their successors. Pinned leases are never expired. There
 // might be none that need attention; or those that do might already
 // have been extended or expired by someone else; so ErrInvalid is
 // expected, and ignored, comfortable that the client will have been
@@ -244,10 +348,18 @@ func (manager *Manager) tick() error {
 	logger.Tracef("expiring leases...")
 	now := manager.config.Clock.Now()
 	for _, name := range names {
-		if leases[name].Expiry.After(now) {
+		info := leases[name]
+		if info.Pinned || info.Expiry.After(now) {
 			continue
 		}
-		switch err := client.ExpireLease(name); err {
+		var err error
+		if info.Successor != "" {
+			logger.Debugf("transferring lease %q to %q", name, info.Successor)
+			err = client.HandOverLease(name, lease.Request{info.Successor, transferDuration})
+		} else {
+			err = client.ExpireLease(name)
+		}
+		switch err {
 		case nil, lease.ErrInvalid:
 		default:
 			return errors.Trace(err)
@@ -85,6 +85,40 @@ func (s *WaitUntilExpiredSuite) TestLeadershipChanged(c *gc.C) {
 		blockTest := newBlockTest(manager, "redis")
 		blockTest.assertBlocked(c)
 
+		// Trigger abortive expiry; the lease has changed hands, so the
+		// waiter is released to make its own claim.
+		clock.Advance(time.Second)
+		err := blockTest.assertUnblocked(c)
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *WaitUntilExpiredSuite) TestLeadershipExtended(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{
+				Holder: "redis/0",
+				Expiry: offset(time.Second),
+			},
+		},
+		expectCalls: []call{{
+			method: "Refresh",
+		}, {
+			method: "ExpireLease",
+			args:   []interface{}{"redis"},
+			err:    corelease.ErrInvalid,
+			callback: func(leases map[string]corelease.Info) {
+				leases["redis"] = corelease.Info{
+					Holder: "redis/0",
+					Expiry: offset(time.Minute),
+				}
+			},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, clock *coretesting.Clock) {
+		blockTest := newBlockTest(manager, "redis")
+		blockTest.assertBlocked(c)
+
 		// Trigger abortive expiry.
 		clock.Advance(time.Second)
 		blockTest.assertBlocked(c)
@@ -139,7 +173,7 @@ func (s *WaitUntilExpiredSuite) TestMultiple(c *gc.C) {
 			callback: func(leases map[string]corelease.Info) {
 				delete(leases, "redis")
 				leases["store"] = corelease.Info{
-					Holder: "store/9",
+					Holder: "store/0",
 					Expiry: offset(time.Minute),
 				}
 			},
@@ -160,7 +194,7 @@ func (s *WaitUntilExpiredSuite) TestMultiple(c *gc.C) {
 		storeTest2.assertBlocked(c)
 
 		// Induce attempted expiry; redis was expired already, store was
-		// refreshed and not expired.
+		// refreshed and found to have been extended.
 		clock.Advance(time.Second)
 		err := redisTest2.assertUnblocked(c)
 		c.Check(err, jc.ErrorIsNil)
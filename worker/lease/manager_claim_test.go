@@ -166,6 +166,22 @@ func (s *ClaimSuite) TestExtendLease_Failure_OtherHolder(c *gc.C) {
 	})
 }
 
+func (s *ClaimSuite) TestExtendLease_Failure_Transferring(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{
+				Holder:    "redis/0",
+				Expiry:    offset(time.Second),
+				Successor: "redis/1",
+			},
+		},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Claim("redis", "redis/0", time.Minute)
+		c.Check(err, gc.Equals, corelease.ErrClaimDenied)
+	})
+}
+
 func (s *ClaimSuite) TestExtendLease_Failure_Error(c *gc.C) {
 	fix := &Fixture{
 		leases: map[string]corelease.Info{
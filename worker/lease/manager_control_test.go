@@ -0,0 +1,147 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corelease "github.com/juju/juju/core/lease"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/lease"
+)
+
+type ControlSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ControlSuite{})
+
+func heldLeases() map[string]corelease.Info {
+	return map[string]corelease.Info{
+		"redis": corelease.Info{
+			Holder: "redis/0",
+			Expiry: offset(time.Second),
+		},
+	}
+}
+
+func (s *ControlSuite) TestPin_Success(c *gc.C) {
+	fix := &Fixture{
+		leases: heldLeases(),
+		expectCalls: []call{{
+			method: "PinLease",
+			args:   []interface{}{"redis"},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Pin("redis")
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *ControlSuite) TestPin_ErrInvalid(c *gc.C) {
+	fix := &Fixture{
+		leases: heldLeases(),
+		expectCalls: []call{{
+			method: "PinLease",
+			args:   []interface{}{"redis"},
+			err:    corelease.ErrInvalid,
+			callback: func(leases map[string]corelease.Info) {
+				leases["redis"] = corelease.Info{
+					Holder: "redis/1",
+					Expiry: offset(time.Minute),
+				}
+			},
+		}, {
+			method: "PinLease",
+			args:   []interface{}{"redis"},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Pin("redis")
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *ControlSuite) TestPin_NotHeld(c *gc.C) {
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "Refresh",
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Pin("redis")
+		c.Check(err, gc.Equals, corelease.ErrNotHeld)
+	})
+}
+
+func (s *ControlSuite) TestUnpin_Success(c *gc.C) {
+	leases := heldLeases()
+	info := leases["redis"]
+	info.Pinned = true
+	leases["redis"] = info
+	fix := &Fixture{
+		leases: leases,
+		expectCalls: []call{{
+			method: "UnpinLease",
+			args:   []interface{}{"redis"},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Unpin("redis")
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *ControlSuite) TestTransfer_Success(c *gc.C) {
+	fix := &Fixture{
+		leases: heldLeases(),
+		expectCalls: []call{{
+			method: "TransferLease",
+			args:   []interface{}{"redis", "redis/1"},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Transfer("redis", "redis/1")
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *ControlSuite) TestTransfer_Pinned(c *gc.C) {
+	leases := heldLeases()
+	info := leases["redis"]
+	info.Pinned = true
+	leases["redis"] = info
+	fix := &Fixture{
+		leases: leases,
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Transfer("redis", "redis/1")
+		c.Check(err, gc.Equals, corelease.ErrPinned)
+	})
+}
+
+func (s *ControlSuite) TestTransfer_SameHolder(c *gc.C) {
+	fix := &Fixture{
+		leases: heldLeases(),
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Transfer("redis", "redis/0")
+		c.Check(err, gc.ErrorMatches, `lease already held by "redis/0"`)
+	})
+}
+
+func (s *ControlSuite) TestTransfer_InvalidHolder(c *gc.C) {
+	fix := &Fixture{
+		leases: heldLeases(),
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *coretesting.Clock) {
+		err := manager.Transfer("redis", "INVALID")
+		c.Check(err, gc.ErrorMatches, `cannot transfer lease to holder "INVALID": name not valid`)
+	})
+}
@@ -103,6 +103,53 @@ func (s *ExpireSuite) TestStartup_NoExpiry_LongEnough(c *gc.C) {
 	})
 }
 
+func (s *ExpireSuite) TestExpire_Pinned(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{
+				Holder: "redis/0",
+				Expiry: offset(time.Second),
+				Pinned: true,
+			},
+		},
+		expectCalls: []call{{
+			method: "Refresh",
+		}},
+	}
+	fix.RunTest(c, func(_ *lease.Manager, clock *coretesting.Clock) {
+		// Pinned leases don't wake the manager; only MaxSleep does.
+		clock.Advance(time.Second)
+		clock.Advance(defaultMaxSleep)
+	})
+}
+
+func (s *ExpireSuite) TestExpire_Transferred(c *gc.C) {
+	fix := &Fixture{
+		leases: map[string]corelease.Info{
+			"redis": corelease.Info{
+				Holder:    "redis/0",
+				Expiry:    offset(time.Second),
+				Successor: "redis/1",
+			},
+		},
+		expectCalls: []call{{
+			method: "Refresh",
+		}, {
+			method: "HandOverLease",
+			args:   []interface{}{"redis", corelease.Request{"redis/1", time.Minute}},
+			callback: func(leases map[string]corelease.Info) {
+				leases["redis"] = corelease.Info{
+					Holder: "redis/1",
+					Expiry: offset(time.Minute + time.Second),
+				}
+			},
+		}},
+	}
+	fix.RunTest(c, func(_ *lease.Manager, clock *coretesting.Clock) {
+		clock.Advance(time.Second)
+	})
+}
+
 func (s *ExpireSuite) TestExpire_ErrInvalid_Expired(c *gc.C) {
 	fix := &Fixture{
 		leases: map[string]corelease.Info{
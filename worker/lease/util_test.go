@@ -135,6 +135,26 @@ func (client *Client) ExpireLease(name string) error {
 	return client.call("ExpireLease", []interface{}{name})
 }
 
+// HandOverLease is part of the corelease.Client interface.
+func (client *Client) HandOverLease(name string, request lease.Request) error {
+	return client.call("HandOverLease", []interface{}{name, request})
+}
+
+// PinLease is part of the corelease.Client interface.
+func (client *Client) PinLease(name string) error {
+	return client.call("PinLease", []interface{}{name})
+}
+
+// UnpinLease is part of the corelease.Client interface.
+func (client *Client) UnpinLease(name string) error {
+	return client.call("UnpinLease", []interface{}{name})
+}
+
+// TransferLease is part of the corelease.Client interface.
+func (client *Client) TransferLease(name, successor string) error {
+	return client.call("TransferLease", []interface{}{name, successor})
+}
+
 // Refresh is part of the lease.Client interface.
 func (client *Client) Refresh() error {
 	return client.call("Refresh", nil)
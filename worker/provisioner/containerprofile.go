@@ -0,0 +1,22 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provisioner
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/instance"
+)
+
+// validateContainerProfile returns an error if the container profile
+// requested for a container holds customisations that are not allowed.
+func validateContainerProfile(profile *instance.ContainerProfile) error {
+	if profile == nil {
+		return nil
+	}
+	if err := profile.Validate(); err != nil {
+		return errors.Annotate(err, "rejecting container profile")
+	}
+	return nil
+}
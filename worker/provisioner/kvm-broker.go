@@ -52,6 +52,9 @@ func (broker *kvmBroker) StartInstance(args environs.StartInstanceParams) (*envi
 	// TODO: refactor common code out of the container brokers.
 	machineId := args.InstanceConfig.MachineId
 	kvmLogger.Infof("starting kvm container for machineId: %s", machineId)
+	if err := validateContainerProfile(args.ContainerProfile); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	// TODO: Default to using the host network until we can configure.  Yes,
 	// this is using the LxcBridge value, we should put it in the api call for
@@ -125,7 +128,7 @@ func (broker *kvmBroker) StartInstance(args environs.StartInstanceParams) (*envi
 	storageConfig := &container.StorageConfig{
 		AllowMount: true,
 	}
-	inst, hardware, err := broker.manager.CreateContainer(args.InstanceConfig, series, network, storageConfig, args.StatusCallback)
+	inst, hardware, err := broker.manager.CreateContainer(args.InstanceConfig, series, network, storageConfig, args.ContainerProfile, args.StatusCallback)
 	if err != nil {
 		kvmLogger.Errorf("failed to start container: %v", err)
 		return nil, err
@@ -45,6 +45,9 @@ type lxdBroker struct {
 
 func (broker *lxdBroker) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
 	machineId := args.InstanceConfig.MachineId
+	if err := validateContainerProfile(args.ContainerProfile); err != nil {
+		return nil, errors.Trace(err)
+	}
 	bridgeDevice := broker.agentConfig.Value(agent.LxdBridge)
 	if bridgeDevice == "" {
 		bridgeDevice = lxdclient.DefaultLXDBridge
@@ -108,7 +111,7 @@ func (broker *lxdBroker) StartInstance(args environs.StartInstanceParams) (*envi
 	}
 
 	storageConfig := &container.StorageConfig{}
-	inst, hardware, err := broker.manager.CreateContainer(args.InstanceConfig, series, network, storageConfig, args.StatusCallback)
+	inst, hardware, err := broker.manager.CreateContainer(args.InstanceConfig, series, network, storageConfig, args.ContainerProfile, args.StatusCallback)
 	if err != nil {
 		return nil, err
 	}
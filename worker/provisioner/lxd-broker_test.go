@@ -112,6 +112,32 @@ func (s *lxdBrokerSuite) TestStartInstance(c *gc.C) {
 	c.Assert(instanceConfig.ToolsList().Arches(), jc.DeepEquals, []string{"amd64"})
 }
 
+func (s *lxdBrokerSuite) TestStartInstanceWithContainerProfile(c *gc.C) {
+	profile := &instance.ContainerProfile{
+		LXDConfig: map[string]string{"security.nesting": "true"},
+	}
+	_, err := s.broker.StartInstance(environs.StartInstanceParams{
+		Tools:            s.possibleTools,
+		InstanceConfig:   s.instanceConfig(c, "1/lxd/0"),
+		ContainerProfile: profile,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.manager.CheckCallNames(c, "CreateContainer")
+	c.Assert(s.manager.Calls()[0].Args[4], gc.Equals, profile)
+}
+
+func (s *lxdBrokerSuite) TestStartInstanceRejectsUnsafeContainerProfile(c *gc.C) {
+	_, err := s.broker.StartInstance(environs.StartInstanceParams{
+		Tools:          s.possibleTools,
+		InstanceConfig: s.instanceConfig(c, "1/lxd/0"),
+		ContainerProfile: &instance.ContainerProfile{
+			LXDConfig: map[string]string{"security.privileged": "true"},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `rejecting container profile: LXD config key "security.privileged" not valid`)
+	s.manager.CheckNoCalls(c)
+}
+
 func (s *lxdBrokerSuite) TestStartInstanceNoHostArchTools(c *gc.C) {
 	_, err := s.broker.StartInstance(environs.StartInstanceParams{
 		Tools: coretools.List{{
@@ -132,9 +158,10 @@ func (m *fakeContainerManager) CreateContainer(instanceConfig *instancecfg.Insta
 	series string,
 	network *container.NetworkConfig,
 	storage *container.StorageConfig,
+	profile *instance.ContainerProfile,
 	callback container.StatusCallback,
 ) (instance.Instance, *instance.HardwareCharacteristics, error) {
-	m.MethodCall(m, "CreateContainer", instanceConfig, series, network, storage, callback)
+	m.MethodCall(m, "CreateContainer", instanceConfig, series, network, storage, profile, callback)
 	return nil, nil, m.NextErr()
 }
 
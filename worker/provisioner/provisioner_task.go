@@ -598,6 +598,7 @@ func constructStartInstanceParams(
 		SubnetsToZones:    subnetsToZones,
 		EndpointBindings:  endpointBindings,
 		ImageMetadata:     possibleImageMetadata,
+		ContainerProfile:  provisioningInfo.ContainerProfile,
 		StatusCallback:    machine.SetInstanceStatus,
 	}, nil
 }
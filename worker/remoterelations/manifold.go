@@ -0,0 +1,85 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// defaultRetryDelay is how long the worker waits before synchronising
+// again after a failure.
+const defaultRetryDelay = 30 * time.Second
+
+// ManifoldConfig defines the names of the manifolds on which the
+// remoterelations worker depends.
+type ManifoldConfig struct {
+	StateName string
+	Clock     clock.Clock
+
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// validate is called by start to check for bad configuration.
+func (config ManifoldConfig) validate() error {
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// start is a StartFunc for a Worker manifold.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	st, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Annotate(err, "acquiring state")
+	}
+	w, err := config.NewWorker(Config{
+		Backend:    st,
+		Clock:      config.Clock,
+		RetryDelay: defaultRetryDelay,
+	})
+	if err != nil {
+		stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+
+	// When the worker is done, indicate that we no longer need the
+	// State.
+	go func() {
+		w.Wait()
+		stTracker.Done()
+	}()
+	return w, nil
+}
+
+// Manifold returns a dependency manifold that runs the remoterelations
+// worker, using the State of the controller model.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.StateName,
+		},
+		Start: config.start,
+	}
+}
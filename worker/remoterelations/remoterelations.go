@@ -0,0 +1,117 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package remoterelations provides a worker which exchanges relation
+// units and settings between models related by service offers.
+package remoterelations
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.remoterelations")
+
+// Backend defines the state functionality used by the worker.
+type Backend interface {
+	// WatchRemoteRelations returns a watcher that notifies of changes
+	// which may need to be propagated between related models.
+	WatchRemoteRelations() state.NotifyWatcher
+
+	// SyncRemoteRelations exchanges relation units and settings
+	// between related models.
+	SyncRemoteRelations() error
+}
+
+// Config defines the operation of a Worker.
+type Config struct {
+	Backend Backend
+	Clock   clock.Clock
+
+	// RetryDelay is how long to wait before synchronising again
+	// after a failure, when nothing else has changed.
+	RetryDelay time.Duration
+}
+
+// Validate returns an error if config cannot drive a Worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	return nil
+}
+
+// New returns a worker which synchronises the relations between
+// services in different models hosted by the controller whenever
+// they change.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker synchronises cross-model relations.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill implements worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	watcher := w.config.Backend.WatchRemoteRelations()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("remote relations watcher closed")
+			}
+		case <-retry:
+		}
+		// Failing to synchronise is not fatal: the failure is
+		// likely to be caused by a single model, and the others
+		// should still be kept up to date.
+		retry = nil
+		if err := w.config.Backend.SyncRemoteRelations(); err != nil {
+			logger.Errorf("cannot synchronise remote relations: %v", err)
+			retry = w.config.Clock.After(w.config.RetryDelay)
+		}
+	}
+}
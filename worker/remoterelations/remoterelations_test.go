@@ -0,0 +1,182 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"launchpad.net/tomb"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/remoterelations"
+)
+
+type RemoteRelationsSuite struct {
+	testing.BaseSuite
+	clock   *testing.Clock
+	backend *fakeBackend
+}
+
+var _ = gc.Suite(&RemoteRelationsSuite{})
+
+func (s *RemoteRelationsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Now())
+	s.backend = newFakeBackend()
+}
+
+func (s *RemoteRelationsSuite) newWorker(c *gc.C) worker.Worker {
+	w, err := remoterelations.New(remoterelations.Config{
+		Backend:    s.backend,
+		Clock:      s.clock,
+		RetryDelay: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { worker.Stop(w) })
+	return w
+}
+
+func (s *RemoteRelationsSuite) assertSync(c *gc.C) {
+	select {
+	case <-s.backend.syncCh:
+	case <-time.After(testing.LongWait):
+		c.Fatal("timed out waiting for sync")
+	}
+}
+
+func (s *RemoteRelationsSuite) assertNoSync(c *gc.C) {
+	select {
+	case <-s.backend.syncCh:
+		c.Fatal("unexpected sync")
+	case <-time.After(testing.ShortWait):
+	}
+}
+
+func (s *RemoteRelationsSuite) TestValidate(c *gc.C) {
+	_, err := remoterelations.New(remoterelations.Config{
+		Clock:      s.clock,
+		RetryDelay: time.Minute,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Backend not valid")
+	_, err = remoterelations.New(remoterelations.Config{
+		Backend:    s.backend,
+		RetryDelay: time.Minute,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Clock not valid")
+	_, err = remoterelations.New(remoterelations.Config{
+		Backend: s.backend,
+		Clock:   s.clock,
+	})
+	c.Assert(err, gc.ErrorMatches, "non-positive RetryDelay not valid")
+}
+
+func (s *RemoteRelationsSuite) TestSyncsOnChange(c *gc.C) {
+	s.newWorker(c)
+	s.backend.watcher.changes <- struct{}{}
+	s.assertSync(c)
+	s.assertNoSync(c)
+
+	s.backend.watcher.changes <- struct{}{}
+	s.assertSync(c)
+}
+
+func (s *RemoteRelationsSuite) TestRetriesAfterSyncError(c *gc.C) {
+	s.backend.errs = []error{errors.New("boom")}
+	w := s.newWorker(c)
+	s.backend.watcher.changes <- struct{}{}
+	s.assertSync(c)
+
+	// The worker keeps running, and tries again after the retry
+	// delay even if nothing changes.
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(testing.LongWait):
+		c.Fatal("timed out waiting for retry")
+	}
+	s.assertNoSync(c)
+	s.clock.Advance(time.Minute)
+	s.assertSync(c)
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	c.Assert(c.GetTestLog(), jc.Contains, "cannot synchronise remote relations: boom")
+}
+
+func (s *RemoteRelationsSuite) TestWatcherError(c *gc.C) {
+	w := s.newWorker(c)
+	s.backend.watcher.tomb.Kill(errors.New("watcher failed"))
+	c.Assert(w.Wait(), gc.ErrorMatches, "watcher failed")
+}
+
+func (s *RemoteRelationsSuite) TestStops(c *gc.C) {
+	w := s.newWorker(c)
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	select {
+	case <-s.backend.watcher.tomb.Dead():
+	case <-time.After(testing.LongWait):
+		c.Fatal("watcher not stopped")
+	}
+}
+
+func newFakeBackend() *fakeBackend {
+	w := &fakeWatcher{changes: make(chan struct{})}
+	go func() {
+		defer w.tomb.Done()
+		<-w.tomb.Dying()
+	}()
+	return &fakeBackend{
+		watcher: w,
+		syncCh:  make(chan bool, 10),
+	}
+}
+
+type fakeBackend struct {
+	watcher *fakeWatcher
+	syncCh  chan bool
+	errs    []error
+}
+
+// WatchRemoteRelations implements remoterelations.Backend.
+func (b *fakeBackend) WatchRemoteRelations() state.NotifyWatcher {
+	return b.watcher
+}
+
+// SyncRemoteRelations implements remoterelations.Backend.
+func (b *fakeBackend) SyncRemoteRelations() error {
+	b.syncCh <- true
+	var err error
+	if len(b.errs) > 0 {
+		err, b.errs = b.errs[0], b.errs[1:]
+	}
+	return err
+}
+
+type fakeWatcher struct {
+	tomb    tomb.Tomb
+	changes chan struct{}
+}
+
+func (w *fakeWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+func (w *fakeWatcher) Wait() error {
+	return w.tomb.Wait()
+}
+
+func (w *fakeWatcher) Stop() error {
+	w.Kill()
+	return w.Wait()
+}
+
+func (w *fakeWatcher) Err() error {
+	return w.tomb.Err()
+}
+
+func (w *fakeWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
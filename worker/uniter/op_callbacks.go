@@ -62,6 +62,10 @@ func (opc *operationCallbacks) CommitHook(hi hook.Info) error {
 		return opc.u.relations.CommitHook(hi)
 	case hi.Kind.IsStorage():
 		return opc.u.storage.CommitHook(hi)
+	case hi.Kind == hook.PreSeriesUpgrade:
+		return opc.u.unit.SetUpgradeSeriesStatus(params.UpgradeSeriesPrepareCompleted)
+	case hi.Kind == hook.PostSeriesUpgrade:
+		return opc.u.unit.SetUpgradeSeriesStatus(params.UpgradeSeriesCompleted)
 	}
 	return nil
 }
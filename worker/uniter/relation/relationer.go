@@ -15,9 +15,10 @@ import (
 
 // Relationer manages a unit's presence in a relation.
 type Relationer struct {
-	ru    *apiuniter.RelationUnit
-	dir   *StateDir
-	dying bool
+	ru        *apiuniter.RelationUnit
+	dir       *StateDir
+	dying     bool
+	suspended bool
 }
 
 // NewRelationer creates a new Relationer. The unit will not join the
@@ -75,6 +76,14 @@ func (r *Relationer) SetDying() error {
 	return nil
 }
 
+// SetSuspended informs the relationer whether the relation is suspended.
+// A suspended relation is broken like a dying one, but the unit remains
+// in its scope so that its settings are kept until the relation is
+// resumed.
+func (r *Relationer) SetSuspended(suspended bool) {
+	r.suspended = suspended
+}
+
 // die is run when the relationer has no further responsibilities; it leaves
 // relation scope, and removes the local relation state directory.
 func (r *Relationer) die() error {
@@ -105,6 +114,9 @@ func (r *Relationer) CommitHook(hi hook.Info) error {
 		panic("implicit relations must not run hooks")
 	}
 	if hi.Kind == hooks.RelationBroken {
+		if r.suspended && !r.dying {
+			return r.dir.Remove()
+		}
 		return r.die()
 	}
 	return r.dir.Write(hi)
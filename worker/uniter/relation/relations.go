@@ -78,6 +78,10 @@ type relations struct {
 	relationsDir string
 	relationers  map[int]*Relationer
 	abort        <-chan struct{}
+
+	// leftSuspended records the ids of suspended relations whose
+	// scopes the unit has left without running further hooks.
+	leftSuspended map[int]bool
 }
 
 // NewRelations returns a new Relations instance.
@@ -93,6 +97,8 @@ func NewRelations(st *uniter.State, tag names.UnitTag, charmDir, relationsDir st
 		relationsDir: relationsDir,
 		relationers:  make(map[int]*Relationer),
 		abort:        abort,
+
+		leftSuspended: make(map[int]bool),
 	}
 	if err := r.init(); err != nil {
 		return nil, errors.Trace(err)
@@ -133,6 +139,11 @@ func (r *relations) init() error {
 		if _, ok := knownDirs[id]; ok {
 			continue
 		}
+		if rel.Suspended() {
+			// The unit stays in the scope of suspended relations,
+			// but has already departed them.
+			continue
+		}
 		dir, err := ReadStateDir(r.relationsDir, id)
 		if err != nil {
 			return errors.Trace(err)
@@ -157,6 +168,13 @@ func (r *relations) NextHook(
 			if relationSnapshot.Life != params.Alive {
 				continue
 			}
+			if relationSnapshot.Suspended {
+				// The unit must leave the scopes of suspended
+				// relations too, so treat them as Dying.
+				relationSnapshot.Life = params.Dying
+				remoteState.Relations[relationId] = relationSnapshot
+				continue
+			}
 			relationer, ok := r.relationers[relationId]
 			if !ok {
 				continue
@@ -190,13 +208,14 @@ func (r *relations) NextHook(
 			continue
 		}
 		var remoteBroken bool
-		if remoteState.Life == params.Dying || relationSnapshot.Life == params.Dying {
+		if remoteState.Life == params.Dying || relationSnapshot.Life == params.Dying || relationSnapshot.Suspended {
 			relationSnapshot = remotestate.RelationSnapshot{}
 			remoteBroken = true
 			// TODO(axw) if relation is implicit, leave scope & remove.
 		}
-		// If either the unit or the relation are Dying,
-		// then the relation should be broken.
+		// If either the unit or the relation are Dying, or
+		// the relation is suspended, then the relation should
+		// be broken.
 		hook, err := nextRelationHook(relationer.dir.State(), relationSnapshot, remoteBroken)
 		if err == resolver.ErrNoOperation {
 			continue
@@ -365,6 +384,19 @@ func (r *relations) update(remote map[int]remotestate.RelationSnapshot) error {
 				if err := r.setDying(id); err != nil {
 					return errors.Trace(err)
 				}
+			} else {
+				r.relationers[id].SetSuspended(relationSnapshot.Suspended)
+			}
+			continue
+		}
+		if relationSnapshot.Suspended {
+			// The unit remains in the scope of a suspended relation
+			// after breaking it, and must leave once it is no longer
+			// Alive.
+			if relationSnapshot.Life != params.Alive {
+				if err := r.leaveSuspended(id); err != nil {
+					return errors.Trace(err)
+				}
 			}
 			continue
 		}
@@ -471,6 +503,30 @@ func (r *relations) add(rel *uniter.Relation, dir *StateDir) (err error) {
 	}
 }
 
+// leaveSuspended causes the unit agent to leave the scope of the
+// suspended relation with the supplied id, which it has already broken.
+func (r *relations) leaveSuspended(id int) error {
+	if r.leftSuspended[id] {
+		return nil
+	}
+	rel, err := r.st.RelationById(id)
+	if params.IsCodeNotFoundOrCodeUnauthorized(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	ru, err := rel.Unit(r.unit)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("leaving suspended relation %q", rel)
+	if err := ru.LeaveScope(); err != nil {
+		return errors.Trace(err)
+	}
+	r.leftSuspended[id] = true
+	return nil
+}
+
 // setDying notifies the relationer identified by the supplied id that the
 // only hook executions to be requested should be those necessary to cleanly
 // exit the relation.
@@ -429,6 +429,50 @@ func (s *relationsSuite) TestHookRelationBroken(c *gc.C) {
 	c.Assert(op.String(), gc.Equals, "run hook relation-broken on unit with relation 1")
 }
 
+func (s *relationsSuite) TestHookRelationSuspended(c *gc.C) {
+	var numCalls int32
+	apiCalls := relationJoinedApiCalls()
+	apiCalls = append(apiCalls, getPrincipalApiCalls(4)...)
+	r := s.assertHookRelationJoined(c, &numCalls, apiCalls...)
+	s.assertHookRelationChanged(c, r, remotestate.RelationSnapshot{
+		Life: params.Alive,
+	}, &numCalls)
+
+	localState := resolver.LocalState{
+		State: operation.State{
+			Kind: operation.Continue,
+		},
+	}
+	remoteState := remotestate.Snapshot{
+		Relations: map[int]remotestate.RelationSnapshot{
+			1: remotestate.RelationSnapshot{
+				Life:      params.Alive,
+				Suspended: true,
+				Members: map[string]int64{
+					"wordpress": 1,
+				},
+			},
+		},
+	}
+	relationsResolver := relation.NewRelationsResolver(r)
+	for _, expect := range []string{"departed", "broken"} {
+		op, err := relationsResolver.NextOp(localState, remoteState, &mockOperations{})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(op.String(), gc.Equals, "run hook relation-"+expect+" on unit with relation 1")
+		_, err = r.PrepareHook(op.(*mockOperation).hookInfo)
+		c.Assert(err, jc.ErrorIsNil)
+		err = r.CommitHook(op.(*mockOperation).hookInfo)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// The unit does not leave scope, so that its settings are kept, but
+	// runs no further hooks until the relation is resumed.
+	c.Assert(filepath.Join(s.relationsDir, "1"), jc.DoesNotExist)
+	_, err := relationsResolver.NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(errors.Cause(err), gc.Equals, resolver.ErrNoOperation)
+	assertNumCalls(c, &numCalls, 12)
+}
+
 func (s *relationsSuite) TestCommitHook(c *gc.C) {
 	var numCalls int32
 	apiCalls := relationJoinedApiCalls()
@@ -191,6 +191,8 @@ type mockUnit struct {
 	configSettingsWatcher *mockNotifyWatcher
 	storageWatcher        *mockStringsWatcher
 	actionWatcher         *mockStringsWatcher
+	upgradeSeriesWatcher  *mockNotifyWatcher
+	upgradeSeriesStatus   params.UpgradeSeriesStatus
 }
 
 func (u *mockUnit) Life() params.Life {
@@ -229,6 +231,14 @@ func (u *mockUnit) WatchStorage() (watcher.StringsWatcher, error) {
 	return u.storageWatcher, nil
 }
 
+func (u *mockUnit) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error) {
+	return u.upgradeSeriesWatcher, nil
+}
+
+func (u *mockUnit) UpgradeSeriesStatus() (params.UpgradeSeriesStatus, error) {
+	return u.upgradeSeriesStatus, nil
+}
+
 func (u *mockUnit) WatchActionNotifications() (watcher.StringsWatcher, error) {
 	return u.actionWatcher, nil
 }
@@ -277,8 +287,9 @@ func (s *mockService) WatchRelations() (watcher.StringsWatcher, error) {
 }
 
 type mockRelation struct {
-	id   int
-	life params.Life
+	id        int
+	life      params.Life
+	suspended bool
 }
 
 func (r *mockRelation) Id() int {
@@ -289,6 +300,10 @@ func (r *mockRelation) Life() params.Life {
 	return r.life
 }
 
+func (r *mockRelation) Suspended() bool {
+	return r.suspended
+}
+
 type mockLeadershipTracker struct {
 	leadership.Tracker
 	claimTicket  mockTicket
@@ -68,11 +68,17 @@ type Snapshot struct {
 	// Commands is the list of IDs of commands to be
 	// executed by this unit.
 	Commands []string
+
+	// UpgradeSeriesStatus is the progress of the unit through
+	// an in-place series upgrade of its machine. It is empty
+	// if no series upgrade is in progress.
+	UpgradeSeriesStatus params.UpgradeSeriesStatus
 }
 
 type RelationSnapshot struct {
-	Life    params.Life
-	Members map[string]int64
+	Life      params.Life
+	Suspended bool
+	Members   map[string]int64
 }
 
 // StorageSnapshot has information relating to a storage
@@ -32,6 +32,8 @@ type Unit interface {
 	WatchConfigSettings() (watcher.NotifyWatcher, error)
 	WatchStorage() (watcher.StringsWatcher, error)
 	WatchActionNotifications() (watcher.StringsWatcher, error)
+	WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error)
+	UpgradeSeriesStatus() (params.UpgradeSeriesStatus, error)
 }
 
 type Service interface {
@@ -59,6 +61,7 @@ type Service interface {
 type Relation interface {
 	Id() int
 	Life() params.Life
+	Suspended() bool
 }
 
 func NewAPIState(st *uniter.State) State {
@@ -249,6 +249,16 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	}
 	requiredEvents++
 
+	var seenUpgradeSeriesChange bool
+	upgradeSeriesw, err := w.unit.WatchUpgradeSeriesNotifications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(upgradeSeriesw); err != nil {
+		return errors.Trace(err)
+	}
+	requiredEvents++
+
 	var seenLeadershipChange bool
 	// There's no watcher for this per se; we wait on a channel
 	// returned by the leadership tracker.
@@ -357,6 +367,16 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			}
 			observedEvent(&seenActionsChange)
 
+		case _, ok := <-upgradeSeriesw.Changes():
+			logger.Debugf("got upgrade series change: ok=%t", ok)
+			if !ok {
+				return errors.New("upgrade series watcher closed")
+			}
+			if err := w.upgradeSeriesChanged(); err != nil {
+				return errors.Trace(err)
+			}
+			observedEvent(&seenUpgradeSeriesChange)
+
 		case keys, ok := <-relationsw.Changes():
 			logger.Debugf("got relations change: ok=%t", ok)
 			if !ok {
@@ -524,6 +544,19 @@ func (w *RemoteStateWatcher) leadershipChanged(isLeader bool) error {
 	return nil
 }
 
+// upgradeSeriesChanged responds to changes in the series upgrade lock
+// of the unit's machine.
+func (w *RemoteStateWatcher) upgradeSeriesChanged() error {
+	status, err := w.unit.UpgradeSeriesStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.mu.Lock()
+	w.current.UpgradeSeriesStatus = status
+	w.mu.Unlock()
+	return nil
+}
+
 // relationsChanged responds to service relation changes.
 func (w *RemoteStateWatcher) relationsChanged(keys []string) error {
 	w.mu.Lock()
@@ -545,6 +578,7 @@ func (w *RemoteStateWatcher) relationsChanged(keys []string) error {
 			if _, ok := w.relations[relationTag]; ok {
 				relationSnapshot := w.current.Relations[rel.Id()]
 				relationSnapshot.Life = rel.Life()
+				relationSnapshot.Suspended = rel.Suspended()
 				w.current.Relations[rel.Id()] = relationSnapshot
 				continue
 			}
@@ -573,8 +607,9 @@ func (w *RemoteStateWatcher) watchRelationUnits(
 	rel Relation, relationTag names.RelationTag, ruw watcher.RelationUnitsWatcher,
 ) error {
 	relationSnapshot := RelationSnapshot{
-		Life:    rel.Life(),
-		Members: make(map[string]int64),
+		Life:      rel.Life(),
+		Suspended: rel.Suspended(),
+		Members:   make(map[string]int64),
 	}
 	select {
 	case <-w.catacomb.Dying():
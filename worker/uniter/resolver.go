@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/remotestate"
 	"github.com/juju/juju/worker/uniter/resolver"
+	"github.com/juju/juju/worker/uniter/upgradeseries"
 )
 
 // ResolverConfig defines configuration for the uniter resolver.
@@ -27,6 +28,7 @@ type ResolverConfig struct {
 	Relations           resolver.Resolver
 	Storage             resolver.Resolver
 	Commands            resolver.Resolver
+	UpgradeSeries       resolver.Resolver
 }
 
 type uniterResolver struct {
@@ -80,7 +82,19 @@ func (s *uniterResolver) NextOp(
 		s.retryHookTimerStarted = false
 	}
 
-	op, err := s.config.Leadership.NextOp(localState, remoteState, opFactory)
+	op, err := s.config.UpgradeSeries.NextOp(localState, remoteState, opFactory)
+	if errors.Cause(err) != resolver.ErrNoOperation {
+		return op, err
+	}
+
+	if localState.Kind == operation.Continue && upgradeseries.BlocksHooks(remoteState) {
+		// The unit's machine is being upgraded to a new series;
+		// nothing else may run until the upgrade completes.
+		logger.Infof("series upgrade in progress; waiting for it to complete")
+		return nil, resolver.ErrNoOperation
+	}
+
+	op, err = s.config.Leadership.NextOp(localState, remoteState, opFactory)
 	if errors.Cause(err) != resolver.ErrNoOperation {
 		return op, err
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/remotestate"
 )
@@ -90,6 +91,11 @@ type LocalState struct {
 	// been committed.
 	LeaderSettingsVersion int
 
+	// UpgradeSeriesStatus is the progress of the unit through an
+	// in-place series upgrade of its machine, as last recorded by the
+	// committing of a pre- or post-series-upgrade hook.
+	UpgradeSeriesStatus params.UpgradeSeriesStatus
+
 	// CompletedActions is the set of actions that have been completed.
 	// This is used to prevent us re running actions requested by the
 	// controller.
@@ -9,6 +9,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/remotestate"
@@ -120,6 +121,14 @@ func (s *resolverOpFactory) wrapHookOp(op operation.Operation, info hook.Info) o
 		op = onCommitWrapper{op, func() {
 			s.LocalState.LeaderSettingsVersion = v
 		}}
+	case hook.PreSeriesUpgrade:
+		op = onCommitWrapper{op, func() {
+			s.LocalState.UpgradeSeriesStatus = params.UpgradeSeriesPrepareCompleted
+		}}
+	case hook.PostSeriesUpgrade:
+		op = onCommitWrapper{op, func() {
+			s.LocalState.UpgradeSeriesStatus = params.UpgradeSeriesCompleted
+		}}
 	}
 
 	charmModifiedVersion := s.RemoteState.CharmModifiedVersion
@@ -11,6 +11,7 @@ import (
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
@@ -126,6 +127,23 @@ func (s *ResolverOpFactorySuite) testLeaderSettingsChanged(
 	c.Assert(f.LocalState.UpdateStatusVersion, gc.Equals, 3)
 }
 
+func (s *ResolverOpFactorySuite) TestSeriesUpgradeHooks(c *gc.C) {
+	f := resolver.NewResolverOpFactory(s.opFactory)
+
+	op, err := f.NewRunHook(hook.Info{Kind: hook.PreSeriesUpgrade})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(f.LocalState.UpgradeSeriesStatus, gc.Equals, params.UpgradeSeriesNotStarted)
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(f.LocalState.UpgradeSeriesStatus, gc.Equals, params.UpgradeSeriesPrepareCompleted)
+
+	op, err = f.NewRunHook(hook.Info{Kind: hook.PostSeriesUpgrade})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(f.LocalState.UpgradeSeriesStatus, gc.Equals, params.UpgradeSeriesCompleted)
+}
+
 func (s *ResolverOpFactorySuite) TestUpgrade(c *gc.C) {
 	s.testUpgrade(c, resolver.ResolverOpFactory.NewUpgrade)
 	s.testUpgrade(c, resolver.ResolverOpFactory.NewRevertUpgrade)
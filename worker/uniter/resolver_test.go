@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/worker/uniter/remotestate"
 	"github.com/juju/juju/worker/uniter/resolver"
 	"github.com/juju/juju/worker/uniter/storage"
+	"github.com/juju/juju/worker/uniter/upgradeseries"
 )
 
 type resolverSuite struct {
@@ -71,6 +72,7 @@ func (s *resolverSuite) SetUpTest(c *gc.C) {
 		Relations:           relation.NewRelationsResolver(&dummyRelations{}),
 		Storage:             storage.NewResolver(attachments),
 		Commands:            nopResolver{},
+		UpgradeSeries:       upgradeseries.NewResolver(),
 	}
 
 	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
@@ -253,3 +255,37 @@ func (s *resolverSuite) TestRunHookStopRetryTimer(c *gc.C) {
 	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
 	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StopRetryHookTimer")
 }
+
+func (s *resolverSuite) TestUpgradeSeriesPrepare(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.UpgradeSeriesStatus = params.UpgradeSeriesPrepareStarted
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run pre-series-upgrade hook")
+
+	// Once the hook has been committed, nothing else runs until
+	// the series upgrade is completed.
+	localState.UpgradeSeriesStatus = params.UpgradeSeriesPrepareCompleted
+	s.remoteState.ConfigVersion++
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	s.remoteState.UpgradeSeriesStatus = params.UpgradeSeriesCompleteStarted
+	op, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run post-series-upgrade hook")
+
+	localState.UpgradeSeriesStatus = params.UpgradeSeriesCompleted
+	s.remoteState.UpgradeSeriesStatus = params.UpgradeSeriesCompleted
+	op, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run config-changed hook")
+}
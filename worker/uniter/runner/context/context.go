@@ -120,6 +120,14 @@ type HookContext struct {
 	// configSettings holds the service configuration.
 	configSettings charm.Settings
 
+	// charmState holds the key-value pairs persisted by the unit's
+	// charm. It is loaded when first used.
+	charmState map[string]string
+
+	// charmStateDirty records whether the charm state has changed,
+	// and so needs to be written when the context is flushed.
+	charmStateDirty bool
+
 	// id identifies the context.
 	id string
 
@@ -475,6 +483,75 @@ func (ctx *HookContext) ConfigSettings() (charm.Settings, error) {
 	return result, nil
 }
 
+// GetCharmState returns the key-value pairs persisted by the unit's
+// charm, including any changes made in this context.
+func (ctx *HookContext) GetCharmState() (map[string]string, error) {
+	if err := ctx.ensureCharmState(); err != nil {
+		return nil, err
+	}
+	result := make(map[string]string, len(ctx.charmState))
+	for key, value := range ctx.charmState {
+		result[key] = value
+	}
+	return result, nil
+}
+
+// maxCharmStateSize is the maximum combined size, in bytes, of the keys
+// and values in a unit's charm state. It matches the limit enforced by
+// the controller, so that charms hear about it when they set a value
+// rather than when the hook's changes are flushed.
+const maxCharmStateSize = 64 * 1024
+
+// SetCharmStateValue sets a key-value pair in the charm state. The
+// change is written when the context is flushed. It fails, leaving the
+// charm state unchanged, if the change would take the combined size of
+// the keys and values over maxCharmStateSize bytes.
+func (ctx *HookContext) SetCharmStateValue(key, value string) error {
+	if err := ctx.ensureCharmState(); err != nil {
+		return err
+	}
+	size := len(key) + len(value)
+	for k, v := range ctx.charmState {
+		if k != key {
+			size += len(k) + len(v)
+		}
+	}
+	if size > maxCharmStateSize {
+		return errors.Errorf("size %d exceeds maximum of %d bytes", size, maxCharmStateSize)
+	}
+	ctx.charmState[key] = value
+	ctx.charmStateDirty = true
+	return nil
+}
+
+// DeleteCharmStateValue removes a key from the charm state. The
+// change is written when the context is flushed.
+func (ctx *HookContext) DeleteCharmStateValue(key string) error {
+	if err := ctx.ensureCharmState(); err != nil {
+		return err
+	}
+	if _, ok := ctx.charmState[key]; ok {
+		delete(ctx.charmState, key)
+		ctx.charmStateDirty = true
+	}
+	return nil
+}
+
+func (ctx *HookContext) ensureCharmState() error {
+	if ctx.charmState != nil {
+		return nil
+	}
+	charmState, err := ctx.unit.CharmState()
+	if err != nil {
+		return errors.Annotate(err, "cannot read charm state")
+	}
+	if charmState == nil {
+		charmState = make(map[string]string)
+	}
+	ctx.charmState = charmState
+	return nil
+}
+
 // ActionName returns the name of the action.
 func (ctx *HookContext) ActionName() (string, error) {
 	if ctx.actionData == nil {
@@ -500,6 +577,16 @@ func (ctx *HookContext) SetActionMessage(message string) error {
 	return nil
 }
 
+// LogActionMessage records a progress message for the action. Unlike
+// the action's results, the message is sent to the controller
+// immediately.
+func (ctx *HookContext) LogActionMessage(message string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return ctx.state.LogActionMessage(ctx.actionData.Tag, message)
+}
+
 // SetActionFailed sets the fail state of the action.
 func (ctx *HookContext) SetActionFailed() error {
 	if ctx.actionData == nil {
@@ -701,6 +788,21 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		}
 	}
 
+	// The charm state is written in its own call, after any relation
+	// settings and port changes and before any storage is added. Each of
+	// these writes is attempted regardless of whether an earlier one
+	// failed, so the charm state may be written even when the hook's
+	// other changes were not; the first failure is reported.
+	if ctx.charmStateDirty && writeChanges {
+		if e := ctx.unit.SetCharmState(ctx.charmState); e != nil {
+			e = errors.Annotate(e, "cannot write charm state")
+			logger.Errorf("%v", e)
+			if ctxErr == nil {
+				ctxErr = e
+			}
+		}
+	}
+
 	// add storage to unit dynamically
 	if len(ctx.storageAddConstraints) > 0 && writeChanges {
 		err := ctx.unit.AddStorage(ctx.storageAddConstraints)
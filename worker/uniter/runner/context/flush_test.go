@@ -4,6 +4,8 @@
 package context_test
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -207,6 +209,73 @@ func (s *FlushContextSuite) TestRunHookAddUnitStorageOnSuccess(c *gc.C) {
 	c.Assert(all, gc.HasLen, 0)
 }
 
+func (s *FlushContextSuite) TestRunHookCharmStateFlushingError(c *gc.C) {
+	err := s.unit.SetCharmState(map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := s.context(c)
+
+	err = ctx.SetCharmStateValue("baz", "qux")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.DeleteCharmStateValue("foo")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Flush the context with a failure.
+	err = ctx.Flush("some badge", errors.New("blam pow"))
+	c.Assert(err, gc.ErrorMatches, "blam pow")
+
+	// Check that the changes have not been written to state.
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
+func (s *FlushContextSuite) TestRunHookCharmStateFlushingSuccess(c *gc.C) {
+	err := s.unit.SetCharmState(map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := s.context(c)
+
+	err = ctx.SetCharmStateValue("baz", "qux")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.DeleteCharmStateValue("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	charmState, err := ctx.GetCharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"baz": "qux"})
+
+	// Flush the context with a success.
+	err = ctx.Flush("some badge", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Check that the changes have been written to state.
+	charmState, err = s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"baz": "qux"})
+}
+
+func (s *FlushContextSuite) TestRunHookCharmStateTooLarge(c *gc.C) {
+	ctx := s.context(c)
+
+	err := ctx.SetCharmStateValue("foo", strings.Repeat("x", 64*1024))
+	c.Assert(err, gc.ErrorMatches, `size 65539 exceeds maximum of 65536 bytes`)
+	err = ctx.SetCharmStateValue("foo", strings.Repeat("x", 64*1024-4))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Replacing a value only counts the new value.
+	err = ctx.SetCharmStateValue("foo", strings.Repeat("y", 64*1024-3))
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.SetCharmStateValue("a", "")
+	c.Assert(err, gc.ErrorMatches, `size 65537 exceeds maximum of 65536 bytes`)
+
+	// The rejected value was not recorded, so the rest is written.
+	err = ctx.Flush("some badge", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{
+		"foo": strings.Repeat("y", 64*1024-3),
+	})
+}
+
 func (s *HookContextSuite) context(c *gc.C) *context.HookContext {
 	uuid, err := utils.NewUUID()
 	c.Assert(err, jc.ErrorIsNil)
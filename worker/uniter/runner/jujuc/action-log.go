@@ -0,0 +1,61 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+)
+
+// maxActionLogMessageLength is the length in bytes of the longest
+// message that can be logged by action-log.
+const maxActionLogMessageLength = 4096
+
+// ActionLogCommand implements the action-log command.
+type ActionLogCommand struct {
+	cmd.CommandBase
+	ctx     Context
+	message string
+}
+
+// NewActionLogCommand returns a new ActionLogCommand with the given context.
+func NewActionLogCommand(ctx Context) (cmd.Command, error) {
+	return &ActionLogCommand{ctx: ctx}, nil
+}
+
+// Info returns the content for --help.
+func (c *ActionLogCommand) Info() *cmd.Info {
+	doc := `
+action-log records a timestamped progress message for the running action.
+Unlike action-set, the message is sent to the controller immediately, so it
+can be watched with "juju show-action-output --watch" while the action runs.
+Messages may be at most 4096 bytes long, and only the most recent 1000
+messages are kept.
+`
+	return &cmd.Info{
+		Name:    "action-log",
+		Args:    "<message>",
+		Purpose: "record a progress message for the action",
+		Doc:     doc,
+	}
+}
+
+// Init sets the message and checks for malformed invocations.
+func (c *ActionLogCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no message specified")
+	}
+	c.message = strings.Join(args, " ")
+	if len(c.message) > maxActionLogMessageLength {
+		return errors.Errorf("message too long: %d bytes (maximum %d)", len(c.message), maxActionLogMessageLength)
+	}
+	return nil
+}
+
+// Run records the progress message for the Action.
+func (c *ActionLogCommand) Run(ctx *cmd.Context) error {
+	return c.ctx.LogActionMessage(c.message)
+}
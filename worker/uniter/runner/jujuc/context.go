@@ -48,6 +48,7 @@ type HookContext interface {
 	ContextInstance
 	ContextNetworking
 	ContextLeadership
+	ContextCharmState
 	ContextMetrics
 	ContextStorage
 	ContextComponents
@@ -94,6 +95,9 @@ type actionHookContext interface {
 	// SetActionMessage sets a message for the Action.
 	SetActionMessage(string) error
 
+	// LogActionMessage records a progress message for the Action.
+	LogActionMessage(string) error
+
 	// SetActionFailed sets a failure state for the Action.
 	SetActionFailed() error
 }
@@ -185,6 +189,23 @@ type ContextLeadership interface {
 	WriteLeaderSettings(map[string]string) error
 }
 
+// ContextCharmState is the part of a hook context related to the
+// key-value pairs that the unit's charm persists between hooks.
+type ContextCharmState interface {
+	// GetCharmState returns the unit's charm state, including any
+	// changes made in this context.
+	GetCharmState() (map[string]string, error)
+
+	// SetCharmStateValue sets a key-value pair in the unit's charm
+	// state. The change is written when the hook completes successfully.
+	// It fails if the charm state would exceed its maximum size.
+	SetCharmStateValue(key, value string) error
+
+	// DeleteCharmStateValue removes a key from the unit's charm state.
+	// The change is written when the hook completes successfully.
+	DeleteCharmStateValue(key string) error
+}
+
 // ContextMetrics is the part of a hook context related to metrics.
 type ContextMetrics interface {
 	// AddMetric records a metric to return after hook execution.
@@ -80,6 +80,17 @@ func (*RestrictedContext) LeaderSettings() (map[string]string, error) {
 // WriteLeaderSettings implements jujuc.Context.
 func (*RestrictedContext) WriteLeaderSettings(map[string]string) error { return ErrRestrictedContext }
 
+// GetCharmState implements jujuc.Context.
+func (*RestrictedContext) GetCharmState() (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// SetCharmStateValue implements jujuc.Context.
+func (*RestrictedContext) SetCharmStateValue(string, string) error { return ErrRestrictedContext }
+
+// DeleteCharmStateValue implements jujuc.Context.
+func (*RestrictedContext) DeleteCharmStateValue(string) error { return ErrRestrictedContext }
+
 // AddMetric implements jujuc.Context.
 func (*RestrictedContext) AddMetric(string, string, time.Time) error { return ErrRestrictedContext }
 
@@ -130,6 +141,9 @@ func (*RestrictedContext) UpdateActionResults(keys []string, value string) error
 // SetActionMessage implements jujuc.Context.
 func (*RestrictedContext) SetActionMessage(string) error { return ErrRestrictedContext }
 
+// LogActionMessage implements jujuc.Context.
+func (*RestrictedContext) LogActionMessage(string) error { return ErrRestrictedContext }
+
 // SetActionFailed implements jujuc.Context.
 func (*RestrictedContext) SetActionFailed() error { return ErrRestrictedContext }
 
@@ -52,6 +52,7 @@ var baseCommands = map[string]creator{
 	"action-get" + cmdSuffix:    NewActionGetCommand,
 	"action-set" + cmdSuffix:    NewActionSetCommand,
 	"action-fail" + cmdSuffix:   NewActionFailCommand,
+	"action-log" + cmdSuffix:    NewActionLogCommand,
 	"relation-ids" + cmdSuffix:  NewRelationIdsCommand,
 	"relation-list" + cmdSuffix: NewRelationListCommand,
 	"relation-set" + cmdSuffix:  NewRelationSetCommand,
@@ -75,6 +76,12 @@ var leaderCommands = map[string]creator{
 	"leader-set" + cmdSuffix: NewLeaderSetCommand,
 }
 
+var charmStateCommands = map[string]creator{
+	"state-delete" + cmdSuffix: NewStateDeleteCommand,
+	"state-get" + cmdSuffix:    NewStateGetCommand,
+	"state-set" + cmdSuffix:    NewStateSetCommand,
+}
+
 func allEnabledCommands() map[string]creator {
 	all := map[string]creator{}
 	add := func(m map[string]creator) {
@@ -85,6 +92,7 @@ func allEnabledCommands() map[string]creator {
 	add(baseCommands)
 	add(storageCommands)
 	add(leaderCommands)
+	add(charmStateCommands)
 	add(registeredCommands)
 	return all
 }
@@ -0,0 +1,62 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type StateDeleteSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&StateDeleteSuite{})
+
+func (s *StateDeleteSuite) run(c *gc.C, hctx *Context, args ...string) (int, *cmd.Context) {
+	com, err := jujuc.NewCommand(hctx, cmdString("state-delete"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, args)
+	return code, ctx
+}
+
+func (s *StateDeleteSuite) TestInitEmpty(c *gc.C) {
+	code, ctx := s.run(c, s.GetHookContext(c, -1, ""))
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: no keys specified\n")
+}
+
+func (s *StateDeleteSuite) TestInitError(c *gc.C) {
+	code, ctx := s.run(c, s.GetHookContext(c, -1, ""), "key", "x=x")
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: invalid key \"x=x\"\n")
+}
+
+func (s *StateDeleteSuite) TestDelete(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.CharmState.CharmState = map[string]string{
+		"key":   "value",
+		"other": "thing",
+		"keep":  "me",
+	}
+	code, ctx := s.run(c, hctx, "key", "other", "unknown")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.CharmState.CharmState, jc.DeepEquals, map[string]string{"keep": "me"})
+}
+
+func (s *StateDeleteSuite) TestDeleteError(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(errors.New("zap"))
+	code, ctx := s.run(c, hctx, "key")
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot delete charm state: zap\n")
+	s.Stub.CheckCallNames(c, "DeleteCharmStateValue")
+}
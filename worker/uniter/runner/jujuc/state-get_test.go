@@ -0,0 +1,91 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type StateGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&StateGetSuite{})
+
+func (s *StateGetSuite) newContext(c *gc.C) *Context {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.CharmState.CharmState = map[string]string{
+		"key":   "value",
+		"other": "thing",
+	}
+	return hctx
+}
+
+func (s *StateGetSuite) run(c *gc.C, hctx *Context, args ...string) (int, *cmd.Context) {
+	com, err := jujuc.NewCommand(hctx, cmdString("state-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, args)
+	return code, ctx
+}
+
+func (s *StateGetSuite) TestInitError(c *gc.C) {
+	code, ctx := s.run(c, s.newContext(c), "x=x")
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: invalid key \"x=x\"\n")
+}
+
+func (s *StateGetSuite) TestTooManyArgs(c *gc.C) {
+	code, ctx := s.run(c, s.newContext(c), "key", "other")
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: unrecognized args: [\"other\"]\n")
+}
+
+func (s *StateGetSuite) TestGetKey(c *gc.C) {
+	code, ctx := s.run(c, s.newContext(c), "key")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "value\n")
+}
+
+func (s *StateGetSuite) TestGetMissingKey(c *gc.C) {
+	code, ctx := s.run(c, s.newContext(c), "unknown")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+}
+
+func (s *StateGetSuite) TestGetAll(c *gc.C) {
+	for _, args := range [][]string{nil, {"-"}} {
+		code, ctx := s.run(c, s.newContext(c), args...)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stdout), jc.YAMLEquals, map[string]string{
+			"key":   "value",
+			"other": "thing",
+		})
+	}
+}
+
+func (s *StateGetSuite) TestGetAllJSON(c *gc.C) {
+	code, ctx := s.run(c, s.newContext(c), "--format", "json")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), jc.JSONEquals, map[string]string{
+		"key":   "value",
+		"other": "thing",
+	})
+}
+
+func (s *StateGetSuite) TestGetError(c *gc.C) {
+	hctx := s.newContext(c)
+	s.Stub.SetErrors(errors.New("zap"))
+	code, ctx := s.run(c, hctx)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot read charm state: zap\n")
+	s.Stub.CheckCallNames(c, "GetCharmState")
+}
@@ -0,0 +1,66 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type StateSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&StateSetSuite{})
+
+func (s *StateSetSuite) run(c *gc.C, hctx *Context, args ...string) (int, *cmd.Context) {
+	com, err := jujuc.NewCommand(hctx, cmdString("state-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := testing.Context(c)
+	code := cmd.Main(com, ctx, args)
+	return code, ctx
+}
+
+func (s *StateSetSuite) TestInitEmpty(c *gc.C) {
+	code, ctx := s.run(c, s.GetHookContext(c, -1, ""))
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: no key/value pairs specified\n")
+}
+
+func (s *StateSetSuite) TestInitError(c *gc.C) {
+	code, ctx := s.run(c, s.GetHookContext(c, -1, ""), "x")
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: expected \"key=value\", got \"x\"\n")
+}
+
+func (s *StateSetSuite) TestSet(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.CharmState.CharmState = map[string]string{"key": "value"}
+	code, ctx := s.run(c, hctx, "key=other", "new=thing", "empty=")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.CharmState.CharmState, jc.DeepEquals, map[string]string{
+		"key":   "other",
+		"new":   "thing",
+		"empty": "",
+	})
+}
+
+func (s *StateSetSuite) TestSetError(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(errors.New("zap"))
+	code, ctx := s.run(c, hctx, "key=value")
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "error: cannot write charm state: zap\n")
+	s.Stub.CheckCalls(c, []jujutesting.StubCall{{
+		FuncName: "SetCharmStateValue",
+		Args:     []interface{}{"key", "value"},
+	}})
+}
@@ -57,6 +57,19 @@ func (c *ContextActionHook) SetActionMessage(message string) error {
 	return nil
 }
 
+// LogActionMessage implements jujuc.ActionHookContext.
+func (c *ContextActionHook) LogActionMessage(message string) error {
+	c.stub.AddCall("LogActionMessage", message)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.info.ActionParams == nil {
+		return errors.Errorf("not running an action")
+	}
+	return nil
+}
+
 // SetActionFailed implements jujuc.ActionHookContext.
 func (c *ContextActionHook) SetActionFailed() error {
 	c.stub.AddCall("SetActionFailed")
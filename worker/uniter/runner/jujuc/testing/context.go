@@ -16,6 +16,7 @@ type ContextInfo struct {
 	Instance
 	NetworkInterface
 	Leadership
+	CharmState
 	Metrics
 	Storage
 	Components
@@ -55,6 +56,7 @@ type Context struct {
 	ContextInstance
 	ContextNetworking
 	ContextLeader
+	ContextCharmState
 	ContextMetrics
 	ContextStorage
 	ContextComponents
@@ -76,6 +78,8 @@ func NewContext(stub *testing.Stub, info *ContextInfo) *Context {
 	ctx.ContextNetworking.info = &info.NetworkInterface
 	ctx.ContextLeader.stub = stub
 	ctx.ContextLeader.info = &info.Leadership
+	ctx.ContextCharmState.stub = stub
+	ctx.ContextCharmState.info = &info.CharmState
 	ctx.ContextMetrics.stub = stub
 	ctx.ContextMetrics.info = &info.Metrics
 	ctx.ContextStorage.stub = stub
@@ -39,6 +39,7 @@ import (
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 	"github.com/juju/juju/worker/uniter/storage"
+	"github.com/juju/juju/worker/uniter/upgradeseries"
 	jujuos "github.com/juju/utils/os"
 )
 
@@ -291,6 +292,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			Commands: runcommands.NewCommandsResolver(
 				u.commands, watcher.CommandCompleted,
 			),
+			UpgradeSeries: upgradeseries.NewResolver(),
 		})
 
 		// We should not do anything until there has been a change
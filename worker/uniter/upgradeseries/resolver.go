@@ -0,0 +1,64 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries
+
+import (
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+var logger = loggo.GetLogger("juju.worker.uniter.upgradeseries")
+
+type upgradeSeriesResolver struct{}
+
+// NewResolver returns a new upgrade-series resolver.
+func NewResolver() resolver.Resolver {
+	return &upgradeSeriesResolver{}
+}
+
+// NextOp is defined on the Resolver interface.
+func (r *upgradeSeriesResolver) NextOp(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	// The series upgrade hooks are only run when the unit is
+	// installed and not otherwise engaged; a hook in an error
+	// state must be resolved first.
+	if !localState.Installed || localState.Kind != operation.Continue {
+		return nil, resolver.ErrNoOperation
+	}
+
+	switch remoteState.UpgradeSeriesStatus {
+	case params.UpgradeSeriesPrepareStarted:
+		if localState.UpgradeSeriesStatus != params.UpgradeSeriesPrepareCompleted {
+			logger.Infof("preparing for series upgrade")
+			return opFactory.NewRunHook(hook.Info{Kind: hook.PreSeriesUpgrade})
+		}
+	case params.UpgradeSeriesCompleteStarted:
+		if localState.UpgradeSeriesStatus != params.UpgradeSeriesCompleted {
+			logger.Infof("completing series upgrade")
+			return opFactory.NewRunHook(hook.Info{Kind: hook.PostSeriesUpgrade})
+		}
+	}
+	return nil, resolver.ErrNoOperation
+}
+
+// BlocksHooks reports whether a series upgrade of the unit's machine
+// is in progress, such that no hooks other than the series upgrade
+// hooks should be run.
+func BlocksHooks(remoteState remotestate.Snapshot) bool {
+	switch remoteState.UpgradeSeriesStatus {
+	case params.UpgradeSeriesPrepareStarted,
+		params.UpgradeSeriesPrepareCompleted,
+		params.UpgradeSeriesCompleteStarted:
+		return true
+	}
+	return false
+}
@@ -95,13 +95,18 @@ func (u *Upgrader) Stop() error {
 }
 
 // allowedTargetVersion checks if targetVersion is too different from
-// curVersion to allow a downgrade.
+// curVersion to allow a downgrade. Downgrading to the version an
+// upgrade was rolled back to is always allowed.
 func allowedTargetVersion(
 	origAgentVersion version.Number,
 	curVersion version.Number,
 	upgradeStepsRunning bool,
 	targetVersion version.Number,
+	rollback bool,
 ) bool {
+	if rollback {
+		return true
+	}
 	if upgradeStepsRunning && targetVersion == origAgentVersion {
 		return true
 	}
@@ -171,7 +176,7 @@ func (u *Upgrader) loop() error {
 			}
 		}
 
-		wantVersion, err := u.st.DesiredVersion(u.tag.String())
+		wantVersion, rollback, err := u.st.DesiredVersionRollback(u.tag.String())
 		if err != nil {
 			return err
 		}
@@ -185,6 +190,7 @@ func (u *Upgrader) loop() error {
 			jujuversion.Current,
 			!u.upgradeStepsWaiter.IsUnlocked(),
 			wantVersion,
+			rollback,
 		) {
 			// See also bug #1299802 where when upgrading from
 			// 1.16 to 1.18 there is a race condition that can
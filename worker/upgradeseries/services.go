@@ -0,0 +1,78 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names"
+	"github.com/juju/utils/series"
+	"github.com/juju/utils/shell"
+
+	"github.com/juju/juju/service"
+	"github.com/juju/juju/service/systemd"
+)
+
+const agentServicePrefix = "jujud-"
+
+// prepareAgentServices writes service definitions for every juju agent
+// installed on the host, using the init system of the given series. The
+// running services are left untouched, and the init system of the
+// series is not contacted: the new definitions take effect when the
+// machine is rebooted into the new series.
+func prepareAgentServices(dataDir, logDir, toSeries string) error {
+	fromInit, err := service.VersionInitSystem(series.HostSeries())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	toInit, err := service.VersionInitSystem(toSeries)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if fromInit == toInit {
+		logger.Debugf("series %q uses init system %q, nothing to prepare", toSeries, toInit)
+		return nil
+	}
+	if toInit != service.InitSystemSystemd {
+		return errors.NotSupportedf("preparing services for init system %q", toInit)
+	}
+
+	renderer, err := shell.NewRenderer("")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	serviceNames, err := service.ListServices()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range serviceNames {
+		if !strings.HasPrefix(name, agentServicePrefix) {
+			continue
+		}
+		tag, err := names.ParseTag(strings.TrimPrefix(name, agentServicePrefix))
+		if err != nil {
+			logger.Warningf("ignoring service %q: %v", name, err)
+			continue
+		}
+		var info service.AgentInfo
+		switch tag := tag.(type) {
+		case names.MachineTag:
+			info = service.NewMachineAgentInfo(tag.Id(), dataDir, logDir)
+		case names.UnitTag:
+			info = service.NewUnitAgentInfo(tag.Id(), dataDir, logDir)
+		default:
+			continue
+		}
+		svc, err := systemd.NewService(name, service.AgentConf(info, renderer), dataDir)
+		if err != nil {
+			return errors.Annotatef(err, "cannot create service %q", name)
+		}
+		if err := svc.WriteService(); err != nil {
+			return errors.Annotatef(err, "cannot write service %q", name)
+		}
+		logger.Infof("wrote %s service %q", toInit, name)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package upgradeseries implements the machine agent side of an
+// in-place series upgrade: it prepares the agent services on the
+// machine for the target series, and reports its progress back to
+// the controller.
+package upgradeseries
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.upgradeseries")
+
+// Facade exposes the controller functionality used by the worker.
+type Facade interface {
+	WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error)
+	UpgradeSeriesStatus() (params.UpgradeSeriesStatus, string, error)
+	SetUpgradeSeriesStatus(params.UpgradeSeriesStatus) error
+}
+
+// Config defines the parameters of the upgradeseries worker.
+type Config struct {
+	Facade Facade
+
+	// PrepareServices writes the service definitions of the agents
+	// on the machine for the init system used by the given series.
+	PrepareServices func(series string) error
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.PrepareServices == nil {
+		return errors.NotValidf("nil PrepareServices")
+	}
+	return nil
+}
+
+// New returns a worker that drives the machine agent through the
+// series upgrade of its machine.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: &handler{config: config},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// handler implements watcher.NotifyHandler.
+type handler struct {
+	config Config
+}
+
+// SetUp is part of the watcher.NotifyHandler interface.
+func (h *handler) SetUp() (watcher.NotifyWatcher, error) {
+	return h.config.Facade.WatchUpgradeSeriesNotifications()
+}
+
+// Handle is part of the watcher.NotifyHandler interface.
+func (h *handler) Handle(_ <-chan struct{}) error {
+	status, series, err := h.config.Facade.UpgradeSeriesStatus()
+	if err != nil {
+		return errors.Annotate(err, "cannot get series upgrade status")
+	}
+	switch status {
+	case params.UpgradeSeriesPrepareStarted:
+		logger.Infof("preparing agent services for series %q", series)
+		if err := h.config.PrepareServices(series); err != nil {
+			return errors.Annotatef(err, "cannot prepare agent services for series %q", series)
+		}
+		return h.setStatus(params.UpgradeSeriesPrepareCompleted)
+	case params.UpgradeSeriesCompleteStarted:
+		logger.Infof("completing series upgrade to %q", series)
+		return h.setStatus(params.UpgradeSeriesCompleted)
+	}
+	return nil
+}
+
+func (h *handler) setStatus(status params.UpgradeSeriesStatus) error {
+	if err := h.config.Facade.SetUpgradeSeriesStatus(status); err != nil {
+		return errors.Annotatef(err, "cannot set series upgrade status to %q", status)
+	}
+	return nil
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (h *handler) TearDown() error {
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgradeseries_test
+
+import (
+	"time"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/upgradeseries"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type Suite struct {
+	jujutesting.IsolationSuite
+
+	stub   *jujutesting.Stub
+	facade *stubFacade
+	config upgradeseries.Config
+}
+
+var _ = gc.Suite(&Suite{})
+
+func (s *Suite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub = new(jujutesting.Stub)
+	s.facade = &stubFacade{
+		stub:   s.stub,
+		series: "xenial",
+		set:    make(chan params.UpgradeSeriesStatus, 1),
+	}
+	s.config = upgradeseries.Config{
+		Facade: s.facade,
+		PrepareServices: func(series string) error {
+			s.stub.AddCall("PrepareServices", series)
+			return s.stub.NextErr()
+		},
+	}
+}
+
+func (s *Suite) TestInvalidConfig(c *gc.C) {
+	s.config.PrepareServices = nil
+	_, err := upgradeseries.New(s.config)
+	c.Check(err, gc.ErrorMatches, "nil PrepareServices not valid")
+	c.Check(s.stub.Calls(), gc.HasLen, 0)
+}
+
+func (s *Suite) TestNotUpgrading(c *gc.C) {
+	w, err := upgradeseries.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.assertNoStatusSet(c)
+	s.stub.CheckCallNames(c, "WatchUpgradeSeriesNotifications", "UpgradeSeriesStatus")
+}
+
+func (s *Suite) TestPrepare(c *gc.C) {
+	s.facade.status = params.UpgradeSeriesPrepareStarted
+	w, err := upgradeseries.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.assertStatusSet(c, params.UpgradeSeriesPrepareCompleted)
+	s.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"WatchUpgradeSeriesNotifications", nil},
+		{"UpgradeSeriesStatus", nil},
+		{"PrepareServices", []interface{}{"xenial"}},
+		{"SetUpgradeSeriesStatus", []interface{}{params.UpgradeSeriesPrepareCompleted}},
+	})
+}
+
+func (s *Suite) TestComplete(c *gc.C) {
+	s.facade.status = params.UpgradeSeriesCompleteStarted
+	w, err := upgradeseries.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.facade.assertStatusSet(c, params.UpgradeSeriesCompleted)
+	s.stub.CheckCallNames(c,
+		"WatchUpgradeSeriesNotifications",
+		"UpgradeSeriesStatus",
+		"SetUpgradeSeriesStatus",
+	)
+}
+
+type stubFacade struct {
+	stub   *jujutesting.Stub
+	status params.UpgradeSeriesStatus
+	series string
+	set    chan params.UpgradeSeriesStatus
+}
+
+func (f *stubFacade) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error) {
+	f.stub.AddCall("WatchUpgradeSeriesNotifications")
+	if err := f.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return notAWatcher{workertest.NewFakeWatcher(1, 1)}, nil
+}
+
+func (f *stubFacade) UpgradeSeriesStatus() (params.UpgradeSeriesStatus, string, error) {
+	f.stub.AddCall("UpgradeSeriesStatus")
+	return f.status, f.series, f.stub.NextErr()
+}
+
+func (f *stubFacade) SetUpgradeSeriesStatus(status params.UpgradeSeriesStatus) error {
+	f.stub.AddCall("SetUpgradeSeriesStatus", status)
+	f.set <- status
+	return f.stub.NextErr()
+}
+
+func (f *stubFacade) assertStatusSet(c *gc.C, expect params.UpgradeSeriesStatus) {
+	select {
+	case status := <-f.set:
+		c.Assert(status, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for status to be set")
+	}
+}
+
+func (f *stubFacade) assertNoStatusSet(c *gc.C) {
+	select {
+	case status := <-f.set:
+		c.Fatalf("unexpected status %q set", status)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type notAWatcher struct {
+	workertest.NotAWatcher
+}
+
+func (w notAWatcher) Changes() watcher.NotifyChannel {
+	return w.NotAWatcher.Changes()
+}
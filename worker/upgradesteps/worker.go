@@ -18,6 +18,7 @@ import (
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/upgrades"
@@ -32,6 +33,12 @@ var logger = loggo.GetLogger("juju.worker.upgradesteps")
 
 var (
 	PerformUpgrade = upgrades.PerformUpgrade // Allow patching
+	RevertUpgrade  = upgrades.RevertUpgrade  // Allow patching
+
+	// CreateUpgradeSnapshot takes a backup of the controller before
+	// any upgrade steps are run, returning its id. It may be patched
+	// for testing.
+	CreateUpgradeSnapshot = createUpgradeSnapshot
 
 	// The maximum time a master controller will wait for other
 	// controllers to come up and indicate they are ready to begin
@@ -241,6 +248,8 @@ func (w *upgradesteps) runUpgrades() error {
 		return errors.New("wrench")
 	}
 
+	w.snapshotState(upgradeInfo)
+
 	if err := w.agent.ChangeConfig(w.runUpgradeSteps); err != nil {
 		return err
 	}
@@ -295,6 +304,61 @@ func (w *upgradesteps) prepareForUpgrade() (*state.UpgradeInfo, error) {
 	return info, nil
 }
 
+// snapshotState takes a backup of the controller before the master
+// runs any upgrade steps, so that the changes they make to state can
+// be undone if the upgrade is rolled back. The snapshot is recorded
+// against the upgrade, and is only taken once per upgrade.
+//
+// The snapshot is a convenience, so failing to take or record it is
+// logged but does not stop the upgrade; a controller that is short of
+// disk space, say, can still be upgraded.
+func (w *upgradesteps) snapshotState(info *state.UpgradeInfo) {
+	if !w.isMaster || info.SnapshotID() != "" {
+		return
+	}
+	logger.Infof("taking snapshot of controller before upgrade to %v", w.toVersion)
+	id, err := CreateUpgradeSnapshot(w.st, w.agent.CurrentConfig())
+	if err != nil {
+		logger.Errorf("cannot snapshot controller before upgrade, continuing without one: %v", err)
+		return
+	}
+	if err := info.SetSnapshotID(id); err != nil {
+		logger.Errorf("cannot record controller snapshot %q, continuing without one: %v", id, err)
+		return
+	}
+	logger.Infof("controller snapshot %q taken before upgrade", id)
+}
+
+func createUpgradeSnapshot(st *state.State, agentConfig agent.Config) (string, error) {
+	stor := backups.NewStorage(st)
+	defer stor.Close()
+
+	session := st.MongoSession().Copy()
+	defer session.Close()
+
+	dbInfo, err := backups.NewDBInfo(st.MongoConnectionInfo(), session)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	machineTag, ok := agentConfig.Tag().(names.MachineTag)
+	if !ok {
+		return "", errors.New("agent's tag is not a MachineTag")
+	}
+	meta, err := backups.NewMetadataState(st, machineTag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	meta.Notes = fmt.Sprintf("taken before upgrade to %v", jujuversion.Current)
+	paths := &backups.Paths{
+		DataDir: agentConfig.DataDir(),
+		LogsDir: agentConfig.LogDir(),
+	}
+	if err := backups.NewBackups(stor).Create(meta, paths, dbInfo); err != nil {
+		return "", errors.Trace(err)
+	}
+	return meta.ID(), nil
+}
+
 func (w *upgradesteps) waitForOtherControllers(info *state.UpgradeInfo) error {
 	watcher := info.Watch()
 	defer watcher.Stop()
@@ -364,6 +428,11 @@ func (w *upgradesteps) runUpgradeSteps(agentConfig agent.ConfigSetter) error {
 		}
 	}
 	if upgradeErr != nil {
+		// Undo what we can of the steps that did run, so that the
+		// agent can be rolled back to its previous tools.
+		if err := RevertUpgrade(w.fromVersion, targets, context); err != nil {
+			logger.Errorf("cannot revert upgrade steps: %v", err)
+		}
 		return upgradeErr
 	}
 	agentConfig.SetUpgradedToVersion(w.toVersion)
@@ -45,6 +45,8 @@ type UpgradeSuite struct {
 	connectionDead  bool
 	machineIsMaster bool
 	preUpgradeError bool
+	snapshots       int
+	reverts         int
 }
 
 var _ = gc.Suite(&UpgradeSuite{})
@@ -85,6 +87,16 @@ func (s *UpgradeSuite) SetUpTest(c *gc.C) {
 	}
 	s.PatchValue(&IsMachineMaster, fakeIsMachineMaster)
 
+	s.snapshots = 0
+	s.PatchValue(&CreateUpgradeSnapshot, func(*state.State, agent.Config) (string, error) {
+		s.snapshots++
+		return "snapshot-id", nil
+	})
+	s.reverts = 0
+	s.PatchValue(&RevertUpgrade, func(version.Number, []upgrades.Target, upgrades.Context) error {
+		s.reverts++
+		return nil
+	})
 }
 
 func (s *UpgradeSuite) captureLogs(c *gc.C) {
@@ -172,6 +184,7 @@ func (s *UpgradeSuite) TestUpgradeStepsFailure(c *gc.C) {
 	c.Check(workerErr, gc.IsNil)
 
 	c.Check(*attemptsP, gc.Equals, maxUpgradeRetries)
+	c.Check(s.reverts, gc.Equals, 1)
 	c.Check(config.Version, gc.Equals, s.oldVersion.Number) // Upgrade didn't finish
 	c.Assert(statusCalls, jc.DeepEquals,
 		s.makeExpectedStatusCalls(maxUpgradeRetries-1, fails, "boom"))
@@ -202,6 +215,7 @@ func (s *UpgradeSuite) TestUpgradeStepsRetries(c *gc.C) {
 
 	c.Check(workerErr, gc.IsNil)
 	c.Check(attempts, gc.Equals, 2)
+	c.Check(s.reverts, gc.Equals, 0)
 	c.Check(config.Version, gc.Equals, jujuversion.Current) // Upgrade finished
 	c.Assert(statusCalls, jc.DeepEquals, s.makeExpectedStatusCalls(1, succeeds, "boom"))
 	c.Assert(s.logWriter.Log(), jc.LogMatches, s.makeExpectedUpgradeLogs(1, "hostMachine", succeeds, "boom"))
@@ -303,6 +317,20 @@ func (s *UpgradeSuite) TestSuccessMaster(c *gc.C) {
 	s.machineIsMaster = true
 	info := s.checkSuccess(c, "databaseMaster", func(*state.UpgradeInfo) {})
 	c.Assert(info.Status(), gc.Equals, state.UpgradeFinishing)
+	c.Assert(s.snapshots, gc.Equals, 1)
+	c.Assert(info.SnapshotID(), gc.Equals, "snapshot-id")
+}
+
+func (s *UpgradeSuite) TestSnapshotFailureDoesNotFailUpgrade(c *gc.C) {
+	s.machineIsMaster = true
+	s.PatchValue(&CreateUpgradeSnapshot, func(*state.State, agent.Config) (string, error) {
+		s.snapshots++
+		return "", errors.New("disk full")
+	})
+	info := s.checkSuccess(c, "databaseMaster", func(*state.UpgradeInfo) {})
+	c.Assert(info.Status(), gc.Equals, state.UpgradeFinishing)
+	c.Assert(s.snapshots, gc.Equals, 1)
+	c.Assert(info.SnapshotID(), gc.Equals, "")
 }
 
 func (s *UpgradeSuite) TestSuccessSecondary(c *gc.C) {
@@ -316,7 +344,9 @@ func (s *UpgradeSuite) TestSuccessSecondary(c *gc.C) {
 		err = info.SetStatus(state.UpgradeFinishing)
 		c.Assert(err, jc.ErrorIsNil)
 	}
-	s.checkSuccess(c, "controller", mungeInfo)
+	info := s.checkSuccess(c, "controller", mungeInfo)
+	c.Assert(s.snapshots, gc.Equals, 0)
+	c.Assert(info.SnapshotID(), gc.Equals, "")
 }
 
 func (s *UpgradeSuite) checkSuccess(c *gc.C, target string, mungeInfo func(*state.UpgradeInfo)) *state.UpgradeInfo {